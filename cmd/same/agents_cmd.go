@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func agentsCmd() *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "agents",
+		Short: "Show how many notes each agent wrote",
+		Long: `Count notes, decisions, and handoffs per agent attribution, for vaults
+that several agents (Claude, Codex, ...) write to.
+
+Attribution comes from the "agent" frontmatter field, which MCP writes set
+from their agent parameter. Notes without it are counted as unattributed.
+
+Examples:
+  same agents
+  same agents --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAgents(jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func runAgents(jsonOut bool) error {
+	db, err := store.Open()
+	if err != nil {
+		return config.ErrNoDatabase
+	}
+	defer db.Close()
+
+	agents, err := db.AgentCounts()
+	if err != nil {
+		return fmt.Errorf("count agents: %w", err)
+	}
+
+	if jsonOut {
+		data, _ := json.MarshalIndent(agents, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	attributed := 0
+	width := len("(unattributed)")
+	for _, a := range agents {
+		if a.Agent != "" {
+			attributed++
+			width = max(width, len(a.Agent))
+		}
+	}
+	if attributed == 0 {
+		fmt.Println("\n  No notes have an agent attribution yet.")
+		fmt.Printf("  %sAgents that save notes over MCP record themselves in the \"agent\" frontmatter field.%s\n\n", cli.Dim, cli.Reset)
+		return nil
+	}
+
+	fmt.Printf("\n  %s%-*s  %6s  %9s  %8s  %s%s\n", cli.Bold, width, "Agent", "Notes", "Decisions", "Handoffs", "Last write", cli.Reset)
+	for _, a := range agents {
+		name := a.Agent
+		if name == "" {
+			name = "(unattributed)"
+		}
+		last := "-"
+		if a.LastModified > 0 {
+			last = formatDuration(time.Since(time.Unix(int64(a.LastModified), 0))) + " ago"
+		}
+		fmt.Printf("  %-*s  %6d  %9d  %8d  %s\n", width, name, a.Notes, a.Decisions, a.Handoffs, last)
+	}
+	fmt.Printf("\n  %sAgents can filter to one author with search_notes_filtered's agent argument.%s\n\n", cli.Dim, cli.Reset)
+	return nil
+}
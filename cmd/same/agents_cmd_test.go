@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func TestRunAgents(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	_ = db.Close()
+
+	out := captureCommandStdout(t, func() {
+		if err := runAgents(false); err != nil {
+			t.Fatalf("runAgents: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No notes have an agent attribution") {
+		t.Fatalf("expected empty message, got:\n%s", out)
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatalf("store.Open: %v", err)
+	}
+	for _, n := range []struct{ path, agent, contentType string }{
+		{"decisions/auth.md", "codex", "decision"},
+		{"sessions/today.md", "codex", "handoff"},
+		{"notes/misc.md", "", "note"},
+	} {
+		rec := store.NoteRecord{Path: n.path, Title: n.path, Tags: "[]", Agent: n.agent, ChunkHeading: "(full)",
+			Text: "text", Modified: 1700000000, ContentHash: n.path, ContentType: n.contentType}
+		if _, err := db.BulkInsertNotesLite([]store.NoteRecord{rec}); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+	_ = db.Close()
+
+	out = captureCommandStdout(t, func() {
+		if err := runAgents(false); err != nil {
+			t.Fatalf("runAgents: %v", err)
+		}
+	})
+	for _, want := range []string{"codex", "(unattributed)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	out = captureCommandStdout(t, func() {
+		if err := runAgents(true); err != nil {
+			t.Fatalf("runAgents --json: %v", err)
+		}
+	})
+	var got []store.AgentStats
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("parse JSON: %v\n%s", err, out)
+	}
+	if len(got) != 2 || got[0].Agent != "codex" || got[0].Decisions != 1 || got[0].Handoffs != 1 {
+		t.Errorf("unexpected JSON: %+v", got)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func archiveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archive [path]",
+		Short: "Stop surfacing a note without deleting it",
+		Long: `Archive a note you want to keep but no longer want surfaced.
+
+Archived notes stay on disk and in the index. Context surfacing and search
+skip them; 'same search --include-archived' still finds them. Archiving
+survives reindexing and is undone with 'same unarchive'.
+
+  same archive path/to/note.md     Archive a note
+  same archive                     List archived notes
+  same unarchive path/to/note.md   Surface the note again`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return runArchiveList()
+			}
+			return runArchive(args[0])
+		},
+	}
+	return cmd
+}
+
+func unarchiveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unarchive [path]",
+		Short: "Surface an archived note again",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUnarchive(args[0])
+		},
+	}
+}
+
+func runArchive(path string) error {
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	notes, err := db.GetNoteByPath(path)
+	if err != nil || len(notes) == 0 {
+		return userError(fmt.Sprintf("Note not found in index: %s", path), "Use the path relative to your vault root, as shown by 'same search'")
+	}
+	if _, err := db.ArchiveNote(path); err != nil {
+		return fmt.Errorf("archive note: %w", err)
+	}
+	fmt.Printf("  %s✓%s Archived: %s\n", cli.Green, cli.Reset, notes[0].Title)
+	fmt.Printf("    %sNo longer surfaced. Undo with: same unarchive %s%s\n", cli.Dim, path, cli.Reset)
+	return nil
+}
+
+func runUnarchive(path string) error {
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	ok, err := db.UnarchiveNote(path)
+	if err != nil {
+		return fmt.Errorf("unarchive note: %w", err)
+	}
+	if !ok {
+		return userError(fmt.Sprintf("Note is not archived: %s", path), "Run 'same archive' to list archived notes")
+	}
+	fmt.Printf("  %s✓%s Unarchived: %s\n", cli.Green, cli.Reset, path)
+	return nil
+}
+
+func runArchiveList() error {
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	paths, err := db.ArchivedPaths()
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fmt.Println("  No archived notes.")
+		return nil
+	}
+	fmt.Printf("\n  %sArchived notes%s (%d)\n\n", cli.Bold, cli.Reset, len(paths))
+	for _, p := range paths {
+		fmt.Printf("    %s\n", p)
+	}
+	fmt.Println()
+	return nil
+}
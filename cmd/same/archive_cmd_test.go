@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunArchive_ListAndUnarchive(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/sprint-12.md", "Sprint 12", "Sprint goals.")
+
+	if err := runArchive("notes/missing.md"); err == nil {
+		t.Error("expected an error archiving a note that is not indexed")
+	}
+	out := captureCommandStdout(t, func() {
+		if err := runArchive("notes/sprint-12.md"); err != nil {
+			t.Fatalf("runArchive: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Archived: Sprint 12") {
+		t.Errorf("unexpected archive output: %q", out)
+	}
+
+	out = captureCommandStdout(t, func() {
+		if err := runArchiveList(); err != nil {
+			t.Fatalf("runArchiveList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "notes/sprint-12.md") {
+		t.Errorf("archived note missing from list: %q", out)
+	}
+
+	captureCommandStdout(t, func() {
+		if err := runUnarchive("notes/sprint-12.md"); err != nil {
+			t.Fatalf("runUnarchive: %v", err)
+		}
+	})
+	if err := runUnarchive("notes/sprint-12.md"); err == nil {
+		t.Error("expected an error unarchiving a note that is not archived")
+	}
+}
@@ -1,20 +1,44 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/sgx-labs/statelessagent/internal/cli"
 	"github.com/sgx-labs/statelessagent/internal/config"
 	"github.com/sgx-labs/statelessagent/internal/llm"
+	"github.com/sgx-labs/statelessagent/internal/memory"
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
+// defaultAskTimeout bounds answer generation; large --top-k contexts on
+// slow local models can otherwise run for many minutes.
+const defaultAskTimeout = 3 * time.Minute
+
+type askOptions struct {
+	Model   string
+	TopK    int
+	Timeout time.Duration
+	Session string // conversation to continue; "new" starts one
+
+	// CiteLines labels each source with the line range of the chunk used.
+	CiteLines bool
+}
+
 func askCmd() *cobra.Command {
-	var model string
-	var topK int
+	opts := askOptions{TopK: 5, Timeout: defaultAskTimeout}
 	cmd := &cobra.Command{
 		Use:   "ask [question]",
 		Short: "Ask a question and get answers from your notes",
@@ -26,25 +50,55 @@ Examples:
   same ask "what did we decide about authentication?"
   same ask "how does the deployment process work?"
   same ask "what are our coding standards?" --model mistral
+  same ask "summarize the roadmap" --top-k 20 --timeout 5m
+  same ask "what did we decide about auth?" --session new
+  same ask "why not sessions?" --session 20260101-120000
+  same ask "where is the retry policy defined?" --cite-lines
+
+With --session, earlier questions and answers in the session are included
+in the prompt so follow-ups keep their context. "--session new" starts a
+fresh session and prints its ID.
+
+With --cite-lines, each source is labelled path:start-end with the lines of
+the note the answer drew on, and the model is asked to cite those ranges.
+
+The answer streams in as it is generated. Press Ctrl+C to stop it.
 
 Provider Configuration:
-  Provider routing follows SAME_CHAT_PROVIDER (or auto mode), with
-  SAME_EMBED_PROVIDER as the default hint. Queue fallback providers with
-  SAME_CHAT_FALLBACKS. SAME will auto-detect the best available chat model.`,
+  Set [ask] provider (ollama, openai, or openai-compatible) with base_url,
+  api_key, and model in config.toml to answer with a specific chat server,
+  for example llama.cpp, LM Studio, or OpenRouter. Otherwise routing follows
+  SAME_CHAT_PROVIDER (or auto mode), with SAME_EMBED_PROVIDER as the default
+  hint. SAME_CHAT_* environment variables override [ask]. Queue fallback
+  providers with SAME_CHAT_FALLBACKS. SAME will auto-detect the best
+  available chat model.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAsk(args[0], model, topK)
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runAsk(ctx, args[0], opts)
 		},
 	}
-	cmd.Flags().StringVar(&model, "model", "", "Chat model to use (auto-detected if empty)")
-	cmd.Flags().IntVar(&topK, "top-k", 5, "Number of notes to use as context")
+	cmd.Flags().StringVar(&opts.Model, "model", "", "Chat model to use (auto-detected if empty)")
+	cmd.Flags().IntVar(&opts.TopK, "top-k", opts.TopK, "Number of notes to use as context")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", opts.Timeout, "Give up on the answer after this long (0 = no limit)")
+	cmd.Flags().StringVar(&opts.Session, "session", "", "Continue a conversation by ID, or \"new\" to start one")
+	cmd.Flags().BoolVar(&opts.CiteLines, "cite-lines", false, "Cite sources as path:start-end line ranges")
 	return cmd
 }
 
-func runAsk(question, model string, topK int) error {
+func runAsk(ctx context.Context, question string, opts askOptions) error {
+	model, topK := opts.Model, opts.TopK
 	if strings.TrimSpace(question) == "" {
 		return userError("Empty question", "Ask something: same ask \"what did we decide about auth?\"")
 	}
+	var session *askSession
+	if opts.Session != "" {
+		var err error
+		if session, err = loadAskSession(opts.Session); err != nil {
+			return err
+		}
+	}
 	// 1. Open database
 	db, err := store.Open()
 	if err != nil {
@@ -61,7 +115,7 @@ func runAsk(question, model string, topK int) error {
 		if err != nil {
 			// Embeddings unavailable — try FTS5, then LIKE-based keyword
 			if db.FTSAvailable() {
-				results, _ = db.FTS5Search(question, store.SearchOptions{TopK: topK})
+				results, _ = db.FTS5Search(question, store.SearchOptions{TopK: topK, Synonyms: store.ConfiguredSynonyms()})
 			}
 			if results == nil {
 				terms := store.ExtractSearchTerms(question)
@@ -88,7 +142,7 @@ func runAsk(question, model string, topK int) error {
 	} else {
 		// No vectors — try FTS5, then LIKE-based keyword
 		if db.FTSAvailable() {
-			results, err = db.FTS5Search(question, store.SearchOptions{TopK: topK})
+			results, err = db.FTS5Search(question, store.SearchOptions{TopK: topK, Synonyms: store.ConfiguredSynonyms()})
 			if err != nil {
 				return fmt.Errorf("search: %w", err)
 			}
@@ -110,13 +164,23 @@ func runAsk(question, model string, topK int) error {
 	}
 
 	// 3. Connect to configured chat provider
-	chat, err := llm.NewClient()
+	ac := config.AskProviderConfig()
+	chat, err := llm.NewClientWithOptions(llm.Options{
+		Provider: ac.Provider,
+		Model:    ac.Model,
+		BaseURL:  ac.BaseURL,
+		APIKey:   ac.APIKey,
+		Timeout:  ac.RequestTimeout(),
+	})
 	if err != nil {
 		return userError(
 			"No chat provider available",
-			"Set SAME_CHAT_PROVIDER (ollama/openai/openai-compatible) or configure SAME_EMBED_PROVIDER for auto routing.",
+			"Set [ask] provider (same config set ask.provider openai-compatible) or SAME_CHAT_PROVIDER, or configure SAME_EMBED_PROVIDER for auto routing.",
 		)
 	}
+	if model == "" && os.Getenv(config.EnvChatModel) == "" {
+		model = ac.Model
+	}
 
 	// 4. Pick model
 	if model == "" {
@@ -141,50 +205,216 @@ func runAsk(question, model string, topK int) error {
 		}
 	}
 
+	citeHint := "citing sources by name"
+	if opts.CiteLines {
+		if err := db.FillLineRanges(results); err != nil {
+			return fmt.Errorf("look up source lines: %w", err)
+		}
+		citeHint = "citing sources by path and line range"
+	}
+
 	fmt.Printf("  %s⦿%s Thinking with %s/%s (%d sources)...\n", cli.Cyan, cli.Reset, chat.Provider(), model, len(results))
 
 	// 6. Build context from search results
-	var context strings.Builder
+	var sources strings.Builder
 	for i, r := range results {
-		context.WriteString(fmt.Sprintf("--- Source %d: %s (%s) ---\n", i+1, r.Title, r.Path))
+		sources.WriteString(fmt.Sprintf("--- Source %d: %s (%s) ---\n", i+1, r.Title, askSourceLabel(r, opts.CiteLines)))
 		snippet := r.Snippet
 		if len(snippet) > 1000 {
 			snippet = snippet[:1000]
 		}
-		context.WriteString(snippet)
-		context.WriteString("\n\n")
+		sources.WriteString(snippet)
+		sources.WriteString("\n\n")
 	}
 
 	// 7. Build prompt
+	var history string
+	if session != nil {
+		history = session.history(maxAskHistoryTokens)
+	}
 	prompt := fmt.Sprintf(`You are a helpful assistant that answers questions using ONLY the provided notes.
 If the notes don't contain enough information to answer, say so honestly.
 Always cite which source(s) you used.
-
+%s
 NOTES:
 %s
 QUESTION: %s
 
-Answer concisely, citing sources by name:`, context.String(), question)
+Answer concisely, %s:`, history, sources.String(), question, citeHint)
 
-	// 8. Generate answer
-	answer, err := chat.Generate(model, prompt)
+	// 8. Generate the answer, printing it as it streams in
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	fmt.Printf("\n  %s─── Answer ───────────────────────────────%s\n\n", cli.Cyan, cli.Reset)
+	out := &answerWriter{}
+	answer, err := llm.GenerateStream(ctx, chat, model, prompt, out.write)
+	out.finish()
 	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return userError(
+				fmt.Sprintf("No answer after %s", opts.Timeout),
+				fmt.Sprintf("Try a smaller --top-k (now %d), a faster --model, or a longer --timeout.", topK),
+			)
+		case errors.Is(err, context.Canceled):
+			return userError("Cancelled", "the answer was interrupted before it finished")
+		}
 		return fmt.Errorf("generate answer: %w", err)
 	}
 
-	// 9. Display answer
-	fmt.Printf("\n  %s─── Answer ───────────────────────────────%s\n\n", cli.Cyan, cli.Reset)
-	// Indent each line of the answer
-	for _, line := range strings.Split(answer, "\n") {
-		fmt.Printf("  %s\n", line)
+	// 9. Remember the turn for follow-ups
+	if session != nil {
+		session.Turns = append(session.Turns, askTurn{Question: question, Answer: answer, At: time.Now().Unix()})
+		if err := session.save(); err != nil {
+			fmt.Fprintf(os.Stderr, "  %sWarning: could not save session: %v%s\n", cli.Yellow, err, cli.Reset)
+		}
 	}
 
 	// 10. Show sources
 	fmt.Printf("\n  %s─── Sources ──────────────────────────────%s\n\n", cli.Dim, cli.Reset)
+	missingLines := false
 	for i, r := range results {
-		fmt.Printf("  %d. %s %s(%s)%s\n", i+1, r.Title, cli.Dim, r.Path, cli.Reset)
+		fmt.Printf("  %d. %s %s(%s)%s\n", i+1, r.Title, cli.Dim, askSourceLabel(r, opts.CiteLines), cli.Reset)
+		missingLines = missingLines || r.StartLine == 0
 	}
 	fmt.Println()
+	if opts.CiteLines && missingLines {
+		fmt.Printf("  %sSome sources have no line ranges yet. Run 'same reindex --force' to record them.%s\n\n", cli.Dim, cli.Reset)
+	}
+	if session != nil {
+		fmt.Printf("  %sSession %s · continue with: same ask \"...\" --session %s%s\n\n", cli.Dim, session.ID, session.ID, cli.Reset)
+	}
 
 	return nil
 }
+
+// askSourceLabel is how a source is named in the prompt and the source
+// list: its path, plus ":start-end" when citing lines and the range is known.
+func askSourceLabel(r store.SearchResult, citeLines bool) string {
+	if !citeLines || r.StartLine == 0 {
+		return r.Path
+	}
+	return fmt.Sprintf("%s:%d-%d", r.Path, r.StartLine, r.EndLine)
+}
+
+// maxAskHistoryTokens caps how much of a session's earlier Q&A is replayed
+// into the prompt; the newest turns are kept.
+const maxAskHistoryTokens = 1000
+
+// maxAskHistoryAnswerChars shortens long earlier answers so one verbose turn
+// does not crowd out the rest of the history.
+const maxAskHistoryAnswerChars = 800
+
+// askSessionIDPattern keeps session IDs safe to use as file names.
+var askSessionIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// askSession is a conversation persisted between 'same ask' runs.
+type askSession struct {
+	ID    string    `json:"id"`
+	Turns []askTurn `json:"turns"`
+}
+
+type askTurn struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+	At       int64  `json:"at"`
+}
+
+func askSessionPath(id string) string {
+	return filepath.Join(config.DataDir(), "ask-sessions", id+".json")
+}
+
+// loadAskSession returns the session with the given ID, or an empty one if
+// it does not exist yet. "new" starts a session with a generated ID.
+func loadAskSession(id string) (*askSession, error) {
+	if id == "new" {
+		return &askSession{ID: time.Now().Format("20060102-150405")}, nil
+	}
+	if !askSessionIDPattern.MatchString(id) {
+		return nil, userError("Invalid session ID", "use letters, digits, '-' and '_' only, or --session new")
+	}
+	s := &askSession{ID: id}
+	data, err := os.ReadFile(askSessionPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read session: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parse session %s: %w", id, err)
+	}
+	s.ID = id
+	return s, nil
+}
+
+func (s *askSession) save() error {
+	path := askSessionPath(s.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// history renders the newest turns that fit in maxTokens as a prompt
+// section, oldest first. Returns "" for a session with no turns.
+func (s *askSession) history(maxTokens int) string {
+	var kept []string
+	used := 0
+	for i := len(s.Turns) - 1; i >= 0; i-- {
+		t := s.Turns[i]
+		turn := fmt.Sprintf("Q: %s\nA: %s\n", t.Question, truncateSnippet(t.Answer, maxAskHistoryAnswerChars))
+		cost := memory.EstimateTokens(turn)
+		if used+cost > maxTokens {
+			break
+		}
+		used += cost
+		kept = append(kept, turn)
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+	slices.Reverse(kept)
+	return "\nCONVERSATION SO FAR (use it to resolve follow-up questions):\n" + strings.Join(kept, "\n")
+}
+
+// answerWriter prints a streamed answer indented by two spaces, dropping
+// leading blank space left behind by filtered thinking blocks.
+type answerWriter struct {
+	started bool
+	midLine bool
+}
+
+func (w *answerWriter) write(s string) {
+	if !w.started {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if s == "" {
+			return
+		}
+		w.started = true
+	}
+	for _, r := range s {
+		if !w.midLine && r != '\n' {
+			fmt.Print("  ")
+			w.midLine = true
+		}
+		fmt.Print(string(r))
+		if r == '\n' {
+			w.midLine = false
+		}
+	}
+}
+
+// finish ends the last answer line.
+func (w *answerWriter) finish() {
+	if w.midLine {
+		fmt.Println()
+	}
+}
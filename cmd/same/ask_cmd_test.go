@@ -1,6 +1,13 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -41,7 +48,7 @@ func TestRunAsk_NoChatProviderConfigured(t *testing.T) {
 		t.Fatalf("BulkInsertNotesLite: %v", err)
 	}
 
-	err = runAsk("sqlite portability", "", 5)
+	err = runAsk(context.Background(), "sqlite portability", askOptions{TopK: 5})
 	if err == nil {
 		t.Fatal("expected error when no chat provider is configured")
 	}
@@ -83,7 +90,7 @@ func TestRunAsk_OllamaUnavailableReturnsActionableHint(t *testing.T) {
 		t.Fatalf("BulkInsertNotesLite: %v", err)
 	}
 
-	err = runAsk("sqlite maintenance", "", 5)
+	err = runAsk(context.Background(), "sqlite maintenance", askOptions{TopK: 5})
 	if err == nil {
 		t.Fatal("expected error when ollama is unavailable")
 	}
@@ -94,3 +101,202 @@ func TestRunAsk_OllamaUnavailableReturnsActionableHint(t *testing.T) {
 		t.Fatalf("expected actionable hint in error, got: %v", err)
 	}
 }
+
+func TestRunAsk_UsesAskProviderConfig(t *testing.T) {
+	var gotModel string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Model string `json:"model"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		gotModel = req.Model
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, tok := range []string{"<think>hmm</think>", "SQLite, for ", "portability (Architecture)."} {
+			data, _ := json.Marshal(map[string]any{"choices": []any{map[string]any{"delta": map[string]string{"content": tok}}}})
+			fmt.Fprintf(w, "data: %s\n\n", data)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	vault := t.TempDir()
+	origVault := config.VaultOverride
+	config.VaultOverride = vault
+	t.Cleanup(func() { config.VaultOverride = origVault })
+
+	t.Setenv("SAME_EMBED_PROVIDER", "none")
+	t.Setenv("SAME_CHAT_PROVIDER", "")
+	t.Setenv("SAME_CHAT_MODEL", "")
+	t.Setenv("SAME_CHAT_BASE_URL", "")
+	t.Setenv("SAME_CHAT_API_KEY", "")
+	t.Setenv("SAME_CHAT_FALLBACKS", "")
+
+	cfgDir := filepath.Join(vault, ".same")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfgText := fmt.Sprintf("[ask]\nprovider = \"openai-compatible\"\nbase_url = %q\nmodel = \"local-llm\"\n", srv.URL)
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte(cfgText), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatalf("store.Open: %v", err)
+	}
+	defer db.Close()
+	insertCommandTestNote(t, db, "notes/arch.md", "Architecture", "We chose sqlite for portability.")
+
+	out := captureCommandStdout(t, func() {
+		if err := runAsk(context.Background(), "sqlite portability", askOptions{TopK: 5}); err != nil {
+			t.Fatalf("runAsk: %v", err)
+		}
+	})
+	if gotModel != "local-llm" {
+		t.Errorf("model = %q, want local-llm from [ask] model", gotModel)
+	}
+	if !strings.Contains(out, "openai-compatible/local-llm") || !strings.Contains(out, "  SQLite, for portability") || strings.Contains(out, "hmm") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestRunAsk_TimeoutSuggestsSmallerContext(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/arch.md", "Architecture", "We chose sqlite for portability.")
+	t.Setenv("SAME_EMBED_PROVIDER", "none")
+	t.Setenv("SAME_CHAT_PROVIDER", "openai-compatible")
+	t.Setenv("SAME_CHAT_BASE_URL", srv.URL)
+	t.Setenv("SAME_CHAT_MODEL", "slow-model")
+	t.Setenv("SAME_CHAT_API_KEY", "")
+	t.Setenv("SAME_CHAT_FALLBACKS", "")
+
+	var err error
+	captureCommandStdout(t, func() {
+		err = runAsk(context.Background(), "sqlite portability", askOptions{TopK: 5, Timeout: 50 * time.Millisecond})
+	})
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if !strings.Contains(err.Error(), "No answer after") || !strings.Contains(err.Error(), "--top-k") {
+		t.Errorf("expected actionable timeout error, got: %v", err)
+	}
+}
+
+func TestRunAsk_SessionCarriesHistory(t *testing.T) {
+	var prompts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Messages) > 0 {
+			prompts = append(prompts, req.Messages[0].Content)
+		}
+		answer := fmt.Sprintf("Answer %d.", len(prompts))
+		data, _ := json.Marshal(map[string]any{"choices": []any{map[string]any{"delta": map[string]string{"content": answer}}}})
+		fmt.Fprintf(w, "data: %s\n\ndata: [DONE]\n\n", data)
+	}))
+	defer srv.Close()
+
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/arch.md", "Architecture", "We chose sqlite for portability.")
+	t.Setenv("SAME_EMBED_PROVIDER", "none")
+	t.Setenv("SAME_CHAT_PROVIDER", "openai-compatible")
+	t.Setenv("SAME_CHAT_BASE_URL", srv.URL)
+	t.Setenv("SAME_CHAT_MODEL", "m")
+	t.Setenv("SAME_CHAT_API_KEY", "")
+	t.Setenv("SAME_CHAT_FALLBACKS", "")
+
+	ask := func(q string) {
+		captureCommandStdout(t, func() {
+			if err := runAsk(context.Background(), q, askOptions{TopK: 5, Session: "design"}); err != nil {
+				t.Fatalf("runAsk: %v", err)
+			}
+		})
+	}
+	ask("why sqlite?")
+	ask("what about portability?")
+
+	if len(prompts) != 2 {
+		t.Fatalf("expected 2 chat requests, got %d", len(prompts))
+	}
+	if strings.Contains(prompts[0], "CONVERSATION SO FAR") {
+		t.Error("first turn should have no history")
+	}
+	if !strings.Contains(prompts[1], "Q: why sqlite?\nA: Answer 1.") {
+		t.Errorf("second prompt missing history:\n%s", prompts[1])
+	}
+
+	session, err := loadAskSession("design")
+	if err != nil {
+		t.Fatalf("loadAskSession: %v", err)
+	}
+	if len(session.Turns) != 2 || session.Turns[1].Answer != "Answer 2." {
+		t.Errorf("saved turns = %+v", session.Turns)
+	}
+	if _, err := loadAskSession("../escape"); err == nil {
+		t.Error("expected invalid session ID to be rejected")
+	}
+}
+
+func TestRunAsk_CiteLines(t *testing.T) {
+	var prompt string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Messages) > 0 {
+			prompt = req.Messages[0].Content
+		}
+		data, _ := json.Marshal(map[string]any{"choices": []any{map[string]any{"delta": map[string]string{"content": "Retries back off."}}}})
+		fmt.Fprintf(w, "data: %s\n\ndata: [DONE]\n\n", data)
+	}))
+	defer srv.Close()
+
+	_, db := setupCommandTestVault(t)
+	rec := store.NoteRecord{
+		Path: "notes/retry.md", Title: "Retry policy", Tags: "[]",
+		ChunkHeading: "## Retries", Text: "## Retries\nRetries back off exponentially.",
+		StartLine: 12, EndLine: 18, ContentType: "note", Confidence: 0.8,
+	}
+	if _, err := db.BulkInsertNotesLite([]store.NoteRecord{rec}); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+	t.Setenv("SAME_EMBED_PROVIDER", "none")
+	t.Setenv("SAME_CHAT_PROVIDER", "openai-compatible")
+	t.Setenv("SAME_CHAT_BASE_URL", srv.URL)
+	t.Setenv("SAME_CHAT_MODEL", "m")
+	t.Setenv("SAME_CHAT_API_KEY", "")
+	t.Setenv("SAME_CHAT_FALLBACKS", "")
+
+	out := captureCommandStdout(t, func() {
+		if err := runAsk(context.Background(), "how do retries back off?", askOptions{TopK: 5, CiteLines: true}); err != nil {
+			t.Fatalf("runAsk: %v", err)
+		}
+	})
+	if !strings.Contains(prompt, "(notes/retry.md:12-18)") {
+		t.Errorf("prompt missing line range:\n%s", prompt)
+	}
+	if !strings.Contains(out, "notes/retry.md:12-18") {
+		t.Errorf("sources missing line range:\n%s", out)
+	}
+}
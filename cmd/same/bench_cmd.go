@@ -3,6 +3,8 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -15,149 +17,272 @@ import (
 )
 
 func benchCmd() *cobra.Command {
-	return &cobra.Command{
+	var opts benchOptions
+	cmd := &cobra.Command{
 		Use:   "bench",
 		Short: "Test how fast search is on your vault",
-		Long:  "Measure cold-start, search, embedding, and database performance.",
+		Long: `Measure cold-start, search, embedding, and database performance.
+
+Use --iterations to run each benchmark several times and report min, median,
+and p95 latencies. Add --json-only for machine-readable output suitable for
+tracking regressions over time.
+
+Examples:
+  same bench
+  same bench --iterations 10
+  same bench --iterations 20 --json-only > bench.json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runBench()
+			if opts.Iterations < 1 {
+				return userError("--iterations must be at least 1", "try: same bench --iterations 10")
+			}
+			return runBench(opts)
 		},
 	}
+	cmd.Flags().IntVar(&opts.Iterations, "iterations", 1, "Run each benchmark N times and report min/median/p95")
+	cmd.Flags().BoolVar(&opts.JSONOnly, "json-only", false, "Print only the JSON report")
+	return cmd
+}
+
+type benchOptions struct {
+	Iterations int
+	JSONOnly   bool
+}
+
+// benchSchemaVersion is bumped whenever the JSON report shape changes.
+const benchSchemaVersion = 1
+
+// benchReport is the JSON document emitted by same bench.
+type benchReport struct {
+	SchemaVersion int           `json:"schema_version"`
+	Version       string        `json:"version"`
+	Timestamp     string        `json:"timestamp"`
+	Iterations    int           `json:"iterations"`
+	Notes         int           `json:"notes"`
+	Chunks        int           `json:"chunks"`
+	Results       []benchResult `json:"results"`
 }
 
 type benchResult struct {
-	Name    string `json:"name"`
-	Latency string `json:"latency_ms"`
-	Detail  string `json:"detail,omitempty"`
+	Name       string  `json:"name"`
+	Latency    string  `json:"latency_ms"` // median, or "FAILED"
+	Detail     string  `json:"detail,omitempty"`
+	Iterations int     `json:"iterations"`
+	MinMs      float64 `json:"min_ms"`
+	MedianMs   float64 `json:"median_ms"`
+	P95Ms      float64 `json:"p95_ms"`
 }
 
-func runBench() error {
-	fmt.Println("SAME Performance Benchmark")
-	fmt.Println("==========================")
-	fmt.Println()
+// benchStats summarizes latency samples. Samples are sorted in place.
+func benchStats(samples []time.Duration) (minMs, medianMs, p95Ms float64) {
+	if len(samples) == 0 {
+		return 0, 0, 0
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	ms := func(d time.Duration) float64 { return float64(d.Microseconds()) / 1000.0 }
+
+	n := len(samples)
+	minMs = ms(samples[0])
+	if n%2 == 1 {
+		medianMs = ms(samples[n/2])
+	} else {
+		medianMs = (ms(samples[n/2-1]) + ms(samples[n/2])) / 2
+	}
+	// Nearest-rank percentile.
+	rank := int(math.Ceil(0.95*float64(n))) - 1
+	p95Ms = ms(samples[rank])
+	return minMs, medianMs, p95Ms
+}
 
-	var results []benchResult
+// newBenchResult builds a result from latency samples. precision is the
+// number of decimals used for the human-readable latency string.
+func newBenchResult(name, detail string, samples []time.Duration, precision int) benchResult {
+	minMs, medianMs, p95Ms := benchStats(samples)
+	return benchResult{
+		Name:       name,
+		Latency:    strconv.FormatFloat(medianMs, 'f', precision, 64),
+		Detail:     detail,
+		Iterations: len(samples),
+		MinMs:      minMs,
+		MedianMs:   medianMs,
+		P95Ms:      p95Ms,
+	}
+}
+
+func failedBenchResult(name string, err error) benchResult {
+	return benchResult{Name: name, Latency: "FAILED", Detail: err.Error()}
+}
 
-	// 1. Database open (cold start)
-	t0 := time.Now()
-	db, err := store.Open()
-	dbOpen := time.Since(t0)
-	if err != nil {
-		return config.ErrNoDatabase
+func runBench(opts benchOptions) error {
+	n := opts.Iterations
+	report := benchReport{
+		SchemaVersion: benchSchemaVersion,
+		Version:       Version,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+		Iterations:    n,
+	}
+
+	printLine := func(r benchResult) {
+		if opts.JSONOnly {
+			return
+		}
+		if r.Latency == "FAILED" {
+			fmt.Printf("  %-30s %8s     %s\n", r.Name, "FAILED", r.Detail)
+			return
+		}
+		spread := ""
+		if r.Iterations > 1 {
+			spread = fmt.Sprintf("  (min %.1f, p95 %.1f)", r.MinMs, r.P95Ms)
+		}
+		fmt.Printf("  %-30s %8s ms  %s%s\n", r.Name, r.Latency, r.Detail, spread)
+	}
+	add := func(r benchResult) {
+		report.Results = append(report.Results, r)
+		printLine(r)
+	}
+	finish := func() error {
+		if opts.JSONOnly {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("encode report: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		printBenchSummary(report.Results)
+
+		// Output JSON for programmatic consumption
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println("\n" + string(data))
+		return nil
+	}
+
+	if !opts.JSONOnly {
+		fmt.Println("SAME Performance Benchmark")
+		fmt.Println("==========================")
+		if n > 1 {
+			fmt.Printf("%d iterations per benchmark (median shown)\n", n)
+		}
+		fmt.Println()
+	}
+
+	// 1. Database open (cold start). The first open is cold; later
+	// iterations measure warm reopen cost.
+	var db *store.DB
+	samples := make([]time.Duration, 0, n)
+	for i := 0; i < n; i++ {
+		t0 := time.Now()
+		d, err := store.Open()
+		samples = append(samples, time.Since(t0))
+		if err != nil {
+			if db != nil {
+				db.Close()
+			}
+			return config.ErrNoDatabase
+		}
+		if db == nil {
+			db = d
+		} else {
+			d.Close()
+		}
 	}
 	defer db.Close()
 
-	noteCount, _ := db.NoteCount()
-	chunkCount, _ := db.ChunkCount()
-	results = append(results, benchResult{
-		Name:    "DB open (cold start)",
-		Latency: fmt.Sprintf("%.1f", float64(dbOpen.Microseconds())/1000.0),
-		Detail:  fmt.Sprintf("%d notes, %d chunks", noteCount, chunkCount),
-	})
-	fmt.Printf("  %-30s %8s ms  %s\n", results[len(results)-1].Name, results[len(results)-1].Latency, results[len(results)-1].Detail)
+	report.Notes, _ = db.NoteCount()
+	report.Chunks, _ = db.ChunkCount()
+	add(newBenchResult("DB open (cold start)",
+		fmt.Sprintf("%d notes, %d chunks", report.Notes, report.Chunks), samples, 1))
 
 	// 2. Embedding latency (single query)
 	client, provErr := newEmbedProvider()
 	if provErr != nil {
-		results = append(results, benchResult{
-			Name:    "Embedding",
-			Latency: "FAILED",
-			Detail:  provErr.Error(),
-		})
-		fmt.Printf("  %-30s %8s     %s\n", "Embedding", "FAILED", provErr.Error())
-		printBenchSummary(results)
-		return nil
+		add(failedBenchResult("Embedding", provErr))
+		return finish()
 	}
 	testQuery := "what decisions were made about the memory system architecture"
-	t0 = time.Now()
-	queryVec, err := client.GetQueryEmbedding(testQuery)
-	embedLatency := time.Since(t0)
 	embedLabel := fmt.Sprintf("Embedding (%s)", client.Name())
-	if err != nil {
-		results = append(results, benchResult{
-			Name:    embedLabel,
-			Latency: "FAILED",
-			Detail:  err.Error(),
-		})
-		fmt.Printf("  %-30s %8s     %s\n", embedLabel, "FAILED", err.Error())
+	var queryVec []float32
+	samples = samples[:0]
+	var embedErr error
+	for i := 0; i < n; i++ {
+		t0 := time.Now()
+		vec, err := client.GetQueryEmbedding(testQuery)
+		samples = append(samples, time.Since(t0))
+		if err != nil {
+			embedErr = err
+			break
+		}
+		queryVec = vec
+	}
+	if embedErr != nil {
+		queryVec = nil
+		add(failedBenchResult(embedLabel, embedErr))
 	} else {
-		results = append(results, benchResult{
-			Name:    embedLabel,
-			Latency: fmt.Sprintf("%.1f", float64(embedLatency.Microseconds())/1000.0),
-			Detail:  fmt.Sprintf("%d dimensions", len(queryVec)),
-		})
-		fmt.Printf("  %-30s %8s ms  %s\n", results[len(results)-1].Name, results[len(results)-1].Latency, results[len(results)-1].Detail)
+		add(newBenchResult(embedLabel, fmt.Sprintf("%d dimensions", len(queryVec)), samples, 1))
 	}
 
 	if queryVec == nil {
-		fmt.Println("\n  Skipping search benchmarks (embedding failed).")
-		printBenchSummary(results)
-		return nil
+		if !opts.JSONOnly {
+			fmt.Println("\n  Skipping search benchmarks (embedding failed).")
+		}
+		return finish()
 	}
 
 	// 3. Vector search (vanilla, KNN only)
-	t0 = time.Now()
-	searchResults, err := db.VectorSearch(queryVec, store.SearchOptions{TopK: 10})
-	searchLatency := time.Since(t0)
-	if err != nil {
-		return fmt.Errorf("search: %w", err)
-	}
-	results = append(results, benchResult{
-		Name:    "Vector search (top-10)",
-		Latency: fmt.Sprintf("%.1f", float64(searchLatency.Microseconds())/1000.0),
-		Detail:  fmt.Sprintf("%d results", len(searchResults)),
-	})
-	fmt.Printf("  %-30s %8s ms  %s\n", results[len(results)-1].Name, results[len(results)-1].Latency, results[len(results)-1].Detail)
+	samples = samples[:0]
+	var searchResults []store.SearchResult
+	for i := 0; i < n; i++ {
+		t0 := time.Now()
+		res, err := db.VectorSearch(queryVec, store.SearchOptions{TopK: 10})
+		samples = append(samples, time.Since(t0))
+		if err != nil {
+			return fmt.Errorf("search: %w", err)
+		}
+		searchResults = res
+	}
+	add(newBenchResult("Vector search (top-10)", fmt.Sprintf("%d results", len(searchResults)), samples, 1))
 
 	// 4. Raw search + composite scoring
-	t0 = time.Now()
-	rawResults, _ := db.VectorSearchRaw(queryVec, 50)
-	_ = rawResults
-	rawSearchLatency := time.Since(t0)
-	results = append(results, benchResult{
-		Name:    "Raw search (top-50)",
-		Latency: fmt.Sprintf("%.1f", float64(rawSearchLatency.Microseconds())/1000.0),
-		Detail:  fmt.Sprintf("%d raw results", len(rawResults)),
-	})
-	fmt.Printf("  %-30s %8s ms  %s\n", results[len(results)-1].Name, results[len(results)-1].Latency, results[len(results)-1].Detail)
-
-	// 5. Composite scoring (CPU only, no I/O)
-	t0 = time.Now()
-	for i := 0; i < 1000; i++ {
-		for _, r := range rawResults {
-			memory.CompositeScore(0.8, r.Modified, r.Confidence, r.ContentType, 0.5, 0.4, 0.1)
+	samples = samples[:0]
+	var rawResults []store.RawSearchResult
+	for i := 0; i < n; i++ {
+		t0 := time.Now()
+		rawResults, _ = db.VectorSearchRaw(queryVec, 50)
+		samples = append(samples, time.Since(t0))
+	}
+	add(newBenchResult("Raw search (top-50)", fmt.Sprintf("%d raw results", len(rawResults)), samples, 1))
+
+	// 5. Composite scoring (CPU only, no I/O). Each sample is the mean
+	// cost of one pass over the raw results, averaged across 1000 passes.
+	samples = samples[:0]
+	var opsPerSec float64
+	for i := 0; i < n; i++ {
+		t0 := time.Now()
+		for j := 0; j < 1000; j++ {
+			for _, r := range rawResults {
+				memory.CompositeScore(0.8, r.Modified, r.Confidence, r.ContentType, 0.5, 0.4, 0.1)
+			}
 		}
+		compositeDur := time.Since(t0)
+		samples = append(samples, compositeDur/1000)
+		opsPerSec = float64(1000*len(rawResults)) / compositeDur.Seconds()
 	}
-	compositeDur := time.Since(t0)
-	opsPerSec := float64(1000*len(rawResults)) / compositeDur.Seconds()
-	results = append(results, benchResult{
-		Name:    "Composite scoring",
-		Latency: fmt.Sprintf("%.3f", float64(compositeDur.Microseconds())/1000.0/1000.0),
-		Detail:  fmt.Sprintf("%.0f scores/sec (1000 x %d)", opsPerSec, len(rawResults)),
-	})
-	fmt.Printf("  %-30s %8s ms  %s\n", results[len(results)-1].Name, results[len(results)-1].Latency, results[len(results)-1].Detail)
+	add(newBenchResult("Composite scoring",
+		fmt.Sprintf("%.0f scores/sec (1000 x %d)", opsPerSec, len(rawResults)), samples, 3))
 
 	// 6. End-to-end: embed + search + score (what a hook actually does)
-	t0 = time.Now()
-	vec2, _ := client.GetQueryEmbedding("recent session handoffs and decisions")
-	raw2, _ := db.VectorSearchRaw(vec2, 12)
-	for _, r := range raw2 {
-		memory.CompositeScore(0.8, r.Modified, r.Confidence, r.ContentType, 0.5, 0.4, 0.1)
-	}
-	e2eLatency := time.Since(t0)
-	results = append(results, benchResult{
-		Name:    "End-to-end (hook sim)",
-		Latency: fmt.Sprintf("%.1f", float64(e2eLatency.Microseconds())/1000.0),
-		Detail:  "embed + search + score",
-	})
-	fmt.Printf("  %-30s %8s ms  %s\n", results[len(results)-1].Name, results[len(results)-1].Latency, results[len(results)-1].Detail)
-
-	printBenchSummary(results)
-
-	// Output JSON for programmatic consumption
-	data, _ := json.MarshalIndent(results, "", "  ")
-	fmt.Println("\n" + string(data))
-	return nil
+	samples = samples[:0]
+	for i := 0; i < n; i++ {
+		t0 := time.Now()
+		vec2, _ := client.GetQueryEmbedding("recent session handoffs and decisions")
+		raw2, _ := db.VectorSearchRaw(vec2, 12)
+		for _, r := range raw2 {
+			memory.CompositeScore(0.8, r.Modified, r.Confidence, r.ContentType, 0.5, 0.4, 0.1)
+		}
+		samples = append(samples, time.Since(t0))
+	}
+	add(newBenchResult("End-to-end (hook sim)", "embed + search + score", samples, 1))
+
+	return finish()
 }
 
 func printBenchSummary(results []benchResult) {
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestBenchStats(t *testing.T) {
+	ms := func(v ...int) []time.Duration {
+		out := make([]time.Duration, len(v))
+		for i, x := range v {
+			out[i] = time.Duration(x) * time.Millisecond
+		}
+		return out
+	}
+
+	minMs, medianMs, p95Ms := benchStats(ms(30, 10, 20))
+	if minMs != 10 || medianMs != 20 || p95Ms != 30 {
+		t.Errorf("odd samples: got min=%v median=%v p95=%v", minMs, medianMs, p95Ms)
+	}
+
+	minMs, medianMs, p95Ms = benchStats(ms(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 100))
+	if minMs != 1 || medianMs != 10.5 || p95Ms != 19 {
+		t.Errorf("20 samples: got min=%v median=%v p95=%v", minMs, medianMs, p95Ms)
+	}
+
+	if minMs, medianMs, p95Ms = benchStats(nil); minMs != 0 || medianMs != 0 || p95Ms != 0 {
+		t.Errorf("empty samples should be zero, got %v %v %v", minMs, medianMs, p95Ms)
+	}
+}
+
+func TestBenchCmd_JSONOnly(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	_ = db.Close()
+
+	cmd := benchCmd()
+	cmd.SetArgs([]string{"--iterations", "3", "--json-only"})
+
+	var execErr error
+	out := captureCommandStdout(t, func() {
+		execErr = cmd.Execute()
+	})
+	if execErr != nil {
+		t.Fatalf("bench: %v", execErr)
+	}
+
+	var report benchReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("expected pure JSON output, got %q: %v", out, err)
+	}
+	if report.SchemaVersion != benchSchemaVersion || report.Iterations != 3 {
+		t.Errorf("unexpected report header: %+v", report)
+	}
+	if len(report.Results) == 0 || report.Results[0].Iterations != 3 {
+		t.Fatalf("expected DB open result with 3 iterations, got %+v", report.Results)
+	}
+}
+
+func TestBenchCmd_RejectsZeroIterations(t *testing.T) {
+	cmd := benchCmd()
+	cmd.SetArgs([]string{"--iterations", "0"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected error for --iterations 0")
+	}
+}
@@ -1,14 +1,23 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 
 	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/embedding"
+	"github.com/sgx-labs/statelessagent/internal/indexer"
+	"github.com/sgx-labs/statelessagent/internal/memory"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func ciCmd() *cobra.Command {
@@ -22,10 +31,12 @@ This command helps you set up GitHub Actions to:
 - Build releases when you create a tag
 - Catch bugs before they reach production
 
-Run 'same ci init' to get started.`,
+Run 'same ci init' to get started. Add 'same ci check' to a pull request
+workflow to catch vault changes that would break a teammate's index.`,
 	}
 
 	cmd.AddCommand(ciInitCmd())
+	cmd.AddCommand(ciCheckCmd())
 	cmd.AddCommand(ciExplainCmd())
 
 	return cmd
@@ -63,6 +74,247 @@ func ciExplainCmd() *cobra.Command {
 	}
 }
 
+func ciCheckCmd() *cobra.Command {
+	var (
+		jsonOut   bool
+		reachable bool
+	)
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Verify the vault would index cleanly (for pull requests)",
+		Long: `Check the vault the way the indexer will see it, without indexing:
+
+  • config   .same/config.toml has no unknown keys or out-of-range values
+  • parse    every note's frontmatter parses, its dates are readable, and
+             its text is valid UTF-8 (otherwise metadata is silently dropped)
+  • embedding  if an index exists, its vectors match the configured model's
+             provider, model, and dimensions
+
+With --reachable, also fail when a Markdown file is hidden from the indexer
+by skip_dirs or .sameignore (built-in exclusions like _PRIVATE/ are fine).
+
+Exits non-zero with a summary when anything fails. --json prints every
+issue with its file and line, for CI annotations.
+
+Example GitHub Actions step:
+  - run: same ci check --reachable`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCICheck(jsonOut, reachable)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output the report as JSON")
+	cmd.Flags().BoolVar(&reachable, "reachable", false, "Fail if any Markdown file is excluded from indexing")
+	return cmd
+}
+
+// ciIssue is one problem found by 'same ci check'. Path is vault-relative.
+type ciIssue struct {
+	Check   string `json:"check"` // "config", "parse", "embedding", or "reachable"
+	Path    string `json:"path,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// ciReport is the result of 'same ci check'.
+type ciReport struct {
+	Vault   string    `json:"vault"`
+	Notes   int       `json:"notes"`
+	Passed  bool      `json:"passed"`
+	Issues  []ciIssue `json:"issues"`
+	Skipped []string  `json:"skipped,omitempty"` // checks that could not run, with why
+}
+
+func runCICheck(jsonOut, reachable bool) error {
+	vaultPath := config.VaultPath()
+	if vaultPath == "" {
+		return config.ErrNoVault
+	}
+	// Loading the config applies [vault] skip_dirs to the walk below.
+	_, _ = config.LoadConfig()
+
+	report := ciReport{Vault: vaultPath, Issues: []ciIssue{}}
+	for _, issue := range config.ValidateConfig() {
+		report.Issues = append(report.Issues, ciIssue{Check: "config", Path: filepath.Join(".same", issue.File), Message: issue.String()})
+	}
+
+	notes := indexer.WalkVaultWithIgnore(vaultPath)
+	report.Notes = len(notes)
+	for _, path := range notes {
+		report.Issues = append(report.Issues, ciCheckNote(vaultPath, path)...)
+	}
+
+	if issues, skipped := ciCheckEmbeddings(); skipped != "" {
+		report.Skipped = append(report.Skipped, skipped)
+	} else {
+		report.Issues = append(report.Issues, issues...)
+	}
+
+	if reachable {
+		report.Issues = append(report.Issues, ciUnreachableNotes(vaultPath, notes)...)
+	}
+	report.Passed = len(report.Issues) == 0
+
+	if jsonOut {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		printCIReport(report)
+	}
+	if !report.Passed {
+		return fmt.Errorf("ci check failed: %d issue(s)", len(report.Issues))
+	}
+	return nil
+}
+
+// ciCheckNote reports problems that would make the indexer ignore part of
+// a note: unreadable text, broken frontmatter, or dates it cannot parse.
+func ciCheckNote(vaultPath, path string) []ciIssue {
+	rel := filepath.ToSlash(relPathOr(vaultPath, path))
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return []ciIssue{{Check: "parse", Path: rel, Message: fmt.Sprintf("cannot read: %v", err)}}
+	}
+	if !utf8.Valid(content) {
+		return []ciIssue{{Check: "parse", Path: rel, Message: "not valid UTF-8"}}
+	}
+	text := string(content)
+	if err := indexer.FrontmatterError(text); err != nil {
+		return []ciIssue{{Check: "parse", Path: rel, Line: 1,
+			Message: fmt.Sprintf("frontmatter does not parse, so its metadata is ignored: %v", err)}}
+	}
+	meta := indexer.ParseNote(text).Meta
+	var issues []ciIssue
+	if v := strings.TrimSpace(meta.Expires); v != "" && memory.ExpiryDate(v) == "" {
+		issues = append(issues, ciIssue{Check: "parse", Path: rel, Line: 1,
+			Message: fmt.Sprintf("expires: %q is not a date (use YYYY-MM-DD)", v)})
+	}
+	if meta.Confidence < 0 || meta.Confidence > 1 {
+		issues = append(issues, ciIssue{Check: "parse", Path: rel, Line: 1,
+			Message: fmt.Sprintf("confidence: %g is outside 0-1", meta.Confidence)})
+	}
+	return issues
+}
+
+// ciCheckEmbeddings compares an existing index against the configured
+// embedding model. It returns a reason instead when there is nothing to
+// compare, which is the usual case on a fresh CI checkout.
+func ciCheckEmbeddings() ([]ciIssue, string) {
+	ec := config.EmbeddingProviderConfig()
+	if ec.Provider == "none" {
+		return nil, "embedding: keyword-only mode"
+	}
+	if _, err := os.Stat(config.DBPath()); err != nil {
+		return nil, "embedding: no index in this checkout"
+	}
+	db, err := store.Open()
+	if err != nil {
+		return []ciIssue{{Check: "embedding", Message: fmt.Sprintf("cannot open index: %v", err)}}, ""
+	}
+	defer db.Close()
+
+	var issues []ciIssue
+	provCfg := embedding.ProviderConfig{
+		Provider:   ec.Provider,
+		Model:      ec.Model,
+		APIKey:     ec.APIKey,
+		BaseURL:    ec.BaseURL,
+		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
+		SkipRetry:  true,
+	}
+	if client, err := embedding.NewProvider(provCfg); err == nil && client != nil {
+		if err := db.CheckEmbeddingMeta(client.Name(), client.Model(), client.Dimensions()); err != nil {
+			issues = append(issues, ciIssue{Check: "embedding", Message: err.Error()})
+		}
+	} else if err := db.CheckEmbeddingMeta(ec.Provider, ec.Model, config.EmbeddingDim()); err != nil {
+		issues = append(issues, ciIssue{Check: "embedding", Message: err.Error()})
+	}
+	if _, err := checkEmbeddingDims(db); err != nil {
+		issues = append(issues, ciIssue{Check: "embedding", Message: err.Error()})
+	}
+	return issues, ""
+}
+
+// ciUnreachableNotes lists Markdown files the indexer's walk skips because
+// of skip_dirs, .sameignore, or symlinks. Built-in exclusions (_PRIVATE/,
+// .obsidian/, CLAUDE.md, ...) are deliberate and not reported.
+func ciUnreachableNotes(vaultPath string, indexed []string) []ciIssue {
+	seen := make(map[string]bool, len(indexed))
+	for _, p := range indexed {
+		seen[p] = true
+	}
+	var issues []ciIssue
+	_ = filepath.WalkDir(vaultPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != vaultPath && config.IsDefaultSkipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".md") || config.SkipFiles[d.Name()] || seen[path] {
+			return nil
+		}
+		rel := filepath.ToSlash(relPathOr(vaultPath, path))
+		reason := "ignored by .sameignore"
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			reason = "a symlink (never followed)"
+		default:
+			for _, part := range strings.Split(rel, "/") {
+				if config.SkipDirs[part] {
+					reason = fmt.Sprintf("inside skipped directory %q", part)
+					break
+				}
+			}
+		}
+		issues = append(issues, ciIssue{Check: "reachable", Path: rel, Message: "not indexed: " + reason})
+		return nil
+	})
+	return issues
+}
+
+func relPathOr(base, path string) string {
+	if rel, err := filepath.Rel(base, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+func printCIReport(r ciReport) {
+	fmt.Printf("\n  %sSAME CI check%s — %d notes in %s\n\n", cli.Bold, cli.Reset, r.Notes, cli.ShortenHome(r.Vault))
+	counts := make(map[string]int)
+	for _, issue := range r.Issues {
+		counts[issue.Check]++
+	}
+	for _, check := range []string{"config", "parse", "embedding", "reachable"} {
+		if n := counts[check]; n > 0 {
+			fmt.Printf("  %s✗%s %-10s %d issue(s)\n", cli.Red, cli.Reset, check, n)
+		}
+	}
+	for _, issue := range r.Issues {
+		loc := issue.Path
+		if issue.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", issue.Path, issue.Line)
+		}
+		if loc != "" {
+			loc += ": "
+		}
+		fmt.Printf("      %s%s\n", loc, issue.Message)
+	}
+	for _, s := range r.Skipped {
+		fmt.Printf("  %s- skipped %s%s\n", cli.Dim, s, cli.Reset)
+	}
+	if r.Passed {
+		fmt.Printf("  %s✓%s Vault would index cleanly.\n\n", cli.Green, cli.Reset)
+		return
+	}
+	fmt.Println()
+}
+
 func runCIInit(force bool) error {
 	// Check if we're in a git repo
 	if _, err := exec.Command("git", "rev-parse", "--show-toplevel").Output(); err != nil {
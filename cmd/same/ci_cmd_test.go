@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunCICheck(t *testing.T) {
+	vault, _ := setupCommandTestVault(t)
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(vault, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("notes/good.md", "---\ntitle: Good\nexpires: 2030-01-01\n---\nFine.\n")
+	write("_PRIVATE/secret.md", "---\ntitle: [broken\n---\nNever indexed.\n")
+
+	out := captureCommandStdout(t, func() {
+		if err := runCICheck(true, true); err != nil {
+			t.Errorf("clean vault: %v", err)
+		}
+	})
+	var report ciReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("parse report: %v\n%s", err, out)
+	}
+	if !report.Passed || report.Notes != 1 {
+		t.Fatalf("report = %+v, want a pass over 1 note", report)
+	}
+
+	write("notes/broken.md", "---\ntitle: [unclosed\n---\nBody.\n")
+	write("notes/dates.md", "---\nexpires: someday\n---\nBody.\n")
+	write("drafts/hidden.md", "Not indexed.\n")
+	write(".sameignore", "drafts/\n")
+
+	out = captureCommandStdout(t, func() {
+		if err := runCICheck(true, true); err == nil {
+			t.Error("expected the check to fail")
+		}
+	})
+	report = ciReport{}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("parse report: %v\n%s", err, out)
+	}
+	got := make(map[string]string)
+	for _, issue := range report.Issues {
+		got[issue.Path] = issue.Check
+	}
+	want := map[string]string{
+		"notes/broken.md":  "parse",
+		"notes/dates.md":   "parse",
+		"drafts/hidden.md": "reachable",
+	}
+	for path, check := range want {
+		if got[path] != check {
+			t.Errorf("%s: check = %q, want %q (issues: %+v)", path, got[path], check, report.Issues)
+		}
+	}
+	if len(report.Issues) != len(want) {
+		t.Errorf("issues = %+v, want %d", report.Issues, len(want))
+	}
+
+	// Without --reachable, hidden files are not an error.
+	out = captureCommandStdout(t, func() {
+		_ = runCICheck(true, false)
+	})
+	report = ciReport{}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("parse report: %v\n%s", err, out)
+	}
+	for _, issue := range report.Issues {
+		if issue.Check == "reachable" {
+			t.Errorf("reachability checked without --reachable: %+v", issue)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -56,7 +58,7 @@ func configCmd() *cobra.Command {
 					fmt.Printf("# Ollama URL: (error: %v)\n", err)
 				} else {
 					source := "default"
-					if os.Getenv("OLLAMA_URL") != "" {
+					if os.Getenv(config.EnvOllamaURL) != "" {
 						source = "from OLLAMA_URL env"
 					} else if cf := config.FindConfigFile(); cf != "" {
 						source = "from config.toml"
@@ -69,6 +71,7 @@ func configCmd() *cobra.Command {
 				model = config.EmbeddingModel
 			}
 			fmt.Printf("# Embed model: %s\n", model)
+			printConfigKeyNotes()
 			return nil
 		},
 	})
@@ -147,7 +150,23 @@ Use --global to set in ~/.config/same/config.toml (applies to all vaults).
 Without --global, sets in the current vault's .same/config.toml.`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := config.SetConfigValue(args[0], args[1], setGlobal); err != nil {
+			write := func() error {
+				return config.SetConfigValue(args[0], args[1], setGlobal)
+			}
+			// A staged embedding set for the new model becomes active
+			// once the config points at it, so search doesn't need a
+			// reindex.
+			switched := false
+			if args[0] == "embedding.model" {
+				cfgPath := config.GlobalConfigPath()
+				if !setGlobal {
+					cfgPath = config.ConfigFilePath(config.VaultPath())
+				}
+				var err error
+				if switched, err = switchEmbeddingModel(cfgPath, args[1], write); err != nil {
+					return err
+				}
+			} else if err := write(); err != nil {
 				return err
 			}
 			target := "vault"
@@ -155,15 +174,91 @@ Without --global, sets in the current vault's .same/config.toml.`,
 				target = "global"
 			}
 			fmt.Printf("  %s✓%s Set %s = %s (%s config)\n", cli.Green, cli.Reset, args[0], args[1], target)
+			if switched {
+				fmt.Println("  Switched to the staged embeddings for this model. No reindex needed.")
+				fmt.Println(stagedFactsNote)
+			}
 			return nil
 		},
 	}
 	setCmd.Flags().BoolVar(&setGlobal, "global", false, "Set in global config (~/.config/same/config.toml)")
 	cmd.AddCommand(setCmd)
 
+	// config env
+	var envJSON bool
+	envCmd := &cobra.Command{
+		Use:   "env",
+		Short: "List the environment variables SAME reads",
+		Long: `List every environment variable SAME recognizes, whether it's set in
+this shell, and the value SAME ends up using for that setting after
+merging config files and defaults. API keys and tokens show only whether
+they're set.
+
+Examples:
+  same config env
+  same config env --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigEnv(envJSON)
+		},
+	}
+	envCmd.Flags().BoolVar(&envJSON, "json", false, "Output as JSON")
+	cmd.AddCommand(envCmd)
+
 	return cmd
 }
 
+// configEnvEntry is one variable in 'same config env --json' output.
+type configEnvEntry struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Set         bool   `json:"set"`
+	Value       string `json:"value,omitempty"`
+	Effective   string `json:"effective,omitempty"`
+	Secret      bool   `json:"secret,omitempty"`
+}
+
+func runConfigEnv(jsonOut bool) error {
+	settings := config.EnvSettings()
+	if jsonOut {
+		entries := make([]configEnvEntry, 0, len(settings))
+		for _, s := range settings {
+			entries = append(entries, configEnvEntry{
+				Name:        s.Name,
+				Description: s.Description,
+				Set:         s.Set,
+				Value:       s.Value,
+				Effective:   s.Effective,
+				Secret:      s.Secret,
+			})
+		}
+		data, _ := json.MarshalIndent(entries, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("\n  %sEnvironment variables%s\n", cli.Bold, cli.Reset)
+	fmt.Printf("  %sSet variables override config files; effective values include both.%s\n\n", cli.Dim, cli.Reset)
+	for _, s := range settings {
+		var state string
+		switch {
+		case s.Set && s.Secret:
+			state = cli.Green + "set" + cli.Reset + cli.Dim + " (hidden)" + cli.Reset
+		case s.Set:
+			state = cli.Green + "set" + cli.Reset + " = " + s.Value
+		default:
+			state = cli.Dim + "not set" + cli.Reset
+		}
+		fmt.Printf("  %-20s %s\n", s.Name, state)
+		if s.Effective != "" && (!s.Set || s.Effective != s.Value) {
+			fmt.Printf("  %-20s %seffective: %s%s\n", "", cli.Dim, s.Effective, cli.Reset)
+		}
+		fmt.Printf("  %-20s %s%s%s\n", "", cli.Dim, s.Description, cli.Reset)
+	}
+	fmt.Println()
+	return nil
+}
+
 func runEditor(editor, path string) error {
 	editor = strings.TrimSpace(editor)
 	if editor == "" {
@@ -242,6 +337,7 @@ func setupSubCmd() *cobra.Command {
 			fmt.Println("    save_decision         Record a decision or insight")
 			fmt.Println("    create_handoff        Create a session handoff note")
 			fmt.Println("    get_session_context   Get current session context")
+			fmt.Println("    surface_context       Pick relevant notes for a prompt")
 			fmt.Println("    recent_activity       View recently modified notes")
 			fmt.Println("    reindex               Re-index the vault")
 			fmt.Println("    index_stats           Index statistics and health")
@@ -257,3 +353,27 @@ func setupSubCmd() *cobra.Command {
 
 	return cmd
 }
+
+// printConfigKeyNotes marks unknown, out-of-range, and deprecated keys found
+// in the config files, since the merged view above silently drops them.
+func printConfigKeyNotes() {
+	if issues := config.ValidateConfig(); len(issues) > 0 {
+		fmt.Printf("#\n# Problems:\n")
+		for _, issue := range issues {
+			fmt.Printf("#   %s\n", issue)
+		}
+	}
+	deprecated := config.DeprecatedConfigKeys()
+	if len(deprecated) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(deprecated))
+	for k := range deprecated {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Printf("#\n# Deprecated keys:\n")
+	for _, k := range keys {
+		fmt.Printf("#   %s (%s)\n", k, deprecated[k])
+	}
+}
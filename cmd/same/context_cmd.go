@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/hooks"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func contextCmd() *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Print the context a new session would start with",
+		Long: `Print the context block the session-bootstrap hook injects when a session
+starts: the previous session's handoff, pinned notes, recent decisions, and
+stale notes, capped at the same budget.
+
+Tools without SAME's hooks can paste this block into a new chat. It is also
+the quickest way to see what the bootstrap hook produces. Nothing is
+recorded while previewing.
+
+Examples:
+  same context
+  same context --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runContext(jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output sections, context, and token estimate as JSON")
+	return cmd
+}
+
+func runContext(jsonOut bool) error {
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	sc := hooks.BuildSessionContext(db)
+
+	if jsonOut {
+		data, _ := json.MarshalIndent(struct {
+			hooks.SessionContext
+			SystemMessage string `json:"system_message,omitempty"`
+		}{sc, sc.SystemMessage()}, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if sc.Context == "" {
+		fmt.Println("No session context: no handoff, pinned notes, recent decisions, or stale notes found.")
+		return nil
+	}
+
+	fmt.Print(sc.SystemMessage())
+	fmt.Printf("\n  %s~%d tokens from %d section(s)%s\n", cli.Dim, sc.Tokens, len(sc.Sections), cli.Reset)
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/hooks"
+)
+
+func TestRunContext_PrintsBootstrapBlock(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "arch.md", "Architecture", "Services talk over gRPC behind the gateway.")
+	if err := db.PinNote("arch.md", ""); err != nil {
+		t.Fatalf("PinNote: %v", err)
+	}
+
+	var runErr error
+	out := captureCommandStdout(t, func() { runErr = runContext(false) })
+	if runErr != nil {
+		t.Fatalf("runContext: %v", runErr)
+	}
+	for _, want := range []string{"<session-bootstrap>", "## Pinned Notes", "Services talk over gRPC", "tokens from"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = captureCommandStdout(t, func() { runErr = runContext(true) })
+	if runErr != nil {
+		t.Fatalf("runContext --json: %v", runErr)
+	}
+	var got hooks.SessionContext
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if got.Tokens == 0 || len(got.Sections) == 0 || got.Sections[0].Name != "pinned" {
+		t.Errorf("unexpected JSON context: %+v", got)
+	}
+}
+
+func TestRunContext_Empty(t *testing.T) {
+	setupCommandTestVault(t)
+
+	out := captureCommandStdout(t, func() {
+		if err := runContext(false); err != nil {
+			t.Fatalf("runContext: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No session context") {
+		t.Errorf("expected empty message, got %q", out)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/memory"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func decisionsCmd() *cobra.Command {
+	var (
+		status  string
+		jsonOut bool
+	)
+	cmd := &cobra.Command{
+		Use:   "decisions",
+		Short: "List recorded decisions, newest first",
+		Long: `Show an ADR-style timeline of decisions: entries in the decision log
+(vault.decision_log, default "decisions.md") plus any other indexed notes
+with content_type: decision.
+
+Auto-extracted decisions are listed as "proposed" until you review them.
+
+Examples:
+  same decisions
+  same decisions --status accepted
+  same decisions --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDecisions(status, jsonOut)
+		},
+	}
+	cmd.Flags().StringVar(&status, "status", "", "Only show decisions with this status (accepted, proposed, superseded)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+// decisionEntry is one row in the decisions timeline.
+type decisionEntry struct {
+	Date          string `json:"date"`
+	Title         string `json:"title"`
+	Status        string `json:"status"`
+	Path          string `json:"path"`
+	Agent         string `json:"agent,omitempty"`
+	AutoExtracted bool   `json:"auto_extracted,omitempty"`
+}
+
+func runDecisions(status string, jsonOut bool) error {
+	status = strings.ToLower(strings.TrimSpace(status))
+	switch status {
+	case "", "accepted", "proposed", "superseded":
+	default:
+		return userError(fmt.Sprintf("Unknown status: %s", status), "Use accepted, proposed, or superseded")
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return config.ErrNoDatabase
+	}
+	defer db.Close()
+
+	entries, err := collectDecisions(db)
+	if err != nil {
+		return err
+	}
+	if status != "" {
+		filtered := entries[:0]
+		for _, e := range entries {
+			if e.Status == status {
+				filtered = append(filtered, e)
+			}
+		}
+		entries = filtered
+	}
+
+	if jsonOut {
+		if entries == nil {
+			entries = []decisionEntry{}
+		}
+		data, _ := json.MarshalIndent(entries, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		if status != "" {
+			fmt.Printf("\n  No %s decisions.\n\n", status)
+		} else {
+			fmt.Println("\n  No decisions recorded yet.")
+			dest := config.DecisionLogPath()
+			if dir := config.DecisionDirectory(); dir != "" {
+				dest = dir + "/"
+			}
+			fmt.Printf("  %sDecisions are saved to %s by the decision extractor hook or save_decision.%s\n\n", cli.Dim, dest, cli.Reset)
+		}
+		return nil
+	}
+
+	fmt.Printf("\n  %d decision(s):\n\n", len(entries))
+	for _, e := range entries {
+		date := e.Date
+		if date == "" {
+			date = "----------"
+		}
+		st := e.Status
+		if st == "" {
+			st = "-"
+		}
+		fmt.Printf("  %s  %s%-10s%s  %s\n", date, decisionStatusColor(e.Status), st, cli.Reset, e.Title)
+		fmt.Printf("  %s            %s%s\n", cli.Dim, e.Path, cli.Reset)
+	}
+	fmt.Println()
+	return nil
+}
+
+// collectDecisions merges decision log entries with other decision notes in
+// the index and returns them newest first.
+func collectDecisions(db *store.DB) ([]decisionEntry, error) {
+	logRel := filepath.ToSlash(filepath.Clean(config.DecisionLogPath()))
+
+	var entries []decisionEntry
+	if logPath, ok := config.SafeVaultSubpath(logRel); ok {
+		if data, err := os.ReadFile(logPath); err == nil {
+			logged := memory.ParseDecisionLog(string(data))
+			// Later entries in the log are newer; walk backwards so the
+			// stable date sort below keeps same-day entries newest first.
+			for i := len(logged) - 1; i >= 0; i-- {
+				d := logged[i]
+				entries = append(entries, decisionEntry{
+					Date:          d.Date,
+					Title:         d.Title,
+					Status:        d.Status,
+					Path:          logRel,
+					Agent:         d.Agent,
+					AutoExtracted: d.AutoExtracted,
+				})
+			}
+		}
+	}
+
+	rows, err := db.Conn().Query(
+		`SELECT path, title, text, modified FROM vault_notes
+		 WHERE content_type = 'decision' AND chunk_id = 0 AND path NOT LIKE '_PRIVATE/%'
+		 ORDER BY modified DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query decisions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var path, title, text string
+		var modified float64
+		if err := rows.Scan(&path, &title, &text, &modified); err != nil {
+			continue
+		}
+		if path == logRel {
+			continue
+		}
+		entries = append(entries, decisionEntry{
+			Date:   time.Unix(int64(modified), 0).Format("2006-01-02"),
+			Title:  title,
+			Status: decisionNoteStatus(text),
+			Path:   path,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query decisions: %w", err)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Date > entries[j].Date })
+	return entries, nil
+}
+
+// decisionNoteStatus reads a "Status:" line from a standalone decision note
+// (ADR style, optionally bolded). Returns "" when the note has none.
+func decisionNoteStatus(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.ReplaceAll(line, "*", ""))
+		if len(line) > len("status:") && strings.EqualFold(line[:len("status:")], "status:") {
+			return strings.ToLower(strings.TrimSpace(line[len("status:"):]))
+		}
+	}
+	return ""
+}
+
+func decisionStatusColor(status string) string {
+	switch status {
+	case "accepted":
+		return cli.Green
+	case "proposed":
+		return cli.Yellow
+	case "superseded":
+		return cli.Dim
+	}
+	return ""
+}
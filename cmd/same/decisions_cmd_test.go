@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func TestCollectDecisions_MergesLogAndNotesNewestFirst(t *testing.T) {
+	vault, db := setupCommandTestVault(t)
+
+	log := "# Decisions & Conclusions\n" +
+		"\n## Decision: Use SQLite\n**Date:** 2026-01-10\n**Status:** Accepted\n\nEmbedded and simple.\n" +
+		"\n## Decision: Drop GraphQL\n**Date:** 2026-01-10\n**Status:** Superseded\n\nREST instead.\n" +
+		"\n### 2026-01-02\n- go with cobra for the CLI\n  - *confidence: high, auto-extracted*\n"
+	if err := os.WriteFile(filepath.Join(vault, "decisions.md"), []byte(log), 0o600); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+
+	modified := time.Date(2026, 1, 5, 12, 0, 0, 0, time.Local)
+	recs := []store.NoteRecord{
+		{Path: "decisions.md", Title: "Decisions", ContentType: "decision"},
+		{Path: "adr/0001-auth.md", Title: "ADR 1: JWT auth", ContentType: "decision", Text: "**Status:** Proposed\n\nUse JWT."},
+	}
+	for i := range recs {
+		recs[i].Tags = "[]"
+		recs[i].ChunkHeading = "(full)"
+		recs[i].ContentHash = recs[i].Path
+		recs[i].Modified = float64(modified.Unix())
+	}
+	if _, err := db.BulkInsertNotesLite(recs); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+
+	entries, err := collectDecisions(db)
+	if err != nil {
+		t.Fatalf("collectDecisions: %v", err)
+	}
+	var titles []string
+	for _, e := range entries {
+		titles = append(titles, e.Title)
+	}
+	want := []string{"Drop GraphQL", "Use SQLite", "ADR 1: JWT auth", "go with cobra for the CLI"}
+	if len(titles) != len(want) {
+		t.Fatalf("titles = %v, want %v", titles, want)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("titles = %v, want %v", titles, want)
+		}
+	}
+	if entries[2].Status != "proposed" || entries[2].Path != "adr/0001-auth.md" {
+		t.Errorf("ADR entry = %+v", entries[2])
+	}
+	if !entries[3].AutoExtracted || entries[3].Status != "proposed" {
+		t.Errorf("auto-extracted entry = %+v", entries[3])
+	}
+}
+
+func TestRunDecisions_StatusFilterJSON(t *testing.T) {
+	vault, _ := setupCommandTestVault(t)
+	log := "\n## Decision: Use SQLite\n**Date:** 2026-01-10\n**Status:** Accepted\n" +
+		"\n## Decision: Drop GraphQL\n**Date:** 2026-01-11\n**Status:** Superseded\n"
+	if err := os.WriteFile(filepath.Join(vault, "decisions.md"), []byte(log), 0o600); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+
+	out := captureCommandStdout(t, func() {
+		if err := runDecisions("accepted", true); err != nil {
+			t.Fatalf("runDecisions: %v", err)
+		}
+	})
+	var got []decisionEntry
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("parse JSON: %v\n%s", err, out)
+	}
+	if len(got) != 1 || got[0].Title != "Use SQLite" {
+		t.Errorf("got %+v, want only Use SQLite", got)
+	}
+
+	if err := runDecisions("maybe", false); err == nil {
+		t.Error("expected error for unknown status")
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+// dedupeNeighbors is how many nearest neighbors are compared per note.
+const dedupeNeighbors = 5
+
+func dedupeCmd() *cobra.Command {
+	var (
+		threshold   float64
+		limit       int
+		jsonOut     bool
+		interactive bool
+	)
+	cmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "Find near-duplicate notes",
+		Long: `Compare every note's embedding with its nearest neighbors and list the
+pairs that are nearly identical: copied templates, versioned handoffs,
+notes saved twice. Each pair shows the note to keep (higher confidence,
+then newer, then more accessed) and the weaker one.
+
+With --interactive, step through the pairs and archive the weaker note
+with 'a', or merge it into the one to keep with 'm' (see 'same merge').
+Archived notes stay on disk and in the index but are no longer surfaced;
+'same unarchive' undoes it.
+
+Needs embeddings; keyword-only vaults have nothing to compare.
+
+Examples:
+  same dedupe
+  same dedupe --threshold 0.9 --limit 50
+  same dedupe --interactive
+  same dedupe --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if threshold <= 0 || threshold > 1 {
+				return userError("--threshold must be between 0 and 1", "Try --threshold 0.9 for looser matches")
+			}
+			if limit < 1 {
+				return userError("--limit must be at least 1", "")
+			}
+			if interactive && jsonOut {
+				return userError("--interactive and --json can't be combined", "")
+			}
+			return runDedupe(threshold, limit, jsonOut, interactive, os.Stdin)
+		},
+	}
+	cmd.Flags().Float64Var(&threshold, "threshold", 0.95, "Minimum cosine similarity to count as a duplicate (0-1)")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum number of pairs to show")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Review each pair and optionally archive or merge the weaker note")
+	return cmd
+}
+
+func runDedupe(threshold float64, limit int, jsonOut, interactive bool, in io.Reader) error {
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	if !db.HasVectors() {
+		return userError("No embeddings in the index to compare",
+			"Dedupe needs embeddings: configure SAME_EMBED_PROVIDER (or rerun 'same init') and run 'same reindex'")
+	}
+
+	pairs, err := db.FindDuplicates(threshold, dedupeNeighbors)
+	if err != nil {
+		return fmt.Errorf("find duplicates: %w", err)
+	}
+	total := len(pairs)
+	if len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+
+	if jsonOut {
+		if pairs == nil {
+			pairs = []store.DuplicatePair{}
+		}
+		data, _ := json.MarshalIndent(pairs, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(pairs) == 0 {
+		fmt.Printf("\n  %sNo near-duplicate notes found (similarity ≥ %.2f).%s\n\n", cli.Green, threshold, cli.Reset)
+		return nil
+	}
+
+	fmt.Printf("\n  %s%d near-duplicate pair(s)%s", cli.Yellow, total, cli.Reset)
+	if total > len(pairs) {
+		fmt.Printf(", showing %d", len(pairs))
+	}
+	fmt.Printf(" %s(similarity ≥ %.2f)%s\n", cli.Dim, threshold, cli.Reset)
+
+	reader := bufio.NewReader(in)
+	archived := make(map[string]bool)
+	for i, p := range pairs {
+		printDuplicatePair(i+1, p)
+		if !interactive {
+			continue
+		}
+		if archived[p.Keep.Path] || archived[p.Drop.Path] {
+			fmt.Printf("   %sskipped: one of these notes was already archived%s\n", cli.Dim, cli.Reset)
+			continue
+		}
+		fmt.Printf("   %s? [a]rchive / [m]erge into %s / [s]kip / [q]uit: ", p.Drop.Path, p.Keep.Path)
+		answer, _ := reader.ReadString('\n')
+		switch strings.TrimSpace(strings.ToLower(answer)) {
+		case "a", "archive":
+			if _, err := db.ArchiveNote(p.Drop.Path); err != nil {
+				return fmt.Errorf("archive %s: %w", p.Drop.Path, err)
+			}
+			archived[p.Drop.Path] = true
+			fmt.Printf("   %s✓%s Archived %s\n", cli.Green, cli.Reset, p.Drop.Path)
+		case "m", "merge":
+			plan, err := planMerge(db, p.Drop.Path, p.Keep.Path)
+			if err != nil {
+				fmt.Printf("   %sCan't merge: %v%s\n", cli.Yellow, err, cli.Reset)
+				continue
+			}
+			plan.print()
+			fmt.Printf("\n   Apply? [y/N] ")
+			confirm, _ := reader.ReadString('\n')
+			if c := strings.TrimSpace(strings.ToLower(confirm)); c != "y" && c != "yes" {
+				continue
+			}
+			if err := plan.apply(db); err != nil {
+				return err
+			}
+			archived[p.Drop.Path] = true
+			fmt.Printf("   %s✓%s Merged %s into %s\n", cli.Green, cli.Reset, p.Drop.Path, p.Keep.Path)
+		case "q", "quit":
+			fmt.Println()
+			return nil
+		}
+	}
+
+	fmt.Println()
+	if interactive {
+		if len(archived) > 0 {
+			fmt.Printf("  %sArchived or merged %d note(s). Undo the archive with: same unarchive <path>%s\n\n", cli.Dim, len(archived), cli.Reset)
+		}
+		return nil
+	}
+	fmt.Printf("  %sReview them with: same dedupe --interactive, or fold one into the other with: same merge <drop> <keep>%s\n\n", cli.Dim, cli.Reset)
+	return nil
+}
+
+func printDuplicatePair(n int, p store.DuplicatePair) {
+	tag := ""
+	if p.Versioned {
+		tag = fmt.Sprintf(" %s(versioned copy)%s", cli.Dim, cli.Reset)
+	}
+	fmt.Printf("\n%d. %.3f similar%s\n", n, p.Similarity, tag)
+	fmt.Printf("   %skeep%s  %s %s(confidence %.2f)%s\n", cli.Green, cli.Reset, p.Keep.Path, cli.Dim, p.Keep.Confidence, cli.Reset)
+	fmt.Printf("   %sdrop%s  %s %s(confidence %.2f)%s\n", cli.Yellow, cli.Reset, p.Drop.Path, cli.Dim, p.Drop.Confidence, cli.Reset)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func TestRunDedupe_ListsAndArchivesWeakerNote(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	vec := func(x, y float32) []float32 {
+		v := make([]float32, 768)
+		v[0], v[1] = x, y
+		return v
+	}
+	for _, n := range []struct {
+		path       string
+		confidence float64
+		vec        []float32
+	}{
+		{"handoffs/sprint.md", 0.4, vec(1, 0)},
+		{"handoffs/sprint-v2.md", 0.8, vec(0.99, 0.05)},
+		{"notes/other.md", 0.5, vec(0, 1)},
+	} {
+		rec := &store.NoteRecord{
+			Path: n.path, Title: n.path, Tags: "[]", ChunkHeading: "(full)", Text: "sprint handoff",
+			Modified: 1700000000, ContentType: "handoff", Confidence: n.confidence,
+		}
+		if err := db.InsertNote(rec, n.vec); err != nil {
+			t.Fatalf("InsertNote %s: %v", n.path, err)
+		}
+	}
+	_ = db.Close()
+
+	out := captureCommandStdout(t, func() {
+		if err := runDedupe(0.95, 20, true, false, strings.NewReader("")); err != nil {
+			t.Fatalf("dedupe --json: %v", err)
+		}
+	})
+	var pairs []store.DuplicatePair
+	if err := json.Unmarshal([]byte(out), &pairs); err != nil {
+		t.Fatalf("parse JSON: %v\n%s", err, out)
+	}
+	if len(pairs) != 1 || pairs[0].Drop.Path != "handoffs/sprint.md" || !pairs[0].Versioned {
+		t.Fatalf("unexpected pairs: %+v", pairs)
+	}
+
+	out = captureCommandStdout(t, func() {
+		if err := runDedupe(0.95, 20, false, true, strings.NewReader("a\n")); err != nil {
+			t.Fatalf("dedupe --interactive: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Archived handoffs/sprint.md") {
+		t.Errorf("expected archive confirmation, got:\n%s", out)
+	}
+
+	out = captureCommandStdout(t, func() {
+		if err := runDedupe(0.95, 20, false, false, strings.NewReader("")); err != nil {
+			t.Fatalf("dedupe: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No near-duplicate notes found") {
+		t.Errorf("expected no pairs after archiving, got:\n%s", out)
+	}
+}
+
+func TestRunDedupe_RequiresEmbeddings(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/a.md", "A", "alpha")
+	_ = db.Close()
+
+	err := runDedupe(0.95, 20, false, false, strings.NewReader(""))
+	if err == nil || !strings.Contains(err.Error(), "No embeddings") {
+		t.Fatalf("expected no-embeddings error, got %v", err)
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+// diffListLimit caps how many paths are printed per change category.
+const diffListLimit = 10
+
+// diffConfidenceEpsilon ignores confidence moves too small to matter.
+const diffConfidenceEpsilon = 0.01
+
+func diffCmd() *cobra.Command {
+	var (
+		jsonOut bool
+		noSave  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show what changed in the index since the last snapshot",
+		Long: `Compare the index with the last saved snapshot and report which notes
+were added, removed, changed, re-embedded, or had their confidence move.
+
+'same reindex' saves a snapshot before it runs, so 'same diff' right after
+a reindex shows what that reindex did. Each 'same diff' also saves the
+current state as the new snapshot (skip with --no-save). The first run
+just records a baseline.
+
+Examples:
+  same reindex && same diff
+  same diff --no-save
+  same diff --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(jsonOut, !noSave)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output the diff as JSON")
+	cmd.Flags().BoolVar(&noSave, "no-save", false, "Compare without replacing the saved snapshot")
+	return cmd
+}
+
+// indexSnapshot is the per-note index state saved between 'same diff' runs.
+type indexSnapshot struct {
+	TakenAt int64             `json:"taken_at"`
+	Model   string            `json:"embed_model,omitempty"`
+	Chunks  int               `json:"chunks"`
+	Notes   []store.NoteState `json:"notes"`
+}
+
+// confidenceChange is a note whose confidence moved between snapshots.
+type confidenceChange struct {
+	Path   string  `json:"path"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+}
+
+// indexDiff summarizes the differences between two snapshots.
+type indexDiff struct {
+	Since        int64              `json:"since"`
+	NotesBefore  int                `json:"notes_before"`
+	NotesAfter   int                `json:"notes_after"`
+	ChunksBefore int                `json:"chunks_before"`
+	ChunksAfter  int                `json:"chunks_after"`
+	ModelBefore  string             `json:"embed_model_before,omitempty"`
+	ModelAfter   string             `json:"embed_model_after,omitempty"`
+	Added        []string           `json:"added"`
+	Removed      []string           `json:"removed"`
+	Changed      []string           `json:"changed"`
+	Reembedded   []string           `json:"reembedded"`
+	Confidence   []confidenceChange `json:"confidence_changed"`
+}
+
+func (d indexDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 &&
+		len(d.Reembedded) == 0 && len(d.Confidence) == 0 && d.ChunksBefore == d.ChunksAfter
+}
+
+func indexSnapshotPath() string {
+	return filepath.Join(config.DataDir(), "index-snapshot.json")
+}
+
+// takeIndexSnapshot records the current state of every indexed note.
+func takeIndexSnapshot(db *store.DB) (*indexSnapshot, error) {
+	states, err := db.NoteStates()
+	if err != nil {
+		return nil, fmt.Errorf("read index state: %w", err)
+	}
+	snap := &indexSnapshot{TakenAt: time.Now().Unix(), Notes: states}
+	snap.Model, _ = db.GetMeta("embed_model")
+	for _, s := range states {
+		snap.Chunks += s.Chunks
+	}
+	return snap, nil
+}
+
+// loadIndexSnapshot returns the saved snapshot, or nil if there is none.
+func loadIndexSnapshot() (*indexSnapshot, error) {
+	data, err := os.ReadFile(indexSnapshotPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	var snap indexSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parse snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+func (s *indexSnapshot) save() error {
+	path := indexSnapshotPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// saveIndexSnapshot snapshots the index so a later 'same diff' can compare
+// against it. Called by reindex before it changes anything.
+func saveIndexSnapshot(db *store.DB) error {
+	snap, err := takeIndexSnapshot(db)
+	if err != nil {
+		return err
+	}
+	return snap.save()
+}
+
+// diffIndexSnapshots compares two snapshots. A note whose content hash
+// changed is "changed"; one with the same content whose rows were rewritten
+// (new chunking or embedding model) is "re-embedded".
+func diffIndexSnapshots(before, after *indexSnapshot) indexDiff {
+	d := indexDiff{
+		Since:        before.TakenAt,
+		NotesBefore:  len(before.Notes),
+		NotesAfter:   len(after.Notes),
+		ChunksBefore: before.Chunks,
+		ChunksAfter:  after.Chunks,
+		ModelBefore:  before.Model,
+		ModelAfter:   after.Model,
+	}
+	old := make(map[string]store.NoteState, len(before.Notes))
+	for _, s := range before.Notes {
+		old[s.Path] = s
+	}
+	for _, cur := range after.Notes {
+		prev, ok := old[cur.Path]
+		if !ok {
+			d.Added = append(d.Added, cur.Path)
+			continue
+		}
+		delete(old, cur.Path)
+		switch {
+		case prev.ContentHash != cur.ContentHash:
+			d.Changed = append(d.Changed, cur.Path)
+		case prev.RowID != cur.RowID || prev.Chunks != cur.Chunks:
+			d.Reembedded = append(d.Reembedded, cur.Path)
+		}
+		if math.Abs(cur.Confidence-prev.Confidence) >= diffConfidenceEpsilon {
+			d.Confidence = append(d.Confidence, confidenceChange{Path: cur.Path, Before: prev.Confidence, After: cur.Confidence})
+		}
+	}
+	// Snapshots are ordered by path, so walking before.Notes keeps Removed sorted.
+	for _, s := range before.Notes {
+		if _, gone := old[s.Path]; gone {
+			d.Removed = append(d.Removed, s.Path)
+		}
+	}
+	return d
+}
+
+func runDiff(jsonOut, save bool) error {
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	before, err := loadIndexSnapshot()
+	if err != nil {
+		return userError(fmt.Sprintf("Can't read the saved snapshot: %v", err), "Delete "+indexSnapshotPath()+" to start a new baseline")
+	}
+	after, err := takeIndexSnapshot(db)
+	if err != nil {
+		return err
+	}
+	if save {
+		if err := after.save(); err != nil {
+			return fmt.Errorf("save snapshot: %w", err)
+		}
+	}
+
+	if before == nil {
+		if jsonOut {
+			fmt.Println("null")
+			return nil
+		}
+		fmt.Printf("\n  No previous snapshot. Recorded the current index (%d notes) as the baseline.\n", len(after.Notes))
+		fmt.Printf("  %sRun 'same diff' again after your next reindex to see what changed.%s\n\n", cli.Dim, cli.Reset)
+		return nil
+	}
+
+	d := diffIndexSnapshots(before, after)
+	if jsonOut {
+		data, _ := json.MarshalIndent(d, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	since := time.Unix(d.Since, 0)
+	fmt.Printf("\n  %sIndex changes since %s%s %s(%s ago)%s\n\n", cli.Bold, since.Format("2006-01-02 15:04"), cli.Reset,
+		cli.Dim, formatDuration(time.Since(since)), cli.Reset)
+	fmt.Printf("    Notes   %d → %d (%+d)\n", d.NotesBefore, d.NotesAfter, d.NotesAfter-d.NotesBefore)
+	fmt.Printf("    Chunks  %d → %d (%+d)\n", d.ChunksBefore, d.ChunksAfter, d.ChunksAfter-d.ChunksBefore)
+	if d.ModelBefore != d.ModelAfter {
+		fmt.Printf("    Model   %s → %s\n", d.ModelBefore, d.ModelAfter)
+	}
+
+	if d.empty() {
+		fmt.Printf("\n  %sNo notes changed.%s\n\n", cli.Green, cli.Reset)
+		return nil
+	}
+
+	printDiffPaths(cli.Green+"+"+cli.Reset, "added", d.Added)
+	printDiffPaths(cli.Red+"-"+cli.Reset, "removed", d.Removed)
+	printDiffPaths(cli.Yellow+"~"+cli.Reset, "content changed", d.Changed)
+	printDiffPaths(cli.Cyan+"↻"+cli.Reset, "re-embedded (content unchanged)", d.Reembedded)
+	if len(d.Confidence) > 0 {
+		fmt.Printf("\n  ± %d confidence changed\n", len(d.Confidence))
+		for i, c := range d.Confidence {
+			if i == diffListLimit {
+				fmt.Printf("      %s… and %d more%s\n", cli.Dim, len(d.Confidence)-diffListLimit, cli.Reset)
+				break
+			}
+			fmt.Printf("      %s %s%.2f → %.2f%s\n", c.Path, cli.Dim, c.Before, c.After, cli.Reset)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func printDiffPaths(marker, label string, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	fmt.Printf("\n  %s %d %s\n", marker, len(paths), label)
+	for i, p := range paths {
+		if i == diffListLimit {
+			fmt.Printf("      %s… and %d more%s\n", cli.Dim, len(paths)-diffListLimit, cli.Reset)
+			break
+		}
+		fmt.Printf("      %s\n", p)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func TestDiffIndexSnapshots(t *testing.T) {
+	before := &indexSnapshot{TakenAt: 100, Model: "a", Chunks: 6, Notes: []store.NoteState{
+		{Path: "changed.md", ContentHash: "h1", Confidence: 0.5, Chunks: 2, RowID: 1},
+		{Path: "gone.md", ContentHash: "h2", Confidence: 0.5, Chunks: 1, RowID: 3},
+		{Path: "reembedded.md", ContentHash: "h3", Confidence: 0.5, Chunks: 2, RowID: 4},
+		{Path: "same.md", ContentHash: "h4", Confidence: 0.5, Chunks: 1, RowID: 6},
+	}}
+	after := &indexSnapshot{TakenAt: 200, Model: "b", Chunks: 7, Notes: []store.NoteState{
+		{Path: "changed.md", ContentHash: "h1b", Confidence: 0.5, Chunks: 2, RowID: 7},
+		{Path: "new.md", ContentHash: "h5", Confidence: 0.5, Chunks: 1, RowID: 9},
+		{Path: "reembedded.md", ContentHash: "h3", Confidence: 0.5, Chunks: 3, RowID: 10},
+		{Path: "same.md", ContentHash: "h4", Confidence: 0.7, Chunks: 1, RowID: 6},
+	}}
+
+	got := diffIndexSnapshots(before, after)
+	want := indexDiff{
+		Since: 100, NotesBefore: 4, NotesAfter: 4, ChunksBefore: 6, ChunksAfter: 7,
+		ModelBefore: "a", ModelAfter: "b",
+		Added:      []string{"new.md"},
+		Removed:    []string{"gone.md"},
+		Changed:    []string{"changed.md"},
+		Reembedded: []string{"reembedded.md"},
+		Confidence: []confidenceChange{{Path: "same.md", Before: 0.5, After: 0.7}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffIndexSnapshots =\n%+v\nwant\n%+v", got, want)
+	}
+	if d := diffIndexSnapshots(after, after); !d.empty() {
+		t.Errorf("diff of a snapshot with itself should be empty, got %+v", d)
+	}
+}
+
+func TestRunDiff_BaselineThenChanges(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/a.md", "A", "First note.")
+
+	out := captureCommandStdout(t, func() {
+		if err := runDiff(false, true); err != nil {
+			t.Fatalf("runDiff baseline: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No previous snapshot") {
+		t.Errorf("first run should record a baseline, got %q", out)
+	}
+
+	insertCommandTestNote(t, db, "notes/b.md", "B", "Second note.")
+	out = captureCommandStdout(t, func() {
+		if err := runDiff(false, false); err != nil {
+			t.Fatalf("runDiff: %v", err)
+		}
+	})
+	if !strings.Contains(out, "1 added") || !strings.Contains(out, "notes/b.md") {
+		t.Errorf("expected notes/b.md to be reported as added, got %q", out)
+	}
+
+	// --no-save left the baseline alone, so the same change shows again.
+	out = captureCommandStdout(t, func() {
+		if err := runDiff(false, true); err != nil {
+			t.Fatalf("runDiff: %v", err)
+		}
+	})
+	if !strings.Contains(out, "notes/b.md") {
+		t.Errorf("--no-save should keep the old baseline, got %q", out)
+	}
+	out = captureCommandStdout(t, func() {
+		if err := runDiff(false, true); err != nil {
+			t.Fatalf("runDiff: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No notes changed") {
+		t.Errorf("expected no changes after saving, got %q", out)
+	}
+}
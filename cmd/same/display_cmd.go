@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -101,6 +102,11 @@ Profiles:
   broad     More results, lower threshold (uses ~2x more tokens)
   pi        Raspberry Pi / low-resource optimization
 
+Custom profiles can be saved to config and used like the builtins:
+  same profile create myteam --max-results 8 --distance 15.5 --composite 0.6
+  same profile use myteam
+  same profile delete myteam
+
 Example: same profile use precise`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return showCurrentProfile()
@@ -109,7 +115,7 @@ Example: same profile use precise`,
 
 	useCmd := &cobra.Command{
 		Use:   "use [profile]",
-		Short: "Switch to a profile (precise, balanced, broad, pi)",
+		Short: "Switch to a profile (precise, balanced, broad, pi, or a custom profile)",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return setProfile(args[0])
@@ -117,6 +123,53 @@ Example: same profile use precise`,
 	}
 	cmd.AddCommand(useCmd)
 
+	var (
+		maxResults  int
+		distance    float64
+		composite   float64
+		tokenBudget int
+		description string
+	)
+	createCmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Save a custom profile to config",
+		Long: `Save a named profile with your own recall/precision tradeoff.
+
+Unset flags default to the 'balanced' profile's values. Creating a profile
+with an existing custom name replaces it. The profile is not activated
+until you run 'same profile use <name>'.
+
+Example: same profile create myteam --max-results 8 --distance 15.5 --composite 0.6 --max-token-budget 1200`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return createProfile(config.Profile{
+				Name:               args[0],
+				Description:        description,
+				MaxResults:         maxResults,
+				DistanceThreshold:  distance,
+				CompositeThreshold: composite,
+				MaxTokenBudget:     tokenBudget,
+			})
+		},
+	}
+	balanced := config.BuiltinProfiles["balanced"]
+	createCmd.Flags().IntVar(&maxResults, "max-results", balanced.MaxResults, "Maximum notes surfaced per prompt")
+	createCmd.Flags().Float64Var(&distance, "distance", balanced.DistanceThreshold, "Maximum vector distance for a match")
+	createCmd.Flags().Float64Var(&composite, "composite", balanced.CompositeThreshold, "Minimum composite score (0-1)")
+	createCmd.Flags().IntVar(&tokenBudget, "max-token-budget", balanced.MaxTokenBudget, fmt.Sprintf("Tokens of notes surfaced per prompt (%d-%d)", config.MinSurfacingTokenBudget, config.MaxSurfacingTokenBudget))
+	createCmd.Flags().StringVar(&description, "description", "", "Short description shown in 'same profile'")
+	cmd.AddCommand(createCmd)
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Remove a custom profile from config",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deleteProfile(args[0])
+		},
+	}
+	cmd.AddCommand(deleteCmd)
+
 	return cmd
 }
 
@@ -126,8 +179,10 @@ func showCurrentProfile() error {
 	cli.Header("SAME Profile")
 	fmt.Println()
 
-	for _, name := range []string{"precise", "balanced", "broad", "pi"} {
-		p := config.BuiltinProfiles[name]
+	all := config.AllProfiles()
+	names := append(append([]string{}, config.BuiltinProfileNames...), config.UserProfileNames()...)
+	for _, name := range names {
+		p := all[name]
 		marker := "  "
 		if name == current {
 			marker = fmt.Sprintf("%s→%s ", cli.Cyan, cli.Reset)
@@ -158,11 +213,12 @@ func setProfile(profileName string) error {
 		return config.ErrNoVault
 	}
 
-	profile, ok := config.BuiltinProfiles[profileName]
+	profile, ok := config.AllProfiles()[profileName]
 	if !ok {
+		available := append(append([]string{}, config.BuiltinProfileNames...), config.UserProfileNames()...)
 		return userError(
 			fmt.Sprintf("Unknown profile: %s", profileName),
-			"Available: precise, balanced, broad, pi",
+			"Available: "+strings.Join(available, ", "),
 		)
 	}
 
@@ -197,8 +253,58 @@ func setProfile(profileName string) error {
 	fmt.Printf("    max_results:         %d\n", profile.MaxResults)
 	fmt.Printf("    distance_threshold:  %.1f\n", profile.DistanceThreshold)
 	fmt.Printf("    composite_threshold: %.2f\n", profile.CompositeThreshold)
+	fmt.Printf("    max_token_budget:    %d\n", profileTokenBudget(profile))
 	fmt.Println()
 	fmt.Println("  Change takes effect on next prompt.")
 
 	return nil
 }
+
+func createProfile(p config.Profile) error {
+	vp := config.VaultPath()
+	if vp == "" {
+		return config.ErrNoVault
+	}
+
+	if err := config.CreateProfile(vp, p); err != nil {
+		return userError(err.Error(), "run 'same profile create --help' for valid values")
+	}
+
+	fmt.Printf("\n  %s✓%s Saved profile: %s%s%s\n", cli.Green, cli.Reset, cli.Bold, p.Name, cli.Reset)
+	fmt.Println()
+	fmt.Printf("    max_results:         %d\n", p.MaxResults)
+	fmt.Printf("    distance_threshold:  %.1f\n", p.DistanceThreshold)
+	fmt.Printf("    composite_threshold: %.2f\n", p.CompositeThreshold)
+	fmt.Printf("    max_token_budget:    %d\n", profileTokenBudget(p))
+	fmt.Println()
+	fmt.Printf("  Activate with: %ssame profile use %s%s\n", cli.Bold, p.Name, cli.Reset)
+
+	return nil
+}
+
+// profileTokenBudget is the surfacing budget a profile applies.
+func profileTokenBudget(p config.Profile) int {
+	if p.MaxTokenBudget > 0 {
+		return p.MaxTokenBudget
+	}
+	return config.DefaultSurfacingTokenBudget
+}
+
+func deleteProfile(name string) error {
+	vp := config.VaultPath()
+	if vp == "" {
+		return config.ErrNoVault
+	}
+
+	active := config.CurrentProfile() == name
+	if err := config.DeleteProfile(vp, name); err != nil {
+		return userError(err.Error(), "run 'same profile' to see custom profiles")
+	}
+
+	fmt.Printf("\n  %s✓%s Deleted profile: %s\n", cli.Green, cli.Reset, name)
+	if active {
+		fmt.Printf("  %sCurrent settings are unchanged and now show as 'custom'.%s\n", cli.Dim, cli.Reset)
+	}
+
+	return nil
+}
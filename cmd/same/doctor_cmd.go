@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,31 +16,54 @@ import (
 
 	"github.com/sgx-labs/statelessagent/internal/cli"
 	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/hooks"
+	"github.com/sgx-labs/statelessagent/internal/indexer"
 	"github.com/sgx-labs/statelessagent/internal/llm"
 	"github.com/sgx-labs/statelessagent/internal/setup"
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func doctorCmd() *cobra.Command {
-	var jsonOut bool
+	var opts doctorOptions
 	cmd := &cobra.Command{
 		Use:   "doctor",
 		Short: "Check system health and diagnose issues",
-		Long:  "Run comprehensive diagnostic checks on your SAME installation. Checks vault path, database, embedding provider, search health, MCP integration, hooks, and index freshness. Use --json for machine-readable output.",
+		Long: `Run comprehensive diagnostic checks on your SAME installation. Checks vault path, database, embedding provider, search health, MCP integration, hooks, and index freshness. Use --json for machine-readable output; the command exits non-zero when any check fails.
+
+With --fix, failed checks that have a safe remediation are repaired and
+re-checked: orphaned vectors are pruned, a stale index is reindexed, and
+missing or non-portable hooks and MCP config are reinstalled. Each fix asks
+for confirmation unless --yes is also passed.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDoctor(jsonOut)
+			if opts.JSON && opts.Fix && !opts.Yes {
+				return userError("--fix with --json requires --yes", "run: same doctor --fix --yes --json")
+			}
+			return runDoctor(opts)
 		},
 	}
-	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&opts.Fix, "fix", false, "Apply safe fixes for failed checks")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Apply fixes without prompting (with --fix)")
 	return cmd
 }
 
+type doctorOptions struct {
+	JSON bool
+	Fix  bool
+	Yes  bool
+}
+
 // DoctorResult represents a single health check result
 type DoctorResult struct {
 	Name    string `json:"name"`
 	Status  string `json:"status"` // "pass", "skip", "fail"
 	Message string `json:"message,omitempty"`
 	Hint    string `json:"hint,omitempty"`
+	Fixed   bool   `json:"fixed,omitempty"` // set when --fix repaired this check
+
+	rawErr string                 // unsanitized error for terminal output
+	note   string                 // extra human-only text printed after the check
+	run    func() (string, error) // the check itself, re-run after a fix
 }
 
 // DoctorReport represents the complete health check report
@@ -98,10 +124,8 @@ func checkBinaryShadowing() (string, error) {
 	return result, nil
 }
 
-func runDoctor(jsonOut bool) error {
-	passed := 0
-	failed := 0
-	skipped := 0
+func runDoctor(opts doctorOptions) error {
+	jsonOut := opts.JSON
 	var results []DoctorResult
 
 	// Probe embedding provider once up front so semantic checks can skip gracefully.
@@ -132,62 +156,36 @@ func runDoctor(jsonOut bool) error {
 	// instead of cascading into confusing "permission denied" errors.
 	vaultOK := false
 
+	// Checks only record results; rendering happens once at the end so the
+	// same slice backs both the human and JSON output.
 	check := func(name string, hint string, fn func() (string, error)) {
 		detail, err := fn()
 		if err != nil {
-			if jsonOut {
-				results = append(results, DoctorResult{
-					Name:    name,
-					Status:  "fail",
-					Message: sanitizeErrorForJSON(err),
-					Hint:    hint,
-				})
-			} else {
-				fmt.Printf("  %s✗%s %s: %s\n",
-					cli.Red, cli.Reset, name, err)
-				if hint != "" {
-					fmt.Printf("    → %s\n", hint)
-				}
-			}
-			failed++
-		} else {
-			if jsonOut {
-				results = append(results, DoctorResult{
-					Name:    name,
-					Status:  "pass",
-					Message: detail,
-				})
-			} else {
-				if detail != "" {
-					fmt.Printf("  %s✓%s %s (%s)\n",
-						cli.Green, cli.Reset, name, detail)
-				} else {
-					fmt.Printf("  %s✓%s %s\n",
-						cli.Green, cli.Reset, name)
-				}
-			}
-			passed++
-		}
-	}
-
-	// skip marks a check as skipped (keyword-only mode) instead of failed.
-	skip := func(name string, reason string) {
-		if jsonOut {
 			results = append(results, DoctorResult{
 				Name:    name,
-				Status:  "skip",
-				Message: reason,
+				Status:  "fail",
+				Message: sanitizeErrorForJSON(err),
+				Hint:    hint,
+				rawErr:  err.Error(),
+				run:     fn,
 			})
-		} else {
-			fmt.Printf("  %s-%s %s: %s\n",
-				cli.Dim, cli.Reset, name, reason)
+			return
 		}
-		skipped++
+		results = append(results, DoctorResult{
+			Name:    name,
+			Status:  "pass",
+			Message: detail,
+			run:     fn,
+		})
 	}
 
-	if !jsonOut {
-		cli.Header("SAME Health Check")
-		fmt.Println()
+	// skip marks a check as skipped (keyword-only mode) instead of failed.
+	skip := func(name string, reason string) {
+		results = append(results, DoctorResult{
+			Name:    name,
+			Status:  "skip",
+			Message: reason,
+		})
 	}
 
 	// 0. Binary shadowing
@@ -240,7 +238,7 @@ func runDoctor(jsonOut bool) error {
 		})
 
 		// 2b. Index mode
-		check("Index mode", "run 'same reindex' with an embedding provider for semantic search", func() (string, error) {
+		check("Index mode", "run 'same upgrade' once an embedding provider is reachable", func() (string, error) {
 			db, err := store.Open()
 			if err != nil {
 				return "", fmt.Errorf("cannot open database")
@@ -267,7 +265,7 @@ func runDoctor(jsonOut bool) error {
 			}
 			noteCount, _ := db.NoteCount()
 			if noteCount > 0 {
-				msg := "keyword-only (configure SAME_EMBED_PROVIDER + run 'same reindex' to upgrade"
+				msg := "keyword-only (configure an embedding provider + run 'same upgrade'"
 				if graphNodes == 0 {
 					msg += "; graph empty — run 'same graph rebuild' after reindex"
 				}
@@ -288,16 +286,14 @@ func runDoctor(jsonOut bool) error {
 			defer db.Close()
 			if !db.HasVectors() {
 				noteCount, _ := db.NoteCount()
-				if noteCount > 0 {
-					if !jsonOut {
-						ec := config.EmbeddingProviderConfig()
-						provider := ec.Provider
-						if provider == "" {
-							provider = "ollama"
-						}
-						fmt.Printf("\n  %s⚡ %s provider is reachable but your index is keyword-only.%s\n", cli.Bold, provider, cli.Reset)
-						fmt.Printf("  %s   Run 'same reindex' to enable semantic search.%s\n\n", cli.Dim, cli.Reset)
+				if noteCount > 0 && len(results) > 0 {
+					ec := config.EmbeddingProviderConfig()
+					provider := ec.Provider
+					if provider == "" {
+						provider = "ollama"
 					}
+					results[len(results)-1].note = fmt.Sprintf("\n  %s⚡ %s provider is reachable but your index is keyword-only.%s\n", cli.Bold, provider, cli.Reset) +
+						fmt.Sprintf("  %s   Run 'same upgrade' to enable semantic search.%s\n", cli.Dim, cli.Reset)
 				}
 			}
 		}
@@ -353,6 +349,36 @@ func runDoctor(jsonOut bool) error {
 		skip("Embedding connection", fmt.Sprintf("skipped (%s)", embedSkipReason))
 	}
 
+	// 3b. Ollama model — reachability alone doesn't mean the model is pulled
+	if provider := config.EmbeddingProviderConfig().Provider; provider == "ollama" || provider == "" {
+		model := config.EmbeddingModel
+		if embedClient, err := newEmbedProvider(); err == nil && embedClient.Model() != "" {
+			model = embedClient.Model()
+		}
+		ollamaURL, urlErr := config.OllamaURL()
+		if ec := config.EmbeddingProviderConfig(); ec.BaseURL != "" {
+			ollamaURL, urlErr = ec.BaseURL, nil
+		}
+		var tagsErr error
+		if urlErr == nil {
+			_, tagsErr = setup.OllamaModels(ollamaURL)
+		}
+		if urlErr != nil || tagsErr != nil {
+			skip("Ollama model", "skipped (Ollama not reachable)")
+		} else {
+			check("Ollama model", fmt.Sprintf("run 'ollama pull %s'", model), func() (string, error) {
+				pulled, err := setup.OllamaModels(ollamaURL)
+				if err != nil {
+					return "", fmt.Errorf("cannot list Ollama models")
+				}
+				if !setup.OllamaHasModel(pulled, model) {
+					return "", fmt.Errorf("embedding model %s is not pulled", model)
+				}
+				return fmt.Sprintf("%s pulled", model), nil
+			})
+		}
+	}
+
 	// 4-6: Search and security checks — skip if vault path is broken
 	if !vaultOK {
 		skip("Search working", "skipped (vault path not found)")
@@ -484,57 +510,37 @@ func runDoctor(jsonOut bool) error {
 
 	} // end vaultOK guard for search/security checks
 
-	// 7. Embedding endpoint policy
-	check("Embedding endpoint policy", "use localhost endpoints for fully local processing", func() (string, error) {
-		ec := config.EmbeddingProviderConfig()
-		provider := strings.TrimSpace(ec.Provider)
-		if provider == "" {
-			provider = "ollama"
-		}
-
-		switch provider {
-		case "none":
-			return "keyword-only (no embedding endpoint)", nil
-		case "ollama":
-			ollamaURL, err := config.OllamaURL()
-			if err != nil {
-				return "", err
-			}
-			u, err := url.Parse(ollamaURL)
-			if err != nil {
-				return "", fmt.Errorf("invalid embedding endpoint URL")
-			}
-			return fmt.Sprintf("local (%s)", u.Host), nil
-		case "openai":
-			return "remote (api.openai.com)", nil
-		case "openai-compatible":
-			baseURL := strings.TrimSpace(ec.BaseURL)
-			if baseURL == "" {
-				return "", fmt.Errorf("openai-compatible requires base_url")
-			}
-			u, err := url.Parse(baseURL)
-			if err != nil {
-				return "", fmt.Errorf("invalid embedding endpoint URL")
-			}
-			host := strings.TrimSpace(u.Hostname())
-			if host == "localhost" || host == "127.0.0.1" || host == "::1" || host == "host.docker.internal" {
-				return fmt.Sprintf("local (%s)", u.Host), nil
-			}
-			return fmt.Sprintf("remote (%s)", u.Host), nil
-		default:
-			return "", fmt.Errorf("unknown embedding provider")
-		}
+	// 7. Network privacy: whether embedding requests leave this machine
+	check("Network privacy", "Ollama must run on localhost; use provider openai-compatible for a remote embedding server", func() (string, error) {
+		return embeddingPrivacy(config.EmbeddingProviderConfig())
 	})
 
 	// 8. Config file validity
-	check("Config file", "check .same/config.toml for syntax errors", func() (string, error) {
+	check("Config file", "check .same/config.toml for syntax errors, typos, and out-of-range values", func() (string, error) {
 		_, err := config.LoadConfig()
 		if err != nil {
 			return "", err
 		}
+		if w := config.ConfigWarning(); w != "" {
+			return "", fmt.Errorf("%s", w)
+		}
 		return "", nil
 	})
 
+	// 8a. Surfacing tuning overrides are informational: they are valid (the
+	// config check covers ranges) but mean surfacing runs off-defaults.
+	check("Surfacing tuning", "remove [surfacing.tuning] from config.toml to restore defaults", func() (string, error) {
+		overrides := hooks.SurfacingTuningOverrides(config.SurfacingTuning())
+		if len(overrides) == 0 {
+			return "defaults", nil
+		}
+		parts := make([]string, len(overrides))
+		for i, o := range overrides {
+			parts[i] = fmt.Sprintf("%s=%g (default %g)", o.Key, o.Value, o.Default)
+		}
+		return "OVERRIDDEN: " + strings.Join(parts, ", "), nil
+	})
+
 	// 8b. Container environment
 	check("Container environment", "consider a remote embedding endpoint if local Ollama is slow", func() (string, error) {
 		ci := config.DetectContainer()
@@ -630,6 +636,7 @@ func runDoctor(jsonOut bool) error {
 	if !vaultOK {
 		skip("Database integrity", "skipped (vault path not found)")
 		skip("Index freshness", "skipped (vault path not found)")
+		skip("Expired notes", "skipped (vault path not found)")
 	} else {
 		check("Database integrity", "run 'same reindex' to rebuild", func() (string, error) {
 			db, err := store.Open()
@@ -667,11 +674,26 @@ func runDoctor(jsonOut bool) error {
 			}
 			return fmt.Sprintf("last indexed %s ago", formatDuration(age)), nil
 		})
+
+		// Expired notes are informational: they are already kept out of
+		// surfacing, the user may just want to delete or archive them.
+		check("Expired notes", "run 'same stale' to review", func() (string, error) {
+			db, err := store.Open()
+			if err != nil {
+				return "", fmt.Errorf("cannot open")
+			}
+			defer db.Close()
+			expired, err := db.ExpiredNotes(time.Now().Format("2006-01-02"))
+			if err != nil || len(expired) == 0 {
+				return "none", nil
+			}
+			return fmt.Sprintf("%d past their expires date (not surfaced; see 'same stale')", len(expired)), nil
+		})
 	} // end vaultOK guard for integrity checks
 
 	// 12. Log file size
 	check("Log file size", "rotation keeps logs under 5MB automatically", func() (string, error) {
-		logPath := filepath.Join(config.DataDir(), "verbose.log")
+		logPath := config.VerboseLogPath()
 		info, err := os.Stat(logPath)
 		if os.IsNotExist(err) {
 			return "no log file", nil
@@ -722,6 +744,15 @@ func runDoctor(jsonOut bool) error {
 			return fmt.Sprintf("%s, %s dims", storedProvider, dims), nil
 		})
 
+		check("Embedding dimensions", "run 'same repair --embeddings' to re-embed mismatched notes", func() (string, error) {
+			db, err := store.Open()
+			if err != nil {
+				return "", fmt.Errorf("cannot open")
+			}
+			defer db.Close()
+			return checkEmbeddingDims(db)
+		})
+
 		check("SQLite integrity", "run 'same repair' to rebuild", func() (string, error) {
 			db, err := store.Open()
 			if err != nil {
@@ -758,7 +789,30 @@ func runDoctor(jsonOut bool) error {
 		})
 	} // end vaultOK guard for DB checks
 
+	// Fix progress and prompts go to stderr in JSON mode so the report on
+	// stdout stays parseable.
+	var fixOut io.Writer = os.Stdout
+	if jsonOut {
+		fixOut = os.Stderr
+	}
+	confirmFix := func(question string) bool {
+		if opts.Yes {
+			return true
+		}
+		fmt.Fprintf(fixOut, "  %s [y/N] ", question)
+		var response string
+		_, _ = fmt.Scanln(&response)
+		return response == "y" || response == "Y" || response == "yes"
+	}
+
 	if jsonOut {
+		if opts.Fix {
+			for _, line := range applyDoctorFixes(results, doctorFixes(fixOut), confirmFix) {
+				fmt.Fprintln(fixOut, "  "+line)
+			}
+		}
+
+		passed, skipped, failed := countDoctorResults(results)
 		report := DoctorReport{
 			Checks: results,
 		}
@@ -779,6 +833,22 @@ func runDoctor(jsonOut bool) error {
 		return nil
 	}
 
+	cli.Header("SAME Health Check")
+	fmt.Println()
+	for _, r := range results {
+		printDoctorResult(r)
+	}
+
+	if opts.Fix && hasFixableFailure(results, doctorFixes(fixOut)) {
+		fmt.Println()
+		fmt.Printf("  %sFixes%s\n", cli.Bold, cli.Reset)
+		for _, line := range applyDoctorFixes(results, doctorFixes(fixOut), confirmFix) {
+			fmt.Println("  " + line)
+		}
+		fmt.Println()
+	}
+
+	passed, skipped, failed := countDoctorResults(results)
 	summary := fmt.Sprintf("%d passed, %d failed", passed, failed)
 	if skipped > 0 {
 		summary += fmt.Sprintf(", %d skipped", skipped)
@@ -801,3 +871,269 @@ func runDoctor(jsonOut bool) error {
 	}
 	return nil
 }
+
+// printDoctorResult renders one check result for the terminal.
+func printDoctorResult(r DoctorResult) {
+	switch r.Status {
+	case "fail":
+		msg := r.rawErr
+		if msg == "" {
+			msg = r.Message
+		}
+		fmt.Printf("  %s✗%s %s: %s\n", cli.Red, cli.Reset, r.Name, msg)
+		if r.Hint != "" {
+			fmt.Printf("    → %s\n", r.Hint)
+		}
+	case "skip":
+		fmt.Printf("  %s-%s %s: %s\n", cli.Dim, cli.Reset, r.Name, r.Message)
+	default:
+		if r.Message != "" {
+			fmt.Printf("  %s✓%s %s (%s)\n", cli.Green, cli.Reset, r.Name, r.Message)
+		} else {
+			fmt.Printf("  %s✓%s %s\n", cli.Green, cli.Reset, r.Name)
+		}
+	}
+	if r.note != "" {
+		fmt.Println(r.note)
+	}
+}
+
+// doctorFix is a safe remediation for a failed doctor check.
+type doctorFix struct {
+	description string
+	apply       func() (string, error)
+}
+
+// doctorFixes maps check names to their remediation. Fixes that report
+// progress write it to w.
+func doctorFixes(w io.Writer) map[string]doctorFix {
+	return map[string]doctorFix{
+		"Database integrity": {
+			description: "Prune orphaned vectors",
+			apply: func() (string, error) {
+				db, err := store.Open()
+				if err != nil {
+					return "", fmt.Errorf("cannot open database")
+				}
+				defer db.Close()
+				n, err := db.PruneOrphanedVectors()
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("removed %d orphaned vectors", n), nil
+			},
+		},
+		"Index freshness": {
+			description: "Reindex changed notes",
+			apply: func() (string, error) {
+				unlock, err := acquireReindexLock()
+				if err != nil {
+					return "", err
+				}
+				defer unlock()
+				db, err := store.Open()
+				if err != nil {
+					return "", fmt.Errorf("cannot open database")
+				}
+				defer db.Close()
+				indexer.Version = Version
+				stats, _, err := indexer.ReindexProgressive(context.Background(), db, false, nil, nil)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d notes reindexed", stats.NewlyIndexed), nil
+			},
+		},
+		"Embedding dimensions": {
+			description: "Re-embed notes with mismatched vector dimensions",
+			apply: func() (string, error) {
+				db, err := store.Open()
+				if err != nil {
+					return "", fmt.Errorf("cannot open database")
+				}
+				defer db.Close()
+				client, err := newEmbedProvider()
+				if err != nil {
+					return "", fmt.Errorf("no embedding provider")
+				}
+				n, err := repairEmbeddingDims(db, client, nil)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d notes re-embedded", n), nil
+			},
+		},
+		"Hooks installed": {
+			description: "Install SAME hooks",
+			apply: func() (string, error) {
+				if err := setup.SetupHooksTo(w, config.VaultPath()); err != nil {
+					return "", err
+				}
+				return "hooks installed", nil
+			},
+		},
+		"MCP config": {
+			description: "Rewrite MCP config with a portable binary path",
+			apply: func() (string, error) {
+				if err := setup.SetupMCPTo(w, config.VaultPath()); err != nil {
+					return "", err
+				}
+				return "MCP config updated", nil
+			},
+		},
+	}
+}
+
+func hasFixableFailure(results []DoctorResult, fixes map[string]doctorFix) bool {
+	for _, r := range results {
+		if _, ok := fixes[r.Name]; ok && r.Status == "fail" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDoctorFixes runs the fix for each failed check that has one, then
+// re-runs the check and updates its result in place. It returns a log line
+// per attempted fix.
+func applyDoctorFixes(results []DoctorResult, fixes map[string]doctorFix, confirm func(string) bool) []string {
+	var log []string
+	for i := range results {
+		r := &results[i]
+		fix, ok := fixes[r.Name]
+		if !ok || r.Status != "fail" {
+			continue
+		}
+		if !confirm(fmt.Sprintf("%s: %s?", r.Name, fix.description)) {
+			log = append(log, fmt.Sprintf("%s-%s %s: skipped", cli.Dim, cli.Reset, r.Name))
+			continue
+		}
+		summary, err := fix.apply()
+		if err != nil {
+			log = append(log, fmt.Sprintf("%s✗%s %s: fix failed: %v", cli.Red, cli.Reset, r.Name, err))
+			continue
+		}
+
+		// Re-run the check to confirm the fix worked.
+		if r.run == nil {
+			continue
+		}
+		detail, checkErr := r.run()
+		if checkErr != nil {
+			r.Message = sanitizeErrorForJSON(checkErr)
+			r.rawErr = checkErr.Error()
+			log = append(log, fmt.Sprintf("%s!%s %s: %s, but the check still fails", cli.Yellow, cli.Reset, r.Name, summary))
+			continue
+		}
+		r.Status = "pass"
+		r.Message = detail
+		r.Hint = ""
+		r.rawErr = ""
+		r.Fixed = true
+		log = append(log, fmt.Sprintf("%s✓%s %s: fixed (%s)", cli.Green, cli.Reset, r.Name, summary))
+	}
+	return log
+}
+
+func countDoctorResults(results []DoctorResult) (passed, skipped, failed int) {
+	for _, r := range results {
+		switch r.Status {
+		case "pass":
+			passed++
+		case "skip":
+			skipped++
+		case "fail":
+			failed++
+		}
+	}
+	return passed, skipped, failed
+}
+
+// embeddingPrivacy says whether embedding requests, which carry note text,
+// stay on this machine and, if not, which host receives them. A remote
+// endpoint is reported rather than failed since it is usually intentional;
+// only Ollama fails, because its provider refuses non-localhost URLs.
+func embeddingPrivacy(ec config.EmbeddingConfig) (string, error) {
+	provider := strings.TrimSpace(ec.Provider)
+	if provider == "" {
+		provider = "ollama"
+	}
+	endpoint := strings.TrimSpace(ec.BaseURL)
+	switch provider {
+	case "none":
+		return "keyword-only, no embedding requests", nil
+	case "ollama":
+		if endpoint == "" {
+			u, err := config.OllamaURL()
+			if err != nil {
+				return "", err
+			}
+			endpoint = u
+		}
+	case "openai":
+		if endpoint == "" {
+			endpoint = "https://api.openai.com"
+		}
+	case "openai-compatible":
+		if endpoint == "" {
+			return "", fmt.Errorf("openai-compatible requires base_url")
+		}
+	default:
+		return "", fmt.Errorf("unknown embedding provider %q", provider)
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid embedding endpoint URL")
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1", "host.docker.internal":
+		return fmt.Sprintf("local: embeddings stay on this machine (%s)", u.Host), nil
+	}
+	if provider == "ollama" {
+		return "", config.ErrOllamaNotLocal
+	}
+	msg := fmt.Sprintf("remote: note text is sent to %s for embedding", u.Host)
+	if u.Scheme == "http" {
+		msg += " over unencrypted HTTP"
+	}
+	return msg, nil
+}
+
+// checkEmbeddingDims reports stored vectors whose length disagrees with the
+// dimensions recorded in the embedding metadata (or, without metadata, the
+// vector table's declared width).
+func checkEmbeddingDims(db *store.DB) (string, error) {
+	counts, err := db.VectorDimensionCounts()
+	if err != nil {
+		return "", nil // vector table may not exist yet
+	}
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	if total == 0 {
+		return "no vectors stored", nil
+	}
+
+	expected := 0
+	if v, ok := db.GetMeta("embed_dims"); ok {
+		expected, _ = strconv.Atoi(v)
+	}
+	if expected <= 0 {
+		if expected, err = db.VectorTableDims(); err != nil {
+			return "", nil
+		}
+	}
+
+	mismatched := 0
+	for dims, n := range counts {
+		if dims != expected {
+			mismatched += n
+		}
+	}
+	if mismatched > 0 {
+		return "", fmt.Errorf("%d of %d vectors do not have %d dims", mismatched, total, expected)
+	}
+	return fmt.Sprintf("%s vectors, %d dims", cli.FormatNumber(total), expected), nil
+}
@@ -4,10 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func TestSanitizeErrorForJSON_RemovesPaths(t *testing.T) {
@@ -41,7 +45,7 @@ func TestRunDoctor_JSONOutput_Structure(t *testing.T) {
 
 	var runErr error
 	out := captureCommandStdout(t, func() {
-		runErr = runDoctor(true)
+		runErr = runDoctor(doctorOptions{JSON: true})
 	})
 	if runErr != nil && !strings.Contains(runErr.Error(), "check(s) failed") {
 		t.Fatalf("unexpected runDoctor error: %v", runErr)
@@ -65,7 +69,7 @@ func TestRunDoctor_TextOutput_ReturnsSummary(t *testing.T) {
 
 	var runErr error
 	out := captureCommandStdout(t, func() {
-		runErr = runDoctor(false)
+		runErr = runDoctor(doctorOptions{})
 	})
 	if runErr != nil && !strings.Contains(runErr.Error(), "check(s) failed") {
 		t.Fatalf("unexpected runDoctor error: %v", runErr)
@@ -75,13 +79,34 @@ func TestRunDoctor_TextOutput_ReturnsSummary(t *testing.T) {
 	}
 }
 
+func TestRunDoctor_TextAndJSONShareChecks(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	_ = db.Close()
+
+	jsonOut := captureCommandStdout(t, func() { _ = runDoctor(doctorOptions{JSON: true}) })
+	textOut := captureCommandStdout(t, func() { _ = runDoctor(doctorOptions{}) })
+
+	var report DoctorReport
+	if err := json.Unmarshal([]byte(jsonOut), &report); err != nil {
+		t.Fatalf("decode doctor report: %v", err)
+	}
+	for _, check := range report.Checks {
+		if !strings.Contains(textOut, check.Name) {
+			t.Errorf("check %q missing from text output", check.Name)
+		}
+		if check.Status == "fail" && check.Hint == "" {
+			t.Errorf("failed check %q should carry a hint", check.Name)
+		}
+	}
+}
+
 func TestDoctorResult_StatusValues(t *testing.T) {
 	_, db := setupCommandTestVault(t)
 	_ = db.Close()
 
 	var runErr error
 	out := captureCommandStdout(t, func() {
-		runErr = runDoctor(true)
+		runErr = runDoctor(doctorOptions{JSON: true})
 	})
 	if runErr != nil && !strings.Contains(runErr.Error(), "check(s) failed") {
 		t.Fatalf("unexpected runDoctor error: %v", runErr)
@@ -136,3 +161,119 @@ func TestDoctor_NoBinaryShadowing(t *testing.T) {
 		t.Fatalf("expected binary detail in output, got: %q", detail)
 	}
 }
+
+func TestApplyDoctorFixes_RerunsCheck(t *testing.T) {
+	healthy := false
+	results := []DoctorResult{
+		{Name: "Broken", Status: "fail", Message: "bad", Hint: "fix it", run: func() (string, error) {
+			if !healthy {
+				return "", errors.New("still bad")
+			}
+			return "ok now", nil
+		}},
+		{Name: "Unfixable", Status: "fail", Message: "bad"},
+		{Name: "Declined", Status: "fail", Message: "bad", run: func() (string, error) { return "", nil }},
+	}
+	applied := 0
+	fixes := map[string]doctorFix{
+		"Broken": {description: "repair", apply: func() (string, error) {
+			applied++
+			healthy = true
+			return "repaired", nil
+		}},
+		"Declined": {description: "repair", apply: func() (string, error) {
+			applied++
+			return "", nil
+		}},
+	}
+	confirm := func(q string) bool { return strings.HasPrefix(q, "Broken") }
+
+	log := applyDoctorFixes(results, fixes, confirm)
+	if applied != 1 {
+		t.Fatalf("expected only the confirmed fix to run, ran %d", applied)
+	}
+	if len(log) != 2 {
+		t.Fatalf("expected log lines for fixed and skipped checks, got %q", log)
+	}
+	if results[0].Status != "pass" || !results[0].Fixed || results[0].Message != "ok now" || results[0].Hint != "" {
+		t.Errorf("fixed check not updated: %+v", results[0])
+	}
+	if results[1].Status != "fail" || results[2].Status != "fail" {
+		t.Errorf("unfixed checks should remain failed: %+v %+v", results[1], results[2])
+	}
+
+	passed, skipped, failed := countDoctorResults(results)
+	if passed != 1 || skipped != 0 || failed != 2 {
+		t.Errorf("counts = %d/%d/%d, want 1/0/2", passed, skipped, failed)
+	}
+}
+
+func TestDoctorCmd_FixJSONRequiresYes(t *testing.T) {
+	cmd := doctorCmd()
+	cmd.SetArgs([]string{"--fix", "--json"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected --yes requirement error, got %v", err)
+	}
+}
+
+func TestCheckEmbeddingDims(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+
+	if detail, err := checkEmbeddingDims(db); err != nil || detail != "no vectors stored" {
+		t.Fatalf("empty index: got %q, %v", detail, err)
+	}
+
+	vec := make([]float32, 768)
+	vec[0] = 1
+	rec := &store.NoteRecord{
+		Path: "notes/a.md", Title: "A", Tags: "[]", ChunkHeading: "(full)",
+		Text: "content", ContentHash: "a", ContentType: "note", Confidence: 0.5,
+	}
+	if err := db.InsertNote(rec, vec); err != nil {
+		t.Fatalf("InsertNote: %v", err)
+	}
+
+	if err := db.SetMeta("embed_dims", "768"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checkEmbeddingDims(db); err != nil {
+		t.Fatalf("matching dims should pass: %v", err)
+	}
+
+	if err := db.SetMeta("embed_dims", "1024"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checkEmbeddingDims(db); err == nil || !strings.Contains(err.Error(), "1 of 1 vectors") {
+		t.Fatalf("expected drift to be reported, got %v", err)
+	}
+}
+
+func TestEmbeddingPrivacy(t *testing.T) {
+	t.Setenv("OLLAMA_URL", "")
+	tests := []struct {
+		name    string
+		ec      config.EmbeddingConfig
+		want    string
+		wantErr bool
+	}{
+		{"keyword only", config.EmbeddingConfig{Provider: "none"}, "keyword-only", false},
+		{"default ollama", config.EmbeddingConfig{Provider: "ollama"}, "local: embeddings stay on this machine (localhost:11434)", false},
+		{"remote ollama", config.EmbeddingConfig{Provider: "ollama", BaseURL: "http://gpu-box:11434"}, "", true},
+		{"openai", config.EmbeddingConfig{Provider: "openai"}, "remote: note text is sent to api.openai.com for embedding", false},
+		{"local compatible", config.EmbeddingConfig{Provider: "openai-compatible", BaseURL: "http://127.0.0.1:8080"}, "local: embeddings stay on this machine (127.0.0.1:8080)", false},
+		{"remote compatible", config.EmbeddingConfig{Provider: "openai-compatible", BaseURL: "http://embed.internal:8080"}, "remote: note text is sent to embed.internal:8080 for embedding over unencrypted HTTP", false},
+		{"compatible without url", config.EmbeddingConfig{Provider: "openai-compatible"}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := embeddingPrivacy(tt.ec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !strings.HasPrefix(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
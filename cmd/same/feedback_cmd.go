@@ -1,37 +1,113 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/sgx-labs/statelessagent/internal/cli"
 	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/memory"
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func feedbackCmd() *cobra.Command {
+	var opts feedbackOptions
 	cmd := &cobra.Command{
 		Use:   "feedback [path] [up|down]",
 		Short: "Tell SAME which notes are helpful (or not)",
 		Long: `Manually adjust how likely a note is to be surfaced.
 
-  same feedback "projects/plan.md" up     Boost confidence
-  same feedback "projects/plan.md" down   Penalize confidence
-  same feedback "projects/*" down         Glob-style path matching
+  same feedback "projects/plan.md" up               Boost confidence
+  same feedback "projects/plan.md" down             Penalize confidence
+  same feedback "projects/*" down                   Glob-style path matching
+  same feedback "projects/*" down --dry-run         Preview matches first
+  same feedback '^notes/20[0-9]{2}-' down --regex   Regex on the path
+  same feedback list                                Show past adjustments
+  same feedback reset "projects/plan.md"            Undo adjustments for a note
 
 'up' makes the note more likely to appear in future sessions.
-'down' makes it much less likely to appear (strong penalty).`,
+'down' makes it much less likely to appear (strong penalty).
+
+A pattern that matches every note in the vault is refused unless --yes is
+given.`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runFeedback(args[0], args[1])
+			return runFeedback(args[0], args[1], opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show matching notes and proposed changes without writing")
+	cmd.Flags().BoolVar(&opts.Regex, "regex", false, "Treat the path as a regular expression")
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Allow a pattern that matches every note in the vault")
+	cmd.AddCommand(feedbackListCmd())
+	cmd.AddCommand(feedbackResetCmd())
+	return cmd
+}
+
+func feedbackListCmd() *cobra.Command {
+	var (
+		limit   int
+		jsonOut bool
+	)
+	cmd := &cobra.Command{
+		Use:   "list [path]",
+		Short: "Show the history of feedback adjustments",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) == 1 {
+				path = args[0]
+			}
+			return runFeedbackList(path, limit, jsonOut)
 		},
 	}
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum entries to show (0 = all)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
 	return cmd
 }
 
-func runFeedback(pathPattern, direction string) error {
+func feedbackResetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset [path]",
+		Short: "Restore a note's confidence to its indexed default",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFeedbackReset(args[0])
+		},
+	}
+}
+
+// feedbackAccessBoost is the access_count bump applied by 'feedback up'.
+const feedbackAccessBoost = 5
+
+type feedbackOptions struct {
+	DryRun bool // show matches and proposed changes without writing
+	Regex  bool // treat the pattern as a Go regexp matched against the path
+	Yes    bool // allow a pattern that matches every note in the vault
+}
+
+// feedbackNote is a note selected by a feedback pattern.
+type feedbackNote struct {
+	path       string
+	title      string
+	confidence float64
+}
+
+// feedbackConfidence returns the confidence a note gets after feedback.
+func feedbackConfidence(old float64, direction string) float64 {
+	if direction == "up" {
+		return math.Min(1.0, old+0.2)
+	}
+	return math.Max(0.05, old-0.3)
+}
+
+func runFeedback(pathPattern, direction string, opts feedbackOptions) error {
 	if strings.TrimSpace(pathPattern) == "" {
 		return userError("Empty path", "Provide a note path: same feedback \"path/to/note.md\" up")
 	}
@@ -41,6 +117,13 @@ func runFeedback(pathPattern, direction string) error {
 			"Use 'up' or 'down'",
 		)
 	}
+	var re *regexp.Regexp
+	if opts.Regex {
+		var err error
+		if re, err = regexp.Compile(pathPattern); err != nil {
+			return userError(fmt.Sprintf("Invalid regex: %v", err), "Regex syntax is Go RE2, e.g. '^projects/.*\\.md$'")
+		}
+	}
 
 	db, err := store.Open()
 	if err != nil {
@@ -48,66 +131,52 @@ func runFeedback(pathPattern, direction string) error {
 	}
 	defer db.Close()
 
-	// Convert glob to SQL LIKE pattern
-	likePattern := strings.ReplaceAll(pathPattern, "*", "%")
-
-	// Get matching notes (chunk_id=0 for dedup)
-	rows, err := db.Conn().Query(
-		`SELECT path, title, confidence, access_count FROM vault_notes WHERE path LIKE ? AND chunk_id = 0 ORDER BY path`,
-		likePattern,
-	)
+	notes, err := matchFeedbackNotes(db, pathPattern, re)
 	if err != nil {
-		return fmt.Errorf("query notes: %w", err)
+		return err
 	}
-	defer rows.Close()
-
-	type noteInfo struct {
-		path        string
-		title       string
-		confidence  float64
-		accessCount int
+	if len(notes) == 0 {
+		return fmt.Errorf("no notes matching %q found in index", pathPattern)
 	}
-	var notes []noteInfo
-	for rows.Next() {
-		var n noteInfo
-		if err := rows.Scan(&n.path, &n.title, &n.confidence, &n.accessCount); err != nil {
-			continue
-		}
-		notes = append(notes, n)
+
+	// Refuse mass adjustments of the whole vault unless asked for explicitly.
+	if total, err := db.NoteCount(); err == nil && total > 1 && len(notes) >= total && !opts.Yes && !opts.DryRun {
+		return userError(
+			fmt.Sprintf("Pattern %q matches all %d notes in the vault", pathPattern, total),
+			"Preview with --dry-run, narrow the pattern, or pass --yes to adjust every note",
+		)
 	}
 
-	if len(notes) == 0 {
-		return fmt.Errorf("no notes matching %q found in index", pathPattern)
+	if opts.DryRun {
+		fmt.Printf("\n  %d note(s) match %q (dry run, nothing changed):\n\n", len(notes), pathPattern)
+		for _, n := range notes {
+			fmt.Printf("    %.2f → %.2f  %s %s\n", n.confidence, feedbackConfidence(n.confidence, direction),
+				n.title, cli.Dim+n.path+cli.Reset)
+		}
+		fmt.Println()
+		return nil
 	}
 
 	for _, n := range notes {
 		oldConf := n.confidence
-		var newConf float64
-		var boostMsg string
+		newConf := feedbackConfidence(oldConf, direction)
+		if err := db.AdjustConfidence(n.path, newConf); err != nil {
+			fmt.Fprintf(os.Stderr, "  error adjusting %s: %v\n", n.path, err)
+			continue
+		}
 
+		var boostMsg string
 		if direction == "up" {
-			newConf = oldConf + 0.2
-			if newConf > 1.0 {
-				newConf = 1.0
-			}
-			if err := db.AdjustConfidence(n.path, newConf); err != nil {
-				fmt.Fprintf(os.Stderr, "  error adjusting %s: %v\n", n.path, err)
-				continue
-			}
-			if err := db.SetAccessBoost(n.path, 5); err != nil {
+			boost := feedbackAccessBoost
+			if err := db.SetAccessBoost(n.path, boost); err != nil {
 				fmt.Fprintf(os.Stderr, "  error boosting %s: %v\n", n.path, err)
+				boost = 0
 			}
-			boostMsg = fmt.Sprintf("✓ Boosted '%s' — confidence: %.2f → %.2f, access +5",
-				n.title, oldConf, newConf)
+			recordFeedback(db, n.path, store.FeedbackUp, oldConf, newConf, boost)
+			boostMsg = fmt.Sprintf("✓ Boosted '%s' — confidence: %.2f → %.2f, access +%d",
+				n.title, oldConf, newConf, feedbackAccessBoost)
 		} else {
-			newConf = oldConf - 0.3
-			if newConf < 0.05 {
-				newConf = 0.05
-			}
-			if err := db.AdjustConfidence(n.path, newConf); err != nil {
-				fmt.Fprintf(os.Stderr, "  error adjusting %s: %v\n", n.path, err)
-				continue
-			}
+			recordFeedback(db, n.path, store.FeedbackDown, oldConf, newConf, 0)
 			boostMsg = fmt.Sprintf("✓ Penalized '%s' — confidence: %.2f → %.2f",
 				n.title, oldConf, newConf)
 		}
@@ -121,3 +190,139 @@ func runFeedback(pathPattern, direction string) error {
 
 	return nil
 }
+
+// matchFeedbackNotes selects notes (chunk_id=0 for dedup) by glob, using
+// SQL LIKE, or by regexp when re is non-nil, matched against the path in Go.
+func matchFeedbackNotes(db *store.DB, pattern string, re *regexp.Regexp) ([]feedbackNote, error) {
+	query := `SELECT path, title, confidence FROM vault_notes WHERE chunk_id = 0 ORDER BY path`
+	var args []any
+	if re == nil {
+		query = `SELECT path, title, confidence FROM vault_notes WHERE path LIKE ? AND chunk_id = 0 ORDER BY path`
+		args = append(args, strings.ReplaceAll(pattern, "*", "%"))
+	}
+	rows, err := db.Conn().Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []feedbackNote
+	for rows.Next() {
+		var n feedbackNote
+		if err := rows.Scan(&n.path, &n.title, &n.confidence); err != nil {
+			continue
+		}
+		if re != nil && !re.MatchString(n.path) {
+			continue
+		}
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
+
+// recordFeedback logs an adjustment. The adjustment itself has already been
+// applied, so a logging failure is reported but not fatal.
+func recordFeedback(db *store.DB, path, direction string, oldConf, newConf float64, boost int) {
+	err := db.RecordFeedback(store.FeedbackEntry{
+		Path:          path,
+		Direction:     direction,
+		OldConfidence: oldConf,
+		NewConfidence: newConf,
+		AccessBoost:   boost,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  warning: could not record feedback for %s: %v\n", path, err)
+	}
+}
+
+func runFeedbackList(path string, limit int, jsonOut bool) error {
+	db, err := store.Open()
+	if err != nil {
+		return config.ErrNoDatabase
+	}
+	defer db.Close()
+
+	entries, err := db.FeedbackHistory(path, limit)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		if entries == nil {
+			entries = []store.FeedbackEntry{}
+		}
+		data, _ := json.MarshalIndent(entries, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("\n  No feedback recorded yet.")
+		fmt.Printf("  %sAdjust a note with: same feedback \"path/to/note.md\" up%s\n\n", cli.Dim, cli.Reset)
+		return nil
+	}
+
+	fmt.Printf("\n  %d feedback adjustment(s), newest first:\n\n", len(entries))
+	for _, e := range entries {
+		when := time.Unix(e.CreatedAt, 0).Format("2006-01-02 15:04")
+		color := cli.Dim
+		switch e.Direction {
+		case store.FeedbackUp:
+			color = cli.Green
+		case store.FeedbackDown:
+			color = cli.Yellow
+		}
+		extra := ""
+		if e.AccessBoost != 0 {
+			extra = fmt.Sprintf("  %saccess %+d%s", cli.Dim, e.AccessBoost, cli.Reset)
+		}
+		fmt.Printf("  %s  %s%-5s%s  %.2f → %.2f  %s%s\n",
+			when, color, e.Direction, cli.Reset, e.OldConfidence, e.NewConfidence, e.Path, extra)
+	}
+	fmt.Println()
+	return nil
+}
+
+// runFeedbackReset restores the confidence a note would get from a fresh
+// index and removes access boosts added by 'feedback up' since the last reset.
+func runFeedbackReset(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return userError("Empty path", "Provide a note path: same feedback reset \"path/to/note.md\"")
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return config.ErrNoDatabase
+	}
+	defer db.Close()
+
+	notes, err := db.GetNoteByPath(path)
+	if err != nil || len(notes) == 0 {
+		return fmt.Errorf("note not found in index: %s\n  Make sure the path is relative to your vault root", path)
+	}
+	n := notes[0]
+
+	// Same inputs the indexer uses when it first scores a note.
+	defaultConf := memory.ComputeConfidence(n.ContentType, n.Modified, 0, n.ReviewBy != "", "unknown")
+	if err := db.AdjustConfidence(path, defaultConf); err != nil {
+		return fmt.Errorf("reset confidence: %w", err)
+	}
+
+	boost, err := db.PendingFeedbackBoost(path)
+	if err != nil {
+		return err
+	}
+	if boost > 0 {
+		if err := db.SetAccessBoost(path, -boost); err != nil {
+			return fmt.Errorf("reset access boost: %w", err)
+		}
+	}
+	recordFeedback(db, path, store.FeedbackReset, n.Confidence, defaultConf, -boost)
+
+	fmt.Printf("  %s✓%s Reset '%s' — confidence: %.2f → %.2f", cli.Green, cli.Reset, n.Title, n.Confidence, defaultConf)
+	if boost > 0 {
+		fmt.Printf(", access -%d", boost)
+	}
+	fmt.Println()
+	return nil
+}
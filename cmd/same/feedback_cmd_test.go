@@ -1,8 +1,11 @@
 package main
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/sgx-labs/statelessagent/internal/memory"
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
@@ -11,7 +14,7 @@ func TestFeedbackCmd_ValidUp(t *testing.T) {
 	insertCommandTestNote(t, db, "test.md", "Test Note", "Some content.")
 	_ = db.Close()
 
-	if err := runFeedback("test.md", "up"); err != nil {
+	if err := runFeedback("test.md", "up", feedbackOptions{}); err != nil {
 		t.Fatalf("runFeedback up: %v", err)
 	}
 
@@ -37,8 +40,128 @@ func TestFeedbackCmd_InvalidDirection(t *testing.T) {
 	_, db := setupCommandTestVault(t)
 	_ = db.Close()
 
-	err := runFeedback("test.md", "sideways")
+	err := runFeedback("test.md", "sideways", feedbackOptions{})
 	if err == nil {
 		t.Fatal("expected error for invalid direction")
 	}
 }
+
+func TestFeedbackCmd_ListAndReset(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "test.md", "Test Note", "Some content.")
+	_ = db.Close()
+
+	captureCommandStdout(t, func() {
+		if err := runFeedback("test.md", "up", feedbackOptions{}); err != nil {
+			t.Fatalf("runFeedback up: %v", err)
+		}
+		if err := runFeedback("test.md", "down", feedbackOptions{}); err != nil {
+			t.Fatalf("runFeedback down: %v", err)
+		}
+	})
+
+	out := captureCommandStdout(t, func() {
+		if err := runFeedbackList("", 0, true); err != nil {
+			t.Fatalf("runFeedbackList: %v", err)
+		}
+	})
+	var entries []store.FeedbackEntry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("parse JSON: %v\n%s", err, out)
+	}
+	if len(entries) != 2 || entries[0].Direction != "down" || entries[1].Direction != "up" {
+		t.Fatalf("entries = %+v, want down then up", entries)
+	}
+	if entries[1].OldConfidence != 0.8 || entries[1].AccessBoost != feedbackAccessBoost {
+		t.Errorf("up entry = %+v", entries[1])
+	}
+
+	captureCommandStdout(t, func() {
+		if err := runFeedbackReset("test.md"); err != nil {
+			t.Fatalf("runFeedbackReset: %v", err)
+		}
+	})
+
+	db2, err := store.Open()
+	if err != nil {
+		t.Fatalf("store.Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db2.Close() })
+	notes, _ := db2.GetNoteByPath("test.md")
+	want := memory.ComputeConfidence(notes[0].ContentType, notes[0].Modified, 0, false, "unknown")
+	if notes[0].Confidence != want {
+		t.Errorf("confidence after reset = %.3f, want indexed default %.3f", notes[0].Confidence, want)
+	}
+	if notes[0].AccessCount != 0 {
+		t.Errorf("access_count after reset = %d, want 0", notes[0].AccessCount)
+	}
+
+	if err := runFeedbackReset("missing.md"); err == nil {
+		t.Error("expected error resetting a note that is not indexed")
+	}
+}
+
+func TestFeedbackCmd_DryRunRegexAndWholeVaultGuard(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "projects/alpha.md", "Alpha", "a")
+	insertCommandTestNote(t, db, "projects/beta.md", "Beta", "b")
+	insertCommandTestNote(t, db, "notes/gamma.md", "Gamma", "c")
+	_ = db.Close()
+
+	confidence := func(path string) float64 {
+		t.Helper()
+		d, err := store.Open()
+		if err != nil {
+			t.Fatalf("store.Open: %v", err)
+		}
+		defer d.Close()
+		notes, _ := d.GetNoteByPath(path)
+		if len(notes) == 0 {
+			t.Fatalf("note %s missing", path)
+		}
+		return notes[0].Confidence
+	}
+
+	out := captureCommandStdout(t, func() {
+		if err := runFeedback("projects/*", "down", feedbackOptions{DryRun: true}); err != nil {
+			t.Fatalf("dry run: %v", err)
+		}
+	})
+	if !strings.Contains(out, "2 note(s) match") || !strings.Contains(out, "0.80 → 0.50") {
+		t.Errorf("dry run output = %q", out)
+	}
+	if got := confidence("projects/alpha.md"); got != 0.8 {
+		t.Errorf("dry run changed confidence to %.2f", got)
+	}
+
+	captureCommandStdout(t, func() {
+		if err := runFeedback(`^projects/b.*\.md$`, "up", feedbackOptions{Regex: true}); err != nil {
+			t.Fatalf("regex feedback: %v", err)
+		}
+	})
+	if got := confidence("projects/beta.md"); got != 1.0 {
+		t.Errorf("regex match confidence = %.2f, want 1.00", got)
+	}
+	if got := confidence("projects/alpha.md"); got != 0.8 {
+		t.Errorf("regex should not touch alpha, got %.2f", got)
+	}
+
+	if err := runFeedback("*", "down", feedbackOptions{}); err == nil {
+		t.Error("expected whole-vault pattern to be refused without --yes")
+	}
+	if got := confidence("notes/gamma.md"); got != 0.8 {
+		t.Errorf("refused pattern changed confidence to %.2f", got)
+	}
+	captureCommandStdout(t, func() {
+		if err := runFeedback("*", "down", feedbackOptions{Yes: true}); err != nil {
+			t.Fatalf("feedback --yes: %v", err)
+		}
+	})
+	if got := confidence("notes/gamma.md"); got != 0.5 {
+		t.Errorf("--yes confidence = %.2f, want 0.50", got)
+	}
+
+	if err := runFeedback("([", "down", feedbackOptions{Regex: true}); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+}
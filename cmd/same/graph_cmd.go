@@ -30,6 +30,7 @@ func graphCmd() *cobra.Command {
 	cmd.AddCommand(graphRebuildCmd())
 	cmd.AddCommand(graphEnableCmd())
 	cmd.AddCommand(graphDisableCmd())
+	cmd.AddCommand(graphExportCmd())
 
 	return cmd
 }
@@ -389,6 +390,202 @@ func graphDisableCmd() *cobra.Command {
 	}
 }
 
+func graphExportCmd() *cobra.Command {
+	var (
+		format    string
+		neighbors int
+		output    string
+	)
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a note link graph as Graphviz DOT or JSON",
+		Long: `Export the vault as a graph of notes for visualization tools.
+
+Nodes are indexed notes. Each note is connected to its nearest neighbors by
+embedding similarity (undirected "similar" edges) and to the notes it links
+with [[wikilinks]] or relative Markdown links (directed "link" edges).
+Similarity edges need embeddings; in keyword-only mode only links are
+exported.
+
+Examples:
+  same graph export > vault.dot
+  same graph export --format json --neighbors 5 -o graph.json
+  same graph export | dot -Tsvg > vault.svg`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "dot" && format != "json" {
+				return userError(fmt.Sprintf("Unknown format %q", format), "use --format dot or --format json")
+			}
+			if neighbors < 0 {
+				return userError("--neighbors must be zero or positive", "use --neighbors 0 to export links only")
+			}
+
+			db, err := store.Open()
+			if err != nil {
+				return config.ErrNoDatabase
+			}
+			defer db.Close()
+
+			g, err := buildNoteGraph(db, neighbors)
+			if err != nil {
+				return err
+			}
+			if neighbors > 0 && !db.HasVectors() {
+				fmt.Fprintln(os.Stderr, "No embeddings in the index; exporting explicit links only.")
+			}
+
+			var out string
+			if format == "json" {
+				data, _ := json.MarshalIndent(g, "", "  ")
+				out = string(data) + "\n"
+			} else {
+				out = g.dot()
+			}
+			if output == "" {
+				fmt.Print(out)
+				return nil
+			}
+			if err := os.WriteFile(output, []byte(out), 0o644); err != nil {
+				return fmt.Errorf("write graph: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Wrote %d notes and %d edges to %s\n", len(g.Nodes), len(g.Edges), output)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "dot", "Output format (dot, json)")
+	cmd.Flags().IntVar(&neighbors, "neighbors", 3, "Nearest neighbors to connect per note (0 = links only)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to this file instead of stdout")
+	return cmd
+}
+
+// Edge kinds in an exported note graph.
+const (
+	noteEdgeSimilar = "similar"
+	noteEdgeLink    = "link"
+)
+
+// noteGraph is the exported note graph.
+type noteGraph struct {
+	Nodes []noteGraphNode `json:"nodes"`
+	Edges []noteGraphEdge `json:"edges"`
+}
+
+type noteGraphNode struct {
+	ID          string `json:"id"` // vault-relative path
+	Title       string `json:"title"`
+	ContentType string `json:"content_type,omitempty"`
+	Domain      string `json:"domain,omitempty"`
+}
+
+type noteGraphEdge struct {
+	Source string  `json:"source"`
+	Target string  `json:"target"`
+	Kind   string  `json:"kind"`
+	Weight float64 `json:"weight,omitempty"` // similarity score for similar edges
+}
+
+// buildNoteGraph connects every indexed note to its top neighbors by stored
+// embedding and to the notes it links to. Similar edges are undirected, so
+// a pair found from both ends is kept once with the higher score.
+func buildNoteGraph(db *store.DB, neighbors int) (*noteGraph, error) {
+	notes, err := db.AllNotes()
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+
+	g := &noteGraph{Nodes: []noteGraphNode{}, Edges: []noteGraphEdge{}}
+	resolver := graph.NewLinkResolver()
+	known := make(map[string]bool, len(notes))
+	for _, n := range notes {
+		g.Nodes = append(g.Nodes, noteGraphNode{ID: n.Path, Title: n.Title, ContentType: n.ContentType, Domain: n.Domain})
+		resolver.Add(n.Path, n.Title)
+		known[n.Path] = true
+	}
+
+	similar := make(map[[2]string]int) // sorted pair -> index in g.Edges
+	useVectors := neighbors > 0 && db.HasVectors()
+	for _, n := range notes {
+		chunks, err := db.GetNoteByPath(n.Path)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", n.Path, err)
+		}
+		var text strings.Builder
+		for _, c := range chunks {
+			text.WriteString(c.Text)
+			text.WriteString("\n")
+		}
+		linked := make(map[string]bool)
+		for _, l := range graph.ParseNoteLinks(text.String()) {
+			target, ok := resolver.Resolve(n.Path, l)
+			if !ok || target == n.Path || linked[target] {
+				continue
+			}
+			linked[target] = true
+			g.Edges = append(g.Edges, noteGraphEdge{Source: n.Path, Target: target, Kind: noteEdgeLink})
+		}
+
+		if !useVectors {
+			continue
+		}
+		vec, err := db.GetNoteEmbedding(n.Path)
+		if err != nil || vec == nil {
+			continue
+		}
+		results, err := db.VectorSearch(vec, store.SearchOptions{TopK: neighbors + 1})
+		if err != nil {
+			return nil, fmt.Errorf("neighbors of %s: %w", n.Path, err)
+		}
+		added := 0
+		for _, r := range results {
+			if added >= neighbors {
+				break
+			}
+			if r.Path == n.Path || !known[r.Path] {
+				continue
+			}
+			added++
+			pair := [2]string{n.Path, r.Path}
+			if pair[1] < pair[0] {
+				pair[0], pair[1] = pair[1], pair[0]
+			}
+			if i, ok := similar[pair]; ok {
+				g.Edges[i].Weight = max(g.Edges[i].Weight, r.Score)
+				continue
+			}
+			similar[pair] = len(g.Edges)
+			g.Edges = append(g.Edges, noteGraphEdge{Source: pair[0], Target: pair[1], Kind: noteEdgeSimilar, Weight: r.Score})
+		}
+	}
+	return g, nil
+}
+
+// dot renders the graph in Graphviz DOT. Links are solid arrows; similarity
+// edges are dashed with no arrowhead and labelled with their score.
+func (g *noteGraph) dot() string {
+	var b strings.Builder
+	b.WriteString("digraph vault {\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s];\n", dotQuote(n.ID), dotQuote(n.Title))
+	}
+	for _, e := range g.Edges {
+		if e.Kind == noteEdgeSimilar {
+			fmt.Fprintf(&b, "  %s -> %s [dir=none, style=dashed, label=\"%.2f\"];\n", dotQuote(e.Source), dotQuote(e.Target), e.Weight)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(e.Source), dotQuote(e.Target))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote returns s as a DOT double-quoted ID.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
 func resolveGraphNode(gdb *graph.DB, nodeType, nodeName string) (*graph.Node, error) {
 	node, err := gdb.FindNode(nodeType, nodeName)
 	if err == nil {
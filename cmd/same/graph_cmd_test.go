@@ -3,6 +3,7 @@ package main
 import (
 	"database/sql"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/sgx-labs/statelessagent/internal/graph"
@@ -67,3 +68,63 @@ func TestResolveGraphNode_NoFallbackForOtherTypes(t *testing.T) {
 		t.Fatalf("expected sql.ErrNoRows, got %v", err)
 	}
 }
+
+func TestBuildNoteGraph_SimilarAndLinkEdges(t *testing.T) {
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	notes := []struct {
+		path, title, text string
+		x                 float32
+	}{
+		{"notes/auth.md", "Auth", "We use JWT. See [[Sessions]].", 0},
+		{"notes/sessions.md", "Sessions", "Session storage, per [auth](auth.md).", 0.1},
+		{"notes/billing.md", "Billing", "Stripe invoices.", 5},
+	}
+	for _, n := range notes {
+		vec := make([]float32, 768)
+		vec[0], vec[1] = 1, n.x
+		rec := store.NoteRecord{Path: n.path, Title: n.title, Tags: "[]", ChunkHeading: "(full)", Text: n.text, ContentType: "note"}
+		if err := db.InsertNote(&rec, vec); err != nil {
+			t.Fatalf("InsertNote: %v", err)
+		}
+	}
+
+	g, err := buildNoteGraph(db, 1)
+	if err != nil {
+		t.Fatalf("buildNoteGraph: %v", err)
+	}
+	if len(g.Nodes) != 3 {
+		t.Fatalf("nodes = %d, want 3", len(g.Nodes))
+	}
+	kinds := make(map[string]int)
+	for _, e := range g.Edges {
+		kinds[e.Kind]++
+		if e.Kind == noteEdgeLink && e.Source == "notes/auth.md" && e.Target != "notes/sessions.md" {
+			t.Errorf("auth links to %q, want notes/sessions.md", e.Target)
+		}
+	}
+	if kinds[noteEdgeLink] != 2 {
+		t.Errorf("link edges = %d, want 2: %+v", kinds[noteEdgeLink], g.Edges)
+	}
+	// auth<->sessions is found from both ends but exported once.
+	if kinds[noteEdgeSimilar] != 2 {
+		t.Errorf("similar edges = %d, want 2: %+v", kinds[noteEdgeSimilar], g.Edges)
+	}
+
+	dot := g.dot()
+	for _, want := range []string{"digraph vault {", `"notes/auth.md" -> "notes/sessions.md";`, "dir=none, style=dashed"} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, dot)
+		}
+	}
+}
+
+func TestDotQuote(t *testing.T) {
+	if got := dotQuote(`say "hi" \ now`); got != `"say \"hi\" \\ now"` {
+		t.Errorf("dotQuote = %s", got)
+	}
+}
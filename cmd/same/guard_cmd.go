@@ -13,6 +13,7 @@ import (
 	"github.com/sgx-labs/statelessagent/internal/cli"
 	"github.com/sgx-labs/statelessagent/internal/config"
 	"github.com/sgx-labs/statelessagent/internal/guard"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func guardCmd() *cobra.Command {
@@ -22,13 +23,27 @@ func guardCmd() *cobra.Command {
 		Long: `SAME Guard scans staged files for PII, blocklisted terms, and
 unauthorized file paths before they reach git.
 
-Run 'same guard install' to set up the git pre-commit hook.`,
+A commit is blocked when a staged file:
+  • is outside the allowed paths (path filter)
+  • matches a PII or credential pattern (emails, API keys, private keys...)
+  • contains a term from _PRIVATE/.blocklist
+
+Soft findings (local paths, soft blocklist terms) can be allowed once
+reviewed. Guard never changes your notes; it only gates git commits.
+
+  same guard install       Set up the git pre-commit hook
+  same guard status        Show what guard enforces and its recent decisions
+  same guard rules         List every path, pattern, and term it checks
+  same guard test <file>   Preview whether a file would be blocked
+  same guard settings      Turn individual checks on or off`,
 	}
 
 	cmd.AddCommand(guardScanCmd())
 	cmd.AddCommand(guardInstallCmd())
 	cmd.AddCommand(guardUninstallCmd())
 	cmd.AddCommand(guardStatusCmd())
+	cmd.AddCommand(guardRulesCmd())
+	cmd.AddCommand(guardTestCmd())
 	cmd.AddCommand(guardReviewCmd())
 	cmd.AddCommand(guardBlocklistCmd())
 	cmd.AddCommand(guardAllowCmd())
@@ -43,31 +58,72 @@ func guardScanCmd() *cobra.Command {
 	var (
 		staged  bool
 		jsonOut bool
+		fix     bool
 	)
 	cmd := &cobra.Command{
-		Use:   "scan",
-		Short: "Scan staged files for PII and blocklisted content",
+		Use:   "scan [file...]",
+		Short: "Scan files for secrets, PII, and blocklisted content",
+		Long: `Scan files for API keys, tokens, personal info, and blocklisted terms.
+Exits non-zero when anything blocking is found, so it can gate a git
+pre-commit hook ('same guard install' sets that up).
+
+With no arguments it scans the files staged for commit. Pass files to scan
+them as they are on disk, or --staged=false to scan every tracked file.
+
+Besides known key formats, long random-looking strings are flagged as
+likely secrets (turn off with 'same guard settings set entropy off'). Add
+your own formats with 'same guard settings add-pattern'.
+
+--fix moves each indexed note with a finding into _PRIVATE/ in your vault
+(never indexed, never committed) and takes it out of the git index. Other
+flagged files, such as configs and scripts, are reported but left in place.
+
+Examples:
+  same guard scan
+  same guard scan notes/api-setup.md
+  same guard scan --fix`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGuardScan(staged, jsonOut)
+			return runGuardScan(args, staged, jsonOut, fix)
 		},
 	}
-	cmd.Flags().BoolVar(&staged, "staged", true, "Only scan staged files")
+	cmd.Flags().BoolVar(&staged, "staged", true, "Scan staged files (when no files are given)")
 	cmd.Flags().BoolVar(&jsonOut, "json", false, "Machine-readable JSON output")
+	cmd.Flags().BoolVar(&fix, "fix", false, "Move indexed notes with findings into _PRIVATE/")
 	return cmd
 }
 
-func runGuardScan(staged, jsonOut bool) error {
+func runGuardScan(files []string, staged, jsonOut, fix bool) error {
 	vaultPath := config.VaultPath()
 	scanner, err := guard.NewScanner(vaultPath)
 	if err != nil {
 		return fmt.Errorf("init scanner: %w", err)
 	}
+	root := gitRoot()
+
+	var (
+		names  []string
+		onDisk map[string]string
+	)
+	switch {
+	case len(files) > 0:
+		names, onDisk, err = guardDiskTargets(files, root)
+	case staged:
+		// Content comes from the index; onDisk is only used by --fix.
+	default:
+		names, onDisk, err = guardTrackedTargets(root)
+	}
+	if err != nil {
+		return err
+	}
 
 	var result *guard.ScanResult
-	if staged {
+	if onDisk == nil {
 		result, err = scanner.ScanStaged()
 	} else {
-		return fmt.Errorf("non-staged scanning not yet supported")
+		scanner.ContentReader = func(file string) ([]byte, error) {
+			return os.ReadFile(onDisk[file])
+		}
+		result, err = scanner.ScanFiles(names)
 	}
 	if err != nil {
 		return fmt.Errorf("scan: %w", err)
@@ -75,18 +131,194 @@ func runGuardScan(staged, jsonOut bool) error {
 
 	if jsonOut {
 		fmt.Println(result.FormatJSON())
-	} else {
+	} else if !fix || result.Passed {
 		fmt.Print(result.FormatFriendly())
 	}
 
+	if result.Passed {
+		return nil
+	}
+	if fix {
+		return fixGuardFindings(vaultPath, root, onDisk, result, jsonOut)
+	}
 	// Cache last scan for the allow flow
-	if !result.Passed {
+	_ = guard.SaveLastScan(vaultPath, result)
+	return fmt.Errorf("guard scan failed")
+}
+
+// fixGuardFindings moves each indexed note with a content finding into the
+// vault's _PRIVATE/ folder and drops it from the search and git indexes.
+// Other flagged files (configs, scripts, anything that is not an indexed .md
+// note) are only reported: moving them could break the repository.
+// Path-only violations are left alone: the file itself is not the problem.
+func fixGuardFindings(vaultPath, root string, onDisk map[string]string, result *guard.ScanResult, quiet bool) error {
+	flagged := make(map[string]bool)
+	var order []string
+	for _, v := range result.Violations {
+		if !flagged[v.File] {
+			flagged[v.File] = true
+			order = append(order, v.File)
+		}
+	}
+
+	var db *store.DB
+	if _, err := os.Stat(config.DBPath()); err == nil {
+		if opened, err := store.Open(); err == nil {
+			db = opened
+			defer db.Close()
+		}
+	}
+
+	var failed int
+	var notNotes []string
+	for _, name := range order {
+		abs := onDisk[name]
+		if abs == "" {
+			abs = filepath.Join(root, filepath.FromSlash(name))
+		}
+		notePath, ok := indexedNotePath(db, vaultPath, abs)
+		if !ok {
+			notNotes = append(notNotes, name)
+			continue
+		}
+		dest, err := moveToPrivate(vaultPath, abs)
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "  %s✗%s %s: %v\n", cli.Red, cli.Reset, name, err)
+			continue
+		}
+		_ = db.FTS5DeleteNote(notePath)
+		_ = db.DeleteByPath(notePath)
+		if root != "" {
+			_ = exec.Command("git", "-C", root, "rm", "--cached", "--quiet", "--ignore-unmatch", "--", name).Run()
+		}
+		_ = guard.AppendAudit(vaultPath, guard.AuditEntry{
+			Action:  "fix_move",
+			Passed:  true,
+			Details: map[string]string{"file": name, "to": dest},
+		})
+		if !quiet {
+			fmt.Printf("  %s✓%s Moved %s → %s\n", cli.Green, cli.Reset, name, cli.ShortenHome(dest))
+		}
+	}
+
+	if !quiet {
+		for _, name := range notNotes {
+			fmt.Printf("  %s!%s %s is not an indexed note; --fix left it in place. Remove the finding by hand.\n", cli.Yellow, cli.Reset, name)
+		}
+	}
+	if failed > 0 || len(notNotes) > 0 || len(result.PathViolations) > 0 {
+		if len(result.PathViolations) > 0 && !quiet {
+			fmt.Printf("\n  %d file(s) are outside the allowed paths; --fix doesn't move those (see: same guard rules).\n", len(result.PathViolations))
+		}
 		_ = guard.SaveLastScan(vaultPath, result)
 		return fmt.Errorf("guard scan failed")
 	}
+	if !quiet {
+		fmt.Printf("\n  %sThe flagged notes are now private and unstaged. Re-run your commit.%s\n", cli.Dim, cli.Reset)
+	}
 	return nil
 }
 
+// indexedNotePath returns abs's vault-relative path when it is a .md note in
+// the vault's index, the only files guard scan --fix will move.
+func indexedNotePath(db *store.DB, vaultPath, abs string) (string, bool) {
+	if db == nil || vaultPath == "" || !strings.EqualFold(filepath.Ext(abs), ".md") {
+		return "", false
+	}
+	rel, err := filepath.Rel(vaultPath, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	rel = filepath.ToSlash(rel)
+	notes, err := db.GetNoteByPath(rel)
+	if err != nil || len(notes) == 0 {
+		return "", false
+	}
+	return rel, true
+}
+
+// moveToPrivate moves abs into <vault>/_PRIVATE/, keeping its path relative
+// to the vault (or just its name if it lives outside the vault).
+func moveToPrivate(vaultPath, abs string) (string, error) {
+	if vaultPath == "" {
+		return "", fmt.Errorf("no vault found — run 'same init' or set VAULT_PATH")
+	}
+	rel, err := filepath.Rel(vaultPath, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		rel = filepath.Base(abs)
+	}
+	if first := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]; strings.EqualFold(first, "_PRIVATE") {
+		return "", fmt.Errorf("already in _PRIVATE/")
+	}
+	dest := filepath.Join(vaultPath, "_PRIVATE", rel)
+	if _, err := os.Stat(dest); err == nil {
+		return "", fmt.Errorf("%s already exists", dest)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o700); err != nil {
+		return "", err
+	}
+	if err := os.Rename(abs, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// gitRoot returns the top of the current git repository, or "".
+func gitRoot() string {
+	out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// guardDiskTargets resolves files on disk to the repo-relative names the
+// path filter expects, so a scan behaves as it would at commit time.
+func guardDiskTargets(files []string, root string) ([]string, map[string]string, error) {
+	onDisk := make(map[string]string, len(files))
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolve %s: %w", f, err)
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return nil, nil, userError(fmt.Sprintf("File not found: %s", f), "Pass a path to a file on disk")
+		}
+		name := filepath.ToSlash(filepath.Clean(f))
+		if root != "" {
+			if r, err := filepath.Rel(root, abs); err == nil && !strings.HasPrefix(r, "..") {
+				name = filepath.ToSlash(r)
+			}
+		}
+		onDisk[name] = abs
+		names = append(names, name)
+	}
+	return names, onDisk, nil
+}
+
+// guardTrackedTargets lists every file git tracks in the repository.
+func guardTrackedTargets(root string) ([]string, map[string]string, error) {
+	if root == "" {
+		return nil, nil, fmt.Errorf("not a git repository — pass the files to scan")
+	}
+	out, err := exec.Command("git", "-C", root, "ls-files").Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("list tracked files: %w", err)
+	}
+	onDisk := make(map[string]string)
+	var names []string
+	for _, name := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if name == "" {
+			continue
+		}
+		onDisk[name] = filepath.Join(root, filepath.FromSlash(name))
+		names = append(names, name)
+	}
+	return names, onDisk, nil
+}
+
 func guardInstallCmd() *cobra.Command {
 	var force bool
 	cmd := &cobra.Command{
@@ -193,20 +425,31 @@ func runGuardUninstall() error {
 }
 
 func guardStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	var recent int
+	cmd := &cobra.Command{
 		Use:   "status",
-		Short: "Show guard configuration and recent audit",
+		Short: "Show guard configuration and recent decisions",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGuardStatus()
+			return runGuardStatus(recent)
 		},
 	}
+	cmd.Flags().IntVar(&recent, "recent", 5, "Number of recent guard decisions to show")
+	return cmd
 }
 
-func runGuardStatus() error {
+func runGuardStatus(recent int) error {
 	vaultPath := config.VaultPath()
+	cfg := guard.LoadGuardConfig()
 
 	fmt.Printf("\n%sSAME Guard Status%s\n\n", cli.Bold, cli.Reset)
 
+	if cfg.Enabled {
+		fmt.Printf("  Guard:      %s✓ enabled%s\n", cli.Green, cli.Reset)
+	} else {
+		fmt.Printf("  Guard:      %s✗ disabled%s (commits are not checked)\n", cli.Red, cli.Reset)
+	}
+
 	// Check hook
 	gitRoot, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
 	if err == nil {
@@ -243,6 +486,19 @@ func runGuardStatus() error {
 		fmt.Printf("  Reviewed:   %snone%s\n", cli.Dim, cli.Reset)
 	}
 
+	// Rules in force
+	if cfg.Enabled {
+		if scanner, err := guard.NewScannerWithConfig(vaultPath, cfg); err == nil {
+			fmt.Printf("  Patterns:   %d PII/credential patterns active\n", len(scanner.Patterns()))
+		}
+		if cfg.PathFilter.Enabled {
+			fmt.Printf("  Paths:      %d allowed paths (everything else is blocked)\n", len(guard.AllowedPaths(nil)))
+		} else {
+			fmt.Printf("  Paths:      %sany path%s (path filter off)\n", cli.Dim, cli.Reset)
+		}
+		fmt.Printf("  Soft mode:  %s\n", cfg.SoftMode)
+	}
+
 	// Audit log
 	auditPath := filepath.Join(vaultPath, ".same", "publish-audit.log")
 	if info, err := os.Stat(auditPath); err == nil {
@@ -251,10 +507,220 @@ func runGuardStatus() error {
 		fmt.Printf("  Audit log:  %snot yet created%s\n", cli.Dim, cli.Reset)
 	}
 
-	fmt.Println()
+	if recent > 0 {
+		entries, err := guard.ReadAudit(vaultPath, recent)
+		if err != nil {
+			return fmt.Errorf("read audit log: %w", err)
+		}
+		if len(entries) > 0 {
+			fmt.Printf("\n  %sRecent decisions%s\n", cli.Bold, cli.Reset)
+			for i := len(entries) - 1; i >= 0; i-- {
+				fmt.Printf("    %s\n", describeAuditEntry(entries[i]))
+			}
+		}
+	}
+
+	fmt.Printf("\n  %sFull ruleset: same guard rules · Preview a file: same guard test <file>%s\n\n", cli.Dim, cli.Reset)
+	return nil
+}
+
+// describeAuditEntry renders one audit log line for 'same guard status'.
+func describeAuditEntry(e guard.AuditEntry) string {
+	when := e.Timestamp
+	if t, err := time.Parse(time.RFC3339, e.Timestamp); err == nil {
+		when = t.Local().Format("2006-01-02 15:04")
+	}
+	var what string
+	switch e.Action {
+	case "scan":
+		if e.Passed {
+			what = fmt.Sprintf("%s✓ commit passed%s (%d files)", cli.Green, cli.Reset, e.FilesCount)
+		} else {
+			what = fmt.Sprintf("%s✗ commit blocked%s (%d files, %d findings)", cli.Red, cli.Reset, e.FilesCount, e.Violations)
+		}
+	case "fix_move":
+		what = "moved a file into _PRIVATE/"
+		if d, ok := e.Details.(map[string]any); ok {
+			what = fmt.Sprintf("moved %v into _PRIVATE/", d["file"])
+		}
+	case "scan_skip_binary":
+		what = fmt.Sprintf("skipped binary file %v", e.Details)
+	case "allow":
+		what = "allowed findings from the last scan"
+		if d, ok := e.Details.(map[string]any); ok {
+			what = fmt.Sprintf("allowed %v finding(s) from the last scan", d["count"])
+		}
+	case "review_add", "review_remove":
+		what = strings.Replace(e.Action, "_", " ", 1)
+		if d, ok := e.Details.(map[string]any); ok {
+			what = fmt.Sprintf("%s %q", what, d["term"])
+		}
+	default:
+		what = e.Action
+	}
+	return fmt.Sprintf("%s%s%s  %s", cli.Dim, when, cli.Reset, what)
+}
+
+func guardRulesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rules",
+		Short: "List the paths, patterns, and terms guard enforces",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGuardRules()
+		},
+	}
+}
+
+func runGuardRules() error {
+	vaultPath := config.VaultPath()
+	cfg := guard.LoadGuardConfig()
+	scanner, err := guard.NewScannerWithConfig(vaultPath, cfg)
+	if err != nil {
+		return fmt.Errorf("init scanner: %w", err)
+	}
+
+	fmt.Printf("\n%sSAME Guard Rules%s\n", cli.Bold, cli.Reset)
+	if !cfg.Enabled {
+		fmt.Printf("\n  %sGuard is disabled — nothing below is enforced (same guard settings set guard on).%s\n", cli.Yellow, cli.Reset)
+	}
+
+	fmt.Printf("\n  %sAllowed paths%s", cli.Bold, cli.Reset)
+	if cfg.PathFilter.Enabled {
+		fmt.Printf(" (files anywhere else are blocked)\n")
+	} else {
+		fmt.Printf(" %s(path filter off — not enforced)%s\n", cli.Dim, cli.Reset)
+	}
+	for _, p := range guard.AllowedPaths(nil) {
+		fmt.Printf("    %s\n", p)
+	}
+
+	patterns := scanner.Patterns()
+	fmt.Printf("\n  %sPatterns%s (%d active)\n", cli.Bold, cli.Reset, len(patterns))
+	if len(patterns) == 0 {
+		fmt.Printf("    %snone — PII scanning is off%s\n", cli.Dim, cli.Reset)
+	}
+	for _, p := range patterns {
+		fmt.Printf("    %-22s [%s] %s\n", p.Name, p.Tier, guard.CategoryLabel(p.Category))
+	}
+	fmt.Printf("    %sSkipped: test files (_test.go, test/, tests/), regex definitions, and placeholders (%s)%s\n",
+		cli.Dim, strings.Join(guard.FalsePositiveMatches(), ", "), cli.Reset)
+
+	fmt.Printf("\n  %sBlocklist%s ", cli.Bold, cli.Reset)
+	terms := scanner.BlockTerms()
+	switch {
+	case !cfg.Blocklist.Enabled:
+		fmt.Printf("%s(off)%s\n", cli.Dim, cli.Reset)
+	case len(terms) == 0:
+		fmt.Printf("%s(no terms — add them to %s)%s\n", cli.Dim, filepath.Join(vaultPath, "_PRIVATE", ".blocklist"), cli.Reset)
+	default:
+		hard := 0
+		for _, t := range terms {
+			if t.Tier == guard.TierHard {
+				hard++
+			}
+		}
+		fmt.Printf("(%d hard, %d soft terms — list them with: same guard blocklist)\n", hard, len(terms)-hard)
+	}
+
+	fmt.Printf("\n  Soft findings: %s", cfg.SoftMode)
+	if cfg.SoftMode == "warn" {
+		fmt.Printf(" (reported, never block)\n\n")
+	} else {
+		fmt.Printf(" (block until allowed with 'same guard allow')\n\n")
+	}
+	return nil
+}
+
+func guardTestCmd() *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "test <file>...",
+		Short: "Preview whether files would be blocked from a commit",
+		Long: `Check files on disk against the guard rules without committing or
+staging anything. Paths are resolved against the git repository root, as
+the pre-commit hook sees them. Exits non-zero if any file would be blocked.
+Preview runs are not written to the audit log.
+
+Examples:
+  same guard test notes/meeting.md
+  same guard test .scripts/deploy.sh --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGuardTest(args, jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Machine-readable JSON output")
+	return cmd
+}
+
+func runGuardTest(files []string, jsonOut bool) error {
+	rel, onDisk, err := guardDiskTargets(files, gitRoot())
+	if err != nil {
+		return err
+	}
+
+	scanner, err := guard.NewScanner(config.VaultPath())
+	if err != nil {
+		return fmt.Errorf("init scanner: %w", err)
+	}
+	scanner.NoAudit = true
+	scanner.ContentReader = func(file string) ([]byte, error) {
+		return os.ReadFile(onDisk[file])
+	}
+	result, err := scanner.ScanFiles(rel)
+	if err != nil {
+		return fmt.Errorf("scan: %w", err)
+	}
+
+	if jsonOut {
+		fmt.Println(result.FormatJSON())
+	} else {
+		printGuardTest(rel, result, scanner.Config.Enabled)
+	}
+	if !result.Passed {
+		return fmt.Errorf("guard would block this commit")
+	}
 	return nil
 }
 
+func printGuardTest(files []string, result *guard.ScanResult, enabled bool) {
+	if !enabled {
+		fmt.Printf("  %sGuard is disabled, so nothing would be blocked.%s\n", cli.Yellow, cli.Reset)
+		return
+	}
+	blocked := make(map[string][]string)
+	warned := make(map[string][]string)
+	for _, pv := range result.PathViolations {
+		blocked[pv.File] = append(blocked[pv.File], "path not in the allowlist (see: same guard rules)")
+	}
+	for _, v := range result.Violations {
+		blocked[v.File] = append(blocked[v.File], fmt.Sprintf("line %d: %s: %s [%s]", v.Line, guard.CategoryLabel(v.Category), v.Redacted, v.Rule))
+	}
+	for _, w := range result.Warnings {
+		note := ""
+		if w.Reviewed {
+			note = ", reviewed"
+		}
+		warned[w.File] = append(warned[w.File], fmt.Sprintf("line %d: %s: %s [%s%s]", w.Line, guard.CategoryLabel(w.Category), w.Redacted, w.Rule, note))
+	}
+	fmt.Println()
+	for _, f := range files {
+		if reasons := blocked[f]; len(reasons) > 0 {
+			fmt.Printf("  %s✗%s %s — would be blocked\n", cli.Red, cli.Reset, f)
+			for _, r := range reasons {
+				fmt.Printf("      %s\n", r)
+			}
+		} else {
+			fmt.Printf("  %s✓%s %s — would pass\n", cli.Green, cli.Reset, f)
+		}
+		for _, w := range warned[f] {
+			fmt.Printf("      %s%s (warning only)%s\n", cli.Dim, w, cli.Reset)
+		}
+	}
+	fmt.Println()
+}
+
 func guardReviewCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "review",
@@ -521,7 +987,9 @@ Examples:
   same guard settings                     Show current settings
   same guard settings set email off       Disable email scanning
   same guard settings set soft-mode warn  Switch soft blocks to warnings
-  same guard settings reset               Reset all settings to defaults`,
+  same guard settings reset               Reset all settings to defaults
+  same guard settings add-pattern acme-token 'acme_[a-z0-9]{32}'
+  same guard settings remove-pattern acme-token`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runGuardSettingsShow()
 		},
@@ -529,6 +997,8 @@ Examples:
 
 	cmd.AddCommand(guardSettingsSetCmd())
 	cmd.AddCommand(guardSettingsResetCmd())
+	cmd.AddCommand(guardSettingsAddPatternCmd())
+	cmd.AddCommand(guardSettingsRemovePatternCmd())
 
 	return cmd
 }
@@ -554,6 +1024,52 @@ func guardSettingsResetCmd() *cobra.Command {
 	}
 }
 
+func guardSettingsAddPatternCmd() *cobra.Command {
+	var soft bool
+	cmd := &cobra.Command{
+		Use:   "add-pattern <name> <regex>",
+		Short: "Add a custom secret pattern (Go regular expression)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p := guard.CustomPattern{Name: args[0], Regex: args[1], Tier: guard.TierHard}
+			if soft {
+				p.Tier = guard.TierSoft
+			}
+			cfg := guard.LoadGuardConfig()
+			if err := cfg.AddCustomPattern(p); err != nil {
+				return userError(err.Error(), "Patterns use Go regexp syntax, e.g. 'acme_[a-z0-9]{32}'")
+			}
+			if err := guard.SaveGuardConfig(cfg); err != nil {
+				return err
+			}
+			fmt.Printf("  %s✓%s Added pattern %s [%s]\n", cli.Green, cli.Reset, p.Name, p.Tier)
+			fmt.Printf("    %sPreview it with: same guard test <file>%s\n", cli.Dim, cli.Reset)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&soft, "soft", false, "Make findings soft (allowable after review) instead of hard")
+	return cmd
+}
+
+func guardSettingsRemovePatternCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-pattern <name>",
+		Short: "Remove a custom secret pattern",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := guard.LoadGuardConfig()
+			if !cfg.RemoveCustomPattern(args[0]) {
+				return userError(fmt.Sprintf("No custom pattern named %q", args[0]), "Run 'same guard settings' to list custom patterns")
+			}
+			if err := guard.SaveGuardConfig(cfg); err != nil {
+				return err
+			}
+			fmt.Printf("  %s✓%s Removed pattern %s\n", cli.Green, cli.Reset, args[0])
+			return nil
+		},
+	}
+}
+
 func runGuardSettingsShow() error {
 	cfg := guard.LoadGuardConfig()
 	vaultPath := config.VaultPath()
@@ -633,6 +1149,8 @@ func runGuardSettingsShow() error {
 		{"api_key", cfg.PII.Patterns.APIKey, "hard"},
 		{"aws_key", cfg.PII.Patterns.AWSKey, "hard"},
 		{"private_key", cfg.PII.Patterns.PrivateKey, "hard"},
+		{"credentials", cfg.PII.Patterns.Credentials, "hard"},
+		{"entropy", cfg.PII.Patterns.Entropy, "soft"},
 	}
 	for _, r := range rows {
 		check := fmt.Sprintf("%s✓ on%s", cli.Green, cli.Reset)
@@ -642,9 +1160,21 @@ func runGuardSettingsShow() error {
 		fmt.Printf("    %-14s %s   [%s]\n", r.key, check, r.tier)
 	}
 
+	if len(cfg.CustomPatterns) > 0 {
+		fmt.Printf("\n  Custom Patterns:\n")
+		for _, cp := range cfg.CustomPatterns {
+			tier := cp.Tier
+			if tier == "" {
+				tier = guard.TierHard
+			}
+			fmt.Printf("    %-14s %s   [%s]\n", cp.Name, cp.Regex, tier)
+		}
+	}
+
 	fmt.Printf("\n  Change: same guard settings set <key> on|off\n")
 	fmt.Printf("  Keys:   guard, pii, blocklist, path-filter, soft-mode, push-protect, push-timeout,\n")
-	fmt.Printf("          email, phone, ssn, local_path, api_key, aws_key, private_key\n\n")
+	fmt.Printf("          email, phone, ssn, local_path, api_key, aws_key, private_key, credentials, entropy\n")
+	fmt.Printf("  Add your own: same guard settings add-pattern <name> <regex>\n\n")
 
 	return nil
 }
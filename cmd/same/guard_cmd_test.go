@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/guard"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func TestSanitizeRepoTicketName(t *testing.T) {
@@ -129,3 +132,85 @@ func runGit(t *testing.T, dir string, args ...string) {
 		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, string(out))
 	}
 }
+
+func TestDescribeAuditEntry(t *testing.T) {
+	cases := []struct {
+		entry guard.AuditEntry
+		want  string
+	}{
+		{guard.AuditEntry{Timestamp: "2026-01-02T03:04:05Z", Action: "scan", FilesCount: 3, Passed: true}, "commit passed"},
+		{guard.AuditEntry{Action: "scan", FilesCount: 2, Violations: 4}, "commit blocked"},
+		{guard.AuditEntry{Action: "allow", Details: map[string]any{"count": "2"}}, "allowed 2 finding(s)"},
+		{guard.AuditEntry{Action: "review_add", Details: map[string]any{"term": "acme"}}, `review add "acme"`},
+	}
+	for _, c := range cases {
+		if got := describeAuditEntry(c.entry); !strings.Contains(got, c.want) {
+			t.Errorf("describeAuditEntry(%s) = %q, want it to contain %q", c.entry.Action, got, c.want)
+		}
+	}
+}
+
+func TestMoveToPrivate(t *testing.T) {
+	vault := t.TempDir()
+	src := filepath.Join(vault, "notes", "keys.md")
+	if err := os.MkdirAll(filepath.Dir(src), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest, err := moveToPrivate(vault, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(vault, "_PRIVATE", "notes", "keys.md"); dest != want {
+		t.Errorf("dest = %s, want %s", dest, want)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Error("source should be gone")
+	}
+	if data, err := os.ReadFile(dest); err != nil || string(data) != "secret" {
+		t.Errorf("moved content = %q, %v", data, err)
+	}
+
+	if _, err := moveToPrivate(vault, dest); err == nil {
+		t.Error("moving a file already in _PRIVATE should fail")
+	}
+	if err := os.WriteFile(src, []byte("again"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := moveToPrivate(vault, src); err == nil {
+		t.Error("an existing destination should not be overwritten")
+	}
+}
+
+func TestIndexedNotePath_OnlyIndexedMarkdownNotes(t *testing.T) {
+	vault := t.TempDir()
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	rec := store.NoteRecord{Path: "notes/keys.md", Title: "Keys", Tags: "[]", ChunkHeading: "(full)", Text: "secret", ContentType: "note"}
+	if _, err := db.BulkInsertNotesLite([]store.NoteRecord{rec}); err != nil {
+		t.Fatal(err)
+	}
+
+	if rel, ok := indexedNotePath(db, vault, filepath.Join(vault, "notes", "keys.md")); !ok || rel != "notes/keys.md" {
+		t.Errorf("indexed note: got %q, %v", rel, ok)
+	}
+	for _, abs := range []string{
+		filepath.Join(vault, "notes", "draft.md"),          // not indexed
+		filepath.Join(vault, "deploy.sh"),                  // not a note
+		filepath.Join(vault, "config.yaml"),                // not a note
+		filepath.Join(filepath.Dir(vault), "elsewhere.md"), // outside the vault
+	} {
+		if _, ok := indexedNotePath(db, vault, abs); ok {
+			t.Errorf("%s should not be fixable", abs)
+		}
+	}
+	if _, ok := indexedNotePath(nil, vault, filepath.Join(vault, "notes", "keys.md")); ok {
+		t.Error("without an index nothing is fixable")
+	}
+}
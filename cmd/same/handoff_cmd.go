@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/indexer"
+	"github.com/sgx-labs/statelessagent/internal/llm"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func handoffCmd() *cobra.Command {
+	var opts handoffOptions
+	cmd := &cobra.Command{
+		Use:   "handoff",
+		Short: "Write a session handoff note now",
+		Long: `Generate a handoff note from recent vault activity and save it to the
+handoff directory (vault.handoff_dir, default "sessions").
+
+Handoffs are normally written by the Stop hook at the end of an AI session.
+Use this when you finish work outside Claude Code, or want a handoff
+mid-session. The note lists recent decisions, recently modified notes, and
+pinned notes. Pass --summary, --pending, and --blockers to write those
+sections yourself; when --summary is omitted and a chat model is available,
+the summary, pending work, and blockers are drafted from recent notes.
+
+Examples:
+  same handoff
+  same handoff --summary "Migrated auth to JWT" --pending "Refresh token rotation"
+  same handoff --no-llm`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHandoff(opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.Summary, "summary", "", "What was worked on")
+	cmd.Flags().StringVar(&opts.Pending, "pending", "", "Work left for next session")
+	cmd.Flags().StringVar(&opts.Blockers, "blockers", "", "Anything blocking progress")
+	cmd.Flags().BoolVar(&opts.NoLLM, "no-llm", false, "Don't draft missing sections with a chat model")
+	return cmd
+}
+
+type handoffOptions struct {
+	Summary  string
+	Pending  string
+	Blockers string
+	NoLLM    bool
+}
+
+const (
+	// handoffWindow is how far back "recent" activity reaches.
+	handoffWindow = 24 * time.Hour
+	// maxHandoffInputSize matches the MCP create_handoff limit.
+	maxHandoffInputSize = 100 * 1024
+)
+
+// handoffContext is the vault state a handoff is built from.
+type handoffContext struct {
+	Decisions []store.NoteRecord
+	Recent    []store.NoteRecord
+	Pinned    []store.PinnedNote
+}
+
+func runHandoff(opts handoffOptions) error {
+	if config.VaultPath() == "" {
+		return config.ErrNoVault
+	}
+	total := len(opts.Summary) + len(opts.Pending) + len(opts.Blockers)
+	if total > maxHandoffInputSize {
+		return userError("Handoff content too large", fmt.Sprintf("keep --summary, --pending, and --blockers under %dKB combined", maxHandoffInputSize/1024))
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return config.ErrNoDatabase
+	}
+	defer db.Close()
+
+	hc := gatherHandoffContext(db, time.Now().Add(-handoffWindow))
+
+	if strings.TrimSpace(opts.Summary) == "" && !opts.NoLLM {
+		draftHandoffSections(&opts, hc)
+	}
+	if strings.TrimSpace(opts.Summary) == "" {
+		opts.Summary = fallbackHandoffSummary(hc)
+	}
+
+	now := time.Now()
+	relPath := filepath.Join(config.HandoffDirectory(), fmt.Sprintf("%s-%s-handoff.md", now.Format("2006-01-02"), now.Format("150405")))
+	absPath, ok := config.SafeVaultSubpath(relPath)
+	if !ok {
+		return userError("Handoff path is outside the vault", "set vault.handoff_dir to a relative directory under the vault")
+	}
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+		return fmt.Errorf("create handoff directory: %w", err)
+	}
+	if err := os.WriteFile(absPath, []byte(buildHandoffNote(opts, hc, now)), 0o600); err != nil {
+		return fmt.Errorf("write handoff: %w", err)
+	}
+
+	// Index just this file so the next session can find it immediately.
+	relSlash := filepath.ToSlash(relPath)
+	vaultPath := config.VaultPath()
+	if client, provErr := newEmbedProvider(); provErr == nil {
+		err = indexer.IndexSingleFile(db, absPath, relSlash, vaultPath, client)
+	} else {
+		err = indexer.IndexSingleFileLite(db, absPath, relSlash, vaultPath)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  %sWarning: handoff saved but not indexed: %v%s\n", cli.Yellow, err, cli.Reset)
+	}
+
+	fmt.Printf("  %s✓%s Handoff saved → %s\n", cli.Green, cli.Reset, relSlash)
+	return nil
+}
+
+// gatherHandoffContext collects decisions and notes modified since `since`,
+// plus pinned notes. Earlier handoffs are left out of the recent list.
+func gatherHandoffContext(db *store.DB, since time.Time) handoffContext {
+	var hc handoffContext
+	recent, _ := db.RecentNotes(50)
+	for _, n := range recent {
+		if n.Modified < float64(since.Unix()) {
+			break
+		}
+		switch n.ContentType {
+		case "handoff":
+			continue
+		case "decision":
+			if len(hc.Decisions) < 5 {
+				hc.Decisions = append(hc.Decisions, n)
+			}
+			continue
+		}
+		if len(hc.Recent) < 10 {
+			hc.Recent = append(hc.Recent, n)
+		}
+	}
+	hc.Pinned, _ = db.GetPinnedNotes()
+	return hc
+}
+
+// draftHandoffSections fills empty summary/pending/blockers from a chat model.
+// Failures are silent: the caller falls back to a structured summary.
+func draftHandoffSections(opts *handoffOptions, hc handoffContext) {
+	if len(hc.Decisions)+len(hc.Recent) == 0 {
+		return
+	}
+	chat, err := llm.NewClient()
+	if err != nil {
+		return
+	}
+	model, err := chat.PickBestModel()
+	if err != nil || model == "" {
+		return
+	}
+	fmt.Printf("  %s*%s Drafting handoff with %s/%s...\n", cli.Cyan, cli.Reset, chat.Provider(), model)
+
+	raw, err := chat.GenerateJSON(model, buildHandoffPrompt(hc))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  %sCould not draft handoff; using recent activity instead.%s\n", cli.Yellow, cli.Reset)
+		return
+	}
+	var draft struct {
+		Summary  string `json:"summary"`
+		Pending  string `json:"pending"`
+		Blockers string `json:"blockers"`
+	}
+	if err := json.Unmarshal([]byte(raw), &draft); err != nil {
+		return
+	}
+	opts.Summary = strings.TrimSpace(draft.Summary)
+	if opts.Pending == "" {
+		opts.Pending = strings.TrimSpace(draft.Pending)
+	}
+	if opts.Blockers == "" {
+		opts.Blockers = strings.TrimSpace(draft.Blockers)
+	}
+}
+
+func buildHandoffPrompt(hc handoffContext) string {
+	var b strings.Builder
+	b.WriteString(`You write session handoff notes for a personal knowledge vault.
+Given the notes changed in the last session, respond with JSON only:
+{"summary": "...", "pending": "...", "blockers": "..."}
+
+RULES:
+- summary: 2-4 markdown bullet lines describing what was worked on
+- pending: markdown bullet lines of unfinished work, or "" if none is evident
+- blockers: markdown bullet lines of blockers, or "" if none are mentioned
+- Use only information from the notes below
+
+`)
+	writeHandoffPromptSection(&b, "DECISIONS", hc.Decisions)
+	writeHandoffPromptSection(&b, "RECENTLY MODIFIED NOTES", hc.Recent)
+	return b.String()
+}
+
+func writeHandoffPromptSection(b *strings.Builder, header string, notes []store.NoteRecord) {
+	fmt.Fprintf(b, "\n%s:\n", header)
+	if len(notes) == 0 {
+		b.WriteString("(none)\n")
+		return
+	}
+	for _, n := range notes {
+		fmt.Fprintf(b, "- [%s] %s: %s\n", n.Path, n.Title, truncateSnippet(n.Text, 250))
+	}
+}
+
+// fallbackHandoffSummary lists recently modified notes when no summary was
+// given and none could be drafted.
+func fallbackHandoffSummary(hc handoffContext) string {
+	if len(hc.Recent) == 0 && len(hc.Decisions) == 0 {
+		return "- (no notes changed in the last 24 hours)"
+	}
+	var lines []string
+	for _, n := range hc.Recent {
+		lines = append(lines, fmt.Sprintf("- Updated %s", n.Title))
+	}
+	for _, n := range hc.Decisions {
+		lines = append(lines, fmt.Sprintf("- Decided: %s", n.Title))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// buildHandoffNote renders the handoff markdown. Section headings match the
+// hook-generated handoffs so session bootstrap reads both the same way.
+func buildHandoffNote(opts handoffOptions, hc handoffContext, now time.Time) string {
+	var b strings.Builder
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "unknown"
+	}
+
+	fmt.Fprintf(&b, "---\ntitle: Session Handoff %s\n", now.Format("2006-01-02 15:04"))
+	b.WriteString("content_type: handoff\n")
+	fmt.Fprintf(&b, "machine: %s\n", host)
+	fmt.Fprintf(&b, "created: %s\n", now.UTC().Format(time.RFC3339))
+	b.WriteString("tags:\n  - handoff\n  - manual\n---\n\n")
+
+	fmt.Fprintf(&b, "# Session Handoff — %s\n\n", now.Format("2006-01-02"))
+	writeHandoffSection(&b, "What we worked on", opts.Summary)
+	writeHandoffSection(&b, "Pending", opts.Pending)
+	writeHandoffSection(&b, "Blockers", opts.Blockers)
+
+	if len(hc.Decisions) > 0 {
+		b.WriteString("## Decisions made\n")
+		for _, n := range hc.Decisions {
+			fmt.Fprintf(&b, "- %s (`%s`)\n", n.Title, n.Path)
+		}
+		b.WriteString("\n")
+	}
+	if len(hc.Recent) > 0 {
+		b.WriteString("## Notes created/updated\n")
+		for _, n := range hc.Recent {
+			fmt.Fprintf(&b, "- `%s`\n", n.Path)
+		}
+		b.WriteString("\n")
+	}
+	if len(hc.Pinned) > 0 {
+		b.WriteString("## Pinned\n")
+		for _, n := range hc.Pinned {
+			fmt.Fprintf(&b, "- %s (`%s`)\n", n.Title, n.Path)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("---\n*Generated by `same handoff`*\n")
+	return b.String()
+}
+
+func writeHandoffSection(b *strings.Builder, heading, body string) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return
+	}
+	fmt.Fprintf(b, "## %s\n%s\n\n", heading, body)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func TestRunHandoff_WritesManualSectionsAndContext(t *testing.T) {
+	vault, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/auth.md", "Auth rework", "Moved sessions to JWT.")
+	insertCommandTestNote(t, db, "notes/pinned.md", "Team conventions", "Always run gofmt.")
+	if err := db.PinNote("notes/pinned.md", ""); err != nil {
+		t.Fatalf("PinNote: %v", err)
+	}
+
+	out := captureCommandStdout(t, func() {
+		err := runHandoff(handoffOptions{
+			Summary:  "- Reworked auth",
+			Pending:  "- Token refresh",
+			Blockers: "- Waiting on API keys",
+			NoLLM:    true,
+		})
+		if err != nil {
+			t.Fatalf("runHandoff: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Handoff saved") {
+		t.Fatalf("expected confirmation, got: %s", out)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(vault, "sessions", "*-handoff.md"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one handoff file, got %v", matches)
+	}
+	data, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatalf("read handoff: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		"content_type: handoff",
+		"## What we worked on\n- Reworked auth",
+		"## Pending\n- Token refresh",
+		"## Blockers\n- Waiting on API keys",
+		"`notes/auth.md`",
+		"## Pinned\n- Team conventions",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("handoff missing %q:\n%s", want, content)
+		}
+	}
+
+	rel, _ := filepath.Rel(vault, matches[0])
+	notes, err := db.GetNoteByPath(filepath.ToSlash(rel))
+	if err != nil || len(notes) == 0 {
+		t.Fatalf("handoff was not indexed: %v", err)
+	}
+}
+
+func TestGatherHandoffContext_SkipsOldNotesAndHandoffs(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	now := float64(time.Now().Unix())
+	recs := []store.NoteRecord{
+		{Path: "notes/fresh.md", Title: "Fresh", ContentType: "note", Modified: now},
+		{Path: "decisions/db.md", Title: "Use SQLite", ContentType: "decision", Modified: now},
+		{Path: "sessions/old-handoff.md", Title: "Handoff", ContentType: "handoff", Modified: now},
+		{Path: "notes/old.md", Title: "Old", ContentType: "note", Modified: now - 3*86400},
+	}
+	for i := range recs {
+		recs[i].Tags = "[]"
+		recs[i].ChunkHeading = "(full)"
+		recs[i].ContentHash = recs[i].Path
+		recs[i].Text = recs[i].Title
+	}
+	if _, err := db.BulkInsertNotesLite(recs); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+
+	hc := gatherHandoffContext(db, time.Now().Add(-handoffWindow))
+	if len(hc.Recent) != 1 || hc.Recent[0].Path != "notes/fresh.md" {
+		t.Errorf("recent = %+v, want only notes/fresh.md", hc.Recent)
+	}
+	if len(hc.Decisions) != 1 || hc.Decisions[0].Path != "decisions/db.md" {
+		t.Errorf("decisions = %+v, want decisions/db.md", hc.Decisions)
+	}
+
+	summary := fallbackHandoffSummary(hc)
+	if !strings.Contains(summary, "Updated Fresh") || !strings.Contains(summary, "Decided: Use SQLite") {
+		t.Errorf("fallback summary = %q", summary)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/sgx-labs/statelessagent/internal/config"
 	"github.com/sgx-labs/statelessagent/internal/hooks"
 	"github.com/sgx-labs/statelessagent/internal/setup"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func hookCmd() *cobra.Command {
@@ -17,7 +19,7 @@ func hookCmd() *cobra.Command {
 		Use:   "hook",
 		Short: "Run a hook handler",
 	}
-	cmd.AddCommand(hookSubCmd("context-surfacing", "UserPromptSubmit hook: surface relevant vault context"))
+	cmd.AddCommand(contextSurfacingHookCmd())
 	cmd.AddCommand(hookSubCmd("decision-extractor", "Stop hook: extract decisions from transcript"))
 	cmd.AddCommand(hookSubCmd("handoff-generator", "PreCompact/Stop hook: generate handoff notes"))
 	cmd.AddCommand(hookSubCmd("feedback-loop", "Stop hook: track which surfaced notes were actually used"))
@@ -37,6 +39,101 @@ func hookSubCmd(name, short string) *cobra.Command {
 	}
 }
 
+func contextSurfacingHookCmd() *cobra.Command {
+	var (
+		dryRun  bool
+		prompt  string
+		jsonOut bool
+	)
+	cmd := hookSubCmd("context-surfacing", "UserPromptSubmit hook: surface relevant vault context")
+	cmd.Long = `UserPromptSubmit hook: surface relevant vault context.
+
+Claude Code runs this with the hook input on stdin. Use --dry-run with
+--prompt to see what would be injected for a prompt: the conversation mode,
+each candidate's scores, and the final <vault-context> block. A dry run
+reads no stdin and logs nothing to usage tracking.
+
+Example:
+  same hook context-surfacing --dry-run --prompt "how does the auth middleware work"`
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !dryRun {
+			if prompt != "" || jsonOut {
+				return userError("--prompt and --json require --dry-run", "use: same hook context-surfacing --dry-run --prompt \"...\"")
+			}
+			hooks.Run("context-surfacing")
+			return nil
+		}
+		return runContextSurfacingDryRun(prompt, jsonOut)
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be injected instead of emitting hook JSON")
+	cmd.Flags().StringVar(&prompt, "prompt", "", "Prompt to surface context for (with --dry-run)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output the dry run as JSON")
+	return cmd
+}
+
+func runContextSurfacingDryRun(prompt string, jsonOut bool) error {
+	if strings.TrimSpace(prompt) == "" {
+		return userError("No prompt given", "use: same hook context-surfacing --dry-run --prompt \"...\"")
+	}
+	db, err := store.Open()
+	if err != nil {
+		return config.ErrNoDatabase
+	}
+	defer db.Close()
+
+	preview := hooks.PreviewContextSurfacing(db, prompt)
+	if jsonOut {
+		data, _ := json.MarshalIndent(preview, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("  %sContext surfacing dry run%s\n\n", cli.Bold, cli.Reset)
+	fmt.Printf("  Prompt:  %s\n", truncateSnippet(prompt, 80))
+	if preview.Mode != "" {
+		recency := ""
+		if preview.Recency {
+			recency = " (recency query)"
+		}
+		fmt.Printf("  Mode:    %s%s\n", preview.Mode, recency)
+	}
+	fmt.Printf("  Result:  %s", preview.Status)
+	if preview.Reason != "" {
+		fmt.Printf(" %s(%s)%s", cli.Dim, preview.Reason, cli.Reset)
+	}
+	fmt.Println()
+
+	if len(preview.Notes) > 0 {
+		fmt.Printf("\n  %sCandidates%s %s(%d of %d tokens used)%s\n",
+			cli.Bold, cli.Reset, cli.Dim, preview.TotalTokens, preview.TokenBudget, cli.Reset)
+		for _, n := range preview.Notes {
+			marker := fmt.Sprintf("%s✓%s", cli.Green, cli.Reset)
+			detail := fmt.Sprintf("%d tokens", n.Tokens)
+			if !n.Included {
+				marker = fmt.Sprintf("%s✗%s", cli.Yellow, cli.Reset)
+				detail = "over budget"
+			}
+			if n.Pinned {
+				detail += ", pinned"
+			}
+			fmt.Printf("    %s %s %s(%s)%s\n", marker, n.Title, cli.Dim, n.Path, cli.Reset)
+			fmt.Printf("      composite %.3f  semantic %.3f  distance %.2f  %s\n",
+				n.Composite, n.Semantic, n.Distance, detail)
+		}
+	}
+
+	if preview.Context != "" {
+		fmt.Printf("\n  %sWould inject:%s\n", cli.Bold, cli.Reset)
+		fmt.Println(preview.Context)
+	}
+	if preview.SystemMessage != "" {
+		fmt.Printf("  %sSystem message:%s\n%s\n", cli.Bold, cli.Reset, preview.SystemMessage)
+	}
+	fmt.Println()
+	return nil
+}
+
 func hooksCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "hooks",
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/hooks"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func TestHooksCmd_ShowStatus(t *testing.T) {
@@ -25,3 +29,50 @@ func TestHooksCmd_ShowStatus(t *testing.T) {
 		t.Fatalf("expected session-bootstrap in output, got: %q", out)
 	}
 }
+
+func TestRunContextSurfacingDryRun_PrintsContextWithoutLogging(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/auth-middleware.md", "Auth middleware design",
+		"The auth middleware validates JWT tokens and refreshes expired sessions.")
+	_ = db.Close()
+
+	var runErr error
+	out := captureCommandStdout(t, func() {
+		runErr = runContextSurfacingDryRun("how does the auth middleware validate JWT tokens", true)
+	})
+	if runErr != nil {
+		t.Fatalf("dry run: %v", runErr)
+	}
+	var preview hooks.SurfacingPreview
+	if err := json.Unmarshal([]byte(out), &preview); err != nil {
+		t.Fatalf("parse dry run JSON: %v\n%s", err, out)
+	}
+	if preview.Status != "injected" || len(preview.Notes) == 0 {
+		t.Fatalf("expected an injection, got %+v", preview)
+	}
+	if preview.Notes[0].Path != "notes/auth-middleware.md" || !preview.Notes[0].Included {
+		t.Errorf("notes = %+v", preview.Notes)
+	}
+	if !strings.Contains(preview.Context, "<vault-context>") {
+		t.Errorf("context = %q, want a <vault-context> block", preview.Context)
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer db.Close()
+	usage, err := db.GetRecentUsage(10)
+	if err != nil {
+		t.Fatalf("GetRecentUsage: %v", err)
+	}
+	if len(usage) != 0 {
+		t.Errorf("dry run logged %d usage records, want 0", len(usage))
+	}
+}
+
+func TestRunContextSurfacingDryRun_RequiresPrompt(t *testing.T) {
+	if err := runContextSurfacingDryRun("  ", false); err == nil {
+		t.Fatal("expected an error for an empty prompt")
+	}
+}
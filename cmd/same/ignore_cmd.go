@@ -17,7 +17,12 @@ func ignoreCmd() *cobra.Command {
 		Use:   "ignore",
 		Short: "View or manage .sameignore patterns",
 		Long: `View and manage the .sameignore file that controls which files are excluded
-from SAME indexing. Works like .gitignore — glob patterns, one per line.
+from SAME indexing. Works like .gitignore — glob patterns, one per line,
+"!pattern" to re-include, "/pattern" to anchor to the file's directory.
+Subdirectories may contain their own .sameignore; its patterns apply
+relative to that directory. Both the indexer and 'same watch' honor them.
+
+_PRIVATE/ is always excluded and cannot be re-included with .sameignore.
 
 Examples:
   same ignore              Show current ignore patterns
@@ -2,14 +2,17 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -18,37 +21,86 @@ import (
 	"github.com/sgx-labs/statelessagent/internal/embedding"
 	"github.com/sgx-labs/statelessagent/internal/indexer"
 	"github.com/sgx-labs/statelessagent/internal/llm"
+	"github.com/sgx-labs/statelessagent/internal/setup"
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
+// reindexOptions controls a 'same reindex' run.
+type reindexOptions struct {
+	Force        bool   // re-embed all files regardless of changes
+	Verbose      bool   // one line per file instead of the progress bar
+	Quiet        bool   // no progress output, for scripts
+	ExtractFacts bool   // run LLM fact extraction after indexing
+	Path         string // reindex only this vault directory
+}
+
 func reindexCmd() *cobra.Command {
-	var (
-		force        bool
-		verbose      bool
-		extractFacts bool
-	)
+	var opts reindexOptions
 	cmd := &cobra.Command{
 		Use:     "reindex",
 		Aliases: []string{"index"},
 		Short:   "Scan your notes and rebuild the search index",
+		Long: `Scan your notes and rebuild the search index.
+
+Shows a progress bar while indexing. Press Ctrl+C to stop after the files
+in flight; notes indexed so far are kept, and the next run picks up the
+rest.
+
+Use --path to reindex one directory of the vault (relative to the vault
+root). Notes outside it are left as they are.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runReindex(force, verbose, extractFacts)
+			return runReindex(opts)
 		},
 	}
-	cmd.Flags().BoolVar(&force, "force", false, "Re-embed all files regardless of changes")
-	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show each file being processed")
-	cmd.Flags().BoolVar(&extractFacts, "extract-facts", false, "Extract atomic facts from notes (requires LLM, slow)")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Re-embed all files regardless of changes")
+	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Show each file being processed")
+	cmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Hide the progress bar (for scripts)")
+	cmd.Flags().BoolVar(&opts.ExtractFacts, "extract-facts", false, "Extract atomic facts from notes (requires LLM, slow)")
+	cmd.Flags().StringVar(&opts.Path, "path", "", "Only reindex notes under this vault directory")
+	cmd.MarkFlagsMutuallyExclusive("verbose", "quiet")
 	return cmd
 }
 
 func statsCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		hot     bool
+		limit   int
+		all     bool
+		jsonOut bool
+	)
+	cmd := &cobra.Command{
 		Use:   "stats",
 		Short: "Show how many notes are indexed",
+		Long: `Show index statistics.
+
+With --hot, list the notes pulling the most weight instead: the most
+accessed notes, the notes most often referenced after being surfaced, and
+notes that keep getting surfaced but are never referenced.
+
+With --all, show note and chunk counts, index age, and database size for
+every registered vault, plus totals.
+
+Examples:
+  same stats
+  same stats --hot
+  same stats --hot --limit 20
+  same stats --all --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if all {
+				return runStatsAll(jsonOut)
+			}
+			if hot {
+				return runStatsHot(limit)
+			}
 			return runStats()
 		},
 	}
+	cmd.Flags().BoolVar(&hot, "hot", false, "Show the most used and most referenced notes")
+	cmd.Flags().IntVar(&limit, "limit", 10, "Notes per list with --hot")
+	cmd.Flags().BoolVar(&all, "all", false, "Show stats for every registered vault")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON (with --all)")
+	cmd.MarkFlagsMutuallyExclusive("all", "hot")
+	return cmd
 }
 
 func migrateCmd() *cobra.Command {
@@ -56,7 +108,7 @@ func migrateCmd() *cobra.Command {
 		Use:   "migrate",
 		Short: "Rebuild index from scratch (replaces old data)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runReindex(true, false, false)
+			return runReindex(reindexOptions{Force: true})
 		},
 	}
 }
@@ -153,13 +205,22 @@ func acquireReindexLock() (func(), error) {
 	return cleanup, nil
 }
 
-func runReindex(force bool, verbose bool, extractFacts bool) error {
+func runReindex(opts reindexOptions) error {
+	force := opts.Force
 	db, err := store.Open()
 	if err != nil {
 		return userError("No SAME vault found", "Run 'same init' first.")
 	}
 	defer db.Close()
 
+	subtree := ""
+	if opts.Path != "" {
+		subtree, err = indexer.VaultSubtree(config.VaultPath(), opts.Path)
+		if err != nil {
+			return userError(fmt.Sprintf("Can't reindex: %v", err), "Give --path a directory inside the vault, relative to its root.")
+		}
+	}
+
 	// Early detection: check for embedding model/dimension mismatch before
 	// starting the reindex. If the model changed and --force is not set,
 	// warn and suggest --force so the user doesn't get garbage results.
@@ -170,6 +231,7 @@ func runReindex(force bool, verbose bool, extractFacts bool) error {
 		APIKey:     ec.APIKey,
 		BaseURL:    ec.BaseURL,
 		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
 		SkipRetry:  true,
 	}
 	if (provCfg.Provider == "ollama" || provCfg.Provider == "") && provCfg.BaseURL == "" {
@@ -192,6 +254,11 @@ func runReindex(force bool, verbose bool, extractFacts bool) error {
 	}
 	defer unlock()
 
+	// Snapshot the index first so 'same diff' can show what this run changed.
+	if err := saveIndexSnapshot(db); err != nil {
+		fmt.Fprintf(os.Stderr, "  ⚠ could not snapshot the index for 'same diff': %v\n", err)
+	}
+
 	// Set up context with signal handling for graceful cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -212,9 +279,11 @@ func runReindex(force bool, verbose bool, extractFacts bool) error {
 	defer signal.Stop(sigCh)
 
 	var liteProgress indexer.ProgressFunc
-	if verbose {
-		liteProgress = func(current, total int, path string) {
-			fmt.Printf("  [%d/%d] %s\n", current, total, path)
+	var embedProgress indexer.EmbeddingProgressFunc
+	if !opts.Quiet {
+		liteProgress = setup.IndexProgress(opts.Verbose)
+		embedProgress = func(completed, total int) {
+			fmt.Fprintf(os.Stderr, "\r  Embedding: %d/%d notes (keyword search active)\033[K", completed, total)
 		}
 	}
 
@@ -224,14 +293,19 @@ func runReindex(force bool, verbose bool, extractFacts bool) error {
 
 	// Progressive mode: FTS5 first (fast), then embeddings (slow).
 	// Keyword search works immediately after Phase 1.
-	embedProgress := func(completed, total int) {
-		fmt.Fprintf(os.Stderr, "\r  Embedding: %d/%d notes (keyword search active)", completed, total)
+	var stats *indexer.Stats
+	var embResult *indexer.EmbeddingProgress
+	if subtree != "" {
+		stats, embResult, err = indexer.ReindexSubtree(ctx, db, subtree, force, liteProgress, embedProgress)
+	} else {
+		stats, embResult, err = indexer.ReindexProgressive(ctx, db, force, liteProgress, embedProgress)
 	}
-
-	stats, embResult, err := indexer.ReindexProgressive(ctx, db, force, liteProgress, embedProgress)
 	if err != nil && !errors.Is(err, indexer.ErrCanceled) {
 		return fmt.Errorf("reindex failed: %w", err)
 	}
+	if !opts.Quiet && !opts.Verbose && stats != nil && stats.NewlyIndexed > 0 {
+		fmt.Println() // newline after progress bar
+	}
 
 	// Clear the embedding progress line if it was printed
 	if embResult != nil && embResult.Total > 0 {
@@ -246,21 +320,28 @@ func runReindex(force bool, verbose bool, extractFacts bool) error {
 
 	// Phase 3: Fact extraction (optional, requires LLM)
 	var factResult *indexer.FactExtractionProgress
-	if extractFacts && !errors.Is(err, indexer.ErrCanceled) {
+	if opts.ExtractFacts && !errors.Is(err, indexer.ErrCanceled) {
 		factResult = runFactExtraction(ctx, db)
 	}
 
 	fmt.Println()
 	if stats != nil && stats.Canceled {
-		fmt.Printf("  %sReindex canceled by user. %d of %d notes indexed.%s\n\n",
-			cli.Yellow, stats.NewlyIndexed, stats.TotalFiles, cli.Reset)
+		fmt.Printf("  %sReindex canceled by user. %d of %d notes indexed.%s\n",
+			cli.Yellow, stats.NewlyIndexed+stats.Resumed, stats.TotalFiles, cli.Reset)
+		fmt.Printf("  Run the same command again to pick up where it left off.\n\n")
 	} else {
 		fmt.Printf("  %sReindex complete%s\n\n", cli.Bold, cli.Reset)
 	}
 	if stats != nil {
+		if subtree != "" {
+			fmt.Printf("  Directory:       %s/\n", subtree)
+		}
 		fmt.Printf("  Files scanned:   %d\n", stats.TotalFiles)
 		fmt.Printf("  Newly indexed:   %d\n", stats.NewlyIndexed)
 		fmt.Printf("  Unchanged:       %d\n", stats.SkippedUnchanged)
+		if stats.Resumed > 0 {
+			fmt.Printf("  Resumed:         %d (done before the interruption)\n", stats.Resumed)
+		}
 		if stats.Errors > 0 {
 			fmt.Printf("  Errors:          %s%d%s\n", cli.Yellow, stats.Errors, cli.Reset)
 		}
@@ -317,6 +398,10 @@ func runFactExtraction(ctx context.Context, db *store.DB) *indexer.FactExtractio
 
 	factResult, factErr := indexer.BackfillFacts(ctx, db, chatClient, model, embedClient, factProgress)
 
+	if factResult != nil && factResult.Reembedded > 0 {
+		fmt.Fprintf(os.Stderr, "  Facts: %d re-embedded with the current model.\n", factResult.Reembedded)
+	}
+
 	// Clear progress line
 	if factResult != nil && factResult.Total > 0 {
 		fmt.Fprintf(os.Stderr, "\r%s\r", strings.Repeat(" ", 60))
@@ -371,3 +456,248 @@ func runStats() error {
 	fmt.Println()
 	return nil
 }
+
+// vaultStats is one registered vault's row in 'same stats --all'.
+type vaultStats struct {
+	Name            string `json:"name"`
+	Path            string `json:"path"`
+	Default         bool   `json:"default,omitempty"`
+	Indexed         bool   `json:"indexed"`
+	Notes           int    `json:"notes"`
+	Chunks          int    `json:"chunks"`
+	IndexAgeSeconds int64  `json:"index_age_seconds,omitempty"`
+	DBBytes         int64  `json:"db_bytes"`
+	Error           string `json:"error,omitempty"`
+}
+
+// statsTotals sums the indexed vaults in 'same stats --all'.
+type statsTotals struct {
+	Vaults  int   `json:"vaults"`
+	Indexed int   `json:"indexed"`
+	Notes   int   `json:"notes"`
+	Chunks  int   `json:"chunks"`
+	DBBytes int64 `json:"db_bytes"`
+}
+
+// collectVaultStats reads index stats for one vault. A vault without a
+// database is reported as not indexed; OpenPath is never called for it
+// because that would create an empty database.
+func collectVaultStats(name, path string) vaultStats {
+	vs := vaultStats{Name: name, Path: path}
+	dbPath := config.VaultDBPath(path)
+	info, err := os.Stat(dbPath)
+	if err != nil {
+		return vs
+	}
+	vs.Indexed = true
+	vs.DBBytes = info.Size()
+
+	db, err := store.OpenPath(dbPath)
+	if err != nil {
+		vs.Error = err.Error()
+		return vs
+	}
+	defer db.Close()
+	vs.Notes, _ = db.NoteCount()
+	vs.Chunks, _ = db.ChunkCount()
+	if age, _ := db.IndexAge(); age > 0 {
+		vs.IndexAgeSeconds = int64(age.Seconds())
+	}
+	return vs
+}
+
+func runStatsAll(jsonOut bool) error {
+	reg := config.LoadRegistry()
+	names := make([]string, 0, len(reg.Vaults))
+	for name := range reg.Vaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([]vaultStats, 0, len(names))
+	totals := statsTotals{Vaults: len(names)}
+	for _, name := range names {
+		vs := collectVaultStats(name, reg.Vaults[name])
+		vs.Default = name == reg.Default
+		rows = append(rows, vs)
+		if vs.Indexed {
+			totals.Indexed++
+			totals.Notes += vs.Notes
+			totals.Chunks += vs.Chunks
+			totals.DBBytes += vs.DBBytes
+		}
+	}
+
+	if jsonOut {
+		data, _ := json.MarshalIndent(struct {
+			Vaults []vaultStats `json:"vaults"`
+			Totals statsTotals  `json:"totals"`
+		}{rows, totals}, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(rows) == 0 {
+		return userError("No vaults registered", "Register one with: same vault add <name> <path>")
+	}
+
+	nameWidth := len("Vault")
+	for _, vs := range rows {
+		if n := len(vs.Name) + 2; n > nameWidth {
+			nameWidth = n
+		}
+	}
+	fmt.Println()
+	fmt.Printf("  %s%-*s  %8s  %8s  %10s  %9s%s\n", cli.Bold, nameWidth, "Vault", "Notes", "Chunks", "Indexed", "DB", cli.Reset)
+	for _, vs := range rows {
+		name := vs.Name
+		if vs.Default {
+			name += " *"
+		}
+		switch {
+		case !vs.Indexed:
+			fmt.Printf("  %-*s  %snot indexed — run 'same reindex' in %s%s\n", nameWidth, name, cli.Dim, cli.ShortenHome(vs.Path), cli.Reset)
+		case vs.Error != "":
+			fmt.Printf("  %-*s  %scan't open database: %s%s\n", nameWidth, name, cli.Red, vs.Error, cli.Reset)
+		default:
+			age := "-"
+			if vs.IndexAgeSeconds > 0 {
+				age = formatDuration(time.Duration(vs.IndexAgeSeconds)*time.Second) + " ago"
+			}
+			fmt.Printf("  %-*s  %8s  %8s  %10s  %6.1f MB\n", nameWidth, name,
+				cli.FormatNumber(vs.Notes), cli.FormatNumber(vs.Chunks), age, float64(vs.DBBytes)/(1024*1024))
+		}
+	}
+	fmt.Printf("  %-*s  %8s  %8s  %10s  %6.1f MB\n", nameWidth, "Total",
+		cli.FormatNumber(totals.Notes), cli.FormatNumber(totals.Chunks), "", float64(totals.DBBytes)/(1024*1024))
+	fmt.Printf("\n  %s%d of %d vault(s) indexed. * = default vault%s\n\n", cli.Dim, totals.Indexed, totals.Vaults, cli.Reset)
+	return nil
+}
+
+const (
+	// hotUsageSessions is how many recent sessions the referenced rate covers.
+	hotUsageSessions = 50
+	// hotMinInjections keeps one-off injections out of the rate rankings.
+	hotMinInjections = 3
+)
+
+// noteUsage summarizes how often a note was surfaced and then referenced.
+type noteUsage struct {
+	Path       string
+	Injected   int
+	Referenced int
+}
+
+func (u noteUsage) rate() float64 {
+	if u.Injected == 0 {
+		return 0
+	}
+	return float64(u.Referenced) / float64(u.Injected)
+}
+
+// aggregateNoteUsage counts injections and referenced injections per path.
+// Results are sorted by referenced rate, then by injection count.
+func aggregateNoteUsage(records []store.UsageRecord) []noteUsage {
+	byPath := make(map[string]*noteUsage)
+	for _, r := range records {
+		for _, p := range r.InjectedPaths {
+			if strings.HasPrefix(strings.ToUpper(p), "_PRIVATE/") {
+				continue
+			}
+			u, ok := byPath[p]
+			if !ok {
+				u = &noteUsage{Path: p}
+				byPath[p] = u
+			}
+			u.Injected++
+			if r.WasReferenced {
+				u.Referenced++
+			}
+		}
+	}
+	usage := make([]noteUsage, 0, len(byPath))
+	for _, u := range byPath {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].rate() != usage[j].rate() {
+			return usage[i].rate() > usage[j].rate()
+		}
+		if usage[i].Injected != usage[j].Injected {
+			return usage[i].Injected > usage[j].Injected
+		}
+		return usage[i].Path < usage[j].Path
+	})
+	return usage
+}
+
+func runStatsHot(limit int) error {
+	if limit <= 0 {
+		limit = 10
+	}
+	db, err := store.Open()
+	if err != nil {
+		return config.ErrNoDatabase
+	}
+	defer db.Close()
+
+	accessed, err := db.TopAccessedNotes(limit)
+	if err != nil {
+		return err
+	}
+	records, err := db.GetRecentUsage(hotUsageSessions)
+	if err != nil {
+		return fmt.Errorf("read usage: %w", err)
+	}
+	usage := aggregateNoteUsage(records)
+
+	fmt.Println()
+	fmt.Printf("  %sMost accessed%s\n\n", cli.Bold, cli.Reset)
+	if len(accessed) == 0 {
+		fmt.Printf("  %sNo notes have been accessed yet.%s\n", cli.Dim, cli.Reset)
+	}
+	for i, n := range accessed {
+		fmt.Printf("  %2d. %-40s %s%d accesses, confidence %.2f%s\n",
+			i+1, n.Path, cli.Dim, n.AccessCount, n.Confidence, cli.Reset)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %sMost referenced%s %s(last %d sessions, surfaced %d+ times)%s\n\n",
+		cli.Bold, cli.Reset, cli.Dim, hotUsageSessions, hotMinInjections, cli.Reset)
+	var referenced, ignored []noteUsage
+	for _, u := range usage {
+		if u.Injected < hotMinInjections {
+			continue
+		}
+		if u.Referenced > 0 {
+			referenced = append(referenced, u)
+		} else {
+			ignored = append(ignored, u)
+		}
+	}
+	if len(referenced) == 0 {
+		fmt.Printf("  %sNot enough usage data yet.%s\n", cli.Dim, cli.Reset)
+	}
+	for i, u := range referenced {
+		if i >= limit {
+			break
+		}
+		fmt.Printf("  %2d. %-40s %s%3.0f%% (%d of %d)%s\n",
+			i+1, u.Path, cli.Dim, u.rate()*100, u.Referenced, u.Injected, cli.Reset)
+	}
+
+	if len(ignored) > 0 {
+		sort.SliceStable(ignored, func(i, j int) bool { return ignored[i].Injected > ignored[j].Injected })
+		fmt.Println()
+		fmt.Printf("  %sSurfaced but never referenced%s\n\n", cli.Bold, cli.Reset)
+		for i, u := range ignored {
+			if i >= limit {
+				break
+			}
+			fmt.Printf("  %2d. %-40s %s%d times%s\n", i+1, u.Path, cli.Dim, u.Injected, cli.Reset)
+		}
+		fmt.Printf("\n  %sConsider 'same feedback \"<path>\" down' for notes that are not helping.%s\n", cli.Dim, cli.Reset)
+	}
+	fmt.Println()
+	return nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func TestRunReindex_NoVault(t *testing.T) {
@@ -31,7 +33,7 @@ func TestRunReindex_NoVault(t *testing.T) {
 	}
 	t.Cleanup(func() { _ = os.Chmod(vault, 0o700) })
 
-	err := runReindex(false, false, false)
+	err := runReindex(reindexOptions{})
 	if err == nil {
 		t.Fatal("expected reindex to fail without a valid vault")
 	}
@@ -166,3 +168,115 @@ func TestRunStats_WithVault(t *testing.T) {
 		t.Fatalf("expected stats header in output, got: %q", out)
 	}
 }
+
+func TestRunStatsAll_AggregatesRegisteredVaults(t *testing.T) {
+	vault, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/a.md", "A", "alpha")
+	insertCommandTestNote(t, db, "notes/b.md", "B", "beta")
+	_ = db.Close()
+
+	uninitialized := t.TempDir()
+	reg := &config.VaultRegistry{
+		Vaults:  map[string]string{"work": vault, "fresh": uninitialized},
+		Default: "work",
+	}
+	if err := reg.Save(); err != nil {
+		t.Fatalf("save registry: %v", err)
+	}
+
+	out := captureCommandStdout(t, func() {
+		if err := runStatsAll(false); err != nil {
+			t.Fatalf("stats --all: %v", err)
+		}
+	})
+	for _, want := range []string{"work *", "not indexed", "Total", "1 of 2 vault(s) indexed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(uninitialized, ".same")); !os.IsNotExist(err) {
+		t.Errorf("stats --all must not create a database in an uninitialized vault")
+	}
+
+	out = captureCommandStdout(t, func() {
+		if err := runStatsAll(true); err != nil {
+			t.Fatalf("stats --all --json: %v", err)
+		}
+	})
+	var got struct {
+		Vaults []vaultStats `json:"vaults"`
+		Totals statsTotals  `json:"totals"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("parse JSON: %v\n%s", err, out)
+	}
+	if len(got.Vaults) != 2 || got.Vaults[0].Name != "fresh" || got.Vaults[0].Indexed {
+		t.Fatalf("unexpected vault rows: %+v", got.Vaults)
+	}
+	if !got.Vaults[1].Default || got.Vaults[1].Notes != 2 {
+		t.Errorf("expected default vault with 2 notes, got %+v", got.Vaults[1])
+	}
+	if got.Totals.Vaults != 2 || got.Totals.Indexed != 1 || got.Totals.Notes != 2 || got.Totals.Chunks != 2 {
+		t.Errorf("unexpected totals: %+v", got.Totals)
+	}
+}
+
+func TestRunStatsHot_ListsAccessedAndReferencedNotes(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/used.md", "Used", "used often")
+	insertCommandTestNote(t, db, "notes/noise.md", "Noise", "never helps")
+	if err := db.SetAccessBoost("notes/used.md", 7); err != nil {
+		t.Fatalf("SetAccessBoost: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		rec := &store.UsageRecord{
+			SessionID:     fmt.Sprintf("s%d", i),
+			HookName:      "context_surfacing",
+			InjectedPaths: []string{"notes/used.md", "notes/noise.md"},
+			WasReferenced: i > 0,
+		}
+		if err := db.InsertUsage(rec); err != nil {
+			t.Fatalf("InsertUsage: %v", err)
+		}
+	}
+	rec := &store.UsageRecord{SessionID: "s9", HookName: "context_surfacing", InjectedPaths: []string{"notes/noise.md"}}
+	if err := db.InsertUsage(rec); err != nil {
+		t.Fatalf("InsertUsage: %v", err)
+	}
+	_ = db.Close()
+
+	var runErr error
+	out := captureCommandStdout(t, func() {
+		runErr = runStatsHot(5)
+	})
+	if runErr != nil {
+		t.Fatalf("runStatsHot: %v", runErr)
+	}
+	if !strings.Contains(out, "notes/used.md") || !strings.Contains(out, "7 accesses") {
+		t.Errorf("expected access ranking, got: %s", out)
+	}
+	if !strings.Contains(out, "(2 of 3)") {
+		t.Errorf("expected referenced rate, got: %s", out)
+	}
+	if !strings.Contains(out, "(2 of 4)") {
+		t.Errorf("expected noise referenced rate, got: %s", out)
+	}
+}
+
+func TestAggregateNoteUsage_SortsByRateAndSkipsPrivate(t *testing.T) {
+	records := []store.UsageRecord{
+		{InjectedPaths: []string{"a.md", "b.md", "_PRIVATE/x.md"}, WasReferenced: true},
+		{InjectedPaths: []string{"a.md"}},
+		{InjectedPaths: []string{"c.md"}},
+	}
+	usage := aggregateNoteUsage(records)
+	if len(usage) != 3 {
+		t.Fatalf("usage = %+v, want 3 paths", usage)
+	}
+	if usage[0].Path != "b.md" || usage[1].Path != "a.md" || usage[2].Path != "c.md" {
+		t.Errorf("order = %s, %s, %s; want b.md, a.md, c.md", usage[0].Path, usage[1].Path, usage[2].Path)
+	}
+	if usage[1].Injected != 2 || usage[1].Referenced != 1 {
+		t.Errorf("a.md = %+v, want 2 injected, 1 referenced", usage[1])
+	}
+}
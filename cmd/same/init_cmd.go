@@ -8,11 +8,14 @@ import (
 
 func initCmd() *cobra.Command {
 	var (
-		yes       bool
-		mcpOnly   bool
-		hooksOnly bool
-		verbose   bool
-		provider  string
+		yes         bool
+		mcpOnly     bool
+		hooksOnly   bool
+		verbose     bool
+		provider    string
+		model       string
+		baseURL     string
+		reconfigure bool
 	)
 	cmd := &cobra.Command{
 		Use:   "init",
@@ -25,16 +28,39 @@ What it does:
   3. Indexes them so your AI can search them
   4. Connects to your AI tools (Claude, Cursor, etc.)
 
-Run this command from inside your project folder.`,
+Run this command from inside your project folder.
+
+For scripted setup (onboarding scripts, devcontainers), pass the embedding
+settings as flags so no prompts are needed:
+
+  same init --yes --provider openai-compatible \
+    --base-url http://localhost:1234 --model nomic-embed-text
+  same init --yes --provider openai --model text-embedding-3-small
+  same init --yes --provider none
+
+To change the embedding provider or model of a vault that's already set
+up (say, you installed Ollama after starting in keyword-only mode), use
+--reconfigure. It updates the config and reindexes, keeping your index,
+hooks and MCP setup; notes are only re-embedded from scratch when the
+model changes:
+
+  same init --reconfigure
+  same init --reconfigure --yes --provider ollama`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return setup.RunInit(setup.InitOptions{
+			opts := setup.InitOptions{
 				Yes:       yes,
 				MCPOnly:   mcpOnly,
 				HooksOnly: hooksOnly,
 				Verbose:   verbose,
 				Version:   Version,
 				Provider:  provider,
-			})
+				Model:     model,
+				BaseURL:   baseURL,
+			}
+			if reconfigure {
+				return setup.RunReconfigure(opts)
+			}
+			return setup.RunInit(opts)
 		},
 	}
 	cmd.Flags().BoolVar(&yes, "yes", false, "Accept all defaults without prompting")
@@ -42,5 +68,10 @@ Run this command from inside your project folder.`,
 	cmd.Flags().BoolVar(&hooksOnly, "hooks-only", false, "Skip MCP setup (Claude Code only)")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show each file being processed")
 	cmd.Flags().StringVar(&provider, "provider", "", "Embedding provider: ollama, openai, openai-compatible, none")
+	cmd.Flags().StringVar(&model, "model", "", "Embedding model (skips auto-detection and the model picker)")
+	cmd.Flags().StringVar(&baseURL, "base-url", "", "Embedding endpoint for openai or openai-compatible providers")
+	cmd.Flags().BoolVar(&reconfigure, "reconfigure", false, "Change the embedding provider/model of an existing vault and reindex")
+	cmd.MarkFlagsMutuallyExclusive("reconfigure", "mcp-only")
+	cmd.MarkFlagsMutuallyExclusive("reconfigure", "hooks-only")
 	return cmd
 }
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/indexer"
+)
+
+func lintCmd() *cobra.Command {
+	var (
+		jsonOut bool
+		fix     bool
+	)
+	cmd := &cobra.Command{
+		Use:   "lint [path...]",
+		Short: "Check note frontmatter for fields the indexer can't use",
+		Long: `Check every note's frontmatter against the fields SAME reads:
+
+  errors    a field has the wrong shape (tags that aren't a list, a
+            confidence that isn't a number, broken YAML), which makes the
+            indexer drop all of the note's metadata
+  warnings  a value is ignored: unknown content_type, a date it can't
+            read, a misspelled key like contentType, or no title
+
+Keys SAME doesn't read are fine unless they look like a typo of one it does.
+
+--fix makes the safe normalizations in place: a single tag or a
+comma-separated string becomes a list, and keys that differ only in case
+or separators (Content-Type) are renamed. Reindex afterwards.
+
+Exits non-zero when any error remains.
+
+Examples:
+  same lint
+  same lint notes/auth.md
+  same lint --fix && same reindex
+  same lint --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLint(args, jsonOut, fix)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output issues as JSON")
+	cmd.Flags().BoolVar(&fix, "fix", false, "Apply safe fixes in place")
+	return cmd
+}
+
+// lintResult is one note's issues in 'same lint' output.
+type lintResult struct {
+	Path   string              `json:"path"`
+	Fixed  int                 `json:"fixed,omitempty"`
+	Issues []indexer.LintIssue `json:"issues"`
+}
+
+func runLint(args []string, jsonOut, fix bool) error {
+	vaultPath := config.VaultPath()
+	if vaultPath == "" {
+		return config.ErrNoVault
+	}
+	var files []string
+	if len(args) == 0 {
+		// Loading the config applies [vault] skip_dirs to the walk.
+		_, _ = config.LoadConfig()
+		files = indexer.WalkVaultWithIgnore(vaultPath)
+	} else {
+		for _, arg := range args {
+			abs, err := resolveNoteFile(arg)
+			if err != nil {
+				return err
+			}
+			files = append(files, abs)
+		}
+	}
+
+	var results []lintResult
+	errs, warnings, fixed := 0, 0, 0
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		res := lintResult{Path: filepath.ToSlash(relPathOr(vaultPath, path))}
+		content := string(data)
+		if fix {
+			if out, n := indexer.FixNote(content); n > 0 {
+				info, err := os.Stat(path)
+				if err != nil {
+					return fmt.Errorf("stat %s: %w", res.Path, err)
+				}
+				if err := os.WriteFile(path, []byte(out), info.Mode().Perm()); err != nil {
+					return fmt.Errorf("write %s: %w", res.Path, err)
+				}
+				content, res.Fixed = out, n
+				fixed += n
+			}
+		}
+		res.Issues = indexer.LintNote(content)
+		for _, is := range res.Issues {
+			if is.Severity == indexer.LintError {
+				errs++
+			} else {
+				warnings++
+			}
+		}
+		if len(res.Issues) > 0 || res.Fixed > 0 {
+			results = append(results, res)
+		}
+	}
+
+	if jsonOut {
+		if results == nil {
+			results = []lintResult{}
+		}
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		printLintResults(results, len(files), errs, warnings, fixed)
+	}
+	if errs > 0 {
+		return fmt.Errorf("lint: %d error(s)", errs)
+	}
+	return nil
+}
+
+func printLintResults(results []lintResult, notes, errs, warnings, fixed int) {
+	fmt.Println()
+	for _, r := range results {
+		for _, is := range r.Issues {
+			mark := cli.Yellow + "warning" + cli.Reset
+			if is.Severity == indexer.LintError {
+				mark = cli.Red + "error" + cli.Reset
+			}
+			hint := ""
+			if is.Fixable {
+				hint = cli.Dim + " (--fix)" + cli.Reset
+			}
+			fmt.Printf("  %s:%d: %s: %s%s\n", r.Path, is.Line, mark, is.Message, hint)
+		}
+	}
+	if fixed > 0 {
+		fmt.Printf("  %s✓%s Fixed %d issue(s). Run 'same reindex' to pick up the changes.\n", cli.Green, cli.Reset, fixed)
+	}
+	if errs == 0 && warnings == 0 {
+		fmt.Printf("  %s✓%s %d note(s), no frontmatter issues.\n\n", cli.Green, cli.Reset, notes)
+		return
+	}
+	fmt.Printf("\n  %d note(s) checked: %d error(s), %d warning(s)\n\n", notes, errs, warnings)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunLint_ReportsAndFixes(t *testing.T) {
+	vault, _ := setupCommandTestVault(t)
+	notes := map[string]string{
+		"good.md":  "---\ntitle: Good\ntags: [a]\ncontent_type: decision\n---\nbody\n",
+		"tags.md":  "---\ntitle: Tags\ntags: auth, security\n---\nbody\n",
+		"typed.md": "---\ntitle: Typed\ncontent_type: decisions\n---\nbody\n",
+	}
+	for name, content := range notes {
+		if err := os.WriteFile(filepath.Join(vault, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var err error
+	out := captureCommandStdout(t, func() { err = runLint(nil, true, false) })
+	if err == nil || !strings.Contains(err.Error(), "1 error") {
+		t.Fatalf("expected 1 lint error, got %v", err)
+	}
+	var results []lintResult
+	if jsonErr := json.Unmarshal([]byte(out), &results); jsonErr != nil {
+		t.Fatalf("parse JSON: %v\n%s", jsonErr, out)
+	}
+	if len(results) != 2 || results[0].Path != "tags.md" || results[1].Path != "typed.md" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	out = captureCommandStdout(t, func() { err = runLint(nil, false, true) })
+	if err != nil {
+		t.Fatalf("lint --fix left errors: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Fixed 1 issue(s)") || !strings.Contains(out, `typed.md:3: `) {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	data, _ := os.ReadFile(filepath.Join(vault, "tags.md"))
+	if !strings.Contains(string(data), "tags: [auth, security]\n") {
+		t.Errorf("tags not fixed:\n%s", data)
+	}
+
+	out = captureCommandStdout(t, func() { err = runLint([]string{"good.md"}, false, false) })
+	if err != nil || !strings.Contains(out, "no frontmatter issues") {
+		t.Errorf("lint good.md = %v\n%s", err, out)
+	}
+}
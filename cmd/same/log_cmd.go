@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -11,21 +15,35 @@ import (
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
+// logFollowInterval is how often --follow polls for new activity.
+const logFollowInterval = time.Second
+
 func logCmd() *cobra.Command {
 	var (
 		lastN   int
 		jsonOut bool
+		follow  bool
 	)
 	cmd := &cobra.Command{
 		Use:   "log",
 		Short: "Show recent SAME activity",
-		Long:  "Shows recent hook activity (context surfacing, decision extraction, handoff generation, and related hooks).",
+		Long: `Shows recent hook activity (context surfacing, decision extraction, handoff generation, and related hooks).
+
+With --follow, keeps running and prints new activity as hooks fire, like
+tail -f. Combined with --json, each entry is printed as one JSON object per
+line. Press Ctrl+C to stop.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if follow {
+				ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+				return runLogFollow(ctx, lastN, jsonOut, logFollowInterval)
+			}
 			return runLog(lastN, jsonOut)
 		},
 	}
 	cmd.Flags().IntVar(&lastN, "last", 20, "Number of recent hook entries to show")
 	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing new activity as it happens")
 	return cmd
 }
 
@@ -58,35 +76,91 @@ func runLog(lastN int, jsonOut bool) error {
 	fmt.Printf("\nRecent Activity (last %d entries):\n\n", min(lastN, len(entries)))
 
 	for _, entry := range entries {
-		ts := time.Unix(entry.TimestampUnix, 0).Local().Format("2006-01-02 15:04")
-		fmt.Printf("  %s  %-20s %-8s", ts, entry.HookName, entry.Status)
-
-		switch entry.Status {
-		case "injected":
-			noteWord := "notes"
-			if entry.SurfacedNotes == 1 {
-				noteWord = "note"
-			}
-			if entry.EstimatedTokens > 0 {
-				fmt.Printf("  %d %s  ~%d tokens\n", entry.SurfacedNotes, noteWord, entry.EstimatedTokens)
-			} else {
-				fmt.Printf("  %d %s\n", entry.SurfacedNotes, noteWord)
-			}
-		case "error":
-			if entry.ErrorMessage != "" {
-				fmt.Printf("  (%s)\n", entry.ErrorMessage)
-			} else {
-				fmt.Printf("\n")
-			}
-		default:
-			if entry.Detail != "" {
-				fmt.Printf("  (%s)\n", entry.Detail)
-			} else {
-				fmt.Printf("\n")
-			}
-		}
+		printLogEntry(entry)
 	}
 	fmt.Println()
 
 	return nil
 }
+
+// runLogFollow prints the last lastN entries oldest first, then polls for
+// new activity every interval until ctx is cancelled.
+func runLogFollow(ctx context.Context, lastN int, jsonOut bool, interval time.Duration) error {
+	db, err := store.Open()
+	if err != nil {
+		return config.ErrNoDatabase
+	}
+	defer db.Close()
+
+	recent, err := db.GetRecentHookActivity(lastN)
+	if err != nil {
+		return fmt.Errorf("query hook activity: %w", err)
+	}
+	var cursor int64
+	for i := len(recent) - 1; i >= 0; i-- {
+		emitFollowEntry(recent[i], jsonOut)
+		cursor = max(cursor, recent[i].RowID)
+	}
+	if !jsonOut {
+		fmt.Fprintln(os.Stderr, "  Watching for new activity (Ctrl+C to stop)...")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		entries, err := db.HookActivityAfter(cursor)
+		if err != nil {
+			return fmt.Errorf("query hook activity: %w", err)
+		}
+		for _, entry := range entries {
+			emitFollowEntry(entry, jsonOut)
+			cursor = entry.RowID
+		}
+	}
+}
+
+// emitFollowEntry prints one entry in follow mode: a compact JSON line with
+// --json, otherwise the same row format as runLog.
+func emitFollowEntry(entry store.HookActivityRecord, jsonOut bool) {
+	if jsonOut {
+		data, _ := json.Marshal(entry)
+		fmt.Println(string(data))
+		return
+	}
+	printLogEntry(entry)
+}
+
+func printLogEntry(entry store.HookActivityRecord) {
+	ts := time.Unix(entry.TimestampUnix, 0).Local().Format("2006-01-02 15:04")
+	fmt.Printf("  %s  %-20s %-8s", ts, entry.HookName, entry.Status)
+
+	switch entry.Status {
+	case "injected":
+		noteWord := "notes"
+		if entry.SurfacedNotes == 1 {
+			noteWord = "note"
+		}
+		if entry.EstimatedTokens > 0 {
+			fmt.Printf("  %d %s  ~%d tokens\n", entry.SurfacedNotes, noteWord, entry.EstimatedTokens)
+		} else {
+			fmt.Printf("  %d %s\n", entry.SurfacedNotes, noteWord)
+		}
+	case "error":
+		if entry.ErrorMessage != "" {
+			fmt.Printf("  (%s)\n", entry.ErrorMessage)
+		} else {
+			fmt.Printf("\n")
+		}
+	default:
+		if entry.Detail != "" {
+			fmt.Printf("  (%s)\n", entry.Detail)
+		} else {
+			fmt.Printf("\n")
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -111,3 +112,51 @@ func TestLogCmd_JSONOutput(t *testing.T) {
 		t.Fatalf("hook_name = %q, want staleness-check", entries[0].HookName)
 	}
 }
+
+func TestRunLogFollow_EmitsNewEntriesAsJSONLines(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	if err := db.InsertHookActivity(&store.HookActivityRecord{
+		HookName: "context-surfacing",
+		Status:   "skipped",
+		Detail:   "short prompt",
+	}); err != nil {
+		t.Fatalf("InsertHookActivity: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	out := captureCommandStdout(t, func() {
+		go func() {
+			done <- runLogFollow(ctx, 5, true, 10*time.Millisecond)
+		}()
+		time.Sleep(50 * time.Millisecond)
+		if err := db.InsertHookActivity(&store.HookActivityRecord{
+			HookName:      "context-surfacing",
+			Status:        "injected",
+			SurfacedNotes: 2,
+		}); err != nil {
+			t.Errorf("InsertHookActivity: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("runLogFollow: %v", err)
+		}
+	})
+	_ = db.Close()
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSON lines, got %d: %q", len(lines), out)
+	}
+	var first, second store.HookActivityRecord
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("parse first line: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("parse second line: %v", err)
+	}
+	if first.Status != "skipped" || second.Status != "injected" || second.SurfacedNotes != 2 {
+		t.Errorf("unexpected entries: %+v, %+v", first, second)
+	}
+}
@@ -92,6 +92,14 @@ func compareSemver(a, b string) int {
 
 // newEmbedProvider creates an embedding provider from config.
 func newEmbedProvider() (embedding.Provider, error) {
+	return newEmbedProviderForModel("")
+}
+
+// newEmbedProviderForModel is newEmbedProvider with the model replaced, for
+// staging embeddings before a switch. The configured dimensions belong to
+// the configured model, so they are dropped. An empty model keeps the
+// configured one.
+func newEmbedProviderForModel(model string) (embedding.Provider, error) {
 	ec := config.EmbeddingProviderConfig()
 	cfg := embedding.ProviderConfig{
 		Provider:   ec.Provider,
@@ -99,6 +107,11 @@ func newEmbedProvider() (embedding.Provider, error) {
 		APIKey:     ec.APIKey,
 		BaseURL:    ec.BaseURL,
 		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
+	}
+	if model != "" && model != ec.Model {
+		cfg.Model = model
+		cfg.Dimensions = 0
 	}
 
 	// Skip connection retries when the user hasn't explicitly configured
@@ -174,28 +187,41 @@ Need help? https://discord.gg/9KfTkcGs7g`,
 		addCmd(),
 		askCmd(),
 		briefCmd(),
+		contextCmd(),
 		relatedCmd(),
+		openCmd(),
+		tagsCmd(),
 		staleCmd(),
+		decisionsCmd(),
 		webCmd(),
 	)
 
 	addGrouped("knowledge",
 		pinCmd(),
+		archiveCmd(),
+		unarchiveCmd(),
+		handoffCmd(),
 		feedbackCmd(),
 		claimCmd(),
+		agentsCmd(),
 		importCmd(),
 		vaultCmd(),
 		graphCmd(),
 		factsCmd(),
 		consolidateCmd(),
+		dedupeCmd(),
+		mergeCmd(),
 		kaizenCmd(),
 	)
 
 	addGrouped("diagnostics",
 		statusCmd(),
 		doctorCmd(),
+		whichCmd(),
 		healthCmd(),
+		lintCmd(),
 		logCmd(),
+		verboseCmd(),
 		hooksCmd(),
 	)
 
@@ -204,8 +230,10 @@ Need help? https://discord.gg/9KfTkcGs7g`,
 		displayCmd(),
 		profileCmd(),
 		modelCmd(),
+		modelsCmd(),
 		setupSubCmd(),
 		reindexCmd(),
+		upgradeCmd(),
 		ignoreCmd(),
 		completionCmd(),
 	)
@@ -221,6 +249,7 @@ Need help? https://discord.gg/9KfTkcGs7g`,
 
 	addGrouped("diagnostics",
 		statsCmd(),
+		diffCmd(),
 		repairCmd(),
 		budgetCmd(),
 	)
@@ -1,26 +1,119 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
 	mcpserver "github.com/sgx-labs/statelessagent/internal/mcp"
 	memory "github.com/sgx-labs/statelessagent/internal/memory"
 	"github.com/sgx-labs/statelessagent/internal/store"
+	"github.com/sgx-labs/statelessagent/internal/web"
 )
 
 func mcpCmd() *cobra.Command {
-	return &cobra.Command{
+	var opts mcpHTTPOptions
+	cmd := &cobra.Command{
 		Use:   "mcp",
 		Short: "Start the AI tool integration server (MCP)",
-		Long:  "Start the SAME MCP server for tool integration with Claude Code, Cursor, Windsurf, and other MCP clients. This is typically started automatically by your AI tool — you rarely need to run it manually. Use 'same init' to configure MCP integration.",
+		Long: `Start the SAME MCP server for tool integration with Claude Code, Cursor, Windsurf, and other MCP clients. This is typically started automatically by your AI tool — you rarely need to run it manually. Use 'same init' to configure MCP integration.
+
+By default the server speaks MCP over stdio. With --http it serves the same
+tools over HTTP instead, for clients that can't spawn a subprocess:
+Streamable HTTP on /mcp and the older HTTP+SSE transport on /sse. Requests
+need a Bearer token (auth.token or SAME_MCP_TOKEN; a session-only token is
+generated otherwise). The server binds to 127.0.0.1 unless --host names
+another interface, which also requires --allow-remote.
+
+--read-only (or [mcp] read_only = true) leaves out every tool that writes
+notes or touches the index, for shared or untrusted setups.
+
+--log <file> records each tool call (name, arguments, result size,
+latency, error) as a JSON line. A relative file name is placed in the
+vault's data directory. Note bodies and _PRIVATE/ paths are never logged.
+
+Examples:
+  same mcp --http
+  same mcp --http --port 9000
+  same mcp --http --host 0.0.0.0 --allow-remote
+  same mcp --read-only
+  same mcp --log mcp.log`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			mcpserver.Version = Version + "+" + CommitHash
-			return mcpserver.Serve()
+			mcpserver.ReadOnly = opts.ReadOnly
+			mcpserver.LogPath = opts.LogPath
+			if !opts.HTTP {
+				return mcpserver.Serve()
+			}
+			return runMCPHTTP(cmd.Context(), opts)
 		},
 	}
+	cmd.Flags().BoolVar(&opts.HTTP, "http", false, "Serve over HTTP (Streamable HTTP and SSE) instead of stdio")
+	cmd.Flags().IntVar(&opts.Port, "port", 4079, "Port for --http")
+	cmd.Flags().StringVar(&opts.Host, "host", "127.0.0.1", "Interface for --http to bind")
+	cmd.Flags().BoolVar(&opts.AllowRemote, "allow-remote", false, "Allow --host to be a non-loopback interface")
+	cmd.Flags().BoolVar(&opts.ReadOnly, "read-only", false, "Register only read tools (no saving, deleting, or reindexing)")
+	cmd.Flags().StringVar(&opts.LogPath, "log", "", "Log each tool call to this file (relative paths are under the data dir)")
+	return cmd
+}
+
+type mcpHTTPOptions struct {
+	HTTP        bool
+	Port        int
+	Host        string
+	AllowRemote bool
+	ReadOnly    bool
+	LogPath     string
+}
+
+func runMCPHTTP(ctx context.Context, opts mcpHTTPOptions) error {
+	if !isLoopbackHost(opts.Host) && !opts.AllowRemote {
+		return userError(
+			fmt.Sprintf("Refusing to expose MCP on %s", opts.Host),
+			"the vault would be reachable from other machines; pass --allow-remote to confirm",
+		)
+	}
+	addr := net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port))
+
+	db, err := mcpserver.InitGlobals()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	token := config.AuthToken()
+	if token == "" {
+		token = generateToken()
+		fmt.Fprintf(os.Stderr, "  Generated MCP auth token (session-only): %s\n", token)
+		fmt.Fprintf(os.Stderr, "  %sSet SAME_MCP_TOKEN or auth.token in config for a persistent token%s\n\n", cli.Dim, cli.Reset)
+	}
+	printMCPConfigSnippets(fmt.Sprintf("http://%s/mcp", addr), token)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return web.ServeMCP(ctx, addr, web.MCPOptions{Server: mcpserver.NewMCPServer(), Token: token}, opts.AllowRemote)
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback IP.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
 }
 
 func budgetCmd() *cobra.Command {
@@ -28,21 +121,66 @@ func budgetCmd() *cobra.Command {
 		sessionID string
 		lastN     int
 		jsonOut   bool
+		export    string
+		outPath   string
 	)
 	cmd := &cobra.Command{
 		Use:   "budget",
 		Short: "Show context utilization metrics",
-		Long:  "Analyze how much injected context Claude actually used. Tracks injection events and reference detection.",
+		Long: `Analyze how much injected context Claude actually used. Tracks injection events and reference detection.
+
+Use --export csv to write one row per session (hook "all") plus one row per
+hook in that session, for charting token waste in a spreadsheet.
+
+Examples:
+  same budget
+  same budget --last 50 --export csv --out budget.csv`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if export != "" {
+				return runBudgetExport(sessionID, lastN, export, outPath)
+			}
+			if outPath != "" {
+				return userError("--out requires --export", "use: same budget --export csv --out budget.csv")
+			}
 			return runBudget(sessionID, lastN, jsonOut)
 		},
 	}
 	cmd.Flags().StringVar(&sessionID, "session", "", "Report for a specific session ID")
 	cmd.Flags().IntVar(&lastN, "last", 10, "Report for last N sessions")
 	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	cmd.Flags().StringVar(&export, "export", "", "Export per-session and per-hook rows (csv)")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write the export to a file instead of stdout")
 	return cmd
 }
 
+func runBudgetExport(sessionID string, lastN int, format, outPath string) error {
+	if !strings.EqualFold(format, "csv") {
+		return userError(fmt.Sprintf("Unknown export format %q", format), "supported formats: csv")
+	}
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	if outPath == "" {
+		return memory.WriteBudgetCSV(os.Stdout, db, sessionID, lastN)
+	}
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", outPath, err)
+	}
+	if err := memory.WriteBudgetCSV(f, db, sessionID, lastN); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", outPath, err)
+	}
+	fmt.Fprintf(os.Stderr, "  %s✓%s Budget exported → %s\n", cli.Green, cli.Reset, outPath)
+	return nil
+}
+
 func runBudget(sessionID string, lastN int, jsonOut bool) error {
 	db, err := store.Open()
 	if err != nil {
@@ -67,6 +205,7 @@ func runBudget(sessionID string, lastN int, jsonOut bool) error {
 		fmt.Printf("  Total tokens injected: %d\n", r.TotalTokensInjected)
 		fmt.Printf("  Referenced by Claude:   %d (%.0f%%)\n", r.ReferencedCount, r.UtilizationRate*100)
 		fmt.Printf("  Wasted tokens:         ~%d\n", r.TotalTokensInjected-int(float64(r.TotalTokensInjected)*r.UtilizationRate))
+		fmt.Printf("  Surfacing budget:      %d tokens per prompt (surfacing.max_token_budget)\n", r.TokenBudget)
 
 		if len(r.PerHook) > 0 {
 			fmt.Println("\n  Per-hook breakdown:")
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func TestMCPCmd_NoVault(t *testing.T) {
@@ -24,3 +27,67 @@ func TestMCPCmd_NoVault(t *testing.T) {
 		t.Fatalf("expected vault init error, got: %v", err)
 	}
 }
+
+func TestRunBudgetExport_WritesSessionAndHookRows(t *testing.T) {
+	vault, db := setupCommandTestVault(t)
+	for _, rec := range []store.UsageRecord{
+		{SessionID: "s1", Timestamp: "2026-01-01T10:00:00Z", HookName: "context_surfacing", EstimatedTokens: 300, WasReferenced: true},
+		{SessionID: "s1", Timestamp: "2026-01-01T10:05:00Z", HookName: "session_bootstrap", EstimatedTokens: 100},
+		{SessionID: "s2", Timestamp: "2026-01-02T09:00:00Z", HookName: "context_surfacing", EstimatedTokens: 200},
+	} {
+		rec := rec
+		if err := db.InsertUsage(&rec); err != nil {
+			t.Fatalf("InsertUsage: %v", err)
+		}
+	}
+	_ = db.Close()
+
+	outPath := filepath.Join(vault, "budget.csv")
+	if err := runBudgetExport("", 10, "csv", outPath); err != nil {
+		t.Fatalf("runBudgetExport: %v", err)
+	}
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	want := []string{
+		"session_id,started,hook,injections,referenced,utilization_rate,total_tokens,avg_tokens_per_injection,wasted_tokens",
+		"s1,2026-01-01T10:00:00Z,all,2,1,0.500,400,200,200",
+		"s1,2026-01-01T10:00:00Z,context_surfacing,1,1,1.000,300,300,0",
+		"s1,2026-01-01T10:00:00Z,session_bootstrap,1,0,0.000,100,100,100",
+		"s2,2026-01-02T09:00:00Z,all,1,0,0.000,200,200,200",
+		"s2,2026-01-02T09:00:00Z,context_surfacing,1,0,0.000,200,200,200",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), data)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestRunBudgetExport_RejectsUnknownFormat(t *testing.T) {
+	setupCommandTestVault(t)
+	if err := runBudgetExport("", 10, "xlsx", ""); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
+
+func TestRunMCPHTTP_RefusesRemoteHostWithoutOptIn(t *testing.T) {
+	err := runMCPHTTP(t.Context(), mcpHTTPOptions{HTTP: true, Port: 4079, Host: "0.0.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "--allow-remote") {
+		t.Fatalf("expected --allow-remote error, got %v", err)
+	}
+
+	for host, want := range map[string]bool{
+		"127.0.0.1": true, "localhost": true, "::1": true, "[::1]": true,
+		"0.0.0.0": false, "192.168.1.5": false, "example.com": false,
+	} {
+		if got := isLoopbackHost(host); got != want {
+			t.Errorf("isLoopbackHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/indexer"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func mergeCmd() *cobra.Command {
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "merge <src> <dst>",
+		Short: "Merge one note into another and archive the original",
+		Long: `Fold a duplicate note into the one you want to keep:
+  1. paragraphs of <src> that <dst> doesn't already contain are appended
+     to <dst> under a "Merged from" heading
+  2. [[wikilinks]] and Markdown links pointing at <src> are rewritten to
+     point at <dst>
+  3. <src> is archived: it stays on disk but is no longer surfaced
+  4. <dst> and every rewritten note are reindexed
+
+The changes are shown as a diff and applied only after you confirm.
+'same dedupe' lists candidate pairs; undo the archive with 'same unarchive'.
+
+Examples:
+  same merge handoffs/sprint.md handoffs/sprint-v2.md
+  same merge notes/auth-old.md notes/auth.md --yes`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMerge(args[0], args[1], yes, os.Stdin)
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Apply without asking for confirmation")
+	return cmd
+}
+
+// mergeEdit is one file a merge rewrites.
+type mergeEdit struct {
+	rel, abs string
+	before   string
+	after    string
+	links    int // links retargeted in this file
+}
+
+// mergePlan is everything 'same merge' will change, computed up front so it
+// can be shown before anything is written.
+type mergePlan struct {
+	src, dst string // vault-relative
+	appended int    // paragraphs of src added to dst
+	edits    []mergeEdit
+}
+
+// planMerge works out the merge of src into dst without writing anything.
+// Both paths are vault-relative and must name existing notes.
+func planMerge(db *store.DB, src, dst string) (*mergePlan, error) {
+	srcAbs, err := resolveNoteFile(src)
+	if err != nil {
+		return nil, err
+	}
+	dstAbs, err := resolveNoteFile(dst)
+	if err != nil {
+		return nil, err
+	}
+	src, dst = vaultRelPath(srcAbs), vaultRelPath(dstAbs)
+	if src == dst {
+		return nil, userError("Can't merge a note into itself", "Pass two different notes: same merge <src> <dst>")
+	}
+
+	srcData, err := os.ReadFile(srcAbs)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", src, err)
+	}
+	dstData, err := os.ReadFile(dstAbs)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", dst, err)
+	}
+
+	plan := &mergePlan{src: src, dst: dst}
+	merged := string(dstData)
+	if blocks := uniqueBlocks(indexer.ParseNote(string(srcData)).Body, merged); len(blocks) > 0 {
+		plan.appended = len(blocks)
+		merged = strings.TrimRight(merged, "\n") + "\n\n## Merged from " + src + "\n\n" + strings.Join(blocks, "\n\n") + "\n"
+	}
+
+	resolver, err := db.NoteLinkResolver()
+	if err != nil {
+		return nil, err
+	}
+	// dst itself may link to src, including in the paragraphs just appended.
+	merged, n := resolver.RetargetLinks(merged, dst, src, dst)
+	plan.edits = append(plan.edits, mergeEdit{rel: dst, abs: dstAbs, before: string(dstData), after: merged, links: n})
+
+	states, err := db.NoteStates()
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	paths := make([]string, 0, len(states))
+	for _, s := range states {
+		if s.Path != src && s.Path != dst {
+			paths = append(paths, s.Path)
+		}
+	}
+	links, err := db.NoteLinksFrom(paths)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range paths {
+		linksToSrc := false
+		for _, l := range links[p] {
+			if target, ok := resolver.Resolve(p, l); ok && target == src {
+				linksToSrc = true
+				break
+			}
+		}
+		if !linksToSrc {
+			continue
+		}
+		abs, ok := config.SafeVaultSubpath(p)
+		if !ok {
+			continue
+		}
+		data, err := os.ReadFile(abs)
+		if err != nil {
+			continue // indexed but gone from disk; the next reindex drops it
+		}
+		after, n := resolver.RetargetLinks(string(data), p, src, dst)
+		if n > 0 {
+			plan.edits = append(plan.edits, mergeEdit{rel: p, abs: abs, before: string(data), after: after, links: n})
+		}
+	}
+	return plan, nil
+}
+
+// uniqueBlocks returns the paragraphs of body, split on blank lines, that
+// don't already appear in existing. Whitespace differences are ignored.
+func uniqueBlocks(body, existing string) []string {
+	have := strings.Join(strings.Fields(existing), " ")
+	var out []string
+	seen := make(map[string]bool)
+	for _, block := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n\n") {
+		block = strings.Trim(block, "\n")
+		norm := strings.Join(strings.Fields(block), " ")
+		if norm == "" || seen[norm] || strings.Contains(have, norm) {
+			continue
+		}
+		seen[norm] = true
+		out = append(out, block)
+	}
+	return out
+}
+
+// print shows the plan as a line diff per file.
+func (p *mergePlan) print() {
+	fmt.Printf("\n  %sMerge %s → %s%s\n", cli.Bold, p.src, p.dst, cli.Reset)
+	for _, e := range p.edits {
+		if e.before == e.after {
+			continue
+		}
+		detail := fmt.Sprintf("%d link(s) retargeted", e.links)
+		if e.rel == p.dst {
+			detail = fmt.Sprintf("%d paragraph(s) appended, %s", p.appended, detail)
+		}
+		fmt.Printf("\n  %s %s(%s)%s\n", e.rel, cli.Dim, detail, cli.Reset)
+		printLineDiff(e.before, e.after)
+	}
+	fmt.Printf("\n  %s archived %s(stays on disk; undo with 'same unarchive %s')%s\n", p.src, cli.Dim, p.src, cli.Reset)
+}
+
+// printLineDiff prints the lines that differ between before and after,
+// after trimming the common prefix and suffix.
+func printLineDiff(before, after string) {
+	a := strings.Split(before, "\n")
+	b := strings.Split(after, "\n")
+	start := 0
+	for start < len(a) && start < len(b) && a[start] == b[start] {
+		start++
+	}
+	endA, endB := len(a), len(b)
+	for endA > start && endB > start && a[endA-1] == b[endB-1] {
+		endA--
+		endB--
+	}
+	for _, l := range a[start:endA] {
+		fmt.Printf("    %s- %s%s\n", cli.Red, l, cli.Reset)
+	}
+	for _, l := range b[start:endB] {
+		fmt.Printf("    %s+ %s%s\n", cli.Green, l, cli.Reset)
+	}
+}
+
+// apply writes the edited files, archives src, and reindexes what changed.
+// Index failures are reported but not fatal: the files are already written
+// and the next 'same reindex' picks them up.
+func (p *mergePlan) apply(db *store.DB) error {
+	for _, e := range p.edits {
+		if e.before == e.after {
+			continue
+		}
+		info, err := os.Stat(e.abs)
+		if err != nil {
+			return fmt.Errorf("stat %s: %w", e.rel, err)
+		}
+		if err := os.WriteFile(e.abs, []byte(e.after), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("write %s: %w", e.rel, err)
+		}
+	}
+	if _, err := db.ArchiveNote(p.src); err != nil {
+		return fmt.Errorf("archive %s: %w", p.src, err)
+	}
+
+	vaultPath := config.VaultPath()
+	client, provErr := newEmbedProvider()
+	for _, e := range p.edits {
+		if e.before == e.after {
+			continue
+		}
+		var err error
+		if provErr == nil {
+			err = indexer.IndexSingleFile(db, e.abs, e.rel, vaultPath, client)
+		} else {
+			err = indexer.IndexSingleFileLite(db, e.abs, e.rel, vaultPath)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %sWarning: %s saved but not indexed: %v — run 'same reindex' to fix%s\n", cli.Yellow, e.rel, err, cli.Reset)
+		}
+	}
+	return nil
+}
+
+// vaultRelPath returns abs relative to the vault root, with forward slashes.
+func vaultRelPath(abs string) string {
+	root, err := filepath.Abs(config.VaultPath())
+	if err != nil {
+		return filepath.ToSlash(abs)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return filepath.ToSlash(abs)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func runMerge(src, dst string, yes bool, in io.Reader) error {
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	plan, err := planMerge(db, src, dst)
+	if err != nil {
+		return err
+	}
+	plan.print()
+
+	if !yes {
+		fmt.Printf("\n  Apply? [y/N] ")
+		answer, _ := bufio.NewReader(in).ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("  Nothing changed.")
+			return nil
+		}
+	}
+	if err := plan.apply(db); err != nil {
+		return err
+	}
+	fmt.Printf("\n  %s✓%s Merged %s into %s\n\n", cli.Green, cli.Reset, plan.src, plan.dst)
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/indexer"
+)
+
+func TestRunMerge_AppendsRetargetsAndArchives(t *testing.T) {
+	vault, db := setupCommandTestVault(t)
+	files := map[string]string{
+		"notes/auth-old.md": "# Auth\n\nWe use JWT tokens.\n\nRefresh tokens rotate daily.\n",
+		"notes/auth.md":     "# Auth\n\nWe use JWT tokens.\n",
+		"notes/index.md":    "See [[auth-old]] and [old](auth-old.md#refresh).\n",
+	}
+	for rel, content := range files {
+		abs := filepath.Join(vault, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(abs, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := indexer.IndexSingleFileLite(db, abs, rel, vault); err != nil {
+			t.Fatalf("index %s: %v", rel, err)
+		}
+	}
+	_ = db.Close()
+
+	out := captureCommandStdout(t, func() {
+		if err := runMerge("notes/auth-old.md", "notes/auth.md", false, strings.NewReader("n\n")); err != nil {
+			t.Fatalf("merge: %v", err)
+		}
+	})
+	if !strings.Contains(out, "+ Refresh tokens rotate daily.") || !strings.Contains(out, "Nothing changed") {
+		t.Fatalf("expected diff and no changes on 'n', got:\n%s", out)
+	}
+	if data, _ := os.ReadFile(filepath.Join(vault, "notes", "auth.md")); string(data) != files["notes/auth.md"] {
+		t.Fatalf("declined merge modified dst:\n%s", data)
+	}
+
+	captureCommandStdout(t, func() {
+		if err := runMerge("notes/auth-old.md", "notes/auth.md", false, strings.NewReader("y\n")); err != nil {
+			t.Fatalf("merge: %v", err)
+		}
+	})
+	dst, _ := os.ReadFile(filepath.Join(vault, "notes", "auth.md"))
+	if strings.Count(string(dst), "We use JWT tokens.") != 1 || !strings.Contains(string(dst), "## Merged from notes/auth-old.md\n\nRefresh tokens rotate daily.") {
+		t.Errorf("unexpected merged dst:\n%s", dst)
+	}
+	index, _ := os.ReadFile(filepath.Join(vault, "notes", "index.md"))
+	if want := "See [[auth]] and [old](auth.md#refresh).\n"; string(index) != want {
+		t.Errorf("links not retargeted:\n%s\nwant\n%s", index, want)
+	}
+	if _, err := os.Stat(filepath.Join(vault, "notes", "auth-old.md")); err != nil {
+		t.Errorf("src should stay on disk: %v", err)
+	}
+
+	out = captureCommandStdout(t, func() {
+		if err := runArchiveList(); err != nil {
+			t.Fatalf("archive list: %v", err)
+		}
+	})
+	if !strings.Contains(out, "notes/auth-old.md") {
+		t.Errorf("expected src archived, got:\n%s", out)
+	}
+
+	if err := runMerge("notes/auth.md", "notes/auth.md", true, strings.NewReader("")); err == nil || !strings.Contains(err.Error(), "into itself") {
+		t.Errorf("expected self-merge error, got %v", err)
+	}
+}
+
+func TestUniqueBlocks(t *testing.T) {
+	got := uniqueBlocks("One.\n\nTwo  lines\nhere.\n\nOne.\n\n\nThree.", "One.\nTwo lines here.")
+	if len(got) != 1 || got[0] != "Three." {
+		t.Errorf("uniqueBlocks = %q, want [Three.]", got)
+	}
+}
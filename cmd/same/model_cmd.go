@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
 	"github.com/sgx-labs/statelessagent/internal/cli"
 	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/indexer"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func modelCmd() *cobra.Command {
@@ -15,15 +23,23 @@ func modelCmd() *cobra.Command {
 		Short: "Show or switch embedding models",
 		Long: `Show the current embedding model and available alternatives.
 
+To change models without a window where semantic search is broken, stage
+the new model first. Staging embeds every note with the new model next to
+the current vectors; search keeps using the current model until you switch.
+Once staging is complete, 'same model use' switches atomically, with no
+reindex. The old vectors are kept, so switching back is just as quick.
+
 Example:
-  same model                              Show current model
-  same model use snowflake-arctic-embed2  Switch to a different model`,
+  same model                                Show current model
+  same model stage snowflake-arctic-embed2  Embed notes with a new model
+  same model use snowflake-arctic-embed2    Switch to a different model`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return showCurrentModel()
 		},
 	}
 
 	cmd.AddCommand(modelUseCmd())
+	cmd.AddCommand(modelStageCmd())
 	return cmd
 }
 
@@ -38,6 +54,148 @@ func modelUseCmd() *cobra.Command {
 	}
 }
 
+func modelStageCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stage [model]",
+		Short: "Embed all notes with a new model before switching to it",
+		Long: `Embed every indexed note with another model from the current provider,
+keeping the vectors alongside the active ones. Search is unaffected while
+this runs. Interrupt it at any time; running it again picks up where it
+stopped. When it finishes, switch with 'same model use <model>'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return stageModel(args[0])
+		},
+	}
+}
+
+func stageModel(model string) error {
+	if config.VaultPath() == "" {
+		return config.ErrNoVault
+	}
+	client, err := newEmbedProviderForModel(model)
+	if err != nil {
+		return userError(fmt.Sprintf("Can't stage %s: %v", model, err), "Check your [embedding] provider settings with 'same config show'.")
+	}
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("\n  Staging %s%s%s embeddings (search keeps using the current model)...\n", cli.Bold, model, cli.Reset)
+	res, err := indexer.StageEmbeddings(ctx, db, client, func(completed, total int) {
+		fmt.Fprintf(os.Stderr, "\r  Embedding: %d/%d notes\033[K", completed, total)
+	})
+	if res != nil && res.Total > 0 {
+		fmt.Fprintf(os.Stderr, "\r%s\r", strings.Repeat(" ", 60))
+	}
+	if errors.Is(err, indexer.ErrCanceled) {
+		fmt.Printf("  Staging paused: %d/%d notes done. Resume with 'same model stage %s'.\n\n", res.Completed, res.Total, model)
+		return nil
+	}
+	if err != nil {
+		return userError(fmt.Sprintf("Can't stage %s: %v", model, err), "")
+	}
+	if res.Failed > 0 {
+		fmt.Printf("  %s!%s %d notes failed to embed. Run 'same model stage %s' again to retry them.\n\n",
+			cli.Yellow, cli.Reset, res.Failed, model)
+		return nil
+	}
+	fmt.Printf("  %s✓%s All notes embedded with %s.\n", cli.Green, cli.Reset, model)
+	fmt.Printf("\n  Switch with: %ssame model use %s%s\n\n", cli.Bold, model, cli.Reset)
+	return nil
+}
+
+// stagedFactsNote tells the user what happens to extracted facts after
+// switching to a staged embedding set.
+const stagedFactsNote = "  Extracted facts, if any, are kept; 'same reindex --extract-facts' re-embeds them with the new model."
+
+// switchEmbeddingModel runs write, which points the config at model, then
+// activates a fully staged set for model if there is one. The config is
+// written first so a failed write can't leave the index on a model the
+// config doesn't name; if activation fails, the config file at cfgPath is
+// put back as it was.
+func switchEmbeddingModel(cfgPath, model string, write func() error) (bool, error) {
+	prev, readErr := os.ReadFile(cfgPath)
+	if err := write(); err != nil {
+		return false, err
+	}
+	switched, err := activateStagedModel(model)
+	if err != nil {
+		switch {
+		case readErr == nil:
+			_ = os.WriteFile(cfgPath, prev, 0o600)
+		case os.IsNotExist(readErr):
+			_ = os.Remove(cfgPath)
+		}
+		return false, err
+	}
+	return switched, nil
+}
+
+// activateStagedModel switches the index to a fully staged set for model,
+// if there is one. It reports whether it switched. An incomplete staged set
+// is an error rather than a fallback to reindexing, since the user staged
+// it to avoid exactly that.
+func activateStagedModel(model string) (bool, error) {
+	if config.VaultPath() == "" {
+		return false, nil
+	}
+	client, err := newEmbedProviderForModel(model)
+	if err != nil {
+		return false, nil
+	}
+	db, err := store.Open()
+	if err != nil {
+		return false, dbOpenError(err)
+	}
+	defer db.Close()
+
+	err = db.ActivateEmbeddingSet(client.Name(), model)
+	if errors.Is(err, store.ErrNoStagedEmbeddings) {
+		return false, nil
+	}
+	if err != nil {
+		return false, userError(fmt.Sprintf("Can't switch to %s: %v", model, err),
+			fmt.Sprintf("Run 'same model stage %s' to finish embedding, then try again.", model))
+	}
+	return true, nil
+}
+
+// printEmbeddingSets lists the embedding sets stored in the index, if any
+// besides the active one.
+func printEmbeddingSets() {
+	if config.VaultPath() == "" {
+		return
+	}
+	db, err := store.Open()
+	if err != nil {
+		return
+	}
+	defer db.Close()
+	sets, err := db.EmbeddingSets()
+	if err != nil || len(sets) < 2 {
+		return
+	}
+
+	fmt.Printf("\n  %sEmbedding sets:%s\n\n", cli.Bold, cli.Reset)
+	for _, s := range sets {
+		status := "staged"
+		switch {
+		case s.Active:
+			status = "active"
+		case s.Missing == 0 && s.Vectors > 0:
+			status = "staged, ready to switch"
+		}
+		fmt.Printf("    %-28s %d/%d notes  %s%s%s\n",
+			s.Provider+"/"+s.Model, s.Vectors, s.Vectors+s.Missing, cli.Dim, status, cli.Reset)
+	}
+}
+
 func showCurrentModel() error {
 	ec := config.EmbeddingProviderConfig()
 
@@ -73,7 +231,10 @@ func showCurrentModel() error {
 			marker, m.Name, m.Dims, cli.Dim, m.Description, cli.Reset)
 	}
 
-	fmt.Printf("\n  Switch with: %ssame model use <name>%s\n\n", cli.Bold, cli.Reset)
+	printEmbeddingSets()
+
+	fmt.Printf("\n  Switch with: %ssame model use <name>%s\n", cli.Bold, cli.Reset)
+	fmt.Printf("  Stage first to switch without reindexing: %ssame model stage <name>%s\n\n", cli.Bold, cli.Reset)
 	return nil
 }
 
@@ -89,7 +250,20 @@ func setModel(model string) error {
 		currentModel = config.EmbeddingModel
 	}
 
-	if model == currentModel {
+	switched, err := switchEmbeddingModel(config.ConfigFilePath(vp), model, func() error {
+		if model == currentModel {
+			return nil
+		}
+		if err := config.SetEmbeddingModel(vp, model); err != nil {
+			return fmt.Errorf("update config: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if model == currentModel && !switched {
 		fmt.Printf("\n  Already using %s%s%s. No changes needed.\n\n", cli.Bold, model, cli.Reset)
 		return nil
 	}
@@ -100,10 +274,6 @@ func setModel(model string) error {
 		fmt.Println()
 	}
 
-	if err := config.SetEmbeddingModel(vp, model); err != nil {
-		return fmt.Errorf("update config: %w", err)
-	}
-
 	// Find new model info
 	desc := ""
 	dims := 0
@@ -123,6 +293,13 @@ func setModel(model string) error {
 		fmt.Printf("    Dimensions: %d\n", dims)
 	}
 
+	if switched {
+		fmt.Println("\n  Switched to the staged embeddings. No reindex needed.")
+		fmt.Println(stagedFactsNote)
+		fmt.Println()
+		return nil
+	}
+
 	fmt.Printf("\n  %sIMPORTANT:%s Run %ssame reindex --force%s to re-embed all notes.\n",
 		cli.Yellow, cli.Reset, cli.Bold, cli.Reset)
 	fmt.Println("  The old embeddings won't work with the new model.")
@@ -5,6 +5,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/ollama"
 )
 
 func TestModelCmd_ShowCurrent(t *testing.T) {
@@ -81,3 +84,48 @@ func TestModelCmd_UseInvalidModel(t *testing.T) {
 		t.Fatalf("expected config to persist model %q, got: %q", invalidModel, string(data))
 	}
 }
+
+func TestBuildModelsReport_MarksPulledAndChat(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	_ = db.Close()
+
+	t.Setenv("SAME_EMBED_PROVIDER", "ollama")
+	t.Setenv("SAME_EMBED_MODEL", "nomic-embed-text")
+
+	r := buildModelsReport([]ollama.Model{
+		{Name: "nomic-embed-text:latest"},
+		{Name: "mistral:latest"},
+		{Name: "llama3.2:1b"},
+	})
+	if !r.OllamaReachable {
+		t.Fatal("expected Ollama to be reported reachable")
+	}
+	for _, m := range r.Embedding {
+		switch m.Name {
+		case "nomic-embed-text":
+			if !m.Pulled || !m.Current {
+				t.Errorf("nomic-embed-text: pulled=%v current=%v, want both true", m.Pulled, m.Current)
+			}
+		case "mxbai-embed-large":
+			if m.Pulled {
+				t.Error("mxbai-embed-large should not be pulled")
+			}
+		}
+	}
+	if len(r.Chat) != 2 {
+		t.Fatalf("expected 2 chat models, got %+v", r.Chat)
+	}
+	for _, m := range r.Chat {
+		if m.Recommended != (m.Name == "llama3.2:1b") {
+			t.Errorf("%s: recommended=%v", m.Name, m.Recommended)
+		}
+	}
+
+	offline := buildModelsReport(nil)
+	if offline.OllamaReachable || len(offline.Chat) != 0 {
+		t.Fatalf("expected unreachable report with no chat models, got %+v", offline)
+	}
+	if len(offline.Embedding) != len(config.KnownModels) {
+		t.Fatalf("expected all known models listed, got %d", len(offline.Embedding))
+	}
+}
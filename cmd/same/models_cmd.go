@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/ollama"
+)
+
+// modelsOllamaTimeout bounds the /api/tags probe so 'same models' stays
+// quick when Ollama isn't running.
+const modelsOllamaTimeout = 3 * time.Second
+
+func modelsCmd() *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "models",
+		Short: "List known embedding models and installed chat models",
+		Long: `List the embedding models SAME knows about (name, dimensions, provider)
+and, when Ollama is reachable, which of them are already pulled. Also lists
+the chat models Ollama has installed, marking the one picked when no chat
+model is configured.
+
+Nothing is downloaded or changed. Switch embedding models with
+'same model use <name>'.
+
+Examples:
+  same models
+  same models --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runModels(jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+// embeddingModelEntry is one known embedding model in 'same models' output.
+type embeddingModelEntry struct {
+	Name        string `json:"name"`
+	Dims        int    `json:"dims"`
+	Provider    string `json:"provider"`
+	Description string `json:"description"`
+	Current     bool   `json:"current"`
+	Pulled      bool   `json:"pulled"`
+}
+
+// chatModelEntry is one installed Ollama chat model in 'same models' output.
+type chatModelEntry struct {
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	Recommended bool   `json:"recommended"`
+}
+
+// modelsReport is the full 'same models' result.
+type modelsReport struct {
+	Provider        string                `json:"provider"`
+	CurrentModel    string                `json:"current_model"`
+	OllamaReachable bool                  `json:"ollama_reachable"`
+	Embedding       []embeddingModelEntry `json:"embedding"`
+	Chat            []chatModelEntry      `json:"chat"`
+}
+
+func runModels(jsonOut bool) error {
+	report := buildModelsReport(listOllamaModels())
+	if jsonOut {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+	printModelsReport(report)
+	return nil
+}
+
+// listOllamaModels returns every model pulled into the configured Ollama,
+// or nil when Ollama isn't configured locally or doesn't answer.
+func listOllamaModels() []ollama.Model {
+	url, err := config.OllamaURL()
+	if err != nil {
+		return nil
+	}
+	client := ollama.NewClientWithURL(url)
+	client.SetTimeout(modelsOllamaTimeout)
+	models, err := client.ListModels()
+	if err != nil {
+		return nil
+	}
+	if models == nil {
+		models = []ollama.Model{}
+	}
+	return models
+}
+
+// buildModelsReport combines config.KnownModels with the models pulled into
+// Ollama. A nil installed slice means Ollama wasn't reachable.
+func buildModelsReport(installed []ollama.Model) modelsReport {
+	ec := config.EmbeddingProviderConfig()
+	current := ec.Model
+	if current == "" {
+		current = config.EmbeddingModel
+	}
+	report := modelsReport{
+		Provider:        ec.Provider,
+		CurrentModel:    current,
+		OllamaReachable: installed != nil,
+		Embedding:       []embeddingModelEntry{},
+		Chat:            []chatModelEntry{},
+	}
+
+	pulled := make(map[string]bool, len(installed))
+	for _, m := range installed {
+		pulled[m.Name] = true
+		pulled[ollama.BaseName(m.Name)] = true
+	}
+	for _, m := range config.KnownModels {
+		report.Embedding = append(report.Embedding, embeddingModelEntry{
+			Name:        m.Name,
+			Dims:        m.Dims,
+			Provider:    m.Provider,
+			Description: m.Description,
+			Current:     m.Name == current,
+			Pulled:      m.Provider == "ollama" && pulled[m.Name],
+		})
+	}
+
+	chat := ollama.ChatModels(installed)
+	best := ollama.BestModel(chat)
+	for _, m := range chat {
+		report.Chat = append(report.Chat, chatModelEntry{
+			Name:        m.Name,
+			Size:        m.Size,
+			Recommended: m.Name == best,
+		})
+	}
+	return report
+}
+
+func printModelsReport(r modelsReport) {
+	fmt.Println()
+	fmt.Printf("  %sEmbedding models%s %s(current: %s via %s)%s\n\n",
+		cli.Bold, cli.Reset, cli.Dim, r.CurrentModel, r.Provider, cli.Reset)
+	fmt.Printf("    %-26s %5s  %-8s %-9s %s\n", "MODEL", "DIMS", "PROVIDER", "STATUS", "DESCRIPTION")
+	for _, m := range r.Embedding {
+		marker := " "
+		if m.Current {
+			marker = cli.Cyan + "→" + cli.Reset
+		}
+		status := fmt.Sprintf("%-9s", "-")
+		switch {
+		case m.Provider != "ollama":
+			status = fmt.Sprintf("%-9s", "api")
+		case m.Pulled:
+			status = cli.Green + fmt.Sprintf("%-9s", "pulled") + cli.Reset
+		case !r.OllamaReachable:
+			status = fmt.Sprintf("%-9s", "?")
+		}
+		fmt.Printf("  %s %-26s %5d  %-8s %s %s%s%s\n",
+			marker, m.Name, m.Dims, m.Provider, status, cli.Dim, m.Description, cli.Reset)
+	}
+
+	fmt.Printf("\n  %sChat models%s\n\n", cli.Bold, cli.Reset)
+	switch {
+	case !r.OllamaReachable:
+		fmt.Printf("    %sOllama isn't reachable; start it to see installed models.%s\n", cli.Dim, cli.Reset)
+	case len(r.Chat) == 0:
+		fmt.Printf("    %sNo chat models installed. Try: ollama pull llama3.2%s\n", cli.Dim, cli.Reset)
+	default:
+		for _, m := range r.Chat {
+			note := ""
+			if m.Recommended {
+				note = cli.Dim + "  (used when no chat model is configured)" + cli.Reset
+			}
+			fmt.Printf("    %s%s\n", m.Name, note)
+		}
+	}
+
+	fmt.Printf("\n  Switch embedding models with: %ssame model use <name>%s\n\n", cli.Bold, cli.Reset)
+}
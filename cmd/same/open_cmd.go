@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
+)
+
+func openCmd() *cobra.Command {
+	var useApp bool
+	cmd := &cobra.Command{
+		Use:   "open <path>",
+		Short: "Open a note in your editor",
+		Long: `Open a vault note in $EDITOR, or in the system's default app with --app.
+
+The path is relative to the vault root, exactly as 'same search' prints it;
+a trailing "§ Section" is ignored. Use --vault to open a note in another
+registered vault.
+
+Examples:
+  same open decisions/auth.md
+  same open "docs/guide.md § Guide > Install"
+  same open notes/diagram.md --app
+  same open --vault work projects/roadmap.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := resolveNoteFile(args[0])
+			if err != nil {
+				return err
+			}
+			return openNoteFile(path, useApp)
+		},
+	}
+	cmd.Flags().BoolVar(&useApp, "app", false, "Open with the system's default app instead of $EDITOR")
+	return cmd
+}
+
+// resolveNoteFile turns a note path as search prints it into the absolute
+// path of an existing file inside the vault.
+func resolveNoteFile(arg string) (string, error) {
+	vaultRoot := config.VaultPath()
+	if vaultRoot == "" {
+		return "", config.ErrNoVault
+	}
+	rel, _, _ := strings.Cut(arg, " § ")
+	rel = strings.TrimSpace(rel)
+	if filepath.IsAbs(rel) {
+		// Accept an absolute path that points into the vault.
+		if r, err := filepath.Rel(vaultRoot, rel); err == nil {
+			rel = r
+		}
+	}
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	if rel == "" || rel == "." {
+		return "", userError("No note path given", "Pass a path from 'same search', e.g. same open decisions/auth.md")
+	}
+
+	abs, ok := config.SafeVaultSubpath(rel)
+	if !ok {
+		return "", userError(fmt.Sprintf("%q is outside the vault", arg), "Use a path relative to the vault root, as 'same search' prints it")
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", userError(fmt.Sprintf("No file at %s", rel), "The note may have moved; run 'same search' again to find it")
+	}
+	if !info.Mode().IsRegular() {
+		return "", userError(fmt.Sprintf("%s is not a file", rel), "Pass the path of a note, not a directory")
+	}
+	return abs, nil
+}
+
+// openNoteFile opens path in $EDITOR (vi when unset), or in the system's
+// default app when useApp is set.
+func openNoteFile(path string, useApp bool) error {
+	if useApp {
+		return openWithDefaultApp(path)
+	}
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	return runEditor(editor, path)
+}
+
+// openWithDefaultApp hands target, a file path or URL, to the OS handler.
+func openWithDefaultApp(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "linux":
+		cmd = exec.Command("xdg-open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		return fmt.Errorf("opening files is not supported on %s", runtime.GOOS)
+	}
+	return cmd.Run()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestResolveNoteFile(t *testing.T) {
+	vault, _ := setupCommandTestVault(t)
+	for _, rel := range []string{"notes/auth.md", "_PRIVATE/keys.md"} {
+		p := filepath.Join(vault, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("# note\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := filepath.Join(vault, "notes", "auth.md")
+
+	for _, arg := range []string{
+		"notes/auth.md",
+		"notes/auth.md § Auth > Tokens",
+		"./notes/auth.md",
+		want,
+	} {
+		got, err := resolveNoteFile(arg)
+		if err != nil {
+			t.Errorf("resolveNoteFile(%q): %v", arg, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("resolveNoteFile(%q) = %q, want %q", arg, got, want)
+		}
+	}
+
+	// Private notes that exist on disk open like any other.
+	if _, err := resolveNoteFile("_PRIVATE/keys.md"); err != nil {
+		t.Errorf("private note: %v", err)
+	}
+
+	for _, arg := range []string{"../outside.md", "notes/missing.md", "notes", ""} {
+		if _, err := resolveNoteFile(arg); err == nil {
+			t.Errorf("resolveNoteFile(%q) should fail", arg)
+		}
+	}
+}
+
+func TestOpenNoteFile_UsesEditor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses the true command as the editor")
+	}
+	t.Setenv("EDITOR", "true")
+	if err := openNoteFile(filepath.Join(t.TempDir(), "note.md"), false); err != nil {
+		t.Fatalf("openNoteFile: %v", err)
+	}
+}
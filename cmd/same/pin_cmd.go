@@ -2,11 +2,14 @@ package main
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/sgx-labs/statelessagent/internal/cli"
 	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/hooks"
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
@@ -20,10 +23,15 @@ Pinned notes are injected every time, regardless of what you're working on.
 Use this for architecture decisions, coding standards, or project context
 that your AI should always know about.
 
-  same pin path/to/note.md      Pin a note
-  same pin list                 Show all pinned notes
-  same pin remove path/to/note  Unpin a note`,
+  same pin path/to/note.md                       Pin a note
+  same pin path/to/note.md --reason "API rules"  Pin and record why
+  same pin list                                  Show pinned notes and why
+  same pin remove path/to/note                   Unpin a note
+
+Pinning an already pinned note with --reason updates its reason.`,
 	}
+	var reason string
+	cmd.Flags().StringVar(&reason, "reason", "", "Why this note is pinned (shown in 'same pin list')")
 
 	cmd.AddCommand(pinAddCmd())
 	cmd.AddCommand(pinListCmd())
@@ -32,7 +40,7 @@ that your AI should always know about.
 	// Allow `same pin <path>` as shorthand for `same pin add <path>`
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		if len(args) == 1 {
-			return runPinAdd(args[0])
+			return runPinAdd(args[0], reason)
 		}
 		return cmd.Help()
 	}
@@ -43,14 +51,17 @@ that your AI should always know about.
 }
 
 func pinAddCmd() *cobra.Command {
-	return &cobra.Command{
+	var reason string
+	cmd := &cobra.Command{
 		Use:   "add [path]",
 		Short: "Pin a note",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPinAdd(args[0])
+			return runPinAdd(args[0], reason)
 		},
 	}
+	cmd.Flags().StringVar(&reason, "reason", "", "Why this note is pinned (shown in 'same pin list')")
+	return cmd
 }
 
 func pinListCmd() *cobra.Command {
@@ -74,7 +85,12 @@ func pinRemoveCmd() *cobra.Command {
 	}
 }
 
-func runPinAdd(path string) error {
+func runPinAdd(path, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if strings.ContainsAny(reason, "\r\n") {
+		return userError("Pin reason must be a single line", "try: same pin path/to/note.md --reason \"API conventions\"")
+	}
+
 	db, err := store.Open()
 	if err != nil {
 		return config.ErrNoDatabase
@@ -88,20 +104,46 @@ func runPinAdd(path string) error {
 	}
 
 	already, _ := db.IsPinned(path)
-	if already {
+	if already && reason == "" {
 		fmt.Printf("  Already pinned: %s\n", path)
 		return nil
 	}
 
-	if err := db.PinNote(path); err != nil {
+	if err := db.PinNote(path, reason); err != nil {
 		return fmt.Errorf("pin note: %w", err)
 	}
+	if already {
+		fmt.Printf("  %s✓%s Updated pin reason: %s\n", cli.Green, cli.Reset, notes[0].Title)
+		return nil
+	}
 
 	fmt.Printf("  %s✓%s Pinned: %s\n", cli.Green, cli.Reset, notes[0].Title)
 	fmt.Printf("    %sThis note will be included in every session%s\n", cli.Dim, cli.Reset)
+	warnPinnedBudget(db)
 	return nil
 }
 
+// warnPinnedBudget tells the user when pinned notes no longer fit in the
+// pinned token budget, since context surfacing will then skip some of them.
+func warnPinnedBudget(db *store.DB) {
+	pinned, err := db.GetPinnedNotes()
+	if err != nil {
+		return
+	}
+	total := 0
+	for _, p := range pinned {
+		total += hooks.PinnedNoteTokens(p.NoteRecord)
+	}
+	budget := config.MemoryMaxPinnedTokens()
+	if total <= budget {
+		return
+	}
+	fmt.Printf("\n  %sWarning:%s pinned notes total ~%d tokens, over the %d-token pinned budget.\n",
+		cli.Yellow, cli.Reset, total, budget)
+	fmt.Printf("    %sLower-confidence pins will be skipped when surfacing. Unpin with 'same pin remove <path>'\n", cli.Dim)
+	fmt.Printf("    or raise the budget: same config set memory.max_pinned_tokens %d%s\n", total, cli.Reset)
+}
+
 func runPinList() error {
 	db, err := store.Open()
 	if err != nil {
@@ -120,14 +162,27 @@ func runPinList() error {
 		return nil
 	}
 
+	pinned, err := db.GetPinnedNotes()
+	if err != nil {
+		return fmt.Errorf("get pinned notes: %w", err)
+	}
+	indexed := make(map[string]bool, len(pinned))
+
 	fmt.Printf("  %sPinned notes%s (always included in sessions):\n\n", cli.Bold, cli.Reset)
+	for _, p := range pinned {
+		indexed[p.Path] = true
+		age := relativeTimeStr(time.Since(time.Unix(p.PinnedAt, 0)))
+		fmt.Printf("    %s %s\n", p.Title, cli.Dim+p.Path+cli.Reset)
+		fmt.Printf("      %spinned %s%s\n", cli.Dim, age, cli.Reset)
+		if p.Reason != "" {
+			fmt.Printf("      Why: %s\n", p.Reason)
+		}
+	}
+	// Pins whose note is no longer indexed (deleted, renamed, or _PRIVATE/).
 	for _, p := range paths {
-		notes, _ := db.GetNoteByPath(p)
-		title := p
-		if len(notes) > 0 {
-			title = notes[0].Title
+		if !indexed[p] {
+			fmt.Printf("    %s %s(not in index)%s\n", p, cli.Yellow, cli.Reset)
 		}
-		fmt.Printf("    %s %s\n", title, cli.Dim+p+cli.Reset)
 	}
 	fmt.Printf("\n  %d pinned note(s).\n", len(paths))
 	return nil
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -26,7 +28,7 @@ func TestPinCmd_PinAndList(t *testing.T) {
 	insertCommandTestNote(t, db, "important.md", "Important Note", "Critical information.")
 	_ = db.Close()
 
-	if err := runPinAdd("important.md"); err != nil {
+	if err := runPinAdd("important.md", ""); err != nil {
 		t.Fatalf("runPinAdd: %v", err)
 	}
 
@@ -41,3 +43,51 @@ func TestPinCmd_PinAndList(t *testing.T) {
 		t.Fatalf("expected pinned note in list output, got: %q", out)
 	}
 }
+
+func TestPinCmd_ReasonShownInList(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "api.md", "API Rules", "Use REST.")
+	_ = db.Close()
+
+	captureCommandStdout(t, func() {
+		if err := runPinAdd("api.md", "agents keep inventing endpoints"); err != nil {
+			t.Fatalf("runPinAdd: %v", err)
+		}
+	})
+
+	out := captureCommandStdout(t, func() {
+		if err := runPinList(); err != nil {
+			t.Fatalf("runPinList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Why: agents keep inventing endpoints") {
+		t.Errorf("expected reason in list output, got: %q", out)
+	}
+	if !strings.Contains(out, "pinned just now") {
+		t.Errorf("expected pin age in list output, got: %q", out)
+	}
+
+	if err := runPinAdd("api.md", "line one\nline two"); err == nil {
+		t.Error("expected error for multi-line reason")
+	}
+}
+
+func TestPinCmd_WarnsWhenOverPinnedBudget(t *testing.T) {
+	vault, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "big.md", "Big Note", strings.Repeat("context ", 400))
+	_ = db.Close()
+
+	cfg := "[memory]\nmax_pinned_tokens = 50\n"
+	if err := os.WriteFile(filepath.Join(vault, ".same", "config.toml"), []byte(cfg), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	out := captureCommandStdout(t, func() {
+		if err := runPinAdd("big.md", ""); err != nil {
+			t.Fatalf("runPinAdd: %v", err)
+		}
+	})
+	if !strings.Contains(out, "over the 50-token pinned budget") {
+		t.Errorf("expected budget warning, got: %q", out)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -8,10 +9,14 @@ import (
 
 	"github.com/sgx-labs/statelessagent/internal/cli"
 	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/embedding"
+	"github.com/sgx-labs/statelessagent/internal/indexer"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func repairCmd() *cobra.Command {
-	return &cobra.Command{
+	var embeddingsOnly bool
+	cmd := &cobra.Command{
 		Use:   "repair",
 		Short: "Back up and rebuild the database",
 		Long: `Creates a backup of vault.db and force-rebuilds the index.
@@ -20,11 +25,20 @@ This is the go-to command when something seems broken. It:
   1. Copies vault.db to vault.db.bak
   2. Runs a full force reindex
 
+With --embeddings, only notes whose stored vectors have the wrong
+dimensions are re-embedded. This is much faster than a full rebuild when
+'same doctor' reports embedding dimension drift.
+
 After repair, verify with 'same doctor'.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if embeddingsOnly {
+				return runRepairEmbeddings()
+			}
 			return runRepair()
 		},
 	}
+	cmd.Flags().BoolVar(&embeddingsOnly, "embeddings", false, "Re-embed only notes with mismatched vector dimensions")
+	return cmd
 }
 
 func runRepair() error {
@@ -54,7 +68,7 @@ func runRepair() error {
 
 	// Step 2: Force reindex
 	fmt.Printf("\n  Rebuilding index...\n")
-	if err := runReindex(true, false, false); err != nil {
+	if err := runReindex(reindexOptions{Force: true}); err != nil {
 		return fmt.Errorf("reindex failed: %w", err)
 	}
 
@@ -65,3 +79,75 @@ func runRepair() error {
 	cli.Footer()
 	return nil
 }
+
+func runRepairEmbeddings() error {
+	cli.Header("SAME Repair: Embeddings")
+	fmt.Println()
+
+	db, err := store.Open()
+	if err != nil {
+		return config.ErrNoDatabase
+	}
+	defer db.Close()
+
+	client, err := newEmbedProvider()
+	if err != nil {
+		return userError("No embedding provider available", "configure SAME_EMBED_PROVIDER, or run 'same doctor' for details")
+	}
+
+	n, err := repairEmbeddingDims(db, client, func(completed, total int) {
+		fmt.Printf("\r  Re-embedding: %d/%d", completed, total)
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		fmt.Printf("  %s✓%s No mismatched embeddings found.\n", cli.Green, cli.Reset)
+	} else {
+		fmt.Printf("\n  %s✓%s Re-embedded %d note chunk(s).\n", cli.Green, cli.Reset, n)
+		fmt.Printf("  Run %ssame doctor%s to verify.\n", cli.Bold, cli.Reset)
+	}
+	cli.Footer()
+	return nil
+}
+
+// repairEmbeddingDims re-embeds every note whose stored vector length does
+// not match what the current provider produces. Returns the number of notes
+// re-embedded. When the provider's dimensions differ from the vector table's
+// fixed width, only a force reindex can help.
+func repairEmbeddingDims(db *store.DB, client embedding.Provider, progress indexer.EmbeddingProgressFunc) (int, error) {
+	probe, err := client.GetQueryEmbedding("test")
+	if err != nil {
+		return 0, userError("Embedding provider is not responding", "run 'same doctor' for details")
+	}
+	dims := len(probe)
+
+	tableDims, err := db.VectorTableDims()
+	if err != nil {
+		return 0, fmt.Errorf("read vector table: %w", err)
+	}
+	if tableDims != dims {
+		return 0, userError(
+			fmt.Sprintf("Your embedding model produces %d-dim vectors but the index stores %d-dim vectors", dims, tableDims),
+			"run 'same reindex --force' to rebuild the index with the current model",
+		)
+	}
+
+	ids, err := db.NoteIDsWithVectorLengthNot(dims)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) > 0 {
+		res, err := indexer.ReembedNotes(context.Background(), db, client, ids, progress)
+		if err != nil {
+			return 0, fmt.Errorf("re-embed: %w", err)
+		}
+		if res.Failed > 0 {
+			return res.Completed, fmt.Errorf("%d of %d notes failed to re-embed", res.Failed, res.Total)
+		}
+	}
+	if err := db.SetEmbeddingMeta(client.Name(), client.Model(), dims); err != nil {
+		return len(ids), fmt.Errorf("record embedding metadata: %w", err)
+	}
+	return len(ids), nil
+}
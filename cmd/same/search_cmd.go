@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 
@@ -16,18 +18,36 @@ import (
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
+// searchOptions holds the flags of 'same search'. Hybrid and OpenN apply to
+// a single vault; AllVaults and Vaults select the vaults for a federated
+// search.
+type searchOptions struct {
+	TopK            int
+	Offset          int
+	Domain          string
+	TrustState      string
+	ContentType     string
+	Tags            []string
+	After, Before   time.Time // zero means unbounded
+	JSON            bool
+	Verbose         bool
+	SnippetLen      int
+	IncludeArchived bool
+
+	Hybrid bool
+	OpenN  int // open result N in $EDITOR instead of listing
+
+	AllVaults bool
+	Vaults    string // comma-separated aliases
+}
+
 func searchCmd() *cobra.Command {
 	var (
-		topK            int
-		domain          string
-		trustState      string
-		contentType     string
+		opts            searchOptions
 		contentTypeAlts string
 		tag             string
-		jsonOut         bool
-		verbose         bool
-		allVaults       bool
-		vaults          string
+		after           string
+		before          string
 	)
 	cmd := &cobra.Command{
 		Use:     "search [query]",
@@ -43,44 +63,162 @@ Examples:
   same search "stale notes" --trust stale
   same search "api design" --domain engineering
   same search "auth" --tag security
+  same search "auth" --top-k 10 --offset 5
+  same search "release plan" --after 14d
+  same search "incident" --after 2026-01-01 --before 2026-02-01
+  same search "ERR_CONN_RESET retries" --hybrid
+  same search "auth decisions" --open 1
+  same search "auth decisions" --snippet-len 400
+  same search "old migration plan" --include-archived
   same search --all "JWT patterns"
   same search --vaults dev,marketing "launch timeline"`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// --content-type is an alias for --type
-			if contentType == "" && contentTypeAlts != "" {
-				contentType = contentTypeAlts
+			if opts.ContentType == "" && contentTypeAlts != "" {
+				opts.ContentType = contentTypeAlts
 			}
 			query := strings.Join(args, " ")
-			var tags []string
 			if tag != "" {
 				for _, t := range strings.Split(tag, ",") {
 					t = strings.TrimSpace(t)
 					if t != "" {
-						tags = append(tags, t)
+						opts.Tags = append(opts.Tags, t)
 					}
 				}
 			}
-			if allVaults || vaults != "" {
-				return runFederatedSearch(query, topK, domain, trustState, contentType, tags, jsonOut, verbose, allVaults, vaults)
+			if opts.Offset < 0 {
+				return userError("--offset must be zero or positive", "Use --offset 5 to skip the first five results")
+			}
+			now := time.Now()
+			if after != "" {
+				t, err := parseTimeFlag(after, now)
+				if err != nil {
+					return userError(fmt.Sprintf("Invalid --after value %q", after), "Use a date (2006-01-02), an RFC3339 timestamp, or a duration like 7d, 2w, 36h")
+				}
+				opts.After = t
+			}
+			if before != "" {
+				t, err := parseTimeFlag(before, now)
+				if err != nil {
+					return userError(fmt.Sprintf("Invalid --before value %q", before), "Use a date (2006-01-02), an RFC3339 timestamp, or a duration like 7d, 2w, 36h")
+				}
+				opts.Before = t
+			}
+			if !opts.After.IsZero() && !opts.Before.IsZero() && opts.After.After(opts.Before) {
+				return userError("--after is later than --before",
+					fmt.Sprintf("The window %s → %s is empty; swap the values or widen the range", opts.After.Format("2006-01-02 15:04"), opts.Before.Format("2006-01-02 15:04")))
+			}
+			if opts.OpenN < 0 {
+				return userError("--open must be a result number", "Use --open 1 to open the top result")
+			}
+			if opts.OpenN > 0 && (opts.JSON || opts.AllVaults || opts.Vaults != "") {
+				return userError("--open can't be combined with --json, --all, or --vaults", "Search one vault, then open a result by its number")
 			}
-			return runSearch(query, topK, domain, trustState, contentType, tags, jsonOut, verbose)
+			if err := validateSnippetLen(opts.SnippetLen); err != nil {
+				return err
+			}
+			if opts.Hybrid && (opts.AllVaults || opts.Vaults != "") {
+				return userError("--hybrid searches one vault at a time", "Drop --all/--vaults, or search without --hybrid")
+			}
+			if opts.AllVaults || opts.Vaults != "" {
+				return runFederatedSearch(query, opts)
+			}
+			return runSearch(query, opts)
 		},
 	}
-	cmd.Flags().IntVar(&topK, "top-k", 5, "Number of results")
-	cmd.Flags().StringVar(&domain, "domain", "", "Filter by domain")
-	cmd.Flags().StringVarP(&trustState, "trust", "t", "", "Filter by trust state (validated, stale, contradicted, unknown)")
-	cmd.Flags().StringVar(&contentType, "type", "", "Filter by content type (decision, handoff, note, research)")
+	cmd.Flags().IntVar(&opts.TopK, "top-k", 5, "Number of results (page size)")
+	cmd.Flags().IntVar(&opts.Offset, "offset", 0, "Skip this many ranked results (for paging through results)")
+	cmd.Flags().StringVar(&opts.Domain, "domain", "", "Filter by domain")
+	cmd.Flags().StringVarP(&opts.TrustState, "trust", "t", "", "Filter by trust state (validated, stale, contradicted, unknown)")
+	cmd.Flags().StringVar(&opts.ContentType, "type", "", "Filter by content type (decision, handoff, note, research)")
 	cmd.Flags().StringVar(&contentTypeAlts, "content-type", "", "Filter by content type (alias for --type)")
 	cmd.Flags().StringVar(&tag, "tag", "", "Filter by tag (comma-separated for multiple)")
-	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
-	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show raw scores for debugging")
-	cmd.Flags().BoolVar(&allVaults, "all", false, "Search across all registered vaults")
-	cmd.Flags().StringVar(&vaults, "vaults", "", "Comma-separated vault aliases to search")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output as JSON")
+	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Show raw scores for debugging")
+	cmd.Flags().BoolVar(&opts.AllVaults, "all", false, "Search across all registered vaults")
+	cmd.Flags().StringVar(&opts.Vaults, "vaults", "", "Comma-separated vault aliases to search")
+	cmd.Flags().StringVar(&after, "after", "", "Only notes modified at or after this time (date, RFC3339, or duration like 7d)")
+	cmd.Flags().StringVar(&before, "before", "", "Only notes modified before this time (date, RFC3339, or duration like 7d)")
+	cmd.Flags().BoolVar(&opts.Hybrid, "hybrid", false, "Fuse semantic and keyword (FTS5) rankings; needs both indexes")
+	cmd.Flags().IntVar(&opts.OpenN, "open", 0, "Open result number N in $EDITOR instead of listing results")
+	cmd.Flags().IntVar(&opts.SnippetLen, "snippet-len", defaultSnippetLen, fmt.Sprintf("Characters of each result's snippet to show (%d-%d)", config.MinSnippetChars, store.ResultSnippetChars))
+	cmd.Flags().BoolVar(&opts.IncludeArchived, "include-archived", false, "Also search notes archived with 'same archive'")
 	return cmd
 }
 
-func runSearch(query string, topK int, domain string, trustState string, contentType string, tags []string, jsonOut bool, verbose bool) error {
+// parseTimeFlag parses a --after/--before style value. It accepts an RFC3339
+// timestamp, a date (2006-01-02 or 2006/01/02, local time), or a duration
+// relative to now. Durations take Go syntax (36h, 90m) plus d (days) and
+// w (weeks) suffixes, so "7d" means seven days before now.
+func parseTimeFlag(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty time value")
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02", "2006/01/02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if n := len(value); n > 1 && (value[n-1] == 'd' || value[n-1] == 'w') {
+		count, err := strconv.Atoi(value[:n-1])
+		if err == nil && count >= 0 {
+			days := count
+			if value[n-1] == 'w' {
+				days = count * 7
+			}
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", value)
+}
+
+// withSection appends a chunk's section path to a note path for display,
+// e.g. "docs/guide.md § Guide > Install". Placeholder headings such as
+// "(full)" or "(part 2)" are omitted.
+func withSection(path, heading string) string {
+	heading = strings.TrimSpace(heading)
+	if heading == "" || strings.HasPrefix(heading, "(") {
+		return path
+	}
+	return path + " § " + heading
+}
+
+// unixOrZero converts a time bound into the Unix-seconds form used by
+// store.SearchOptions, mapping the zero time to "unbounded".
+func unixOrZero(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.Unix())
+}
+
+// keywordFallbackSearch runs the LIKE-based keyword search used when neither
+// vectors nor FTS5 results are available, honoring the time window and page.
+func keywordFallbackSearch(db *store.DB, query string, opts store.SearchOptions) ([]store.SearchResult, error) {
+	terms := store.ExtractSearchTerms(query)
+	rawResults, err := db.KeywordSearch(terms, opts.TopK+opts.Offset)
+	if err != nil {
+		return nil, err
+	}
+	var results []store.SearchResult
+	for _, rr := range rawResults {
+		if !opts.InModifiedWindow(rr.Modified) {
+			continue
+		}
+		results = append(results, store.RawToSearchResult(rr, 0.5))
+	}
+	return store.PageResults(results, opts.Offset, opts.TopK), nil
+}
+
+func runSearch(query string, opts searchOptions) error {
 	if strings.TrimSpace(query) == "" {
 		return userError("Empty search query", "Provide a search term: same search \"your query\"")
 	}
@@ -90,27 +228,46 @@ func runSearch(query string, topK int, domain string, trustState string, content
 	}
 	defer db.Close()
 
+	if opts.Hybrid && (!db.HasVectors() || !db.FTSAvailable()) {
+		return userError("--hybrid needs both semantic and keyword indexes",
+			"Configure an embedding provider and run 'same reindex', or search without --hybrid")
+	}
+
 	// Auto-detect metadata queries (trust state, confidence, provenance)
 	// Only apply if the user didn't explicitly set --trust
 	metaHints := memory.InferMetadataFilters(query)
-	effectiveTrust := trustState
+	effectiveTrust := opts.TrustState
 	if effectiveTrust == "" && metaHints.TrustState != "" {
 		effectiveTrust = metaHints.TrustState
 	}
 
 	searchOpts := store.SearchOptions{
-		TopK:            topK,
-		Domain:          domain,
+		TopK:            opts.TopK,
+		Domain:          opts.Domain,
 		TrustState:      effectiveTrust,
-		ContentType:     contentType,
-		Tags:            tags,
+		ContentType:     opts.ContentType,
+		Tags:            opts.Tags,
+		ModifiedAfter:   unixOrZero(opts.After),
+		ModifiedBefore:  unixOrZero(opts.Before),
+		IncludeArchived: opts.IncludeArchived,
 		QueryTypeBoosts: memory.InferQueryTypeBoost(query),
 	}
 
+	// Metadata queries merge a second result list below, so they page after
+	// the merge instead of inside the store search.
+	cliOffset := 0
+	if metaHints.IsMetadataQuery && opts.Offset > 0 {
+		searchOpts.TopK = opts.TopK + opts.Offset
+		cliOffset = opts.Offset
+	} else {
+		searchOpts.Offset = opts.Offset
+	}
+
 	// Detect lite mode (no vectors) and fall back to FTS5/keyword
 	var results []store.SearchResult
 	if !db.HasVectors() {
 		if db.FTSAvailable() {
+			searchOpts.Synonyms = store.ConfiguredSynonyms()
 			results, err = db.FTS5Search(query, searchOpts)
 			if err != nil {
 				return fmt.Errorf("search: %w", err)
@@ -118,42 +275,36 @@ func runSearch(query string, topK int, domain string, trustState string, content
 		}
 		// LIKE-based keyword fallback if FTS5 unavailable or returned nothing
 		if results == nil {
-			terms := store.ExtractSearchTerms(query)
-			rawResults, err := db.KeywordSearch(terms, topK)
+			results, err = keywordFallbackSearch(db, query, searchOpts)
 			if err != nil {
 				return fmt.Errorf("search: %w", err)
 			}
-			for _, rr := range rawResults {
-				results = append(results, store.RawToSearchResult(rr, 0.5))
-			}
 		}
-		if !jsonOut && len(results) > 0 {
+		if !opts.JSON && len(results) > 0 {
 			fmt.Printf("  %sUsing keyword search (no embedding provider configured). For semantic search: `same config set embedding.provider ollama` then `same reindex`%s\n", cli.Dim, cli.Reset)
 			if _, probeErr := newEmbedProvider(); probeErr == nil {
-				fmt.Printf("  %sTip: Embedding provider detected! Run %ssame reindex%s to upgrade to semantic search.%s\n",
+				fmt.Printf("  %sTip: Embedding provider detected! Run %ssame upgrade%s to switch to semantic search.%s\n",
 					cli.Dim, cli.Bold, cli.Reset+cli.Dim, cli.Reset)
 			}
 		}
 	} else {
 		client, err := newEmbedProvider()
+		if err != nil && opts.Hybrid {
+			return fmt.Errorf("can't connect to embedding provider (ollama/openai/openai-compatible): %w", err)
+		}
 		if err != nil {
 			// Embedding provider unavailable — try FTS5 fallback, then LIKE-based
 			if db.FTSAvailable() {
+				searchOpts.Synonyms = store.ConfiguredSynonyms()
 				results, _ = db.FTS5Search(query, searchOpts)
 			}
 			if results == nil {
-				terms := store.ExtractSearchTerms(query)
-				rawResults, kwErr := db.KeywordSearch(terms, topK)
-				if kwErr == nil {
-					for _, rr := range rawResults {
-						results = append(results, store.RawToSearchResult(rr, 0.5))
-					}
-				}
+				results, _ = keywordFallbackSearch(db, query, searchOpts)
 			}
 			if results == nil {
 				return fmt.Errorf("can't connect to embedding provider (ollama/openai/openai-compatible): %w", err)
 			}
-			if !jsonOut {
+			if !opts.JSON {
 				fmt.Printf("  %s(keyword fallback — embedding provider unavailable)%s\n", cli.Dim, cli.Reset)
 			}
 		} else {
@@ -166,7 +317,11 @@ func runSearch(query string, topK int, domain string, trustState string, content
 				return embedding.HumanizeError(fmt.Errorf("embed query: %w", err))
 			}
 
-			results, err = db.HybridSearch(queryVec, query, searchOpts)
+			if opts.Hybrid {
+				results, err = db.FusedSearch(queryVec, query, searchOpts)
+			} else {
+				results, err = db.HybridSearch(queryVec, query, searchOpts)
+			}
 			if err != nil {
 				return fmt.Errorf("search: %w", err)
 			}
@@ -179,11 +334,14 @@ func runSearch(query string, topK int, domain string, trustState string, content
 	// so they rank above content-only matches for metadata-focused queries.
 	if metaHints.IsMetadataQuery {
 		metaOpts := store.SearchOptions{
-			TopK:        topK,
-			Domain:      domain,
-			TrustState:  effectiveTrust,
-			ContentType: contentType,
-			Tags:        tags,
+			TopK:            opts.TopK + cliOffset,
+			Domain:          opts.Domain,
+			TrustState:      effectiveTrust,
+			ContentType:     opts.ContentType,
+			Tags:            opts.Tags,
+			ModifiedAfter:   searchOpts.ModifiedAfter,
+			ModifiedBefore:  searchOpts.ModifiedBefore,
+			IncludeArchived: opts.IncludeArchived,
 		}
 		metaResults, metaErr := db.MetadataFilterSearch(metaOpts)
 		if metaErr == nil && len(metaResults) > 0 {
@@ -201,18 +359,33 @@ func runSearch(query string, topK int, domain string, trustState string, content
 				merged = append(merged, r)
 			}
 			// Trim to topK
-			if len(merged) > topK {
-				merged = merged[:topK]
+			if len(merged) > opts.TopK+cliOffset {
+				merged = merged[:opts.TopK+cliOffset]
 			}
 			results = merged
 		}
+		if cliOffset > 0 {
+			results = store.PageResults(results, cliOffset, opts.TopK)
+		}
 	}
 
 	if len(results) == 0 {
-		if jsonOut {
+		if opts.JSON {
 			fmt.Println("[]")
 			return nil
 		}
+		if opts.Offset > 0 {
+			fmt.Printf("\n  No more results past #%d. Try a smaller --offset.\n\n", opts.Offset)
+			return nil
+		}
+		if suggestions, _ := db.SpellingSuggestions(query, 3); len(suggestions) > 0 {
+			fmt.Printf("\n  No results found. Did you mean:\n")
+			for _, sug := range suggestions {
+				fmt.Printf("    %ssame search %q%s\n", cli.Bold, sug, cli.Reset)
+			}
+			fmt.Println()
+			return nil
+		}
 		noteCount, _ := db.NoteCount()
 		if noteCount < 5 {
 			fmt.Printf("\n  No results found. Your vault has only %d notes.\n", noteCount)
@@ -227,7 +400,11 @@ func runSearch(query string, topK int, domain string, trustState string, content
 		return nil
 	}
 
-	if jsonOut {
+	if opts.OpenN > 0 {
+		return openSearchResult(db, results, opts.Offset, opts.OpenN)
+	}
+
+	if opts.JSON {
 		data, _ := json.MarshalIndent(results, "", "  ")
 		fmt.Println(string(data))
 		return nil
@@ -239,9 +416,9 @@ func runSearch(query string, topK int, domain string, trustState string, content
 			typeTag = fmt.Sprintf(" [%s]", r.ContentType)
 		}
 
-		fmt.Printf("\n%d. %s%s\n", i+1, r.Title, typeTag)
-		fmt.Printf("   %s\n", r.Path)
-		if verbose {
+		fmt.Printf("\n%d. %s%s\n", opts.Offset+i+1, r.Title, typeTag)
+		fmt.Printf("   %s\n", withSection(r.Path, r.ChunkHeading))
+		if opts.Verbose {
 			fmt.Printf("   Relevance: %.0f%%  Distance: %.1f  Confidence: %.0f%%\n",
 				r.Score*100, r.Distance, r.Confidence*100)
 		} else {
@@ -251,19 +428,11 @@ func runSearch(query string, topK int, domain string, trustState string, content
 			fmt.Printf("   %s\n", trustLine)
 		}
 
-		// Show first 150 chars of snippet
-		snippet := r.Snippet
-		if len(snippet) > 150 {
-			snippet = snippet[:150] + "..."
-		}
-		// Replace newlines with spaces for compact display
-		snippet = strings.ReplaceAll(snippet, "\n", " ")
-		snippet = strings.ReplaceAll(snippet, "\r", "")
-		fmt.Printf("   %s\n", snippet)
+		fmt.Printf("   %s\n", displaySnippet(r.Snippet, opts.SnippetLen))
 	}
 	fmt.Println()
 
-	if !jsonOut {
+	if !opts.JSON {
 		reg := config.LoadRegistry()
 		if len(reg.Vaults) >= 2 {
 			fmt.Printf("  %sSearching 1 vault. Use --all to search %d vaults.%s\n", cli.Dim, len(reg.Vaults), cli.Reset)
@@ -271,6 +440,9 @@ func runSearch(query string, topK int, domain string, trustState string, content
 		if len(results) > 0 {
 			fmt.Printf("  %sExplore related: same related %s%s\n", cli.Dim, results[0].Path, cli.Reset)
 		}
+		if len(results) == opts.TopK {
+			fmt.Printf("  %sMore results: same search %q --offset %d%s\n", cli.Dim, query, opts.Offset+opts.TopK, cli.Reset)
+		}
 		if len(results) < 3 {
 			fmt.Printf("  %sTip: run 'same ask \"<your question>\"' for AI-powered answers with citations%s\n", cli.Dim, cli.Reset)
 		}
@@ -288,39 +460,71 @@ func runSearch(query string, topK int, domain string, trustState string, content
 	return nil
 }
 
-func runFederatedSearch(query string, topK int, domain string, trustState string, contentType string, tags []string, jsonOut bool, verbose bool, allVaults bool, vaultsFlag string) error {
+// defaultSnippetLen is how much of each snippet search and related show
+// unless --snippet-len says otherwise.
+const defaultSnippetLen = 150
+
+// validateSnippetLen checks a --snippet-len value. Result snippets are
+// stored at up to store.ResultSnippetChars, so longer values show nothing
+// more.
+func validateSnippetLen(n int) error {
+	if n < config.MinSnippetChars || n > store.ResultSnippetChars {
+		return userError(fmt.Sprintf("--snippet-len must be between %d and %d", config.MinSnippetChars, store.ResultSnippetChars),
+			"Use a shorter value for terse output, e.g. --snippet-len 80")
+	}
+	return nil
+}
+
+// displaySnippet cuts snippet to at most n bytes (defaultSnippetLen when n
+// is 0) without splitting a character, and flattens it onto one line.
+func displaySnippet(snippet string, n int) string {
+	if n <= 0 {
+		n = defaultSnippetLen
+	}
+	if len(snippet) > n {
+		for n > 0 && !utf8.RuneStart(snippet[n]) {
+			n--
+		}
+		snippet = snippet[:n] + "..."
+	}
+	snippet = strings.ReplaceAll(snippet, "\n", " ")
+	return strings.ReplaceAll(snippet, "\r", "")
+}
+
+// openSearchResult opens result number n, counted as the listing would
+// number it (so with --offset 5 the first result is 6).
+func openSearchResult(db *store.DB, results []store.SearchResult, offset, n int) error {
+	i := n - offset - 1
+	if i < 0 || i >= len(results) {
+		return userError(fmt.Sprintf("--open %d is out of range", n),
+			fmt.Sprintf("This search returned results %d-%d", offset+1, offset+len(results)))
+	}
+	r := results[i]
+	path, err := resolveNoteFile(r.Path)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  Opening %s\n", withSection(r.Path, r.ChunkHeading))
+	_ = db.IncrementAccessCount([]string{r.Path})
+	return openNoteFile(path, false)
+}
+
+func runFederatedSearch(query string, opts searchOptions) error {
 	if strings.TrimSpace(query) == "" {
 		return userError("Empty search query", "Provide a search term: same search --all \"your query\"")
 	}
 
 	// Resolve which vaults to search
-	reg := config.LoadRegistry()
-	vaultDBPaths := make(map[string]string)
-
-	if allVaults {
-		for alias, vaultPath := range reg.Vaults {
-			dbPath := vaultDBPath(vaultPath)
-			if _, err := os.Stat(dbPath); err == nil {
-				vaultDBPaths[alias] = dbPath
-			}
-		}
-	} else {
-		for _, alias := range strings.Split(vaultsFlag, ",") {
-			alias = strings.TrimSpace(alias)
-			if alias == "" {
-				continue
-			}
-			resolved := reg.ResolveVault(alias)
-			if resolved == "" {
-				fmt.Fprintf(os.Stderr, "Warning: vault %q not found, skipping\n", alias)
-				continue
-			}
-			dbPath := vaultDBPath(resolved)
-			if _, err := os.Stat(dbPath); err == nil {
-				vaultDBPaths[alias] = dbPath
-			} else {
-				fmt.Fprintf(os.Stderr, "Warning: vault %q has no index — run 'same reindex' in that vault\n", alias)
-			}
+	var aliases []string
+	if !opts.AllVaults {
+		aliases = strings.Split(opts.Vaults, ",")
+	}
+	vaultDBPaths, skipped := config.LoadRegistry().SearchableVaults(aliases, true)
+	for _, sv := range skipped {
+		if sv.Reason == "not indexed" {
+			fmt.Fprintf(os.Stderr, "Warning: vault %q has no index — run 'same reindex' in that vault\n", sv.Alias)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: vault %q not found, skipping\n", sv.Alias)
 		}
 	}
 
@@ -338,24 +542,28 @@ func runFederatedSearch(query string, topK int, domain string, trustState string
 
 	// Auto-detect metadata queries for federated search too
 	fedMetaHints := memory.InferMetadataFilters(query)
-	fedEffectiveTrust := trustState
+	fedEffectiveTrust := opts.TrustState
 	if fedEffectiveTrust == "" && fedMetaHints.TrustState != "" {
 		fedEffectiveTrust = fedMetaHints.TrustState
 	}
 
 	results, err := store.FederatedSearch(vaultDBPaths, queryVec, query, store.SearchOptions{
-		TopK:            topK,
-		Domain:          domain,
+		TopK:            opts.TopK,
+		Domain:          opts.Domain,
 		TrustState:      fedEffectiveTrust,
-		ContentType:     contentType,
-		Tags:            tags,
+		ContentType:     opts.ContentType,
+		Tags:            opts.Tags,
+		ModifiedAfter:   unixOrZero(opts.After),
+		ModifiedBefore:  unixOrZero(opts.Before),
+		Offset:          opts.Offset,
+		IncludeArchived: opts.IncludeArchived,
 		QueryTypeBoosts: memory.InferQueryTypeBoost(query),
 	})
 	if err != nil {
 		return fmt.Errorf("federated search: %w", err)
 	}
 
-	if jsonOut {
+	if opts.JSON {
 		data, _ := json.MarshalIndent(results, "", "  ")
 		fmt.Println(string(data))
 		return nil
@@ -377,11 +585,15 @@ func runFederatedSearch(query string, topK int, domain string, trustState string
 			typeTag = fmt.Sprintf(" [%s]", r.ContentType)
 		}
 
-		fmt.Printf("\n%d. %s%s  %s[%s]%s\n", i+1, r.Title, typeTag, cli.Dim, r.Vault, cli.Reset)
-		fmt.Printf("   %s\n", r.Path)
-		if verbose {
-			fmt.Printf("   Relevance: %.0f%%  Distance: %.1f  Confidence: %.0f%%\n",
-				r.Score*100, r.Distance, r.Confidence*100)
+		vaultTag := r.Vault
+		if len(r.AlsoIn) > 0 {
+			vaultTag += ", also in " + strings.Join(r.AlsoIn, ", ")
+		}
+		fmt.Printf("\n%d. %s%s  %s[%s]%s\n", opts.Offset+i+1, r.Title, typeTag, cli.Dim, vaultTag, cli.Reset)
+		fmt.Printf("   %s\n", withSection(r.Path, r.ChunkHeading))
+		if opts.Verbose {
+			fmt.Printf("   Relevance: %.0f%%  Normalized: %.2f  Distance: %.1f  Confidence: %.0f%%\n",
+				r.Score*100, r.NormalizedScore, r.Distance, r.Confidence*100)
 		} else {
 			fmt.Printf("   Match: %s\n", formatRelevance(r.Score))
 		}
@@ -389,47 +601,50 @@ func runFederatedSearch(query string, topK int, domain string, trustState string
 			fmt.Printf("   %s\n", trustLine)
 		}
 
-		snippet := r.Snippet
-		if len(snippet) > 150 {
-			snippet = snippet[:150] + "..."
-		}
-		snippet = strings.ReplaceAll(snippet, "\n", " ")
-		snippet = strings.ReplaceAll(snippet, "\r", "")
-		fmt.Printf("   %s\n", snippet)
+		fmt.Printf("   %s\n", displaySnippet(r.Snippet, opts.SnippetLen))
 	}
 	fmt.Println()
 
 	return nil
 }
 
-// vaultDBPath returns the database file path for a given vault root directory.
-func vaultDBPath(vaultRoot string) string {
-	return filepath.Join(vaultRoot, ".same", "data", "vault.db")
-}
-
 func relatedCmd() *cobra.Command {
 	var (
-		topK    int
-		jsonOut bool
-		verbose bool
+		topK        int
+		contentType string
+		domain      string
+		jsonOut     bool
+		verbose     bool
+		snippetLen  int
 	)
 	cmd := &cobra.Command{
-		Use:     "related [note-path]",
-		Short:   "Find notes related to a given note",
-		Long:    "Find notes similar to a given note. SAME uses the note's embedding to find semantically related content in your vault.",
-		Example: `  same related "architecture.md"`,
-		Args:    cobra.ExactArgs(1),
+		Use:   "related [note-path]",
+		Short: "Find notes related to a given note",
+		Long: `Find notes similar to a given note. SAME uses the note's embedding to find semantically related content in your vault.
+
+Narrow the neighbors with --type and --domain to build a focused reading
+list, for example only the decisions related to a design note.`,
+		Example: `  same related "architecture.md"
+  same related "architecture.md" --type decision
+  same related "architecture.md" --domain engineering`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRelated(args[0], topK, jsonOut, verbose)
+			if err := validateSnippetLen(snippetLen); err != nil {
+				return err
+			}
+			return runRelated(args[0], topK, contentType, domain, jsonOut, verbose, snippetLen)
 		},
 	}
 	cmd.Flags().IntVar(&topK, "top-k", 5, "Number of related notes to show")
+	cmd.Flags().StringVar(&contentType, "type", "", "Only show notes of this content type (decision, handoff, note, research)")
+	cmd.Flags().StringVar(&domain, "domain", "", "Only show notes in this domain")
 	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show raw scores for debugging")
+	cmd.Flags().IntVar(&snippetLen, "snippet-len", defaultSnippetLen, fmt.Sprintf("Characters of each note's snippet to show (%d-%d)", config.MinSnippetChars, store.ResultSnippetChars))
 	return cmd
 }
 
-func runRelated(notePath string, topK int, jsonOut bool, verbose bool) error {
+func runRelated(notePath string, topK int, contentType, domain string, jsonOut bool, verbose bool, snippetLen int) error {
 	db, err := store.Open()
 	if err != nil {
 		return dbOpenError(err)
@@ -459,7 +674,9 @@ func runRelated(notePath string, topK int, jsonOut bool, verbose bool) error {
 
 	// Search for similar notes, requesting extra to filter out the source note
 	results, err := db.VectorSearch(noteVec, store.SearchOptions{
-		TopK: topK + 3,
+		TopK:        topK + 3,
+		ContentType: contentType,
+		Domain:      domain,
 	})
 	if err != nil {
 		return fmt.Errorf("search: %w", err)
@@ -483,6 +700,10 @@ func runRelated(notePath string, topK int, jsonOut bool, verbose bool) error {
 	}
 
 	if len(filtered) == 0 {
+		if contentType != "" || domain != "" {
+			fmt.Println("No related notes found matching the --type/--domain filters.")
+			return nil
+		}
 		fmt.Println("No related notes found.")
 		return nil
 	}
@@ -505,87 +726,9 @@ func runRelated(notePath string, topK int, jsonOut bool, verbose bool) error {
 			fmt.Printf("   %s\n", trustLine)
 		}
 
-		snippet := r.Snippet
-		if len(snippet) > 150 {
-			snippet = snippet[:150] + "..."
-		}
-		snippet = strings.ReplaceAll(snippet, "\n", " ")
-		snippet = strings.ReplaceAll(snippet, "\r", "")
-		fmt.Printf("   %s\n", snippet)
+		fmt.Printf("   %s\n", displaySnippet(r.Snippet, snippetLen))
 	}
 	fmt.Println()
 
 	return nil
 }
-
-func staleCmd() *cobra.Command {
-	var (
-		topK    int
-		jsonOut bool
-	)
-	cmd := &cobra.Command{
-		Use:   "stale",
-		Short: "List notes with stale trust state",
-		Long: `Show all notes marked as stale — their source material has changed
-since the note was written. Equivalent to: same search --trust stale
-
-Use this to find notes that may need review or updating.`,
-		Args: cobra.NoArgs,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStale(topK, jsonOut)
-		},
-	}
-	cmd.Flags().IntVar(&topK, "top-k", 20, "Maximum number of results")
-	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
-	return cmd
-}
-
-func runStale(topK int, jsonOut bool) error {
-	db, err := store.Open()
-	if err != nil {
-		return config.ErrNoDatabase
-	}
-	defer db.Close()
-
-	results, err := db.MetadataFilterSearch(store.SearchOptions{
-		TopK:       topK,
-		TrustState: "stale",
-	})
-	if err != nil {
-		return fmt.Errorf("stale search: %w", err)
-	}
-
-	if jsonOut {
-		data, _ := json.MarshalIndent(results, "", "  ")
-		fmt.Println(string(data))
-		return nil
-	}
-
-	if len(results) == 0 {
-		fmt.Printf("\n  %sNo stale notes found. Your memory is up to date.%s\n\n", cli.Green, cli.Reset)
-		return nil
-	}
-
-	fmt.Printf("\n  %s%d stale note(s)%s — source material has changed since these were written:\n", cli.Yellow, len(results), cli.Reset)
-	for i, r := range results {
-		typeTag := ""
-		if r.ContentType != "" && r.ContentType != "note" {
-			typeTag = fmt.Sprintf(" [%s]", r.ContentType)
-		}
-
-		fmt.Printf("\n%d. %s%s\n", i+1, r.Title, typeTag)
-		fmt.Printf("   %s\n", r.Path)
-		fmt.Printf("   Trust: %sstale%s\n", cli.Yellow, cli.Reset)
-
-		snippet := r.Snippet
-		if len(snippet) > 150 {
-			snippet = snippet[:150] + "..."
-		}
-		snippet = strings.ReplaceAll(snippet, "\n", " ")
-		snippet = strings.ReplaceAll(snippet, "\r", "")
-		fmt.Printf("   %s\n", snippet)
-	}
-	fmt.Printf("\n  %sTip: Review and update these notes, then run 'same reindex' to refresh trust state.%s\n\n", cli.Dim, cli.Reset)
-
-	return nil
-}
@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -86,13 +88,13 @@ func captureCommandStdout(t *testing.T, fn func()) string {
 }
 
 func TestRunSearch_EmptyQuery(t *testing.T) {
-	if err := runSearch("", 5, "", "", "", nil, false, false); err == nil {
+	if err := runSearch("", searchOptions{TopK: 5}); err == nil {
 		t.Fatal("expected error for empty query")
 	}
 }
 
 func TestRunSearch_WhitespaceQuery(t *testing.T) {
-	if err := runSearch("   ", 5, "", "", "", nil, false, false); err == nil {
+	if err := runSearch("   ", searchOptions{TopK: 5}); err == nil {
 		t.Fatal("expected error for whitespace query")
 	}
 }
@@ -104,7 +106,7 @@ func TestRunSearch_KeywordMode(t *testing.T) {
 
 	var runErr error
 	out := captureCommandStdout(t, func() {
-		runErr = runSearch("jwt-tokens", 5, "", "", "", nil, false, false)
+		runErr = runSearch("jwt-tokens", searchOptions{TopK: 5})
 	})
 	if runErr != nil {
 		t.Fatalf("runSearch: %v", runErr)
@@ -114,6 +116,84 @@ func TestRunSearch_KeywordMode(t *testing.T) {
 	}
 }
 
+func TestRunSearch_HybridNeedsVectors(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "auth.md", "Authentication Design", "We decided to use jwt-tokens for authentication.")
+	_ = db.Close()
+
+	err := runSearch("jwt-tokens", searchOptions{TopK: 5, Hybrid: true})
+	if err == nil || !strings.Contains(err.Error(), "--hybrid") {
+		t.Fatalf("expected --hybrid error on a keyword-only vault, got %v", err)
+	}
+}
+
+func TestRunSearch_NoResults_SuggestsSpelling(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "auth.md", "Authentication Design", "We decided to use jwt-tokens.")
+	_ = db.Close()
+
+	var runErr error
+	out := captureCommandStdout(t, func() {
+		runErr = runSearch("autentication", searchOptions{TopK: 5})
+	})
+	if runErr != nil {
+		t.Fatalf("runSearch: %v", runErr)
+	}
+	if !strings.Contains(out, "Did you mean") || !strings.Contains(out, `"authentication"`) {
+		t.Fatalf("expected a spelling suggestion, got: %s", out)
+	}
+}
+
+func TestDisplaySnippet(t *testing.T) {
+	if got := displaySnippet("line one\nline two", 0); got != "line one line two" {
+		t.Errorf("displaySnippet flatten = %q", got)
+	}
+	long := strings.Repeat("a", 60) + "é" + strings.Repeat("b", 200)
+	if got := displaySnippet(long, 61); got != strings.Repeat("a", 60)+"..." {
+		t.Errorf("displaySnippet split a rune: %q", got)
+	}
+	if got := displaySnippet(long, 0); len(got) != defaultSnippetLen+len("...") {
+		t.Errorf("displaySnippet default length = %d, want %d", len(got), defaultSnippetLen+len("..."))
+	}
+	for _, n := range []int{0, 10, 501} {
+		if err := validateSnippetLen(n); err == nil {
+			t.Errorf("validateSnippetLen(%d) = nil, want error", n)
+		}
+	}
+	if err := validateSnippetLen(80); err != nil {
+		t.Errorf("validateSnippetLen(80): %v", err)
+	}
+}
+
+func TestRunSearch_OpenResult(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses the true command as the editor")
+	}
+	vault, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "auth.md", "Authentication Design", "We decided to use jwt-tokens for authentication.")
+	_ = db.Close()
+	if err := os.WriteFile(filepath.Join(vault, "auth.md"), []byte("# Authentication Design\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EDITOR", "true")
+
+	var runErr error
+	out := captureCommandStdout(t, func() {
+		runErr = runSearch("jwt-tokens", searchOptions{TopK: 5, OpenN: 1})
+	})
+	if runErr != nil {
+		t.Fatalf("runSearch --open 1: %v", runErr)
+	}
+	if !strings.Contains(out, "Opening auth.md") {
+		t.Fatalf("expected the opened path, got: %s", out)
+	}
+
+	err := runSearch("jwt-tokens", searchOptions{TopK: 5, OpenN: 2})
+	if err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Fatalf("expected out-of-range error for --open 2, got %v", err)
+	}
+}
+
 func TestRunSearch_NoResults_FewNotes(t *testing.T) {
 	_, db := setupCommandTestVault(t)
 	insertCommandTestNote(t, db, "a.md", "A", "alpha")
@@ -122,7 +202,7 @@ func TestRunSearch_NoResults_FewNotes(t *testing.T) {
 
 	var runErr error
 	out := captureCommandStdout(t, func() {
-		runErr = runSearch("not-present-term", 5, "", "", "", nil, false, false)
+		runErr = runSearch("not-present-term", searchOptions{TopK: 5})
 	})
 	if runErr != nil {
 		t.Fatalf("runSearch: %v", runErr)
@@ -141,7 +221,7 @@ func TestRunSearch_NoResults_ManyNotes(t *testing.T) {
 
 	var runErr error
 	out := captureCommandStdout(t, func() {
-		runErr = runSearch("missing-query-value", 5, "", "", "", nil, false, false)
+		runErr = runSearch("missing-query-value", searchOptions{TopK: 5})
 	})
 	if runErr != nil {
 		t.Fatalf("runSearch: %v", runErr)
@@ -158,7 +238,7 @@ func TestRunSearch_JSONOutput_EmptyResults(t *testing.T) {
 
 	var runErr error
 	out := captureCommandStdout(t, func() {
-		runErr = runSearch("term-not-found", 5, "", "", "", nil, true, false)
+		runErr = runSearch("term-not-found", searchOptions{TopK: 5, JSON: true})
 	})
 	if runErr != nil {
 		t.Fatalf("runSearch: %v", runErr)
@@ -175,7 +255,7 @@ func TestRunSearch_JSONOutput_WithResults(t *testing.T) {
 
 	var runErr error
 	out := captureCommandStdout(t, func() {
-		runErr = runSearch("unique-term-123", 5, "", "", "", nil, true, false)
+		runErr = runSearch("unique-term-123", searchOptions{TopK: 5, JSON: true})
 	})
 	if runErr != nil {
 		t.Fatalf("runSearch: %v", runErr)
@@ -191,7 +271,7 @@ func TestRunSearch_JSONOutput_WithResults(t *testing.T) {
 }
 
 func TestRunFederatedSearch_EmptyQuery(t *testing.T) {
-	if err := runFederatedSearch("", 5, "", "", "", nil, false, false, true, ""); err == nil {
+	if err := runFederatedSearch("", searchOptions{TopK: 5, AllVaults: true}); err == nil {
 		t.Fatal("expected error for empty federated query")
 	}
 }
@@ -204,7 +284,7 @@ func TestRunRelated_MissingPath(t *testing.T) {
 	t.Setenv("SAME_EMBED_MODEL", "test-embed")
 	t.Setenv("SAME_EMBED_BASE_URL", "http://127.0.0.1:11434")
 
-	err := runRelated("notes/missing.md", 5, false, false)
+	err := runRelated("notes/missing.md", 5, "", "", false, false, 0)
 	if err == nil {
 		t.Fatal("expected error for missing path")
 	}
@@ -212,3 +292,153 @@ func TestRunRelated_MissingPath(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestParseTimeFlag(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"7d", now.AddDate(0, 0, -7)},
+		{"2w", now.AddDate(0, 0, -14)},
+		{"36h", now.Add(-36 * time.Hour)},
+		{"2026-01-02", time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)},
+		{"2026/01/02", time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)},
+		{"2026-01-02T03:04:05Z", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseTimeFlag(tt.in, now)
+		if err != nil {
+			t.Errorf("parseTimeFlag(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseTimeFlag(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "yesterday", "-3d", "12x"} {
+		if _, err := parseTimeFlag(bad, now); err == nil {
+			t.Errorf("parseTimeFlag(%q): expected error", bad)
+		}
+	}
+}
+
+func TestSearchCmd_AfterLaterThanBefore(t *testing.T) {
+	cmd := searchCmd()
+	cmd.SetArgs([]string{"query", "--after", "2026-02-01", "--before", "2026-01-01"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected error when --after is later than --before")
+	}
+	if !strings.Contains(err.Error(), "--after is later than --before") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunSearch_OffsetNumbering(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	for _, name := range []string{"one", "two", "three", "four"} {
+		insertCommandTestNote(t, db, name+".md", "Paging "+name, "offset-term appears in "+name)
+	}
+	_ = db.Close()
+
+	var runErr error
+	out := captureCommandStdout(t, func() {
+		runErr = runSearch("offset-term", searchOptions{TopK: 2, Offset: 2})
+	})
+	if runErr != nil {
+		t.Fatalf("runSearch: %v", runErr)
+	}
+	if !strings.Contains(out, "\n3. ") || strings.Contains(out, "\n1. ") {
+		t.Fatalf("expected numbering to start at 3 with --offset 2, got: %s", out)
+	}
+
+	out = captureCommandStdout(t, func() {
+		runErr = runSearch("offset-term", searchOptions{TopK: 2, Offset: 10})
+	})
+	if runErr != nil {
+		t.Fatalf("runSearch past end: %v", runErr)
+	}
+	if !strings.Contains(out, "No more results past #10") {
+		t.Fatalf("expected past-end message, got: %s", out)
+	}
+}
+
+func TestWithSection(t *testing.T) {
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{"", "docs/guide.md"},
+		{"(full)", "docs/guide.md"},
+		{"(part 2)", "docs/guide.md"},
+		{"Guide > Install", "docs/guide.md § Guide > Install"},
+	}
+	for _, tt := range tests {
+		if got := withSection("docs/guide.md", tt.heading); got != tt.want {
+			t.Errorf("withSection(%q) = %q, want %q", tt.heading, got, tt.want)
+		}
+	}
+}
+
+func TestRunRelated_FiltersByTypeAndDomain(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	vec := func(x float32) []float32 {
+		v := make([]float32, 768)
+		v[0], v[1] = 1, x
+		return v
+	}
+	notes := []struct {
+		path, contentType, domain string
+		x                         float32
+	}{
+		{"notes/design.md", "note", "engineering", 0},
+		{"notes/adr-1.md", "decision", "engineering", 0.1},
+		{"notes/meeting.md", "note", "engineering", 0.05},
+		{"notes/pricing.md", "decision", "sales", 0.02},
+	}
+	for _, n := range notes {
+		rec := store.NoteRecord{
+			Path: n.path, Title: n.path, Tags: "[]", ChunkHeading: "(full)", Text: n.path,
+			Modified: float64(time.Now().Unix()), ContentHash: n.path, ContentType: n.contentType,
+			Domain: n.domain, Confidence: 0.8,
+		}
+		if err := db.InsertNote(&rec, vec(n.x)); err != nil {
+			t.Fatalf("InsertNote: %v", err)
+		}
+	}
+	t.Setenv("SAME_EMBED_PROVIDER", "openai-compatible")
+	t.Setenv("SAME_EMBED_MODEL", "test-embed")
+	t.Setenv("SAME_EMBED_BASE_URL", "http://127.0.0.1:11434")
+
+	related := func(contentType, domain string) []string {
+		out := captureCommandStdout(t, func() {
+			if err := runRelated("notes/design.md", 5, contentType, domain, true, false, 0); err != nil {
+				t.Fatalf("runRelated: %v", err)
+			}
+		})
+		var results []store.SearchResult
+		if err := json.Unmarshal([]byte(out), &results); err != nil {
+			t.Fatalf("decode %q: %v", out, err)
+		}
+		var paths []string
+		for _, r := range results {
+			paths = append(paths, r.Path)
+		}
+		return paths
+	}
+
+	if got := related("decision", ""); len(got) != 2 || slices.Contains(got, "notes/meeting.md") {
+		t.Errorf("--type decision = %v, want the two decisions", got)
+	}
+	if got := related("decision", "engineering"); len(got) != 1 || got[0] != "notes/adr-1.md" {
+		t.Errorf("--type decision --domain engineering = %v, want [notes/adr-1.md]", got)
+	}
+	if got := related("", ""); slices.Contains(got, "notes/design.md") {
+		t.Errorf("source note should be excluded, got %v", got)
+	}
+}
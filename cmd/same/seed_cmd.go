@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
 	"github.com/sgx-labs/statelessagent/internal/seed"
 )
 
@@ -20,27 +22,33 @@ func seedCmd() *cobra.Command {
 templates, and decision frameworks that give your AI instant expertise.
 
 Seeds are curated knowledge bases that work out of the box with SAME.
-Install one and start searching immediately.`,
+Install one and start searching immediately, or package your own vault
+with 'same seed create' to share it with your team.`,
 	}
 
 	cmd.AddCommand(seedListCmd())
 	cmd.AddCommand(seedInstallCmd())
 	cmd.AddCommand(seedInfoCmd())
 	cmd.AddCommand(seedRemoveCmd())
+	cmd.AddCommand(seedCreateCmd())
 	return cmd
 }
 
 func seedListCmd() *cobra.Command {
 	var refresh bool
+	var offline bool
 	var jsonOut bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Show available seeds",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			manifest, err := seed.FetchManifest(refresh)
+			if refresh && offline {
+				return userError("--refresh and --offline can't be combined", "drop one of them")
+			}
+			manifest, err := loadSeedManifest(refresh, offline)
 			if err != nil {
-				return userError("Could not fetch seed list", "Check your internet connection and try again")
+				return err
 			}
 
 			if jsonOut {
@@ -72,6 +80,7 @@ func seedListCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVar(&refresh, "refresh", false, "Bypass cache and fetch fresh list")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Use the cached seed list without going online")
 	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
 	return cmd
 }
@@ -80,6 +89,8 @@ func seedInstallCmd() *cobra.Command {
 	var path string
 	var force bool
 	var noIndex bool
+	var offline bool
+	var allowUnverified bool
 
 	cmd := &cobra.Command{
 		Use:               "install [name]",
@@ -91,7 +102,7 @@ func seedInstallCmd() *cobra.Command {
 
 			// Allow selecting by number (e.g. "same seed install 1")
 			if n, err := strconv.Atoi(name); err == nil && n >= 1 {
-				if m, mErr := seed.FetchManifest(false); mErr == nil && n <= len(m.Seeds) {
+				if m, mErr := loadSeedManifest(false, offline); mErr == nil && n <= len(m.Seeds) {
 					name = m.Seeds[n-1].Name
 				}
 			}
@@ -104,7 +115,10 @@ func seedInstallCmd() *cobra.Command {
 				Path:    path,
 				Force:   force,
 				NoIndex: noIndex,
+				Offline: offline,
 				Version: Version,
+
+				AllowUnverified: allowUnverified,
 				OnDownloadStart: func() {
 					fmt.Printf("  Downloading...               ")
 				},
@@ -129,9 +143,20 @@ func seedInstallCmd() *cobra.Command {
 				if strings.Contains(errMsg, "already exists") {
 					return userError(errMsg, "use --force to overwrite the existing installation")
 				}
+				if strings.Contains(errMsg, "no cached seed list") {
+					return userError("No cached seed list", "run 'same seed list' once while online, or drop --offline")
+				}
 				if strings.Contains(errMsg, "not found") {
 					return userError(errMsg, "run 'same seed list' to see available seeds")
 				}
+				if strings.Contains(errMsg, "checksum mismatch") {
+					return userError("Seed failed checksum verification — nothing was installed",
+						"the download may be corrupt or tampered with; try again, and report it if it keeps failing")
+				}
+				if errors.Is(err, seed.ErrNoChecksum) {
+					return userError("Seed has no checksum in the seed list — nothing was installed",
+						"its files cannot be verified; rerun with --allow-unverified only if you trust the source")
+				}
 				if strings.Contains(errMsg, "requires SAME") {
 					return userError(errMsg, "run 'same update' to get the latest version")
 				}
@@ -142,6 +167,11 @@ func seedInstallCmd() *cobra.Command {
 				return fmt.Errorf("install failed: %w", err)
 			}
 
+			if result.Verified {
+				fmt.Printf("  Checksum verified            %s✓%s\n", cli.Green, cli.Reset)
+			} else {
+				fmt.Printf("  %s!%s Checksum not verified (installed with --allow-unverified)\n", cli.Yellow, cli.Reset)
+			}
 			fmt.Printf("  Registered as vault %q\n", name)
 			seed.PrintLegalNotice()
 			fmt.Printf("\n  Installed to %s\n", cli.ShortenHome(result.DestDir))
@@ -155,6 +185,8 @@ func seedInstallCmd() *cobra.Command {
 	cmd.Flags().StringVar(&path, "path", "", "Custom install directory (default: ~/same-seeds/<name>)")
 	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing installation")
 	cmd.Flags().BoolVar(&noIndex, "no-index", false, "Skip indexing after install")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Look the seed up in the cached seed list instead of fetching it")
+	cmd.Flags().BoolVar(&allowUnverified, "allow-unverified", false, "Install even if the seed list has no checksum for this seed")
 	return cmd
 }
 
@@ -262,6 +294,88 @@ func seedRemoveCmd() *cobra.Command {
 	return cmd
 }
 
+func seedCreateCmd() *cobra.Command {
+	var output string
+	var description string
+	var seedVersion string
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Package the current vault as a shareable seed",
+		Long: `Bundle the current vault's markdown notes into a seed archive you can host.
+
+The archive has the same layout as the public seed-vaults repository: a
+seeds.json manifest entry (name, description, note count, size, version)
+next to the notes under <name>/. _PRIVATE/, hidden directories such as
+.same/, and .sameignore matches are never included.
+
+Examples:
+  same seed create team-runbooks --description "On-call runbooks"
+  same seed create team-runbooks --seed-version 1.2.0 -o dist/team-runbooks.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vaultPath := config.VaultPath()
+			if vaultPath == "" {
+				return userError("No vault found", "run 'same init' first to set up your vault")
+			}
+			result, err := seed.Create(seed.CreateOptions{
+				Name:           args[0],
+				VaultDir:       vaultPath,
+				Output:         output,
+				Description:    description,
+				Version:        seedVersion,
+				MinSameVersion: seedMinVersion(Version),
+			})
+			if err != nil {
+				if strings.Contains(err.Error(), "seed name") {
+					return userError(err.Error(), "use lowercase letters, digits, and hyphens, e.g. team-runbooks")
+				}
+				return fmt.Errorf("create seed: %w", err)
+			}
+
+			fmt.Printf("\n  %s✓%s Packaged %d notes (%d KB) into %s\n",
+				cli.Green, cli.Reset, result.Seed.NoteCount, result.Seed.SizeKB, result.Output)
+			entry, _ := json.MarshalIndent(result.Seed, "  ", "  ")
+			fmt.Printf("\n  Manifest entry (also in the archive's seeds.json):\n  %s\n\n", entry)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Archive path (default: <name>.tar.gz)")
+	cmd.Flags().StringVar(&description, "description", "", "One-line description for the manifest")
+	cmd.Flags().StringVar(&seedVersion, "seed-version", "1.0.0", "Version of this seed")
+	return cmd
+}
+
+// seedMinVersion returns the running SAME version as a manifest
+// min_same_version, or "" for development builds.
+func seedMinVersion(v string) string {
+	v = strings.TrimPrefix(v, "v")
+	if v == "" || v == "dev" {
+		return ""
+	}
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	return v
+}
+
+// loadSeedManifest returns the seed list: the cached copy when offline,
+// otherwise the cache or a fresh fetch.
+func loadSeedManifest(refresh, offline bool) (*seed.Manifest, error) {
+	if offline {
+		m, err := seed.CachedManifest()
+		if err != nil {
+			return nil, userError("No cached seed list", "run 'same seed list' once while online, or drop --offline")
+		}
+		return m, nil
+	}
+	m, err := seed.FetchManifest(refresh)
+	if err != nil {
+		return nil, userError("Could not fetch seed list", "Check your internet connection and try again")
+	}
+	return m, nil
+}
+
 // seedNameCompleter provides tab-completion for seed names.
 func seedNameCompleter(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if len(args) > 0 {
@@ -120,3 +120,68 @@ func TestSeedCmd_InstallInvalidName(t *testing.T) {
 		t.Fatalf("expected recovery hint in error, got: %v", err)
 	}
 }
+
+func TestSeedCmd_ListOffline(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	// A stale cache would normally trigger a refetch; --offline uses it as is.
+	writeSeedManifestCache(t, home, time.Now().Add(-30*24*time.Hour), []seed.Seed{testSeedEntry()})
+
+	cmd := seedListCmd()
+	cmd.SetArgs([]string{"--offline", "--json"})
+
+	var execErr error
+	out := captureCommandStdout(t, func() {
+		execErr = cmd.Execute()
+	})
+	if execErr != nil {
+		t.Fatalf("seed list --offline: %v", execErr)
+	}
+	if !strings.Contains(out, "solo-dev-kit") {
+		t.Fatalf("expected cached seed in output, got: %q", out)
+	}
+}
+
+func TestSeedCmd_OfflineWithoutCache(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	for name, args := range map[string][]string{
+		"list":    {"--offline"},
+		"install": {"solo-dev-kit", "--offline"},
+	} {
+		cmd := seedListCmd()
+		if name == "install" {
+			cmd = seedInstallCmd()
+		}
+		cmd.SetArgs(args)
+		var err error
+		captureCommandStdout(t, func() { err = cmd.Execute() })
+		if err == nil {
+			t.Fatalf("%s: expected error without a cached seed list", name)
+		}
+		if !strings.Contains(err.Error(), "No cached seed list") {
+			t.Fatalf("%s: expected no-cache message, got: %v", name, err)
+		}
+	}
+}
+
+func TestSeedCmd_InstallOfflineInvalidName(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	writeSeedManifestCache(t, home, time.Now().Add(-30*24*time.Hour), []seed.Seed{testSeedEntry()})
+
+	cmd := seedInstallCmd()
+	cmd.SetArgs([]string{"missing-seed", "--offline", "--no-index"})
+
+	var err error
+	captureCommandStdout(t, func() { err = cmd.Execute() })
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not-found from the cached list, got: %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/memory"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func staleCmd() *cobra.Command {
+	var (
+		topK    int
+		days    int
+		jsonOut bool
+	)
+	cmd := &cobra.Command{
+		Use:   "stale",
+		Short: "List notes that may be outdated",
+		Long: `Show notes that may need review, oldest first:
+  • source changed — the files a note was written from have changed
+  • review overdue — past the note's review_by date
+  • expired        — past the note's expires date (no longer surfaced)
+  • not updated    — not modified for [surfacing] stale_after_days days
+
+Age-based staleness is off until you set stale_after_days (or pass --days).
+Notes that don't go out of date can opt out with "evergreen: true" in
+their frontmatter. Notes that only matter for a while can set
+"expires: 2026-03-01"; after that date they stay searchable but are no
+longer surfaced automatically.
+
+Examples:
+  same stale
+  same stale --days 90
+  same config set surfacing.stale_after_days 180
+  same stale --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if days < 0 {
+				return userError("--days must be zero or positive", "Use --days 90 to flag notes not modified for 90 days")
+			}
+			if !cmd.Flags().Changed("days") {
+				days = config.SurfacingStaleAfterDays()
+			}
+			return runStale(topK, days, jsonOut)
+		},
+	}
+	cmd.Flags().IntVar(&topK, "top-k", 20, "Maximum number of results")
+	cmd.Flags().IntVar(&days, "days", 0, "Flag notes not modified for this many days (default: surfacing.stale_after_days)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func runStale(topK, days int, jsonOut bool) error {
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	entries, err := memory.ListStaleNotes(db, days, topK, time.Now())
+	if err != nil {
+		return fmt.Errorf("stale search: %w", err)
+	}
+
+	if jsonOut {
+		if entries == nil {
+			entries = []memory.StaleEntry{}
+		}
+		data, _ := json.MarshalIndent(entries, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("\n  %sNo stale notes found. Your memory is up to date.%s\n", cli.Green, cli.Reset)
+		if days == 0 {
+			fmt.Printf("  %sTip: set surfacing.stale_after_days to also flag notes by age.%s\n", cli.Dim, cli.Reset)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("\n  %s%d stale note(s)%s, oldest first:\n", cli.Yellow, len(entries), cli.Reset)
+	for i, e := range entries {
+		typeTag := ""
+		if e.ContentType != "" && e.ContentType != "note" {
+			typeTag = fmt.Sprintf(" [%s]", e.ContentType)
+		}
+
+		fmt.Printf("\n%d. %s%s\n", i+1, e.Title, typeTag)
+		fmt.Printf("   %s\n", e.Path)
+		fmt.Printf("   %s%s%s %s(last modified %d days ago)%s\n",
+			cli.Yellow, strings.Join(e.Reasons, ", "), cli.Reset, cli.Dim, e.DaysOld, cli.Reset)
+	}
+	fmt.Printf("\n  %sTip: Review and update these notes, then run 'same reindex' to refresh trust state.%s\n", cli.Dim, cli.Reset)
+	fmt.Printf("  %sMark notes that never go out of date with \"evergreen: true\" in their frontmatter.%s\n\n", cli.Dim, cli.Reset)
+
+	return nil
+}
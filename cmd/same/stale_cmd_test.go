@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sgx-labs/statelessagent/internal/memory"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func TestRunStale_ByAge(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	old := float64(time.Now().AddDate(0, 0, -300).Unix())
+	if _, err := db.BulkInsertNotesLite([]store.NoteRecord{
+		{Path: "old.md", Title: "Old Runbook", Tags: "[]", ChunkHeading: "(full)", Text: "x", Modified: old, ContentType: "note"},
+		{Path: "principles.md", Title: "Principles", Tags: "[]", ChunkHeading: "(full)", Text: "x", Modified: old, ContentType: "note", Evergreen: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	insertCommandTestNote(t, db, "new.md", "New Note", "x")
+
+	var runErr error
+	out := captureCommandStdout(t, func() { runErr = runStale(20, 180, false) })
+	if runErr != nil {
+		t.Fatalf("runStale: %v", runErr)
+	}
+	if !strings.Contains(out, "Old Runbook") || !strings.Contains(out, "not updated in 180+ days") {
+		t.Errorf("expected the old note flagged by age:\n%s", out)
+	}
+	if strings.Contains(out, "Principles") || strings.Contains(out, "New Note") {
+		t.Errorf("evergreen and recent notes should not be listed:\n%s", out)
+	}
+
+	out = captureCommandStdout(t, func() { runErr = runStale(20, 0, true) })
+	if runErr != nil {
+		t.Fatalf("runStale --json: %v", runErr)
+	}
+	var entries []memory.StaleEntry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(entries) != 0 {
+		t.Errorf("with age-based staleness off got %+v, want none", entries)
+	}
+}
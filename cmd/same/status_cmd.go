@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -20,8 +23,18 @@ import (
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
+// statusWatchInterval is the default refresh period for --watch.
+const statusWatchInterval = 2 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
 func statusCmd() *cobra.Command {
-	var jsonOut bool
+	var (
+		jsonOut  bool
+		watch    bool
+		interval time.Duration
+	)
 	cmd := &cobra.Command{
 		Use:     "status",
 		Aliases: []string{"st"},
@@ -31,15 +44,71 @@ func statusCmd() *cobra.Command {
   - Which embedding/chat providers are active
   - Which AI tool integrations are active
 
-Run this anytime to see if SAME is working.`,
+Run this anytime to see if SAME is working.
+
+With --watch, clears the screen and reprints the status every few seconds
+until Ctrl+C, which is handy while a large vault indexes or Ollama starts.
+Combined with --json, each refresh is printed as one JSON object per line.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if watch {
+				if interval < time.Second {
+					return userError("--interval must be at least 1s", "e.g. same status --watch --interval 5s")
+				}
+				ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+				return runStatusWatch(ctx, jsonOut, interval)
+			}
 			return runStatus(jsonOut)
 		},
 	}
 	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Reprint the status every few seconds until Ctrl+C")
+	cmd.Flags().DurationVar(&interval, "interval", statusWatchInterval, "Refresh period for --watch")
 	return cmd
 }
 
+// runStatusWatch prints a status frame every interval until ctx is
+// cancelled. The vault is resolved again for each frame.
+func runStatusWatch(ctx context.Context, jsonOut bool, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := printStatusFrame(jsonOut, interval); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// printStatusFrame prints one --watch refresh: a compact JSON line, or the
+// full human status on a cleared screen.
+func printStatusFrame(jsonOut bool, interval time.Duration) error {
+	if jsonOut {
+		vp := config.VaultPath()
+		if vp == "" {
+			return config.ErrNoVault
+		}
+		data := collectStatusData(vp, detectEmbeddingStatus(), detectChatStatus(), detectGraphStatus())
+		line, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("marshal JSON: %w", err)
+		}
+		fmt.Println(string(line))
+		return nil
+	}
+	fmt.Print(clearScreen)
+	if err := runStatus(false); err != nil {
+		return err
+	}
+	fmt.Printf("  %sRefreshing every %s · updated %s · Ctrl+C to stop%s\n",
+		cli.Dim, interval, time.Now().Format("15:04:05"), cli.Reset)
+	return nil
+}
+
 type runtimeStatus struct {
 	Provider string `json:"provider"`
 	Status   string `json:"status"`
@@ -63,11 +132,12 @@ type graphRuntimeStatus struct {
 // StatusData represents the status information for JSON output.
 type StatusData struct {
 	Vault struct {
-		Path       string  `json:"path"` // Just the directory name, not full absolute path
-		Notes      int     `json:"notes"`
-		Chunks     int     `json:"chunks"`
-		IndexedAgo string  `json:"indexed_ago,omitempty"`
-		DBSizeMB   float64 `json:"db_size_mb,omitempty"`
+		Path            string  `json:"path"` // Just the directory name, not full absolute path
+		Notes           int     `json:"notes"`
+		Chunks          int     `json:"chunks"`
+		IndexedAgo      string  `json:"indexed_ago,omitempty"`
+		IndexAgeSeconds int64   `json:"index_age_seconds,omitempty"`
+		DBSizeMB        float64 `json:"db_size_mb,omitempty"`
 	} `json:"vault"`
 	Environment *struct {
 		Container bool   `json:"container"`
@@ -92,8 +162,10 @@ type StatusData struct {
 		Names   []string `json:"names,omitempty"`
 	} `json:"vaults"`
 	Config struct {
-		Loaded  string `json:"loaded,omitempty"` // Just the filename, not full path
-		Warning string `json:"warning,omitempty"`
+		Loaded  string   `json:"loaded,omitempty"` // Just the filename, not full path
+		Warning string   `json:"warning,omitempty"`
+		Error   string   `json:"error,omitempty"`  // load failure; defaults are in use
+		Issues  []string `json:"issues,omitempty"` // unknown keys and out-of-range values
 	} `json:"config"`
 	Initialized bool `json:"initialized"`
 }
@@ -109,99 +181,7 @@ func runStatus(jsonOut bool) error {
 	graphStatus := detectGraphStatus()
 
 	if jsonOut {
-		// Collect data for JSON output
-		data := StatusData{}
-		// SECURITY: Only include vault directory name, not full absolute path
-		data.Vault.Path = filepath.Base(vp)
-		data.Hooks = make(map[string]bool)
-		data.Embedding = embeddingStatus
-		data.Chat = chatStatus
-		data.Graph = graphStatus
-
-		if ci := config.DetectContainer(); ci.Detected {
-			data.Environment = &struct {
-				Container bool   `json:"container"`
-				Type      string `json:"type,omitempty"`
-			}{Container: true, Type: ci.Type}
-		}
-
-		db, err := store.Open()
-		if err != nil {
-			data.Initialized = false
-		} else {
-			defer db.Close()
-			data.Initialized = true
-
-			noteCount, _ := db.NoteCount()
-			chunkCount, _ := db.ChunkCount()
-			data.Vault.Notes = noteCount
-			data.Vault.Chunks = chunkCount
-
-			// Index age
-			indexAge, _ := db.IndexAge()
-			if indexAge > 0 {
-				data.Vault.IndexedAgo = formatDuration(indexAge)
-			}
-
-			// DB size
-			dbPath := config.DBPath()
-			if info, err := os.Stat(dbPath); err == nil {
-				data.Vault.DBSizeMB = float64(info.Size()) / (1024 * 1024)
-			}
-
-			if nodes, edges, graphErr := graphCounts(db); graphErr == nil {
-				data.Graph.Nodes = nodes
-				data.Graph.Edges = edges
-			} else if data.Graph.Hint == "" {
-				data.Graph.Hint = "graph tables unavailable — run 'same reindex'"
-			}
-		}
-
-		if embeddingStatus.Provider == "ollama" {
-			data.Ollama = &struct {
-				Status string `json:"status"`
-				Model  string `json:"model,omitempty"`
-				Error  string `json:"error,omitempty"`
-			}{
-				Status: embeddingStatus.Status,
-				Model:  embeddingStatus.Model,
-				Error:  embeddingStatus.Error,
-			}
-		}
-
-		// Hooks
-		hookStatus := setup.HooksInstalled(vp)
-		hookNames := []string{
-			"context-surfacing",
-			"decision-extractor",
-			"handoff-generator",
-			"feedback-loop",
-			"staleness-check",
-			"session-bootstrap",
-		}
-		for _, name := range hookNames {
-			data.Hooks[name] = hookStatus[name]
-		}
-
-		// MCP
-		data.MCP.Installed = setup.MCPInstalled(vp)
-
-		// Vaults
-		reg := config.LoadRegistry()
-		data.Vaults.Count = len(reg.Vaults)
-		data.Vaults.Default = reg.Default
-		for name := range reg.Vaults {
-			data.Vaults.Names = append(data.Vaults.Names, name)
-		}
-
-		// Config
-		if w := config.ConfigWarning(); w != "" {
-			data.Config.Warning = w
-		} else if cf := config.FindConfigFile(); cf != "" {
-			// SECURITY: Only include filename, not full path
-			data.Config.Loaded = filepath.Base(cf)
-		}
-
+		data := collectStatusData(vp, embeddingStatus, chatStatus, graphStatus)
 		jsonBytes, err := json.MarshalIndent(data, "", "  ")
 		if err != nil {
 			return fmt.Errorf("marshal JSON: %w", err)
@@ -355,9 +335,13 @@ func runStatus(jsonOut bool) error {
 	}
 
 	cli.Section("Config")
-	if w := config.ConfigWarning(); w != "" {
-		fmt.Printf("  %sconfig error:%s %s\n", cli.Red, cli.Reset, w)
+	if _, err := config.LoadConfig(); err != nil {
+		fmt.Printf("  %sconfig error:%s %v\n", cli.Red, cli.Reset, err)
 		fmt.Printf("  (using defaults — check .same/config.toml)\n")
+	} else if issues := config.ValidateConfig(); len(issues) > 0 {
+		for _, issue := range issues {
+			fmt.Printf("  %sconfig warning:%s %s\n", cli.Yellow, cli.Reset, issue)
+		}
 	} else if config.FindConfigFile() != "" {
 		fmt.Printf("  Loaded:  %s\n", cli.ShortenHome(config.FindConfigFile()))
 	} else {
@@ -370,6 +354,123 @@ func runStatus(jsonOut bool) error {
 	return nil
 }
 
+// collectStatusData gathers what 'same status' reports into the shape of
+// 'same status --json'.
+func collectStatusData(vp string, embeddingStatus, chatStatus runtimeStatus, graphStatus graphRuntimeStatus) StatusData {
+	data := StatusData{}
+	// SECURITY: Only include vault directory name, not full absolute path
+	data.Vault.Path = filepath.Base(vp)
+	data.Hooks = make(map[string]bool)
+	data.Embedding = embeddingStatus
+	data.Chat = chatStatus
+	data.Graph = graphStatus
+
+	if ci := config.DetectContainer(); ci.Detected {
+		data.Environment = &struct {
+			Container bool   `json:"container"`
+			Type      string `json:"type,omitempty"`
+		}{Container: true, Type: ci.Type}
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		data.Initialized = false
+	} else {
+		defer db.Close()
+		data.Initialized = true
+
+		noteCount, _ := db.NoteCount()
+		chunkCount, _ := db.ChunkCount()
+		data.Vault.Notes = noteCount
+		data.Vault.Chunks = chunkCount
+
+		// Index age
+		indexAge, _ := db.IndexAge()
+		if indexAge > 0 {
+			data.Vault.IndexedAgo = formatDuration(indexAge)
+			data.Vault.IndexAgeSeconds = int64(indexAge.Seconds())
+		}
+
+		// DB size
+		dbPath := config.DBPath()
+		if info, err := os.Stat(dbPath); err == nil {
+			data.Vault.DBSizeMB = float64(info.Size()) / (1024 * 1024)
+		}
+
+		if nodes, edges, graphErr := graphCounts(db); graphErr == nil {
+			data.Graph.Nodes = nodes
+			data.Graph.Edges = edges
+		} else if data.Graph.Hint == "" {
+			data.Graph.Hint = "graph tables unavailable — run 'same reindex'"
+		}
+	}
+
+	if embeddingStatus.Provider == "ollama" {
+		data.Ollama = &struct {
+			Status string `json:"status"`
+			Model  string `json:"model,omitempty"`
+			Error  string `json:"error,omitempty"`
+		}{
+			Status: embeddingStatus.Status,
+			Model:  embeddingStatus.Model,
+			Error:  embeddingStatus.Error,
+		}
+	}
+
+	// Hooks
+	hookStatus := setup.HooksInstalled(vp)
+	hookNames := []string{
+		"context-surfacing",
+		"decision-extractor",
+		"handoff-generator",
+		"feedback-loop",
+		"staleness-check",
+		"session-bootstrap",
+	}
+	for _, name := range hookNames {
+		data.Hooks[name] = hookStatus[name]
+	}
+
+	// MCP
+	data.MCP.Installed = setup.MCPInstalled(vp)
+
+	// Vaults
+	reg := config.LoadRegistry()
+	data.Vaults.Count = len(reg.Vaults)
+	data.Vaults.Default = reg.Default
+	for name := range reg.Vaults {
+		data.Vaults.Names = append(data.Vaults.Names, name)
+	}
+
+	// Config
+	data.Config.Warning = config.ConfigWarning()
+	if _, err := config.LoadConfig(); err != nil {
+		data.Config.Error = configErrorWithoutPaths(err)
+	} else {
+		if cf := config.FindConfigFile(); cf != "" {
+			// SECURITY: Only include filename, not full path
+			data.Config.Loaded = filepath.Base(cf)
+		}
+		for _, issue := range config.ValidateConfig() {
+			data.Config.Issues = append(data.Config.Issues, issue.String())
+		}
+	}
+
+	return data
+}
+
+// configErrorWithoutPaths reports a config load error with the config
+// directories stripped, leaving file names only.
+func configErrorWithoutPaths(err error) string {
+	msg := sanitizeRuntimeError(err)
+	for _, path := range []string{config.GlobalConfigPath(), config.FindConfigFile()} {
+		if path != "" {
+			msg = strings.ReplaceAll(msg, path, filepath.Base(path))
+		}
+	}
+	return msg
+}
+
 func detectEmbeddingStatus() runtimeStatus {
 	ec := config.EmbeddingProviderConfig()
 	provider := strings.TrimSpace(ec.Provider)
@@ -444,7 +545,7 @@ func detectEmbeddingStatus() runtimeStatus {
 }
 
 func detectChatStatus() runtimeStatus {
-	requested := strings.TrimSpace(os.Getenv("SAME_CHAT_PROVIDER"))
+	requested := strings.TrimSpace(os.Getenv(config.EnvChatProvider))
 	if requested == "" {
 		requested = "auto"
 	}
@@ -1,8 +1,17 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
 func TestActiveVaultSource(t *testing.T) {
@@ -135,3 +144,98 @@ func TestDetectGraphStatusLocalOnlyFallbackNoLocalProvider(t *testing.T) {
 		t.Fatal("expected non-empty hint for local-only fallback")
 	}
 }
+
+func TestRunStatusJSON_ReportsIndexAndConfigState(t *testing.T) {
+	vault, db := setupCommandTestVault(t)
+	t.Setenv("SAME_CHAT_PROVIDER", "none")
+	rec := store.NoteRecord{
+		Path: "notes/a.md", Title: "A", Tags: "[]", ChunkHeading: "(full)", Text: "alpha",
+		Modified: float64(time.Now().Add(-2 * time.Hour).Unix()), ContentHash: "a-hash", ContentType: "note", Confidence: 0.8,
+	}
+	if _, err := db.BulkInsertNotesLite([]store.NoteRecord{rec}); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+	cfg := filepath.Join(vault, ".same", "config.toml")
+	if err := os.WriteFile(cfg, []byte("[memory]\nmax_resluts = 5\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	var runErr error
+	out := captureCommandStdout(t, func() { runErr = runStatus(true) })
+	if runErr != nil {
+		t.Fatalf("runStatus: %v", runErr)
+	}
+	var data StatusData
+	if err := json.Unmarshal([]byte(out), &data); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+
+	if !data.Initialized || data.Vault.Notes != 1 || data.Vault.Chunks != 1 {
+		t.Errorf("vault = %+v initialized=%v", data.Vault, data.Initialized)
+	}
+	if data.Vault.Path != filepath.Base(vault) {
+		t.Errorf("vault path = %q, want directory name only", data.Vault.Path)
+	}
+	if data.Vault.IndexAgeSeconds < 7000 || data.Vault.IndexedAgo == "" {
+		t.Errorf("index age = %d (%q), want about two hours", data.Vault.IndexAgeSeconds, data.Vault.IndexedAgo)
+	}
+	if data.Embedding.Status != "disabled" {
+		t.Errorf("embedding status = %q", data.Embedding.Status)
+	}
+	if len(data.Hooks) == 0 {
+		t.Error("expected per-hook install status")
+	}
+	if data.Config.Loaded != "config.toml" || len(data.Config.Issues) != 1 || !strings.Contains(data.Config.Issues[0], "max_resluts") {
+		t.Errorf("config = %+v", data.Config)
+	}
+	if strings.Contains(out, vault) {
+		t.Errorf("JSON output leaks the absolute vault path:\n%s", out)
+	}
+}
+
+func TestRunStatusWatch_JSONLinePerRefresh(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	t.Setenv("SAME_CHAT_PROVIDER", "none")
+	insertCommandTestNote(t, db, "notes/a.md", "A", "alpha")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 350*time.Millisecond)
+	defer cancel()
+	var runErr error
+	out := captureCommandStdout(t, func() { runErr = runStatusWatch(ctx, true, 100*time.Millisecond) })
+	if runErr != nil {
+		t.Fatalf("runStatusWatch: %v", runErr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected several refreshes, got %d:\n%s", len(lines), out)
+	}
+	for i, line := range lines {
+		var data StatusData
+		if err := json.Unmarshal([]byte(line), &data); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v\n%s", i, err, line)
+		}
+		if data.Vault.Notes != 1 {
+			t.Errorf("line %d: notes = %d, want 1", i, data.Vault.Notes)
+		}
+	}
+	if strings.Contains(out, clearScreen) {
+		t.Error("JSON watch output should not clear the screen")
+	}
+}
+
+func TestRunStatusWatch_WithoutVaultFails(t *testing.T) {
+	old := config.VaultOverride
+	config.VaultOverride = ""
+	t.Cleanup(func() { config.VaultOverride = old })
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("VAULT_PATH", "")
+	t.Chdir(home)
+
+	err := runStatusWatch(context.Background(), true, time.Second)
+	if !errors.Is(err, config.ErrNoVault) {
+		t.Fatalf("expected ErrNoVault, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func tagsCmd() *cobra.Command {
+	var (
+		prefix  string
+		jsonOut bool
+	)
+	cmd := &cobra.Command{
+		Use:   "tags",
+		Short: "List all tags in your vault with note counts",
+		Long: `List every frontmatter tag in the vault with the number of notes using it,
+most frequent first. Use this to keep tagging consistent and to find values
+for 'same search --tag'.
+
+Examples:
+  same tags
+  same tags --prefix team/
+  same tags --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTags(prefix, jsonOut)
+		},
+	}
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Only show tags starting with this prefix (e.g. team/)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func runTags(prefix string, jsonOut bool) error {
+	db, err := store.Open()
+	if err != nil {
+		return config.ErrNoDatabase
+	}
+	defer db.Close()
+
+	tags, err := db.TagCounts(prefix)
+	if err != nil {
+		return fmt.Errorf("list tags: %w", err)
+	}
+
+	if jsonOut {
+		data, _ := json.MarshalIndent(tags, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(tags) == 0 {
+		if prefix != "" {
+			fmt.Printf("\n  No tags starting with %q.\n\n", prefix)
+		} else {
+			fmt.Println("\n  No tags found.")
+			fmt.Printf("  %sAdd tags in note frontmatter (tags: [auth, api]) and run 'same reindex'.%s\n\n", cli.Dim, cli.Reset)
+		}
+		return nil
+	}
+
+	width := 0
+	for _, t := range tags {
+		if len(t.Tag) > width {
+			width = len(t.Tag)
+		}
+	}
+
+	fmt.Printf("\n  %d tag(s):\n\n", len(tags))
+	for _, t := range tags {
+		noteWord := "notes"
+		if t.Count == 1 {
+			noteWord = "note"
+		}
+		fmt.Printf("  %-*s  %d %s\n", width, t.Tag, t.Count, noteWord)
+	}
+	fmt.Printf("\n  %sFilter search by tag: same search \"query\" --tag %s%s\n\n", cli.Dim, tags[0].Tag, cli.Reset)
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func insertTaggedTestNote(t *testing.T, db *store.DB, path, tags string) {
+	t.Helper()
+	rec := store.NoteRecord{
+		Path:         path,
+		Title:        path,
+		Tags:         tags,
+		ChunkID:      0,
+		ChunkHeading: "(full)",
+		Text:         "tagged note",
+		Modified:     1700000000,
+		ContentHash:  path + "-hash",
+		ContentType:  "note",
+	}
+	if _, err := db.BulkInsertNotesLite([]store.NoteRecord{rec}); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+}
+
+func TestTagsCmd_Empty(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	_ = db.Close()
+
+	var runErr error
+	out := captureCommandStdout(t, func() {
+		runErr = runTags("", false)
+	})
+	if runErr != nil {
+		t.Fatalf("runTags: %v", runErr)
+	}
+	if !strings.Contains(out, "No tags found") {
+		t.Fatalf("expected empty tags message, got: %q", out)
+	}
+}
+
+func TestTagsCmd_CountsAndPrefix(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertTaggedTestNote(t, db, "a.md", `["auth", "team/api"]`)
+	insertTaggedTestNote(t, db, "b.md", `["auth"]`)
+	insertTaggedTestNote(t, db, "c.md", `["team/web"]`)
+	_ = db.Close()
+
+	var runErr error
+	out := captureCommandStdout(t, func() {
+		runErr = runTags("", false)
+	})
+	if runErr != nil {
+		t.Fatalf("runTags: %v", runErr)
+	}
+	if !strings.Contains(out, "auth") || !strings.Contains(out, "2 notes") {
+		t.Fatalf("expected auth with 2 notes, got: %q", out)
+	}
+	if strings.Index(out, "auth") > strings.Index(out, "team/api") {
+		t.Fatalf("expected most frequent tag first, got: %q", out)
+	}
+
+	out = captureCommandStdout(t, func() {
+		runErr = runTags("team/", true)
+	})
+	if runErr != nil {
+		t.Fatalf("runTags json: %v", runErr)
+	}
+	var tags []store.TagCount
+	if err := json.Unmarshal([]byte(out), &tags); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 team/ tags, got %+v", tags)
+	}
+}
@@ -381,7 +381,7 @@ tags: [standards, team]
 
 	// Pin it
 	fmt.Printf("  %s$%s same pin standards.md\n\n", cli.Dim, cli.Reset)
-	if err := ts.db.PinNote("standards.md"); err != nil {
+	if err := ts.db.PinNote("standards.md", ""); err != nil {
 		return err
 	}
 	fmt.Printf("  %s✓%s Pinned! This note now appears in %severy session%s.\n", cli.Green, cli.Reset, cli.Bold, cli.Reset)
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func upgradeCmd() *cobra.Command {
+	var verbose bool
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade a keyword-only index to semantic search",
+		Long: `Add embeddings to a vault that was indexed in keyword-only mode, for
+example because Ollama wasn't running during 'same init'.
+
+Checks that the configured embedding provider is reachable now, then
+rebuilds the index with embeddings and shows the search mode before and
+after. Does nothing if the index already has embeddings.
+
+To switch to a different provider or model, use 'same init --reconfigure'.
+To update the same binary itself, use 'same update'.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpgrade(verbose)
+		},
+	}
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show each file being processed")
+	return cmd
+}
+
+// searchModeLabel describes how the index can be searched.
+func searchModeLabel(hasVectors bool) string {
+	if hasVectors {
+		return "semantic"
+	}
+	return "keyword-only"
+}
+
+func runUpgrade(verbose bool) error {
+	if config.VaultPath() == "" {
+		return config.ErrNoVault
+	}
+	db, err := store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	hadVectors := db.HasVectors()
+	noteCount, _ := db.NoteCount()
+	_ = db.Close()
+
+	ec := config.EmbeddingProviderConfig()
+	if hadVectors {
+		fmt.Printf("\n  %s✓%s Search mode is already semantic (%s). Nothing to upgrade.\n\n",
+			cli.Green, cli.Reset, describeProvider(ec))
+		return nil
+	}
+	if noteCount == 0 {
+		return userError("The index is empty", "Run 'same reindex' to index your notes.")
+	}
+	if ec.Provider == "none" {
+		return userError("The embedding provider is set to none (keyword-only)",
+			"Pick a provider with 'same init --reconfigure', which also adds the embeddings.")
+	}
+
+	client, err := newEmbedProvider()
+	if err != nil {
+		return userError(fmt.Sprintf("Can't use the %s embedding provider: %v", ec.Provider, err),
+			"Check your [embedding] settings with 'same config show'.")
+	}
+	if _, err := client.GetQueryEmbedding("test"); err != nil {
+		hint := "Check your [embedding] settings with 'same config show', then run 'same upgrade' again."
+		if ec.Provider == "ollama" {
+			hint = "Start Ollama ('ollama serve') and pull the model ('ollama pull " + client.Model() + "'), then run 'same upgrade' again."
+		}
+		return userError(fmt.Sprintf("The %s embedding provider isn't reachable: %v", ec.Provider, err), hint)
+	}
+
+	fmt.Printf("\n  %s✓%s %s is reachable. Adding embeddings to %d notes...\n\n",
+		cli.Green, cli.Reset, describeProvider(ec), noteCount)
+	if err := runReindex(reindexOptions{Force: true, Verbose: verbose}); err != nil {
+		return err
+	}
+
+	db, err = store.Open()
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+	hasVectors := db.HasVectors()
+
+	fmt.Printf("\n  %sSearch mode:%s %s → %s\n", cli.Bold, cli.Reset,
+		searchModeLabel(hadVectors), searchModeLabel(hasVectors))
+	if !hasVectors {
+		fmt.Printf("  %sEmbedding didn't finish. Run 'same reindex' to retry.%s\n", cli.Dim, cli.Reset)
+	}
+	fmt.Println()
+	return nil
+}
+
+// describeProvider formats the embedding provider and model, e.g.
+// "ollama/nomic-embed-text".
+func describeProvider(ec config.EmbeddingConfig) string {
+	if ec.Model == "" {
+		return ec.Provider
+	}
+	return ec.Provider + "/" + ec.Model
+}
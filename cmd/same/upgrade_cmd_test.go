@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunUpgrade_ProviderNone(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/a.md", "A", "keyword only note")
+	_ = db.Close()
+
+	err := runUpgrade(false)
+	if err == nil || !strings.Contains(err.Error(), "set to none") {
+		t.Fatalf("expected provider=none error, got %v", err)
+	}
+}
+
+func TestRunUpgrade_ProviderUnreachable(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/a.md", "A", "keyword only note")
+	_ = db.Close()
+
+	// Unset means the ollama default without connection retries; nothing
+	// listens on port 1, so the probe fails fast.
+	t.Setenv("SAME_EMBED_PROVIDER", "")
+	t.Setenv("OLLAMA_URL", "http://127.0.0.1:1")
+
+	err := runUpgrade(false)
+	if err == nil || !strings.Contains(err.Error(), "isn't reachable") {
+		t.Fatalf("expected unreachable provider error, got %v", err)
+	}
+}
+
+func TestRunUpgrade_EmptyIndex(t *testing.T) {
+	_, db := setupCommandTestVault(t)
+	_ = db.Close()
+
+	err := runUpgrade(false)
+	if err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Fatalf("expected empty index error, got %v", err)
+	}
+}
@@ -21,10 +21,15 @@ import (
 )
 
 func watchCmd() *cobra.Command {
-	return &cobra.Command{
+	var reloadConfig bool
+	cmd := &cobra.Command{
 		Use:   "watch",
 		Short: "Auto-update the index when notes change",
-		Long:  "Monitor the vault filesystem for markdown file changes. Automatically reindexes modified, created, or deleted notes with a 2-second debounce.",
+		Long: `Monitor the vault filesystem for markdown file changes. Automatically reindexes modified, created, or deleted notes with a 2-second debounce.
+
+By default .same/config.toml is watched too, so edits to thresholds, profiles,
+display mode, skip_dirs, or noise_paths take effect without restarting.
+Pass --reindex-on-config-change=false to disable this.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
@@ -47,9 +52,11 @@ func watchCmd() *cobra.Command {
 				return dbOpenError(err)
 			}
 			defer db.Close()
-			return watcher.Watch(ctx, db)
+			return watcher.Watch(ctx, db, watcher.Options{ReloadConfig: reloadConfig})
 		},
 	}
+	cmd.Flags().BoolVar(&reloadConfig, "reindex-on-config-change", true, "Reload settings when .same/config.toml changes")
+	return cmd
 }
 
 func vaultCmd() *cobra.Command {
@@ -240,6 +247,10 @@ func vaultCmd() *cobra.Command {
 			if !ok {
 				return fmt.Errorf("vault %q not registered", oldName)
 			}
+			if newName == oldName {
+				fmt.Printf("  Vault %q already has that name.\n", oldName)
+				return nil
+			}
 			if _, exists := reg.Vaults[newName]; exists {
 				return fmt.Errorf("vault %q already exists", newName)
 			}
@@ -256,6 +267,20 @@ func vaultCmd() *cobra.Command {
 		},
 	})
 
+	cmd.AddCommand(&cobra.Command{
+		Use:   "info [name]",
+		Short: "Show index stats for a registered vault",
+		Long: `Show note count, chunk count, index age, database size, and embedding
+model for a registered vault, without switching to it.
+
+Example:
+  same vault info work`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVaultInfo(args[0])
+		},
+	})
+
 	var dryRun bool
 	feedCmd := &cobra.Command{
 		Use:   "feed [source] [target]",
@@ -375,6 +400,66 @@ func safeFeedPath(notePath string) string {
 	return clean
 }
 
+func runVaultInfo(name string) error {
+	reg := config.LoadRegistry()
+	vaultPath, ok := reg.Vaults[name]
+	if !ok {
+		return userError(fmt.Sprintf("vault %q not registered", name), "see registered vaults with: same vault list")
+	}
+
+	fmt.Printf("\n  %sVault: %s%s", cli.Bold, name, cli.Reset)
+	if reg.Default == name {
+		fmt.Printf("  %s(default)%s", cli.Green, cli.Reset)
+	}
+	fmt.Printf("\n\n")
+	fmt.Printf("  Path:      %s\n", cli.ShortenHome(vaultPath))
+
+	// Stat first: OpenPath would create an empty database.
+	dbPath := config.VaultDBPath(vaultPath)
+	info, err := os.Stat(dbPath)
+	if err != nil {
+		fmt.Printf("  DB:        %snot initialized%s\n\n", cli.Red, cli.Reset)
+		fmt.Printf("  %sRun 'same init' in %s to set it up.%s\n\n", cli.Dim, cli.ShortenHome(vaultPath), cli.Reset)
+		return nil
+	}
+
+	db, err := store.OpenPath(dbPath)
+	if err != nil {
+		return dbOpenError(err)
+	}
+	defer db.Close()
+
+	noteCount, _ := db.NoteCount()
+	chunkCount, _ := db.ChunkCount()
+	fmt.Printf("  Notes:     %s indexed\n", cli.FormatNumber(noteCount))
+	fmt.Printf("  Chunks:    %s\n", cli.FormatNumber(chunkCount))
+	if indexAge, _ := db.IndexAge(); indexAge > 0 {
+		fmt.Printf("  Indexed:   %s ago\n", formatDuration(indexAge))
+	}
+	fmt.Printf("  DB:        %.1f MB\n", float64(info.Size())/(1024*1024))
+
+	provider, _ := db.GetMeta("embed_provider")
+	model, _ := db.GetMeta("embed_model")
+	dims, _ := db.GetMeta("embed_dims")
+	switch {
+	case model != "":
+		line := model
+		if provider != "" {
+			line = provider + "/" + model
+		}
+		if dims != "" {
+			line += fmt.Sprintf(" (%s dims)", dims)
+		}
+		fmt.Printf("  Embedding: %s\n", line)
+	case provider != "":
+		fmt.Printf("  Embedding: %s\n", provider)
+	default:
+		fmt.Printf("  Embedding: %sunknown (reindex to record)%s\n", cli.Dim, cli.Reset)
+	}
+	fmt.Println()
+	return nil
+}
+
 func runVaultFeed(sourceAlias, targetAlias string, dryRun bool) error {
 	reg := config.LoadRegistry()
 
@@ -394,7 +479,7 @@ func runVaultFeed(sourceAlias, targetAlias string, dryRun bool) error {
 		return fmt.Errorf("source and target cannot be the same vault")
 	}
 
-	sourceDB, err := store.OpenPath(filepath.Join(sourcePath, ".same", "data", "vault.db"))
+	sourceDB, err := store.OpenPath(config.VaultDBPath(sourcePath))
 	if err != nil {
 		return fmt.Errorf("open source vault database: %w", err)
 	}
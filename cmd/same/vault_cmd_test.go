@@ -1,6 +1,8 @@
 package main
 
 import (
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -252,3 +254,103 @@ func TestVaultPrune_NoMissingVaults(t *testing.T) {
 		t.Fatalf("default vault = %q, want live", reg.Default)
 	}
 }
+
+func TestVaultRename_PreservesPathAndDefault(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	work := t.TempDir()
+	other := t.TempDir()
+	reg := &config.VaultRegistry{
+		Vaults:  map[string]string{"wrok": work, "other": other},
+		Default: "wrok",
+	}
+	if err := reg.Save(); err != nil {
+		t.Fatalf("save registry: %v", err)
+	}
+
+	rename := func(args ...string) error {
+		cmd := vaultCmd()
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		var runErr error
+		captureCommandStdout(t, func() {
+			cmd.SetArgs(append([]string{"rename"}, args...))
+			runErr = cmd.Execute()
+		})
+		return runErr
+	}
+
+	if err := rename("wrok", "work"); err != nil {
+		t.Fatalf("vault rename: %v", err)
+	}
+	reg = config.LoadRegistry()
+	if _, ok := reg.Vaults["wrok"]; ok {
+		t.Fatal("expected old name to be removed")
+	}
+	if reg.Vaults["work"] != work {
+		t.Fatalf("renamed vault path = %q, want %q", reg.Vaults["work"], work)
+	}
+	if reg.Default != "work" {
+		t.Fatalf("default vault = %q, want work", reg.Default)
+	}
+
+	if err := rename("work", "other"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected collision error, got %v", err)
+	}
+	if err := rename("missing", "fresh"); err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Fatalf("expected not-registered error, got %v", err)
+	}
+	if err := rename("work", "work"); err != nil {
+		t.Fatalf("renaming to the same name should be a no-op, got %v", err)
+	}
+	if reg = config.LoadRegistry(); len(reg.Vaults) != 2 || reg.Vaults["other"] != other {
+		t.Fatalf("registry changed unexpectedly: %#v", reg)
+	}
+}
+
+func TestVaultInfo_ReportsRegisteredVaultStats(t *testing.T) {
+	vault, db := setupCommandTestVault(t)
+	insertCommandTestNote(t, db, "notes/a.md", "A", "alpha")
+	insertCommandTestNote(t, db, "notes/b.md", "B", "beta")
+	if err := db.SetEmbeddingMeta("ollama", "nomic-embed-text", 768); err != nil {
+		t.Fatalf("SetEmbeddingMeta: %v", err)
+	}
+
+	uninitialized := t.TempDir()
+	reg := &config.VaultRegistry{
+		Vaults:  map[string]string{"work": vault, "fresh": uninitialized},
+		Default: "work",
+	}
+	if err := reg.Save(); err != nil {
+		t.Fatalf("save registry: %v", err)
+	}
+
+	out := captureCommandStdout(t, func() {
+		if err := runVaultInfo("work"); err != nil {
+			t.Fatalf("vault info: %v", err)
+		}
+	})
+	for _, want := range []string{"Vault: work", "(default)", "Notes:     2 indexed", "Chunks:    2", "ollama/nomic-embed-text (768 dims)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+
+	out = captureCommandStdout(t, func() {
+		if err := runVaultInfo("fresh"); err != nil {
+			t.Fatalf("vault info: %v", err)
+		}
+	})
+	if !strings.Contains(out, "not initialized") {
+		t.Errorf("expected not initialized for vault without DB, got:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(uninitialized, ".same")); !os.IsNotExist(err) {
+		t.Errorf("vault info must not create a database in an uninitialized vault")
+	}
+
+	if err := runVaultInfo("missing"); err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Fatalf("expected not-registered error, got %v", err)
+	}
+}
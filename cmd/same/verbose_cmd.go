@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+)
+
+// verboseWatchInterval is how often 'same verbose watch' polls the log.
+const verboseWatchInterval = 500 * time.Millisecond
+
+// verboseWatchAnchor is how many trailing bytes watch remembers so it can
+// find its place again after the hook rotates verbose.log.
+const verboseWatchAnchor = 256
+
+func verboseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verbose",
+		Short: "Turn verbose surfacing logs on or off, or watch them",
+		Long: `Verbose monitoring logs every context-surfacing decision — what was
+surfaced, what was skipped, and why — to verbose.log in the data directory.
+It takes effect on the next prompt; no restart needed.
+
+  same verbose on       Start logging
+  same verbose off      Stop logging
+  same verbose status   Show whether logging is on and where the log is
+  same verbose watch    Follow the log as prompts come in (Ctrl+C to stop)
+
+SAME_VERBOSE=1 in the environment also turns logging on. For analysis
+rather than reading, SAME_TRACE=1 appends one JSON line per prompt (mode,
+candidates per search mode, gates hit, final selection) to
+surfacing-trace.jsonl; SAME_TRACE=<path> writes it elsewhere.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerboseStatus()
+		},
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "on",
+		Short: "Start logging surfacing decisions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerboseOn()
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "off",
+		Short: "Stop logging surfacing decisions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerboseOff()
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show whether verbose logging is on",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerboseStatus()
+		},
+	})
+
+	var lines int
+	watch := &cobra.Command{
+		Use:   "watch",
+		Short: "Follow the verbose log (Ctrl+C to stop)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !config.VerboseEnabled() {
+				fmt.Fprintf(os.Stderr, "  %sVerbose logging is off — run 'same verbose on' so new prompts are logged.%s\n", cli.Dim, cli.Reset)
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			fmt.Fprintf(os.Stderr, "  Watching %s (Ctrl+C to stop)...\n", cli.ShortenHome(config.VerboseLogPath()))
+			return runVerboseWatch(ctx, os.Stdout, lines, verboseWatchInterval)
+		},
+	}
+	watch.Flags().IntVarP(&lines, "lines", "n", 40, "Lines of existing log to show first")
+	cmd.AddCommand(watch)
+
+	return cmd
+}
+
+func runVerboseOn() error {
+	flag := config.VerboseFlagPath()
+	if err := os.MkdirAll(filepath.Dir(flag), 0o700); err != nil {
+		return fmt.Errorf("create data directory: %w", err)
+	}
+	if err := os.WriteFile(flag, nil, 0o600); err != nil {
+		return fmt.Errorf("create verbose flag: %w", err)
+	}
+	fmt.Printf("  %s✓%s Verbose logging on.\n", cli.Green, cli.Reset)
+	fmt.Printf("    %sFollow it with: same verbose watch%s\n", cli.Dim, cli.Reset)
+	return nil
+}
+
+func runVerboseOff() error {
+	if err := os.Remove(config.VerboseFlagPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove verbose flag: %w", err)
+	}
+	if os.Getenv(config.EnvVerbose) != "" {
+		fmt.Printf("  %s!%s Flag removed, but SAME_VERBOSE is set in your environment, so logging stays on.\n", cli.Yellow, cli.Reset)
+		return nil
+	}
+	fmt.Printf("  %s✓%s Verbose logging off.\n", cli.Green, cli.Reset)
+	return nil
+}
+
+func runVerboseStatus() error {
+	state := "off"
+	switch {
+	case os.Getenv(config.EnvVerbose) != "":
+		state = "on (SAME_VERBOSE)"
+	case config.VerboseEnabled():
+		state = "on"
+	}
+	fmt.Printf("  Verbose logging: %s\n", state)
+
+	logPath := config.VerboseLogPath()
+	if info, err := os.Stat(logPath); err == nil {
+		fmt.Printf("  Log: %s (%.1f MB, updated %s ago)\n", cli.ShortenHome(logPath),
+			float64(info.Size())/(1024*1024), formatDuration(time.Since(info.ModTime())))
+	} else {
+		fmt.Printf("  Log: %s (not created yet)\n", cli.ShortenHome(logPath))
+	}
+	if state == "off" {
+		fmt.Printf("  %sTurn on with: same verbose on%s\n", cli.Dim, cli.Reset)
+	}
+	return nil
+}
+
+// runVerboseWatch prints the last lines of verbose.log, then anything
+// appended to it until ctx is done. The hook rotates the log by rewriting
+// it with only its last ~1MB; when the file shrinks, watch looks for the
+// bytes it printed last and resumes after them.
+func runVerboseWatch(ctx context.Context, w io.Writer, lines int, interval time.Duration) error {
+	logPath := config.VerboseLogPath()
+
+	var offset int64
+	var anchor []byte
+	if data, err := os.ReadFile(logPath); err == nil {
+		_, _ = w.Write(lastLines(data, lines))
+		offset = int64(len(data))
+		anchor = tailBytes(data, verboseWatchAnchor)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		info, err := os.Stat(logPath)
+		if err != nil {
+			continue // not created yet, or mid-rotation
+		}
+		if info.Size() == offset {
+			continue
+		}
+		data, err := os.ReadFile(logPath)
+		if err != nil {
+			continue
+		}
+		start := offset
+		if int64(len(data)) < offset {
+			// Rotated: resume after the last bytes we printed, or from the
+			// top if they were trimmed away.
+			start = 0
+			if i := bytes.LastIndex(data, anchor); len(anchor) > 0 && i >= 0 {
+				start = int64(i + len(anchor))
+			}
+		}
+		_, _ = w.Write(data[start:])
+		offset = int64(len(data))
+		anchor = tailBytes(data, verboseWatchAnchor)
+	}
+}
+
+// lastLines returns the final n lines of data, or nil when n <= 0.
+func lastLines(data []byte, n int) []byte {
+	if n <= 0 {
+		return nil
+	}
+	end := len(data)
+	if end > 0 && data[end-1] == '\n' {
+		end--
+	}
+	for i := end - 1; i >= 0; i-- {
+		if data[i] == '\n' {
+			n--
+			if n == 0 {
+				return data[i+1:]
+			}
+		}
+	}
+	return data
+}
+
+func tailBytes(data []byte, n int) []byte {
+	if len(data) > n {
+		data = data[len(data)-n:]
+	}
+	return append([]byte(nil), data...)
+}
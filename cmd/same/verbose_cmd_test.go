@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
+)
+
+func TestRunVerboseOnOff(t *testing.T) {
+	setupCommandTestVault(t)
+	t.Setenv("SAME_VERBOSE", "")
+
+	captureCommandStdout(t, func() {
+		if err := runVerboseOn(); err != nil {
+			t.Fatalf("runVerboseOn: %v", err)
+		}
+	})
+	if !config.VerboseEnabled() {
+		t.Fatal("verbose should be enabled after 'same verbose on'")
+	}
+	out := captureCommandStdout(t, func() {
+		if err := runVerboseStatus(); err != nil {
+			t.Fatalf("runVerboseStatus: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Verbose logging: on") {
+		t.Errorf("status should report on, got %q", out)
+	}
+
+	captureCommandStdout(t, func() {
+		if err := runVerboseOff(); err != nil {
+			t.Fatalf("runVerboseOff: %v", err)
+		}
+		// Turning it off twice is not an error.
+		if err := runVerboseOff(); err != nil {
+			t.Fatalf("runVerboseOff again: %v", err)
+		}
+	})
+	if config.VerboseEnabled() {
+		t.Fatal("verbose should be disabled after 'same verbose off'")
+	}
+}
+
+func TestLastLines(t *testing.T) {
+	data := []byte("one\ntwo\nthree\n")
+	if got := string(lastLines(data, 2)); got != "two\nthree\n" {
+		t.Errorf("lastLines(2) = %q", got)
+	}
+	if got := string(lastLines(data, 10)); got != string(data) {
+		t.Errorf("lastLines(10) = %q", got)
+	}
+	if got := lastLines(data, 0); got != nil {
+		t.Errorf("lastLines(0) = %q, want nil", got)
+	}
+}
+
+// syncBuffer lets the test read what the watcher goroutine wrote.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestRunVerboseWatch_FollowsAppendsAndRotation(t *testing.T) {
+	setupCommandTestVault(t)
+	logPath := config.VerboseLogPath()
+	filler := strings.Repeat("earlier decision\n", 40)
+	if err := os.WriteFile(logPath, []byte(filler+"old-3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out syncBuffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- runVerboseWatch(ctx, &out, 1, 10*time.Millisecond) }()
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !strings.Contains(out.String(), want) {
+			if time.Now().After(deadline) {
+				t.Fatalf("watch output %q never contained %q", out.String(), want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	waitFor("old-3\n")
+	appendLog := func(s string) {
+		f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = f.WriteString(s)
+		_ = f.Close()
+	}
+	appendLog("new-1\n")
+	waitFor("new-1\n")
+
+	// Rotation rewrites the file with only its tail, then appends.
+	if err := os.WriteFile(logPath, []byte(filler[len(filler)/2:]+"old-3\nnew-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	appendLog("new-2\n")
+	waitFor("new-2\n")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("runVerboseWatch: %v", err)
+	}
+	if got := out.String(); got != "old-3\nnew-1\nnew-2\n" {
+		t.Errorf("watch output = %q, want each line exactly once", got)
+	}
+}
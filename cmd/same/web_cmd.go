@@ -9,7 +9,6 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
-	"runtime"
 	"syscall"
 	"time"
 
@@ -44,6 +43,7 @@ Examples:
   same web                  # Start background server, open browser
   same web --port 8080      # Custom port
   same web --fg             # Run in foreground (blocks terminal)
+  same web --fg --open      # Foreground, and open the browser once ready
   same web --mcp --fg       # Dashboard + MCP endpoint (foreground)`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			vp := config.VaultPath()
@@ -87,6 +87,13 @@ Examples:
 			if foreground {
 				fmt.Printf("\n  Dashboard: %shttp://%s%s\n", cli.Bold, addr, cli.Reset)
 				fmt.Printf("  %sPress Ctrl+C to stop%s\n\n", cli.Dim, cli.Reset)
+				if openFlag {
+					go func() {
+						if waitForServer(addr, 3*time.Second) {
+							openBrowser("http://" + addr)
+						}
+					}()
+				}
 			}
 
 			webVersion := Version
@@ -128,7 +135,7 @@ Examples:
 		},
 	}
 	cmd.Flags().IntVar(&port, "port", 4078, "Port to listen on")
-	cmd.Flags().BoolVar(&openFlag, "open", false, "Auto-open browser (default in background mode)")
+	cmd.Flags().BoolVar(&openFlag, "open", false, "Open the browser once the server is up (always on in background mode)")
 	cmd.Flags().BoolVar(&foreground, "fg", false, "Run in foreground (blocks terminal)")
 	cmd.Flags().BoolVar(&mcpFlag, "mcp", false, "Enable Streamable HTTP MCP endpoint on /mcp")
 	return cmd
@@ -152,20 +159,8 @@ func launchBackground(addr string, port int, vaultPath string) error {
 		return fmt.Errorf("start background server: %w", err)
 	}
 
-	// Wait for server to be ready (up to 3 seconds)
 	url := fmt.Sprintf("http://%s", addr)
-	ready := false
-	for i := 0; i < 30; i++ {
-		time.Sleep(100 * time.Millisecond)
-		conn, err := net.DialTimeout("tcp", addr, 200*time.Millisecond)
-		if err == nil {
-			conn.Close()
-			ready = true
-			break
-		}
-	}
-
-	if !ready {
+	if !waitForServer(addr, 3*time.Second) {
 		// Check if port is already in use (another instance)
 		conn, err := net.DialTimeout("tcp", addr, 200*time.Millisecond)
 		if err == nil {
@@ -192,19 +187,23 @@ func launchBackground(addr string, port int, vaultPath string) error {
 	return nil
 }
 
-func openBrowser(url string) {
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-	default:
-		return
+// waitForServer polls addr until it accepts TCP connections or timeout
+// passes, and reports whether it came up.
+func waitForServer(addr string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+		conn, err := net.DialTimeout("tcp", addr, 200*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return true
+		}
 	}
-	_ = cmd.Run()
+	return false
+}
+
+func openBrowser(url string) {
+	_ = openWithDefaultApp(url)
 }
 
 // generateToken creates a random 32-byte hex token for session-only use.
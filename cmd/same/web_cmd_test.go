@@ -1,7 +1,9 @@
 package main
 
 import (
+	"net"
 	"testing"
+	"time"
 
 	"github.com/sgx-labs/statelessagent/internal/config"
 )
@@ -21,3 +23,18 @@ func TestWebCmd_NoVault(t *testing.T) {
 		t.Fatal("expected error when vault path is invalid")
 	}
 }
+
+func TestWaitForServer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	if !waitForServer(addr, time.Second) {
+		t.Error("expected listening address to be reported ready")
+	}
+	ln.Close()
+	if waitForServer(addr, 300*time.Millisecond) {
+		t.Error("expected closed address to time out")
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+)
+
+func whichCmd() *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "which",
+		Short: "Show which vault and config commands here will use",
+		Long: `Show the vault a command run from this directory would use, how it was
+found, and the config file and database that go with it.
+
+The vault is resolved in this order: the --vault flag, VAULT_PATH, [vault]
+path in the config, a vault marker (.same, .obsidian, ...) in the current
+directory or one of its subdirectories, the registry default ('same vault
+default'), and finally a marker near the same binary.
+
+Examples:
+  same which
+  same --vault work which
+  same which --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWhich(jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+// whichReport is the output of 'same which'.
+type whichReport struct {
+	Vault        string `json:"vault"`
+	VaultName    string `json:"vault_name,omitempty"`
+	Source       string `json:"source"`
+	ConfigFile   string `json:"config_file,omitempty"`
+	GlobalConfig string `json:"global_config,omitempty"`
+	// UnusedConfig is the vault's own config file when a different one (or
+	// none) is loaded from this directory.
+	UnusedConfig string `json:"unused_config,omitempty"`
+	Database     string `json:"database"`
+	DatabaseOK   bool   `json:"database_exists"`
+	DataDirEnv   bool   `json:"data_dir_from_env,omitempty"`
+}
+
+func runWhich(jsonOut bool) error {
+	vault, source := config.VaultSource()
+	if vault == "" {
+		if source != "" {
+			return userError(fmt.Sprintf("The vault from the %s was ignored because it's too broad", source),
+				"Point --vault or VAULT_PATH at your notes folder.")
+		}
+		return config.ErrNoVault
+	}
+
+	r := whichReport{
+		Vault:      vault,
+		VaultName:  config.LoadRegistry().NameForPath(vault),
+		Source:     source,
+		ConfigFile: config.FindConfigFile(),
+		Database:   config.DBPath(),
+		DataDirEnv: os.Getenv(config.EnvDataDir) != "",
+	}
+	if _, err := os.Stat(config.GlobalConfigPath()); err == nil {
+		r.GlobalConfig = config.GlobalConfigPath()
+	}
+	if own := config.ConfigFilePath(vault); own != r.ConfigFile {
+		if _, err := os.Stat(own); err == nil {
+			r.UnusedConfig = own
+		}
+	}
+	if _, err := os.Stat(r.Database); err == nil {
+		r.DatabaseOK = true
+	}
+
+	if jsonOut {
+		data, _ := json.MarshalIndent(r, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+	printWhich(r)
+	return nil
+}
+
+func printWhich(r whichReport) {
+	fmt.Println()
+	name := ""
+	if r.VaultName != "" {
+		name = fmt.Sprintf(" %s(%s)%s", cli.Dim, r.VaultName, cli.Reset)
+	}
+	fmt.Printf("  %sVault:%s     %s%s\n", cli.Bold, cli.Reset, cli.ShortenHome(r.Vault), name)
+	fmt.Printf("  %sFound by:%s  %s\n", cli.Bold, cli.Reset, r.Source)
+
+	if r.ConfigFile != "" {
+		fmt.Printf("  %sConfig:%s    %s\n", cli.Bold, cli.Reset, cli.ShortenHome(r.ConfigFile))
+	} else {
+		fmt.Printf("  %sConfig:%s    %snone (built-in defaults)%s\n", cli.Bold, cli.Reset, cli.Dim, cli.Reset)
+	}
+	if r.GlobalConfig != "" {
+		fmt.Printf("  %sGlobal:%s    %s %s(applied first)%s\n", cli.Bold, cli.Reset, cli.ShortenHome(r.GlobalConfig), cli.Dim, cli.Reset)
+	}
+
+	db := cli.ShortenHome(r.Database)
+	if r.DataDirEnv {
+		db += cli.Dim + " (SAME_DATA_DIR)" + cli.Reset
+	}
+	if !r.DatabaseOK {
+		db += cli.Dim + " (not created yet — run 'same init')" + cli.Reset
+	}
+	fmt.Printf("  %sDatabase:%s  %s\n", cli.Bold, cli.Reset, db)
+
+	if r.UnusedConfig != "" {
+		fmt.Printf("\n  %s!%s %s exists but isn't loaded from here.\n", cli.Yellow, cli.Reset, cli.ShortenHome(r.UnusedConfig))
+		fmt.Printf("    %sConfig is found via --vault, VAULT_PATH or the current directory; cd into the vault or pass --vault.%s\n", cli.Dim, cli.Reset)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
+)
+
+func TestRunWhich_ReportsSourceAndPaths(t *testing.T) {
+	vault, db := setupCommandTestVault(t)
+	_ = db.Close()
+	if err := config.GenerateConfig(vault); err != nil {
+		t.Fatalf("GenerateConfig: %v", err)
+	}
+
+	var runErr error
+	out := captureCommandStdout(t, func() {
+		runErr = runWhich(true)
+	})
+	if runErr != nil {
+		t.Fatalf("runWhich: %v", runErr)
+	}
+	var r whichReport
+	if err := json.Unmarshal([]byte(out), &r); err != nil {
+		t.Fatalf("unmarshal %q: %v", out, err)
+	}
+	if r.Vault != vault {
+		t.Errorf("vault = %q, want %q", r.Vault, vault)
+	}
+	if r.Source != config.VaultFromFlag {
+		t.Errorf("source = %q, want %q", r.Source, config.VaultFromFlag)
+	}
+	if r.ConfigFile != config.ConfigFilePath(vault) || r.UnusedConfig != "" {
+		t.Errorf("config = %q (unused %q), want the vault's own", r.ConfigFile, r.UnusedConfig)
+	}
+	if r.Database != filepath.Join(vault, ".same", "data", "vault.db") || !r.DatabaseOK {
+		t.Errorf("database = %q (exists %v)", r.Database, r.DatabaseOK)
+	}
+}
@@ -6,10 +6,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -128,15 +132,137 @@ const (
 
 // Config holds all SAME configuration, loaded from TOML + env + flags.
 type Config struct {
+	// Include names a base config file, relative to this one, whose values
+	// apply first; this file's values override them. See decodeConfigFile.
+	Include string `toml:"include,omitempty"`
+
 	Vault     VaultConfig     `toml:"vault"`
 	Ollama    OllamaConfig    `toml:"ollama"`
 	Embedding EmbeddingConfig `toml:"embedding"`
 	Graph     GraphConfig     `toml:"graph"`
 	Chat      ChatConfig      `toml:"chat"`
+	Ask       AskConfig       `toml:"ask"`
 	Memory    MemoryConfig    `toml:"memory"`
 	Hooks     HooksConfig     `toml:"hooks"`
 	Display   DisplayConfig   `toml:"display"`
 	Auth      AuthConfig      `toml:"auth"`
+	MCP       MCPConfig       `toml:"mcp"`
+	Indexer   IndexerConfig   `toml:"indexer"`
+	Surfacing SurfacingConfig `toml:"surfacing"`
+	Security  SecurityConfig  `toml:"security"`
+	Search    SearchConfig    `toml:"search"`
+	Output    OutputConfig    `toml:"output"`
+
+	// Profiles holds user-defined profiles keyed by name ([profiles.<name>]).
+	Profiles map[string]ProfileConfig `toml:"profiles,omitempty"`
+}
+
+// Chunking strategies for [indexer] chunk_strategy.
+const (
+	ChunkStrategyHeadings = "headings" // split long notes on # / ## boundaries (default)
+	ChunkStrategyFixed    = "fixed"    // split long notes into fixed-size paragraph runs
+)
+
+// IndexerConfig controls how notes are split into chunks.
+type IndexerConfig struct {
+	ChunkStrategy string `toml:"chunk_strategy"` // "headings" (default) or "fixed"
+	ChunkOverlap  int    `toml:"chunk_overlap"`  // characters shared between size-split chunks (0 = none)
+
+	EmbedConcurrency int `toml:"embed_concurrency"` // embedding requests in flight during reindex
+}
+
+// DefaultChunkOverlap is the default number of characters carried over
+// between consecutive size-split chunks.
+const DefaultChunkOverlap = 200
+
+// Bounds for [indexer] embed_concurrency.
+const (
+	DefaultEmbedConcurrency = 4
+	MaxEmbedConcurrency     = 16
+)
+
+// SurfacingConfig tunes how context surfacing ranks candidate notes.
+type SurfacingConfig struct {
+	// PathWeights maps vault-relative path prefixes to composite score
+	// multipliers (e.g. {"decisions/" = 1.5, "archive/" = 0.5}).
+	PathWeights map[string]float64 `toml:"path_weights"`
+
+	// ConfidenceHalfLifeDays halves the confidence of notes that have not
+	// been modified for this many days. Pinned and frequently accessed notes
+	// are exempt. 0 (default) disables decay.
+	ConfidenceHalfLifeDays float64 `toml:"confidence_half_life_days"`
+
+	// MaxTokenBudget caps the tokens of notes context surfacing injects per
+	// prompt. 0 uses DefaultSurfacingTokenBudget.
+	MaxTokenBudget int `toml:"max_token_budget"`
+
+	// LinkBoost surfaces notes linked ([[wikilinks]] or Markdown links) from
+	// the top results, scored at this fraction of the linking note's score.
+	// 0 (default) disables link expansion.
+	LinkBoost float64 `toml:"link_boost"`
+
+	// SnippetChars caps the characters of each surfaced note's snippet.
+	// Terse snippets suit small context windows; 0 uses
+	// DefaultSurfacingSnippetChars.
+	SnippetChars int `toml:"snippet_chars"`
+
+	// StaleAfterDays flags notes not modified for this many days as stale
+	// in `same stale` and the staleness-check hook. Notes with
+	// "evergreen: true" in their frontmatter are never flagged for age.
+	// 0 (default) disables age-based staleness.
+	StaleAfterDays int `toml:"stale_after_days"`
+
+	// Tuning overrides context surfacing's internal thresholds, for
+	// experimenting without a rebuild, e.g. { max_distance = 16.8 }. Keys
+	// and ranges are listed in SurfacingTuningRanges; unset keys keep the
+	// built-in values.
+	Tuning map[string]float64 `toml:"tuning"`
+}
+
+// SearchConfig tunes keyword search.
+type SearchConfig struct {
+	// Synonyms expands keyword (FTS5) queries with related words, so
+	// "login" also finds "authentication". A recall heuristic for vaults
+	// without embeddings; it knows nothing about meaning. Off by default.
+	Synonyms bool `toml:"synonyms"`
+
+	// SynonymGroups adds groups of interchangeable words to the built-in
+	// list, e.g. [["k8s", "kubernetes", "cluster"]].
+	SynonymGroups [][]string `toml:"synonym_groups"`
+}
+
+// Prompt-injection detection levels for [security] injection_detection.
+const (
+	InjectionDetectionOff     = "off"     // no snippet filtering
+	InjectionDetectionLenient = "lenient" // exact-phrase pattern list only
+	InjectionDetectionStrict  = "strict"  // promptguard detector plus the pattern list (default)
+)
+
+// SecurityConfig holds content-safety settings.
+type SecurityConfig struct {
+	// InjectionDetection controls how surfaced snippets are screened for
+	// prompt injection: "off", "lenient", or "strict" (default).
+	InjectionDetection string `toml:"injection_detection"`
+
+	// TrustedPaths lists vault-relative path prefixes whose snippets skip
+	// prompt-injection filtering. This trades safety for recall on notes
+	// that legitimately discuss prompts; a trusted note can steer the agent.
+	// Never applies to _PRIVATE/.
+	TrustedPaths []string `toml:"trusted_paths"`
+}
+
+// MCPConfig holds settings for the MCP server.
+type MCPConfig struct {
+	// WritablePaths restricts MCP write tools to these vault-relative path
+	// prefixes (e.g. ["notes/", "sessions/"]). Empty means no restriction.
+	WritablePaths []string `toml:"writable_paths"`
+
+	// ReadOnly registers only tools that never modify the vault or index.
+	ReadOnly bool `toml:"read_only"`
+
+	// WriteRateLimit caps MCP write tool calls per minute. 0 uses the
+	// built-in default of 30.
+	WriteRateLimit int `toml:"write_rate_limit"`
 }
 
 // AuthConfig holds authentication settings for remote access.
@@ -153,6 +279,33 @@ type VaultConfig struct {
 	DecisionLog string   `toml:"decision_log"`
 }
 
+// Decision output styles for [output.decision] style.
+const (
+	DecisionStyleLog   = "log"   // append every decision to one log file (default)
+	DecisionStyleFiles = "files" // write each decision as its own note, ADR style
+)
+
+// DefaultDecisionDir is where decisions go with style "files" and no path.
+const DefaultDecisionDir = "decisions"
+
+// OutputConfig sets where SAME writes the notes it generates, per content
+// type. Unset values fall back to vault.decision_log and vault.handoff_dir.
+type OutputConfig struct {
+	Decision DecisionOutputConfig `toml:"decision"`
+	Handoff  HandoffOutputConfig  `toml:"handoff"`
+}
+
+// DecisionOutputConfig is [output.decision].
+type DecisionOutputConfig struct {
+	Style string `toml:"style"` // "log" (default) or "files"
+	Path  string `toml:"path"`  // the log file for "log", a directory for "files"
+}
+
+// HandoffOutputConfig is [output.handoff].
+type HandoffOutputConfig struct {
+	Dir string `toml:"dir"`
+}
+
 // OllamaConfig holds Ollama connection settings.
 type OllamaConfig struct {
 	URL   string `toml:"url"`
@@ -162,9 +315,19 @@ type OllamaConfig struct {
 // MemoryConfig holds memory engine tuning parameters.
 type MemoryConfig struct {
 	MaxTokenBudget     int     `toml:"max_token_budget"`
+	MaxPinnedTokens    int     `toml:"max_pinned_tokens"` // cap on pinned notes surfaced per prompt
+	MaxResults         int     `toml:"max_results"`
+	DistanceThreshold  float64 `toml:"distance_threshold"`
+	CompositeThreshold float64 `toml:"composite_threshold"`
+}
+
+// ProfileConfig holds a user-defined memory profile saved in config.
+type ProfileConfig struct {
+	Description        string  `toml:"description,omitempty"`
 	MaxResults         int     `toml:"max_results"`
 	DistanceThreshold  float64 `toml:"distance_threshold"`
 	CompositeThreshold float64 `toml:"composite_threshold"`
+	MaxTokenBudget     int     `toml:"max_token_budget,omitempty"`
 }
 
 // EmbeddingConfig holds embedding provider settings.
@@ -174,6 +337,16 @@ type EmbeddingConfig struct {
 	APIKey     string `toml:"api_key"`    // API key (required for openai, optional for openai-compatible)
 	BaseURL    string `toml:"base_url"`   // base URL for embedding API (provider-specific default if empty)
 	Dimensions int    `toml:"dimensions"` // vector dimensions (0 = provider default)
+	Timeout    int    `toml:"timeout"`    // seconds per embedding request (0 = provider default)
+}
+
+// MaxRequestTimeout bounds [embedding] and [ask] timeout, in seconds.
+const MaxRequestTimeout = 3600
+
+// RequestTimeout returns the configured per-request timeout, or 0 to use
+// the provider's default.
+func (c EmbeddingConfig) RequestTimeout() time.Duration {
+	return time.Duration(c.Timeout) * time.Second
 }
 
 // GraphConfig holds knowledge-graph extraction settings.
@@ -187,6 +360,24 @@ type ChatConfig struct {
 	Model string `toml:"model"` // optional: override chat model for consolidation, ask, brief
 }
 
+// AskConfig selects the chat provider used by 'same ask', so answers can
+// come from an OpenAI-compatible server (llama.cpp, LM Studio, OpenRouter)
+// without running Ollama. Empty fields fall back to the shared chat
+// resolution; SAME_CHAT_* environment variables still take precedence.
+type AskConfig struct {
+	Provider string `toml:"provider"` // "auto" (default), "ollama", "openai", "openai-compatible"
+	Model    string `toml:"model"`    // chat model for answers (auto-detected if empty)
+	BaseURL  string `toml:"base_url"` // chat completions endpoint for openai-compatible
+	APIKey   string `toml:"api_key"`  // API key for openai or authenticated endpoints
+	Timeout  int    `toml:"timeout"`  // seconds per chat request (0 = provider default)
+}
+
+// RequestTimeout returns the configured per-request timeout, or 0 to use
+// the provider's default.
+func (c AskConfig) RequestTimeout() time.Duration {
+	return time.Duration(c.Timeout) * time.Second
+}
+
 // HooksConfig controls which hooks are enabled.
 type HooksConfig struct {
 	ContextSurfacing  bool `toml:"context_surfacing"`
@@ -237,6 +428,12 @@ func DefaultConfig() *Config {
 		Display: DisplayConfig{
 			Mode: "full",
 		},
+		Indexer: IndexerConfig{
+			ChunkStrategy: ChunkStrategyHeadings,
+			ChunkOverlap:  DefaultChunkOverlap,
+
+			EmbedConcurrency: DefaultEmbedConcurrency,
+		},
 	}
 }
 
@@ -249,7 +446,7 @@ func LoadConfig() (*Config, error) {
 	globalPath := GlobalConfigPath()
 	if globalPath != "" {
 		if _, err := os.Stat(globalPath); err == nil {
-			meta, err := toml.DecodeFile(globalPath, cfg)
+			meta, err := decodeConfigFile(globalPath, cfg)
 			if err != nil {
 				return nil, fmt.Errorf("parse global config %s: %w", globalPath, err)
 			}
@@ -260,7 +457,7 @@ func LoadConfig() (*Config, error) {
 	// Overlay per-vault config (if it exists)
 	configPath := findConfigFile()
 	if configPath != "" {
-		meta, err := toml.DecodeFile(configPath, cfg)
+		meta, err := decodeConfigFile(configPath, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("parse config %s: %w", configPath, err)
 		}
@@ -268,19 +465,19 @@ func LoadConfig() (*Config, error) {
 	}
 
 	// Environment variables override TOML values
-	if v := os.Getenv("VAULT_PATH"); v != "" {
+	if v := os.Getenv(EnvVaultPath); v != "" {
 		cfg.Vault.Path = v
 	}
-	if v := os.Getenv("OLLAMA_URL"); v != "" {
+	if v := os.Getenv(EnvOllamaURL); v != "" {
 		cfg.Ollama.URL = v
 	}
-	if v := os.Getenv("SAME_HANDOFF_DIR"); v != "" {
+	if v := os.Getenv(EnvHandoffDir); v != "" {
 		cfg.Vault.HandoffDir = v
 	}
-	if v := os.Getenv("SAME_DECISION_LOG"); v != "" {
+	if v := os.Getenv(EnvDecisionLog); v != "" {
 		cfg.Vault.DecisionLog = v
 	}
-	if v := os.Getenv("SAME_SKIP_DIRS"); v != "" {
+	if v := os.Getenv(EnvSkipDirs); v != "" {
 		for _, d := range strings.Split(v, ",") {
 			d = strings.TrimSpace(d)
 			if d != "" {
@@ -288,7 +485,7 @@ func LoadConfig() (*Config, error) {
 			}
 		}
 	}
-	if v := os.Getenv("SAME_NOISE_PATHS"); v != "" {
+	if v := os.Getenv(EnvNoisePaths); v != "" {
 		for _, p := range strings.Split(v, ",") {
 			p = strings.TrimSpace(p)
 			if p != "" {
@@ -298,28 +495,28 @@ func LoadConfig() (*Config, error) {
 	}
 
 	// Embedding provider overrides
-	if v := os.Getenv("SAME_EMBED_PROVIDER"); v != "" {
+	if v := os.Getenv(EnvEmbedProvider); v != "" {
 		cfg.Embedding.Provider = v
 	}
-	if v := os.Getenv("SAME_EMBED_MODEL"); v != "" {
+	if v := os.Getenv(EnvEmbedModel); v != "" {
 		cfg.Embedding.Model = v
 	}
-	if v := os.Getenv("SAME_EMBED_BASE_URL"); v != "" {
+	if v := os.Getenv(EnvEmbedBaseURL); v != "" {
 		cfg.Embedding.BaseURL = v
 	}
-	if v := os.Getenv("SAME_EMBED_API_KEY"); v != "" {
+	if v := os.Getenv(EnvEmbedAPIKey); v != "" {
 		cfg.Embedding.APIKey = v
 	}
-	if v := os.Getenv("SAME_GRAPH_LLM"); v != "" {
+	if v := os.Getenv(EnvGraphLLM); v != "" {
 		cfg.Graph.LLMMode = v
 	}
 	// Auth token override
-	if v := os.Getenv("SAME_MCP_TOKEN"); v != "" {
+	if v := os.Getenv(EnvMCPToken); v != "" {
 		cfg.Auth.Token = v
 	}
 	// Also check OPENAI_API_KEY as a convenience fallback
 	if cfg.Embedding.APIKey == "" && (cfg.Embedding.Provider == "openai" || cfg.Embedding.Provider == "openai-compatible") {
-		if v := os.Getenv("OPENAI_API_KEY"); v != "" {
+		if v := os.Getenv(EnvOpenAIAPIKey); v != "" {
 			cfg.Embedding.APIKey = v
 		}
 	}
@@ -352,7 +549,7 @@ func LoadConfigFrom(configPath string) (*Config, error) {
 
 	if configPath != "" {
 		if _, err := os.Stat(configPath); err == nil {
-			meta, err := toml.DecodeFile(configPath, cfg)
+			meta, err := decodeConfigFile(configPath, cfg)
 			if err != nil {
 				return nil, fmt.Errorf("parse config %s: %w", configPath, err)
 			}
@@ -361,29 +558,29 @@ func LoadConfigFrom(configPath string) (*Config, error) {
 	}
 
 	// Environment variables override TOML values (same as LoadConfig)
-	if v := os.Getenv("VAULT_PATH"); v != "" {
+	if v := os.Getenv(EnvVaultPath); v != "" {
 		cfg.Vault.Path = v
 	}
-	if v := os.Getenv("OLLAMA_URL"); v != "" {
+	if v := os.Getenv(EnvOllamaURL); v != "" {
 		cfg.Ollama.URL = v
 	}
-	if v := os.Getenv("SAME_EMBED_PROVIDER"); v != "" {
+	if v := os.Getenv(EnvEmbedProvider); v != "" {
 		cfg.Embedding.Provider = v
 	}
-	if v := os.Getenv("SAME_EMBED_MODEL"); v != "" {
+	if v := os.Getenv(EnvEmbedModel); v != "" {
 		cfg.Embedding.Model = v
 	}
-	if v := os.Getenv("SAME_EMBED_BASE_URL"); v != "" {
+	if v := os.Getenv(EnvEmbedBaseURL); v != "" {
 		cfg.Embedding.BaseURL = v
 	}
-	if v := os.Getenv("SAME_EMBED_API_KEY"); v != "" {
+	if v := os.Getenv(EnvEmbedAPIKey); v != "" {
 		cfg.Embedding.APIKey = v
 	}
-	if v := os.Getenv("SAME_GRAPH_LLM"); v != "" {
+	if v := os.Getenv(EnvGraphLLM); v != "" {
 		cfg.Graph.LLMMode = v
 	}
 	if cfg.Embedding.APIKey == "" && (cfg.Embedding.Provider == "openai" || cfg.Embedding.Provider == "openai-compatible") {
-		if v := os.Getenv("OPENAI_API_KEY"); v != "" {
+		if v := os.Getenv(EnvOpenAIAPIKey); v != "" {
 			cfg.Embedding.APIKey = v
 		}
 	}
@@ -391,6 +588,77 @@ func LoadConfigFrom(configPath string) (*Config, error) {
 	return cfg, nil
 }
 
+// loadConfigForWrite decodes only the file at path onto the defaults, for
+// callers that change a setting and write the file back. Included files and
+// env overrides are left out so they aren't copied into the file; the
+// include line itself is kept. A missing file yields the defaults.
+func loadConfigForWrite(path string) (*Config, error) {
+	cfg := DefaultConfig()
+	if _, err := os.Stat(path); err != nil {
+		return cfg, nil
+	}
+	if _, err := toml.DecodeFile(path, cfg); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// decodeConfigFile decodes path into cfg after the chain of base files it
+// includes, so each file overrides the ones it includes. An include that is
+// missing or would form a cycle is skipped; ValidateConfig reports it.
+func decodeConfigFile(path string, cfg *Config) (toml.MetaData, error) {
+	chain, _ := configIncludeChain(path)
+	for i := len(chain) - 1; i > 0; i-- {
+		meta, err := toml.DecodeFile(chain[i], cfg)
+		if err != nil {
+			return meta, fmt.Errorf("include %s: %w", chain[i], err)
+		}
+		warnUnknownKeys(meta, chain[i])
+	}
+	return toml.DecodeFile(path, cfg)
+}
+
+// configIncludeChain follows include directives from path and returns the
+// files in order, path first. When the chain stops at a missing file or a
+// cycle, problem describes it as a message about the last file's include.
+func configIncludeChain(path string) (chain []string, problem string) {
+	chain = []string{path}
+	seen := map[string]bool{}
+	if abs, err := filepath.Abs(path); err == nil {
+		seen[abs] = true
+	}
+	for cur := path; ; {
+		var head struct {
+			Include string `toml:"include"`
+		}
+		// A file that doesn't parse ends the chain; decoding it reports why.
+		if _, err := toml.DecodeFile(cur, &head); err != nil || head.Include == "" {
+			return chain, ""
+		}
+		next := head.Include
+		if !filepath.IsAbs(next) {
+			next = filepath.Join(filepath.Dir(cur), next)
+		}
+		abs, err := filepath.Abs(next)
+		if err != nil {
+			abs = next
+		}
+		if seen[abs] {
+			names := make([]string, 0, len(chain)+1)
+			for _, p := range append(chain, next) {
+				names = append(names, filepath.Base(p))
+			}
+			return chain, "forms an include cycle: " + strings.Join(names, " → ")
+		}
+		if _, err := os.Stat(next); err != nil {
+			return chain, fmt.Sprintf("names %s, which can't be read; it was skipped", head.Include)
+		}
+		seen[abs] = true
+		chain = append(chain, next)
+		cur = next
+	}
+}
+
 // findConfigFile looks for .same/config.toml starting from vault path, then CWD.
 func findConfigFile() string {
 	// Check vault path first (if already resolved)
@@ -422,7 +690,7 @@ func resolveVaultForConfig() string {
 		}
 		return VaultOverride
 	}
-	if v := os.Getenv("VAULT_PATH"); v != "" {
+	if v := os.Getenv(EnvVaultPath); v != "" {
 		return v
 	}
 	return ""
@@ -461,7 +729,11 @@ func generateTOMLContent(vaultPath string) string {
 	b.WriteString("# Priority: CLI flags > environment variables > this file > built-in defaults\n")
 	b.WriteString("# Environment variables: VAULT_PATH, OLLAMA_URL, SAME_HANDOFF_DIR,\n")
 	b.WriteString("#   SAME_DECISION_LOG, SAME_SKIP_DIRS, SAME_NOISE_PATHS, SAME_DATA_DIR,\n")
-	b.WriteString("#   SAME_GRAPH_LLM\n\n")
+	b.WriteString("#   SAME_GRAPH_LLM\n")
+	b.WriteString("#\n")
+	b.WriteString("# Shared team defaults: include a base file (relative to this one) whose\n")
+	b.WriteString("# values apply first; anything set here overrides them.\n")
+	b.WriteString("# include = \"../shared-same.toml\"\n\n")
 
 	b.WriteString("[vault]\n")
 	if vaultPath != "" {
@@ -474,6 +746,10 @@ func generateTOMLContent(vaultPath string) string {
 	b.WriteString("handoff_dir = \"sessions\"\n")
 	b.WriteString("decision_log = \"decisions.md\"\n\n")
 
+	b.WriteString("# [output.decision]              # one note per decision instead of the log\n")
+	b.WriteString("# style = \"files\"               # \"log\" (default) or \"files\" (ADR style)\n")
+	b.WriteString("# path = \"adr\"\n\n")
+
 	// Use the active model (may have been changed via model picker or env var)
 	activeModel := EmbeddingModel
 	ec := EmbeddingProviderConfig()
@@ -493,6 +769,9 @@ func generateTOMLContent(vaultPath string) string {
 	}
 	fmt.Fprintf(&b, "provider = %q\n", activeProvider)
 	fmt.Fprintf(&b, "model = %q\n", activeModel)
+	if ec.BaseURL != "" && activeProvider != "ollama" {
+		fmt.Fprintf(&b, "base_url = %q\n", ec.BaseURL)
+	}
 	b.WriteString("# api_key = \"\"                  # required for cloud providers\n")
 	b.WriteString("#                               # or set SAME_EMBED_API_KEY / OPENAI_API_KEY\n")
 	b.WriteString("# dimensions = 0                # 0 = use provider default\n\n")
@@ -511,9 +790,14 @@ func generateTOMLContent(vaultPath string) string {
 	b.WriteString("#                               # defaults to auto-detected (smallest available)\n")
 	b.WriteString("#                               # or set SAME_CHAT_MODEL env var\n\n")
 
+	b.WriteString("[ask]\n")
+	b.WriteString("# provider = \"openai-compatible\"  # chat provider for 'same ask': auto, ollama, openai, openai-compatible\n")
+	b.WriteString("# base_url = \"http://localhost:8080\"  # llama.cpp, LM Studio, OpenRouter, ...\n")
+	b.WriteString("# model = \"llama3.2\"            # defaults to auto-detected\n\n")
+
 	b.WriteString("[memory]\n")
 	b.WriteString("# Presets: same profile use precise|balanced|broad|pi\n")
-	b.WriteString("max_token_budget = 1600\n")
+	b.WriteString("# max_pinned_tokens = 400       # pinned notes beyond this are skipped with a warning\n")
 	b.WriteString("max_results = 4\n")
 	b.WriteString("distance_threshold = 16.2\n")
 	b.WriteString("composite_threshold = 0.35\n\n")
@@ -522,7 +806,33 @@ func generateTOMLContent(vaultPath string) string {
 	b.WriteString("context_surfacing = true\n")
 	b.WriteString("decision_extractor = true\n")
 	b.WriteString("handoff_generator = true\n")
-	b.WriteString("staleness_check = true\n")
+	b.WriteString("staleness_check = true\n\n")
+
+	b.WriteString("[indexer]\n")
+	b.WriteString("# chunk_strategy = \"headings\"  # \"headings\" (split on #/## sections) or \"fixed\" (size-based)\n")
+	b.WriteString("# chunk_overlap = 200           # characters shared between size-split chunks (0 = none)\n")
+	b.WriteString("# embed_concurrency = 4         # parallel embedding requests (1-16; local Ollama is capped at 2)\n\n")
+
+	b.WriteString("[surfacing]\n")
+	b.WriteString("# path_weights = { \"decisions/\" = 1.5, \"archive/\" = 0.5 }  # >1 promotes, <1 demotes\n")
+	b.WriteString("# max_token_budget = 800        # tokens of notes injected per prompt (100-8000)\n")
+	b.WriteString("# link_boost = 0.6              # also surface notes linked from top results (0-1, 0 = off)\n")
+	b.WriteString("# snippet_chars = 400           # characters per surfaced snippet (50-1000)\n")
+	b.WriteString("# stale_after_days = 180        # flag notes not modified for this long (0 = off)\n")
+	b.WriteString("# tuning = { max_distance = 16.3, min_composite = 0.70 }  # experimental threshold overrides\n\n")
+
+	b.WriteString("[search]\n")
+	b.WriteString("# synonyms = false              # expand keyword-only searches with related words (heuristic)\n")
+	b.WriteString("# synonym_groups = [[\"k8s\", \"kubernetes\"]]  # extra words to treat as interchangeable\n\n")
+
+	b.WriteString("[security]\n")
+	b.WriteString("# injection_detection = \"strict\"  # \"strict\", \"lenient\" (exact phrases only), or \"off\"\n")
+	b.WriteString("# trusted_paths = [\"docs/security/\"]  # skip injection filtering for these notes (security tradeoff)\n\n")
+
+	b.WriteString("[mcp]\n")
+	b.WriteString("# writable_paths = [\"notes/\", \"sessions/\"]  # restrict where agents can write (default: anywhere)\n")
+	b.WriteString("# read_only = false  # true: search and read tools only, no writes or reindex\n")
+	b.WriteString("# write_rate_limit = 30  # max MCP write tool calls per minute\n")
 
 	return b.String()
 }
@@ -566,11 +876,16 @@ func ShowConfig() string {
 
 // HandoffDirectory returns the directory for session handoff notes.
 func HandoffDirectory() string {
-	if v := os.Getenv("SAME_HANDOFF_DIR"); v != "" {
+	if v := os.Getenv(EnvHandoffDir); v != "" {
 		return v
 	}
-	if cfg := loadConfigSafe(); cfg != nil && cfg.Vault.HandoffDir != "" {
-		return cfg.Vault.HandoffDir
+	if cfg := loadConfigSafe(); cfg != nil {
+		if cfg.Output.Handoff.Dir != "" {
+			return cfg.Output.Handoff.Dir
+		}
+		if cfg.Vault.HandoffDir != "" {
+			return cfg.Vault.HandoffDir
+		}
 	}
 	return "sessions"
 }
@@ -584,21 +899,46 @@ func HandoffMaxAge() int {
 	return 48 // 2 days default
 }
 
+// HandoffTemplatePath returns the path of the vault's handoff template,
+// .same/templates/handoff.md. The file is optional.
+func HandoffTemplatePath() string {
+	return filepath.Join(VaultPath(), ".same", "templates", "handoff.md")
+}
+
 // DecisionLogPath returns the path (relative to vault root) for the decision log.
 func DecisionLogPath() string {
-	if v := os.Getenv("SAME_DECISION_LOG"); v != "" {
+	if v := os.Getenv(EnvDecisionLog); v != "" {
 		return v
 	}
-	if cfg := loadConfigSafe(); cfg != nil && cfg.Vault.DecisionLog != "" {
-		return cfg.Vault.DecisionLog
+	if cfg := loadConfigSafe(); cfg != nil {
+		if cfg.Output.Decision.Path != "" && cfg.Output.Decision.Style != DecisionStyleFiles {
+			return cfg.Output.Decision.Path
+		}
+		if cfg.Vault.DecisionLog != "" {
+			return cfg.Vault.DecisionLog
+		}
 	}
 	return "decisions.md"
 }
 
+// DecisionDirectory returns the directory (relative to vault root) that
+// decisions are written to one note apiece, or "" when [output.decision]
+// style is "log" and they are appended to DecisionLogPath instead.
+func DecisionDirectory() string {
+	cfg := loadConfigSafe()
+	if cfg == nil || cfg.Output.Decision.Style != DecisionStyleFiles {
+		return ""
+	}
+	if cfg.Output.Decision.Path != "" {
+		return cfg.Output.Decision.Path
+	}
+	return DefaultDecisionDir
+}
+
 // NoisePaths returns the configured list of path prefixes to filter from surfacing.
 // Returns nil (no filtering) if unconfigured.
 func NoisePaths() []string {
-	if v := os.Getenv("SAME_NOISE_PATHS"); v != "" {
+	if v := os.Getenv(EnvNoisePaths); v != "" {
 		var paths []string
 		for _, p := range strings.Split(v, ",") {
 			p = strings.TrimSpace(p)
@@ -646,10 +986,24 @@ func MemoryMaxTokenBudget() int {
 	return 1600
 }
 
+// DefaultMaxPinnedTokens is the default budget for pinned notes surfaced with
+// each prompt: half of the context surfacing budget, so search results always
+// have room.
+const DefaultMaxPinnedTokens = 400
+
+// MemoryMaxPinnedTokens returns the token budget for pinned notes injected by
+// context surfacing. Pins beyond it are skipped with a warning.
+func MemoryMaxPinnedTokens() int {
+	if cfg := loadConfigSafe(); cfg != nil && cfg.Memory.MaxPinnedTokens > 0 {
+		return cfg.Memory.MaxPinnedTokens
+	}
+	return DefaultMaxPinnedTokens
+}
+
 // AuthToken returns the configured Bearer auth token for MCP HTTP access.
 // Checks SAME_MCP_TOKEN env var first, then config file auth.token.
 func AuthToken() string {
-	if v := os.Getenv("SAME_MCP_TOKEN"); v != "" {
+	if v := os.Getenv(EnvMCPToken); v != "" {
 		return v
 	}
 	if cfg := loadConfigSafe(); cfg != nil && cfg.Auth.Token != "" {
@@ -658,6 +1012,312 @@ func AuthToken() string {
 	return ""
 }
 
+// ChunkStrategy returns the configured chunking strategy for long notes.
+// Unrecognized values fall back to ChunkStrategyHeadings.
+func ChunkStrategy() string {
+	if cfg := loadConfigSafe(); cfg != nil && strings.EqualFold(strings.TrimSpace(cfg.Indexer.ChunkStrategy), ChunkStrategyFixed) {
+		return ChunkStrategyFixed
+	}
+	return ChunkStrategyHeadings
+}
+
+// EmbedConcurrency returns how many embedding requests reindex keeps in
+// flight, clamped to [1, MaxEmbedConcurrency]. Unset or zero means the
+// default.
+func EmbedConcurrency() int {
+	n := DefaultEmbedConcurrency
+	if cfg := loadConfigSafe(); cfg != nil && cfg.Indexer.EmbedConcurrency != 0 {
+		n = cfg.Indexer.EmbedConcurrency
+	}
+	return min(max(n, 1), MaxEmbedConcurrency)
+}
+
+// ChunkOverlap returns the configured overlap, in characters, between
+// consecutive size-split chunks. Clamped to [0, MaxEmbedChars/4] so an
+// overlap can never crowd out a chunk's own content.
+func ChunkOverlap() int {
+	overlap := DefaultChunkOverlap
+	if cfg := loadConfigSafe(); cfg != nil {
+		overlap = cfg.Indexer.ChunkOverlap
+	}
+	if overlap < 0 {
+		return 0
+	}
+	if overlap > MaxEmbedChars/4 {
+		return MaxEmbedChars / 4
+	}
+	return overlap
+}
+
+// MCPWritablePaths returns the normalized vault-relative prefixes that MCP
+// write tools may target. Returns nil (no restriction) if unconfigured, and
+// an error if the config cannot be loaded so callers can fail closed instead
+// of dropping the allowlist.
+func MCPWritablePaths() ([]string, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, p := range cfg.MCP.WritablePaths {
+		p = strings.TrimSpace(strings.ReplaceAll(p, "\\", "/"))
+		p = strings.TrimPrefix(p, "./")
+		if p == "" {
+			continue
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+// MCPReadOnly reports whether [mcp] read_only is set.
+func MCPReadOnly() bool {
+	cfg := loadConfigSafe()
+	return cfg != nil && cfg.MCP.ReadOnly
+}
+
+// MCPWriteRateLimit returns [mcp] write_rate_limit, or 0 when unset or
+// invalid so the MCP server keeps its default.
+func MCPWriteRateLimit() int {
+	if cfg := loadConfigSafe(); cfg != nil && cfg.MCP.WriteRateLimit > 0 {
+		return cfg.MCP.WriteRateLimit
+	}
+	return 0
+}
+
+// InjectionTrustedPaths returns the normalized [security] trusted_paths
+// prefixes. Prefixes inside _PRIVATE/ are dropped; callers must still refuse
+// to trust _PRIVATE/ notes, since a short prefix like "_" would cover them.
+func InjectionTrustedPaths() []string {
+	cfg := loadConfigSafe()
+	if cfg == nil {
+		return nil
+	}
+	var paths []string
+	for _, p := range cfg.Security.TrustedPaths {
+		p = strings.TrimSpace(strings.ReplaceAll(p, "\\", "/"))
+		p = strings.TrimPrefix(p, "./")
+		if p == "" || strings.HasPrefix(strings.ToUpper(p), "_PRIVATE") {
+			continue
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// SurfacingPathWeights returns the configured [surfacing] path_weights with
+// normalized prefixes. Entries with empty prefixes or non-finite weights
+// are dropped. Returns nil if unconfigured.
+func SurfacingPathWeights() map[string]float64 {
+	cfg := loadConfigSafe()
+	if cfg == nil || len(cfg.Surfacing.PathWeights) == 0 {
+		return nil
+	}
+	weights := make(map[string]float64, len(cfg.Surfacing.PathWeights))
+	for p, w := range cfg.Surfacing.PathWeights {
+		p = strings.TrimSpace(strings.ReplaceAll(p, "\\", "/"))
+		p = strings.TrimPrefix(p, "./")
+		if p == "" || math.IsNaN(w) || math.IsInf(w, 0) {
+			continue
+		}
+		weights[p] = w
+	}
+	return weights
+}
+
+// ConfidenceHalfLifeDays returns the configured [surfacing]
+// confidence_half_life_days, or 0 when decay is disabled or misconfigured.
+func ConfidenceHalfLifeDays() float64 {
+	cfg := loadConfigSafe()
+	if cfg == nil {
+		return 0
+	}
+	d := cfg.Surfacing.ConfidenceHalfLifeDays
+	if d <= 0 || math.IsNaN(d) || math.IsInf(d, 0) {
+		return 0
+	}
+	return d
+}
+
+// SurfacingLinkBoost returns the configured [surfacing] link_boost, or 0
+// when link expansion is off or the value is outside 0-1.
+func SurfacingLinkBoost() float64 {
+	cfg := loadConfigSafe()
+	if cfg == nil {
+		return 0
+	}
+	b := cfg.Surfacing.LinkBoost
+	if b <= 0 || b > 1 || math.IsNaN(b) {
+		return 0
+	}
+	return b
+}
+
+// SearchSynonymsEnabled reports whether [search] synonyms is on.
+func SearchSynonymsEnabled() bool {
+	cfg := loadConfigSafe()
+	return cfg != nil && cfg.Search.Synonyms
+}
+
+// SearchSynonymGroups returns the configured [search] synonym_groups,
+// lowercased and trimmed. Groups with fewer than two words are dropped.
+func SearchSynonymGroups() [][]string {
+	cfg := loadConfigSafe()
+	if cfg == nil {
+		return nil
+	}
+	var groups [][]string
+	for _, g := range cfg.Search.SynonymGroups {
+		var words []string
+		for _, w := range g {
+			if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+				words = append(words, w)
+			}
+		}
+		if len(words) >= 2 {
+			groups = append(groups, words)
+		}
+	}
+	return groups
+}
+
+// InjectionDetectionMode returns the configured [security]
+// injection_detection level. Unset or unrecognized values fall back to
+// strict so a typo never weakens filtering.
+func InjectionDetectionMode() string {
+	cfg := loadConfigSafe()
+	if cfg == nil {
+		return InjectionDetectionStrict
+	}
+	switch mode := strings.ToLower(strings.TrimSpace(cfg.Security.InjectionDetection)); mode {
+	case InjectionDetectionOff, InjectionDetectionLenient:
+		return mode
+	default:
+		return InjectionDetectionStrict
+	}
+}
+
+// Bounds for [surfacing] max_token_budget.
+const (
+	DefaultSurfacingTokenBudget = 800
+	MinSurfacingTokenBudget     = 100
+	MaxSurfacingTokenBudget     = 8000
+)
+
+// ValidateSurfacingTokenBudget checks that n is a usable surfacing budget.
+func ValidateSurfacingTokenBudget(n int) error {
+	if n < MinSurfacingTokenBudget || n > MaxSurfacingTokenBudget {
+		return fmt.Errorf("max_token_budget must be between %d and %d", MinSurfacingTokenBudget, MaxSurfacingTokenBudget)
+	}
+	return nil
+}
+
+// SurfacingMaxTokenBudget returns the token budget for notes injected by
+// context surfacing. It is read on every hook invocation, so edits apply to
+// the next prompt. Out-of-range values are clamped to the valid bounds.
+func SurfacingMaxTokenBudget() int {
+	cfg := loadConfigSafe()
+	if cfg == nil || cfg.Surfacing.MaxTokenBudget <= 0 {
+		return DefaultSurfacingTokenBudget
+	}
+	n := cfg.Surfacing.MaxTokenBudget
+	if n < MinSurfacingTokenBudget {
+		return MinSurfacingTokenBudget
+	}
+	if n > MaxSurfacingTokenBudget {
+		return MaxSurfacingTokenBudget
+	}
+	return n
+}
+
+// Bounds for snippet lengths: [surfacing] snippet_chars and the CLI's
+// --snippet-len. The maximum matches the input the injection detector
+// scans, so no part of a surfaced snippet goes unchecked.
+const (
+	DefaultSurfacingSnippetChars = 400
+	MinSnippetChars              = 50
+	MaxSnippetChars              = 1000
+)
+
+// ValidateSnippetChars checks that n is a usable snippet length.
+func ValidateSnippetChars(n int) error {
+	if n < MinSnippetChars || n > MaxSnippetChars {
+		return fmt.Errorf("snippet length must be between %d and %d", MinSnippetChars, MaxSnippetChars)
+	}
+	return nil
+}
+
+// SurfacingSnippetChars returns the snippet length for notes injected by
+// context surfacing. Out-of-range values are clamped to the valid bounds.
+func SurfacingSnippetChars() int {
+	cfg := loadConfigSafe()
+	if cfg == nil || cfg.Surfacing.SnippetChars <= 0 {
+		return DefaultSurfacingSnippetChars
+	}
+	return min(max(cfg.Surfacing.SnippetChars, MinSnippetChars), MaxSnippetChars)
+}
+
+// SurfacingTuningRanges lists the [surfacing.tuning] keys and the inclusive
+// range each accepts.
+var SurfacingTuningRanges = map[string][2]float64{
+	"max_results":               {1, 10},
+	"max_distance":              {1, 100},
+	"min_composite":             {0, 1},
+	"min_semantic_floor":        {0, 1},
+	"min_title_overlap":         {0, 1},
+	"high_tier_overlap":         {0, 1},
+	"recency_relevance_weight":  {0, 1},
+	"recency_recency_weight":    {0, 1},
+	"recency_confidence_weight": {0, 1},
+	"recency_min_composite":     {0, 1},
+	"recency_max_results":       {1, 10},
+}
+
+// ValidateSurfacingTuning checks one [surfacing.tuning] key and value.
+func ValidateSurfacingTuning(key string, v float64) error {
+	r, ok := SurfacingTuningRanges[key]
+	if !ok {
+		return fmt.Errorf("unknown surfacing tuning key %q", key)
+	}
+	if math.IsNaN(v) || v < r[0] || v > r[1] {
+		return fmt.Errorf("surfacing.tuning.%s must be between %g and %g", key, r[0], r[1])
+	}
+	if strings.HasSuffix(key, "max_results") && v != math.Trunc(v) {
+		return fmt.Errorf("surfacing.tuning.%s must be a whole number", key)
+	}
+	return nil
+}
+
+// SurfacingTuning returns the valid [surfacing.tuning] overrides, or nil
+// when none are set. Unknown keys and out-of-range values are dropped;
+// 'same doctor' reports them.
+func SurfacingTuning() map[string]float64 {
+	cfg := loadConfigSafe()
+	if cfg == nil || len(cfg.Surfacing.Tuning) == 0 {
+		return nil
+	}
+	tuning := make(map[string]float64, len(cfg.Surfacing.Tuning))
+	for k, v := range cfg.Surfacing.Tuning {
+		if ValidateSurfacingTuning(k, v) == nil {
+			tuning[k] = v
+		}
+	}
+	if len(tuning) == 0 {
+		return nil
+	}
+	return tuning
+}
+
+// SurfacingStaleAfterDays returns the configured [surfacing]
+// stale_after_days, or 0 when age-based staleness is off.
+func SurfacingStaleAfterDays() int {
+	cfg := loadConfigSafe()
+	if cfg == nil || cfg.Surfacing.StaleAfterDays < 0 {
+		return 0
+	}
+	return cfg.Surfacing.StaleAfterDays
+}
+
 // IsEmbeddingProviderExplicit returns true when the user has explicitly
 // configured an embedding provider via env var or config file. Returns false
 // when no provider has been set and the system would default to "ollama".
@@ -665,7 +1325,7 @@ func AuthToken() string {
 // explicitly configured, retries are skipped to avoid slow fallback delays.
 func IsEmbeddingProviderExplicit() bool {
 	// Env var explicitly set — the user chose a provider.
-	if os.Getenv("SAME_EMBED_PROVIDER") != "" {
+	if os.Getenv(EnvEmbedProvider) != "" {
 		return true
 	}
 	// Config file has a non-empty provider — the user chose it during init.
@@ -688,7 +1348,7 @@ func IsEmbeddingProviderExplicit() bool {
 
 // EmbeddingProvider returns the configured embedding provider name.
 func EmbeddingProvider() string {
-	if v := os.Getenv("SAME_EMBED_PROVIDER"); v != "" {
+	if v := os.Getenv(EnvEmbedProvider); v != "" {
 		return v
 	}
 	if cfg := loadConfigSafe(); cfg != nil && cfg.Embedding.Provider != "" {
@@ -711,20 +1371,20 @@ func EmbeddingProviderConfig() EmbeddingConfig {
 
 	// Env vars take precedence (already merged in LoadConfig, but handle
 	// the case where loadConfigSafe is called without full LoadConfig)
-	if v := os.Getenv("SAME_EMBED_PROVIDER"); v != "" {
+	if v := os.Getenv(EnvEmbedProvider); v != "" {
 		ec.Provider = v
 	}
-	if v := os.Getenv("SAME_EMBED_MODEL"); v != "" {
+	if v := os.Getenv(EnvEmbedModel); v != "" {
 		ec.Model = v
 	}
-	if v := os.Getenv("SAME_EMBED_BASE_URL"); v != "" {
+	if v := os.Getenv(EnvEmbedBaseURL); v != "" {
 		ec.BaseURL = v
 	}
-	if v := os.Getenv("SAME_EMBED_API_KEY"); v != "" {
+	if v := os.Getenv(EnvEmbedAPIKey); v != "" {
 		ec.APIKey = v
 	}
 	if ec.APIKey == "" && (ec.Provider == "openai" || ec.Provider == "openai-compatible") {
-		if v := os.Getenv("OPENAI_API_KEY"); v != "" {
+		if v := os.Getenv(EnvOpenAIAPIKey); v != "" {
 			ec.APIKey = v
 		}
 	}
@@ -749,7 +1409,7 @@ func EmbeddingProviderConfig() EmbeddingConfig {
 // "off" (default), "local-only", or "on".
 func GraphLLMMode() string {
 	mode := ""
-	if v := os.Getenv("SAME_GRAPH_LLM"); v != "" {
+	if v := os.Getenv(EnvGraphLLM); v != "" {
 		mode = v
 	} else if cfg := loadConfigSafe(); cfg != nil {
 		mode = cfg.Graph.LLMMode
@@ -771,7 +1431,7 @@ func GraphLLMMode() string {
 // ChatModel returns the explicitly configured chat model.
 // Checked in order: SAME_CHAT_MODEL env var > [chat] model in config.
 func ChatModel() string {
-	if v := os.Getenv("SAME_CHAT_MODEL"); strings.TrimSpace(v) != "" {
+	if v := os.Getenv(EnvChatModel); strings.TrimSpace(v) != "" {
 		return strings.TrimSpace(v)
 	}
 	if cfg := loadConfigSafe(); cfg != nil && strings.TrimSpace(cfg.Chat.Model) != "" {
@@ -780,11 +1440,27 @@ func ChatModel() string {
 	return ""
 }
 
+// AskProviderConfig returns the [ask] chat provider settings with
+// surrounding whitespace trimmed. Empty fields mean "use the default".
+func AskProviderConfig() AskConfig {
+	cfg := loadConfigSafe()
+	if cfg == nil {
+		return AskConfig{}
+	}
+	return AskConfig{
+		Provider: strings.ToLower(strings.TrimSpace(cfg.Ask.Provider)),
+		Model:    strings.TrimSpace(cfg.Ask.Model),
+		BaseURL:  strings.TrimSpace(cfg.Ask.BaseURL),
+		APIKey:   strings.TrimSpace(cfg.Ask.APIKey),
+		Timeout:  cfg.Ask.Timeout,
+	}
+}
+
 // GraphModel returns the explicitly configured model for graph LLM extraction,
 // or empty string if none is set (caller should fall back to auto-detection).
 // Checked in order: SAME_GRAPH_MODEL env var > [graph] model in config.
 func GraphModel() string {
-	if v := os.Getenv("SAME_GRAPH_MODEL"); strings.TrimSpace(v) != "" {
+	if v := os.Getenv(EnvGraphModel); strings.TrimSpace(v) != "" {
 		return strings.TrimSpace(v)
 	}
 	if cfg := loadConfigSafe(); cfg != nil && strings.TrimSpace(cfg.Graph.Model) != "" {
@@ -806,24 +1482,14 @@ func SetGraphLLMMode(vaultPath, mode string) error {
 
 	cfgPath := ConfigFilePath(vaultPath)
 
-	cfg, err := LoadConfigFrom(cfgPath)
+	cfg, err := loadConfigForWrite(cfgPath)
 	if err != nil {
 		cfg = DefaultConfig()
 	}
 
 	cfg.Graph.LLMMode = mode
 
-	var buf bytes.Buffer
-	encoder := toml.NewEncoder(&buf)
-	if err := encoder.Encode(cfg); err != nil {
-		return fmt.Errorf("encode config: %w", err)
-	}
-
-	if err := os.MkdirAll(filepath.Dir(cfgPath), 0o700); err != nil {
-		return fmt.Errorf("create config directory: %w", err)
-	}
-
-	return os.WriteFile(cfgPath, buf.Bytes(), 0o600)
+	return writeConfigFile(cfgPath, cfg)
 }
 
 // loadConfigSafe loads config without risking recursion. Returns nil on error.
@@ -835,13 +1501,19 @@ func loadConfigSafe() *Config {
 	return cfg
 }
 
-// ConfigWarning returns any config file parse error, or empty string if OK.
+// ConfigWarning returns any config file parse error, or else a summary of
+// unknown keys and out-of-range values. Empty string if OK.
 func ConfigWarning() string {
 	_, err := LoadConfig()
 	if err != nil {
 		return err.Error()
 	}
-	return ""
+	issues := ValidateConfig()
+	msgs := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		msgs = append(msgs, issue.String())
+	}
+	return strings.Join(msgs, "; ")
 }
 
 // FindConfigFile returns the path to the active config file, or empty string if none found.
@@ -864,27 +1536,19 @@ var configSuggestions = map[string]string{
 	"base-url":      "base_url",
 	"token_budget":  "max_token_budget",
 	"budget":        "max_token_budget",
+	"writable_dirs": "writable_paths",
+	"allowed_paths": "writable_paths",
+	"chunking":      "chunk_strategy",
+	"chunk_mode":    "chunk_strategy",
+	"overlap":       "chunk_overlap",
+	"path_boosts":   "path_weights",
+	"dir_weights":   "path_weights",
 }
 
 // warnUnknownKeys prints warnings for unrecognized config keys.
 func warnUnknownKeys(meta toml.MetaData, configPath string) {
-	undecoded := meta.Undecoded()
-	if len(undecoded) == 0 {
-		return
-	}
-
-	fname := filepath.Base(configPath)
-	for _, key := range undecoded {
-		keyStr := key.String()
-		lastPart := key[len(key)-1]
-
-		if suggestion, ok := configSuggestions[lastPart]; ok {
-			fmt.Fprintf(os.Stderr, "same: WARNING: unknown key %q in %s — did you mean %q?\n",
-				keyStr, fname, suggestion)
-		} else {
-			fmt.Fprintf(os.Stderr, "same: WARNING: unknown key %q in %s (will be ignored)\n",
-				keyStr, fname)
-		}
+	for _, issue := range unknownKeyIssues(meta, filepath.Base(configPath)) {
+		fmt.Fprintf(os.Stderr, "same: WARNING: %s\n", issue)
 	}
 }
 
@@ -903,6 +1567,12 @@ var defaultSkipDirs = map[string]bool{
 	"_PRIVATE":     true,
 }
 
+// IsDefaultSkipDir reports whether name is one of the directories every
+// vault walk skips, as opposed to one added by SAME_SKIP_DIRS or config.
+func IsDefaultSkipDir(name string) bool {
+	return defaultSkipDirs[name]
+}
+
 // SkipFiles are filenames excluded from indexing (meta-docs, not project knowledge).
 var SkipFiles = map[string]bool{
 	"CLAUDE.md": true,
@@ -916,7 +1586,7 @@ func buildSkipDirs() map[string]bool {
 	for k, v := range defaultSkipDirs {
 		dirs[k] = v
 	}
-	if extra := os.Getenv("SAME_SKIP_DIRS"); extra != "" {
+	if extra := os.Getenv(EnvSkipDirs); extra != "" {
 		for _, d := range strings.Split(extra, ",") {
 			d = strings.TrimSpace(d)
 			if d != "" {
@@ -934,7 +1604,7 @@ func RebuildSkipDirs(extra []string) {
 	for k, v := range defaultSkipDirs {
 		dirs[k] = v
 	}
-	if envExtra := os.Getenv("SAME_SKIP_DIRS"); envExtra != "" {
+	if envExtra := os.Getenv(EnvSkipDirs); envExtra != "" {
 		for _, d := range strings.Split(envExtra, ",") {
 			d = strings.TrimSpace(d)
 			if d != "" {
@@ -951,11 +1621,31 @@ func RebuildSkipDirs(extra []string) {
 	SkipDirs = dirs
 }
 
+// Where VaultSource found the vault.
+const (
+	VaultFromFlag     = "--vault flag"
+	VaultFromEnv      = "VAULT_PATH environment variable"
+	VaultFromConfig   = "[vault] path in config"
+	VaultFromCWD      = "vault marker in the current directory"
+	VaultFromChild    = "vault marker in a subdirectory"
+	VaultFromRegistry = "registry default"
+	VaultFromBinary   = "vault marker near the same binary"
+)
+
 // VaultPath returns the vault root directory.
 // SECURITY: Validates the path is a reasonable vault root (not / or other
 // dangerous top-level paths that would cause the indexer to walk the entire filesystem).
 func VaultPath() string {
-	var path string
+	path, _ := VaultSource()
+	return path
+}
+
+// VaultSource is VaultPath that also reports how the vault was found, as
+// one of the VaultFrom* constants. The source is empty when no vault was
+// found; the path is empty but the source set when the candidate was
+// rejected as too broad.
+func VaultSource() (string, string) {
+	var path, source string
 	// CLI flag should always have highest priority.
 	if VaultOverride != "" {
 		reg := LoadRegistry()
@@ -964,17 +1654,18 @@ func VaultPath() string {
 		} else {
 			path = VaultOverride
 		}
-	} else if v := os.Getenv("VAULT_PATH"); v != "" {
-		path = v
+		source = VaultFromFlag
+	} else if v := os.Getenv(EnvVaultPath); v != "" {
+		path, source = v, VaultFromEnv
 	} else if cfg := loadConfigSafe(); cfg != nil && cfg.Vault.Path != "" {
-		path = cfg.Vault.Path
+		path, source = cfg.Vault.Path, VaultFromConfig
 	} else {
-		path = defaultVaultPath()
+		path, source = defaultVaultPathSource()
 	}
 	if path != "" {
 		path = validateVaultPath(path)
 	}
-	return path
+	return path, source
 }
 
 // validateVaultPath rejects vault paths that are too broad (e.g., /, /home, /Users)
@@ -1100,7 +1791,7 @@ var (
 // OllamaURL returns the validated Ollama API URL.
 // Returns an error if the URL is invalid or does not point to localhost.
 func OllamaURL() (string, error) {
-	raw := os.Getenv("OLLAMA_URL")
+	raw := os.Getenv(EnvOllamaURL)
 	if raw == "" {
 		if cfg := loadConfigSafe(); cfg != nil && cfg.Ollama.URL != "" {
 			raw = cfg.Ollama.URL
@@ -1131,7 +1822,7 @@ func DBPath() string {
 // DataDir returns the data directory for the same binary.
 // SECURITY: Validates SAME_DATA_DIR is an existing, writable directory.
 func DataDir() string {
-	if v := os.Getenv("SAME_DATA_DIR"); v != "" {
+	if v := os.Getenv(EnvDataDir); v != "" {
 		return validateDataDir(v)
 	}
 	return filepath.Join(VaultPath(), ".same", "data")
@@ -1331,6 +2022,59 @@ func (r *VaultRegistry) ResolveVault(alias string) string {
 	return ""
 }
 
+// VaultDBPath returns the database path for a vault root directory.
+func VaultDBPath(vaultRoot string) string {
+	return filepath.Join(vaultRoot, ".same", "data", "vault.db")
+}
+
+// SkippedVault is a requested vault that cross-vault search cannot use.
+type SkippedVault struct {
+	Alias  string
+	Reason string // "not registered" or "not indexed"
+}
+
+// SearchableVaults resolves aliases to database paths for cross-vault
+// search. An empty aliases list selects every registered vault that has an
+// index. With allowPaths, an alias that isn't registered may name a vault
+// directory directly (CLI use); without it, only registry entries resolve,
+// so callers like MCP can't be steered at arbitrary paths.
+func (r *VaultRegistry) SearchableVaults(aliases []string, allowPaths bool) (map[string]string, []SkippedVault) {
+	dbs := make(map[string]string)
+	var skipped []SkippedVault
+
+	if len(aliases) == 0 {
+		for alias, vaultPath := range r.Vaults {
+			dbPath := VaultDBPath(vaultPath)
+			if _, err := os.Stat(dbPath); err == nil {
+				dbs[alias] = dbPath
+			}
+		}
+		return dbs, nil
+	}
+
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			continue
+		}
+		resolved := r.Vaults[alias]
+		if resolved == "" && allowPaths {
+			resolved = r.ResolveVault(alias)
+		}
+		if resolved == "" {
+			skipped = append(skipped, SkippedVault{Alias: alias, Reason: "not registered"})
+			continue
+		}
+		dbPath := VaultDBPath(resolved)
+		if _, err := os.Stat(dbPath); err != nil {
+			skipped = append(skipped, SkippedVault{Alias: alias, Reason: "not indexed"})
+			continue
+		}
+		dbs[alias] = dbPath
+	}
+	return dbs, skipped
+}
+
 // VaultOverride is set by the --vault global flag.
 var VaultOverride string
 
@@ -1339,21 +2083,28 @@ var VaultOverride string
 var VaultMarkers = []string{".same", ".obsidian", ".logseq", ".foam", ".dendron"}
 
 func defaultVaultPath() string {
+	path, _ := defaultVaultPathSource()
+	return path
+}
+
+// defaultVaultPathSource auto-detects the vault, reporting where it was
+// found as a VaultFrom* constant.
+func defaultVaultPathSource() (string, string) {
 	// Check --vault flag override first
 	if VaultOverride != "" {
 		reg := LoadRegistry()
 		if resolved := reg.ResolveVault(VaultOverride); resolved != "" {
-			return resolved
+			return resolved, VaultFromFlag
 		}
 		// Treat as direct path
-		return VaultOverride
+		return VaultOverride, VaultFromFlag
 	}
 
 	// Auto-detect: check CWD for any known marker (before registry default)
 	if cwd, err := os.Getwd(); err == nil {
 		for _, marker := range VaultMarkers {
 			if _, err := os.Stat(filepath.Join(cwd, marker)); err == nil {
-				return cwd
+				return cwd, VaultFromCWD
 			}
 		}
 
@@ -1374,7 +2125,7 @@ func defaultVaultPath() string {
 				}
 			}
 			if len(childVaults) == 1 {
-				return childVaults[0]
+				return childVaults[0], VaultFromChild
 			}
 			if len(childVaults) > 1 {
 				fmt.Fprintf(os.Stderr, "  \u26a0 Multiple vaults found near %s:\n", cwd)
@@ -1396,7 +2147,7 @@ func defaultVaultPath() string {
 	reg := LoadRegistry()
 	if reg.Default != "" {
 		if p, ok := reg.Vaults[reg.Default]; ok {
-			return p
+			return p, VaultFromRegistry
 		}
 	}
 
@@ -1406,7 +2157,7 @@ func defaultVaultPath() string {
 		for i := 0; i < 5; i++ {
 			for _, marker := range VaultMarkers {
 				if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
-					return dir
+					return dir, VaultFromBinary
 				}
 			}
 			dir = filepath.Dir(dir)
@@ -1414,7 +2165,7 @@ func defaultVaultPath() string {
 	}
 
 	// No vault found — return empty string (caller should show helpful error)
-	return ""
+	return "", ""
 }
 
 // DisplayMode returns the current display mode from config.
@@ -1434,6 +2185,7 @@ type Profile struct {
 	MaxResults         int
 	DistanceThreshold  float64
 	CompositeThreshold float64
+	MaxTokenBudget     int    // surfacing token budget; 0 uses the default
 	TokenWarning       string // warning about token usage
 }
 
@@ -1445,6 +2197,7 @@ var BuiltinProfiles = map[string]Profile{
 		MaxResults:         2,
 		DistanceThreshold:  14.0,
 		CompositeThreshold: 0.75,
+		MaxTokenBudget:     800,
 		TokenWarning:       "Uses fewer tokens per query",
 	},
 	"balanced": {
@@ -1453,6 +2206,7 @@ var BuiltinProfiles = map[string]Profile{
 		MaxResults:         4,
 		DistanceThreshold:  16.2,
 		CompositeThreshold: 0.35,
+		MaxTokenBudget:     800,
 		TokenWarning:       "",
 	},
 	"broad": {
@@ -1461,6 +2215,7 @@ var BuiltinProfiles = map[string]Profile{
 		MaxResults:         4,
 		DistanceThreshold:  18.0,
 		CompositeThreshold: 0.55,
+		MaxTokenBudget:     1600,
 		TokenWarning:       "Uses ~2x more tokens per query",
 	},
 	"pi": {
@@ -1469,22 +2224,112 @@ var BuiltinProfiles = map[string]Profile{
 		MaxResults:         2,
 		DistanceThreshold:  15.0,
 		CompositeThreshold: 0.65,
+		MaxTokenBudget:     500,
 		TokenWarning:       "Minimizes CPU/RAM pressure and token usage",
 	},
 }
 
+// BuiltinProfileNames lists the builtin profiles in display order.
+var BuiltinProfileNames = []string{"precise", "balanced", "broad", "pi"}
+
+// profileNameRe restricts user profile names to simple identifiers.
+var profileNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// ValidateProfileName checks that name can be used for a user-defined profile.
+// Builtin names and the reserved name "custom" are rejected.
+func ValidateProfileName(name string) error {
+	if !profileNameRe.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits, '-' or '_' (max 32 characters)", name)
+	}
+	if _, ok := BuiltinProfiles[name]; ok || name == "custom" {
+		return fmt.Errorf("profile name %q is reserved", name)
+	}
+	return nil
+}
+
+// userProfiles converts the [profiles] section of cfg into Profiles.
+// Entries with invalid or reserved names are ignored.
+func userProfiles(cfg *Config) map[string]Profile {
+	out := make(map[string]Profile)
+	if cfg == nil {
+		return out
+	}
+	for name, pc := range cfg.Profiles {
+		if ValidateProfileName(name) != nil {
+			continue
+		}
+		desc := pc.Description
+		if desc == "" {
+			desc = "Custom profile"
+		}
+		out[name] = Profile{
+			Name:               name,
+			Description:        desc,
+			MaxResults:         pc.MaxResults,
+			DistanceThreshold:  pc.DistanceThreshold,
+			CompositeThreshold: pc.CompositeThreshold,
+			MaxTokenBudget:     pc.MaxTokenBudget,
+		}
+	}
+	return out
+}
+
+// mergedProfiles returns the builtin profiles plus the user profiles in cfg.
+func mergedProfiles(cfg *Config) map[string]Profile {
+	all := userProfiles(cfg)
+	for name, p := range BuiltinProfiles {
+		all[name] = p
+	}
+	return all
+}
+
+// AllProfiles returns the builtin profiles merged with user-defined
+// profiles from the current config.
+func AllProfiles() map[string]Profile {
+	return mergedProfiles(loadConfigSafe())
+}
+
+// UserProfileNames returns the sorted names of user-defined profiles.
+func UserProfileNames() []string {
+	names := make([]string, 0)
+	for name := range userProfiles(loadConfigSafe()) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // CurrentProfile returns the name of the current profile based on config values,
-// or "custom" if values don't match any builtin profile.
+// or "custom" if values don't match any builtin or user-defined profile.
 func CurrentProfile() string {
 	cfg := loadConfigSafe()
 	if cfg == nil {
 		return "balanced"
 	}
+	return matchProfile(cfg)
+}
 
-	for name, p := range BuiltinProfiles {
-		if cfg.Memory.MaxResults == p.MaxResults &&
+// matchProfile finds the profile whose settings equal cfg's [memory] values.
+// Builtins are checked first, then user profiles in name order.
+func matchProfile(cfg *Config) string {
+	matches := func(p Profile) bool {
+		return cfg.Memory.MaxResults == p.MaxResults &&
 			cfg.Memory.DistanceThreshold == p.DistanceThreshold &&
-			cfg.Memory.CompositeThreshold == p.CompositeThreshold {
+			cfg.Memory.CompositeThreshold == p.CompositeThreshold
+	}
+	for _, name := range BuiltinProfileNames {
+		if matches(BuiltinProfiles[name]) {
+			return name
+		}
+	}
+	user := userProfiles(cfg)
+	names := make([]string, 0, len(user))
+	for name := range user {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if matches(user[name]) {
 			return name
 		}
 	}
@@ -1492,31 +2337,117 @@ func CurrentProfile() string {
 }
 
 // SetProfile applies a profile's settings to the config file.
+// profileName may be a builtin or a user-defined profile.
 func SetProfile(vaultPath, profileName string) error {
-	profile, ok := BuiltinProfiles[profileName]
-	if !ok {
-		return fmt.Errorf("unknown profile: %s (available: precise, balanced, broad, pi)", profileName)
-	}
-
 	cfgPath := ConfigFilePath(vaultPath)
 
 	// Load from the target vault's config file to avoid clobbering
 	// settings when CWD != vaultPath (e.g., during init).
-	cfg, err := LoadConfigFrom(cfgPath)
+	cfg, err := loadConfigForWrite(cfgPath)
 	if err != nil {
 		cfg = DefaultConfig()
 	}
 
+	all := mergedProfiles(cfg)
+	profile, ok := all[profileName]
+	if !ok {
+		return fmt.Errorf("unknown profile: %s (available: %s)", profileName, strings.Join(sortedProfileNames(all), ", "))
+	}
+
 	// Apply profile settings
 	cfg.Memory.MaxResults = profile.MaxResults
 	cfg.Memory.DistanceThreshold = profile.DistanceThreshold
 	cfg.Memory.CompositeThreshold = profile.CompositeThreshold
+	cfg.Surfacing.MaxTokenBudget = profile.MaxTokenBudget
 
-	// Marshal to TOML
-	var buf bytes.Buffer
-	encoder := toml.NewEncoder(&buf)
-	if err := encoder.Encode(cfg); err != nil {
-		return fmt.Errorf("encode config: %w", err)
+	return writeConfigFile(cfgPath, cfg)
+}
+
+// CreateProfile saves a user-defined profile to the vault's config file.
+// Existing user profiles with the same name are replaced.
+func CreateProfile(vaultPath string, p Profile) error {
+	if err := ValidateProfileName(p.Name); err != nil {
+		return err
+	}
+	if p.MaxResults < 1 || p.MaxResults > 100 {
+		return fmt.Errorf("max_results must be between 1 and 100")
+	}
+	if p.DistanceThreshold <= 0 {
+		return fmt.Errorf("distance_threshold must be greater than 0")
+	}
+	if p.CompositeThreshold < 0 || p.CompositeThreshold > 1 {
+		return fmt.Errorf("composite_threshold must be between 0 and 1")
+	}
+	if p.MaxTokenBudget != 0 {
+		if err := ValidateSurfacingTokenBudget(p.MaxTokenBudget); err != nil {
+			return err
+		}
+	}
+
+	cfgPath := ConfigFilePath(vaultPath)
+	cfg, err := loadConfigForWrite(cfgPath)
+	if err != nil {
+		cfg = DefaultConfig()
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]ProfileConfig)
+	}
+	cfg.Profiles[p.Name] = ProfileConfig{
+		Description:        p.Description,
+		MaxResults:         p.MaxResults,
+		DistanceThreshold:  p.DistanceThreshold,
+		CompositeThreshold: p.CompositeThreshold,
+		MaxTokenBudget:     p.MaxTokenBudget,
+	}
+
+	return writeConfigFile(cfgPath, cfg)
+}
+
+// DeleteProfile removes a user-defined profile from the vault's config file.
+// The active [memory] settings are left unchanged.
+func DeleteProfile(vaultPath, name string) error {
+	if _, ok := BuiltinProfiles[name]; ok {
+		return fmt.Errorf("cannot delete builtin profile: %s", name)
+	}
+
+	cfgPath := ConfigFilePath(vaultPath)
+	cfg, err := loadConfigForWrite(cfgPath)
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile: %s", name)
+	}
+	delete(cfg.Profiles, name)
+	if len(cfg.Profiles) == 0 {
+		cfg.Profiles = nil
+	}
+
+	return writeConfigFile(cfgPath, cfg)
+}
+
+func sortedProfileNames(profiles map[string]Profile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeConfigFile encodes cfg as TOML and writes it to cfgPath. When the
+// file on disk includes a base file, only the keys it already sets and the
+// ones that changed are written, so the base's values still apply.
+func writeConfigFile(cfgPath string, cfg *Config) error {
+	data, err := encodeConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if orig, err := loadConfigForWrite(cfgPath); err == nil && orig.Include != "" {
+		if data, err = encodeOwnKeys(cfgPath, orig, cfg); err != nil {
+			return err
+		}
 	}
 
 	// Ensure directory exists
@@ -1525,7 +2456,67 @@ func SetProfile(vaultPath, profileName string) error {
 	}
 
 	// Write file
-	return os.WriteFile(cfgPath, buf.Bytes(), 0o600)
+	return os.WriteFile(cfgPath, data, 0o600)
+}
+
+func encodeConfig(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, fmt.Errorf("encode config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeOwnKeys encodes the keys of cfg that cfgPath sets itself or that
+// differ from orig, the file's current contents.
+func encodeOwnKeys(cfgPath string, orig, cfg *Config) ([]byte, error) {
+	var defined map[string]any
+	meta, err := toml.DecodeFile(cfgPath, &defined)
+	if err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", cfgPath, err)
+	}
+	before, err := configAsMap(orig)
+	if err != nil {
+		return nil, err
+	}
+	after, err := configAsMap(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return encodeConfig(ownConfigKeys(after, before, meta, nil))
+}
+
+// configAsMap round-trips cfg through TOML into a generic map.
+func configAsMap(cfg *Config) (map[string]any, error) {
+	data, err := encodeConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if _, err := toml.Decode(string(data), &m); err != nil {
+		return nil, fmt.Errorf("decode config: %w", err)
+	}
+	return m, nil
+}
+
+// ownConfigKeys keeps the entries of after that meta defines or whose value
+// differs from before, dropping tables left empty.
+func ownConfigKeys(after, before map[string]any, meta toml.MetaData, prefix []string) map[string]any {
+	out := make(map[string]any)
+	for k, v := range after {
+		key := append(append([]string{}, prefix...), k)
+		if table, ok := v.(map[string]any); ok {
+			old, _ := before[k].(map[string]any)
+			if kept := ownConfigKeys(table, old, meta, key); len(kept) > 0 || meta.IsDefined(key...) {
+				out[k] = kept
+			}
+			continue
+		}
+		if meta.IsDefined(key...) || !reflect.DeepEqual(v, before[k]) {
+			out[k] = v
+		}
+	}
+	return out
 }
 
 // SetDisplayMode updates the display mode in the config file.
@@ -1534,7 +2525,7 @@ func SetDisplayMode(vaultPath, mode string) error {
 
 	// Load from the target vault's config file to avoid clobbering
 	// settings when CWD != vaultPath (e.g., during init).
-	cfg, err := LoadConfigFrom(cfgPath)
+	cfg, err := loadConfigForWrite(cfgPath)
 	if err != nil {
 		cfg = DefaultConfig()
 	}
@@ -1542,27 +2533,14 @@ func SetDisplayMode(vaultPath, mode string) error {
 	// Update display mode
 	cfg.Display.Mode = mode
 
-	// Marshal to TOML
-	var buf bytes.Buffer
-	encoder := toml.NewEncoder(&buf)
-	if err := encoder.Encode(cfg); err != nil {
-		return fmt.Errorf("encode config: %w", err)
-	}
-
-	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(cfgPath), 0o700); err != nil {
-		return fmt.Errorf("create config directory: %w", err)
-	}
-
-	// Write file
-	return os.WriteFile(cfgPath, buf.Bytes(), 0o600)
+	return writeConfigFile(cfgPath, cfg)
 }
 
 // SetEmbeddingModel updates the embedding model in the config file.
 func SetEmbeddingModel(vaultPath, model string) error {
 	cfgPath := ConfigFilePath(vaultPath)
 
-	cfg, err := LoadConfigFrom(cfgPath)
+	cfg, err := loadConfigForWrite(cfgPath)
 	if err != nil {
 		cfg = DefaultConfig()
 	}
@@ -1571,27 +2549,66 @@ func SetEmbeddingModel(vaultPath, model string) error {
 	// Also update legacy [ollama].model for compatibility
 	cfg.Ollama.Model = model
 
-	var buf bytes.Buffer
-	encoder := toml.NewEncoder(&buf)
-	if err := encoder.Encode(cfg); err != nil {
-		return fmt.Errorf("encode config: %w", err)
+	return writeConfigFile(cfgPath, cfg)
+}
+
+// SetEmbeddingConfig updates the embedding provider, model and base URL in
+// the config file, leaving the API key and other settings as they are. An
+// empty model or base URL clears the setting so the provider default applies.
+func SetEmbeddingConfig(vaultPath string, ec EmbeddingConfig) error {
+	cfgPath := ConfigFilePath(vaultPath)
+
+	cfg, err := loadConfigForWrite(cfgPath)
+	if err != nil {
+		cfg = DefaultConfig()
 	}
 
-	if err := os.MkdirAll(filepath.Dir(cfgPath), 0o700); err != nil {
-		return fmt.Errorf("create config directory: %w", err)
+	cfg.Embedding.Provider = ec.Provider
+	cfg.Embedding.Model = ec.Model
+	cfg.Embedding.BaseURL = ec.BaseURL
+	if ec.Provider == "ollama" && ec.Model != "" {
+		// Keep legacy [ollama].model in step, as SetEmbeddingModel does
+		cfg.Ollama.Model = ec.Model
 	}
-	return os.WriteFile(cfgPath, buf.Bytes(), 0o600)
+
+	return writeConfigFile(cfgPath, cfg)
+}
+
+// VerboseFlagPath returns the flag file whose presence turns verbose
+// monitoring on ('same verbose on' creates it, 'same verbose off' removes it).
+func VerboseFlagPath() string {
+	return filepath.Join(DataDir(), "verbose")
+}
+
+// VerboseLogPath returns the log file verbose monitoring appends to.
+func VerboseLogPath() string {
+	return filepath.Join(DataDir(), "verbose.log")
 }
 
 // VerboseEnabled returns true when verbose monitoring is active.
 func VerboseEnabled() bool {
-	if os.Getenv("SAME_VERBOSE") != "" {
+	if os.Getenv(EnvVerbose) != "" {
 		return true
 	}
-	_, err := os.Stat(filepath.Join(DataDir(), "verbose"))
+	_, err := os.Stat(VerboseFlagPath())
 	return err == nil
 }
 
+// SurfacingTracePath returns the file context surfacing appends a JSON trace
+// line to on every run, or "" when tracing is off. SAME_TRACE=1 writes
+// surfacing-trace.jsonl in the data directory; any other value except
+// "0"/"false" is taken as the file path.
+func SurfacingTracePath() string {
+	v := strings.TrimSpace(os.Getenv(EnvTrace))
+	switch strings.ToLower(v) {
+	case "", "0", "false", "off":
+		return ""
+	case "1", "true", "on":
+		return filepath.Join(DataDir(), "surfacing-trace.jsonl")
+	}
+	return v
+}
+
 // MachineName returns the user-configured machine name, or falls back to hostname.
 func MachineName() string {
 	cfg := loadUserConfig()
@@ -1666,7 +2683,7 @@ func SetConfigValue(key, value string, global bool) error {
 		cfgPath = ConfigFilePath(vp)
 	}
 
-	cfg, err := LoadConfigFrom(cfgPath)
+	cfg, err := loadConfigForWrite(cfgPath)
 	if err != nil {
 		cfg = DefaultConfig()
 	}
@@ -1675,21 +2692,15 @@ func SetConfigValue(key, value string, global bool) error {
 		return err
 	}
 
-	var buf bytes.Buffer
-	encoder := toml.NewEncoder(&buf)
-	if err := encoder.Encode(cfg); err != nil {
-		return fmt.Errorf("encode config: %w", err)
-	}
-
-	if err := os.MkdirAll(filepath.Dir(cfgPath), 0o700); err != nil {
-		return fmt.Errorf("create config dir: %w", err)
-	}
-	return os.WriteFile(cfgPath, buf.Bytes(), 0o600)
+	return writeConfigFile(cfgPath, cfg)
 }
 
 // setField maps dot-notation keys to Config struct fields.
 func setField(cfg *Config, section, field, value string) error {
 	key := section + "." + field
+	if name, ok := strings.CutPrefix(key, "surfacing.tuning."); ok {
+		return setSurfacingTuning(cfg, name, value)
+	}
 	switch key {
 	case "ollama.url":
 		cfg.Ollama.URL = value
@@ -1709,6 +2720,16 @@ func setField(cfg *Config, section, field, value string) error {
 			return fmt.Errorf("invalid integer for %s: %w", key, err)
 		}
 		cfg.Embedding.Dimensions = n
+	case "embedding.timeout", "ask.timeout":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > MaxRequestTimeout {
+			return fmt.Errorf("invalid value for %s: %q (use 0 for the provider default, up to %d seconds)", key, value, MaxRequestTimeout)
+		}
+		if section == "ask" {
+			cfg.Ask.Timeout = n
+		} else {
+			cfg.Embedding.Timeout = n
+		}
 	case "chat.model":
 		cfg.Chat.Model = value
 	case "graph.llm_mode":
@@ -1719,12 +2740,83 @@ func setField(cfg *Config, section, field, value string) error {
 		cfg.Graph.LLMMode = value
 	case "graph.model":
 		cfg.Graph.Model = value
+	case "ask.provider":
+		valid := map[string]bool{"auto": true, "ollama": true, "openai": true, "openai-compatible": true}
+		if !valid[value] {
+			return fmt.Errorf("invalid value for ask.provider: %q (use auto, ollama, openai, or openai-compatible)", value)
+		}
+		cfg.Ask.Provider = value
+	case "ask.model":
+		cfg.Ask.Model = value
+	case "ask.base_url":
+		cfg.Ask.BaseURL = value
+	case "ask.api_key":
+		cfg.Ask.APIKey = value
 	case "memory.max_token_budget":
 		n, err := strconv.Atoi(value)
 		if err != nil {
 			return fmt.Errorf("invalid integer for %s: %w", key, err)
 		}
 		cfg.Memory.MaxTokenBudget = n
+	case "memory.max_pinned_tokens":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer for %s: %w", key, err)
+		}
+		cfg.Memory.MaxPinnedTokens = n
+	case "security.injection_detection":
+		mode := strings.ToLower(strings.TrimSpace(value))
+		switch mode {
+		case InjectionDetectionOff, InjectionDetectionLenient, InjectionDetectionStrict:
+		default:
+			return fmt.Errorf("invalid value for security.injection_detection: %q (use off, lenient, or strict)", value)
+		}
+		cfg.Security.InjectionDetection = mode
+	case "surfacing.max_token_budget":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer for %s: %w", key, err)
+		}
+		if err := ValidateSurfacingTokenBudget(n); err != nil {
+			return err
+		}
+		cfg.Surfacing.MaxTokenBudget = n
+	case "surfacing.confidence_half_life_days":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid float for %s: %w", key, err)
+		}
+		if f < 0 {
+			return fmt.Errorf("%s must be 0 (off) or a positive number of days", key)
+		}
+		cfg.Surfacing.ConfidenceHalfLifeDays = f
+	case "surfacing.link_boost":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid float for %s: %w", key, err)
+		}
+		if f < 0 || f > 1 || math.IsNaN(f) {
+			return fmt.Errorf("%s must be between 0 (off) and 1", key)
+		}
+		cfg.Surfacing.LinkBoost = f
+	case "surfacing.snippet_chars":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer for %s: %w", key, err)
+		}
+		if err := ValidateSnippetChars(n); err != nil {
+			return err
+		}
+		cfg.Surfacing.SnippetChars = n
+	case "surfacing.stale_after_days":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer for %s: %w", key, err)
+		}
+		if n < 0 {
+			return fmt.Errorf("%s must be 0 (off) or a positive number of days", key)
+		}
+		cfg.Surfacing.StaleAfterDays = n
 	case "memory.max_results":
 		n, err := strconv.Atoi(value)
 		if err != nil {
@@ -1763,12 +2855,43 @@ func setField(cfg *Config, section, field, value string) error {
 		cfg.Vault.HandoffDir = value
 	case "auth.token":
 		cfg.Auth.Token = value
+	case "mcp.read_only":
+		cfg.MCP.ReadOnly = parseBoolValue(value)
+	case "search.synonyms":
+		cfg.Search.Synonyms = parseBoolValue(value)
+	case "mcp.write_rate_limit":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid value for mcp.write_rate_limit: %q (must be a positive integer)", value)
+		}
+		cfg.MCP.WriteRateLimit = n
 	default:
 		return fmt.Errorf("unknown config key %q — run 'same config show' to see available keys", key)
 	}
 	return nil
 }
 
+// setSurfacingTuning sets one [surfacing.tuning] override. "default"
+// removes it, restoring the built-in value.
+func setSurfacingTuning(cfg *Config, name, value string) error {
+	if strings.EqualFold(value, "default") {
+		delete(cfg.Surfacing.Tuning, name)
+		return nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid float for surfacing.tuning.%s: %w", name, err)
+	}
+	if err := ValidateSurfacingTuning(name, f); err != nil {
+		return err
+	}
+	if cfg.Surfacing.Tuning == nil {
+		cfg.Surfacing.Tuning = make(map[string]float64)
+	}
+	cfg.Surfacing.Tuning[name] = f
+	return nil
+}
+
 // parseBoolValue parses a boolean string value (true/false/yes/no/on/off/1/0).
 func parseBoolValue(s string) bool {
 	switch strings.ToLower(s) {
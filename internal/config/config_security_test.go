@@ -389,6 +389,69 @@ func TestSetProfile_InvalidName(t *testing.T) {
 	}
 }
 
+func TestCreateProfile_UseAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	p := Profile{Name: "myteam", MaxResults: 8, DistanceThreshold: 15.5, CompositeThreshold: 0.6, MaxTokenBudget: 1200}
+	if err := CreateProfile(dir, p); err != nil {
+		t.Fatalf("CreateProfile: %v", err)
+	}
+	if err := SetProfile(dir, "myteam"); err != nil {
+		t.Fatalf("SetProfile: %v", err)
+	}
+
+	cfg, err := LoadConfigFrom(ConfigFilePath(dir))
+	if err != nil {
+		t.Fatalf("LoadConfigFrom: %v", err)
+	}
+	if cfg.Memory.MaxResults != 8 || cfg.Memory.DistanceThreshold != 15.5 || cfg.Memory.CompositeThreshold != 0.6 {
+		t.Errorf("memory settings not applied: %+v", cfg.Memory)
+	}
+	if cfg.Surfacing.MaxTokenBudget != 1200 {
+		t.Errorf("surfacing.max_token_budget = %d, want 1200", cfg.Surfacing.MaxTokenBudget)
+	}
+	if got := matchProfile(cfg); got != "myteam" {
+		t.Errorf("matchProfile = %q, want myteam", got)
+	}
+
+	if err := DeleteProfile(dir, "myteam"); err != nil {
+		t.Fatalf("DeleteProfile: %v", err)
+	}
+	cfg, err = LoadConfigFrom(ConfigFilePath(dir))
+	if err != nil {
+		t.Fatalf("LoadConfigFrom: %v", err)
+	}
+	if _, ok := cfg.Profiles["myteam"]; ok {
+		t.Error("profile should be removed from config")
+	}
+	if got := matchProfile(cfg); got != "custom" {
+		t.Errorf("matchProfile after delete = %q, want custom", got)
+	}
+	if err := SetProfile(dir, "myteam"); err == nil {
+		t.Error("expected error using a deleted profile")
+	}
+}
+
+func TestCreateProfile_Rejects(t *testing.T) {
+	dir := t.TempDir()
+	cases := []Profile{
+		{Name: "balanced", MaxResults: 4, DistanceThreshold: 16, CompositeThreshold: 0.5},
+		{Name: "custom", MaxResults: 4, DistanceThreshold: 16, CompositeThreshold: 0.5},
+		{Name: "Bad Name", MaxResults: 4, DistanceThreshold: 16, CompositeThreshold: 0.5},
+		{Name: "ok", MaxResults: 0, DistanceThreshold: 16, CompositeThreshold: 0.5},
+		{Name: "ok", MaxResults: 4, DistanceThreshold: 0, CompositeThreshold: 0.5},
+		{Name: "ok", MaxResults: 4, DistanceThreshold: 16, CompositeThreshold: 1.5},
+		{Name: "ok", MaxResults: 4, DistanceThreshold: 16, CompositeThreshold: 0.5, MaxTokenBudget: 50},
+	}
+	for _, p := range cases {
+		if err := CreateProfile(dir, p); err == nil {
+			t.Errorf("expected error for %+v", p)
+		}
+	}
+	if err := DeleteProfile(dir, "precise"); err == nil {
+		t.Error("expected error deleting a builtin profile")
+	}
+}
+
 // --- GenerateConfig ---
 
 func TestGenerateConfig_CreatesFile(t *testing.T) {
@@ -409,3 +472,24 @@ func TestGenerateConfig_CreatesFile(t *testing.T) {
 		t.Errorf("expected permissions 0600, got %o", perm)
 	}
 }
+
+func TestGenerateConfig_RecordsEmbeddingBaseURL(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SAME_EMBED_PROVIDER", "openai-compatible")
+	t.Setenv("SAME_EMBED_MODEL", "nomic-embed-text")
+	t.Setenv("SAME_EMBED_BASE_URL", "http://localhost:1234")
+	if err := GenerateConfig(dir); err != nil {
+		t.Fatalf("GenerateConfig: %v", err)
+	}
+
+	cfg, err := LoadConfigFrom(ConfigFilePath(dir))
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.Embedding.BaseURL != "http://localhost:1234" {
+		t.Errorf("base_url = %q, want http://localhost:1234", cfg.Embedding.BaseURL)
+	}
+	if cfg.Embedding.Model != "nomic-embed-text" {
+		t.Errorf("model = %q, want nomic-embed-text", cfg.Embedding.Model)
+	}
+}
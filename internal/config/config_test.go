@@ -524,6 +524,42 @@ func TestDefaultVaultPath_SingleChildVault(t *testing.T) {
 	}
 }
 
+func TestVaultSource_EnvAndCWD(t *testing.T) {
+	oldOverride := VaultOverride
+	VaultOverride = ""
+	t.Cleanup(func() { VaultOverride = oldOverride })
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	envVault := t.TempDir()
+	t.Setenv("VAULT_PATH", envVault)
+	if path, source := VaultSource(); path != envVault || source != VaultFromEnv {
+		t.Errorf("VaultSource() = %q, %q; want %q from env", path, source, envVault)
+	}
+
+	t.Setenv("VAULT_PATH", "")
+	cwdVault := t.TempDir()
+	if err := os.Mkdir(filepath.Join(cwdVault, ".obsidian"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(cwdVault); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldWd) })
+	path, source := VaultSource()
+	// Resolve symlinks for macOS /var → /private/var comparison
+	resolvedWant, _ := filepath.EvalSymlinks(cwdVault)
+	resolvedGot, _ := filepath.EvalSymlinks(path)
+	if resolvedGot != resolvedWant || source != VaultFromCWD {
+		t.Errorf("VaultSource() = %q, %q; want %q from cwd", path, source, resolvedWant)
+	}
+}
+
 func TestDefaultVaultPath_MultipleChildVaults(t *testing.T) {
 	parent := t.TempDir()
 	child1 := filepath.Join(parent, "project1")
@@ -918,6 +954,149 @@ func TestConfigSet_EnumValidation(t *testing.T) {
 	}
 }
 
+func TestConfigSet_SurfacingTokenBudget(t *testing.T) {
+	_ = setupTestVault(t)
+
+	if got := SurfacingMaxTokenBudget(); got != DefaultSurfacingTokenBudget {
+		t.Errorf("default budget = %d, want %d", got, DefaultSurfacingTokenBudget)
+	}
+	for _, bad := range []string{"50", "9000", "lots"} {
+		if err := SetConfigValue("surfacing.max_token_budget", bad, false); err == nil {
+			t.Errorf("expected error for max_token_budget %q", bad)
+		}
+	}
+	if err := SetConfigValue("surfacing.max_token_budget", "1200", false); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	if got := SurfacingMaxTokenBudget(); got != 1200 {
+		t.Errorf("budget = %d, want 1200", got)
+	}
+}
+
+func TestConfigSet_SurfacingSnippetChars(t *testing.T) {
+	_ = setupTestVault(t)
+
+	if got := SurfacingSnippetChars(); got != DefaultSurfacingSnippetChars {
+		t.Errorf("default snippet_chars = %d, want %d", got, DefaultSurfacingSnippetChars)
+	}
+	for _, bad := range []string{"10", "5000", "short"} {
+		if err := SetConfigValue("surfacing.snippet_chars", bad, false); err == nil {
+			t.Errorf("expected error for snippet_chars %q", bad)
+		}
+	}
+	if err := SetConfigValue("surfacing.snippet_chars", "200", false); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	if got := SurfacingSnippetChars(); got != 200 {
+		t.Errorf("snippet_chars = %d, want 200", got)
+	}
+}
+
+func TestConfigSet_SurfacingStaleAfterDays(t *testing.T) {
+	_ = setupTestVault(t)
+
+	if got := SurfacingStaleAfterDays(); got != 0 {
+		t.Errorf("default stale_after_days = %d, want 0 (off)", got)
+	}
+	for _, bad := range []string{"-1", "soon"} {
+		if err := SetConfigValue("surfacing.stale_after_days", bad, false); err == nil {
+			t.Errorf("expected error for stale_after_days %q", bad)
+		}
+	}
+	if err := SetConfigValue("surfacing.stale_after_days", "90", false); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	if got := SurfacingStaleAfterDays(); got != 90 {
+		t.Errorf("stale_after_days = %d, want 90", got)
+	}
+}
+
+func TestConfigSet_SurfacingTuning(t *testing.T) {
+	_ = setupTestVault(t)
+
+	if got := SurfacingTuning(); got != nil {
+		t.Errorf("default tuning = %v, want nil", got)
+	}
+	for key, bad := range map[string]string{
+		"surfacing.tuning.max_distance":  "200",
+		"surfacing.tuning.min_composite": "high",
+		"surfacing.tuning.max_results":   "2.5",
+		"surfacing.tuning.max_distnace":  "16",
+	} {
+		if err := SetConfigValue(key, bad, false); err == nil {
+			t.Errorf("expected error for %s = %q", key, bad)
+		}
+	}
+	if err := SetConfigValue("surfacing.tuning.max_distance", "16.8", false); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	if err := SetConfigValue("surfacing.tuning.max_results", "5", false); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	got := SurfacingTuning()
+	if got["max_distance"] != 16.8 || got["max_results"] != 5 || len(got) != 2 {
+		t.Errorf("tuning = %v, want max_distance=16.8 max_results=5", got)
+	}
+
+	if err := SetConfigValue("surfacing.tuning.max_results", "default", false); err != nil {
+		t.Fatalf("SetConfigValue default: %v", err)
+	}
+	if got := SurfacingTuning(); len(got) != 1 || got["max_distance"] != 16.8 {
+		t.Errorf("after reset tuning = %v, want only max_distance", got)
+	}
+}
+
+func TestValidateConfig_SurfacingTuning(t *testing.T) {
+	vault := setupTestVault(t)
+	t.Setenv("HOME", t.TempDir())
+	if err := os.MkdirAll(filepath.Join(vault, ".same"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfgText := `[surfacing.tuning]
+max_distance = 16.8
+min_composite = 1.5
+min_compsite = 0.6
+`
+	if err := os.WriteFile(filepath.Join(vault, ".same", "config.toml"), []byte(cfgText), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]ConfigIssue{}
+	for _, issue := range ValidateConfig() {
+		got[issue.Key] = issue
+	}
+	if len(got) != 2 {
+		t.Errorf("issues = %v, want 2", got)
+	}
+	if issue, ok := got["surfacing.tuning.min_composite"]; !ok || !strings.Contains(issue.Message, "between 0 and 1") {
+		t.Errorf("min_composite issue = %+v, want a range issue", issue)
+	}
+	if issue := got["surfacing.tuning.min_compsite"]; issue.Message != issueUnknownKey || issue.Suggestion != "surfacing.tuning.min_composite" {
+		t.Errorf("min_compsite issue = %+v, want unknown key suggesting min_composite", issue)
+	}
+	if tuning := SurfacingTuning(); len(tuning) != 1 || tuning["max_distance"] != 16.8 {
+		t.Errorf("SurfacingTuning = %v, want only the valid override", tuning)
+	}
+}
+
+func TestConfigSet_InjectionDetection(t *testing.T) {
+	_ = setupTestVault(t)
+
+	if got := InjectionDetectionMode(); got != InjectionDetectionStrict {
+		t.Errorf("default mode = %q, want strict", got)
+	}
+	if err := SetConfigValue("security.injection_detection", "paranoid", false); err == nil {
+		t.Error("expected error for an unknown detection level")
+	}
+	if err := SetConfigValue("security.injection_detection", "Lenient", false); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	if got := InjectionDetectionMode(); got != InjectionDetectionLenient {
+		t.Errorf("mode = %q, want lenient", got)
+	}
+}
+
 func TestConfigSet_UnknownKey(t *testing.T) {
 	_ = setupTestVault(t)
 
@@ -1042,3 +1221,363 @@ func TestConfigSet_PreservesExistingValues(t *testing.T) {
 		t.Errorf("memory.max_results = %d, want 10", cfg.Memory.MaxResults)
 	}
 }
+
+func TestSearchableVaults(t *testing.T) {
+	indexed := t.TempDir()
+	if err := os.MkdirAll(filepath.Dir(VaultDBPath(indexed)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(VaultDBPath(indexed), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	unindexed := t.TempDir()
+	unregistered := t.TempDir()
+	if err := os.MkdirAll(filepath.Dir(VaultDBPath(unregistered)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(VaultDBPath(unregistered), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	reg := &VaultRegistry{Vaults: map[string]string{"dev": indexed, "empty": unindexed}}
+
+	all, skipped := reg.SearchableVaults(nil, false)
+	if len(all) != 1 || all["dev"] != VaultDBPath(indexed) || len(skipped) != 0 {
+		t.Fatalf("all vaults = %v, skipped %v; want only dev", all, skipped)
+	}
+
+	dbs, skipped := reg.SearchableVaults([]string{"dev", " empty ", "", "nope", unregistered}, false)
+	if len(dbs) != 1 || dbs["dev"] == "" {
+		t.Fatalf("dbs = %v, want only dev", dbs)
+	}
+	reasons := map[string]string{}
+	for _, sv := range skipped {
+		reasons[sv.Alias] = sv.Reason
+	}
+	if reasons["empty"] != "not indexed" || reasons["nope"] != "not registered" || reasons[unregistered] != "not registered" {
+		t.Fatalf("skip reasons = %v", reasons)
+	}
+
+	// Paths resolve only when explicitly allowed.
+	dbs, _ = reg.SearchableVaults([]string{unregistered}, true)
+	if dbs[unregistered] != VaultDBPath(unregistered) {
+		t.Fatalf("expected path alias to resolve with allowPaths, got %v", dbs)
+	}
+}
+
+func TestValidateConfig_UnknownKeysAndRanges(t *testing.T) {
+	tmpHome := t.TempDir()
+	vault := t.TempDir()
+
+	oldOverride := VaultOverride
+	VaultOverride = vault
+	t.Cleanup(func() { VaultOverride = oldOverride })
+
+	t.Setenv("VAULT_PATH", vault)
+	t.Setenv("HOME", tmpHome)
+
+	vaultDir := filepath.Join(vault, ".same")
+	if err := os.MkdirAll(vaultDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfgText := `[memory]
+distnace_threshold = 12.0
+max_results = 500
+max_token_budget = 1600
+
+[memroy]
+max_results = 3
+
+[vault]
+exclude_paths = ["tmp"]
+zzz_unrelated = 1
+
+[display]
+mode = "loud"
+`
+	if err := os.WriteFile(filepath.Join(vaultDir, "config.toml"), []byte(cfgText), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"memory.distnace_threshold": "memory.distance_threshold",
+		"memroy.max_results":        "memory.max_results",
+		"vault.exclude_paths":       "vault.skip_dirs",
+		"vault.zzz_unrelated":       "",
+	}
+	ranges := map[string]bool{"memory.max_results": false, "display.mode": false}
+	for _, issue := range ValidateConfig() {
+		if issue.Message != issueUnknownKey {
+			if _, ok := ranges[issue.Key]; !ok {
+				t.Errorf("unexpected range issue: %s", issue)
+			}
+			ranges[issue.Key] = true
+			continue
+		}
+		suggestion, ok := want[issue.Key]
+		if !ok {
+			t.Errorf("unexpected unknown key issue: %s", issue)
+			continue
+		}
+		if issue.Suggestion != suggestion {
+			t.Errorf("suggestion for %s = %q, want %q", issue.Key, issue.Suggestion, suggestion)
+		}
+		delete(want, issue.Key)
+	}
+	for key := range want {
+		t.Errorf("missing unknown key issue for %s", key)
+	}
+	for key, seen := range ranges {
+		if !seen {
+			t.Errorf("missing range issue for %s", key)
+		}
+	}
+
+	if w := ConfigWarning(); !strings.Contains(w, `did you mean "memory.distance_threshold"`) {
+		t.Errorf("ConfigWarning = %q, want typo suggestion", w)
+	}
+	if dep := DeprecatedConfigKeys(); dep["memory.max_token_budget"] == "" {
+		t.Errorf("DeprecatedConfigKeys = %v, want memory.max_token_budget", dep)
+	}
+}
+
+func TestConfigSet_AskProvider(t *testing.T) {
+	setupTestVault(t)
+	if err := SetConfigValue("ask.provider", "llamacpp", false); err == nil {
+		t.Error("expected error for unknown ask.provider")
+	}
+	if err := SetConfigValue("ask.provider", "openai-compatible", false); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	if err := SetConfigValue("ask.base_url", "http://localhost:8080", false); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	ac := AskProviderConfig()
+	if ac.Provider != "openai-compatible" || ac.BaseURL != "http://localhost:8080" {
+		t.Errorf("AskProviderConfig = %+v", ac)
+	}
+}
+
+func TestOutputConfig_PerTypeLocations(t *testing.T) {
+	vault := setupTestVault(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SAME_HANDOFF_DIR", "")
+	t.Setenv("SAME_DECISION_LOG", "")
+	if err := os.MkdirAll(filepath.Join(vault, ".same"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(text string) {
+		t.Helper()
+		if err := os.WriteFile(ConfigFilePath(vault), []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`[vault]
+handoff_dir = "sessions"
+decision_log = "log.md"
+
+[output.decision]
+style = "files"
+path = "adr"
+
+[output.handoff]
+dir = "team/handoffs"
+`)
+	if got := DecisionDirectory(); got != "adr" {
+		t.Errorf("DecisionDirectory = %q, want adr", got)
+	}
+	if got := DecisionLogPath(); got != "log.md" {
+		t.Errorf("DecisionLogPath = %q, want the vault.decision_log fallback", got)
+	}
+	if got := HandoffDirectory(); got != "team/handoffs" {
+		t.Errorf("HandoffDirectory = %q, want team/handoffs", got)
+	}
+
+	write(`[output.decision]
+path = "docs/decisions.md"
+`)
+	if got := DecisionDirectory(); got != "" {
+		t.Errorf("DecisionDirectory = %q, want empty for log style", got)
+	}
+	if got := DecisionLogPath(); got != "docs/decisions.md" {
+		t.Errorf("DecisionLogPath = %q, want docs/decisions.md", got)
+	}
+
+	write(`[output.decision]
+style = "adr"
+path = "../outside"
+
+[output.handoff]
+dir = "/tmp/handoffs"
+`)
+	got := make(map[string]bool)
+	for _, issue := range ValidateConfig() {
+		got[issue.Key] = true
+	}
+	for _, key := range []string{"output.decision.style", "output.decision.path", "output.handoff.dir"} {
+		if !got[key] {
+			t.Errorf("missing validation issue for %s (got %v)", key, got)
+		}
+	}
+}
+
+func TestConfigSet_RequestTimeouts(t *testing.T) {
+	setupTestVault(t)
+	if err := SetConfigValue("embedding.timeout", "180", false); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	if err := SetConfigValue("ask.timeout", "-1", false); err == nil {
+		t.Error("expected error for negative ask.timeout")
+	}
+	if got := EmbeddingProviderConfig().RequestTimeout(); got != 180*time.Second {
+		t.Errorf("embedding RequestTimeout = %v, want 3m", got)
+	}
+	if got := AskProviderConfig().RequestTimeout(); got != 0 {
+		t.Errorf("ask RequestTimeout = %v, want 0 (provider default)", got)
+	}
+}
+
+func TestConfigInclude_MergesBaseThenLocal(t *testing.T) {
+	vault := setupTestVault(t)
+	t.Setenv("HOME", t.TempDir())
+	shared := filepath.Join(vault, "shared-same.toml")
+	if err := os.WriteFile(shared, []byte("[memory]\nmax_results = 7\ndistance_threshold = 14.5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	local := ConfigFilePath(vault)
+	if err := os.MkdirAll(filepath.Dir(local), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(local, []byte("include = \"../shared-same.toml\"\n\n[memory]\nmax_results = 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.Memory.MaxResults != 3 {
+		t.Errorf("max_results = %d, want local override 3", cfg.Memory.MaxResults)
+	}
+	if cfg.Memory.DistanceThreshold != 14.5 {
+		t.Errorf("distance_threshold = %v, want inherited 14.5", cfg.Memory.DistanceThreshold)
+	}
+	if issues := ValidateConfig(); len(issues) != 0 {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+}
+
+func TestSetConfigValue_DoesNotCopyIncludedValues(t *testing.T) {
+	vault := setupTestVault(t)
+	t.Setenv("HOME", t.TempDir())
+	shared := filepath.Join(vault, "shared-same.toml")
+	if err := os.WriteFile(shared, []byte("[memory]\nmax_results = 9\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	local := ConfigFilePath(vault)
+	if err := os.MkdirAll(filepath.Dir(local), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(local, []byte("include = \"../shared-same.toml\"\n\n[display]\nmode = \"compact\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetConfigValue("ollama.url", "http://localhost:11435", false); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	data, err := os.ReadFile(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "max_results") {
+		t.Errorf("base value copied into the local file:\n%s", data)
+	}
+	for _, want := range []string{`include = "../shared-same.toml"`, `mode = "compact"`, `url = "http://localhost:11435"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("local file missing %s:\n%s", want, data)
+		}
+	}
+
+	// A later change to the base still reaches the vault.
+	if err := os.WriteFile(shared, []byte("[memory]\nmax_results = 4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.Memory.MaxResults != 4 {
+		t.Errorf("max_results = %d, want 4 from the edited base", cfg.Memory.MaxResults)
+	}
+}
+
+func TestConfigInclude_MissingAndCycleAreWarnings(t *testing.T) {
+	vault := setupTestVault(t)
+	t.Setenv("HOME", t.TempDir())
+	dir := filepath.Join(vault, ".same")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	local := ConfigFilePath(vault)
+
+	if err := os.WriteFile(local, []byte("include = \"nope.toml\"\n\n[memory]\nmax_results = 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig with missing include: %v", err)
+	}
+	if cfg.Memory.MaxResults != 3 {
+		t.Errorf("max_results = %d, want 3", cfg.Memory.MaxResults)
+	}
+	if w := ConfigWarning(); !strings.Contains(w, "nope.toml") {
+		t.Errorf("ConfigWarning() = %q, want the missing include", w)
+	}
+
+	if err := os.WriteFile(local, []byte("include = \"base.toml\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "base.toml"), []byte("include = \"config.toml\"\n[memory]\nmax_results = 6\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig with include cycle: %v", err)
+	}
+	if cfg.Memory.MaxResults != 6 {
+		t.Errorf("max_results = %d, want 6 from base.toml", cfg.Memory.MaxResults)
+	}
+	if w := ConfigWarning(); !strings.Contains(w, "cycle") {
+		t.Errorf("ConfigWarning() = %q, want an include cycle", w)
+	}
+}
+
+func TestEnvSettings_ReportsSetValuesAndHidesSecrets(t *testing.T) {
+	setupTestVault(t)
+	t.Setenv(EnvQuiet, "1")
+	t.Setenv(EnvEmbedProvider, "openai")
+	t.Setenv(EnvEmbedAPIKey, "sk-secret")
+	os.Unsetenv(EnvCompact)
+
+	byName := make(map[string]EnvSetting)
+	for _, s := range EnvSettings() {
+		byName[s.Name] = s
+	}
+	if len(byName) != len(EnvVars) {
+		t.Fatalf("duplicate names in EnvVars: %d unique of %d", len(byName), len(EnvVars))
+	}
+
+	if s := byName[EnvQuiet]; !s.Set || s.Value != "1" {
+		t.Errorf("SAME_QUIET = %+v, want set to 1", s)
+	}
+	if s := byName[EnvCompact]; s.Set {
+		t.Errorf("SAME_COMPACT reported set: %+v", s)
+	}
+	if s := byName[EnvEmbedProvider]; s.Effective != "openai" {
+		t.Errorf("SAME_EMBED_PROVIDER effective = %q, want openai", s.Effective)
+	}
+	if s := byName[EnvEmbedAPIKey]; !s.Set || s.Value != "" || s.Effective != "" {
+		t.Errorf("SAME_EMBED_API_KEY should be set but hidden, got %+v", s)
+	}
+}
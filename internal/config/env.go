@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// Environment variables SAME reads. Where a variable overrides a config
+// file setting, the variable wins. Every name here is listed in EnvVars,
+// which 'same config env' prints.
+const (
+	EnvVaultPath     = "VAULT_PATH"
+	EnvDataDir       = "SAME_DATA_DIR"
+	EnvHandoffDir    = "SAME_HANDOFF_DIR"
+	EnvDecisionLog   = "SAME_DECISION_LOG"
+	EnvSkipDirs      = "SAME_SKIP_DIRS"
+	EnvNoisePaths    = "SAME_NOISE_PATHS"
+	EnvOllamaURL     = "OLLAMA_URL"
+	EnvEmbedProvider = "SAME_EMBED_PROVIDER"
+	EnvEmbedModel    = "SAME_EMBED_MODEL"
+	EnvEmbedBaseURL  = "SAME_EMBED_BASE_URL"
+	EnvEmbedAPIKey   = "SAME_EMBED_API_KEY"
+	EnvOpenAIAPIKey  = "OPENAI_API_KEY"
+	EnvChatProvider  = "SAME_CHAT_PROVIDER"
+	EnvChatModel     = "SAME_CHAT_MODEL"
+	EnvChatBaseURL   = "SAME_CHAT_BASE_URL"
+	EnvChatAPIKey    = "SAME_CHAT_API_KEY"
+	EnvChatFallbacks = "SAME_CHAT_FALLBACKS"
+	EnvGraphLLM      = "SAME_GRAPH_LLM"
+	EnvGraphModel    = "SAME_GRAPH_MODEL"
+	EnvMCPToken      = "SAME_MCP_TOKEN"
+	EnvQuiet         = "SAME_QUIET"
+	EnvCompact       = "SAME_COMPACT"
+	EnvVerbose       = "SAME_VERBOSE"
+	EnvTrace         = "SAME_TRACE"
+)
+
+// EnvVar documents one environment variable SAME reads.
+type EnvVar struct {
+	Name        string
+	Description string
+	Secret      bool // never print the value
+	// Effective returns the value SAME ends up using for this setting,
+	// whether it came from the variable, the config file or a default.
+	// Nil when the variable has no setting behind it.
+	Effective func() string
+}
+
+// EnvVars lists every environment variable SAME reads, grouped by area.
+var EnvVars = []EnvVar{
+	{Name: EnvVaultPath, Description: "Vault root (after --vault)", Effective: VaultPath},
+	{Name: EnvDataDir, Description: "Directory for the index database and logs", Effective: DataDir},
+	{Name: EnvHandoffDir, Description: "Vault-relative directory for session handoffs", Effective: HandoffDirectory},
+	{Name: EnvDecisionLog, Description: "Vault-relative decision log file", Effective: DecisionLogPath},
+	{Name: EnvSkipDirs, Description: "Comma-separated extra directories to skip when indexing"},
+	{Name: EnvNoisePaths, Description: "Comma-separated paths filtered from context surfacing", Effective: func() string {
+		return strings.Join(NoisePaths(), ",")
+	}},
+	{Name: EnvOllamaURL, Description: "Ollama server URL (must be local)", Effective: func() string {
+		u, err := OllamaURL()
+		if err != nil {
+			return "invalid: " + err.Error()
+		}
+		return u
+	}},
+	{Name: EnvEmbedProvider, Description: "Embedding provider: ollama, openai, openai-compatible, none", Effective: func() string {
+		return EmbeddingProviderConfig().Provider
+	}},
+	{Name: EnvEmbedModel, Description: "Embedding model", Effective: func() string {
+		if m := EmbeddingProviderConfig().Model; m != "" {
+			return m
+		}
+		return EmbeddingModel
+	}},
+	{Name: EnvEmbedBaseURL, Description: "Embedding endpoint for openai/openai-compatible", Effective: func() string {
+		return EmbeddingProviderConfig().BaseURL
+	}},
+	{Name: EnvEmbedAPIKey, Description: "Embedding API key", Secret: true},
+	{Name: EnvOpenAIAPIKey, Description: "OpenAI API key, used when no SAME-specific key is set", Secret: true},
+	{Name: EnvChatProvider, Description: "Chat provider: auto, ollama, openai, openai-compatible"},
+	{Name: EnvChatModel, Description: "Chat model for ask, brief and consolidation", Effective: ChatModel},
+	{Name: EnvChatBaseURL, Description: "Chat endpoint for openai/openai-compatible"},
+	{Name: EnvChatAPIKey, Description: "Chat API key", Secret: true},
+	{Name: EnvChatFallbacks, Description: "Comma-separated chat providers to try in order"},
+	{Name: EnvGraphLLM, Description: "Graph LLM extraction: off, local-only, on", Effective: GraphLLMMode},
+	{Name: EnvGraphModel, Description: "Chat model for graph extraction", Effective: GraphModel},
+	{Name: EnvMCPToken, Description: "Bearer token for the MCP HTTP transport", Secret: true},
+	{Name: EnvQuiet, Description: "1 or true hides surfaced-context output in hooks"},
+	{Name: EnvCompact, Description: "1 or true shows compact surfaced-context output"},
+	{Name: EnvVerbose, Description: "Any value turns on verbose monitoring", Effective: func() string {
+		if VerboseEnabled() {
+			return "on"
+		}
+		return "off"
+	}},
+	{Name: EnvTrace, Description: "1 or a file path writes a JSON trace of context surfacing", Effective: SurfacingTracePath},
+}
+
+// EnvSetting is an EnvVar's state in the current process.
+type EnvSetting struct {
+	EnvVar
+	Set       bool
+	Value     string // raw value; empty for secrets
+	Effective string // empty for secrets and variables without a setting
+}
+
+// EnvSettings reports every variable in EnvVars: whether it is set, its
+// value and the effective setting. Secret values are never returned.
+func EnvSettings() []EnvSetting {
+	out := make([]EnvSetting, 0, len(EnvVars))
+	for _, ev := range EnvVars {
+		s := EnvSetting{EnvVar: ev}
+		s.Value, s.Set = os.LookupEnv(ev.Name)
+		if ev.Secret {
+			s.Value = ""
+		} else if ev.Effective != nil {
+			s.Effective = ev.Effective()
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,359 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ConfigIssue is one problem found in a config file: an unknown key or a
+// value outside its accepted range.
+type ConfigIssue struct {
+	File       string // config file base name
+	Key        string // dotted key, e.g. "memory.distance_threshold"
+	Message    string
+	Suggestion string // likely intended dotted key, for unknown keys
+}
+
+func (i ConfigIssue) String() string {
+	if i.Message != issueUnknownKey {
+		return fmt.Sprintf("%q in %s %s", i.Key, i.File, i.Message)
+	}
+	if i.Suggestion != "" {
+		return fmt.Sprintf("unknown key %q in %s — did you mean %q?", i.Key, i.File, i.Suggestion)
+	}
+	return fmt.Sprintf("unknown key %q in %s (will be ignored)", i.Key, i.File)
+}
+
+// issueUnknownKey is the Message of unknown-key issues.
+const issueUnknownKey = "is not a known key"
+
+// deprecatedConfigKeys maps keys that still parse but no longer have an
+// effect to a note on what replaced them.
+var deprecatedConfigKeys = map[string]string{
+	"memory.max_token_budget": "context surfacing reads surfacing.max_token_budget",
+}
+
+// ValidateConfig checks the global and vault config files for unknown keys
+// and out-of-range values. Files that fail to parse are skipped; LoadConfig
+// reports those.
+func ValidateConfig() []ConfigIssue {
+	var issues []ConfigIssue
+	if globalPath := GlobalConfigPath(); globalPath != "" {
+		if _, err := os.Stat(globalPath); err == nil {
+			issues = append(issues, validateConfigChain(globalPath)...)
+		}
+	}
+	if configPath := findConfigFile(); configPath != "" {
+		issues = append(issues, validateConfigChain(configPath)...)
+	}
+	return issues
+}
+
+// validateConfigChain validates path and every base file it includes, and
+// reports an include that is missing or forms a cycle.
+func validateConfigChain(path string) []ConfigIssue {
+	chain, problem := configIncludeChain(path)
+	var issues []ConfigIssue
+	for _, p := range chain {
+		issues = append(issues, validateConfigFile(p)...)
+	}
+	if problem != "" {
+		issues = append(issues, ConfigIssue{
+			File:    filepath.Base(chain[len(chain)-1]),
+			Key:     "include",
+			Message: problem,
+		})
+	}
+	return issues
+}
+
+func validateConfigFile(path string) []ConfigIssue {
+	var cfg Config
+	meta, err := toml.DecodeFile(path, &cfg)
+	if err != nil {
+		return nil
+	}
+	fname := filepath.Base(path)
+	issues := unknownKeyIssues(meta, fname)
+	return append(issues, rangeIssues(&cfg, meta, fname)...)
+}
+
+// DeprecatedConfigKeys returns the deprecated keys set in the global or
+// vault config file, mapped to a note on what replaced them.
+func DeprecatedConfigKeys() map[string]string {
+	found := make(map[string]string)
+	for _, path := range []string{GlobalConfigPath(), findConfigFile()} {
+		if path == "" {
+			continue
+		}
+		var cfg Config
+		meta, err := toml.DecodeFile(path, &cfg)
+		if err != nil {
+			continue
+		}
+		for key, note := range deprecatedConfigKeys {
+			if meta.IsDefined(strings.Split(key, ".")...) {
+				found[key] = note
+			}
+		}
+	}
+	return found
+}
+
+// unknownKeyIssues reports keys the decoder did not map to a Config field.
+// An unknown table is reported through its keys rather than on its own.
+func unknownKeyIssues(meta toml.MetaData, fname string) []ConfigIssue {
+	undecoded := meta.Undecoded()
+	tables := make(map[string]bool)
+	for _, key := range undecoded {
+		if len(key) > 1 {
+			tables[key[:len(key)-1].String()] = true
+		}
+	}
+	var issues []ConfigIssue
+	for _, key := range undecoded {
+		if tables[key.String()] {
+			continue
+		}
+		issues = append(issues, ConfigIssue{
+			File:       fname,
+			Key:        key.String(),
+			Message:    issueUnknownKey,
+			Suggestion: suggestConfigKey(key),
+		})
+	}
+	return issues
+}
+
+// rangeIssues checks values that LoadConfig would otherwise clamp or that
+// the runtime silently ignores. Only keys set in the file are checked.
+func rangeIssues(cfg *Config, meta toml.MetaData, fname string) []ConfigIssue {
+	var issues []ConfigIssue
+	bad := func(key, format string, args ...any) {
+		if meta.IsDefined(strings.Split(key, ".")...) {
+			issues = append(issues, ConfigIssue{File: fname, Key: key, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+	oneOf := func(key, value string, allowed ...string) {
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		bad(key, "must be one of %s", strings.Join(allowed, ", "))
+	}
+
+	if cfg.Memory.MaxResults < 1 || cfg.Memory.MaxResults > 100 {
+		bad("memory.max_results", "must be between 1 and 100")
+	}
+	if cfg.Memory.DistanceThreshold <= 0 {
+		bad("memory.distance_threshold", "must be greater than 0")
+	}
+	if cfg.Memory.CompositeThreshold < 0 || cfg.Memory.CompositeThreshold > 1 {
+		bad("memory.composite_threshold", "must be between 0 and 1")
+	}
+	if cfg.Memory.MaxPinnedTokens < 0 {
+		bad("memory.max_pinned_tokens", "must not be negative")
+	}
+	if ValidateSurfacingTokenBudget(cfg.Surfacing.MaxTokenBudget) != nil {
+		bad("surfacing.max_token_budget", "must be between %d and %d", MinSurfacingTokenBudget, MaxSurfacingTokenBudget)
+	}
+	if h := cfg.Surfacing.ConfidenceHalfLifeDays; h < 0 || math.IsNaN(h) || math.IsInf(h, 0) {
+		bad("surfacing.confidence_half_life_days", "must be 0 (off) or a positive number of days")
+	}
+	if b := cfg.Surfacing.LinkBoost; b < 0 || b > 1 || math.IsNaN(b) {
+		bad("surfacing.link_boost", "must be between 0 (off) and 1")
+	}
+	if n := cfg.Surfacing.SnippetChars; n != 0 && ValidateSnippetChars(n) != nil {
+		bad("surfacing.snippet_chars", "must be between %d and %d", MinSnippetChars, MaxSnippetChars)
+	}
+	if cfg.Surfacing.StaleAfterDays < 0 {
+		bad("surfacing.stale_after_days", "must be 0 (off) or a positive number of days")
+	}
+	for k, v := range cfg.Surfacing.Tuning {
+		key := "surfacing.tuning." + k
+		if _, known := SurfacingTuningRanges[k]; !known {
+			issues = append(issues, ConfigIssue{File: fname, Key: key, Message: issueUnknownKey,
+				Suggestion: suggestTuningKey(k)})
+			continue
+		}
+		if err := ValidateSurfacingTuning(k, v); err != nil {
+			bad(key, "%s", strings.TrimPrefix(err.Error(), key+" "))
+		}
+	}
+	if cfg.Indexer.ChunkOverlap < 0 {
+		bad("indexer.chunk_overlap", "must not be negative")
+	}
+	if n := cfg.Indexer.EmbedConcurrency; n < 1 || n > MaxEmbedConcurrency {
+		bad("indexer.embed_concurrency", "must be between 1 and %d", MaxEmbedConcurrency)
+	}
+	if cfg.Embedding.Dimensions < 0 {
+		bad("embedding.dimensions", "must not be negative")
+	}
+	if n := cfg.Embedding.Timeout; n < 0 || n > MaxRequestTimeout {
+		bad("embedding.timeout", "must be between 0 (provider default) and %d seconds", MaxRequestTimeout)
+	}
+	if n := cfg.Ask.Timeout; n < 0 || n > MaxRequestTimeout {
+		bad("ask.timeout", "must be between 0 (provider default) and %d seconds", MaxRequestTimeout)
+	}
+	if cfg.MCP.WriteRateLimit < 0 {
+		bad("mcp.write_rate_limit", "must not be negative")
+	}
+	if cfg.Hooks.HandoffMaxAgeDays < 0 {
+		bad("hooks.handoff_max_age_days", "must not be negative")
+	}
+	for _, g := range cfg.Search.SynonymGroups {
+		if len(g) < 2 {
+			bad("search.synonym_groups", "must list at least two words per group")
+			break
+		}
+	}
+	for key, p := range map[string]string{
+		"vault.handoff_dir":    cfg.Vault.HandoffDir,
+		"vault.decision_log":   cfg.Vault.DecisionLog,
+		"output.decision.path": cfg.Output.Decision.Path,
+		"output.handoff.dir":   cfg.Output.Handoff.Dir,
+	} {
+		if p != "" && !isVaultRelative(p) {
+			bad(key, "must be a relative path inside the vault")
+		}
+	}
+	oneOf("output.decision.style", cfg.Output.Decision.Style, DecisionStyleLog, DecisionStyleFiles)
+	oneOf("indexer.chunk_strategy", cfg.Indexer.ChunkStrategy, ChunkStrategyHeadings, ChunkStrategyFixed)
+	oneOf("graph.llm_mode", cfg.Graph.LLMMode, "off", "local-only", "on")
+	oneOf("ask.provider", strings.ToLower(strings.TrimSpace(cfg.Ask.Provider)), "auto", "ollama", "openai", "openai-compatible")
+	oneOf("display.mode", cfg.Display.Mode, "full", "compact", "quiet")
+	oneOf("security.injection_detection", strings.ToLower(strings.TrimSpace(cfg.Security.InjectionDetection)),
+		InjectionDetectionOff, InjectionDetectionLenient, InjectionDetectionStrict)
+	return issues
+}
+
+// isVaultRelative reports whether p is a relative path that stays inside
+// the vault once cleaned. Writers still resolve it with SafeVaultSubpath.
+func isVaultRelative(p string) bool {
+	p = filepath.ToSlash(p)
+	if filepath.IsAbs(p) || strings.HasPrefix(p, "/") || filepath.VolumeName(p) != "" {
+		return false
+	}
+	clean := path.Clean(p)
+	return clean != "." && clean != ".." && !strings.HasPrefix(clean, "../")
+}
+
+// suggestTuningKey returns the [surfacing.tuning] key most likely meant by
+// an unknown one, as a dotted key, or "" when nothing is close.
+func suggestTuningKey(name string) string {
+	candidates := make(map[string]reflect.Type, len(SurfacingTuningRanges))
+	for k := range SurfacingTuningRanges {
+		candidates[k] = nil
+	}
+	if match := nearestName(name, candidates); match != "" {
+		return "surfacing.tuning." + match
+	}
+	return ""
+}
+
+// suggestConfigKey returns the dotted key most likely meant by an unknown
+// key, or "" when nothing is close. Each segment is matched against the
+// fields valid at that level, so both "[memroy]" and "distnace_threshold"
+// are caught.
+func suggestConfigKey(key toml.Key) string {
+	t := reflect.TypeOf(Config{})
+	parts := make([]string, 0, len(key))
+	changed := false
+	for i, part := range key {
+		if t.Kind() != reflect.Struct {
+			// Map-valued tables accept any key; nothing left to check.
+			parts = append(parts, key[i:]...)
+			break
+		}
+		fields := tomlFields(t)
+		if ft, ok := fields[part]; ok {
+			parts = append(parts, part)
+			t = ft
+			continue
+		}
+		match := ""
+		if i == len(key)-1 {
+			if s, ok := configSuggestions[part]; ok {
+				if _, valid := fields[s]; valid {
+					match = s
+				}
+			}
+		}
+		if match == "" {
+			match = nearestName(part, fields)
+		}
+		if match == "" {
+			return ""
+		}
+		parts = append(parts, match)
+		t = fields[match]
+		changed = true
+	}
+	if !changed {
+		return ""
+	}
+	return strings.Join(parts, ".")
+}
+
+// tomlFields maps the TOML names of a struct's fields to their types.
+func tomlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// nearestName returns the candidate within a small edit distance of name,
+// or "" if none is close enough to be a typo.
+func nearestName(name string, candidates map[string]reflect.Type) string {
+	name = strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+	maxDist := 2
+	if len(name) >= 10 {
+		maxDist = 3
+	}
+	best, bestDist := "", maxDist+1
+	for c := range candidates {
+		if d := editDistance(name, c); d < bestDist || (d == bestDist && c < best) {
+			best, bestDist = c, d
+		}
+	}
+	if bestDist > maxDist {
+		return ""
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,222 @@
+package embedding
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Query cache defaults.
+const (
+	DefaultQueryCacheEntries = 500
+	DefaultQueryCacheTTL     = 24 * time.Hour
+)
+
+// queryCacheHeader is the size of the per-entry header: the creation time
+// as Unix nanoseconds. The float32 vector follows, little-endian.
+const queryCacheHeader = 8
+
+// QueryCache is a small on-disk LRU cache of query embeddings. Each entry is
+// one file named by the SHA-256 of (provider, model, endpoint, prompt), so
+// concurrent hook processes can share it without a lock. File mtimes track
+// recency; the header records creation time for TTL expiry.
+type QueryCache struct {
+	dir        string
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// NewQueryCache returns a cache rooted at dir. Non-positive maxEntries or ttl
+// fall back to the defaults. The directory is created on first write.
+func NewQueryCache(dir string, maxEntries int, ttl time.Duration) *QueryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultQueryCacheEntries
+	}
+	if ttl <= 0 {
+		ttl = DefaultQueryCacheTTL
+	}
+	return &QueryCache{dir: dir, maxEntries: maxEntries, ttl: ttl, now: time.Now}
+}
+
+// queryCacheKey hashes the provider identity and normalized prompt.
+// Prompts are normalized by trimming and collapsing whitespace only, since
+// case and punctuation can change the embedding. The endpoint is part of the
+// identity: two servers can serve different weights under one model name.
+func queryCacheKey(provider, model, endpoint, text string) string {
+	normalized := strings.Join(strings.Fields(text), " ")
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s", provider, model, normalizeEndpoint(endpoint), normalized)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// endpointer is implemented by providers that call a configurable base URL.
+type endpointer interface {
+	endpoint() string
+}
+
+// normalizeEndpoint lowercases the scheme and host of a base URL and drops
+// trailing slashes, so equivalent spellings share cache entries.
+func normalizeEndpoint(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return strings.TrimRight(raw, "/")
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimRight(u.Path, "/")
+	return u.String()
+}
+
+// Get returns the cached vector for key. Expired or corrupt entries are
+// removed and reported as misses.
+func (c *QueryCache) Get(key string) ([]float32, bool) {
+	path := filepath.Join(c.dir, key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	if len(data) <= queryCacheHeader || (len(data)-queryCacheHeader)%4 != 0 {
+		_ = os.Remove(path)
+		return nil, false
+	}
+	created := time.Unix(0, int64(binary.LittleEndian.Uint64(data[:queryCacheHeader])))
+	if c.now().Sub(created) > c.ttl {
+		_ = os.Remove(path)
+		return nil, false
+	}
+
+	body := data[queryCacheHeader:]
+	vec := make([]float32, len(body)/4)
+	for i := range vec {
+		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(body[i*4:]))
+	}
+
+	// Bump recency for LRU eviction.
+	now := c.now()
+	_ = os.Chtimes(path, now, now)
+	return vec, true
+}
+
+// Put stores vec under key and evicts the least recently used entries when
+// the cache is over capacity. Errors are returned but callers may ignore
+// them: the cache is an optimization only.
+func (c *QueryCache) Put(key string, vec []float32) error {
+	if len(vec) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("create query cache dir: %w", err)
+	}
+
+	buf := make([]byte, queryCacheHeader+4*len(vec))
+	binary.LittleEndian.PutUint64(buf, uint64(c.now().UnixNano()))
+	for i, v := range vec {
+		binary.LittleEndian.PutUint32(buf[queryCacheHeader+i*4:], math.Float32bits(v))
+	}
+
+	// Write to a temp file and rename so concurrent readers never see a
+	// partial entry.
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("write query cache: %w", err)
+	}
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write query cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write query cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(c.dir, key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write query cache: %w", err)
+	}
+
+	c.evict()
+	return nil
+}
+
+// evict removes the least recently used entries beyond maxEntries.
+func (c *QueryCache) evict() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	type entry struct {
+		name string
+		mod  time.Time
+	}
+	var files []entry
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, entry{e.Name(), info.ModTime()})
+	}
+	if len(files) <= c.maxEntries {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].mod.Before(files[j].mod) })
+	for _, f := range files[:len(files)-c.maxEntries] {
+		_ = os.Remove(filepath.Join(c.dir, f.name))
+	}
+}
+
+// cachedProvider wraps a Provider so query embeddings are served from a
+// QueryCache when possible. Document embeddings are never cached.
+type cachedProvider struct {
+	Provider
+	cache *QueryCache
+}
+
+// WithQueryCache returns p with GetQueryEmbedding backed by cache.
+// Returns p unchanged when either argument is nil.
+func WithQueryCache(p Provider, cache *QueryCache) Provider {
+	if p == nil || cache == nil {
+		return p
+	}
+	return &cachedProvider{Provider: p, cache: cache}
+}
+
+// GetQueryEmbedding returns a cached vector for text, or embeds and caches it.
+func (c *cachedProvider) GetQueryEmbedding(text string) ([]float32, error) {
+	endpoint := ""
+	if e, ok := c.Provider.(endpointer); ok {
+		endpoint = e.endpoint()
+	}
+	key := queryCacheKey(c.Provider.Name(), c.Provider.Model(), endpoint, text)
+	dims := c.Provider.Dimensions()
+	if vec, ok := c.cache.Get(key); ok && (dims <= 0 || len(vec) == dims) {
+		return vec, nil
+	}
+	vec, err := c.Provider.GetQueryEmbedding(text)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.cache.Put(key, vec)
+	return vec, nil
+}
+
+// GetEmbedding routes query-purpose requests through the cache.
+func (c *cachedProvider) GetEmbedding(text string, purpose string) ([]float32, error) {
+	if purpose == "query" {
+		return c.GetQueryEmbedding(text)
+	}
+	return c.Provider.GetEmbedding(text, purpose)
+}
@@ -0,0 +1,161 @@
+package embedding
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type countingProvider struct {
+	calls int
+	dims  int
+}
+
+func (p *countingProvider) GetEmbedding(text, purpose string) ([]float32, error) {
+	return p.GetQueryEmbedding(text)
+}
+func (p *countingProvider) GetDocumentEmbedding(text string) ([]float32, error) {
+	return p.GetQueryEmbedding(text)
+}
+func (p *countingProvider) GetDocumentEmbeddings(texts []string) ([][]float32, error) {
+	return nil, nil
+}
+func (p *countingProvider) GetQueryEmbedding(text string) ([]float32, error) {
+	p.calls++
+	vec := make([]float32, p.dims)
+	for i := range vec {
+		vec[i] = float32(len(text)+i) / 10
+	}
+	return vec, nil
+}
+func (p *countingProvider) Name() string    { return "fake" }
+func (p *countingProvider) Model() string   { return "fake-model" }
+func (p *countingProvider) Dimensions() int { return p.dims }
+
+// endpointProvider is a countingProvider that reports a base URL.
+type endpointProvider struct {
+	countingProvider
+	baseURL string
+}
+
+func (p *endpointProvider) endpoint() string { return p.baseURL }
+
+func TestWithQueryCache_HitsSkipProvider(t *testing.T) {
+	inner := &countingProvider{dims: 4}
+	p := WithQueryCache(inner, NewQueryCache(t.TempDir(), 10, time.Hour))
+
+	first, err := p.GetQueryEmbedding("how does auth work")
+	if err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	second, err := p.GetQueryEmbedding("  how does   auth work\n")
+	if err != nil {
+		t.Fatalf("second call: %v", err)
+	}
+	if inner.calls != 1 {
+		t.Fatalf("provider called %d times, want 1", inner.calls)
+	}
+	if len(second) != len(first) || second[3] != first[3] {
+		t.Errorf("cached vector = %v, want %v", second, first)
+	}
+
+	// Document embeddings bypass the cache.
+	if _, err := p.GetDocumentEmbedding("how does auth work"); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("document embedding should not be cached, calls = %d", inner.calls)
+	}
+}
+
+func TestWithQueryCache_KeysOnEndpoint(t *testing.T) {
+	cache := NewQueryCache(t.TempDir(), 10, time.Hour)
+	a := &endpointProvider{countingProvider: countingProvider{dims: 4}, baseURL: "http://gpu-a:8080"}
+	b := &endpointProvider{countingProvider: countingProvider{dims: 4}, baseURL: "http://gpu-b:8080"}
+
+	if _, err := WithQueryCache(a, cache).GetQueryEmbedding("q"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WithQueryCache(b, cache).GetQueryEmbedding("q"); err != nil {
+		t.Fatal(err)
+	}
+	if b.calls != 1 {
+		t.Errorf("same model on another endpoint should miss the cache, calls = %d", b.calls)
+	}
+
+	// Spelling differences in the same endpoint still share entries.
+	a2 := &endpointProvider{countingProvider: countingProvider{dims: 4}, baseURL: "HTTP://GPU-A:8080/"}
+	if _, err := WithQueryCache(a2, cache).GetQueryEmbedding("q"); err != nil {
+		t.Fatal(err)
+	}
+	if a2.calls != 0 {
+		t.Errorf("normalized endpoint should hit the cache, calls = %d", a2.calls)
+	}
+}
+
+func TestQueryCache_TTLExpiry(t *testing.T) {
+	cache := NewQueryCache(t.TempDir(), 10, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	if err := cache.Put("k", []float32{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("k"); !ok {
+		t.Fatal("expected hit before TTL")
+	}
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.Get("k"); ok {
+		t.Error("expected miss after TTL")
+	}
+}
+
+func TestQueryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewQueryCache(dir, 2, time.Hour)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	for _, key := range []string{"a", "b"} {
+		if err := cache.Put(key, []float32{1}); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(time.Second)
+	}
+	// Touch "a" so "b" becomes the least recently used.
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected hit for a")
+	}
+	now = now.Add(time.Second)
+	if err := cache.Put("c", []float32{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "b")); !os.IsNotExist(err) {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to remain cached", key)
+		}
+	}
+}
+
+func TestQueryCache_DimensionMismatchIsMiss(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewQueryCache(dir, 10, time.Hour)
+	small := &countingProvider{dims: 2}
+	if _, err := WithQueryCache(small, cache).GetQueryEmbedding("q"); err != nil {
+		t.Fatal(err)
+	}
+
+	large := &countingProvider{dims: 3}
+	vec, err := WithQueryCache(large, cache).GetQueryEmbedding("q")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vec) != 3 || large.calls != 1 {
+		t.Errorf("expected re-embed on dimension change, got len=%d calls=%d", len(vec), large.calls)
+	}
+}
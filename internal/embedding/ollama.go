@@ -53,7 +53,7 @@ func newOllamaProvider(cfg ProviderConfig) (*OllamaProvider, error) {
 	}
 
 	return &OllamaProvider{
-		httpClient: &http.Client{Timeout: 60 * time.Second},
+		httpClient: &http.Client{Timeout: requestTimeout(cfg.Timeout, 60*time.Second)},
 		baseURL:    baseURL,
 		model:      model,
 		dims:       dims,
@@ -65,6 +65,9 @@ func (p *OllamaProvider) Name() string    { return "ollama" }
 func (p *OllamaProvider) Model() string   { return p.model }
 func (p *OllamaProvider) Dimensions() int { return p.dims }
 
+// endpoint returns the base URL, which keys the query cache.
+func (p *OllamaProvider) endpoint() string { return p.baseURL }
+
 // ollamaBatchSize is the maximum number of texts per /api/embed request.
 // Keeps memory usage reasonable for large vaults.
 const ollamaBatchSize = 50
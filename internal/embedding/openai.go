@@ -76,7 +76,7 @@ func newOpenAIProvider(cfg ProviderConfig) (*OpenAIProvider, error) {
 	}
 
 	return &OpenAIProvider{
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: &http.Client{Timeout: requestTimeout(cfg.Timeout, 30*time.Second)},
 		baseURL:    baseURL,
 		model:      model,
 		apiKey:     cfg.APIKey,
@@ -89,6 +89,9 @@ func (p *OpenAIProvider) Name() string    { return p.name }
 func (p *OpenAIProvider) Model() string   { return p.model }
 func (p *OpenAIProvider) Dimensions() int { return p.dims }
 
+// endpoint returns the base URL, which keys the query cache.
+func (p *OpenAIProvider) endpoint() string { return p.baseURL }
+
 type openaiEmbeddingRequest struct {
 	Input      interface{} `json:"input"` // string for single, []string for batch
 	Model      string      `json:"model"`
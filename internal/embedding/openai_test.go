@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestNewOpenAIProvider_RequiresKeyForOpenAI(t *testing.T) {
@@ -236,3 +237,26 @@ func TestNewProvider_OpenAICompatible(t *testing.T) {
 		t.Errorf("expected name openai-compatible, got %q", p.Name())
 	}
 }
+
+func TestProviderTimeouts(t *testing.T) {
+	cfg := ProviderConfig{Provider: "openai-compatible", BaseURL: "http://localhost:8080", Model: "m"}
+	p, err := newOpenAIProvider(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.httpClient.Timeout != 30*time.Second {
+		t.Errorf("default openai timeout = %v, want 30s", p.httpClient.Timeout)
+	}
+	cfg.Timeout = 5 * time.Minute
+	if p, _ = newOpenAIProvider(cfg); p.httpClient.Timeout != 5*time.Minute {
+		t.Errorf("configured openai timeout = %v, want 5m", p.httpClient.Timeout)
+	}
+
+	o, err := newOllamaProvider(ProviderConfig{Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.httpClient.Timeout != 2*time.Second {
+		t.Errorf("configured ollama timeout = %v, want 2s", o.httpClient.Timeout)
+	}
+}
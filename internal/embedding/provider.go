@@ -10,6 +10,7 @@ package embedding
 import (
 	"fmt"
 	"math"
+	"time"
 )
 
 // Provider generates embedding vectors from text.
@@ -48,6 +49,10 @@ type ProviderConfig struct {
 	BaseURL    string // base URL (provider-specific defaults if empty)
 	Dimensions int    // vector dimensions (0 = provider default)
 
+	// Timeout bounds each embedding request. Zero uses the provider default,
+	// which is generous enough for a cold local model.
+	Timeout time.Duration
+
 	// SkipRetry disables connection retries for providers that support them.
 	// When true, network errors fail immediately instead of retrying.
 	// Used to avoid 6-second retry delays when the user hasn't configured
@@ -100,3 +105,11 @@ func validateEmbedding(vec []float32, expectedDims int) error {
 	}
 	return nil
 }
+
+// requestTimeout returns timeout, or def when it is unset.
+func requestTimeout(timeout, def time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	return def
+}
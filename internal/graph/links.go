@@ -0,0 +1,206 @@
+package graph
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// [[target]], [[target#heading]], [[target|alias]]
+	reWikiLink = regexp.MustCompile(`\[\[([^\[\]|#\n]+)(?:#[^\[\]|\n]*)?(?:\|[^\[\]\n]*)?\]\]`)
+
+	// [text](target) and [text](target "title"); images are excluded by the caller.
+	reMarkdownLink = regexp.MustCompile(`(!?)\[[^\]\n]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"\n]*")?\s*\)`)
+
+	// Fenced code blocks are skipped so example links don't become edges.
+	reFencedCode = regexp.MustCompile("(?s)```.*?```")
+)
+
+// NoteLink is an explicit link written in a note, before resolution.
+type NoteLink struct {
+	Target string // note name for wikilinks, link path for Markdown links
+	Wiki   bool
+}
+
+// ParseNoteLinks returns the [[wikilinks]] and Markdown links to other notes
+// in content, in order of first appearance and without duplicates. URLs,
+// in-page anchors, images, and links to non-Markdown files are skipped.
+func ParseNoteLinks(content string) []NoteLink {
+	content = reFencedCode.ReplaceAllString(content, "")
+	seen := make(map[NoteLink]bool)
+	var links []NoteLink
+	add := func(l NoteLink) {
+		if l.Target == "" || seen[l] {
+			return
+		}
+		seen[l] = true
+		links = append(links, l)
+	}
+
+	for _, m := range reWikiLink.FindAllStringSubmatch(content, -1) {
+		add(NoteLink{Target: strings.TrimSpace(m[1]), Wiki: true})
+	}
+	for _, m := range reMarkdownLink.FindAllStringSubmatch(content, -1) {
+		if m[1] == "!" {
+			continue
+		}
+		target := m[2]
+		if isLikelyURLReference(target) || strings.Contains(target, ":") || strings.HasPrefix(target, "#") {
+			continue
+		}
+		if i := strings.IndexAny(target, "#?"); i >= 0 {
+			target = target[:i]
+		}
+		if unescaped, err := url.PathUnescape(target); err == nil {
+			target = unescaped
+		}
+		if ext := path.Ext(target); ext != "" && !strings.EqualFold(ext, ".md") {
+			continue
+		}
+		add(NoteLink{Target: strings.ReplaceAll(target, "\\", "/")})
+	}
+	return links
+}
+
+// LinkResolver maps parsed links to the vault-relative paths of indexed
+// notes.
+type LinkResolver struct {
+	paths map[string]string // lowercased path -> path
+	names map[string]string // lowercased base name or title -> path
+}
+
+// NewLinkResolver returns an empty resolver; register notes with Add.
+func NewLinkResolver() *LinkResolver {
+	return &LinkResolver{paths: make(map[string]string), names: make(map[string]string)}
+}
+
+// Add registers a note. When two notes share a base name or title, the
+// first one added wins for wikilinks.
+func (r *LinkResolver) Add(notePath, title string) {
+	r.paths[strings.ToLower(notePath)] = notePath
+	base := strings.ToLower(strings.TrimSuffix(path.Base(notePath), path.Ext(notePath)))
+	if _, ok := r.names[base]; !ok {
+		r.names[base] = notePath
+	}
+	if t := strings.ToLower(strings.TrimSpace(title)); t != "" {
+		if _, ok := r.names[t]; !ok {
+			r.names[t] = notePath
+		}
+	}
+}
+
+// Resolve returns the note a link written in from points to. Markdown links
+// are resolved against the linking note's directory, then the vault root.
+// Wikilinks match a note path, then a file base name, then a title, all
+// case-insensitively.
+func (r *LinkResolver) Resolve(from string, link NoteLink) (string, bool) {
+	target := link.Target
+	if link.Wiki {
+		key := strings.ToLower(target)
+		if p, ok := r.lookupPath(key); ok {
+			return p, true
+		}
+		p, ok := r.names[key]
+		return p, ok
+	}
+
+	if strings.HasPrefix(target, "/") {
+		return r.lookupPath(strings.ToLower(path.Clean(strings.TrimPrefix(target, "/"))))
+	}
+	rel := path.Clean(path.Join(path.Dir(from), target))
+	if p, ok := r.lookupPath(strings.ToLower(rel)); ok {
+		return p, true
+	}
+	return r.lookupPath(strings.ToLower(path.Clean(target)))
+}
+
+// lookupPath matches a lowercased path with or without its .md extension.
+func (r *LinkResolver) lookupPath(key string) (string, bool) {
+	if key == "." || key == ".." || strings.HasPrefix(key, "../") {
+		return "", false
+	}
+	if p, ok := r.paths[key]; ok {
+		return p, true
+	}
+	p, ok := r.paths[key+".md"]
+	return p, ok
+}
+
+// RetargetLinks rewrites the links in content, written in the note at from,
+// that resolve to oldPath so they point at newPath instead. Wikilinks keep
+// their #heading and |alias; Markdown links keep their #anchor and become
+// relative to from. Links in fenced code blocks are left alone. Returns the
+// new content and the number of links rewritten.
+func (r *LinkResolver) RetargetLinks(content, from, oldPath, newPath string) (string, int) {
+	wikiTarget := strings.TrimSuffix(newPath, path.Ext(newPath))
+	if base := path.Base(wikiTarget); r.names[strings.ToLower(base)] == newPath {
+		wikiTarget = base
+	}
+	mdTarget := strings.ReplaceAll(relativeLink(from, newPath), " ", "%20")
+
+	n := 0
+	rewrite := func(text string) string {
+		text = reWikiLink.ReplaceAllStringFunc(text, func(m string) string {
+			sub := reWikiLink.FindStringSubmatch(m)
+			target := strings.TrimSpace(sub[1])
+			if p, ok := r.Resolve(from, NoteLink{Target: target, Wiki: true}); !ok || p != oldPath {
+				return m
+			}
+			n++
+			return "[[" + wikiTarget + m[2+len(sub[1]):]
+		})
+		return reMarkdownLink.ReplaceAllStringFunc(text, func(m string) string {
+			sub := reMarkdownLink.FindStringSubmatch(m)
+			if sub[1] == "!" {
+				return m
+			}
+			links := ParseNoteLinks(m)
+			if len(links) != 1 {
+				return m
+			}
+			if p, ok := r.Resolve(from, links[0]); !ok || p != oldPath {
+				return m
+			}
+			target := sub[2]
+			suffix := ""
+			if i := strings.Index(target, "#"); i >= 0 {
+				suffix = target[i:]
+			}
+			n++
+			i := strings.Index(m, "](")
+			return m[:i] + strings.Replace(m[i:], target, mdTarget+suffix, 1)
+		})
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range reFencedCode.FindAllStringIndex(content, -1) {
+		b.WriteString(rewrite(content[last:loc[0]]))
+		b.WriteString(content[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(rewrite(content[last:]))
+	return b.String(), n
+}
+
+// relativeLink returns the Markdown link from the note at from to the note
+// at to, both vault-relative.
+func relativeLink(from, to string) string {
+	fromParts := strings.Split(path.Dir(from), "/")
+	if fromParts[0] == "." {
+		fromParts = nil
+	}
+	toParts := strings.Split(to, "/")
+	i := 0
+	for i < len(fromParts) && i < len(toParts)-1 && fromParts[i] == toParts[i] {
+		i++
+	}
+	parts := make([]string, 0, len(fromParts)-i+len(toParts)-i)
+	for range fromParts[i:] {
+		parts = append(parts, "..")
+	}
+	parts = append(parts, toParts[i:]...)
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNoteLinks(t *testing.T) {
+	content := "See [[Auth Design]] and [[auth design|again]], [[roadmap#Q3]].\n" +
+		"Details in [the plan](../plans/q3%20plan.md#scope) and [spec](spec).\n" +
+		"Skip [site](https://example.com), [anchor](#top), ![img](diagram.md), [pdf](doc.pdf).\n" +
+		"```\n[[in-code]] [x](code.md)\n```\n"
+
+	got := ParseNoteLinks(content)
+	want := []NoteLink{
+		{Target: "Auth Design", Wiki: true},
+		{Target: "auth design", Wiki: true},
+		{Target: "roadmap", Wiki: true},
+		{Target: "../plans/q3 plan.md"},
+		{Target: "spec"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseNoteLinks =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestLinkResolver_Resolve(t *testing.T) {
+	r := NewLinkResolver()
+	r.Add("notes/auth-design.md", "Auth Design")
+	r.Add("plans/q3 plan.md", "Q3 Plan")
+	r.Add("notes/spec.md", "Spec")
+	r.Add("roadmap.md", "Roadmap")
+
+	tests := []struct {
+		link NoteLink
+		want string
+	}{
+		{NoteLink{Target: "Auth Design", Wiki: true}, "notes/auth-design.md"},
+		{NoteLink{Target: "auth-design", Wiki: true}, "notes/auth-design.md"},
+		{NoteLink{Target: "notes/spec", Wiki: true}, "notes/spec.md"},
+		{NoteLink{Target: "../plans/q3 plan.md"}, "plans/q3 plan.md"},
+		{NoteLink{Target: "spec"}, "notes/spec.md"},
+		{NoteLink{Target: "roadmap.md"}, "roadmap.md"},
+		{NoteLink{Target: "/roadmap.md"}, "roadmap.md"},
+	}
+	for _, tt := range tests {
+		got, ok := r.Resolve("notes/index.md", tt.link)
+		if !ok || got != tt.want {
+			t.Errorf("Resolve(%+v) = %q, %v; want %q", tt.link, got, ok, tt.want)
+		}
+	}
+	if got, ok := r.Resolve("notes/index.md", NoteLink{Target: "../../outside.md"}); ok {
+		t.Errorf("link outside the vault resolved to %q", got)
+	}
+	if _, ok := r.Resolve("notes/index.md", NoteLink{Target: "missing", Wiki: true}); ok {
+		t.Error("unknown wikilink should not resolve")
+	}
+}
+
+func TestLinkResolver_RetargetLinks(t *testing.T) {
+	r := NewLinkResolver()
+	r.Add("notes/auth-old.md", "Old Auth")
+	r.Add("notes/auth-design.md", "Auth Design")
+	r.Add("plans/q3 plan.md", "Q3 Plan")
+
+	content := "See [[Old Auth]], [[auth-old#Tokens|tokens]] and [[Auth Design]].\n" +
+		"Also [old](../notes/auth-old.md#scope) and [plan](q3%20plan.md).\n" +
+		"```\n[[auth-old]]\n```\n"
+	got, n := r.RetargetLinks(content, "plans/index.md", "notes/auth-old.md", "notes/auth-design.md")
+	want := "See [[auth-design]], [[auth-design#Tokens|tokens]] and [[Auth Design]].\n" +
+		"Also [old](../notes/auth-design.md#scope) and [plan](q3%20plan.md).\n" +
+		"```\n[[auth-old]]\n```\n"
+	if got != want || n != 3 {
+		t.Fatalf("RetargetLinks = %d\n%s\nwant 3\n%s", n, got, want)
+	}
+
+	got, n = r.RetargetLinks("[[Q3 Plan]]", "notes/index.md", "plans/q3 plan.md", "notes/auth-design.md")
+	if got != "[[auth-design]]" || n != 1 {
+		t.Errorf("RetargetLinks = %q, %d", got, n)
+	}
+	got, _ = r.RetargetLinks("[x](auth-old.md)", "notes/index.md", "notes/auth-old.md", "plans/q3 plan.md")
+	if got != "[x](../plans/q3%20plan.md)" {
+		t.Errorf("RetargetLinks markdown = %q", got)
+	}
+}
@@ -48,3 +48,11 @@ func IsPathAllowed(filePath string, customPaths []string) bool {
 	}
 	return false
 }
+
+// AllowedPaths returns the default allowlist followed by customPaths.
+// Entries ending in "/" allow everything under that directory.
+func AllowedPaths(customPaths []string) []string {
+	out := make([]string, 0, len(defaultAllowedPaths)+len(customPaths))
+	out = append(out, defaultAllowedPaths...)
+	return append(out, customPaths...)
+}
@@ -1,7 +1,9 @@
 package guard
 
 import (
+	"bufio"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"time"
@@ -52,3 +54,31 @@ func AppendAudit(vaultPath string, entry AuditEntry) error {
 	}
 	return nil
 }
+
+// ReadAudit returns the last limit entries of the audit log, oldest first.
+// A missing log yields no entries; malformed lines are skipped.
+func ReadAudit(vaultPath string, limit int) ([]AuditEntry, error) {
+	f, err := os.Open(auditLogPath(vaultPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var e AuditEntry
+		if json.Unmarshal(sc.Bytes(), &e) != nil {
+			continue
+		}
+		entries = append(entries, e)
+		if limit > 0 && len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+	return entries, sc.Err()
+}
@@ -15,6 +15,7 @@ type Scanner struct {
 	CustomPaths   []string // additional allowed paths
 	ContentReader ContentReader
 	Config        GuardConfig
+	NoAudit       bool // preview scans ('same guard test') leave the audit log alone
 	patterns      []Pattern
 	blockTerms    []CompiledTerm
 	reviewed      *ReviewedTerms
@@ -38,6 +39,16 @@ func NewScannerWithConfig(vaultPath string, cfg GuardConfig) (*Scanner, error) {
 		patterns = nil // guard disabled entirely
 	}
 
+	if cfg.Enabled {
+		for _, cp := range cfg.CustomPatterns {
+			p, err := cp.Compile()
+			if err != nil {
+				return nil, err
+			}
+			patterns = append(patterns, p)
+		}
+	}
+
 	s := &Scanner{
 		VaultPath:     vaultPath,
 		Config:        cfg,
@@ -113,12 +124,14 @@ func (s *Scanner) ScanFiles(files []string) (*ScanResult, error) {
 			checkLen = 8192
 		}
 		if checkLen > 0 && bytes.ContainsRune(content[:checkLen], 0) {
-			_ = AppendAudit(s.VaultPath, AuditEntry{
-				Action:     "scan_skip_binary",
-				FilesCount: 1,
-				Passed:     true,
-				Details:    file,
-			})
+			if !s.NoAudit {
+				_ = AppendAudit(s.VaultPath, AuditEntry{
+					Action:     "scan_skip_binary",
+					FilesCount: 1,
+					Passed:     true,
+					Details:    file,
+				})
+			}
 			continue
 		}
 
@@ -126,16 +139,29 @@ func (s *Scanner) ScanFiles(files []string) (*ScanResult, error) {
 	}
 
 	// Audit the scan
-	_ = AppendAudit(s.VaultPath, AuditEntry{
-		Action:     "scan",
-		FilesCount: len(files),
-		Passed:     result.Passed,
-		Violations: len(result.Violations) + len(result.PathViolations),
-	})
+	if !s.NoAudit {
+		_ = AppendAudit(s.VaultPath, AuditEntry{
+			Action:     "scan",
+			FilesCount: len(files),
+			Passed:     result.Passed,
+			Violations: len(result.Violations) + len(result.PathViolations),
+		})
+	}
 
 	return result, nil
 }
 
+// Patterns returns the PII and credential patterns this scanner enforces,
+// after the guard settings are applied.
+func (s *Scanner) Patterns() []Pattern {
+	return s.patterns
+}
+
+// BlockTerms returns the blocklist terms this scanner enforces.
+func (s *Scanner) BlockTerms() []CompiledTerm {
+	return s.blockTerms
+}
+
 // scanContent scans file content line-by-line for blocklist and PII violations.
 func (s *Scanner) scanContent(file, text string, result *ScanResult) {
 	lines := strings.Split(text, "\n")
@@ -600,6 +600,8 @@ func TestCategoryLabel(t *testing.T) {
 		{CatHardBlock, "A blocklisted term"},
 		{CatSoftBlock, "A blocklisted term (soft)"},
 		{CatPathBlock, "Not in allowed directories"},
+		{CatEntropy, "A likely secret (random-looking string)"},
+		{CatCustom, "A match for a custom pattern"},
 	}
 	for _, tt := range tests {
 		got := CategoryLabel(tt.cat)
@@ -648,6 +650,68 @@ func TestLoadLastScan_NotFound(t *testing.T) {
 	}
 }
 
+func TestReadAudit(t *testing.T) {
+	dir := t.TempDir()
+	if entries, err := ReadAudit(dir, 5); err != nil || entries != nil {
+		t.Fatalf("missing log: entries=%v err=%v, want none", entries, err)
+	}
+
+	for i := 1; i <= 4; i++ {
+		if err := AppendAudit(dir, AuditEntry{Action: "scan", FilesCount: i, Passed: i%2 == 0}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := os.OpenFile(auditLogPath(dir), os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("not json\n")
+	_ = f.Close()
+
+	entries, err := ReadAudit(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].FilesCount != 3 || entries[1].FilesCount != 4 {
+		t.Errorf("entries = %+v, want the last two scans oldest first", entries)
+	}
+}
+
+func TestScanFiles_NoAudit(t *testing.T) {
+	dir := t.TempDir()
+	s := &Scanner{
+		VaultPath: dir,
+		Config:    DefaultGuardConfig(),
+		NoAudit:   true,
+		patterns:  builtinPatterns(),
+		reviewed:  &ReviewedTerms{},
+		ContentReader: func(file string) ([]byte, error) {
+			return []byte("contact: someone@realcompany.io\n"), nil
+		},
+	}
+	result, err := s.ScanFiles([]string{".scripts/notes.sh"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Passed {
+		t.Error("expected the email to block")
+	}
+	if _, err := os.Stat(auditLogPath(dir)); !os.IsNotExist(err) {
+		t.Errorf("preview scan wrote the audit log (stat err = %v)", err)
+	}
+}
+
+func TestAllowedPaths(t *testing.T) {
+	paths := AllowedPaths([]string{"docs/"})
+	if len(paths) != len(defaultAllowedPaths)+1 || paths[len(paths)-1] != "docs/" {
+		t.Errorf("AllowedPaths = %v, want defaults plus docs/", paths)
+	}
+	paths[0] = "changed"
+	if defaultAllowedPaths[0] == "changed" {
+		t.Error("AllowedPaths must not alias the default list")
+	}
+}
+
 // helper
 func contains(s, substr string) bool {
 	return len(s) > 0 && len(substr) > 0 && (s == substr || len(s) > len(substr) && containsImpl(s, substr))
@@ -105,6 +105,22 @@ func CategoryLabel(cat Category) string {
 		return "An AWS access key"
 	case CatPrivateKey:
 		return "A private key"
+	case CatCloudCred:
+		return "A cloud credential"
+	case CatGitToken:
+		return "A git platform token"
+	case CatPayment:
+		return "A payment credential"
+	case CatComms:
+		return "A messaging service token"
+	case CatDevToken:
+		return "A developer token"
+	case CatObserve:
+		return "A monitoring service key"
+	case CatEntropy:
+		return "A likely secret (random-looking string)"
+	case CatCustom:
+		return "A match for a custom pattern"
 	case CatHardBlock:
 		return "A blocklisted term"
 	case CatSoftBlock:
@@ -131,6 +147,7 @@ func (r *ScanResult) FormatFriendly() string {
 	if len(r.Violations) > 0 || len(r.PathViolations) > 0 {
 		// Describe what was found
 		hasPII := false
+		hasSecret := false
 		hasBlock := false
 		hasPath := false
 		for _, v := range r.Violations {
@@ -139,6 +156,8 @@ func (r *ScanResult) FormatFriendly() string {
 				hasPII = true
 			case v.Category == CatHardBlock || v.Category == CatSoftBlock:
 				hasBlock = true
+			default:
+				hasSecret = true
 			}
 		}
 		if len(r.PathViolations) > 0 {
@@ -148,6 +167,8 @@ func (r *ScanResult) FormatFriendly() string {
 		b.WriteString("\n")
 		if hasPII {
 			b.WriteString("  I found something that looks like personal info:\n\n")
+		} else if hasSecret {
+			b.WriteString("  I found something that looks like a secret:\n\n")
 		} else if hasBlock {
 			b.WriteString("  I found blocklisted content:\n\n")
 		} else if hasPath {
@@ -2,6 +2,8 @@
 package guard
 
 import (
+	"fmt"
+	"math"
 	"regexp"
 	"strings"
 )
@@ -31,6 +33,8 @@ const (
 	CatComms      Category = "comms_credential"
 	CatDevToken   Category = "dev_token"
 	CatObserve    Category = "observability_credential"
+	CatEntropy    Category = "high_entropy_string"
+	CatCustom     Category = "custom_pattern"
 	CatHardBlock  Category = "hard_blocklist"
 	CatSoftBlock  Category = "soft_blocklist"
 	CatPathBlock  Category = "path_violation"
@@ -42,6 +46,10 @@ type Pattern struct {
 	Tier     Tier
 	Category Category
 	Regex    *regexp.Regexp
+	// Check, when set, must also accept a regex match for it to count.
+	// Heuristic patterns use it; their matches are dropped when a regular
+	// pattern already flagged the same text.
+	Check func(match string) bool
 }
 
 // builtinPatterns returns the compiled set of PII regexes.
@@ -190,16 +198,93 @@ func credentialPatterns() []Pattern {
 	}
 }
 
-// AllPatterns returns the complete set of patterns — both PII and credential.
+// minEntropyBits is the Shannon entropy (bits per character) above which a
+// long token is treated as a likely secret. Random base64/base62 keys score
+// about 4.5-5.5; hex digests top out at 4.0 and prose-like identifiers
+// stay well below.
+const minEntropyBits = 4.3
+
+// heuristicPatterns returns patterns that catch secrets without a known
+// prefix. They are soft-tier: likely, not certain.
+func heuristicPatterns() []Pattern {
+	return []Pattern{
+		{Name: "high_entropy", Tier: TierSoft, Category: CatEntropy,
+			Regex: regexp.MustCompile(`[A-Za-z0-9+/_\-]{32,}={0,2}`),
+			Check: isHighEntropySecret},
+	}
+}
+
+// isHighEntropySecret reports whether s looks like a random token: mixed
+// letters and digits with high per-character entropy.
+func isHighEntropySecret(s string) bool {
+	s = strings.TrimRight(s, "=")
+	if !strings.ContainsAny(s, "0123456789") || strings.IndexFunc(s, func(r rune) bool {
+		return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+	}) < 0 {
+		return false
+	}
+	return shannonEntropy(s) >= minEntropyBits
+}
+
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	n := float64(len([]rune(s)))
+	var bits float64
+	for _, c := range counts {
+		p := float64(c) / n
+		bits -= p * math.Log2(p)
+	}
+	return bits
+}
+
+// CustomPattern is a user-defined secret pattern from the guard config.
+type CustomPattern struct {
+	Name  string `json:"name"`
+	Regex string `json:"regex"`
+	Tier  Tier   `json:"tier,omitempty"` // "hard" (default) or "soft"
+}
+
+// Compile validates the custom pattern and compiles it.
+func (c CustomPattern) Compile() (Pattern, error) {
+	if strings.TrimSpace(c.Name) == "" {
+		return Pattern{}, fmt.Errorf("custom pattern needs a name")
+	}
+	tier := c.Tier
+	switch tier {
+	case "":
+		tier = TierHard
+	case TierHard, TierSoft:
+	default:
+		return Pattern{}, fmt.Errorf("custom pattern %q: tier must be 'hard' or 'soft', got %q", c.Name, c.Tier)
+	}
+	re, err := regexp.Compile(c.Regex)
+	if err != nil {
+		return Pattern{}, fmt.Errorf("custom pattern %q: %w", c.Name, err)
+	}
+	if re.MatchString("") {
+		return Pattern{}, fmt.Errorf("custom pattern %q matches empty text", c.Name)
+	}
+	return Pattern{Name: "custom:" + c.Name, Tier: tier, Category: CatCustom, Regex: re}, nil
+}
+
+// AllPatterns returns the complete set of built-in patterns — PII,
+// credential, and heuristic.
 func AllPatterns() []Pattern {
-	return append(builtinPatterns(), credentialPatterns()...)
+	patterns := append(builtinPatterns(), credentialPatterns()...)
+	return append(patterns, heuristicPatterns()...)
 }
 
-// ScanContent checks text content against all patterns (PII + credentials).
+// ScanContent checks text content against the PII and credential patterns.
 // Returns results for each match found. Used by MCP save_note to warn on
 // credential content.
 func ScanContent(content string) []ScanLineResult {
-	patterns := AllPatterns()
+	patterns := append(builtinPatterns(), credentialPatterns()...)
 	var results []ScanLineResult
 	for _, line := range strings.Split(content, "\n") {
 		lineResults := scanLine(line, "", patterns)
@@ -238,6 +323,12 @@ var falsePositivePatterns = []string{
 	"test@test.com",
 }
 
+// FalsePositiveMatches returns the substrings that mark a match as a known
+// placeholder (example.com addresses, zeroed SSNs) rather than real data.
+func FalsePositiveMatches() []string {
+	return append([]string(nil), falsePositivePatterns...)
+}
+
 // isFalsePositiveMatch checks whether a specific PII match is a known false positive.
 // SECURITY: This checks the match itself, NOT the whole line. A real token on a line
 // containing "test" will still be flagged — only the specific match is evaluated.
@@ -295,6 +386,9 @@ func scanLine(line string, filePath string, patterns []Pattern) []ScanLineResult
 			if isFalsePositiveMatch(m) {
 				continue
 			}
+			if p.Check != nil && (!p.Check(m) || overlapsResult(results, m)) {
+				continue
+			}
 			results = append(results, ScanLineResult{
 				Pattern:  p,
 				Match:    m,
@@ -305,6 +399,16 @@ func scanLine(line string, filePath string, patterns []Pattern) []ScanLineResult
 	return results
 }
 
+// overlapsResult reports whether m is part of (or contains) an earlier match.
+func overlapsResult(results []ScanLineResult, m string) bool {
+	for _, r := range results {
+		if strings.Contains(r.Match, m) || strings.Contains(m, r.Match) {
+			return true
+		}
+	}
+	return false
+}
+
 // redact partially obscures a matched string for safe display.
 func redact(s string) string {
 	if len(s) <= 6 {
@@ -16,6 +16,9 @@ type GuardConfig struct {
 	PathFilter  ToggleBlock    `json:"path_filter"`
 	SoftMode    string         `json:"soft_mode"` // "block" or "warn"
 	PushProtect PushProtectCfg `json:"push_protect"`
+	// CustomPatterns extend the built-in secret patterns. They apply
+	// whenever guard is enabled.
+	CustomPatterns []CustomPattern `json:"custom_patterns,omitempty"`
 }
 
 // PushProtectCfg controls push protection (requires same push-allow before git push).
@@ -39,6 +42,10 @@ type PIIPatterns struct {
 	APIKey     bool `json:"api_key"`
 	AWSKey     bool `json:"aws_key"`
 	PrivateKey bool `json:"private_key"`
+	// Credentials covers provider-specific tokens (cloud, git, payment...).
+	Credentials bool `json:"credentials"`
+	// Entropy flags long random-looking strings that match no known format.
+	Entropy bool `json:"entropy"`
 }
 
 // ToggleBlock is a simple enabled toggle for a feature group.
@@ -53,13 +60,15 @@ func DefaultGuardConfig() GuardConfig {
 		PII: PIIConfig{
 			Enabled: true,
 			Patterns: PIIPatterns{
-				Email:      true,
-				Phone:      true,
-				SSN:        true,
-				LocalPath:  true,
-				APIKey:     true,
-				AWSKey:     true,
-				PrivateKey: true,
+				Email:       true,
+				Phone:       true,
+				SSN:         true,
+				LocalPath:   true,
+				APIKey:      true,
+				AWSKey:      true,
+				PrivateKey:  true,
+				Credentials: true,
+				Entropy:     true,
 			},
 		},
 		Blocklist:   ToggleBlock{Enabled: true},
@@ -78,6 +87,16 @@ var userFacingKeyToPatternNames = map[string][]string{
 	"api_key":     {"api_key_assignment", "sk_key", "github_token", "slack_token"},
 	"aws_key":     {"aws_key"},
 	"private_key": {"private_key_header"},
+	"credentials": patternNames(credentialPatterns()),
+	"entropy":     patternNames(heuristicPatterns()),
+}
+
+func patternNames(patterns []Pattern) []string {
+	names := make([]string, len(patterns))
+	for i, p := range patterns {
+		names[i] = p.Name
+	}
+	return names
 }
 
 // EnabledPatternNames returns the set of internal pattern names that are enabled.
@@ -103,6 +122,8 @@ func (c *GuardConfig) EnabledPatternNames() map[string]bool {
 	addIfEnabled(pats.APIKey, "api_key")
 	addIfEnabled(pats.AWSKey, "aws_key")
 	addIfEnabled(pats.PrivateKey, "private_key")
+	addIfEnabled(pats.Credentials, "credentials")
+	addIfEnabled(pats.Entropy, "entropy")
 
 	return enabled
 }
@@ -140,6 +161,10 @@ func (c *GuardConfig) SetKey(key, value string) error {
 		c.PII.Patterns.AWSKey = boolVal
 	case "private_key":
 		c.PII.Patterns.PrivateKey = boolVal
+	case "credentials":
+		c.PII.Patterns.Credentials = boolVal
+	case "entropy":
+		c.PII.Patterns.Entropy = boolVal
 	case "push-protect", "push_protect":
 		c.PushProtect.Enabled = boolVal
 	case "push-timeout", "push_timeout":
@@ -157,6 +182,33 @@ func (c *GuardConfig) SetKey(key, value string) error {
 	return nil
 }
 
+// AddCustomPattern adds or replaces the custom pattern with p's name.
+func (c *GuardConfig) AddCustomPattern(p CustomPattern) error {
+	if _, err := p.Compile(); err != nil {
+		return err
+	}
+	for i, existing := range c.CustomPatterns {
+		if existing.Name == p.Name {
+			c.CustomPatterns[i] = p
+			return nil
+		}
+	}
+	c.CustomPatterns = append(c.CustomPatterns, p)
+	return nil
+}
+
+// RemoveCustomPattern removes the named custom pattern, reporting whether
+// it existed.
+func (c *GuardConfig) RemoveCustomPattern(name string) bool {
+	for i, p := range c.CustomPatterns {
+		if p.Name == name {
+			c.CustomPatterns = append(c.CustomPatterns[:i], c.CustomPatterns[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // guardConfigPath returns the path to the user-level guard config.
 func guardConfigPath() string {
 	home, _ := os.UserHomeDir()
@@ -170,13 +222,15 @@ type configFile struct {
 }
 
 // LoadGuardConfig loads the guard config from ~/.config/same/config.json.
-// Returns defaults if file doesn't exist or guard key is absent.
+// Returns defaults if file doesn't exist or guard key is absent. Settings
+// missing from an older file keep their defaults.
 func LoadGuardConfig() GuardConfig {
 	data, err := os.ReadFile(guardConfigPath())
 	if err != nil {
 		return DefaultGuardConfig()
 	}
-	var cfg configFile
+	def := DefaultGuardConfig()
+	cfg := configFile{Guard: &def}
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return DefaultGuardConfig()
 	}
@@ -170,6 +170,69 @@ func TestGuardConfig_SaveLoadRoundTrip(t *testing.T) {
 	}
 }
 
+func TestLoadGuardConfig_MissingKeysKeepDefaults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".config", "same", "config.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	// A config saved before credentials/entropy existed.
+	old := `{"guard": {"enabled": true, "pii": {"enabled": true, "patterns": {"email": false, "api_key": true}}, "soft_mode": "warn"}}`
+	if err := os.WriteFile(path, []byte(old), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := LoadGuardConfig()
+	if cfg.PII.Patterns.Email || !cfg.PII.Patterns.APIKey || cfg.SoftMode != "warn" {
+		t.Errorf("saved settings not honored: %+v", cfg)
+	}
+	if !cfg.PII.Patterns.Credentials || !cfg.PII.Patterns.Entropy {
+		t.Error("new checks should default on for older configs")
+	}
+}
+
+func TestGuardConfig_CustomPatterns(t *testing.T) {
+	cfg := DefaultGuardConfig()
+	for _, bad := range []CustomPattern{
+		{Name: "", Regex: "x+"},
+		{Name: "paren", Regex: "("},
+		{Name: "empty", Regex: "a*"},
+		{Name: "tier", Regex: "x+", Tier: "medium"},
+	} {
+		if err := cfg.AddCustomPattern(bad); err == nil {
+			t.Errorf("AddCustomPattern(%+v) should fail", bad)
+		}
+	}
+	if err := cfg.AddCustomPattern(CustomPattern{Name: "acme", Regex: `acme_[a-z0-9]{8}`}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.AddCustomPattern(CustomPattern{Name: "acme", Regex: `acme-[a-z0-9]{8}`, Tier: TierSoft}); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.CustomPatterns) != 1 || cfg.CustomPatterns[0].Tier != TierSoft {
+		t.Errorf("re-adding a name should replace it: %+v", cfg.CustomPatterns)
+	}
+
+	s, err := NewScannerWithConfig(t.TempDir(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, p := range s.Patterns() {
+		if p.Name == "custom:acme" && p.Tier == TierSoft && p.Category == CatCustom {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("scanner should include the custom pattern")
+	}
+
+	if !cfg.RemoveCustomPattern("acme") || cfg.RemoveCustomPattern("acme") {
+		t.Error("RemoveCustomPattern should remove once")
+	}
+}
+
 func TestFilterByConfig_AllEnabled(t *testing.T) {
 	cfg := DefaultGuardConfig()
 	patterns := builtinPatterns()
@@ -13,24 +13,30 @@ import (
 )
 
 const (
-	minPromptChars   = 20
-	maxResults       = 3 // data shows expected notes often land at #3; sweep confirmed no precision loss
-	maxSnippetChars  = 400
+	minPromptChars = 20
+	// maxPerNoteTokens caps any single note's contribution to the token budget.
+	// Prevents a large note from consuming the entire budget and crowding out
+	// other relevant results. At 400 tokens (~1600 chars), even a 10K-char
+	// note will leave room for 1-2 more results within the default 800 token
+	// budget. Smaller budgets (surfacing.max_token_budget) lower it to half
+	// the budget.
+	maxPerNoteTokens = 400
+)
+
+// Surfacing thresholds. These are variables only so [surfacing.tuning] can
+// override them for experimentation (see surfacing_tuning.go); the values
+// here are the defaults and are restored at the start of every run.
+var (
+	maxResults       = 3     // data shows expected notes often land at #3; sweep confirmed no precision loss
 	maxDistance      = 16.3  // L2 distance; relaxed from 16.0→16.2→16.3 — matches within this range are relevant; off-topic > 16.8
 	minComposite     = 0.70  // composite threshold; distance gate handles negative discrimination
 	minSemanticFloor = 0.25  // absolute floor: if semantic score < this, skip regardless of boost
-	maxTokenBudget   = 800   // tightened from 1000; less context waste
 	minTitleOverlap  = 0.10  // bidirectional overlap threshold for title matching
 	highTierOverlap  = 0.199 // effective 0.20 with floating point margin (e.g., 3/5*3/9 = 0.19999...)
-	// maxPerNoteTokens caps any single note's contribution to the token budget.
-	// Prevents a large note from consuming the entire budget and crowding out
-	// other relevant results. At 400 tokens (~1600 chars), even a 10K-char
-	// note will leave room for 1-2 more results within the 800 token budget.
-	maxPerNoteTokens = 400
 )
 
 // Recency-aware weights: when query has recency intent, shift weight heavily to recency.
-const (
+var (
 	recencyRelWeight    = 0.1
 	recencyRecWeight    = 0.7
 	recencyConfWeight   = 0.2
@@ -52,6 +58,19 @@ func noisyPathPrefixes() []string {
 	return config.NoisePaths()
 }
 
+// surfacingPathWeights returns the user-configured [surfacing] path_weights.
+func surfacingPathWeights() map[string]float64 {
+	return config.SurfacingPathWeights()
+}
+
+func surfacingLinkBoost() float64 {
+	return config.SurfacingLinkBoost()
+}
+
+// snippetChars caps each surfaced snippet. Set from [surfacing]
+// snippet_chars at the start of each context surfacing run.
+var snippetChars = config.DefaultSurfacingSnippetChars
+
 type scored struct {
 	path           string
 	title          string
@@ -64,6 +83,7 @@ type scored struct {
 	distance       float64
 	titleOverlap   float64
 	contentBoosted bool     // true when titleOverlap was set by Mode 5 content boost
+	pinned         bool     // injected because it is pinned, not found by search
 	tokens         int      // estimated tokens (set after selection)
 	matchTerms     []string // terms from prompt that matched this note
 }
@@ -71,6 +91,25 @@ type scored struct {
 // runContextSurfacing embeds the user's prompt, searches the vault,
 // and injects relevant context.
 func runContextSurfacing(db *store.DB, input *HookInput) hookRunResult {
+	tracePath := config.SurfacingTracePath()
+	if tracePath == "" {
+		return surfaceContext(db, input, nil)
+	}
+	activeTrace = newSurfacingTrace(input)
+	defer func() { activeTrace = nil }()
+	result := surfaceContext(db, input, nil)
+	activeTrace.finish(result)
+	writeSurfacingTrace(tracePath, activeTrace)
+	return result
+}
+
+// surfaceContext is runContextSurfacing with an optional preview. When
+// preview is non-nil, the surfacing display on stderr is suppressed and the
+// mode and candidate selection are recorded into it.
+func surfaceContext(db *store.DB, input *HookInput, preview *SurfacingPreview) hookRunResult {
+	applySurfacingTuning(config.SurfacingTuning())
+	activeTrace.setTuning(surfacingTuningOverrides())
+
 	prompt := input.Prompt
 	if len(prompt) < minPromptChars {
 		logDecision(db, input.SessionID, prompt, "", -1, "skip_short", nil)
@@ -91,18 +130,19 @@ func runContextSurfacing(db *store.DB, input *HookInput) hookRunResult {
 
 	// Check display mode from config, with env var override
 	displayMode := config.DisplayMode() // "full", "compact", or "quiet"
-	if os.Getenv("SAME_QUIET") == "1" || os.Getenv("SAME_QUIET") == "true" {
+	if os.Getenv(config.EnvQuiet) == "1" || os.Getenv(config.EnvQuiet) == "true" {
 		displayMode = "quiet"
-	} else if os.Getenv("SAME_COMPACT") == "1" || os.Getenv("SAME_COMPACT") == "true" {
+	} else if os.Getenv(config.EnvCompact) == "1" || os.Getenv(config.EnvCompact) == "true" {
 		displayMode = "compact"
 	}
-	quietMode := displayMode == "quiet"
+	quietMode := displayMode == "quiet" || preview != nil
 	compactMode := displayMode == "compact"
 
 	isRecency := memory.HasRecencyIntent(prompt)
 
 	// Set prompt early for term extraction (used by low-signal gate and search)
 	keyTermsPrompt = prompt
+	snippetChars = config.SurfacingSnippetChars()
 
 	// Skip low-signal prompts: if term extraction finds no specific terms
 	// (acronyms, quoted phrases, hyphenated) and at most 1 broad term
@@ -116,6 +156,11 @@ func runContextSurfacing(db *store.DB, input *HookInput) hookRunResult {
 
 	// --- Decision matrix: mode × topic change ---
 	mode := detectMode(prompt)
+	activeTrace.setMode(mode.String(), isRecency)
+	if preview != nil {
+		preview.Mode = mode.String()
+		preview.Recency = isRecency
+	}
 
 	if !isRecency {
 		switch mode {
@@ -148,7 +193,9 @@ func runContextSurfacing(db *store.DB, input *HookInput) hookRunResult {
 		// No embedding provider — fall through to keyword search
 		fmt.Fprintf(os.Stderr, "same: no embedding provider, using keyword search\n")
 		writeVerboseLog(fmt.Sprintf("Embed provider error: %v — keyword fallback\n", err))
+		activeTrace.setStrategy("keyword")
 		candidates = keywordFallbackSearch(db)
+		activeTrace.added("keyword", len(candidates))
 	} else {
 		// Check for embedding model/dimension mismatch before searching
 		if mismatchErr := db.CheckEmbeddingMeta(embedProvider.Name(), embedProvider.Model(), embedProvider.Dimensions()); mismatchErr != nil {
@@ -182,14 +229,24 @@ Suggested actions for the user:
 				fmt.Fprintf(os.Stderr, "same: embedding failed, falling back to keyword search\n")
 			}
 			writeVerboseLog(fmt.Sprintf("Embedding failed: %v — keyword fallback\n", embedErr))
+			activeTrace.setStrategy("keyword")
 			candidates = keywordFallbackSearch(db)
+			activeTrace.added("keyword", len(candidates))
 		} else if isRecency {
+			activeTrace.setStrategy("recency")
 			candidates = recencyHybridSearch(db, queryVec)
+			activeTrace.added("recency", len(candidates))
 		} else {
+			activeTrace.setStrategy("standard")
 			candidates = standardSearch(db, queryVec)
 		}
 	}
 
+	// Time-boxed notes (frontmatter expires:) stop surfacing after their date.
+	found := len(candidates)
+	candidates = dropExpired(db, candidates, time.Now().Format("2006-01-02"))
+	activeTrace.rejected("expired", found-len(candidates))
+
 	// If no candidates found, show empty state (unless quiet)
 	if len(candidates) == 0 {
 		if !quietMode {
@@ -199,9 +256,13 @@ Suggested actions for the user:
 	}
 
 	// Inject pinned notes: always surface them regardless of search results.
-	// They're prepended so they survive the effectiveMax cap.
+	// They're prepended so they survive the effectiveMax cap, which is why
+	// they get their own token budget: over-pinning would otherwise crowd
+	// out search results on every prompt.
+	var pinnedWarning string
 	{
 		pinnedRecords, _ := db.GetPinnedNotes()
+		var eligible []store.PinnedNote
 		for _, rec := range pinnedRecords {
 			// Skip if already in candidates
 			alreadyPresent := false
@@ -218,6 +279,19 @@ Suggested actions for the user:
 			if shouldSkipPath(rec.Path) {
 				continue
 			}
+			eligible = append(eligible, rec)
+		}
+		pinBudget := config.MemoryMaxPinnedTokens()
+		kept, skipped := selectPinnedWithinBudget(eligible, pinBudget)
+		if len(skipped) > 0 {
+			pinnedWarning = pinnedBudgetWarning(skipped, pinBudget)
+			writeVerboseLog(fmt.Sprintf("Pinned budget: kept %d, skipped %d (budget %d tokens)\n", len(kept), len(skipped), pinBudget))
+		}
+		activeTrace.added("pinned", len(kept))
+		activeTrace.rejected("pinned_budget", len(skipped))
+		// Prepend in reverse so the highest-priority pin ends up first.
+		for i := len(kept) - 1; i >= 0; i-- {
+			rec := kept[i]
 			// Prepend with high score so pinned notes survive trimming
 			pinned := scored{
 				path:         rec.Path,
@@ -227,6 +301,7 @@ Suggested actions for the user:
 				snippet:      rec.Text,
 				composite:    1.0,
 				titleOverlap: 1.0, // prevent overlap-based trimming
+				pinned:       true,
 			}
 			candidates = append([]scored{pinned}, candidates...)
 		}
@@ -257,6 +332,7 @@ Suggested actions for the user:
 		}
 	}
 	if len(candidates) > effectiveMax {
+		activeTrace.rejected("result_cap", len(candidates)-effectiveMax)
 		candidates = candidates[:effectiveMax]
 	}
 
@@ -272,6 +348,7 @@ Suggested actions for the user:
 		if leaderOverlap > 0 && leaderOverlap < highTierOverlap {
 			for i := 1; i < len(candidates); i++ {
 				if candidates[i].titleOverlap <= 0 {
+					activeTrace.rejected("zero_overlap_trim", len(candidates)-i)
 					candidates = candidates[:i]
 					break
 				}
@@ -293,6 +370,7 @@ Suggested actions for the user:
 		}
 		for i := 1; i < len(candidates); i++ {
 			if candidates[i].titleOverlap < relThreshold {
+				activeTrace.rejected("overlap_gap", len(candidates)-i)
 				candidates = candidates[:i]
 				break
 			}
@@ -302,6 +380,8 @@ Suggested actions for the user:
 	// Build context string, capped at token budget.
 	// Continue past oversized candidates — a large note that doesn't fit
 	// shouldn't prevent smaller, high-relevance notes behind it from being included.
+	tokenBudget := config.SurfacingMaxTokenBudget()
+	perNoteTokens := min(maxPerNoteTokens, tokenBudget/2)
 	var parts []string
 	var included []scored
 	var excluded []scored
@@ -313,7 +393,7 @@ Suggested actions for the user:
 		// truncate it so other results get a fair share of the budget.
 		snippet := candidates[i].snippet
 		if snippet != "" {
-			maxSnipChars := maxPerNoteTokens * 4 // ~4 chars per token
+			maxSnipChars := perNoteTokens * 4 // ~4 chars per token
 			if len(snippet) > maxSnipChars {
 				snippet = smartTruncate(snippet, maxSnipChars)
 			}
@@ -339,7 +419,7 @@ Suggested actions for the user:
 		// Find which prompt terms appear in this note's title/snippet
 		candidates[i].matchTerms = findMatchingTerms(promptTerms, candidates[i].title, candidates[i].snippet)
 
-		if totalTokens+entryTokens > maxTokenBudget {
+		if totalTokens+entryTokens > tokenBudget {
 			// Skip this note but keep scanning — smaller notes may still fit
 			excluded = append(excluded, candidates[i])
 			continue
@@ -350,6 +430,14 @@ Suggested actions for the user:
 		totalTokens += entryTokens
 	}
 
+	if preview != nil {
+		preview.record(included, excluded, totalTokens, tokenBudget, perNoteTokens)
+	}
+	activeTrace.setThreshold("max_results_effective", float64(effectiveMax))
+	activeTrace.setThreshold("token_budget", float64(tokenBudget))
+	activeTrace.rejected("token_budget", len(excluded))
+	activeTrace.record(included, excluded, totalTokens)
+
 	if len(parts) == 0 {
 		if !quietMode {
 			cli.SurfacingEmpty(totalVault)
@@ -422,6 +510,7 @@ Suggested actions for the user:
 	verboseDecision("inject", mode.String(), -1, prompt, titles, totalTokens)
 
 	out := &HookOutput{
+		SystemMessage: pinnedWarning,
 		HookSpecificOutput: &HookSpecific{
 			HookEventName: "UserPromptSubmit",
 			AdditionalContext: fmt.Sprintf(
@@ -3,8 +3,10 @@ package hooks
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/sgx-labs/statelessagent/internal/graph"
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
@@ -125,6 +127,25 @@ func TestQueryBiasedSnippet_FallsBackToStart(t *testing.T) {
 	}
 }
 
+func TestQueryBiasedSnippet_SkipsFrontmatter(t *testing.T) {
+	keyTermsPrompt = ""
+	text := "---\ntitle: [unclosed\ntags: x\n---\nThe actual note body starts here."
+	got := queryBiasedSnippet(text, 200)
+	if got != "The actual note body starts here." {
+		t.Errorf("expected frontmatter stripped, got %q", got)
+	}
+}
+
+func TestQueryBiasedSnippet_StartsAtCodeFence(t *testing.T) {
+	keyTermsPrompt = "retry backoff"
+	text := strings.Repeat("Intro paragraph that is long enough to not be pulled in as context. ", 4) + "\n\n" +
+		"```go\nfunc connect() {\n\n\t// retry with backoff\n\tfor {}\n}\n```\n\nTrailing prose."
+	got := queryBiasedSnippet(text, 120)
+	if !strings.HasPrefix(got, "```go") {
+		t.Errorf("expected snippet to start at the opening fence, got %q", got)
+	}
+}
+
 // --- isConversational ---
 
 func TestIsConversational_Greetings(t *testing.T) {
@@ -610,3 +631,149 @@ func TestSanitizeContextTags_CaseInsensitive(t *testing.T) {
 		t.Errorf("expected case-insensitive sanitization, got %q", got)
 	}
 }
+
+// --- path weights ---
+
+func TestPathWeight_LongestPrefixWins(t *testing.T) {
+	weights := map[string]float64{
+		"decisions/":          1.5,
+		"decisions/archived/": 0.5,
+		"archive/":            -1,
+	}
+	cases := map[string]float64{
+		"decisions/auth.md":          1.5,
+		"decisions/archived/old.md":  0.5,
+		"archive/2023/notes.md":      -1,
+		"notes/decisions/readme.md":  1.0,
+		"sessions/2026-01-01-abc.md": 1.0,
+	}
+	for path, want := range cases {
+		if got := pathWeight(path, weights); got != want {
+			t.Errorf("pathWeight(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestApplyPathWeights_ScalesComposite(t *testing.T) {
+	candidates := []scored{
+		{path: "decisions/a.md", composite: 0.8},
+		{path: "archive/b.md", composite: 0.8},
+		{path: "notes/c.md", composite: 0.8},
+	}
+	applyPathWeights(candidates, map[string]float64{"decisions/": 1.5, "archive/": 0.5})
+
+	want := []float64{1.2, 0.4, 0.8}
+	for i, c := range candidates {
+		if diff := c.composite - want[i]; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("%s composite = %v, want %v", c.path, c.composite, want[i])
+		}
+	}
+
+	applyPathWeights(candidates, nil)
+	if candidates[2].composite != 0.8 {
+		t.Errorf("nil weights should leave scores unchanged, got %v", candidates[2].composite)
+	}
+}
+
+func TestExpandFromLinks_AddsLinkedNotesAfterCandidates(t *testing.T) {
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	for _, n := range []struct{ path, title string }{
+		{"notes/auth.md", "Auth"},
+		{"notes/sessions.md", "Session Storage"},
+		{"plans/rollout.md", "Rollout"},
+		{"_PRIVATE/keys.md", "Keys"},
+	} {
+		rec := store.NoteRecord{Path: n.path, Title: n.title, Tags: "[]", ChunkHeading: "(full)", Text: n.title + " body", ContentType: "note", Confidence: 0.8}
+		if _, err := db.BulkInsertNotesLite([]store.NoteRecord{rec}); err != nil {
+			t.Fatalf("insert %s: %v", n.path, err)
+		}
+	}
+	links := graph.ParseNoteLinks("See [[Session Storage]], [rollout](../plans/rollout.md), [[keys]] and [[missing]].")
+	if err := db.SetNoteLinks("notes/auth.md", links); err != nil {
+		t.Fatalf("SetNoteLinks: %v", err)
+	}
+
+	candidates := []scored{{path: "notes/auth.md", composite: 0.8}}
+	seen := map[string]bool{"notes/auth.md": true}
+	got := expandFromLinks(db, candidates, seen, 0.5)
+
+	if len(got) != 3 {
+		t.Fatalf("expected 2 linked notes appended, got %+v", got)
+	}
+	if got[0].path != "notes/auth.md" {
+		t.Errorf("original candidate should stay first, got %q", got[0].path)
+	}
+	if got[1].path != "notes/sessions.md" || got[2].path != "plans/rollout.md" {
+		t.Errorf("linked notes = %q, %q", got[1].path, got[2].path)
+	}
+	if got[1].composite != 0.4 {
+		t.Errorf("linked composite = %v, want 0.4", got[1].composite)
+	}
+
+	// Deleting the linking note drops its links.
+	if err := db.DeleteByPath("notes/auth.md"); err != nil {
+		t.Fatalf("DeleteByPath: %v", err)
+	}
+	if remaining, _ := db.NoteLinksFrom([]string{"notes/auth.md"}); len(remaining) != 0 {
+		t.Errorf("links should be removed with the note, got %+v", remaining)
+	}
+}
+
+func TestDropExpired(t *testing.T) {
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	note := func(path, expires string) store.NoteRecord {
+		return store.NoteRecord{Path: path, Title: path, Tags: "[]", ChunkHeading: "(full)", Text: "text", ContentType: "note", Expires: expires}
+	}
+	recs := []store.NoteRecord{note("sprint.md", "2026-03-01"), note("today.md", "2026-03-02"), note("plain.md", "")}
+	if _, err := db.BulkInsertNotesLite(recs); err != nil {
+		t.Fatal(err)
+	}
+
+	candidates := []scored{{path: "sprint.md"}, {path: "today.md"}, {path: "plain.md"}}
+	got := dropExpired(db, candidates, "2026-03-02")
+	if len(got) != 2 || got[0].path != "today.md" || got[1].path != "plain.md" {
+		t.Errorf("dropExpired kept %+v, want today.md and plain.md", got)
+	}
+}
+
+func TestPreviewContextSurfacing_LeavesStoreNoisePathsAlone(t *testing.T) {
+	t.Setenv("SAME_EMBED_PROVIDER", "none")
+	t.Setenv("SAME_NOISE_PATHS", "drafts/")
+	old := store.NoisePaths
+	store.NoisePaths = nil
+	defer func() { store.NoisePaths = old }()
+
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	// The surface_context MCP tool calls this from concurrent handlers.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			PreviewContextSurfacing(db, "how does the deploy pipeline work")
+		}()
+	}
+	wg.Wait()
+
+	if store.NoisePaths != nil {
+		t.Errorf("store.NoisePaths = %v, want it untouched", store.NoisePaths)
+	}
+	if !shouldSkipPath("drafts/idea.md") {
+		t.Error("configured noise path should still be skipped during surfacing")
+	}
+}
@@ -37,6 +37,24 @@ func runDecisionExtractor(db *store.DB, input *HookInput) hookRunResult {
 		return hookSkipped("no new decisions")
 	}
 
+	// One note per decision (ADR style) when [output.decision] style = "files".
+	if dirRel := config.DecisionDirectory(); dirRel != "" {
+		dir, ok := config.SafeVaultSubpath(dirRel)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "same: decision directory is outside your vault — check [output.decision] path\n")
+			return hookError("invalid decision directory")
+		}
+		count := 0
+		for _, d := range decisions {
+			if _, err := memory.WriteDecisionNote(dir, memory.DecisionFromExtract(d)); err != nil {
+				fmt.Fprintf(os.Stderr, "same: warning: %v\n", err)
+				break
+			}
+			count++
+		}
+		return decisionsExtracted(count, dirRel)
+	}
+
 	// Append to decision log (validate path stays in vault)
 	logPath, ok := config.SafeVaultSubpath(config.DecisionLogPath())
 	if !ok {
@@ -44,15 +62,19 @@ func runDecisionExtractor(db *store.DB, input *HookInput) hookRunResult {
 		return hookError("invalid decision log path")
 	}
 	count := memory.AppendToDecisionLog(decisions, logPath, "")
+	return decisionsExtracted(count, config.DecisionLogPath())
+}
 
+// decisionsExtracted reports count decisions written to dest.
+func decisionsExtracted(count int, dest string) hookRunResult {
 	if count > 0 {
 		if !isQuietMode() {
-			fmt.Fprintf(os.Stderr, "same: ✓ extracted %d decision(s) → %s\n", count, config.DecisionLogPath())
+			fmt.Fprintf(os.Stderr, "same: ✓ extracted %d decision(s) → %s\n", count, dest)
 		}
 		out := &HookOutput{
 			SystemMessage: fmt.Sprintf(
-				"\n<vault-decisions>\nExtracted %d decision(s) from this session.\nAppended to: %s\nTagged as auto-extracted for human review.\n</vault-decisions>\n",
-				count, config.DecisionLogPath(),
+				"\n<vault-decisions>\nExtracted %d decision(s) from this session.\nSaved to: %s\nTagged as auto-extracted for human review.\n</vault-decisions>\n",
+				count, dest,
 			),
 		}
 		return hookInjected(out, 0, 0, nil, fmt.Sprintf("%d decision(s) extracted", count))
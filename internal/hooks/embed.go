@@ -2,6 +2,7 @@ package hooks
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/sgx-labs/statelessagent/internal/config"
 	"github.com/sgx-labs/statelessagent/internal/embedding"
@@ -15,6 +16,7 @@ func newEmbedProvider() (embedding.Provider, error) {
 		Model:      ec.Model,
 		APIKey:     ec.APIKey,
 		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
 	}
 
 	// Skip connection retries when the user hasn't explicitly configured
@@ -33,5 +35,14 @@ func newEmbedProvider() (embedding.Provider, error) {
 		cfg.BaseURL = ollamaURL
 	}
 
-	return embedding.NewProvider(cfg)
+	provider, err := embedding.NewProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hooks re-embed the prompt on every invocation; retries and repeated
+	// prompts are served from an on-disk cache instead of the network.
+	cache := embedding.NewQueryCache(filepath.Join(config.DataDir(), "query-cache"),
+		embedding.DefaultQueryCacheEntries, embedding.DefaultQueryCacheTTL)
+	return embedding.WithQueryCache(provider, cache), nil
 }
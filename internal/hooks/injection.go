@@ -2,6 +2,7 @@ package hooks
 
 import (
 	"context"
+	"strings"
 
 	"github.com/mdombrov-33/go-promptguard/detector"
 )
@@ -12,16 +13,24 @@ import (
 var promptGuard = detector.New(
 	detector.WithThreshold(0.6),       // stricter than default 0.7 — we're filtering vault content, not user input
 	detector.WithAllDetectors(),       // role injection, prompt leak, instruction override, obfuscation, normalization, delimiter
-	detector.WithMaxInputLength(1000), // snippets are capped at 300 chars, but be safe
+	detector.WithMaxInputLength(1000), // matches config.MaxSnippetChars so whole snippets are scanned
 	// No LLM judge — pattern + statistical analysis only for sub-ms latency
 )
 
 // detectInjection runs the go-promptguard multi-detector against text.
-// Returns true if an injection attempt is detected (i.e. the input is NOT safe).
-func detectInjection(text string) bool {
+// Returns true if an injection attempt is detected (i.e. the input is NOT
+// safe), along with the detector pattern types that fired.
+func detectInjection(text string) (bool, string) {
 	if len(text) == 0 {
-		return false
+		return false, ""
 	}
 	result := promptGuard.Detect(context.Background(), text)
-	return !result.Safe
+	if result.Safe {
+		return false, ""
+	}
+	types := make([]string, 0, len(result.DetectedPatterns))
+	for _, p := range result.DetectedPatterns {
+		types = append(types, p.Type)
+	}
+	return true, strings.Join(types, ", ")
 }
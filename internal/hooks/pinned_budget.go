@@ -0,0 +1,61 @@
+package hooks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sgx-labs/statelessagent/internal/memory"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+// PinnedNoteTokens estimates what a pinned note costs each time context
+// surfacing injects it: the title and path line plus its text after the
+// per-note cap.
+func PinnedNoteTokens(rec store.NoteRecord) int {
+	text := rec.Text
+	if maxChars := maxPerNoteTokens * 4; len(text) > maxChars {
+		text = smartTruncate(text, maxChars)
+	}
+	return memory.EstimateTokens(fmt.Sprintf("**%s** (%s)\n%s\n%s", rec.Title, rec.ContentType, rec.Path, text))
+}
+
+// selectPinnedWithinBudget picks the pins to inject when their combined
+// cost exceeds budget. Higher-confidence pins win (feedback up/down adjusts
+// this), then older pins. Like the main budget loop, an oversized pin is
+// skipped without stopping smaller ones behind it. Returned pins keep the
+// priority order.
+func selectPinnedWithinBudget(pins []store.PinnedNote, budget int) (kept, skipped []store.PinnedNote) {
+	ordered := make([]store.PinnedNote, len(pins))
+	copy(ordered, pins)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Confidence != ordered[j].Confidence {
+			return ordered[i].Confidence > ordered[j].Confidence
+		}
+		return ordered[i].PinnedAt < ordered[j].PinnedAt
+	})
+
+	used := 0
+	for _, p := range ordered {
+		cost := PinnedNoteTokens(p.NoteRecord)
+		if used+cost > budget {
+			skipped = append(skipped, p)
+			continue
+		}
+		used += cost
+		kept = append(kept, p)
+	}
+	return kept, skipped
+}
+
+// pinnedBudgetWarning is the systemMessage shown when pins were dropped.
+func pinnedBudgetWarning(skipped []store.PinnedNote, budget int) string {
+	titles := make([]string, 0, len(skipped))
+	for _, p := range skipped {
+		titles = append(titles, p.Title)
+	}
+	return fmt.Sprintf(
+		"\n<same-diagnostic>\nPinned notes exceed the %d-token pinned budget; skipped %d: %s.\nSuggested actions for the user:\n- Unpin notes with \"same pin remove <path>\"\n- Or raise the budget: same config set memory.max_pinned_tokens <n>\n</same-diagnostic>\n",
+		budget, len(skipped), strings.Join(titles, ", "),
+	)
+}
@@ -0,0 +1,74 @@
+package hooks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func testPin(path string, confidence float64, pinnedAt int64, textLen int) store.PinnedNote {
+	return store.PinnedNote{
+		NoteRecord: store.NoteRecord{
+			Path:        path,
+			Title:       path,
+			ContentType: "note",
+			Confidence:  confidence,
+			Text:        strings.Repeat("word ", textLen/5),
+		},
+		PinnedAt: pinnedAt,
+	}
+}
+
+func TestSelectPinnedWithinBudget_PrefersConfidenceThenAge(t *testing.T) {
+	pins := []store.PinnedNote{
+		testPin("old-low.md", 0.5, 100, 400),
+		testPin("new-high.md", 0.9, 300, 400),
+		testPin("old-high.md", 0.9, 200, 400),
+	}
+	one := PinnedNoteTokens(pins[0].NoteRecord)
+
+	kept, skipped := selectPinnedWithinBudget(pins, 2*one+1)
+	if len(kept) != 2 || kept[0].Path != "old-high.md" || kept[1].Path != "new-high.md" {
+		t.Errorf("kept = %v, want [old-high.md new-high.md]", pinPaths(kept))
+	}
+	if len(skipped) != 1 || skipped[0].Path != "old-low.md" {
+		t.Errorf("skipped = %v, want [old-low.md]", pinPaths(skipped))
+	}
+}
+
+func TestSelectPinnedWithinBudget_OversizedPinDoesNotBlockSmallerOnes(t *testing.T) {
+	pins := []store.PinnedNote{
+		testPin("huge.md", 0.9, 100, 5000),
+		testPin("small.md", 0.5, 200, 100),
+	}
+	budget := PinnedNoteTokens(pins[1].NoteRecord) + 10
+
+	kept, skipped := selectPinnedWithinBudget(pins, budget)
+	if len(kept) != 1 || kept[0].Path != "small.md" {
+		t.Errorf("kept = %v, want [small.md]", pinPaths(kept))
+	}
+	if len(skipped) != 1 || skipped[0].Path != "huge.md" {
+		t.Errorf("skipped = %v, want [huge.md]", pinPaths(skipped))
+	}
+
+	msg := pinnedBudgetWarning(skipped, budget)
+	if !strings.Contains(msg, "huge.md") || !strings.Contains(msg, "memory.max_pinned_tokens") {
+		t.Errorf("warning should name the skipped pin and the config key, got %q", msg)
+	}
+}
+
+func TestPinnedNoteTokens_CapsLongNotes(t *testing.T) {
+	long := testPin("long.md", 0.5, 0, 50000)
+	if got := PinnedNoteTokens(long.NoteRecord); got > maxPerNoteTokens+50 {
+		t.Errorf("PinnedNoteTokens = %d, want at most ~%d", got, maxPerNoteTokens)
+	}
+}
+
+func pinPaths(pins []store.PinnedNote) []string {
+	var out []string
+	for _, p := range pins {
+		out = append(out, p.Path)
+	}
+	return out
+}
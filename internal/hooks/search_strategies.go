@@ -1,6 +1,7 @@
 package hooks
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
@@ -19,6 +20,7 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 
 	raw, err := db.VectorSearchRaw(queryVec, maxResults*6)
 	vectorEmpty := err != nil || len(raw) == 0 || raw[0].Distance > maxDistance
+	activeTrace.added("vector_raw", len(raw))
 
 	var candidates []scored
 	seen := make(map[string]bool)
@@ -33,6 +35,7 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 
 		for _, r := range deduped {
 			if r.Distance > maxDistance {
+				activeTrace.rejected("max_distance", 1)
 				continue
 			}
 			// SECURITY: never auto-surface _PRIVATE/ content
@@ -42,12 +45,14 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 
 			semScore := 1.0 - ((r.Distance - minDist) / dRange)
 			if semScore < minSemanticFloor {
+				activeTrace.rejected("min_semantic_floor", 1)
 				continue
 			}
 
 			comp := memory.CompositeScore(semScore, r.Modified, r.Confidence, r.ContentType,
 				0.3, 0.3, 0.4)
 			if comp < minComposite {
+				activeTrace.rejected("min_composite", 1)
 				continue
 			}
 
@@ -58,12 +63,14 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 			seen[r.Path] = true
 		}
 	}
+	activeTrace.added("vector", len(candidates))
 
 	// Mode 2 (name overlap): ALWAYS runs. Uses title+path matching with
 	// minMatches=1, then filters by bidirectional overlap score. Uses
 	// permissive titleTerms (3+ chars) to catch short words like "home",
 	// "same" that keyword extraction misses. Searches both title and path
 	// to find notes in project directories (e.g., project-alpha/design-brief.md).
+	before := len(candidates)
 	if len(titleTerms) > 0 {
 		titleResults, titleErr := db.KeywordSearchTitleMatch(titleTerms, 1, maxResults*10)
 		if titleErr == nil {
@@ -101,12 +108,14 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 			}
 		}
 	}
+	activeTrace.added("title", len(candidates)-before)
 
 	// Mode 2b: Hub/overview rescue. Large directories (e.g., 86 experiment
 	// files) can crowd hub notes out of Mode 2's LIMIT. This targeted pass
 	// searches title-only for hub-type notes with strong title overlap
 	// (>= 0.50). Uses real titleOverlap so hubs sort correctly alongside
 	// other results with path-based overlap.
+	before = len(candidates)
 	if len(titleTerms) > 0 {
 		hubResults, hubErr := db.KeywordSearchTitleMatch(titleTerms, 1, 10, true)
 		if hubErr == nil {
@@ -136,6 +145,7 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 			}
 		}
 	}
+	activeTrace.added("hub", len(candidates)-before)
 
 	// Check for strong/positive candidates before keyword fallback
 	hasStrongCandidateForKW := false
@@ -155,6 +165,7 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 	// strategy and PM skills" where the expected note has terms only
 	// in content, not title). Uses ContentTermSearch (all chunks) to
 	// find notes where query terms appear in body text.
+	before = len(candidates)
 	if len(specificTerms) > 0 && (len(candidates) < maxResults || (vectorEmpty && !hasStrongCandidateForKW)) {
 		terms := specificTerms
 		if len(broadTerms) > 0 {
@@ -233,8 +244,10 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 			}
 		}
 	}
+	activeTrace.added("keyword", len(candidates)-before)
 
 	// Mode 3: broad fallback — original behavior for when vector is empty
+	before = len(candidates)
 	if len(candidates) < maxResults && vectorEmpty && len(broadTerms) >= 2 && len(specificTerms) == 0 {
 		kwResults, err := db.KeywordSearchTitleMatch(broadTerms, 2, maxResults*3)
 		if err == nil {
@@ -255,11 +268,13 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 			}
 		}
 	}
+	activeTrace.added("broad", len(candidates)-before)
 
 	// Mode 4: fuzzy title search for misspellings (e.g., "kubernetes" -> "kuberntes").
 	// Uses edit distance 1 matching. Runs when there's room for more results.
 	// Only adds hub-type notes or notes with very high title overlap (>= 0.40)
 	// to avoid false positives from broad fuzzy matching.
+	before = len(candidates)
 	if len(candidates) < maxResults {
 		searchTerms := store.ExtractSearchTerms(keyTermsPrompt)
 		fuzzyResults, _ := db.FuzzyTitleSearch(searchTerms, maxResults*3)
@@ -289,6 +304,7 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 			}
 		}
 	}
+	activeTrace.added("fuzzy", len(candidates)-before)
 
 	// Mode 5: broad content keyword search. Searches note content using
 	// broad terms via ContentTermSearch (checks ALL chunks, not just
@@ -340,6 +356,7 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 			}
 		}
 	}
+	activeTrace.added("content", len(candidates)-candidatesBeforeMode5)
 
 	if len(candidates) == 0 {
 		return nil
@@ -347,10 +364,17 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 
 	// Graph 1-hop expansion: for top vector results, find graph neighbors
 	// that are note-type nodes and add them as supplemental results.
+	before = len(candidates)
 	candidates = expandFromGraph(db, candidates, seen)
+	activeTrace.added("graph", len(candidates)-before)
 
 	// Near-dedup: collapse versioned copies in the same directory.
+	before = len(candidates)
 	candidates = nearDedup(candidates, titleTerms)
+	activeTrace.rejected("near_dedup", before-len(candidates))
+
+	// Per-directory weights from [surfacing] path_weights.
+	applyPathWeights(candidates, surfacingPathWeights())
 
 	// Sort: three tiers — high title overlap (>= 0.20), positive title
 	// overlap (> 0), and zero overlap. Within each tier: priority content
@@ -377,6 +401,14 @@ func standardSearch(db *store.DB, queryVec []float32) []scored {
 		return candidates[i].composite > candidates[j].composite
 	})
 
+	// Link expansion: notes the top results explicitly link to go after
+	// everything else, so they only surface when there is room left.
+	if boost := surfacingLinkBoost(); boost > 0 {
+		before = len(candidates)
+		candidates = expandFromLinks(db, candidates, seen, boost)
+		activeTrace.added("links", len(candidates)-before)
+	}
+
 	return candidates
 }
 
@@ -388,7 +420,7 @@ func keywordFallbackSearch(db *store.DB) []scored {
 		return nil
 	}
 
-	results, err := db.FTS5Search(prompt, store.SearchOptions{TopK: maxResults})
+	results, err := db.FTS5Search(prompt, store.SearchOptions{TopK: maxResults, Synonyms: store.ConfiguredSynonyms()})
 	if err != nil {
 		// FTS5 table may not exist yet — fall back to LIKE-based keyword search
 		terms := store.ExtractSearchTerms(prompt)
@@ -404,16 +436,13 @@ func keywordFallbackSearch(db *store.DB) []scored {
 			if shouldSkipPath(r.Path) {
 				continue
 			}
-			snippet := r.Text
-			if len(snippet) > int(maxSnippetChars) {
-				snippet = snippet[:maxSnippetChars]
-			}
+			snippet := store.Snippet(r.Text, snippetChars)
 			candidates = append(candidates, scored{
 				path:        r.Path,
 				title:       r.Title,
 				contentType: r.ContentType,
 				confidence:  r.Confidence,
-				snippet:     sanitizeSnippet(snippet),
+				snippet:     sanitizeSnippet(r.Path, snippet),
 				composite:   0.5,
 				semantic:    0,
 			})
@@ -431,7 +460,7 @@ func keywordFallbackSearch(db *store.DB) []scored {
 			title:       r.Title,
 			contentType: r.ContentType,
 			confidence:  r.Confidence,
-			snippet:     sanitizeSnippet(r.Snippet),
+			snippet:     sanitizeSnippet(r.Path, r.Snippet),
 			composite:   0.5,
 			semantic:    0,
 		})
@@ -514,8 +543,8 @@ func recencyHybridSearch(db *store.DB, queryVec []float32) []scored {
 			recencyRelWeight, recencyRecWeight, recencyConfWeight)
 
 		if comp >= recencyMinComposite {
-			snippet := queryBiasedSnippet(n.Text, maxSnippetChars)
-			snippet = sanitizeSnippet(snippet)
+			snippet := queryBiasedSnippet(n.Text, snippetChars)
+			snippet = sanitizeSnippet(n.Path, snippet)
 			candidateMap[n.Path] = &scored{
 				path:        n.Path,
 				title:       n.Title,
@@ -603,37 +632,20 @@ func dedup(raw []store.RawSearchResult) []store.RawSearchResult {
 }
 
 // nearDedup collapses versioned copies in the same directory. When two
-// candidates share a parent directory and one filename (sans .md) is a
-// prefix of the other, they're treated as near-duplicates and only the
+// candidates are versioned copies (see store.IsVersionedCopy), only the
 // better-scoring one is kept. Uses full-path overlap (title + path) to
 // correctly resolve when the query mentions a version suffix.
 func nearDedup(candidates []scored, queryTerms []string) []scored {
-	type key struct{ dir, base string }
-	pathParts := func(p string) key {
-		slash := strings.LastIndex(p, "/")
-		if slash < 0 {
-			return key{"", strings.TrimSuffix(p, ".md")}
-		}
-		return key{p[:slash], strings.TrimSuffix(p[slash+1:], ".md")}
-	}
-
-	// Build pairs of near-duplicates (same dir, one prefix of other)
 	remove := make(map[int]bool)
 	for i := 0; i < len(candidates); i++ {
 		if remove[i] {
 			continue
 		}
-		ki := pathParts(candidates[i].path)
 		for j := i + 1; j < len(candidates); j++ {
 			if remove[j] {
 				continue
 			}
-			kj := pathParts(candidates[j].path)
-			if ki.dir != kj.dir {
-				continue
-			}
-			li, lj := strings.ToLower(ki.base), strings.ToLower(kj.base)
-			if !strings.HasPrefix(lj, li) && !strings.HasPrefix(li, lj) {
+			if !store.IsVersionedCopy(candidates[i].path, candidates[j].path) {
 				continue
 			}
 			// Near-duplicate found — use full-path overlap to pick winner
@@ -674,8 +686,8 @@ func distRange(results []store.RawSearchResult) (float64, float64) {
 }
 
 func makeScored(r store.RawSearchResult, comp, sem float64) scored {
-	snippet := queryBiasedSnippet(r.Text, maxSnippetChars)
-	snippet = sanitizeSnippet(snippet)
+	snippet := queryBiasedSnippet(r.Text, snippetChars)
+	snippet = sanitizeSnippet(r.Path, snippet)
 	return scored{
 		path:        r.Path,
 		title:       r.Title,
@@ -745,11 +757,8 @@ func expandFromGraph(db *store.DB, candidates []scored, seen map[string]bool) []
 			rec := records[0] // chunk_id=0 is the root chunk
 			seen[notePath] = true
 
-			snippet := rec.Text
-			if len(snippet) > 500 {
-				snippet = snippet[:500]
-			}
-			snippet = sanitizeSnippet(snippet)
+			snippet := store.Snippet(rec.Text, store.ResultSnippetChars)
+			snippet = sanitizeSnippet(rec.Path, snippet)
 
 			// Dampened score: 60% of parent's composite
 			dampened := c.composite * 0.6
@@ -768,6 +777,61 @@ func expandFromGraph(db *store.DB, candidates []scored, seen map[string]bool) []
 	return append(candidates, expanded...)
 }
 
+// expandFromLinks appends notes that the top candidates link to with
+// [[wikilinks]] or Markdown links, scored at boost times the linking note's
+// composite. Links are followed one hop only.
+func expandFromLinks(db *store.DB, candidates []scored, seen map[string]bool, boost float64) []scored {
+	from := candidates
+	if len(from) > maxResults {
+		from = from[:maxResults]
+	}
+	paths := make([]string, len(from))
+	for i, c := range from {
+		paths[i] = c.path
+	}
+	links, err := db.NoteLinksFrom(paths)
+	if err != nil || len(links) == 0 {
+		return candidates
+	}
+	resolver, err := db.NoteLinkResolver()
+	if err != nil {
+		return candidates
+	}
+
+	var linked []scored
+	for _, c := range from {
+		for _, l := range links[c.path] {
+			if len(linked) >= maxResults {
+				break
+			}
+			target, ok := resolver.Resolve(c.path, l)
+			if !ok || seen[target] || shouldSkipPath(target) {
+				continue
+			}
+			records, err := db.GetNoteByPath(target)
+			if err != nil || len(records) == 0 {
+				continue
+			}
+			seen[target] = true
+			rec := records[0]
+			snippet := store.Snippet(rec.Text, store.ResultSnippetChars)
+			linked = append(linked, scored{
+				path:        target,
+				title:       rec.Title,
+				contentType: rec.ContentType,
+				confidence:  rec.Confidence,
+				trustState:  rec.TrustState,
+				snippet:     sanitizeSnippet(rec.Path, snippet),
+				composite:   c.composite * boost,
+			})
+		}
+	}
+	if len(linked) > 0 {
+		writeVerboseLog(fmt.Sprintf("Link expansion: %d linked note(s) added\n", len(linked)))
+	}
+	return append(candidates, linked...)
+}
+
 // isPrivatePath returns true if the path is under the _PRIVATE/ directory.
 // Case-insensitive to match safeVaultPath() and filterPrivatePaths() behavior.
 func isPrivatePath(path string) bool {
@@ -786,11 +850,56 @@ func isNoisyPath(path string) bool {
 	return false
 }
 
+// pathWeight returns the multiplier for the longest prefix in weights that
+// matches path, or 1.0 when none match.
+func pathWeight(path string, weights map[string]float64) float64 {
+	best := ""
+	w := 1.0
+	for prefix, weight := range weights {
+		if strings.HasPrefix(path, prefix) && len(prefix) > len(best) {
+			best = prefix
+			w = weight
+		}
+	}
+	return w
+}
+
+// applyPathWeights scales each candidate's composite score by its path weight.
+func applyPathWeights(candidates []scored, weights map[string]float64) {
+	if len(weights) == 0 {
+		return
+	}
+	for i := range candidates {
+		candidates[i].composite *= pathWeight(candidates[i].path, weights)
+	}
+}
+
 // shouldSkipPath returns true if the path should be excluded from surfacing.
 func shouldSkipPath(path string) bool {
 	return isPrivatePath(path) || isNoisyPath(path)
 }
 
+// dropExpired removes candidates whose frontmatter expires date is before
+// today (YYYY-MM-DD). Expired notes stay searchable; they are only kept out
+// of auto-surfacing.
+func dropExpired(db *store.DB, candidates []scored, today string) []scored {
+	expired, err := db.ExpiredNotes(today)
+	if err != nil || len(expired) == 0 {
+		return candidates
+	}
+	skip := make(map[string]bool, len(expired))
+	for _, n := range expired {
+		skip[n.Path] = true
+	}
+	kept := candidates[:0]
+	for _, c := range candidates {
+		if !skip[c.path] {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
 // isRecencyRelevantType returns true if a content type is session-relevant.
 // Used to filter RecentNotes merge to avoid surfacing random notes that
 // happen to be recently modified.
@@ -37,38 +37,116 @@ func runSessionBootstrap(db *store.DB, input *HookInput) hookRunResult {
 		fmt.Fprint(os.Stderr, tip)
 	}
 
-	quiet := isQuietMode()
-	var sections []string
-	surfacedNotes := 0
+	// Confidence decay is applied lazily, at most once a day, so vaults that
+	// are rarely reindexed still see old notes fade.
+	if halfLife := config.ConfidenceHalfLifeDays(); halfLife > 0 {
+		if _, err := db.DecayConfidence(halfLife, time.Now(), 24*time.Hour); err != nil {
+			writeVerboseLog(fmt.Sprintf("confidence decay: %v\n", err))
+		}
+	}
+
+	sc := buildSessionContext(db, sessionID, true)
+	if sc.Context == "" {
+		return hookEmpty("no bootstrap context")
+	}
+
+	if !isQuietMode() {
+		for _, sec := range sc.Sections {
+			switch sec.Name {
+			case "previous_session":
+				fmt.Fprintf(os.Stderr, "same: ← previous session loaded (%s)\n", sec.Detail)
+			case "pinned":
+				fmt.Fprintf(os.Stderr, "same: 📌 %d pinned note(s) loaded\n", sec.Count)
+			case "decisions":
+				fmt.Fprintf(os.Stderr, "same: ↑ %d active decision(s) loaded\n", sec.Count)
+			case "stale":
+				fmt.Fprintf(os.Stderr, "same: ⚠ %d stale note(s) need review\n", sec.Count)
+			}
+		}
+	}
+
+	out := &HookOutput{SystemMessage: sc.SystemMessage()}
+	return hookInjected(out, sc.Notes, sc.Tokens, nil, "")
+}
+
+// bootstrapGuidance gives the agent brief instructions on using SAME's trust
+// features. Injected once at session start — not on every prompt.
+const bootstrapGuidance = `<same-guidance>
+Notes tagged "trust: stale" may be outdated — caveat answers that rely on them.
+When you save a note about a changed decision, SAME auto-detects contradictions with existing notes.
+Use search_notes_filtered with trust_state parameter to find only validated or only stale context.
+Run mem_health for a vault quality overview.
+</same-guidance>`
+
+// SessionContext is the context block the session-bootstrap hook injects at
+// session start.
+type SessionContext struct {
+	Sections []SessionContextSection `json:"sections"`
+	Context  string                  `json:"context"`
+	Notes    int                     `json:"notes"`
+	Tokens   int                     `json:"tokens"`
+}
+
+// SessionContextSection is one part of the session context, in priority
+// order: previous_session, active_instances, pinned, decisions, stale.
+type SessionContextSection struct {
+	Name   string `json:"name"`
+	Count  int    `json:"count"`
+	Detail string `json:"detail,omitempty"`
+	Text   string `json:"text"`
+}
+
+// SystemMessage returns the context wrapped the way the hook injects it, or
+// "" when there is nothing to inject.
+func (sc SessionContext) SystemMessage() string {
+	if sc.Context == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n<session-bootstrap>\n%s\n%s\n</session-bootstrap>\n", sc.Context, bootstrapGuidance)
+}
+
+// BuildSessionContext assembles the context a new session would start with,
+// for use outside the hook. Nothing is recorded: the recovery lookup is not
+// logged and no instance is registered.
+func BuildSessionContext(db *store.DB) SessionContext {
+	return buildSessionContext(db, "", false)
+}
+
+// buildSessionContext collects the bootstrap sections by priority. When
+// record is set the previous-session lookup is logged to the database.
+func buildSessionContext(db *store.DB, sessionID string, record bool) SessionContext {
+	var sc SessionContext
+	add := func(name string, count int, detail, text string) {
+		sc.Sections = append(sc.Sections, SessionContextSection{Name: name, Count: count, Detail: detail, Text: text})
+	}
 
 	// Priority 0: Unified recovery (replaces separate session index + handoff lookup)
 	// Uses a priority cascade: handoff → instance → session index
-	recovered := RecoverPreviousSession(db, sessionID)
-	if recovered != nil {
+	recordDB := db
+	if !record {
+		recordDB = nil
+	}
+	if recovered := RecoverPreviousSession(recordDB, sessionID); recovered != nil {
 		if ctx := FormatRecoveryContext(recovered); ctx != "" {
-			sections = append(sections, ctx)
-			surfacedNotes++
-			if !quiet {
-				source := "session index"
-				switch recovered.Source {
-				case RecoveryHandoff:
-					source = "handoff"
-				case RecoveryInstance:
-					source = "instance"
-				}
-				fmt.Fprintf(os.Stderr, "same: ← previous session loaded (%s, %s)\n", source, formatAge(recovered.EndedAt))
+			source := "session index"
+			switch recovered.Source {
+			case RecoveryHandoff:
+				source = "handoff"
+			case RecoveryInstance:
+				source = "instance"
 			}
+			add("previous_session", 1, fmt.Sprintf("%s, %s", source, formatAge(recovered.EndedAt)), ctx)
+			sc.Notes++
 		}
 	}
 
 	// Priority 0b: Active instances (other Claude Code sessions)
 	if instances := findActiveInstances(sessionID); instances != "" {
-		sections = append(sections, instances)
+		add("active_instances", strings.Count(instances, "\n- "), "", instances)
 	}
 
 	// Priority 1: Pinned notes (always included — user's most important context)
 	if pinned := findPinnedNotesSection(db); pinned != "" {
-		sections = append(sections, pinned)
 		n := strings.Count(pinned, "\n### ")
 		if n == 0 {
 			n = strings.Count(pinned, "\n- ")
@@ -76,42 +154,38 @@ func runSessionBootstrap(db *store.DB, input *HookInput) hookRunResult {
 		if n == 0 {
 			n = 1
 		}
-		surfacedNotes += n
-		if !quiet {
-			fmt.Fprintf(os.Stderr, "same: 📌 %d pinned note(s) loaded\n", n)
-		}
+		add("pinned", n, "", pinned)
+		sc.Notes += n
 	}
 
 	// Priority 2: Active decisions (last 7 days)
 	if decisions := findActiveDecisions(); decisions != "" {
-		sections = append(sections, decisions)
-		if !quiet {
-			n := strings.Count(decisions, "\n## ") + strings.Count(decisions, "\n### ")
-			if n == 0 {
-				n = 1
-			}
-			fmt.Fprintf(os.Stderr, "same: ↑ %d active decision(s) loaded\n", n)
+		n := strings.Count(decisions, "\n## ") + strings.Count(decisions, "\n### ")
+		if n == 0 {
+			n = 1
 		}
+		add("decisions", n, "", decisions)
 	}
 
 	// Priority 3: Stale notes (reuse existing logic)
 	if stale := findStaleNotesSection(db); stale != "" {
-		sections = append(sections, stale)
 		n := strings.Count(stale, "\n- ")
 		if n == 0 {
 			n = 1
 		}
-		surfacedNotes += n
-		if !quiet {
-			fmt.Fprintf(os.Stderr, "same: ⚠ %d stale note(s) need review\n", n)
-		}
+		add("stale", n, "", stale)
+		sc.Notes += n
 	}
 
-	if len(sections) == 0 {
-		return hookEmpty("no bootstrap context")
+	if len(sc.Sections) == 0 {
+		return sc
 	}
 
-	context := strings.Join(sections, "\n\n")
+	texts := make([]string, len(sc.Sections))
+	for i, sec := range sc.Sections {
+		texts[i] = sec.Text
+	}
+	context := strings.Join(texts, "\n\n")
 
 	// SECURITY: Sanitize XML tags that could break the session-bootstrap wrapper
 	// or enable stored prompt injection via crafted handoff/decision content.
@@ -121,28 +195,14 @@ func runSessionBootstrap(db *store.DB, input *HookInput) hookRunResult {
 	if len(context) > bootstrapMaxChars {
 		context = context[:bootstrapMaxChars]
 	}
-
-	// Agent guidance: brief instructions on using SAME's trust features.
-	// Injected once at session start — not on every prompt.
-	guidance := `<same-guidance>
-Notes tagged "trust: stale" may be outdated — caveat answers that rely on them.
-When you save a note about a changed decision, SAME auto-detects contradictions with existing notes.
-Use search_notes_filtered with trust_state parameter to find only validated or only stale context.
-Run mem_health for a vault quality overview.
-</same-guidance>`
-
-	out := &HookOutput{
-		SystemMessage: fmt.Sprintf(
-			"\n<session-bootstrap>\n%s\n%s\n</session-bootstrap>\n",
-			context, guidance,
-		),
-	}
-	return hookInjected(out, surfacedNotes, memory.EstimateTokens(context), nil, "")
+	sc.Context = context
+	sc.Tokens = memory.EstimateTokens(context)
+	return sc
 }
 
 // isQuietMode returns true if the user has set display mode to quiet.
 func isQuietMode() bool {
-	if os.Getenv("SAME_QUIET") == "1" || os.Getenv("SAME_QUIET") == "true" {
+	if os.Getenv(config.EnvQuiet) == "1" || os.Getenv(config.EnvQuiet) == "true" {
 		return true
 	}
 	return config.DisplayMode() == "quiet"
@@ -13,11 +13,21 @@ import (
 )
 
 // runStalenessCheck queries for stale notes and surfaces them,
-// including both review-by staleness and source file divergence.
+// including review-by staleness, notes past [surfacing] stale_after_days,
+// and source file divergence.
 func runStalenessCheck(db *store.DB, _ *HookInput) hookRunResult {
 	stale := memory.FindStaleNotes(db, 5, true)
 	contextText := memory.FormatStaleNotesContext(stale)
 
+	aged := memory.FindAgedNotes(db, config.SurfacingStaleAfterDays(), 3, time.Now())
+	if agedText := memory.FormatAgedNotesContext(aged); agedText != "" {
+		if contextText != "" {
+			contextText += "\n\n"
+		}
+		// SECURITY: titles come from note content
+		contextText += sanitizeContextTags(agedText)
+	}
+
 	// Check source divergence
 	vaultPath := config.VaultPath()
 	divergenceContext := buildDivergenceContext(db, vaultPath)
@@ -35,7 +45,7 @@ func runStalenessCheck(db *store.DB, _ *HookInput) hookRunResult {
 		return hookEmpty("no stale notes")
 	}
 
-	totalNotes := len(stale)
+	totalNotes := len(stale) + len(aged)
 	if divergenceContext != "" {
 		// Count the diverged notes included in context (up to 3)
 		totalNotes += strings.Count(divergenceContext, "\n- ")
@@ -0,0 +1,101 @@
+package hooks
+
+import (
+	"sync"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+// SurfacingPreview describes what context surfacing would inject for a
+// prompt, for debugging thresholds outside a Claude Code session.
+type SurfacingPreview struct {
+	Prompt        string        `json:"prompt"`
+	Mode          string        `json:"mode,omitempty"`
+	Recency       bool          `json:"recency"`
+	Status        string        `json:"status"`
+	Reason        string        `json:"reason,omitempty"`
+	Notes         []PreviewNote `json:"notes"`
+	TotalTokens   int           `json:"total_tokens"`
+	TokenBudget   int           `json:"token_budget"`
+	Context       string        `json:"context,omitempty"`
+	SystemMessage string        `json:"system_message,omitempty"`
+}
+
+// PreviewNote is one candidate that reached the token budget stage.
+type PreviewNote struct {
+	Path        string  `json:"path"`
+	Title       string  `json:"title"`
+	ContentType string  `json:"content_type"`
+	Composite   float64 `json:"composite"`
+	Semantic    float64 `json:"semantic"`
+	Distance    float64 `json:"distance"`
+	Tokens      int     `json:"tokens"`
+	Included    bool    `json:"included"`
+	Pinned      bool    `json:"pinned,omitempty"`
+	Snippet     string  `json:"snippet,omitempty"`
+}
+
+func (p *SurfacingPreview) record(included, excluded []scored, totalTokens, budget, perNoteTokens int) {
+	p.TotalTokens = totalTokens
+	p.TokenBudget = budget
+	for _, s := range included {
+		p.Notes = append(p.Notes, previewNote(s, true, perNoteTokens))
+	}
+	for _, s := range excluded {
+		p.Notes = append(p.Notes, previewNote(s, false, perNoteTokens))
+	}
+}
+
+// previewNote converts a candidate, truncating its snippet the same way the
+// injected context does.
+func previewNote(s scored, included bool, perNoteTokens int) PreviewNote {
+	snippet := s.snippet
+	if maxChars := perNoteTokens * 4; len(snippet) > maxChars {
+		snippet = smartTruncate(snippet, maxChars)
+	}
+	return PreviewNote{
+		Path:        s.path,
+		Title:       s.title,
+		ContentType: s.contentType,
+		Composite:   s.composite,
+		Semantic:    s.semantic,
+		Distance:    s.distance,
+		Tokens:      s.tokens,
+		Included:    included,
+		Pinned:      s.pinned,
+		Snippet:     snippet,
+	}
+}
+
+// previewMu guards the package-level tuning and term-extraction state that
+// surfaceContext sets for each run.
+var previewMu sync.Mutex
+
+// PreviewContextSurfacing runs context surfacing for prompt without a
+// session, so nothing is logged to the usage or decision tables and no topic
+// state is stored. The result lists the notes that reached the token budget
+// and the exact context block that would be injected.
+//
+// It is safe to call concurrently, as the surface_context MCP tool does.
+// Noise paths are read from config by shouldSkipPath rather than set on
+// store.NoisePaths, and previewMu serializes the package state that
+// surfacing tunes per run.
+func PreviewContextSurfacing(db *store.DB, prompt string) SurfacingPreview {
+	previewMu.Lock()
+	defer previewMu.Unlock()
+
+	preview := SurfacingPreview{Prompt: prompt}
+	result := normalizeHookResult(surfaceContext(db, &HookInput{Prompt: prompt}, &preview))
+	preview.Status = result.Status
+	preview.Reason = result.Detail
+	if result.ErrorMessage != "" {
+		preview.Reason = result.ErrorMessage
+	}
+	if out := result.Output; out != nil {
+		preview.SystemMessage = out.SystemMessage
+		if out.HookSpecificOutput != nil {
+			preview.Context = out.HookSpecificOutput.AdditionalContext
+		}
+	}
+	return preview
+}
@@ -0,0 +1,188 @@
+package hooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// surfacingTrace records one context-surfacing run in machine-readable form
+// for offline analysis (regression sets, threshold tuning). Enabled with
+// SAME_TRACE; each run appends one JSON line. Unlike verbose.log it is not
+// rotated, so turn it off once you have the data you need.
+type surfacingTrace struct {
+	Time       string             `json:"time"`
+	SessionID  string             `json:"session_id,omitempty"`
+	Prompt     string             `json:"prompt"`
+	Mode       string             `json:"mode,omitempty"`
+	Recency    bool               `json:"recency"`
+	Strategy   string             `json:"strategy,omitempty"`
+	Decision   string             `json:"decision,omitempty"`
+	Jaccard    *float64           `json:"jaccard,omitempty"`
+	Status     string             `json:"status"`
+	Detail     string             `json:"detail,omitempty"`
+	Added      map[string]int     `json:"added,omitempty"`    // candidates added per search mode
+	Rejected   map[string]int     `json:"rejected,omitempty"` // candidates dropped per gate
+	Thresholds map[string]float64 `json:"thresholds"`
+	Tuning     map[string]float64 `json:"tuning,omitempty"` // [surfacing.tuning] overrides in effect
+	Selected   []traceNote        `json:"selected,omitempty"`
+	Excluded   []traceNote        `json:"excluded,omitempty"`
+	Tokens     int                `json:"tokens"`
+	DurationMS int64              `json:"duration_ms"`
+
+	start time.Time
+}
+
+// traceNote is one candidate that reached the token budget stage.
+type traceNote struct {
+	Path         string  `json:"path"`
+	ContentType  string  `json:"content_type"`
+	Composite    float64 `json:"composite"`
+	Semantic     float64 `json:"semantic"`
+	Distance     float64 `json:"distance"`
+	TitleOverlap float64 `json:"title_overlap"`
+	Tokens       int     `json:"tokens,omitempty"`
+	Pinned       bool    `json:"pinned,omitempty"`
+}
+
+// activeTrace is the trace for the current run, or nil when tracing is off.
+// Every method is a no-op on nil so call sites need no guards.
+var activeTrace *surfacingTrace
+
+func newSurfacingTrace(input *HookInput) *surfacingTrace {
+	now := time.Now()
+	return &surfacingTrace{
+		Time:       now.UTC().Format(time.RFC3339),
+		SessionID:  input.SessionID,
+		Prompt:     input.Prompt,
+		Added:      make(map[string]int),
+		Rejected:   make(map[string]int),
+		Thresholds: make(map[string]float64),
+		start:      now,
+	}
+}
+
+func (t *surfacingTrace) setMode(mode string, recency bool) {
+	if t == nil {
+		return
+	}
+	t.Mode = mode
+	t.Recency = recency
+}
+
+func (t *surfacingTrace) setStrategy(strategy string) {
+	if t == nil {
+		return
+	}
+	t.Strategy = strategy
+}
+
+// added counts n candidates contributed by a search mode.
+func (t *surfacingTrace) added(mode string, n int) {
+	if t == nil || n <= 0 {
+		return
+	}
+	t.Added[mode] += n
+}
+
+// rejected counts n candidates dropped by a gate or threshold.
+func (t *surfacingTrace) rejected(gate string, n int) {
+	if t == nil || n <= 0 {
+		return
+	}
+	t.Rejected[gate] += n
+}
+
+func (t *surfacingTrace) setThreshold(name string, v float64) {
+	if t == nil {
+		return
+	}
+	t.Thresholds[name] = v
+}
+
+// setTuning records the thresholds for this run, after any
+// [surfacing.tuning] overrides, and which of them were overridden.
+func (t *surfacingTrace) setTuning(overrides []SurfacingTuningOverride) {
+	if t == nil {
+		return
+	}
+	for name, k := range surfacingKnobs {
+		t.Thresholds[name] = k.get()
+	}
+	for _, o := range overrides {
+		if t.Tuning == nil {
+			t.Tuning = make(map[string]float64)
+		}
+		t.Tuning[o.Key] = o.Value
+	}
+}
+
+func (t *surfacingTrace) decide(decision string, jaccard float64) {
+	if t == nil {
+		return
+	}
+	t.Decision = decision
+	if jaccard >= 0 {
+		t.Jaccard = &jaccard
+	}
+}
+
+func (t *surfacingTrace) record(included, excluded []scored, totalTokens int) {
+	if t == nil {
+		return
+	}
+	t.Tokens = totalTokens
+	for _, s := range included {
+		t.Selected = append(t.Selected, newTraceNote(s))
+	}
+	for _, s := range excluded {
+		t.Excluded = append(t.Excluded, newTraceNote(s))
+	}
+}
+
+func (t *surfacingTrace) finish(result hookRunResult) {
+	result = normalizeHookResult(result)
+	t.Status = result.Status
+	t.Detail = result.Detail
+	if result.ErrorMessage != "" {
+		t.Detail = result.ErrorMessage
+	}
+	t.DurationMS = time.Since(t.start).Milliseconds()
+}
+
+func newTraceNote(s scored) traceNote {
+	return traceNote{
+		Path:         s.path,
+		ContentType:  s.contentType,
+		Composite:    s.composite,
+		Semantic:     s.semantic,
+		Distance:     s.distance,
+		TitleOverlap: s.titleOverlap,
+		Tokens:       s.tokens,
+		Pinned:       s.pinned,
+	}
+}
+
+// writeSurfacingTrace appends t as one JSON line to path. Uses 0o600
+// permissions since the trace contains prompts.
+func writeSurfacingTrace(path string, t *surfacingTrace) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		fmt.Fprintf(os.Stderr, "same: warning: failed to create trace directory: %v\n", err)
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "same: warning: failed to open surfacing trace: %v\n", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		fmt.Fprintf(os.Stderr, "same: warning: failed to write surfacing trace: %v\n", err)
+	}
+}
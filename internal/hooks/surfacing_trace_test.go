@@ -0,0 +1,93 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func TestRunContextSurfacing_WritesTraceLine(t *testing.T) {
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatalf("open memory db: %v", err)
+	}
+	defer db.Close()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	t.Setenv("SAME_TRACE", tracePath)
+
+	runContextSurfacing(db, &HookInput{Prompt: "too short"})
+	runContextSurfacing(db, &HookInput{Prompt: "/compact the conversation please now"})
+	if activeTrace != nil {
+		t.Error("activeTrace should be cleared after the run")
+	}
+
+	data, err := os.ReadFile(tracePath)
+	if err != nil {
+		t.Fatalf("read trace: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one trace line per run, got %d: %q", len(lines), data)
+	}
+	var first, second surfacingTrace
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("parse trace: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("parse trace: %v", err)
+	}
+	if first.Prompt != "too short" || first.Decision != "skip_short" || first.Status != hookStatusSkipped {
+		t.Errorf("unexpected first trace: %+v", first)
+	}
+	if second.Decision != "skip_slash" || second.Thresholds["max_distance"] != maxDistance {
+		t.Errorf("unexpected second trace: %+v", second)
+	}
+}
+
+func TestRunContextSurfacing_NoTraceWhenUnset(t *testing.T) {
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatalf("open memory db: %v", err)
+	}
+	defer db.Close()
+	t.Setenv("SAME_TRACE", "0")
+
+	runContextSurfacing(db, &HookInput{Prompt: "too short"})
+	if activeTrace != nil {
+		t.Error("tracing should stay off when SAME_TRACE=0")
+	}
+}
+
+func TestSurfacingTrace_NilSafe(t *testing.T) {
+	var tr *surfacingTrace
+	tr.setMode("exploring", false)
+	tr.setStrategy("standard")
+	tr.added("vector", 3)
+	tr.rejected("max_distance", 1)
+	tr.setThreshold("token_budget", 800)
+	tr.decide("inject", -1)
+	tr.record([]scored{{path: "a.md"}}, nil, 10)
+}
+
+func TestSurfacingTrace_Counts(t *testing.T) {
+	tr := newSurfacingTrace(&HookInput{Prompt: "p"})
+	tr.added("vector", 2)
+	tr.added("vector", 1)
+	tr.added("title", 0)
+	tr.rejected("result_cap", 2)
+	tr.decide("skip_sametopic", 0.8)
+	if tr.Added["vector"] != 3 || len(tr.Added) != 1 {
+		t.Errorf("Added = %v, want vector:3 only", tr.Added)
+	}
+	if tr.Rejected["result_cap"] != 2 {
+		t.Errorf("Rejected = %v", tr.Rejected)
+	}
+	if tr.Jaccard == nil || *tr.Jaccard != 0.8 {
+		t.Errorf("Jaccard = %v, want 0.8", tr.Jaccard)
+	}
+}
@@ -0,0 +1,114 @@
+package hooks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// tuningKnob points at one surfacing threshold that [surfacing.tuning] may
+// override. Exactly one of f and i is set.
+type tuningKnob struct {
+	f *float64
+	i *int
+}
+
+func (k tuningKnob) get() float64 {
+	if k.i != nil {
+		return float64(*k.i)
+	}
+	return *k.f
+}
+
+func (k tuningKnob) set(v float64) {
+	if k.i != nil {
+		*k.i = int(v)
+		return
+	}
+	*k.f = v
+}
+
+// surfacingKnobs maps [surfacing.tuning] keys to the thresholds they
+// override. Keys must match config.SurfacingTuningRanges.
+var surfacingKnobs = map[string]tuningKnob{
+	"max_results":               {i: &maxResults},
+	"max_distance":              {f: &maxDistance},
+	"min_composite":             {f: &minComposite},
+	"min_semantic_floor":        {f: &minSemanticFloor},
+	"min_title_overlap":         {f: &minTitleOverlap},
+	"high_tier_overlap":         {f: &highTierOverlap},
+	"recency_relevance_weight":  {f: &recencyRelWeight},
+	"recency_recency_weight":    {f: &recencyRecWeight},
+	"recency_confidence_weight": {f: &recencyConfWeight},
+	"recency_min_composite":     {f: &recencyMinComposite},
+	"recency_max_results":       {i: &recencyMaxResults},
+}
+
+// surfacingDefaults holds the built-in threshold values, captured before
+// any override is applied.
+var surfacingDefaults = func() map[string]float64 {
+	d := make(map[string]float64, len(surfacingKnobs))
+	for name, k := range surfacingKnobs {
+		d[name] = k.get()
+	}
+	return d
+}()
+
+// SurfacingTuningOverride is a threshold that differs from its default.
+type SurfacingTuningOverride struct {
+	Key     string  `json:"key"`
+	Value   float64 `json:"value"`
+	Default float64 `json:"default"`
+}
+
+// applySurfacingTuning resets every threshold to its default, then applies
+// overrides (already validated by config.SurfacingTuning). Resetting first
+// keeps a long-lived process from carrying overrides across config changes.
+// When any override is in effect and verbose logging is on, a warning line
+// goes to the verbose log so off-default runs are easy to spot.
+func applySurfacingTuning(overrides map[string]float64) {
+	for name, k := range surfacingKnobs {
+		k.set(surfacingDefaults[name])
+	}
+	for name, v := range overrides {
+		if k, ok := surfacingKnobs[name]; ok {
+			k.set(v)
+		}
+	}
+	if active := surfacingTuningOverrides(); len(active) > 0 && isVerbose() {
+		parts := make([]string, len(active))
+		for i, o := range active {
+			parts[i] = fmt.Sprintf("%s=%g (default %g)", o.Key, o.Value, o.Default)
+		}
+		writeVerboseLog(fmt.Sprintf("%s! surfacing.tuning overrides in effect: %s%s\n",
+			cYellow, strings.Join(parts, ", "), cReset))
+	}
+}
+
+// surfacingTuningOverrides lists the thresholds currently off their
+// defaults, sorted by key.
+func surfacingTuningOverrides() []SurfacingTuningOverride {
+	var out []SurfacingTuningOverride
+	for name, k := range surfacingKnobs {
+		if v := k.get(); v != surfacingDefaults[name] {
+			out = append(out, SurfacingTuningOverride{Key: name, Value: v, Default: surfacingDefaults[name]})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// SurfacingTuningOverrides reports which [surfacing.tuning] overrides the
+// given config values would put in effect, for 'same doctor'.
+func SurfacingTuningOverrides(tuning map[string]float64) []SurfacingTuningOverride {
+	var out []SurfacingTuningOverride
+	for name, v := range tuning {
+		def, ok := surfacingDefaults[name]
+		if !ok || v == def {
+			continue
+		}
+		out = append(out, SurfacingTuningOverride{Key: name, Value: v, Default: def})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
@@ -0,0 +1,54 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
+)
+
+func TestSurfacingKnobsMatchConfigRanges(t *testing.T) {
+	for name := range config.SurfacingTuningRanges {
+		if _, ok := surfacingKnobs[name]; !ok {
+			t.Errorf("config key %q has no surfacing knob", name)
+		}
+	}
+	for name := range surfacingKnobs {
+		r, ok := config.SurfacingTuningRanges[name]
+		if !ok {
+			t.Errorf("knob %q has no config range", name)
+			continue
+		}
+		if d := surfacingDefaults[name]; d < r[0] || d > r[1] {
+			t.Errorf("default %s = %g is outside its range %v", name, d, r)
+		}
+	}
+}
+
+func TestApplySurfacingTuning(t *testing.T) {
+	t.Cleanup(func() { applySurfacingTuning(nil) })
+
+	applySurfacingTuning(map[string]float64{"max_distance": 17.5, "recency_max_results": 5})
+	if maxDistance != 17.5 || recencyMaxResults != 5 {
+		t.Fatalf("maxDistance=%g recencyMaxResults=%d, want 17.5 and 5", maxDistance, recencyMaxResults)
+	}
+	got := surfacingTuningOverrides()
+	if len(got) != 2 || got[0].Key != "max_distance" || got[0].Default != 16.3 || got[1].Key != "recency_max_results" {
+		t.Errorf("overrides = %+v", got)
+	}
+
+	// A later run without overrides restores every default.
+	applySurfacingTuning(nil)
+	if maxDistance != 16.3 || recencyMaxResults != 3 {
+		t.Errorf("after reset maxDistance=%g recencyMaxResults=%d, want defaults", maxDistance, recencyMaxResults)
+	}
+	if got := surfacingTuningOverrides(); len(got) != 0 {
+		t.Errorf("overrides after reset = %+v, want none", got)
+	}
+}
+
+func TestSurfacingTuningOverridesSkipsDefaults(t *testing.T) {
+	got := SurfacingTuningOverrides(map[string]float64{"min_composite": 0.70, "min_semantic_floor": 0.3})
+	if len(got) != 1 || got[0].Key != "min_semantic_floor" || got[0].Value != 0.3 || got[0].Default != 0.25 {
+		t.Errorf("overrides = %+v, want only min_semantic_floor", got)
+	}
+}
@@ -1,9 +1,11 @@
 package hooks
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
+	"github.com/sgx-labs/statelessagent/internal/config"
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
@@ -26,11 +28,18 @@ var injectionPatterns = []string{
 }
 
 // smartTruncate truncates text at a sentence or paragraph boundary near maxLen.
-// Falls back to word boundary if no sentence break is found.
+// Falls back to word boundary if no sentence break is found. The cut never
+// lands inside a fenced code block (see store.FenceSafePrefix).
 func smartTruncate(text string, maxLen int) string {
 	if len(text) <= maxLen {
 		return text
 	}
+	cut := breakNear(text, maxLen)
+	return store.FenceSafePrefix(text, len(cut))
+}
+
+// breakNear returns text cut at the best boundary before maxLen.
+func breakNear(text string, maxLen int) string {
 
 	// Look for the last sentence-ending punctuation before maxLen
 	// Search in the last 30% of the allowed range for a good break
@@ -92,9 +101,11 @@ func stripLeadingHeadings(text string) string {
 // queryBiasedSnippet extracts the most query-relevant window of text.
 // Instead of always showing the first N chars (which may just be an intro),
 // it finds the paragraph with the most query-term overlap and starts there.
-// Falls back to the beginning if no query terms match.
+// Falls back to the beginning if no query terms match. Frontmatter is
+// skipped, and a window that would open inside a fenced code block starts
+// at its opening fence instead.
 func queryBiasedSnippet(text string, maxLen int) string {
-	text = stripLeadingHeadings(text)
+	text = stripLeadingHeadings(store.SnippetSource(text))
 	if text == "" || len(text) <= maxLen {
 		return text
 	}
@@ -153,6 +164,7 @@ func queryBiasedSnippet(text string, maxLen int) string {
 	if offset >= len(text) {
 		return smartTruncate(text, maxLen)
 	}
+	offset = store.FenceStart(text, offset)
 
 	return smartTruncate(text[offset:], maxLen)
 }
@@ -173,25 +185,60 @@ func contentTermCoverage(text string, terms []string) float64 {
 	return float64(matches) / float64(len(terms))
 }
 
-// sanitizeSnippet removes prompt injection patterns from snippet text.
-// Primary detection uses go-promptguard's multi-detector (pattern matching +
-// statistical analysis). The legacy string-match list is kept as a fallback
-// for belt-and-suspenders defense.
-func sanitizeSnippet(text string) string {
-	// Primary: go-promptguard multi-detector
-	if detectInjection(text) {
-		return "[content filtered for security]"
+// filteredSnippet replaces snippets flagged as prompt injection.
+const filteredSnippet = "[content filtered for security]"
+
+// sanitizeSnippet removes prompt injection patterns from the snippet of the
+// note at path. [security] injection_detection picks the checks: strict
+// (default) runs go-promptguard's multi-detector (pattern matching +
+// statistical analysis) and then the legacy string-match list, lenient runs
+// only the string-match list, and off returns text unchanged. Notes under
+// [security] trusted_paths are returned unchanged too. When verbose mode is
+// on, the note and the trigger are written to the verbose log.
+func sanitizeSnippet(path, text string) string {
+	mode := config.InjectionDetectionMode()
+	if mode == config.InjectionDetectionOff || isTrustedSnippetPath(path) {
+		return text
+	}
+	if mode == config.InjectionDetectionStrict {
+		if hit, types := detectInjection(text); hit {
+			logFilteredSnippet(path, "promptguard: "+types)
+			return filteredSnippet
+		}
 	}
-	// Fallback: legacy pattern matching
 	lower := strings.ToLower(text)
 	for _, pattern := range injectionPatterns {
 		if strings.Contains(lower, strings.ToLower(pattern)) {
-			return "[content filtered for security]"
+			logFilteredSnippet(path, fmt.Sprintf("pattern %q", pattern))
+			return filteredSnippet
 		}
 	}
 	return text
 }
 
+// isTrustedSnippetPath reports whether path is under a [security]
+// trusted_paths prefix. _PRIVATE/ notes are never trusted.
+func isTrustedSnippetPath(path string) bool {
+	if path == "" || isPrivatePath(path) {
+		return false
+	}
+	for _, prefix := range config.InjectionTrustedPaths() {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// logFilteredSnippet records why a snippet was filtered so false positives
+// can be traced to a note.
+func logFilteredSnippet(path, trigger string) {
+	if !isVerbose() {
+		return
+	}
+	writeVerboseLog(fmt.Sprintf("Filtered snippet from %s (%s)\n", path, trigger))
+}
+
 // sanitizeContextTags strips XML-like tags from note content that could
 // break structural wrappers (vault-context, plugin-context, session-bootstrap,
 // vault-handoff, vault-decisions, same-diagnostic) and enable indirect prompt
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -51,7 +50,7 @@ func pickVerb(verbs []string) string {
 
 // verboseLogPath returns the file path for verbose output.
 func verboseLogPath() string {
-	return filepath.Join(config.DataDir(), "verbose.log")
+	return config.VerboseLogPath()
 }
 
 // pendingVerboseMsg accumulates the verbose status line for this invocation.
@@ -214,6 +213,8 @@ func logDecision(db *store.DB, sessionID, prompt, mode string, jaccard float64,
 		snippet = snippet[:80]
 	}
 
+	activeTrace.decide(decision, jaccard)
+
 	// Styled verbose output (inject is handled separately with titles/tokens)
 	if decision != "inject" {
 		verboseDecision(decision, mode, jaccard, prompt, nil, 0)
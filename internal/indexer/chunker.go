@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/sgx-labs/statelessagent/internal/config"
 )
@@ -12,11 +13,17 @@ import (
 type Chunk struct {
 	Heading string
 	Text    string
+
+	// StartLine and EndLine locate the chunk in the note body (1-based,
+	// inclusive). Zero when the chunk could not be located.
+	StartLine int
+	EndLine   int
 }
 
 var (
-	h2Split = regexp.MustCompile(`(?m)^## `)
-	h3Split = regexp.MustCompile(`(?m)^### `)
+	// h12Heading matches H1/H2 heading lines; group 1 is the #s, group 2 the text.
+	h12Heading = regexp.MustCompile(`(?m)^(#{1,2})[ \t]+(.+?)[ \t]*$`)
+	h3Heading  = regexp.MustCompile(`(?m)^###[ \t]+(.+?)[ \t]*$`)
 
 	// turnPattern matches conversational turn markers like **User:**, **Assistant:**,
 	// User:, Assistant:, Human:, AI: at the start of a line.
@@ -143,64 +150,173 @@ func ChunkByTurns(body string) []Chunk {
 	return chunks
 }
 
-// ChunkByHeadings splits note body by H2 headings, with H3 sub-splitting for large sections.
+// ChunkOptions controls how ChunkNote splits a note.
+type ChunkOptions struct {
+	Strategy string // config.ChunkStrategyHeadings or config.ChunkStrategyFixed
+	Overlap  int    // characters repeated between consecutive size-split chunks
+}
+
+// ChunkNote splits a note body into chunks. Short notes are kept whole;
+// conversational notes are split by turns under the headings strategy.
+// Oversized chunks are always split further by size, and size-based splits
+// share opts.Overlap characters so passages straddling a boundary stay
+// retrievable.
+//
+// Each chunk's StartLine/EndLine are set relative to body.
+func ChunkNote(body string, opts ChunkOptions) []Chunk {
+	chunks := chunkNote(body, opts)
+	locateLines(body, chunks)
+	return chunks
+}
+
+func chunkNote(body string, opts ChunkOptions) []Chunk {
+	var chunks []Chunk
+	switch {
+	case opts.Strategy == config.ChunkStrategyFixed:
+		if len(body) <= config.ChunkTokenThreshold {
+			return []Chunk{{Heading: "(full)", Text: body}}
+		}
+		chunks = ChunkBySizeOverlap(body, config.MaxEmbedChars, opts.Overlap)
+	case ShouldChunkByTurns(body):
+		turnChunks := ChunkByTurns(body)
+		headingChunks := ChunkByHeadings(body)
+		// Use whichever produces more chunks (better granularity).
+		if len(turnChunks) >= len(headingChunks) {
+			chunks = turnChunks
+		} else {
+			chunks = headingChunks
+		}
+	case len(body) > config.ChunkTokenThreshold:
+		chunks = ChunkByHeadings(body)
+	default:
+		return []Chunk{{Heading: "(full)", Text: body}}
+	}
+	return splitOversized(chunks, opts.Overlap)
+}
+
+// locateSlack allows for whitespace the chunkers trimmed or rewrote when
+// estimating where a chunk's last line sits.
+const locateSlack = 64
+
+// locateLines sets StartLine/EndLine on each chunk by finding its first and
+// last content lines in body. Chunkers trim whitespace and rebuild heading
+// lines, so only individual lines are matched; when a line can't be found
+// the next one is tried. Chunks are searched from the previous chunk's
+// start because size-split chunks overlap. Chunks with no matching lines
+// keep zero.
+func locateLines(body string, chunks []Chunk) {
+	cursor := 0
+	for i := range chunks {
+		lines := contentLines(chunks[i].Text)
+		startPos := -1
+		for _, l := range lines {
+			if idx := strings.Index(body[cursor:], l); idx >= 0 {
+				startPos = cursor + idx
+				break
+			}
+		}
+		if startPos < 0 {
+			continue
+		}
+
+		endPos := startPos
+		for j := len(lines) - 1; j >= 0; j-- {
+			l := lines[j]
+			// Start near where the last line should be so a line repeated
+			// earlier in the chunk doesn't cut the range short.
+			from := min(len(body), startPos+max(0, len(chunks[i].Text)-len(l)-locateSlack))
+			idx := strings.Index(body[from:], l)
+			if idx < 0 && from != startPos {
+				from = startPos
+				idx = strings.Index(body[from:], l)
+			}
+			if idx >= 0 {
+				endPos = from + idx + len(l) - 1
+				break
+			}
+		}
+
+		chunks[i].StartLine = 1 + strings.Count(body[:startPos], "\n")
+		chunks[i].EndLine = 1 + strings.Count(body[:endPos], "\n")
+		cursor = startPos
+	}
+}
+
+// contentLines returns the trimmed, non-empty lines of text.
+func contentLines(text string) []string {
+	var lines []string
+	for _, l := range strings.Split(text, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// splitOversized splits chunks larger than the embedding limit by size.
+// Pieces of a named section keep the section's heading path so snippets
+// still show where they came from.
+func splitOversized(chunks []Chunk, overlap int) []Chunk {
+	var final []Chunk
+	for _, c := range chunks {
+		if len(c.Text) <= config.MaxEmbedChars {
+			final = append(final, c)
+			continue
+		}
+		parts := ChunkBySizeOverlap(c.Text, config.MaxEmbedChars, overlap)
+		if c.Heading != "" && !strings.HasPrefix(c.Heading, "(") {
+			for i := range parts {
+				parts[i].Heading = fmt.Sprintf("%s %s", c.Heading, partHeading(i+1))
+			}
+		}
+		final = append(final, parts...)
+	}
+	return final
+}
+
+// ChunkByHeadings splits note body on H1 and H2 headings, with H3
+// sub-splitting for large sections. Each chunk's Heading is its heading path
+// (e.g. "Guide > Install > Linux"). Headings inside fenced code blocks are
+// ignored.
 func ChunkByHeadings(body string) []Chunk {
-	parts := h2Split.Split(body, -1)
+	locs := h12Heading.FindAllStringSubmatchIndex(maskFencedCodeBlocks(body), -1)
 	var chunks []Chunk
 
-	// First part is intro (before first H2)
-	if strings.TrimSpace(parts[0]) != "" {
-		chunks = append(chunks, Chunk{Heading: "(intro)", Text: strings.TrimSpace(parts[0])})
+	// Intro is everything before the first heading.
+	introEnd := len(body)
+	if len(locs) > 0 {
+		introEnd = locs[0][0]
+	}
+	if intro := strings.TrimSpace(body[:introEnd]); intro != "" {
+		chunks = append(chunks, Chunk{Heading: "(intro)", Text: intro})
 	}
 
-	// Find H2 headings for labeling
-	headingLocs := h2Split.FindAllStringIndex(body, -1)
-	for i, part := range parts[1:] {
-		_ = headingLocs // suppress warning
-		lines := strings.SplitN(part, "\n", 2)
-		heading := strings.TrimSpace(lines[0])
-		text := ""
-		if len(lines) > 1 {
-			text = strings.TrimSpace(lines[1])
+	var h1 string
+	for i, loc := range locs {
+		level := loc[3] - loc[2]
+		heading := strings.TrimSpace(body[loc[4]:loc[5]])
+		path := heading
+		if level == 1 {
+			h1 = heading
+		} else {
+			path = joinHeadingPath(h1, heading)
 		}
+
+		end := len(body)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		text := strings.TrimSpace(body[loc[1]:end])
 		if text == "" {
 			continue
 		}
 
-		fullText := "## " + heading + "\n" + text
-
-		// If H2 section is too large, try splitting by H3
+		fullText := strings.Repeat("#", level) + " " + heading + "\n" + text
 		if len(fullText) > config.MaxEmbedChars {
-			h3Parts := h3Split.Split(fullText, -1)
-			if len(h3Parts) > 1 {
-				if strings.TrimSpace(h3Parts[0]) != "" {
-					chunks = append(chunks, Chunk{
-						Heading: heading,
-						Text:    strings.TrimSpace(h3Parts[0]),
-					})
-				}
-				for _, h3Part := range h3Parts[1:] {
-					h3Lines := strings.SplitN(h3Part, "\n", 2)
-					h3Heading := strings.TrimSpace(h3Lines[0])
-					h3Text := ""
-					if len(h3Lines) > 1 {
-						h3Text = strings.TrimSpace(h3Lines[1])
-					}
-					if h3Text != "" {
-						chunks = append(chunks, Chunk{
-							Heading: heading + " > " + h3Heading,
-							Text:    "### " + h3Heading + "\n" + h3Text,
-						})
-					}
-				}
-			} else {
-				chunks = append(chunks, Chunk{Heading: heading, Text: fullText})
-			}
+			chunks = append(chunks, chunkByH3(path, fullText)...)
 		} else {
-			chunks = append(chunks, Chunk{Heading: heading, Text: fullText})
+			chunks = append(chunks, Chunk{Heading: path, Text: fullText})
 		}
-
-		_ = i
 	}
 
 	if len(chunks) == 0 {
@@ -209,11 +325,94 @@ func ChunkByHeadings(body string) []Chunk {
 	return chunks
 }
 
+// chunkByH3 splits an oversized section on its H3 headings. Returns the
+// section unchanged if it has no H3s.
+func chunkByH3(path, section string) []Chunk {
+	locs := h3Heading.FindAllStringSubmatchIndex(maskFencedCodeBlocks(section), -1)
+	if len(locs) == 0 {
+		return []Chunk{{Heading: path, Text: section}}
+	}
+
+	var chunks []Chunk
+	if lead := strings.TrimSpace(section[:locs[0][0]]); lead != "" {
+		chunks = append(chunks, Chunk{Heading: path, Text: lead})
+	}
+	for i, loc := range locs {
+		h3 := strings.TrimSpace(section[loc[2]:loc[3]])
+		end := len(section)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		text := strings.TrimSpace(section[loc[1]:end])
+		if text == "" {
+			continue
+		}
+		chunks = append(chunks, Chunk{
+			Heading: joinHeadingPath(path, h3),
+			Text:    "### " + h3 + "\n" + text,
+		})
+	}
+	return chunks
+}
+
+func joinHeadingPath(parent, heading string) string {
+	if parent == "" {
+		return heading
+	}
+	return parent + " > " + heading
+}
+
 // ChunkBySize splits text into chunks at paragraph boundaries.
 func ChunkBySize(text string, maxChars int) []Chunk {
+	return ChunkBySizeOverlap(text, maxChars, 0)
+}
+
+// ChunkBySizeOverlap splits text at paragraph boundaries like ChunkBySize,
+// then prefixes every chunk after the first with up to overlap characters
+// from the end of the previous chunk. Chunks are packed to leave room for
+// the overlap so they still fit within maxChars.
+func ChunkBySizeOverlap(text string, maxChars, overlap int) []Chunk {
 	if maxChars <= 0 {
 		maxChars = config.MaxEmbedChars
 	}
+	if overlap < 0 {
+		overlap = 0
+	} else if overlap > maxChars/4 {
+		overlap = maxChars / 4
+	}
+	chunks := chunkParagraphs(text, maxChars-overlap)
+	if overlap == 0 || len(chunks) < 2 {
+		return chunks
+	}
+	for i := len(chunks) - 1; i > 0; i-- {
+		if tail := overlapTail(chunks[i-1].Text, overlap); tail != "" {
+			chunks[i].Text = tail + "\n\n" + chunks[i].Text
+		}
+	}
+	return chunks
+}
+
+// overlapTail returns at most n trailing bytes of text, starting at a word
+// boundary so the carried-over context doesn't begin mid-word.
+func overlapTail(text string, n int) string {
+	if len(text) <= n {
+		return strings.TrimSpace(text)
+	}
+	tail := text[len(text)-n:]
+	if idx := strings.IndexAny(tail, " \t\n"); idx >= 0 {
+		tail = tail[idx:]
+	} else {
+		// No whitespace: step forward to a valid UTF-8 boundary.
+		for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+			tail = tail[1:]
+		}
+	}
+	return strings.TrimSpace(tail)
+}
+
+// chunkParagraphs greedily packs paragraphs into chunks of at most maxChars
+// (a single oversized paragraph becomes its own chunk).
+func chunkParagraphs(text string, maxChars int) []Chunk {
 	paragraphs := strings.Split(text, "\n\n")
 	var chunks []Chunk
 	var current strings.Builder
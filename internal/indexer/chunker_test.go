@@ -3,6 +3,8 @@ package indexer
 import (
 	"strings"
 	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
 )
 
 // --- ShouldChunkByTurns tests ---
@@ -263,3 +265,198 @@ func TestChunkByHeadingsNoContent(t *testing.T) {
 		t.Errorf("expected (intro) heading, got %q", chunks[0].Heading)
 	}
 }
+
+// --- Heading paths and chunk strategies ---
+
+func TestChunkByHeadings_HeadingPaths(t *testing.T) {
+	body := "# Guide\n\nWhat this guide covers.\n\n## Install\n\nRun the installer.\n\n# Reference\n\n## Flags\n\nAll the flags.\n"
+	chunks := ChunkByHeadings(body)
+
+	want := []string{"Guide", "Guide > Install", "Reference > Flags"}
+	if len(chunks) != len(want) {
+		t.Fatalf("expected %d chunks, got %d: %+v", len(want), len(chunks), chunks)
+	}
+	for i, h := range want {
+		if chunks[i].Heading != h {
+			t.Errorf("chunk %d heading = %q, want %q", i, chunks[i].Heading, h)
+		}
+	}
+	if !strings.HasPrefix(chunks[1].Text, "## Install\n") {
+		t.Errorf("section chunk should start with its heading line, got %q", chunks[1].Text)
+	}
+}
+
+func TestChunkByHeadings_IgnoresHeadingsInCodeFences(t *testing.T) {
+	body := "## Setup\n\n```bash\n# install deps\nmake deps\n```\n\n## Usage\n\nRun it.\n"
+	chunks := ChunkByHeadings(body)
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d: %+v", len(chunks), chunks)
+	}
+	if !strings.Contains(chunks[0].Text, "# install deps") {
+		t.Errorf("code comment should stay inside the Setup chunk, got %q", chunks[0].Text)
+	}
+}
+
+func TestChunkByHeadings_OversizedSectionSplitsByH3(t *testing.T) {
+	filler := strings.Repeat("word ", config.MaxEmbedChars/5)
+	body := "# Manual\n\n## Commands\n\nOverview.\n\n### search\n\n" + filler + "\n\n### index\n\nIndex things.\n"
+	chunks := ChunkByHeadings(body)
+
+	var headings []string
+	for _, c := range chunks {
+		headings = append(headings, c.Heading)
+	}
+	joined := strings.Join(headings, "|")
+	for _, want := range []string{"Manual > Commands", "Manual > Commands > search", "Manual > Commands > index"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected heading %q in %v", want, headings)
+		}
+	}
+}
+
+func TestChunkNote_Strategies(t *testing.T) {
+	short := "## A\n\nShort note.\n"
+	for _, strategy := range []string{config.ChunkStrategyHeadings, config.ChunkStrategyFixed} {
+		chunks := ChunkNote(short, ChunkOptions{Strategy: strategy})
+		if len(chunks) != 1 || chunks[0].Heading != "(full)" {
+			t.Errorf("%s: short note should be one (full) chunk, got %+v", strategy, chunks)
+		}
+	}
+
+	section := strings.Repeat("Some sentence about the topic. ", 120)
+	long := "## Alpha\n\n" + section + "\n\n## Beta\n\n" + section + "\n\n## Gamma\n\n" + section + "\n"
+	if len(long) <= config.ChunkTokenThreshold {
+		t.Fatalf("test body too short: %d", len(long))
+	}
+
+	byHeadings := ChunkNote(long, ChunkOptions{Strategy: config.ChunkStrategyHeadings})
+	if len(byHeadings) != 3 || byHeadings[0].Heading != "Alpha" || byHeadings[2].Heading != "Gamma" {
+		t.Errorf("headings strategy: unexpected chunks %+v", chunkHeadings(byHeadings))
+	}
+
+	fixed := ChunkNote(long, ChunkOptions{Strategy: config.ChunkStrategyFixed})
+	if len(fixed) == 0 || fixed[0].Heading != "(part 1)" {
+		t.Errorf("fixed strategy: expected (part N) chunks, got %v", chunkHeadings(fixed))
+	}
+	for _, c := range fixed {
+		if len(c.Text) > config.MaxEmbedChars {
+			t.Errorf("fixed chunk exceeds MaxEmbedChars: %d", len(c.Text))
+		}
+	}
+}
+
+func TestChunkNote_OversizedSectionKeepsHeadingPath(t *testing.T) {
+	para := strings.Repeat("x", 2000)
+	var paras []string
+	for i := 0; i < 6; i++ {
+		paras = append(paras, para)
+	}
+	body := "# Doc\n\n## Big\n\n" + strings.Join(paras, "\n\n") + "\n"
+	chunks := ChunkNote(body, ChunkOptions{Strategy: config.ChunkStrategyHeadings})
+	if len(chunks) < 2 {
+		t.Fatalf("expected oversized section to be split, got %d chunk(s)", len(chunks))
+	}
+	for _, c := range chunks {
+		if !strings.HasPrefix(c.Heading, "Doc > Big (part ") {
+			t.Errorf("expected heading path with part suffix, got %q", c.Heading)
+		}
+	}
+}
+
+func chunkHeadings(chunks []Chunk) []string {
+	var out []string
+	for _, c := range chunks {
+		out = append(out, c.Heading)
+	}
+	return out
+}
+
+func TestChunkBySizeOverlap(t *testing.T) {
+	paras := []string{
+		strings.Repeat("alpha ", 25),
+		strings.Repeat("bravo ", 25),
+		strings.Repeat("charlie ", 20),
+	}
+	text := strings.Join(paras, "\n\n")
+
+	plain := ChunkBySize(text, 250)
+	overlapped := ChunkBySizeOverlap(text, 250, 50)
+	if len(overlapped) < 2 {
+		t.Fatalf("expected multiple chunks, got %d", len(overlapped))
+	}
+	if overlapped[0].Text != plain[0].Text {
+		t.Errorf("first chunk should not carry overlap: %q", overlapped[0].Text)
+	}
+	for i := 1; i < len(overlapped); i++ {
+		if !strings.HasPrefix(overlapped[i].Text, "alpha") && !strings.HasPrefix(overlapped[i].Text, "bravo") {
+			t.Errorf("chunk %d should start with the previous chunk's tail, got %q", i, overlapped[i].Text[:20])
+		}
+		if len(overlapped[i].Text) > 250 {
+			t.Errorf("chunk %d exceeds maxChars with overlap: %d", i, len(overlapped[i].Text))
+		}
+	}
+	if overlapped[1].Heading != "(part 2)" {
+		t.Errorf("overlap should not change part labels, got %q", overlapped[1].Heading)
+	}
+
+	if got := ChunkBySizeOverlap(text, 250, 0); len(got) != len(plain) || got[1].Text != plain[1].Text {
+		t.Error("zero overlap should match ChunkBySize")
+	}
+}
+
+func TestOverlapTail(t *testing.T) {
+	if got := overlapTail("the quick brown fox", 8); got != "fox" {
+		t.Errorf("overlapTail should start at a word boundary, got %q", got)
+	}
+	if got := overlapTail("short", 50); got != "short" {
+		t.Errorf("short text should be returned whole, got %q", got)
+	}
+	if got := overlapTail("ééééé", 3); got != "é" {
+		t.Errorf("overlapTail should not split a rune, got %q", got)
+	}
+}
+
+func TestChunkNote_LineRanges(t *testing.T) {
+	filler := strings.Repeat("Some detail about the design that pads the section out.\n", 80)
+	content := "---\ntitle: Design\n---\n" +
+		"# Design\n\nIntro paragraph.\n\n" +
+		"## Storage\n\n" + filler + "Storage ends here.\n\n" +
+		"## Retries\n\n" + filler + "Retries end here.\n"
+
+	parsed := ParseNote(content)
+	if parsed.BodyLine != 4 {
+		t.Fatalf("BodyLine = %d, want 4", parsed.BodyLine)
+	}
+	chunks := ChunkNote(parsed.Body, ChunkOptions{})
+	if len(chunks) < 2 {
+		t.Fatalf("expected heading chunks, got %d", len(chunks))
+	}
+
+	fileLines := strings.Split(content, "\n")
+	lineOf := func(s string) int {
+		for i, l := range fileLines {
+			if l == s {
+				return i + 1
+			}
+		}
+		t.Fatalf("line %q not in fixture", s)
+		return 0
+	}
+	last := chunks[len(chunks)-1]
+	if got := fileLine(last.StartLine, parsed.BodyLine); got != lineOf("## Retries") {
+		t.Errorf("last chunk starts at file line %d, want %d", got, lineOf("## Retries"))
+	}
+	if got := fileLine(last.EndLine, parsed.BodyLine); got != lineOf("Retries end here.") {
+		t.Errorf("last chunk ends at file line %d, want %d", got, lineOf("Retries end here."))
+	}
+	for i, c := range chunks {
+		if c.StartLine == 0 || c.EndLine < c.StartLine {
+			t.Errorf("chunk %d has bad range %d-%d", i, c.StartLine, c.EndLine)
+		}
+	}
+
+	short := ChunkNote("one\ntwo\n", ChunkOptions{})
+	if short[0].StartLine != 1 || short[0].EndLine != 2 {
+		t.Errorf("full-note chunk range = %d-%d, want 1-2", short[0].StartLine, short[0].EndLine)
+	}
+}
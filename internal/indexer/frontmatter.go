@@ -21,12 +21,18 @@ type NoteMeta struct {
 	Confidence       float64  `yaml:"confidence"`        // 0.0-1.0 confidence score from frontmatter
 	ProvenanceSource string   `yaml:"provenance_source"` // absolute path to original file
 	ProvenanceHash   string   `yaml:"provenance_hash"`   // SHA256 at import time
+	Evergreen        bool     `yaml:"evergreen"`         // never flagged stale for its age
+	Expires          string   `yaml:"expires"`           // date after which the note is not auto-surfaced
 }
 
 // ParsedNote holds the parsed content of a markdown note.
 type ParsedNote struct {
 	Meta NoteMeta
 	Body string
+
+	// BodyLine is the file line (1-based) on which Body starts, so line
+	// numbers within Body can be mapped back to the file.
+	BodyLine int
 }
 
 // ParseNote parses a markdown file's frontmatter and body.
@@ -35,7 +41,7 @@ func ParseNote(content string) ParsedNote {
 	body, err := frontmatter.Parse(strings.NewReader(content), &meta)
 	if err != nil {
 		// If frontmatter parsing fails, treat entire content as body
-		return ParsedNote{Body: content}
+		return ParsedNote{Body: content, BodyLine: 1}
 	}
 
 	// Use alternate review-by key if primary is empty
@@ -43,8 +49,23 @@ func ParseNote(content string) ParsedNote {
 		meta.ReviewBy = meta.ReviewByAlt
 	}
 
+	bodyLine := 1
+	if strings.HasSuffix(content, string(body)) {
+		bodyLine += strings.Count(content[:len(content)-len(body)], "\n")
+	}
 	return ParsedNote{
-		Meta: meta,
-		Body: string(body),
+		Meta:     meta,
+		Body:     string(body),
+		BodyLine: bodyLine,
 	}
 }
+
+// FrontmatterError returns the error from parsing content's frontmatter, or
+// nil when it parses or there is none. ParseNote treats a note with broken
+// frontmatter as all body, silently dropping its metadata; this is how
+// callers find such notes.
+func FrontmatterError(content string) error {
+	var meta NoteMeta
+	_, err := frontmatter.Parse(strings.NewReader(content), &meta)
+	return err
+}
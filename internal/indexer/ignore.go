@@ -8,11 +8,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
 // DefaultSameignore is the default content for .sameignore files created by `same init`.
 const DefaultSameignore = `# .sameignore — files and directories to exclude from SAME indexing
-# Works like .gitignore: glob patterns, one per line, # for comments
+# Works like .gitignore: glob patterns, one per line, # for comments.
+# Prefix with ! to re-include, / to anchor to this directory. Subdirectories
+# may have their own .sameignore. _PRIVATE/ is always excluded regardless.
 
 # Build artifacts and dependencies
 node_modules/
@@ -88,8 +91,10 @@ type IgnorePatterns struct {
 }
 
 type ignorePattern struct {
-	pattern string
-	isDir   bool // pattern ends with / — only matches directories
+	pattern  string
+	isDir    bool // pattern ends with / — only matches directories
+	negate   bool // pattern starts with ! — re-includes a previous match
+	anchored bool // pattern starts with / — matches from the .sameignore directory only
 }
 
 // LoadSameignore reads and parses a .sameignore file from the vault root.
@@ -127,9 +132,20 @@ func parseSameignoreFromScanner(scanner *bufio.Scanner) *IgnorePatterns {
 		}
 
 		p := ignorePattern{pattern: line}
-		if strings.HasSuffix(line, "/") {
+		if strings.HasPrefix(p.pattern, "!") {
+			p.negate = true
+			p.pattern = strings.TrimPrefix(p.pattern, "!")
+		}
+		if strings.HasSuffix(p.pattern, "/") {
 			p.isDir = true
-			p.pattern = strings.TrimSuffix(line, "/")
+			p.pattern = strings.TrimSuffix(p.pattern, "/")
+		}
+		if strings.HasPrefix(p.pattern, "/") {
+			p.anchored = true
+			p.pattern = strings.TrimPrefix(p.pattern, "/")
+		}
+		if p.pattern == "" {
+			continue
 		}
 
 		ip.patterns = append(ip.patterns, p)
@@ -142,54 +158,49 @@ func parseSameignoreFromScanner(scanner *bufio.Scanner) *IgnorePatterns {
 // relPath should use forward slashes (e.g., "subdir/file.md").
 // isDir should be true if the path is a directory.
 func (ip *IgnorePatterns) ShouldIgnore(relPath string, isDir bool) bool {
+	_, ignored := ip.match(relPath, isDir)
+	return ignored
+}
+
+// match reports whether any pattern matches relPath and, if so, whether the
+// last matching pattern ignores it. As in gitignore, later patterns override
+// earlier ones, so "!keep.md" after "*.md" re-includes keep.md.
+func (ip *IgnorePatterns) match(relPath string, isDir bool) (matched, ignored bool) {
 	if ip == nil || len(ip.patterns) == 0 {
-		return false
+		return false, false
 	}
 
 	// Normalize to forward slashes
 	relPath = filepath.ToSlash(relPath)
-	name := filepath.Base(relPath)
 
 	for _, p := range ip.patterns {
-		// Directory-only patterns only match directories
-		if p.isDir && !isDir {
-			continue
+		if p.matches(relPath, isDir) {
+			matched = true
+			ignored = !p.negate
 		}
+	}
+	return matched, ignored
+}
 
-		// Check if the pattern contains a slash (path pattern vs basename pattern)
-		if strings.Contains(p.pattern, "/") {
-			// Path pattern: match against the full relative path
-			if matchPath(relPath, p.pattern) {
-				return true
-			}
-		} else {
-			// Basename pattern: match against any path component
-			// For directories, check each component
-			if isDir {
-				// Match the directory name itself
-				if matchGlob(name, p.pattern) {
-					return true
-				}
-			} else {
-				// For files, check the filename
-				if matchGlob(name, p.pattern) {
-					return true
-				}
-				// Also check each directory component in the path
-				parts := strings.Split(relPath, "/")
-				if p.isDir {
-					// Directory pattern: check path components (not the file itself)
-					for _, part := range parts[:len(parts)-1] {
-						if matchGlob(part, p.pattern) {
-							return true
-						}
-					}
-				}
-			}
-		}
+func (p ignorePattern) matches(relPath string, isDir bool) bool {
+	// Directory-only patterns only match directories. Files beneath them
+	// are excluded because walks skip the whole directory.
+	if p.isDir && !isDir {
+		return false
 	}
 
-	return false
+	if p.anchored {
+		matched, _ := filepath.Match(p.pattern, relPath)
+		return matched
+	}
+
+	// Check if the pattern contains a slash (path pattern vs basename pattern)
+	if strings.Contains(p.pattern, "/") {
+		// Path pattern: match against the full relative path
+		return matchPath(relPath, p.pattern)
+	}
+	// Basename pattern: match the file or directory name itself
+	return matchGlob(filepath.Base(relPath), p.pattern)
 }
 
 // matchPath matches a relative path against a pattern that may contain path separators.
@@ -222,6 +233,79 @@ func matchGlob(name, pattern string) bool {
 	return matched
 }
 
+// VaultIgnore combines the vault-root .sameignore with .sameignore files in
+// subdirectories. Like .gitignore, a nested file's patterns are relative to
+// its own directory and take precedence over its ancestors'. Nested files are
+// loaded lazily and cached; call Invalidate after one changes.
+type VaultIgnore struct {
+	root string
+
+	mu   sync.Mutex
+	dirs map[string]*IgnorePatterns // dir relative to root ("" for root) -> patterns, nil if none
+}
+
+// LoadVaultIgnore returns the ignore rules for the vault at vaultPath.
+// It never returns nil; a vault without any .sameignore ignores nothing.
+func LoadVaultIgnore(vaultPath string) *VaultIgnore {
+	return &VaultIgnore{root: vaultPath, dirs: make(map[string]*IgnorePatterns)}
+}
+
+// ShouldIgnore reports whether relPath (relative to the vault root, forward
+// slashes) is excluded by any applicable .sameignore file.
+func (v *VaultIgnore) ShouldIgnore(relPath string, isDir bool) bool {
+	if v == nil {
+		return false
+	}
+	relPath = strings.Trim(filepath.ToSlash(relPath), "/")
+	if relPath == "" || relPath == "." {
+		return false
+	}
+
+	// Walk from the root down to the path's parent so deeper files win.
+	ignored := false
+	dir := ""
+	parts := strings.Split(relPath, "/")
+	for i := 0; i < len(parts); i++ {
+		if ip := v.patternsFor(dir); ip != nil {
+			if matched, ign := ip.match(strings.Join(parts[i:], "/"), isDir); matched {
+				ignored = ign
+			}
+		}
+		if dir == "" {
+			dir = parts[i]
+		} else {
+			dir += "/" + parts[i]
+		}
+	}
+	return ignored
+}
+
+// Invalidate drops the cached patterns for the .sameignore in relDir
+// ("" or "." for the vault root) so the next lookup re-reads it.
+func (v *VaultIgnore) Invalidate(relDir string) {
+	if v == nil {
+		return
+	}
+	relDir = strings.Trim(filepath.ToSlash(relDir), "/")
+	if relDir == "." {
+		relDir = ""
+	}
+	v.mu.Lock()
+	delete(v.dirs, relDir)
+	v.mu.Unlock()
+}
+
+func (v *VaultIgnore) patternsFor(relDir string) *IgnorePatterns {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if ip, ok := v.dirs[relDir]; ok {
+		return ip
+	}
+	ip := LoadSameignore(filepath.Join(v.root, filepath.FromSlash(relDir)))
+	v.dirs[relDir] = ip
+	return ip
+}
+
 // PatternCount returns the number of active patterns.
 func (ip *IgnorePatterns) PatternCount() int {
 	if ip == nil {
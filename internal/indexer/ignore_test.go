@@ -3,6 +3,7 @@ package indexer
 import (
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -319,3 +320,81 @@ func TestWalkVault_RespectsSameignore(t *testing.T) {
 		t.Errorf("expected good.md, got %s", found[0])
 	}
 }
+
+func TestShouldIgnore_NegationAndAnchoring(t *testing.T) {
+	ip := ParseSameignoreString("*.gen.md\n!keep.gen.md\n/drafts/\n")
+
+	tests := []struct {
+		path   string
+		isDir  bool
+		expect bool
+	}{
+		{"api.gen.md", false, true},
+		{"docs/api.gen.md", false, true},
+		{"keep.gen.md", false, false}, // re-included by later ! pattern
+		{"drafts", true, true},
+		{"notes/drafts", true, false}, // anchored to the .sameignore dir
+	}
+
+	for _, tt := range tests {
+		got := ip.ShouldIgnore(tt.path, tt.isDir)
+		if got != tt.expect {
+			t.Errorf("ShouldIgnore(%q, isDir=%v) = %v, want %v", tt.path, tt.isDir, got, tt.expect)
+		}
+	}
+}
+
+func TestWalkVault_NestedSameignore(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		".sameignore":          "*.gen.md\n",
+		"docs/.sameignore":     "!api.gen.md\n/vendor/\n",
+		"docs/api.gen.md":      "# API\n",
+		"docs/other.gen.md":    "# Other\n",
+		"docs/vendor/lib.md":   "# Vendored\n",
+		"docs/guide.md":        "# Guide\n",
+		"top.gen.md":           "# Generated\n",
+		"notes/vendor/kept.md": "# Kept\n", // docs/.sameignore doesn't apply here
+	}
+	for rel, content := range files {
+		path := filepath.Join(tmpDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	for _, f := range WalkVaultWithIgnore(tmpDir) {
+		rel, _ := filepath.Rel(tmpDir, f)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	sort.Strings(got)
+
+	want := []string{"docs/api.gen.md", "docs/guide.md", "notes/vendor/kept.md"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("walk = %v, want %v", got, want)
+	}
+}
+
+func TestVaultIgnore_Invalidate(t *testing.T) {
+	tmpDir := t.TempDir()
+	vi := LoadVaultIgnore(tmpDir)
+
+	if vi.ShouldIgnore("scratch.md", false) {
+		t.Fatal("expected nothing ignored without a .sameignore")
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".sameignore"), []byte("scratch.md\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if vi.ShouldIgnore("scratch.md", false) {
+		t.Fatal("expected cached (empty) rules before Invalidate")
+	}
+	vi.Invalidate(".")
+	if !vi.ShouldIgnore("scratch.md", false) {
+		t.Fatal("expected scratch.md ignored after Invalidate")
+	}
+}
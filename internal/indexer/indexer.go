@@ -9,6 +9,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +33,7 @@ type Stats struct {
 	TotalFiles       int    `json:"total_files"`
 	NewlyIndexed     int    `json:"newly_indexed"`
 	SkippedUnchanged int    `json:"skipped_unchanged"`
+	Resumed          int    `json:"resumed,omitempty"` // of SkippedUnchanged, files an interrupted run already stored
 	Errors           int    `json:"errors"`
 	NotesInIndex     int    `json:"total_notes_in_index"`
 	ChunksInIndex    int    `json:"total_chunks_in_index"`
@@ -85,6 +87,7 @@ func ReindexWithProgress(ctx context.Context, db *store.DB, force bool, progress
 		APIKey:     ec.APIKey,
 		BaseURL:    ec.BaseURL,
 		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
 		SkipRetry:  !config.IsEmbeddingProviderExplicit(),
 	}
 	// For ollama provider, use the legacy [ollama] URL if no base_url is set
@@ -146,11 +149,19 @@ func ReindexWithProgress(ctx context.Context, db *store.DB, force bool, progress
 		}
 	}
 
+	chunkOpts := configuredChunkOptions()
+	if !force && chunkSettingsChanged(db, chunkOpts) {
+		fmt.Fprintf(os.Stderr, "same: chunking settings changed (strategy %q, overlap %d) — re-chunking all notes\n",
+			chunkOpts.Strategy, chunkOpts.Overlap)
+		force = true
+	}
+
 	mdFiles := walkVault(vaultPath)
 	stats := &Stats{
 		TotalFiles: len(mdFiles),
 		Timestamp:  time.Now().UTC().Format(time.RFC3339),
 	}
+	resumed := beginReindexRun(db, "full", chunkOpts)
 
 	// In incremental mode, load existing hashes to skip unchanged files.
 	// In force mode, all files are re-indexed — but we do NOT delete upfront
@@ -179,7 +190,8 @@ func ReindexWithProgress(ctx context.Context, db *store.DB, force bool, progress
 		relPath := relativePath(fp, vaultPath)
 		currentPaths[relPath] = true
 
-		if !force {
+		_, wasResumed := resumed[relPath]
+		if !force || wasResumed {
 			content, err := os.ReadFile(fp)
 			if err != nil {
 				stats.Errors++
@@ -190,6 +202,11 @@ func ReindexWithProgress(ctx context.Context, db *store.DB, force bool, progress
 					relPath, len(content)/1024)
 			}
 			hash := sha256Hash(string(content))
+			if resumed[relPath] == hash {
+				stats.SkippedUnchanged++
+				stats.Resumed++
+				continue
+			}
 			if existing, ok := existingHashes[relPath]; ok && existing == hash {
 				stats.SkippedUnchanged++
 				continue
@@ -208,8 +225,9 @@ func ReindexWithProgress(ctx context.Context, db *store.DB, force bool, progress
 		}
 	}
 
-	// Process files with a worker pool (4 goroutines)
-	const numWorkers = 4
+	// Process files with a worker pool sized by [indexer] embed_concurrency;
+	// each worker embeds one file's chunks at a time.
+	numWorkers := embedWorkers(embedClient)
 	workCh := make(chan fileWork, len(work))
 	resultCh := make(chan embResult, len(work))
 
@@ -301,6 +319,7 @@ sendLoop:
 					continue
 				}
 				recordFrontmatterProvenance(db, result.Path, result.Meta)
+				recordNoteLinks(db, result.Path, result.Content)
 				if rootID, ok := insertedIDs[result.Path]; ok {
 					agent := ""
 					if len(result.Records) > 0 {
@@ -352,6 +371,7 @@ sendLoop:
 		}
 
 		recordFrontmatterProvenance(db, result.Path, result.Meta)
+		recordNoteLinks(db, result.Path, result.Content)
 
 		// Defer graph extraction to after all embeddings are done
 		if rootID, ok := insertedIDs[result.Path]; ok {
@@ -378,7 +398,8 @@ sendLoop:
 	}
 
 	// Second pass: graph extraction runs after all embeddings are complete.
-	// This avoids concurrent embedding + LLM model usage on Ollama.
+	// This avoids concurrent embedding + LLM model usage on Ollama. A file
+	// counts as done for resume only once its graph pass has run.
 	if !canceled {
 		for _, gw := range pendingGraph {
 			if ctx.Err() != nil {
@@ -387,11 +408,16 @@ sendLoop:
 			if discovered, err := extractor.ExtractFromNote(gw.rootID, gw.path, string(gw.content), gw.agent); err == nil {
 				recordDiscoveredSources(db, gw.path, vaultPath, discovered)
 			}
+			markReindexed(db, gw.path, gw.content)
 		}
 	}
 
 	// If canceled, return partial stats
 	if canceled {
+		// Notes stored before the cancel stay; bring keyword search up to them.
+		if err := db.RebuildFTS(); err != nil {
+			fmt.Fprintf(os.Stderr, "  [WARN] FTS rebuild: %v\n", err)
+		}
 		noteCount, _ := db.NoteCount()
 		chunkCount, _ := db.ChunkCount()
 		stats.NotesInIndex = noteCount
@@ -435,6 +461,8 @@ sendLoop:
 	if err := db.SetMeta("index_mode", "full"); err != nil {
 		fmt.Fprintf(os.Stderr, "  [WARN] set index metadata: %v\n", err)
 	}
+	recordChunkSettings(db, chunkOpts)
+	finishReindexRun(db)
 
 	// Record reindex timestamp and version for doctor diagnostics
 	if err := db.SetMeta("last_reindex_time", time.Now().UTC().Format(time.RFC3339)); err != nil {
@@ -459,6 +487,8 @@ sendLoop:
 	// Prune old usage data (90 days)
 	_, _ = db.PruneUsageData(90)
 
+	applyConfidenceDecay(db)
+
 	// Save stats to file
 	saveStats(stats)
 
@@ -554,7 +584,9 @@ func IndexSingleFile(database *store.DB, filePath, relPath, vaultPath string, em
 		return nil
 	}
 
-	// Remove old chunks for this path before inserting new ones
+	// Remove old chunks for this path before inserting new ones. FTS
+	// entries must go first: they are looked up through the old rows.
+	ftsErr := database.FTS5DeleteNote(relPath)
 	if err := database.DeleteByPath(relPath); err != nil {
 		return fmt.Errorf("delete old chunks: %w", err)
 	}
@@ -570,6 +602,7 @@ func IndexSingleFile(database *store.DB, filePath, relPath, vaultPath string, em
 	}
 
 	recordFrontmatterProvenance(database, relPath, meta)
+	recordNoteLinks(database, relPath, content)
 
 	// Graph Extraction
 	// Basic extractor without LLM for single-file update speed
@@ -585,12 +618,25 @@ func IndexSingleFile(database *store.DB, filePath, relPath, vaultPath string, em
 		}
 	}
 
-	// Rebuild FTS for the updated content
-	_ = database.RebuildFTS()
-
+	syncNoteFTS(database, relPath, ftsErr)
 	return nil
 }
 
+// FileUnchanged reports whether filePath's content matches what is indexed
+// for relPath. Sync tools and editors often rewrite or touch files without
+// changing them; callers use this to skip re-embedding those.
+func FileUnchanged(database *store.DB, filePath, relPath string) bool {
+	stored, ok := database.ContentHash(relPath)
+	if !ok {
+		return false
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+	return sha256Hash(string(content)) == stored
+}
+
 // IndexSingleFileLite indexes (or re-indexes) a single file without embeddings.
 // Used by watcher mode when provider="none" (keyword-only mode).
 func IndexSingleFileLite(database *store.DB, filePath, relPath, vaultPath string) error {
@@ -602,6 +648,7 @@ func IndexSingleFileLite(database *store.DB, filePath, relPath, vaultPath string
 		return nil
 	}
 
+	ftsErr := database.FTS5DeleteNote(relPath)
 	if err := database.DeleteByPath(relPath); err != nil {
 		return fmt.Errorf("delete old chunks: %w", err)
 	}
@@ -612,6 +659,7 @@ func IndexSingleFileLite(database *store.DB, filePath, relPath, vaultPath string
 	}
 
 	recordFrontmatterProvenance(database, relPath, meta)
+	recordNoteLinks(database, relPath, content)
 
 	graphDB := graph.NewDB(database.Conn())
 	extractor := graph.NewExtractor(graphDB)
@@ -625,10 +673,20 @@ func IndexSingleFileLite(database *store.DB, filePath, relPath, vaultPath string
 		}
 	}
 
-	_ = database.RebuildFTS()
+	syncNoteFTS(database, relPath, ftsErr)
 	return nil
 }
 
+// syncNoteFTS indexes the freshly inserted chunks for relPath. If removing
+// the old entries failed (deleteErr), the incremental path can't be trusted
+// and the whole FTS index is rebuilt instead.
+func syncNoteFTS(database *store.DB, relPath string, deleteErr error) {
+	if deleteErr == nil && database.FTS5UpsertNote(relPath) == nil {
+		return
+	}
+	_ = database.RebuildFTS()
+}
+
 // BuildRecordsForFile builds note records and embeddings for a single file.
 // Exported for use by the watcher.
 func BuildRecordsForFile(filePath, relPath, vaultPath string, embedClient embedding.Provider) ([]store.NoteRecord, [][]float32, error) {
@@ -660,7 +718,9 @@ func buildRecordsWithContent(filePath, relPath, vaultPath string, embedClient em
 		return nil, nil, nil, NoteMeta{}, fmt.Errorf("stat file: %w", err)
 	}
 	mtime := float64(info.ModTime().Unix())
-	contentHash := sha256Hash(body)
+	// Hash the whole file, frontmatter included, so the incremental check
+	// (which hashes what's on disk) matches and metadata edits are picked up.
+	contentHash := sha256Hash(string(content))
 
 	title := meta.Title
 	if title == "" {
@@ -675,43 +735,9 @@ func buildRecordsWithContent(filePath, relPath, vaultPath string, embedClient em
 	contentType := memory.InferContentType(relPath, meta.ContentType, meta.Tags)
 	reviewBy := strings.TrimSpace(meta.ReviewBy)
 	confidence := memory.ComputeConfidence(contentType, mtime, 0, reviewBy != "", "unknown")
+	expires := memory.ExpiryDate(meta.Expires)
 
-	// Determine chunks — try turn-level chunking first for conversational content.
-	var chunks []Chunk
-	if ShouldChunkByTurns(body) {
-		turnChunks := ChunkByTurns(body)
-		headingChunks := ChunkByHeadings(body)
-		// Use whichever produces more chunks (better granularity).
-		if len(turnChunks) >= len(headingChunks) {
-			chunks = turnChunks
-		} else {
-			chunks = headingChunks
-		}
-		// Split oversized chunks further.
-		var final []Chunk
-		for _, c := range chunks {
-			if len(c.Text) > config.MaxEmbedChars {
-				final = append(final, ChunkBySize(c.Text, config.MaxEmbedChars)...)
-			} else {
-				final = append(final, c)
-			}
-		}
-		chunks = final
-	} else if len(body) > config.ChunkTokenThreshold {
-		chunks = ChunkByHeadings(body)
-		// If any chunk is still too large, split further
-		var final []Chunk
-		for _, c := range chunks {
-			if len(c.Text) > config.MaxEmbedChars {
-				final = append(final, ChunkBySize(c.Text, config.MaxEmbedChars)...)
-			} else {
-				final = append(final, c)
-			}
-		}
-		chunks = final
-	} else {
-		chunks = []Chunk{{Heading: "(full)", Text: body}}
-	}
+	chunks := ChunkNote(body, chunkOptionsFor(body))
 
 	// Collect all embed texts for batch embedding
 	embedTexts := make([]string, len(chunks))
@@ -755,11 +781,15 @@ func buildRecordsWithContent(filePath, relPath, vaultPath string, embedClient em
 				Agent:        strings.TrimSpace(meta.Agent),
 				ChunkID:      i,
 				ChunkHeading: chunk.Heading,
+				StartLine:    fileLine(chunk.StartLine, parsed.BodyLine),
+				EndLine:      fileLine(chunk.EndLine, parsed.BodyLine),
 				Text:         text,
 				Modified:     mtime,
 				ContentHash:  contentHash,
 				ContentType:  contentType,
 				ReviewBy:     reviewBy,
+				Evergreen:    meta.Evergreen,
+				Expires:      expires,
 				Confidence:   confidence,
 				AccessCount:  0,
 			})
@@ -782,11 +812,15 @@ func buildRecordsWithContent(filePath, relPath, vaultPath string, embedClient em
 				Agent:        strings.TrimSpace(meta.Agent),
 				ChunkID:      i,
 				ChunkHeading: chunk.Heading,
+				StartLine:    fileLine(chunk.StartLine, parsed.BodyLine),
+				EndLine:      fileLine(chunk.EndLine, parsed.BodyLine),
 				Text:         text,
 				Modified:     mtime,
 				ContentHash:  contentHash,
 				ContentType:  contentType,
 				ReviewBy:     reviewBy,
+				Evergreen:    meta.Evergreen,
+				Expires:      expires,
 				Confidence:   confidence,
 				AccessCount:  0,
 			})
@@ -813,11 +847,15 @@ func buildRecordsWithContent(filePath, relPath, vaultPath string, embedClient em
 				Agent:        strings.TrimSpace(meta.Agent),
 				ChunkID:      i,
 				ChunkHeading: chunk.Heading,
+				StartLine:    fileLine(chunk.StartLine, parsed.BodyLine),
+				EndLine:      fileLine(chunk.EndLine, parsed.BodyLine),
 				Text:         text,
 				Modified:     mtime,
 				ContentHash:  contentHash,
 				ContentType:  contentType,
 				ReviewBy:     reviewBy,
+				Evergreen:    meta.Evergreen,
+				Expires:      expires,
 				Confidence:   confidence,
 				AccessCount:  0,
 			})
@@ -834,11 +872,10 @@ func WalkVault(vaultPath string) []string {
 }
 
 // WalkVaultWithIgnore returns all markdown file paths, respecting both skip dirs
-// and .sameignore patterns. This is the preferred entry point for callers that
-// want full ignore support.
+// and .sameignore patterns (including nested .sameignore files). This is the
+// preferred entry point for callers that want full ignore support.
 func WalkVaultWithIgnore(vaultPath string) []string {
-	ip := LoadSameignore(vaultPath)
-	return walkVaultWithIgnore(vaultPath, ip)
+	return walkVaultWithIgnore(vaultPath, LoadVaultIgnore(vaultPath))
 }
 
 // CountMarkdownFiles returns the number of .md files in a directory.
@@ -847,11 +884,16 @@ func CountMarkdownFiles(dir string) int {
 }
 
 func walkVault(vaultPath string) []string {
-	ip := LoadSameignore(vaultPath)
-	return walkVaultWithIgnore(vaultPath, ip)
+	return walkVaultWithIgnore(vaultPath, LoadVaultIgnore(vaultPath))
 }
 
-func walkVaultWithIgnore(vaultPath string, ip *IgnorePatterns) []string {
+func walkVaultWithIgnore(vaultPath string, ip *VaultIgnore) []string {
+	return walkVaultTree(vaultPath, vaultPath, ip)
+}
+
+// walkVaultTree walks the directory root inside vaultPath. Skip dirs and
+// .sameignore patterns are matched against vault-relative paths.
+func walkVaultTree(vaultPath, root string, ip *VaultIgnore) []string {
 	vaultAbs, _ := filepath.Abs(vaultPath)
 	// Canonicalize the vault root so that macOS /var → /private/var
 	// (and similar symlinked roots) compare correctly with EvalSymlinks results.
@@ -860,7 +902,7 @@ func walkVaultWithIgnore(vaultPath string, ip *IgnorePatterns) []string {
 		realVault = vaultAbs
 	}
 	var files []string
-	if err := filepath.WalkDir(vaultPath, func(path string, d fs.DirEntry, err error) error {
+	if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return nil
 		}
@@ -907,7 +949,7 @@ func walkVaultWithIgnore(vaultPath string, ip *IgnorePatterns) []string {
 		}
 		return nil
 	}); err != nil {
-		fmt.Fprintf(os.Stderr, "same: warning: vault walk failed for %s: %v\n", vaultPath, err)
+		fmt.Fprintf(os.Stderr, "same: warning: vault walk failed for %s: %v\n", root, err)
 	}
 	return files
 }
@@ -920,11 +962,100 @@ func relativePath(filePath, vaultPath string) string {
 	return filepath.ToSlash(rel)
 }
 
+// configuredChunkOptions reads the chunking settings from config.
+func configuredChunkOptions() ChunkOptions {
+	return ChunkOptions{Strategy: config.ChunkStrategy(), Overlap: config.ChunkOverlap()}
+}
+
+// chunkOptionsFor returns the chunking options to use for body. They only
+// matter for notes long enough to be split, so config is consulted only
+// for those.
+func chunkOptionsFor(body string) ChunkOptions {
+	if len(body) <= config.ChunkTokenThreshold {
+		return ChunkOptions{Strategy: config.ChunkStrategyHeadings}
+	}
+	return configuredChunkOptions()
+}
+
+// Meta keys recording the chunking settings the index was last built with.
+const (
+	chunkStrategyMetaKey = "chunk_strategy"
+	chunkOverlapMetaKey  = "chunk_overlap"
+)
+
+// chunkSettingsChanged reports whether the configured chunking settings
+// differ from the ones the index was built with. Indexes built before the
+// settings existed used the headings strategy; a missing overlap record is
+// accepted as-is so upgrading doesn't force a full re-embed.
+func chunkSettingsChanged(db *store.DB, opts ChunkOptions) bool {
+	prev, ok := db.GetMeta(chunkStrategyMetaKey)
+	if !ok || prev == "" {
+		prev = config.ChunkStrategyHeadings
+	}
+	if prev != opts.Strategy {
+		return true
+	}
+	if prevOverlap, ok := db.GetMeta(chunkOverlapMetaKey); ok && prevOverlap != strconv.Itoa(opts.Overlap) {
+		return true
+	}
+	return false
+}
+
+// recordChunkSettings stores the chunking settings used for this index.
+func recordChunkSettings(db *store.DB, opts ChunkOptions) {
+	if err := db.SetMeta(chunkStrategyMetaKey, opts.Strategy); err != nil {
+		fmt.Fprintf(os.Stderr, "  [WARN] set chunk strategy metadata: %v\n", err)
+	}
+	if err := db.SetMeta(chunkOverlapMetaKey, strconv.Itoa(opts.Overlap)); err != nil {
+		fmt.Fprintf(os.Stderr, "  [WARN] set chunk overlap metadata: %v\n", err)
+	}
+}
+
+// beginReindexRun starts tracking a reindex run and returns the files an
+// interrupted run of the same kind already stored, mapped to the content hash
+// they were stored with. mode and the chunk settings make up the run's kind:
+// progress from a lite run doesn't stand in for embedded notes, and notes
+// chunked with old settings must be redone.
+func beginReindexRun(db *store.DB, mode string, opts ChunkOptions) map[string]string {
+	runKey := fmt.Sprintf("%s:%s:%d", mode, opts.Strategy, opts.Overlap)
+	done, err := db.BeginReindexRun(runKey, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  [WARN] reindex progress: %v\n", err)
+		return nil
+	}
+	if len(done) > 0 {
+		fmt.Fprintf(os.Stderr, "same: resuming interrupted reindex — %d files already done\n", len(done))
+	}
+	return done
+}
+
+// markReindexed records that the current run has stored relPath.
+func markReindexed(db *store.DB, relPath string, content []byte) {
+	if err := db.MarkFileReindexed(relPath, sha256Hash(string(content))); err != nil {
+		fmt.Fprintf(os.Stderr, "  [WARN] reindex progress: %v\n", err)
+	}
+}
+
+// finishReindexRun drops the progress of a run that completed.
+func finishReindexRun(db *store.DB) {
+	if err := db.FinishReindexRun(); err != nil {
+		fmt.Fprintf(os.Stderr, "  [WARN] reindex progress: %v\n", err)
+	}
+}
+
 func sha256Hash(s string) string {
 	h := sha256.Sum256([]byte(s))
 	return fmt.Sprintf("%x", h)
 }
 
+// recordNoteLinks stores the wikilinks and Markdown links a note makes so
+// context surfacing can follow them. Best-effort, like provenance.
+func recordNoteLinks(database *store.DB, notePath string, content []byte) {
+	if err := database.SetNoteLinks(notePath, graph.ParseNoteLinks(string(content))); err != nil {
+		fmt.Fprintf(os.Stderr, "  [WARN] record links for %s: %v\n", notePath, err)
+	}
+}
+
 // recordDiscoveredSources records graph-extracted source references as provenance.
 // Best-effort: errors are logged but don't propagate.
 func recordDiscoveredSources(database *store.DB, notePath, vaultPath string, discovered []graph.DiscoveredSource) {
@@ -1017,12 +1148,40 @@ type liteResult struct {
 // Uses a worker pool (4 goroutines) for parallel file I/O and parsing, matching
 // the concurrency model of the full Reindex function.
 func ReindexLite(ctx context.Context, db *store.DB, force bool, progress ProgressFunc) (*Stats, error) {
+	return reindexLite(ctx, db, force, progress, liteRun{})
+}
+
+// liteRun adjusts a reindexLite run.
+type liteRun struct {
+	// keepOpen leaves the run resumable after the lite pass; ReindexProgressive
+	// finishes it once embeddings are done too.
+	keepOpen bool
+	// subtree limits the run to notes under this vault-relative directory.
+	subtree string
+}
+
+func reindexLite(ctx context.Context, db *store.DB, force bool, progress ProgressFunc, run liteRun) (*Stats, error) {
 	vaultPath := config.VaultPath()
-	mdFiles := walkVault(vaultPath)
+	chunkOpts := configuredChunkOptions()
+	if !force && chunkSettingsChanged(db, chunkOpts) {
+		fmt.Fprintf(os.Stderr, "same: chunking settings changed (strategy %q, overlap %d) — re-chunking all notes\n",
+			chunkOpts.Strategy, chunkOpts.Overlap)
+		force = true
+	}
+
+	mode := "lite"
+	var mdFiles []string
+	if run.subtree != "" {
+		mode += "@" + run.subtree
+		mdFiles = walkVaultTree(vaultPath, filepath.Join(vaultPath, filepath.FromSlash(run.subtree)), LoadVaultIgnore(vaultPath))
+	} else {
+		mdFiles = walkVault(vaultPath)
+	}
 	stats := &Stats{
 		TotalFiles: len(mdFiles),
 		Timestamp:  time.Now().UTC().Format(time.RFC3339),
 	}
+	resumed := beginReindexRun(db, mode, chunkOpts)
 
 	// In incremental mode, load existing hashes to skip unchanged files.
 	// In force mode, all files are re-indexed — but we do NOT delete upfront
@@ -1047,13 +1206,19 @@ func ReindexLite(ctx context.Context, db *store.DB, force bool, progress Progres
 		relPath := relativePath(fp, vaultPath)
 		currentPaths[relPath] = true
 
-		if !force {
+		_, wasResumed := resumed[relPath]
+		if !force || wasResumed {
 			content, err := os.ReadFile(fp)
 			if err != nil {
 				stats.Errors++
 				continue
 			}
 			hash := sha256Hash(string(content))
+			if resumed[relPath] == hash {
+				stats.SkippedUnchanged++
+				stats.Resumed++
+				continue
+			}
 			if existing, ok := existingHashes[relPath]; ok && existing == hash {
 				stats.SkippedUnchanged++
 				continue
@@ -1149,6 +1314,7 @@ sendLoop:
 		}
 
 		recordFrontmatterProvenance(db, result.RelPath, result.Meta)
+		recordNoteLinks(db, result.RelPath, result.Content)
 
 		// Graph Extraction
 		if rootID, ok := insertedIDs[result.RelPath]; ok {
@@ -1160,6 +1326,7 @@ sendLoop:
 				recordDiscoveredSources(db, result.RelPath, vaultPath, discovered)
 			}
 		}
+		markReindexed(db, result.RelPath, result.Content)
 
 		stats.NewlyIndexed++
 		processed := stats.NewlyIndexed + stats.SkippedUnchanged + stats.Errors
@@ -1169,6 +1336,10 @@ sendLoop:
 	}
 
 	if canceled {
+		// Notes stored before the cancel stay; bring keyword search up to them.
+		if err := db.RebuildFTS(); err != nil {
+			fmt.Fprintf(os.Stderr, "  [WARN] FTS rebuild: %v\n", err)
+		}
 		noteCount, _ := db.NoteCount()
 		chunkCount, _ := db.ChunkCount()
 		stats.NotesInIndex = noteCount
@@ -1182,7 +1353,7 @@ sendLoop:
 	if force {
 		if indexed, err := db.GetContentHashes(); err == nil {
 			for path := range indexed {
-				if !currentPaths[path] {
+				if !currentPaths[path] && inSubtree(path, run.subtree) {
 					_ = db.DeleteByPath(path)
 				}
 			}
@@ -1194,26 +1365,45 @@ sendLoop:
 	stats.NotesInIndex = noteCount
 	stats.ChunksInIndex = chunkCount
 
-	if err := db.SetMeta("last_reindex_time", time.Now().UTC().Format(time.RFC3339)); err != nil {
-		fmt.Fprintf(os.Stderr, "  [WARN] set last reindex time: %v\n", err)
-	}
-	if err := db.SetMeta("index_mode", "lite"); err != nil {
-		fmt.Fprintf(os.Stderr, "  [WARN] set index metadata: %v\n", err)
-	}
-	if Version != "" {
-		if err := db.SetMeta("same_version", Version); err != nil {
-			fmt.Fprintf(os.Stderr, "  [WARN] set SAME version metadata: %v\n", err)
+	// A subtree run leaves the rest of the index as it was, so the
+	// whole-index metadata (mode, chunk settings, last run) stays too.
+	if run.subtree == "" {
+		if err := db.SetMeta("last_reindex_time", time.Now().UTC().Format(time.RFC3339)); err != nil {
+			fmt.Fprintf(os.Stderr, "  [WARN] set last reindex time: %v\n", err)
 		}
+		if err := db.SetMeta("index_mode", "lite"); err != nil {
+			fmt.Fprintf(os.Stderr, "  [WARN] set index metadata: %v\n", err)
+		}
+		recordChunkSettings(db, chunkOpts)
+		if Version != "" {
+			if err := db.SetMeta("same_version", Version); err != nil {
+				fmt.Fprintf(os.Stderr, "  [WARN] set SAME version metadata: %v\n", err)
+			}
+		}
+	}
+	if !run.keepOpen {
+		finishReindexRun(db)
 	}
 
 	if err := db.RebuildFTS(); err != nil {
 		fmt.Fprintf(os.Stderr, "  [WARN] FTS rebuild: %v\n", err)
 	}
-	saveStats(stats)
+	applyConfidenceDecay(db)
+	if run.subtree == "" {
+		saveStats(stats)
+	}
 
 	return stats, nil
 }
 
+// applyConfidenceDecay runs [surfacing] confidence decay after a reindex so
+// notes rewritten by the indexer and untouched notes are both up to date.
+func applyConfidenceDecay(db *store.DB) {
+	if _, err := db.DecayConfidence(config.ConfidenceHalfLifeDays(), time.Now(), 0); err != nil {
+		fmt.Fprintf(os.Stderr, "  [WARN] confidence decay: %v\n", err)
+	}
+}
+
 // ReindexProgressive indexes the vault in two phases:
 //
 // Phase 1 (fast): Insert all notes into vault_notes + FTS5 (no embeddings).
@@ -1226,15 +1416,21 @@ sendLoop:
 // skipped (equivalent to ReindexLite). If Phase 2 is canceled, the FTS5 index
 // remains intact and embeddings resume on next run via BackfillEmbeddings.
 func ReindexProgressive(ctx context.Context, db *store.DB, force bool, liteProgress ProgressFunc, embedProgress EmbeddingProgressFunc) (*Stats, *EmbeddingProgress, error) {
+	return reindexProgressive(ctx, db, force, "", liteProgress, embedProgress)
+}
+
+func reindexProgressive(ctx context.Context, db *store.DB, force bool, subtree string, liteProgress ProgressFunc, embedProgress EmbeddingProgressFunc) (*Stats, *EmbeddingProgress, error) {
 	// Phase 1: FTS5-only indexing (fast)
-	stats, err := ReindexLite(ctx, db, force, liteProgress)
+	stats, err := reindexLite(ctx, db, force, liteProgress, liteRun{keepOpen: true, subtree: subtree})
 	if err != nil {
 		return stats, nil, err
 	}
 
 	// Set index mode to progressive (between lite and full)
-	if err := db.SetMeta("index_mode", "progressive"); err != nil {
-		fmt.Fprintf(os.Stderr, "  [WARN] set index mode: %v\n", err)
+	if subtree == "" {
+		if err := db.SetMeta("index_mode", "progressive"); err != nil {
+			fmt.Fprintf(os.Stderr, "  [WARN] set index mode: %v\n", err)
+		}
 	}
 
 	// Phase 2: Embedding backfill
@@ -1246,6 +1442,7 @@ func ReindexProgressive(ctx context.Context, db *store.DB, force bool, liteProgr
 		APIKey:     ec.APIKey,
 		BaseURL:    ec.BaseURL,
 		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
 		SkipRetry:  !config.IsEmbeddingProviderExplicit(),
 	}
 	if (provCfg.Provider == "ollama" || provCfg.Provider == "") && provCfg.BaseURL == "" {
@@ -1257,17 +1454,27 @@ func ReindexProgressive(ctx context.Context, db *store.DB, force bool, liteProgr
 	embedClient, err := embedding.NewProvider(provCfg)
 	if err != nil {
 		// Embedding provider not available — Phase 1 results stand as lite mode
+		finishReindexRun(db)
 		return stats, nil, nil
 	}
 
 	// Preflight check before committing to the embedding pass
 	if err := preflightEmbeddingProvider(embedClient); err != nil {
 		// Embedding provider not responding — stay in lite mode
+		finishReindexRun(db)
 		return stats, nil, nil
 	}
 
 	// Run embedding backfill
-	embResult, embErr := BackfillEmbeddings(ctx, db, embedClient, embedProgress)
+	var embResult *EmbeddingProgress
+	var embErr error
+	if subtree == "" {
+		embResult, embErr = BackfillEmbeddings(ctx, db, embedClient, embedProgress)
+	} else if ids, idsErr := db.UnembeddedNoteIDsUnder(subtree); idsErr != nil {
+		embErr = fmt.Errorf("get unembedded notes: %w", idsErr)
+	} else {
+		embResult, embErr = embedNotes(ctx, db, embedClient, ids, false, embedProgress)
+	}
 
 	// Best-effort: unload the embedding model from Ollama to free GPU/CPU memory.
 	// This prevents a stale runner process from consuming resources after reindex.
@@ -1290,7 +1497,7 @@ func ReindexProgressive(ctx context.Context, db *store.DB, force bool, liteProgr
 	}
 
 	// Update index mode based on completion
-	if embResult != nil && embResult.Completed == embResult.Total && embResult.Total > 0 {
+	if subtree == "" && embResult != nil && embResult.Completed == embResult.Total && embResult.Total > 0 {
 		if metaErr := db.SetMeta("index_mode", "full"); metaErr != nil {
 			fmt.Fprintf(os.Stderr, "  [WARN] set index mode: %v\n", metaErr)
 		}
@@ -1306,6 +1513,7 @@ func ReindexProgressive(ctx context.Context, db *store.DB, force bool, liteProgr
 		stats.Canceled = true
 		return stats, embResult, ErrCanceled
 	}
+	finishReindexRun(db)
 
 	return stats, embResult, nil
 }
@@ -1314,8 +1522,8 @@ func ReindexProgressive(ctx context.Context, db *store.DB, force bool, liteProgr
 type EmbeddingProgressFunc func(completed, total int)
 
 // BackfillEmbeddings generates embeddings for notes that were indexed without
-// vectors (FTS5-only). It processes notes one at a time to avoid overloading
-// the embedding provider. Returns progress stats and nil error on completion.
+// vectors (FTS5-only). Up to [indexer] embed_concurrency notes are embedded
+// at once (at most two for Ollama). Returns progress stats and nil error on completion.
 // If the context is canceled, returns partial progress and ErrCanceled.
 func BackfillEmbeddings(ctx context.Context, db *store.DB, embedClient embedding.Provider, progress EmbeddingProgressFunc) (*EmbeddingProgress, error) {
 	ids, err := db.UnembeddedNoteIDs()
@@ -1323,6 +1531,85 @@ func BackfillEmbeddings(ctx context.Context, db *store.DB, embedClient embedding
 		return nil, fmt.Errorf("get unembedded notes: %w", err)
 	}
 
+	return embedNotes(ctx, db, embedClient, ids, false, progress)
+}
+
+// ReembedNotes replaces the stored vectors for the given note IDs with fresh
+// embeddings. Used to repair rows whose vector dimensions drifted from the
+// rest of the index without a full force reindex.
+func ReembedNotes(ctx context.Context, db *store.DB, embedClient embedding.Provider, ids []int64, progress EmbeddingProgressFunc) (*EmbeddingProgress, error) {
+	return embedNotes(ctx, db, embedClient, ids, true, progress)
+}
+
+// ollamaMaxEmbedConcurrency caps parallel requests to Ollama. A local
+// Ollama serves embeddings from one model runner, so more requests only
+// queue there while competing with the rest of the machine.
+const ollamaMaxEmbedConcurrency = 2
+
+// embedWorkers returns how many embedding requests to keep in flight for
+// embedClient: [indexer] embed_concurrency, capped for Ollama.
+func embedWorkers(embedClient embedding.Provider) int {
+	n := config.EmbedConcurrency()
+	if embedClient.Name() == "ollama" {
+		n = min(n, ollamaMaxEmbedConcurrency)
+	}
+	return n
+}
+
+// embedOutcome is one note's embedding result, handed from a worker to the
+// writer.
+type embedOutcome struct {
+	note *store.NoteRecord
+	vec  []float32
+	err  error
+}
+
+// vectorWriter stores one note's vector.
+type vectorWriter func(noteID int64, vec []float32) error
+
+// embedNotes embeds each note chunk in ids and stores the vector. When
+// replace is true any existing vector is deleted first.
+func embedNotes(ctx context.Context, db *store.DB, embedClient embedding.Provider, ids []int64, replace bool, progress EmbeddingProgressFunc) (*EmbeddingProgress, error) {
+	return embedNotesConcurrently(ctx, db, embedClient, ids, activeVectorWriter(db, replace), progress, embedWorkers(embedClient))
+}
+
+// activeVectorWriter writes to the vectors search uses.
+func activeVectorWriter(db *store.DB, replace bool) vectorWriter {
+	return func(noteID int64, vec []float32) error {
+		if replace {
+			if err := db.DeleteEmbeddingForNote(noteID); err != nil {
+				return fmt.Errorf("replace: %w", err)
+			}
+		}
+		return db.InsertEmbeddingForNote(noteID, vec)
+	}
+}
+
+// StageEmbeddings embeds, with embedClient's model, the notes its staged
+// embedding set doesn't cover yet. The active vectors are untouched, so
+// search keeps working while it runs; an interrupted run picks up where it
+// stopped. Switch to the new model with store.ActivateEmbeddingSet once
+// every note is covered.
+func StageEmbeddings(ctx context.Context, db *store.DB, embedClient embedding.Provider, progress EmbeddingProgressFunc) (*EmbeddingProgress, error) {
+	setID, err := db.StageEmbeddingSet(embedClient.Name(), embedClient.Model())
+	if err != nil {
+		return nil, err
+	}
+	ids, err := db.NoteIDsWithoutStagedVector(setID)
+	if err != nil {
+		return nil, err
+	}
+	write := func(noteID int64, vec []float32) error {
+		return db.InsertStagedEmbedding(setID, noteID, vec)
+	}
+	return embedNotesConcurrently(ctx, db, embedClient, ids, write, progress, embedWorkers(embedClient))
+}
+
+// embedNotesConcurrently runs up to workers embedding requests at once.
+// Vectors are written by a single goroutine in ids order, so the store sees
+// the same sequence of writes whatever the concurrency; a slot is freed only
+// once its note is written, which bounds the vectors held in memory.
+func embedNotesConcurrently(ctx context.Context, db *store.DB, embedClient embedding.Provider, ids []int64, write vectorWriter, progress EmbeddingProgressFunc, workers int) (*EmbeddingProgress, error) {
 	result := &EmbeddingProgress{
 		Total: len(ids),
 	}
@@ -1331,37 +1618,63 @@ func BackfillEmbeddings(ctx context.Context, db *store.DB, embedClient embedding
 		return result, nil
 	}
 
-	for _, noteID := range ids {
+	outcomes := make([]chan embedOutcome, len(ids))
+	for i := range outcomes {
+		outcomes[i] = make(chan embedOutcome, 1)
+	}
+	slots := make(chan struct{}, max(workers, 1))
+
+	go func() {
+		for i, noteID := range ids {
+			select {
+			case <-ctx.Done():
+				return
+			case slots <- struct{}{}:
+			}
+			// Reads stay on this goroutine; only the embedding calls run in
+			// parallel.
+			note, err := db.GetNoteByID(noteID)
+			if err != nil || note == nil {
+				outcomes[i] <- embedOutcome{}
+				continue
+			}
+			go func(out chan<- embedOutcome, note *store.NoteRecord) {
+				// Build the same embed text used by buildRecordsWithContent
+				embedText := note.Title + "\n" + note.Text
+				if len(embedText) > config.MaxEmbedChars {
+					embedText = embedText[:config.MaxEmbedChars]
+				}
+				vec, err := embedClient.GetDocumentEmbedding(embedText)
+				out <- embedOutcome{note: note, vec: vec, err: err}
+			}(outcomes[i], note)
+		}
+	}()
+
+	for i, noteID := range ids {
 		// Check cancellation before each note
+		var o embedOutcome
 		select {
 		case <-ctx.Done():
 			return result, ErrCanceled
-		default:
+		case o = <-outcomes[i]:
 		}
+		<-slots
 
-		note, err := db.GetNoteByID(noteID)
-		if err != nil || note == nil {
+		if o.note == nil {
 			result.Failed++
 			continue
 		}
-
-		// Build the same embed text used by buildRecordsWithContent
-		embedText := note.Title + "\n" + note.Text
-		if len(embedText) > config.MaxEmbedChars {
-			embedText = embedText[:config.MaxEmbedChars]
-		}
-
-		vec, err := embedClient.GetDocumentEmbedding(embedText)
-		if err != nil {
+		note := o.note
+		if o.err != nil {
 			fileName := filepath.Base(note.Path)
 			fmt.Fprintf(os.Stderr, "  \u26a0 Skipped embedding for %s (chunk %d): %v\n",
-				fileName, note.ChunkID, embedding.HumanizeError(err))
+				fileName, note.ChunkID, embedding.HumanizeError(o.err))
 			fmt.Fprintf(os.Stderr, "    Note is still keyword-searchable.\n")
 			result.Failed++
 			continue
 		}
 
-		if err := db.InsertEmbeddingForNote(noteID, vec); err != nil {
+		if err := write(noteID, o.vec); err != nil {
 			fmt.Fprintf(os.Stderr, "  [WARN] insert embedding %s (chunk %d): %v\n",
 				note.Path, note.ChunkID, err)
 			result.Failed++
@@ -1385,6 +1698,8 @@ type FactExtractionProgress struct {
 	Failed    int // Number of notes that failed extraction
 	Total     int // Total notes eligible for extraction
 	Facts     int // Total facts extracted in this run
+
+	Reembedded int // Existing facts re-embedded with the current model
 }
 
 // FactProgressFunc is called during fact extraction to report progress.
@@ -1395,10 +1710,37 @@ type FactProgressFunc func(completed, total int)
 // to extract atomic knowledge, generates an embedding for each fact,
 // and stores the fact + embedding for precision search.
 //
+// Facts that lost their vectors when the embedding model changed are
+// re-embedded first; that needs only the embedding provider.
+//
 // This is Phase 3 of the indexing pipeline — it runs after FTS5 indexing
 // and embedding backfill, and requires both an LLM (chat) and embedding
 // provider. If either is unavailable, the caller should skip this phase.
 func BackfillFacts(ctx context.Context, db *store.DB, chatClient llm.Client, chatModel string, embedClient embedding.Provider, progress FactProgressFunc) (*FactExtractionProgress, error) {
+	result := &FactExtractionProgress{}
+
+	stale, err := db.FactsWithoutEmbeddings()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range stale {
+		select {
+		case <-ctx.Done():
+			return result, ErrCanceled
+		default:
+		}
+		vec, embErr := embedClient.GetDocumentEmbedding(f.FactText)
+		if embErr != nil {
+			fmt.Fprintf(os.Stderr, "  [WARN] re-embedding fact for %s: %v\n", f.SourcePath, embErr)
+			continue
+		}
+		if setErr := db.SetFactEmbedding(f.ID, vec); setErr != nil {
+			fmt.Fprintf(os.Stderr, "  [WARN] store fact vector for %s: %v\n", f.SourcePath, setErr)
+			continue
+		}
+		result.Reembedded++
+	}
+
 	// Get all indexed note paths (chunk_id=0 = root chunks, one per note)
 	hashes, err := db.GetContentHashes()
 	if err != nil {
@@ -1419,10 +1761,8 @@ func BackfillFacts(ctx context.Context, db *store.DB, chatClient llm.Client, cha
 		}
 	}
 
-	result := &FactExtractionProgress{
-		Skipped: len(pathsWithFacts),
-		Total:   len(paths),
-	}
+	result.Skipped = len(pathsWithFacts)
+	result.Total = len(paths)
 
 	if len(paths) == 0 {
 		return result, nil
@@ -1502,6 +1842,15 @@ func BackfillFacts(ctx context.Context, db *store.DB, chatClient llm.Client, cha
 	return result, nil
 }
 
+// fileLine maps a 1-based line within a note body to its line in the file.
+// Zero (unknown) stays zero.
+func fileLine(bodyLine, bodyStart int) int {
+	if bodyLine <= 0 {
+		return 0
+	}
+	return bodyLine + max(bodyStart, 1) - 1
+}
+
 // buildRecordsLite builds note records WITHOUT embeddings.
 func buildRecordsLite(filePath, relPath, vaultPath string) ([]store.NoteRecord, []byte, NoteMeta, error) {
 	content, err := os.ReadFile(filePath)
@@ -1518,7 +1867,9 @@ func buildRecordsLite(filePath, relPath, vaultPath string) ([]store.NoteRecord,
 		return nil, nil, NoteMeta{}, fmt.Errorf("stat file: %w", err)
 	}
 	mtime := float64(info.ModTime().Unix())
-	contentHash := sha256Hash(body)
+	// Hash the whole file, frontmatter included, so the incremental check
+	// (which hashes what's on disk) matches and metadata edits are picked up.
+	contentHash := sha256Hash(string(content))
 
 	title := meta.Title
 	if title == "" {
@@ -1533,39 +1884,9 @@ func buildRecordsLite(filePath, relPath, vaultPath string) ([]store.NoteRecord,
 	contentType := memory.InferContentType(relPath, meta.ContentType, meta.Tags)
 	reviewBy := strings.TrimSpace(meta.ReviewBy)
 	confidence := memory.ComputeConfidence(contentType, mtime, 0, reviewBy != "", "unknown")
+	expires := memory.ExpiryDate(meta.Expires)
 
-	var chunks []Chunk
-	if ShouldChunkByTurns(body) {
-		turnChunks := ChunkByTurns(body)
-		headingChunks := ChunkByHeadings(body)
-		if len(turnChunks) >= len(headingChunks) {
-			chunks = turnChunks
-		} else {
-			chunks = headingChunks
-		}
-		var final []Chunk
-		for _, c := range chunks {
-			if len(c.Text) > config.MaxEmbedChars {
-				final = append(final, ChunkBySize(c.Text, config.MaxEmbedChars)...)
-			} else {
-				final = append(final, c)
-			}
-		}
-		chunks = final
-	} else if len(body) > config.ChunkTokenThreshold {
-		chunks = ChunkByHeadings(body)
-		var final []Chunk
-		for _, c := range chunks {
-			if len(c.Text) > config.MaxEmbedChars {
-				final = append(final, ChunkBySize(c.Text, config.MaxEmbedChars)...)
-			} else {
-				final = append(final, c)
-			}
-		}
-		chunks = final
-	} else {
-		chunks = []Chunk{{Heading: "(full)", Text: body}}
-	}
+	chunks := ChunkNote(body, chunkOptionsFor(body))
 
 	var records []store.NoteRecord
 	for i, chunk := range chunks {
@@ -1583,11 +1904,15 @@ func buildRecordsLite(filePath, relPath, vaultPath string) ([]store.NoteRecord,
 			Agent:        strings.TrimSpace(meta.Agent),
 			ChunkID:      i,
 			ChunkHeading: chunk.Heading,
+			StartLine:    fileLine(chunk.StartLine, parsed.BodyLine),
+			EndLine:      fileLine(chunk.EndLine, parsed.BodyLine),
 			Text:         text,
 			Modified:     mtime,
 			ContentHash:  contentHash,
 			ContentType:  contentType,
 			ReviewBy:     reviewBy,
+			Evergreen:    meta.Evergreen,
+			Expires:      expires,
 			Confidence:   confidence,
 			AccessCount:  0,
 		})
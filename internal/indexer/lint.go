@@ -0,0 +1,338 @@
+package indexer
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	yaml "go.yaml.in/yaml/v3"
+
+	"github.com/sgx-labs/statelessagent/internal/memory"
+)
+
+// Lint severities. An error means the indexer drops the note's metadata (or
+// the field) entirely; a warning means a value is ignored or suspicious.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// LintIssue is one frontmatter problem found by LintNote. Line is 1-based
+// within the file.
+type LintIssue struct {
+	Line     int    `json:"line"`
+	Field    string `json:"field,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Fixable  bool   `json:"fixable,omitempty"`
+
+	fix func(lines []string) // rewrites the issue's line; set when Fixable
+}
+
+// fieldKind is the YAML shape NoteMeta expects for a frontmatter key.
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindList
+	kindNumber
+	kindBool
+	kindDate
+)
+
+// frontmatterFields maps each key NoteMeta reads to the shape it expects,
+// derived from NoteMeta's yaml tags so the two cannot drift apart.
+var frontmatterFields = func() map[string]fieldKind {
+	fields := make(map[string]fieldKind)
+	t := reflect.TypeOf(NoteMeta{})
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("yaml")
+		switch t.Field(i).Type.Kind() {
+		case reflect.Slice:
+			fields[name] = kindList
+		case reflect.Float64:
+			fields[name] = kindNumber
+		case reflect.Bool:
+			fields[name] = kindBool
+		default:
+			fields[name] = kindString
+		}
+	}
+	for _, name := range []string{"review_by", "review-by", "expires"} {
+		fields[name] = kindDate
+	}
+	return fields
+}()
+
+var trustStates = []string{"contradicted", "stale", "unknown", "validated"}
+
+// LintNote checks a note's YAML frontmatter against the fields the indexer
+// reads. Notes without frontmatter have nothing to check. Keys the indexer
+// doesn't read are allowed, except near-misses of ones it does.
+func LintNote(content string) []LintIssue {
+	block, firstLine, ok := yamlFrontmatter(content)
+	if !ok {
+		return nil
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(block), &doc); err != nil {
+		return []LintIssue{{Line: 1, Severity: LintError,
+			Message: fmt.Sprintf("frontmatter is not valid YAML, so all of it is ignored: %v", err)}}
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return nil
+	}
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return []LintIssue{{Line: 1, Severity: LintError,
+			Message: "frontmatter is not a list of key: value fields, so all of it is ignored"}}
+	}
+
+	present := make(map[string]bool)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		present[mapping.Content[i].Value] = true
+	}
+
+	var issues []LintIssue
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, val := mapping.Content[i], mapping.Content[i+1]
+		line := key.Line + firstLine - 1
+		kind, known := frontmatterFields[key.Value]
+		if !known {
+			issues = append(issues, lintUnknownKey(key, line, present)...)
+			continue
+		}
+		issues = append(issues, lintField(key, val, kind, line)...)
+	}
+	if !present["title"] {
+		issues = append(issues, LintIssue{Line: 1, Field: "title", Severity: LintWarning,
+			Message: "no title; the note is titled by its file name"})
+	}
+	return issues
+}
+
+// FixNote applies the fixable issues LintNote reports and returns the new
+// content and how many fixes were made. Only the affected lines change.
+func FixNote(content string) (string, int) {
+	issues := LintNote(content)
+	lines := strings.Split(content, "\n")
+	n := 0
+	for _, issue := range issues {
+		if issue.fix != nil {
+			issue.fix(lines)
+			n++
+		}
+	}
+	if n == 0 {
+		return content, 0
+	}
+	return strings.Join(lines, "\n"), n
+}
+
+// yamlFrontmatter returns the YAML between a leading "---" line and the
+// next "---" line, and the file line the YAML starts on.
+func yamlFrontmatter(content string) (string, int, bool) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	first, rest, ok := strings.Cut(content, "\n")
+	if !ok || strings.TrimRight(first, "\r") != "---" {
+		return "", 0, false
+	}
+	var block strings.Builder
+	for {
+		line, more, found := strings.Cut(rest, "\n")
+		if strings.TrimRight(line, "\r") == "---" {
+			return block.String(), 2, true
+		}
+		if !found {
+			return "", 0, false
+		}
+		block.WriteString(line)
+		block.WriteString("\n")
+		rest = more
+	}
+}
+
+func lintUnknownKey(key *yaml.Node, line int, present map[string]bool) []LintIssue {
+	name := key.Value
+	if canon := canonicalField(name); canon != "" {
+		issue := LintIssue{Line: line, Field: name, Severity: LintWarning,
+			Message: fmt.Sprintf("%q is not read; the key is %q", name, canon)}
+		if !present[canon] && key.Style == 0 {
+			issue.Fixable = true
+			issue.fix = func(lines []string) {
+				l := lines[line-1]
+				col := key.Column - 1
+				if col+len(name) <= len(l) && l[col:col+len(name)] == name {
+					lines[line-1] = l[:col] + canon + l[col+len(name):]
+				}
+			}
+		}
+		return []LintIssue{issue}
+	}
+	if near := nearestField(name); near != "" {
+		return []LintIssue{{Line: line, Field: name, Severity: LintWarning,
+			Message: fmt.Sprintf("%q is not read; did you mean %q?", name, near)}}
+	}
+	return nil
+}
+
+func lintField(key, val *yaml.Node, kind fieldKind, line int) []LintIssue {
+	name := key.Value
+	issue := func(severity, format string, args ...any) []LintIssue {
+		return []LintIssue{{Line: line, Field: name, Severity: severity, Message: fmt.Sprintf(format, args...)}}
+	}
+	if val.Tag == "!!null" {
+		return nil
+	}
+	if kind != kindList && val.Kind != yaml.ScalarNode {
+		return issue(LintError, "%s must be a single value, not a list or map; as written the whole frontmatter is ignored", name)
+	}
+
+	switch kind {
+	case kindList:
+		switch val.Kind {
+		case yaml.ScalarNode:
+			out := issue(LintError, "%s must be a list like [a, b]; as written the whole frontmatter is ignored", name)
+			if val.Line == key.Line && val.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				out[0].Fixable = true
+				out[0].fix = func(lines []string) { lines[line-1] = rewriteAsList(lines[line-1], key, val.Value) }
+			}
+			return out
+		case yaml.SequenceNode:
+			for _, item := range val.Content {
+				if item.Kind != yaml.ScalarNode {
+					return issue(LintError, "%s items must be plain values; as written the whole frontmatter is ignored", name)
+				}
+			}
+		default:
+			return issue(LintError, "%s must be a list like [a, b]; as written the whole frontmatter is ignored", name)
+		}
+	case kindNumber:
+		if val.Tag != "!!int" && val.Tag != "!!float" {
+			return issue(LintError, "%s must be a number; as written the whole frontmatter is ignored", name)
+		}
+		var f float64
+		if err := val.Decode(&f); err == nil && (f < 0 || f > 1) {
+			return issue(LintWarning, "%s: %g is outside 0-1", name, f)
+		}
+	case kindBool:
+		// The frontmatter parser follows YAML 1.1, which also reads yes/no
+		// and on/off as booleans.
+		yaml11 := []string{"y", "yes", "n", "no", "on", "off"}
+		if val.Tag != "!!bool" && !slices.Contains(yaml11, strings.ToLower(val.Value)) {
+			return issue(LintError, "%s must be true or false; as written the whole frontmatter is ignored", name)
+		}
+	case kindDate:
+		if memory.ExpiryDate(val.Value) == "" {
+			return issue(LintWarning, "%s: %q is not a date (use YYYY-MM-DD), so it has no effect", name, val.Value)
+		}
+	case kindString:
+		v := strings.ToLower(strings.TrimSpace(val.Value))
+		switch name {
+		case "content_type":
+			known := memory.KnownContentTypes()
+			if slices.Contains(known, v) {
+				return nil
+			}
+			msg := fmt.Sprintf("content_type %q is not a known type, so it is inferred from the path instead (known: %s)", val.Value, strings.Join(known, ", "))
+			if near := nearestName(v, known); near != "" {
+				msg += fmt.Sprintf("; did you mean %q?", near)
+			}
+			return issue(LintWarning, "%s", msg)
+		case "trust_state":
+			if !slices.Contains(trustStates, v) {
+				return issue(LintWarning, "trust_state %q is not one of %s", val.Value, strings.Join(trustStates, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// rewriteAsList replaces the scalar value on a "key: a, b" line with the
+// flow list "key: [a, b]", keeping the key and its indentation as written.
+func rewriteAsList(line string, key *yaml.Node, value string) string {
+	var items []string
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			items = append(items, t)
+		}
+	}
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, t := range items {
+		seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: t})
+	}
+	out, err := yaml.Marshal(seq)
+	if err != nil {
+		return line
+	}
+	colon := strings.Index(line[key.Column-1:], ":")
+	if colon < 0 {
+		return line
+	}
+	end := key.Column - 1 + colon + 1
+	return line[:end] + " " + strings.TrimSpace(string(out))
+}
+
+// canonicalField returns the field name matches once case, "-" and "_" are
+// ignored (contentType, Content-Type), or "" if none does.
+func canonicalField(name string) string {
+	squash := func(s string) string {
+		return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(s))
+	}
+	for field := range frontmatterFields {
+		if field != name && squash(field) == squash(name) && !strings.Contains(field, "-") {
+			return field
+		}
+	}
+	return ""
+}
+
+// nearestField returns the field name within a typo's distance of name.
+func nearestField(name string) string {
+	fields := make([]string, 0, len(frontmatterFields))
+	for f := range frontmatterFields {
+		if !strings.Contains(f, "-") {
+			fields = append(fields, f)
+		}
+	}
+	return nearestName(strings.ToLower(name), fields)
+}
+
+// nearestName returns the candidate within a small edit distance of name,
+// or "" if none is close enough to be a typo.
+func nearestName(name string, candidates []string) string {
+	maxDist := 2
+	if len(name) < 5 {
+		maxDist = 1
+	}
+	slices.Sort(candidates)
+	best, bestDist := "", maxDist+1
+	for _, c := range candidates {
+		if d := editDistance(name, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,86 @@
+package indexer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintNote(t *testing.T) {
+	content := strings.Join([]string{
+		"---",
+		"title: Auth",
+		"tags: auth, security",
+		"content_type: decisions",
+		"confidence: high",
+		"contentType: note",
+		"evergreen: yes",
+		"review_by: next week",
+		"aliases: [login]",
+		"---",
+		"body",
+	}, "\n")
+	issues := LintNote(content)
+
+	byField := make(map[string]LintIssue)
+	for _, is := range issues {
+		byField[is.Field] = is
+	}
+	tests := []struct {
+		field    string
+		line     int
+		severity string
+		fixable  bool
+		contains string
+	}{
+		{"tags", 3, LintError, true, "must be a list"},
+		{"content_type", 4, LintWarning, false, `did you mean "decision"`},
+		{"confidence", 5, LintError, false, "must be a number"},
+		{"contentType", 6, LintWarning, false, `the key is "content_type"`},
+		{"review_by", 8, LintWarning, false, "not a date"},
+	}
+	for _, tt := range tests {
+		is, ok := byField[tt.field]
+		if !ok {
+			t.Errorf("no issue for %s in %+v", tt.field, issues)
+			continue
+		}
+		if is.Line != tt.line || is.Severity != tt.severity || is.Fixable != tt.fixable || !strings.Contains(is.Message, tt.contains) {
+			t.Errorf("%s: got %+v, want line %d %s fixable=%v containing %q", tt.field, is, tt.line, tt.severity, tt.fixable, tt.contains)
+		}
+	}
+	if len(issues) != len(tests) {
+		t.Errorf("got %d issues, want %d: %+v", len(issues), len(tests), issues)
+	}
+
+	if got := LintNote("no frontmatter\n"); got != nil {
+		t.Errorf("note without frontmatter: %+v", got)
+	}
+	if got := LintNote("---\ntitle: [unclosed\n---\n"); len(got) != 1 || got[0].Severity != LintError {
+		t.Errorf("invalid YAML: %+v", got)
+	}
+	if got := LintNote("---\ntags: [a]\n---\n"); len(got) != 1 || got[0].Field != "title" {
+		t.Errorf("missing title: %+v", got)
+	}
+}
+
+func TestFixNote(t *testing.T) {
+	content := "---\ntitle: Auth\n  # comment kept\ntags: auth, security\nContent-Type: decision\n---\nbody\n"
+	fixed, n := FixNote(content)
+	want := "---\ntitle: Auth\n  # comment kept\ntags: [auth, security]\ncontent_type: decision\n---\nbody\n"
+	if fixed != want || n != 2 {
+		t.Fatalf("FixNote = %d\n%s\nwant 2\n%s", n, fixed, want)
+	}
+	meta := ParseNote(fixed).Meta
+	if meta.ContentType != "decision" || len(meta.Tags) != 2 {
+		t.Errorf("fixed note parses as %+v", meta)
+	}
+	if again, n := FixNote(fixed); n != 0 || again != fixed {
+		t.Errorf("FixNote is not idempotent: %d fixes", n)
+	}
+
+	// The canonical key is already present, so renaming would duplicate it.
+	dup := "---\ntitle: A\ncontent_type: note\ncontentType: hub\n---\n"
+	if _, n := FixNote(dup); n != 0 {
+		t.Errorf("FixNote renamed a key onto an existing one")
+	}
+}
@@ -0,0 +1,76 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+// ReindexSubtree is ReindexProgressive limited to the notes under dir, a
+// directory given relative to the vault root or as an absolute path inside
+// it. Notes elsewhere in the vault are left untouched; with force, indexed
+// notes under dir whose files are gone are removed.
+func ReindexSubtree(ctx context.Context, db *store.DB, dir string, force bool, liteProgress ProgressFunc, embedProgress EmbeddingProgressFunc) (*Stats, *EmbeddingProgress, error) {
+	subtree, err := VaultSubtree(config.VaultPath(), dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reindexProgressive(ctx, db, force, subtree, liteProgress, embedProgress)
+}
+
+// VaultSubtree resolves dir to a vault-relative, slash-separated directory.
+// Relative paths are taken from the vault root. It fails when dir is the
+// vault itself, escapes the vault (including through symlinks), is not a
+// directory, or lies in a directory the indexer skips.
+func VaultSubtree(vaultPath, dir string) (string, error) {
+	vaultAbs, err := filepath.Abs(vaultPath)
+	if err != nil {
+		return "", fmt.Errorf("resolve vault path: %w", err)
+	}
+	target := dir
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(vaultAbs, target)
+	}
+	target = filepath.Clean(target)
+
+	realVault, err := filepath.EvalSymlinks(vaultAbs)
+	if err != nil {
+		realVault = vaultAbs
+	}
+	realTarget, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", dir, err)
+	}
+	rel, err := filepath.Rel(realVault, realTarget)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the vault", dir)
+	}
+	if rel == "." {
+		return "", fmt.Errorf("%s is the vault root; reindex without a path instead", dir)
+	}
+	if info, err := os.Stat(realTarget); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", dir)
+	}
+
+	rel = filepath.ToSlash(rel)
+	ip := LoadVaultIgnore(vaultPath)
+	parts := strings.Split(rel, "/")
+	for i, name := range parts {
+		prefix := strings.Join(parts[:i+1], "/")
+		if config.SkipDirs[name] || (ip != nil && ip.ShouldIgnore(prefix, true)) {
+			return "", fmt.Errorf("%s is excluded from indexing", dir)
+		}
+	}
+	return rel, nil
+}
+
+// inSubtree reports whether the vault-relative path lies under subtree. An
+// empty subtree contains every path.
+func inSubtree(path, subtree string) bool {
+	return subtree == "" || strings.HasPrefix(path, subtree+"/")
+}
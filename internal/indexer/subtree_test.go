@@ -0,0 +1,90 @@
+package indexer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func TestReindexSubtree_LeavesRestOfIndexAlone(t *testing.T) {
+	t.Setenv("SAME_EMBED_PROVIDER", "none")
+	vaultDir := setupTestVault(t)
+	writeTestNote(t, vaultDir, "projects/alpha/plan.md", "# Plan\n\nFirst draft.\n")
+	writeTestNote(t, vaultDir, "projects/alpha/old.md", "# Old\n\nGoing away.\n")
+	writeTestNote(t, vaultDir, "projects/alphabet.md", "# Alphabet\n\nSibling, not inside.\n")
+	writeTestNote(t, vaultDir, "journal/today.md", "# Today\n\nOriginal.\n")
+
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+	if _, err := ReindexLite(context.Background(), db, true, nil); err != nil {
+		t.Fatalf("ReindexLite: %v", err)
+	}
+	before, _ := db.GetContentHashes()
+
+	writeTestNote(t, vaultDir, "projects/alpha/plan.md", "# Plan\n\nSecond draft.\n")
+	writeTestNote(t, vaultDir, "projects/alpha/new.md", "# New\n\nJust added.\n")
+	if err := os.Remove(filepath.Join(vaultDir, "projects", "alpha", "old.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(vaultDir, "projects", "alphabet.md")); err != nil {
+		t.Fatal(err)
+	}
+	writeTestNote(t, vaultDir, "journal/today.md", "# Today\n\nEdited outside the subtree.\n")
+
+	stats, _, err := ReindexSubtree(context.Background(), db, "projects/alpha", true, nil, nil)
+	if err != nil {
+		t.Fatalf("ReindexSubtree: %v", err)
+	}
+	if stats.TotalFiles != 2 || stats.NewlyIndexed != 2 {
+		t.Errorf("stats = %+v, want 2 files scanned and indexed", stats)
+	}
+
+	after, _ := db.GetContentHashes()
+	if after["projects/alpha/plan.md"] == before["projects/alpha/plan.md"] {
+		t.Error("changed note under the subtree was not reindexed")
+	}
+	if _, ok := after["projects/alpha/new.md"]; !ok {
+		t.Error("new note under the subtree was not indexed")
+	}
+	if _, ok := after["projects/alpha/old.md"]; ok {
+		t.Error("deleted note under the subtree is still indexed")
+	}
+	if _, ok := after["projects/alphabet.md"]; !ok {
+		t.Error("note outside the subtree (sharing its name prefix) was removed")
+	}
+	if after["journal/today.md"] != before["journal/today.md"] {
+		t.Error("note outside the subtree was reindexed")
+	}
+}
+
+func TestVaultSubtree(t *testing.T) {
+	vaultDir := setupTestVault(t)
+	writeTestNote(t, vaultDir, "projects/alpha/plan.md", "# Plan\n")
+	writeTestNote(t, vaultDir, "_PRIVATE/keys/secret.md", "# Secret\n")
+	outside := t.TempDir()
+
+	if got, err := VaultSubtree(vaultDir, "projects/alpha/"); err != nil || got != "projects/alpha" {
+		t.Errorf("relative dir = %q, %v; want projects/alpha", got, err)
+	}
+	if got, err := VaultSubtree(vaultDir, filepath.Join(vaultDir, "projects")); err != nil || got != "projects" {
+		t.Errorf("absolute dir = %q, %v; want projects", got, err)
+	}
+	for _, dir := range []string{
+		".",
+		"../" + filepath.Base(outside),
+		outside,
+		"projects/alpha/plan.md",
+		"projects/missing",
+		"_PRIVATE/keys",
+	} {
+		if got, err := VaultSubtree(vaultDir, dir); err == nil {
+			t.Errorf("VaultSubtree(%q) = %q, want error", dir, got)
+		}
+	}
+}
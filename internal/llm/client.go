@@ -1,12 +1,16 @@
 package llm
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/llmutil"
 	"github.com/sgx-labs/statelessagent/internal/ollama"
 )
 
@@ -18,12 +22,83 @@ type Client interface {
 	Provider() string
 }
 
+// StreamingClient is implemented by clients that can stream generated text
+// as it arrives and abort the request when ctx is cancelled.
+type StreamingClient interface {
+	GenerateStream(ctx context.Context, model, prompt string, onToken func(string)) (string, error)
+}
+
+// GenerateStream generates a response, calling onToken with displayable
+// pieces as they arrive. Thinking blocks are filtered out of the pieces and
+// the returned text. Clients that cannot stream deliver the whole answer in
+// one piece. Returns ctx.Err() once ctx is done, even if the client ignores
+// cancellation.
+func GenerateStream(ctx context.Context, client Client, model, prompt string, onToken func(string)) (string, error) {
+	var (
+		filter  llmutil.ThinkingFilter
+		mu      sync.Mutex
+		stopped bool // set once GenerateStream returns; later tokens are dropped
+	)
+	emit := func(s string) {
+		if s != "" && onToken != nil {
+			onToken(s)
+		}
+	}
+
+	type result struct {
+		text string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		if sc, ok := client.(StreamingClient); ok {
+			r.text, r.err = sc.GenerateStream(ctx, model, prompt, func(tok string) {
+				mu.Lock()
+				defer mu.Unlock()
+				if !stopped {
+					emit(filter.Write(tok))
+				}
+			})
+		} else {
+			r.text, r.err = client.Generate(model, prompt)
+		}
+		done <- r
+	}()
+
+	select {
+	case <-ctx.Done():
+		mu.Lock()
+		stopped = true
+		mu.Unlock()
+		return "", ctx.Err()
+	case r := <-done:
+		if r.err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return "", r.err
+		}
+		if _, ok := client.(StreamingClient); ok {
+			emit(filter.Flush())
+		} else {
+			emit(r.text)
+		}
+		return r.text, nil
+	}
+}
+
 type clientConfig struct {
 	Provider  string
 	Model     string
 	BaseURL   string
 	APIKey    string
+	Timeout   time.Duration
 	Fallbacks []string
+
+	// baseURLSet is true when BaseURL came from SAME_CHAT_BASE_URL or
+	// Options rather than the embedding config.
+	baseURLSet bool
 }
 
 // Options controls chat client resolution behavior.
@@ -33,6 +108,17 @@ type Options struct {
 	//   - ollama
 	//   - openai-compatible with localhost/127.0.0.1/::1 base URL
 	LocalOnly bool
+
+	// Provider, Model, BaseURL, and APIKey are per-command defaults (for
+	// example the [ask] config section). The matching SAME_CHAT_* variable
+	// still wins when set.
+	Provider string
+	Model    string
+	BaseURL  string
+	APIKey   string
+
+	// Timeout bounds each chat request; zero keeps the provider default.
+	Timeout time.Duration
 }
 
 // NewClient constructs a chat client using provider-aware defaults.
@@ -48,7 +134,7 @@ func NewClient() (Client, error) {
 // NewClientWithOptions constructs a chat client using provider-aware defaults
 // and optional resolution constraints.
 func NewClientWithOptions(opts Options) (Client, error) {
-	cfg := resolveClientConfig()
+	cfg := resolveClientConfig(opts)
 	providers := providerOrder(cfg)
 	if opts.LocalOnly {
 		providers = filterLocalProviders(providers, cfg)
@@ -72,15 +158,17 @@ func NewClientWithOptions(opts Options) (Client, error) {
 	return nil, fmt.Errorf("no chat provider available (%s)", strings.Join(errs, "; "))
 }
 
-func resolveClientConfig() clientConfig {
+func resolveClientConfig(opts Options) clientConfig {
 	ec := config.EmbeddingProviderConfig()
 
 	cfg := clientConfig{
-		Provider: strings.TrimSpace(os.Getenv("SAME_CHAT_PROVIDER")),
-		Model:    strings.TrimSpace(os.Getenv("SAME_CHAT_MODEL")),
-		BaseURL:  strings.TrimSpace(os.Getenv("SAME_CHAT_BASE_URL")),
-		APIKey:   strings.TrimSpace(os.Getenv("SAME_CHAT_API_KEY")),
+		Provider: envOr(config.EnvChatProvider, opts.Provider),
+		Model:    envOr(config.EnvChatModel, opts.Model),
+		BaseURL:  envOr(config.EnvChatBaseURL, opts.BaseURL),
+		APIKey:   envOr(config.EnvChatAPIKey, opts.APIKey),
+		Timeout:  opts.Timeout,
 	}
+	cfg.baseURLSet = cfg.BaseURL != ""
 
 	if cfg.Provider == "" {
 		cfg.Provider = "auto"
@@ -94,7 +182,7 @@ func resolveClientConfig() clientConfig {
 		cfg.APIKey = strings.TrimSpace(ec.APIKey)
 	}
 
-	if v := strings.TrimSpace(os.Getenv("SAME_CHAT_FALLBACKS")); v != "" {
+	if v := strings.TrimSpace(os.Getenv(config.EnvChatFallbacks)); v != "" {
 		for _, p := range strings.Split(v, ",") {
 			p = normalizeProvider(p)
 			if p != "" {
@@ -106,6 +194,15 @@ func resolveClientConfig() clientConfig {
 	return cfg
 }
 
+// envOr returns the trimmed value of the environment variable key, or
+// fallback when it is unset.
+func envOr(key, fallback string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return strings.TrimSpace(fallback)
+}
+
 func providerOrder(cfg clientConfig) []string {
 	p := normalizeProvider(cfg.Provider)
 	if p != "" && p != "auto" {
@@ -144,7 +241,7 @@ func providerOrder(cfg clientConfig) []string {
 	if cfg.BaseURL != "" {
 		add("openai-compatible")
 	}
-	if cfg.APIKey != "" || strings.TrimSpace(os.Getenv("OPENAI_API_KEY")) != "" {
+	if cfg.APIKey != "" || strings.TrimSpace(os.Getenv(config.EnvOpenAIAPIKey)) != "" {
 		add("openai")
 	}
 
@@ -158,13 +255,17 @@ func newClientForProvider(provider string, cfg clientConfig) (Client, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &ollamaClient{client: ollama.NewClientWithURL(url)}, nil
+		client := ollama.NewClientWithURL(url)
+		if cfg.Timeout > 0 {
+			client.SetTimeout(cfg.Timeout)
+		}
+		return &ollamaClient{client: client}, nil
 	case "openai", "openai-compatible":
 		baseURL := cfg.BaseURL
 		// In auto mode, openai-compatible may inherit base_url from embedding config.
 		// For the real OpenAI provider, default back to api.openai.com unless the
-		// user explicitly set SAME_CHAT_BASE_URL.
-		if normalizeProvider(provider) == "openai" && strings.TrimSpace(os.Getenv("SAME_CHAT_BASE_URL")) == "" {
+		// user explicitly set a chat base URL.
+		if normalizeProvider(provider) == "openai" && !cfg.baseURLSet {
 			baseURL = ""
 		}
 		return newOpenAIClient(openAIClientConfig{
@@ -172,6 +273,7 @@ func newClientForProvider(provider string, cfg clientConfig) (Client, error) {
 			Model:    cfg.Model,
 			BaseURL:  baseURL,
 			APIKey:   cfg.APIKey,
+			Timeout:  cfg.Timeout,
 		})
 	case "none":
 		return nil, fmt.Errorf("chat provider disabled (SAME_CHAT_PROVIDER=none)")
@@ -233,3 +335,7 @@ func (c *ollamaClient) GenerateJSON(model, prompt string) (string, error) {
 func (c *ollamaClient) PickBestModel() (string, error) {
 	return c.client.PickBestModel()
 }
+
+func (c *ollamaClient) GenerateStream(ctx context.Context, model, prompt string, onToken func(string)) (string, error) {
+	return c.client.GenerateStream(ctx, model, prompt, onToken)
+}
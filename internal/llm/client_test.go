@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewClient_AutoUsesOpenAICompatibleFromEmbeddingProvider(t *testing.T) {
@@ -118,3 +119,59 @@ func jsonResponse(status int, body string) *http.Response {
 		Body:       io.NopCloser(strings.NewReader(body)),
 	}
 }
+
+func TestNewClientWithOptions_ProviderDefaultsAndEnvOverride(t *testing.T) {
+	t.Setenv("SAME_CHAT_PROVIDER", "")
+	t.Setenv("SAME_CHAT_MODEL", "")
+	t.Setenv("SAME_CHAT_BASE_URL", "")
+	t.Setenv("SAME_CHAT_API_KEY", "")
+	t.Setenv("SAME_CHAT_FALLBACKS", "")
+	t.Setenv("SAME_EMBED_PROVIDER", "ollama")
+
+	opts := Options{Provider: "openai", BaseURL: "https://openrouter.example/api", APIKey: "sk-test", Model: "small"}
+	client, err := NewClientWithOptions(opts)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	oc, ok := client.(*openAIClient)
+	if !ok {
+		t.Fatalf("expected openai client, got %T", client)
+	}
+	if oc.baseURL != "https://openrouter.example/api" || oc.model != "small" {
+		t.Errorf("client = %s/%s, want option base URL and model", oc.baseURL, oc.model)
+	}
+
+	t.Setenv("SAME_CHAT_PROVIDER", "openai-compatible")
+	t.Setenv("SAME_CHAT_BASE_URL", "http://localhost:1234")
+	client, err = NewClientWithOptions(opts)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions with env: %v", err)
+	}
+	if client.Provider() != "openai-compatible" || client.(*openAIClient).baseURL != "http://localhost:1234" {
+		t.Errorf("env should override options, got %s %s", client.Provider(), client.(*openAIClient).baseURL)
+	}
+}
+
+func TestNewClientWithOptions_Timeout(t *testing.T) {
+	t.Setenv("SAME_CHAT_PROVIDER", "openai-compatible")
+	t.Setenv("SAME_CHAT_BASE_URL", "http://localhost:8080/v1")
+	t.Setenv("SAME_CHAT_MODEL", "llama3.2")
+	t.Setenv("SAME_CHAT_API_KEY", "")
+	t.Setenv("SAME_CHAT_FALLBACKS", "")
+
+	client, err := NewClientWithOptions(Options{Timeout: 3 * time.Minute})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if got := client.(*openAIClient).httpClient.Timeout; got != 3*time.Minute {
+		t.Errorf("timeout = %v, want 3m", got)
+	}
+
+	client, err = NewClientWithOptions(Options{})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions: %v", err)
+	}
+	if got := client.(*openAIClient).httpClient.Timeout; got != 60*time.Second {
+		t.Errorf("default timeout = %v, want 60s", got)
+	}
+}
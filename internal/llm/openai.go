@@ -1,7 +1,9 @@
 package llm
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sgx-labs/statelessagent/internal/config"
 	"github.com/sgx-labs/statelessagent/internal/llmutil"
 )
 
@@ -25,6 +28,7 @@ type openAIClientConfig struct {
 	Model    string
 	BaseURL  string
 	APIKey   string
+	Timeout  time.Duration // 0 = 60s
 }
 
 type openAIClient struct {
@@ -52,7 +56,7 @@ func newOpenAIClient(cfg openAIClientConfig) (*openAIClient, error) {
 
 	apiKey := strings.TrimSpace(cfg.APIKey)
 	if provider == "openai" && apiKey == "" {
-		apiKey = strings.TrimSpace(os.Getenv("OPENAI_API_KEY"))
+		apiKey = strings.TrimSpace(os.Getenv(config.EnvOpenAIAPIKey))
 	}
 	if provider == "openai" && apiKey == "" {
 		return nil, fmt.Errorf("openai chat provider requires SAME_CHAT_API_KEY or OPENAI_API_KEY")
@@ -72,12 +76,17 @@ func newOpenAIClient(cfg openAIClientConfig) (*openAIClient, error) {
 		}
 	}
 
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+
 	return &openAIClient{
 		provider:   provider,
 		baseURL:    baseURL,
 		apiKey:     apiKey,
 		model:      model,
-		httpClient: &http.Client{Timeout: 60 * time.Second},
+		httpClient: &http.Client{Timeout: timeout},
 	}, nil
 }
 
@@ -267,6 +276,103 @@ func (c *openAIClient) generate(model, prompt string, jsonMode bool) (string, er
 	return llmutil.StripThinkingTokens(text), nil
 }
 
+type chatStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// GenerateStream requests a streamed chat completion and calls onToken with
+// each content delta as it arrives. Cancelling ctx aborts the request.
+func (c *openAIClient) GenerateStream(ctx context.Context, model, prompt string, onToken func(string)) (string, error) {
+	model, err := c.resolveModel(model)
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(chatRequest{
+		Model:    model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+		Stream:   true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	req.Header.Set("X-Title", "SAME")
+	req.Header.Set("HTTP-Referer", "https://statelessagent.com")
+
+	// ctx bounds the stream; the client's overall timeout would cut off
+	// long answers mid-stream.
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", &chatHTTPError{StatusCode: 0, Message: sanitizeChatError(err.Error(), c.apiKey)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 32*1024))
+		return "", &chatHTTPError{StatusCode: resp.StatusCode, Message: sanitizeChatError(string(respBody), c.apiKey)}
+	}
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, 10*1024*1024))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+		var chunk chatStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", fmt.Errorf("decode stream: %w", err)
+		}
+		if chunk.Error != nil {
+			return "", fmt.Errorf("chat provider error: %s", sanitizeChatError(chunk.Error.Message, c.apiKey))
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		full.WriteString(chunk.Choices[0].Delta.Content)
+		if onToken != nil {
+			onToken(chunk.Choices[0].Delta.Content)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("read stream: %w", err)
+	}
+
+	text := llmutil.StripThinkingTokens(full.String())
+	if text == "" {
+		return "", fmt.Errorf("chat response had empty content")
+	}
+	return text, nil
+}
+
 type listModelsResponse struct {
 	Data []struct {
 		ID string `json:"id"`
@@ -26,3 +26,78 @@ func StripThinkingTokens(s string) string {
 	result = reReflectionTags.ReplaceAllString(result, "")
 	return strings.TrimSpace(result)
 }
+
+// thinkingTagNames are the tags StripThinkingTokens removes.
+var thinkingTagNames = []string{"think", "reasoning", "reflection"}
+
+// ThinkingFilter removes thinking blocks from streamed text, where a tag
+// may be split across chunks. Feed chunks in order with Write and display
+// what it returns; call Flush at the end of the stream.
+type ThinkingFilter struct {
+	buf     string
+	closing string // closing tag while inside a block, "" otherwise
+}
+
+// Write adds chunk to the stream and returns the text that is now safe to
+// display. Text that could be the start of a tag is held back.
+func (f *ThinkingFilter) Write(chunk string) string {
+	f.buf += chunk
+	var out strings.Builder
+	for {
+		if f.closing != "" {
+			idx := strings.Index(strings.ToLower(f.buf), f.closing)
+			if idx < 0 {
+				// Keep just enough to match a closing tag split across chunks.
+				if keep := len(f.closing) - 1; len(f.buf) > keep {
+					f.buf = f.buf[len(f.buf)-keep:]
+				}
+				return out.String()
+			}
+			f.buf = f.buf[idx+len(f.closing):]
+			f.closing = ""
+			continue
+		}
+
+		lt := strings.IndexByte(f.buf, '<')
+		if lt < 0 {
+			out.WriteString(f.buf)
+			f.buf = ""
+			return out.String()
+		}
+		out.WriteString(f.buf[:lt])
+		f.buf = f.buf[lt:]
+
+		lower := strings.ToLower(f.buf)
+		partial := false
+		for _, tag := range thinkingTagNames {
+			open := "<" + tag + ">"
+			if strings.HasPrefix(lower, open) {
+				f.buf = f.buf[len(open):]
+				f.closing = "</" + tag + ">"
+				break
+			}
+			if strings.HasPrefix(open, lower) {
+				partial = true
+			}
+		}
+		if f.closing != "" {
+			continue
+		}
+		if partial {
+			return out.String()
+		}
+		out.WriteByte('<')
+		f.buf = f.buf[1:]
+	}
+}
+
+// Flush returns text held back at the end of the stream. An unterminated
+// thinking block is dropped.
+func (f *ThinkingFilter) Flush() string {
+	rest := f.buf
+	f.buf = ""
+	if f.closing != "" {
+		return ""
+	}
+	return rest
+}
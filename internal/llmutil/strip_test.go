@@ -89,3 +89,16 @@ func TestStripThinkingTokens(t *testing.T) {
 		})
 	}
 }
+
+func TestThinkingFilter_SplitTags(t *testing.T) {
+	chunks := []string{"<th", "ink>step 1", " step 2</thi", "nk>\nThe ", "answer is <b>42</b>", " <reas"}
+	var f ThinkingFilter
+	var got string
+	for _, c := range chunks {
+		got += f.Write(c)
+	}
+	got += f.Flush()
+	if want := "\nThe answer is <b>42</b> <reas"; got != want {
+		t.Errorf("filtered stream = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
+)
+
+// LogPath, when set by the caller before NewMCPServer, turns on the tool
+// call log. A relative path is resolved under config.DataDir().
+var LogPath string
+
+const (
+	callLogMaxSize  = 5 * 1024 * 1024 // rotate above 5MB...
+	callLogKeepSize = 1 * 1024 * 1024 // ...keeping the last ~1MB
+	callLogMaxArg   = 200             // longer string arguments are logged as a length
+)
+
+// bodyArgs are arguments that carry note text. They are logged as a
+// length, never as content.
+var bodyArgs = map[string]bool{
+	"content":     true,
+	"body":        true,
+	"summary":     true,
+	"description": true,
+	"prompt":      true,
+	"fields":      true,
+	"pending":     true,
+	"blockers":    true,
+	"reason":      true,
+}
+
+// callLogEntry is one line of the tool call log.
+type callLogEntry struct {
+	Time        string         `json:"time"`
+	Tool        string         `json:"tool"`
+	Args        map[string]any `json:"args,omitempty"`
+	ResultBytes int            `json:"result_bytes"`
+	LatencyMS   int64          `json:"latency_ms"`
+	IsError     bool           `json:"is_error,omitempty"`
+	Error       string         `json:"error,omitempty"`
+}
+
+// ResolveLogPath returns where the tool call log for name is written.
+func ResolveLogPath(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(config.DataDir(), name)
+}
+
+// callLogMiddleware records every tools/call request to path as a JSON
+// line: tool name, sanitized arguments, result size, latency, and error.
+// Results and note bodies are never written.
+func callLogMiddleware(path string) mcp.Middleware {
+	var mu sync.Mutex
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			call, ok := req.(*mcp.CallToolRequest)
+			if !ok || method != "tools/call" {
+				return next(ctx, method, req)
+			}
+			start := time.Now()
+			res, err := next(ctx, method, req)
+
+			entry := callLogEntry{
+				Time:      start.UTC().Format(time.RFC3339),
+				LatencyMS: time.Since(start).Milliseconds(),
+			}
+			if call.Params != nil {
+				entry.Tool = call.Params.Name
+				entry.Args = sanitizeCallArgs(call.Params.Arguments)
+			}
+			if err != nil {
+				entry.IsError = true
+				entry.Error = err.Error()
+			}
+			if result, ok := res.(*mcp.CallToolResult); ok && result != nil {
+				entry.IsError = entry.IsError || result.IsError
+				for _, c := range result.Content {
+					if tc, ok := c.(*mcp.TextContent); ok {
+						entry.ResultBytes += len(tc.Text)
+					}
+				}
+			}
+
+			line, _ := json.Marshal(entry)
+			mu.Lock()
+			appendCallLog(path, append(line, '\n'))
+			mu.Unlock()
+			return res, err
+		}
+	}
+}
+
+// sanitizeCallArgs keeps tool arguments useful for debugging without
+// leaking vault content: note bodies and long strings become a length, and
+// any value naming a _PRIVATE/ path is redacted.
+func sanitizeCallArgs(raw json.RawMessage) map[string]any {
+	var args map[string]any
+	if len(raw) == 0 || json.Unmarshal(raw, &args) != nil {
+		return nil
+	}
+	for k, v := range args {
+		if bodyArgs[k] {
+			args[k] = fmt.Sprintf("<%d chars>", len(fmt.Sprint(v)))
+			continue
+		}
+		args[k] = sanitizeArgValue(v)
+	}
+	return args
+}
+
+func sanitizeArgValue(v any) any {
+	switch val := v.(type) {
+	case string:
+		if strings.Contains(strings.ToUpper(filepath.ToSlash(val)), "_PRIVATE/") {
+			return "[private]"
+		}
+		if len(val) > callLogMaxArg {
+			return fmt.Sprintf("<%d chars>", len(val))
+		}
+		return val
+	case []any:
+		for i := range val {
+			val[i] = sanitizeArgValue(val[i])
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// appendCallLog appends line to the log, trimming it to its last ~1MB once
+// it grows past 5MB. The log is owner-only since queries can be sensitive.
+func appendCallLog(path string, line []byte) {
+	if info, err := os.Stat(path); err == nil && info.Size() > callLogMaxSize {
+		if data, err := os.ReadFile(path); err == nil && len(data) > callLogKeepSize {
+			kept := data[len(data)-callLogKeepSize:]
+			if idx := bytes.IndexByte(kept, '\n'); idx >= 0 {
+				kept = kept[idx+1:]
+			}
+			if err := os.WriteFile(path, kept, 0o600); err != nil {
+				fmt.Fprintf(os.Stderr, "same: warning: failed to rotate MCP log: %v\n", err)
+			}
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "same: warning: failed to open MCP log: %v\n", err)
+		return
+	}
+	if _, err := f.Write(line); err != nil {
+		fmt.Fprintf(os.Stderr, "same: warning: failed to append MCP log: %v\n", err)
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "same: warning: failed to close MCP log: %v\n", err)
+	}
+}
@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestCallLog_RecordsSanitizedToolCalls(t *testing.T) {
+	vault := setupHandlerTest(t)
+	logPath := filepath.Join(t.TempDir(), "mcp.log")
+	LogPath = logPath
+	t.Cleanup(func() { LogPath = "" })
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := NewMCPServer().Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "test"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer session.Close()
+
+	body := "# Secret plan\nthe launch code is 1234"
+	calls := []*mcp.CallToolParams{
+		{Name: "save_note", Arguments: map[string]any{"path": "notes/plan.md", "content": body, "append": false}},
+		{Name: "get_note", Arguments: map[string]any{"path": "_PRIVATE/diary.md"}},
+		{Name: "create_handoff", Arguments: map[string]any{
+			"summary":  "shipped the plan",
+			"pending":  "rotate the staging password",
+			"blockers": "waiting on the vendor contract",
+		}},
+		{Name: "mem_forget", Arguments: map[string]any{"path": "notes/plan.md", "reason": "mentions the acquisition"}},
+	}
+	for _, c := range calls {
+		if _, err := session.CallTool(ctx, c); err != nil {
+			t.Fatalf("CallTool %s: %v", c.Name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(vault, "notes", "plan.md")); err != nil {
+		t.Fatalf("save_note did not write the note: %v", err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	text := string(data)
+	for _, leaked := range []string{"launch code", "diary", "staging password", "vendor contract", "acquisition"} {
+		if strings.Contains(text, leaked) {
+			t.Errorf("log leaked %q:\n%s", leaked, text)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) != len(calls) {
+		t.Fatalf("got %d log lines, want %d:\n%s", len(lines), len(calls), text)
+	}
+	var save, get callLogEntry
+	if err := json.Unmarshal([]byte(lines[0]), &save); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &get); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if save.Tool != "save_note" || save.Args["path"] != "notes/plan.md" || save.ResultBytes == 0 || save.IsError {
+		t.Errorf("save_note entry = %+v", save)
+	}
+	if save.Args["content"] != fmt.Sprintf("<%d chars>", len(body)) {
+		t.Errorf("content logged as %v, want its length", save.Args["content"])
+	}
+	if get.Tool != "get_note" || get.Args["path"] != "[private]" || !get.IsError {
+		t.Errorf("get_note entry = %+v", get)
+	}
+}
@@ -0,0 +1,278 @@
+package mcp
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// frontmatterKeys are the top-level keys update_note_frontmatter may set.
+// Anything else is rejected so agents cannot smuggle in arbitrary metadata
+// (e.g. trust_state or provenance fields that SAME manages itself).
+var frontmatterKeys = map[string]bool{
+	"tags":         true,
+	"domain":       true,
+	"workstream":   true,
+	"content_type": true,
+	"confidence":   true,
+}
+
+// maxFrontmatterValueLen bounds string values written via MCP.
+const maxFrontmatterValueLen = 200
+
+// normalizeFrontmatterFields validates the requested updates and converts them
+// to the values that will be written. A nil value means "remove the key".
+func normalizeFrontmatterFields(fields map[string]any) (map[string]any, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("fields is required")
+	}
+	out := make(map[string]any, len(fields))
+	for key, raw := range fields {
+		if !frontmatterKeys[key] {
+			return nil, fmt.Errorf("unknown key %q (allowed: %s)", key, strings.Join(sortedFrontmatterKeys(), ", "))
+		}
+		if raw == nil {
+			out[key] = nil
+			continue
+		}
+		switch key {
+		case "tags":
+			tags, err := normalizeFrontmatterTags(raw)
+			if err != nil {
+				return nil, err
+			}
+			if len(tags) == 0 {
+				out[key] = nil
+			} else {
+				out[key] = tags
+			}
+		case "confidence":
+			f, ok := raw.(float64)
+			if !ok || math.IsNaN(f) || f < 0 || f > 1 {
+				return nil, fmt.Errorf("confidence must be a number between 0 and 1")
+			}
+			out[key] = f
+		default:
+			s, ok := raw.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a string", key)
+			}
+			s = strings.TrimSpace(s)
+			if err := validateFrontmatterString(key, s); err != nil {
+				return nil, err
+			}
+			if s == "" {
+				out[key] = nil
+			} else {
+				out[key] = s
+			}
+		}
+	}
+	return out, nil
+}
+
+// normalizeFrontmatterTags accepts a list of strings or a comma-separated
+// string and returns trimmed, de-duplicated tags in their original order.
+func normalizeFrontmatterTags(raw any) ([]string, error) {
+	var items []string
+	switch v := raw.(type) {
+	case string:
+		items = strings.Split(v, ",")
+	case []any:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("tags must be a list of strings")
+			}
+			items = append(items, s)
+		}
+	default:
+		return nil, fmt.Errorf("tags must be a list of strings")
+	}
+
+	seen := make(map[string]bool, len(items))
+	var tags []string
+	for _, t := range items {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		if err := validateFrontmatterString("tags", t); err != nil {
+			return nil, err
+		}
+		seen[t] = true
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
+
+func validateFrontmatterString(key, s string) error {
+	if len(s) > maxFrontmatterValueLen {
+		return fmt.Errorf("%s value too long (max %d characters)", key, maxFrontmatterValueLen)
+	}
+	if strings.ContainsAny(s, "\r\n\x00") {
+		return fmt.Errorf("%s value must be a single line", key)
+	}
+	return nil
+}
+
+func sortedFrontmatterKeys() []string {
+	keys := make([]string, 0, len(frontmatterKeys))
+	for k := range frontmatterKeys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// frontmatterNewline returns the line ending a note uses: "\r\n" when its
+// frontmatter opens with one (or, without frontmatter, when it has any),
+// otherwise "\n".
+func frontmatterNewline(content string) string {
+	switch {
+	case strings.HasPrefix(content, "---\r\n"):
+		return "\r\n"
+	case strings.HasPrefix(content, "---\n"):
+		return "\n"
+	case strings.Contains(content, "\r\n"):
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// splitFrontmatter separates a leading "---" YAML block from the body,
+// accepting LF or CRLF line endings. The block is returned with LF endings.
+// ok is false when the content has no frontmatter block.
+func splitFrontmatter(content string) (block, body string, ok bool) {
+	nl := frontmatterNewline(content)
+	if !strings.HasPrefix(content, "---"+nl) {
+		return "", content, false
+	}
+	rest := content[len("---"+nl):]
+	if strings.HasPrefix(rest, "---") {
+		// Empty block: "---\n---"
+		return "", strings.TrimPrefix(rest[len("---"):], nl), true
+	}
+	idx := strings.Index(rest, nl+"---")
+	if idx < 0 {
+		return "", content, false
+	}
+	tail := rest[idx+len(nl+"---"):]
+	if tail != "" && !strings.HasPrefix(tail, nl) {
+		return "", content, false
+	}
+	block = strings.ReplaceAll(rest[:idx+len(nl)], "\r\n", "\n")
+	return block, strings.TrimPrefix(tail, nl), true
+}
+
+// mergeFrontmatter applies updates to the note's frontmatter and returns the
+// rewritten content. The body is left byte-for-byte intact and keys that are
+// not being updated keep their order. changed reports whether any value
+// actually differs, so repeated calls with the same input are no-ops.
+func mergeFrontmatter(content string, updates map[string]any) (string, bool, error) {
+	block, body, hasFM := splitFrontmatter(content)
+
+	var doc yaml.Node
+	if strings.TrimSpace(block) != "" {
+		if err := yaml.Unmarshal([]byte(block), &doc); err != nil {
+			return "", false, fmt.Errorf("existing frontmatter is not valid YAML")
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return "", false, fmt.Errorf("existing frontmatter is not a key/value mapping")
+	}
+	mapping := doc.Content[0]
+
+	before, err := encodeFrontmatter(&doc)
+	if err != nil {
+		return "", false, err
+	}
+
+	keys := make([]string, 0, len(updates))
+	for k := range updates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := setMappingValue(mapping, key, updates[key]); err != nil {
+			return "", false, err
+		}
+	}
+
+	after, err := encodeFrontmatter(&doc)
+	if err != nil {
+		return "", false, err
+	}
+	if after == before {
+		return content, false, nil
+	}
+
+	if len(mapping.Content) == 0 {
+		if !hasFM {
+			return content, false, nil
+		}
+		return body, true, nil
+	}
+	nl := frontmatterNewline(content)
+	if !hasFM {
+		body = nl + body
+	}
+	after = strings.ReplaceAll(after, "\n", nl)
+	return "---" + nl + after + "---" + nl + body, true, nil
+}
+
+// setMappingValue replaces, appends, or (for nil) removes a key in a YAML
+// mapping node.
+func setMappingValue(mapping *yaml.Node, key string, value any) error {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			continue
+		}
+		if value == nil {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return nil
+		}
+		var v yaml.Node
+		if err := v.Encode(value); err != nil {
+			return fmt.Errorf("encode %s: %w", key, err)
+		}
+		mapping.Content[i+1] = &v
+		return nil
+	}
+	if value == nil {
+		return nil
+	}
+	var v yaml.Node
+	if err := v.Encode(value); err != nil {
+		return fmt.Errorf("encode %s: %w", key, err)
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&v,
+	)
+	return nil
+}
+
+func encodeFrontmatter(doc *yaml.Node) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return "", fmt.Errorf("encode frontmatter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("encode frontmatter: %w", err)
+	}
+	out := buf.String()
+	if strings.TrimSpace(out) == "{}" {
+		return "", nil
+	}
+	return out, nil
+}
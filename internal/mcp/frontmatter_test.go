@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeFrontmatterFields(t *testing.T) {
+	got, err := normalizeFrontmatterFields(map[string]any{
+		"tags":       "api, auth, api, ",
+		"domain":     "  engineering ",
+		"workstream": "",
+		"confidence": 0.8,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tags, _ := got["tags"].([]string)
+	if strings.Join(tags, ",") != "api,auth" {
+		t.Errorf("tags = %v, want [api auth]", got["tags"])
+	}
+	if got["domain"] != "engineering" {
+		t.Errorf("domain = %v", got["domain"])
+	}
+	if v, ok := got["workstream"]; !ok || v != nil {
+		t.Errorf("empty workstream should mean removal, got %v", v)
+	}
+
+	bad := []map[string]any{
+		nil,
+		{"trust_state": "validated"},
+		{"title": "x"},
+		{"confidence": 1.5},
+		{"confidence": "high"},
+		{"domain": 3.0},
+		{"domain": "a\nb"},
+		{"tags": []any{"ok", 1.0}},
+	}
+	for _, fields := range bad {
+		if _, err := normalizeFrontmatterFields(fields); err == nil {
+			t.Errorf("expected error for %v", fields)
+		}
+	}
+}
+
+func TestMergeFrontmatter(t *testing.T) {
+	content := "---\ntitle: Auth\ntags: [old]\nreview_by: 2026-01-01\n---\n\n# Auth\n\nBody stays put.\n---\nnot frontmatter\n"
+
+	updated, changed, err := mergeFrontmatter(content, map[string]any{
+		"tags":   []string{"api", "auth"},
+		"domain": "engineering",
+	})
+	if err != nil {
+		t.Fatalf("mergeFrontmatter: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected changed=true")
+	}
+	block, body, ok := splitFrontmatter(updated)
+	if !ok {
+		t.Fatalf("expected frontmatter in %q", updated)
+	}
+	if body != "\n# Auth\n\nBody stays put.\n---\nnot frontmatter\n" {
+		t.Errorf("body modified: %q", body)
+	}
+	for _, want := range []string{"title: Auth", "review_by: 2026-01-01", "domain: engineering", "- api", "- auth"} {
+		if !strings.Contains(block, want) {
+			t.Errorf("frontmatter missing %q:\n%s", want, block)
+		}
+	}
+	if strings.Contains(block, "old") {
+		t.Errorf("old tags should be replaced:\n%s", block)
+	}
+	if strings.Index(block, "title") > strings.Index(block, "domain") {
+		t.Errorf("existing keys should keep their position:\n%s", block)
+	}
+
+	again, changed, err := mergeFrontmatter(updated, map[string]any{
+		"tags":   []string{"api", "auth"},
+		"domain": "engineering",
+	})
+	if err != nil {
+		t.Fatalf("second merge: %v", err)
+	}
+	if changed || again != updated {
+		t.Errorf("second merge should be a no-op, got changed=%v\n%s", changed, again)
+	}
+}
+
+func TestMergeFrontmatter_NoExistingBlock(t *testing.T) {
+	updated, changed, err := mergeFrontmatter("# Plain\n", map[string]any{"content_type": "decision"})
+	if err != nil {
+		t.Fatalf("mergeFrontmatter: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected changed=true")
+	}
+	if updated != "---\ncontent_type: decision\n---\n\n# Plain\n" {
+		t.Errorf("unexpected content: %q", updated)
+	}
+
+	// Removing a key that isn't there leaves the note alone.
+	same, changed, err := mergeFrontmatter("# Plain\n", map[string]any{"domain": nil})
+	if err != nil || changed || same != "# Plain\n" {
+		t.Errorf("expected no-op, got changed=%v err=%v content=%q", changed, err, same)
+	}
+}
+
+func TestMergeFrontmatter_RemoveKey(t *testing.T) {
+	updated, changed, err := mergeFrontmatter("---\ndomain: ops\ntitle: T\n---\nbody\n", map[string]any{"domain": nil})
+	if err != nil {
+		t.Fatalf("mergeFrontmatter: %v", err)
+	}
+	if !changed || updated != "---\ntitle: T\n---\nbody\n" {
+		t.Errorf("unexpected result changed=%v: %q", changed, updated)
+	}
+}
+
+func TestMergeFrontmatter_InvalidYAML(t *testing.T) {
+	if _, _, err := mergeFrontmatter("---\n- just\n- a list\n---\nbody\n", map[string]any{"domain": "x"}); err == nil {
+		t.Error("expected error for non-mapping frontmatter")
+	}
+	if _, _, err := mergeFrontmatter("---\nkey: [unclosed\n---\nbody\n", map[string]any{"domain": "x"}); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestMergeFrontmatter_CRLF(t *testing.T) {
+	content := "---\r\ntitle: Auth\r\ntags: [old]\r\n---\r\n\r\n# Auth\r\n\r\nBody stays put.\r\n"
+
+	updated, changed, err := mergeFrontmatter(content, map[string]any{"domain": "engineering"})
+	if err != nil {
+		t.Fatalf("mergeFrontmatter: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected changed=true")
+	}
+	if strings.Count(updated, "---") != 2 {
+		t.Fatalf("expected the existing block to be updated, not a second one added:\n%q", updated)
+	}
+	want := "---\r\ntitle: Auth\r\ntags: [old]\r\ndomain: engineering\r\n---\r\n\r\n# Auth\r\n\r\nBody stays put.\r\n"
+	if updated != want {
+		t.Errorf("updated =\n%q\nwant\n%q", updated, want)
+	}
+}
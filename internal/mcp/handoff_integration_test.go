@@ -233,8 +233,8 @@ func TestNoteWithProvenanceCrossToolFlow(t *testing.T) {
 		t.Fatalf("save_note failed: %v", err)
 	}
 	saveText := resultText(t, saveResult)
-	if !strings.Contains(saveText, "Saved") {
-		t.Fatalf("expected 'Saved', got %q", saveText)
+	if saveResult.IsError || !strings.Contains(saveText, `"chunks_indexed"`) {
+		t.Fatalf("expected save_note result, got %q", saveText)
 	}
 	t.Logf("Agent A: %s", saveText)
 
@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Resource URIs for the orientation context that get_session_context
+// returns, for clients that read ambient context as resources.
+const (
+	resourcePinned          = "same://pinned"
+	resourceLatestHandoff   = "same://handoff/latest"
+	resourceRecentDecisions = "same://decisions/recent"
+)
+
+// recentDecisionsLimit caps same://decisions/recent.
+const recentDecisionsLimit = 10
+
+func registerResources(server *mcp.Server) {
+	server.AddResource(&mcp.Resource{
+		URI:         resourcePinned,
+		Name:        "pinned",
+		Title:       "Pinned notes",
+		Description: "Notes pinned with 'same pin', which are included in every session. JSON array of {path, title, text}.",
+		MIMEType:    "application/json",
+	}, jsonResource(func() any { return pinnedNotesContext() }))
+
+	server.AddResource(&mcp.Resource{
+		URI:         resourceLatestHandoff,
+		Name:        "handoff-latest",
+		Title:       "Latest handoff",
+		Description: "The most recent session handoff note. JSON object {path, title, text, modified}, or null when there is none.",
+		MIMEType:    "application/json",
+	}, jsonResource(func() any { return latestHandoffContext() }))
+
+	server.AddResource(&mcp.Resource{
+		URI:         resourceRecentDecisions,
+		Name:        "decisions-recent",
+		Title:       "Recent decisions",
+		Description: "The most recently modified decision notes. JSON array of {path, title, text, modified}.",
+		MIMEType:    "application/json",
+	}, jsonResource(func() any { return recentDecisionsContext(recentDecisionsLimit) }))
+}
+
+// jsonResource serves the value built by fn as a JSON resource. It is built
+// on every read so clients always see the current index.
+func jsonResource(fn func() any) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		data, err := json.MarshalIndent(fn(), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		}}}, nil
+	}
+}
+
+// pinnedNotesContext returns the pinned notes with text capped at 500
+// characters. Never nil, so an empty list encodes as [].
+func pinnedNotesContext() []map[string]string {
+	pinnedList := []map[string]string{}
+	pinned, err := db.GetPinnedNotes()
+	if err != nil {
+		return pinnedList
+	}
+	for _, p := range pinned {
+		text := p.Text
+		if len(text) > 500 {
+			text = text[:500] + "..."
+		}
+		// SECURITY: Neutralize injection tags in pinned note text
+		pinnedList = append(pinnedList, map[string]string{
+			"path":  p.Path,
+			"title": p.Title,
+			"text":  neutralizeTags(text),
+		})
+	}
+	return pinnedList
+}
+
+// latestHandoffContext returns the newest handoff with text capped at 1000
+// characters, or nil if there is none.
+func latestHandoffContext() map[string]string {
+	handoff, err := db.GetLatestHandoff()
+	if err != nil || handoff == nil {
+		return nil
+	}
+	text := handoff.Text
+	if len(text) > 1000 {
+		text = text[:1000] + "..."
+	}
+	// SECURITY: Neutralize injection tags in handoff text
+	return map[string]string{
+		"path":     handoff.Path,
+		"title":    handoff.Title,
+		"text":     neutralizeTags(text),
+		"modified": formatTimestamp(handoff.Modified),
+	}
+}
+
+// recentDecisionsContext returns up to limit decision notes, newest first,
+// with text capped at 500 characters.
+func recentDecisionsContext(limit int) []map[string]string {
+	decisions := []map[string]string{}
+	notes, err := db.RecentNotesByType("decision", limit)
+	if err != nil {
+		return decisions
+	}
+	for _, n := range notes {
+		text := n.Text
+		if len(text) > 500 {
+			text = text[:500] + "..."
+		}
+		// SECURITY: Neutralize injection tags in decision text
+		decisions = append(decisions, map[string]string{
+			"path":     n.Path,
+			"title":    n.Title,
+			"text":     neutralizeTags(text),
+			"modified": formatTimestamp(n.Modified),
+		})
+	}
+	return decisions
+}
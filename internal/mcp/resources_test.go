@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func TestResources_ReadPinnedHandoffAndDecisions(t *testing.T) {
+	setupHandlerTest(t)
+
+	notes := []store.NoteRecord{
+		{Path: "notes/pinned.md", Title: "Pinned", ChunkID: 0, ChunkHeading: "(full)", Text: "always include", Modified: 1, ContentHash: "p", ContentType: "note"},
+		{Path: "sessions/handoff.md", Title: "Handoff", ChunkID: 0, ChunkHeading: "(full)", Text: "next: ship it", Modified: 2, ContentHash: "h", ContentType: "handoff"},
+		{Path: "decisions/old.md", Title: "Old", ChunkID: 0, ChunkHeading: "(full)", Text: "use sqlite", Modified: 3, ContentHash: "d1", ContentType: "decision"},
+		{Path: "decisions/new.md", Title: "New", ChunkID: 0, ChunkHeading: "(full)", Text: "use sqlite-vec", Modified: 4, ContentHash: "d2", ContentType: "decision"},
+		{Path: "_PRIVATE/decisions/secret.md", Title: "Secret", ChunkID: 0, ChunkHeading: "(full)", Text: "hidden", Modified: 5, ContentHash: "s", ContentType: "decision"},
+	}
+	if _, err := db.BulkInsertNotesLite(notes); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+	if err := db.PinNote("notes/pinned.md", ""); err != nil {
+		t.Fatalf("PinNote: %v", err)
+	}
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := NewMCPServer().Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "test"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer session.Close()
+
+	listed, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources: %v", err)
+	}
+	if len(listed.Resources) != 3 {
+		t.Fatalf("listed %d resources, want 3", len(listed.Resources))
+	}
+
+	read := func(uri string, v any) {
+		t.Helper()
+		res, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+		if err != nil {
+			t.Fatalf("ReadResource %s: %v", uri, err)
+		}
+		if len(res.Contents) != 1 {
+			t.Fatalf("%s: got %d contents, want 1", uri, len(res.Contents))
+		}
+		if err := json.Unmarshal([]byte(res.Contents[0].Text), v); err != nil {
+			t.Fatalf("%s: unmarshal %q: %v", uri, res.Contents[0].Text, err)
+		}
+	}
+
+	var pinned []map[string]string
+	read(resourcePinned, &pinned)
+	if len(pinned) != 1 || pinned[0]["path"] != "notes/pinned.md" {
+		t.Errorf("pinned = %v", pinned)
+	}
+
+	var handoff map[string]string
+	read(resourceLatestHandoff, &handoff)
+	if handoff["path"] != "sessions/handoff.md" {
+		t.Errorf("handoff = %v", handoff)
+	}
+
+	var decisions []map[string]string
+	read(resourceRecentDecisions, &decisions)
+	if len(decisions) != 2 || decisions[0]["path"] != "decisions/new.md" || decisions[1]["path"] != "decisions/old.md" {
+		t.Errorf("decisions = %v, want new then old without _PRIVATE", decisions)
+	}
+}
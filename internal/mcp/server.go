@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/sgx-labs/statelessagent/internal/consolidate"
 	"github.com/sgx-labs/statelessagent/internal/embedding"
 	"github.com/sgx-labs/statelessagent/internal/guard"
+	"github.com/sgx-labs/statelessagent/internal/hooks"
 	"github.com/sgx-labs/statelessagent/internal/indexer"
 	"github.com/sgx-labs/statelessagent/internal/llm"
 	"github.com/sgx-labs/statelessagent/internal/memory"
@@ -39,7 +41,7 @@ var (
 )
 
 const reindexCooldown = 60 * time.Second
-const writeRateLimit = 30                // max write operations per minute
+const writeRateLimit = 30                // default max write operations per minute
 const writeRateWindow = 60 * time.Second // rate limit window
 
 // Write rate limiter — prevents rapid write abuse via prompt injection.
@@ -48,12 +50,18 @@ var (
 	writeMu    sync.Mutex
 )
 
-func checkWriteRateLimit() bool {
-	writeMu.Lock()
-	defer writeMu.Unlock()
-	now := time.Now()
+// currentWriteRateLimit returns [mcp] write_rate_limit, or writeRateLimit
+// when unset.
+func currentWriteRateLimit() int {
+	if n := config.MCPWriteRateLimit(); n > 0 {
+		return n
+	}
+	return writeRateLimit
+}
+
+// pruneWriteTimes drops writes that have left the window. Caller holds writeMu.
+func pruneWriteTimes(now time.Time) {
 	cutoff := now.Add(-writeRateWindow)
-	// Prune old entries
 	valid := writeTimes[:0]
 	for _, t := range writeTimes {
 		if t.After(cutoff) {
@@ -61,16 +69,82 @@ func checkWriteRateLimit() bool {
 		}
 	}
 	writeTimes = valid
-	if len(writeTimes) >= writeRateLimit {
+}
+
+func checkWriteRateLimit() bool {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	now := time.Now()
+	pruneWriteTimes(now)
+	if len(writeTimes) >= currentWriteRateLimit() {
 		return false
 	}
 	writeTimes = append(writeTimes, now)
 	return true
 }
 
+// writeRateHeadroom returns how many writes are left in the current window
+// and when the oldest counted write leaves it, freeing a slot. resetAt is
+// zero when no writes are being counted.
+func writeRateHeadroom() (remaining int, resetAt time.Time) {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	pruneWriteTimes(time.Now())
+	remaining = max(currentWriteRateLimit()-len(writeTimes), 0)
+	if len(writeTimes) > 0 {
+		resetAt = writeTimes[0].Add(writeRateWindow)
+	}
+	return remaining, resetAt
+}
+
+// rateLimitedResult is returned by write tools when the limiter trips.
+func rateLimitedResult() *mcp.CallToolResult {
+	_, resetAt := writeRateHeadroom()
+	wait := max(int(time.Until(resetAt).Seconds()+0.999), 1)
+	return errorResult(fmt.Sprintf(
+		"Error: write rate limit reached (%d writes per %ds). Next write allowed in %ds, at %s.",
+		currentWriteRateLimit(), int(writeRateWindow.Seconds()), wait, resetAt.UTC().Format(time.RFC3339)))
+}
+
+// withWriteHeadroom appends the remaining write budget to successful results
+// of a rate-limited tool so agents can pace themselves.
+func withWriteHeadroom[In any](h mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, any, error) {
+		result, out, err := h(ctx, req, input)
+		if err != nil || result == nil || result.IsError {
+			return result, out, err
+		}
+		remaining, resetAt := writeRateHeadroom()
+		note := fmt.Sprintf("Writes remaining: %d of %d this window.", remaining, currentWriteRateLimit())
+		if !resetAt.IsZero() {
+			note += fmt.Sprintf(" Window frees a slot at %s.", resetAt.UTC().Format(time.RFC3339))
+		}
+		result.Content = append(result.Content, &mcp.TextContent{Text: note})
+		return result, out, err
+	}
+}
+
 // Version is set by the caller (main) before calling Serve.
 var Version = "dev"
 
+// ReadOnly, when set by the caller before NewMCPServer, drops every tool in
+// writeTools. The [mcp] read_only config key has the same effect.
+var ReadOnly bool
+
+// writeTools are the tools that modify notes, note metadata, or the index.
+var writeTools = []string{
+	"reindex",
+	"save_note",
+	"update_note_frontmatter",
+	"delete_note",
+	"save_decision",
+	"create_handoff",
+	"mem_consolidate",
+	"mem_forget",
+	"mem_restore",
+	"save_kaizen",
+}
+
 // InitGlobals opens the vault database and initializes the package-level
 // embedding client and vault root. Call once before using NewMCPServer.
 // The caller is responsible for closing the returned *store.DB when done.
@@ -91,6 +165,7 @@ func InitGlobals() (*store.DB, error) {
 		APIKey:     ec.APIKey,
 		BaseURL:    ec.BaseURL,
 		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
 		SkipRetry:  !config.IsEmbeddingProviderExplicit(),
 	}
 	// For ollama provider, use the legacy [ollama] URL if no base_url is set
@@ -118,7 +193,7 @@ func InitGlobals() (*store.DB, error) {
 	return db, nil
 }
 
-// NewMCPServer creates a configured MCP server with all SAME tools registered.
+// NewMCPServer creates a configured MCP server with all SAME tools and resources registered.
 // The caller must call initGlobals() first to initialize the database and
 // embedding client. This allows the same server to be used with different
 // transports (stdio, Streamable HTTP).
@@ -129,6 +204,13 @@ func NewMCPServer() *mcp.Server {
 	}, nil)
 
 	registerTools(server)
+	registerResources(server)
+	if ReadOnly || config.MCPReadOnly() {
+		server.RemoveTools(writeTools...)
+	}
+	if LogPath != "" {
+		server.AddReceivingMiddleware(callLogMiddleware(ResolveLogPath(LogPath)))
+	}
 
 	return server
 }
@@ -157,6 +239,7 @@ func refreshEmbedClient() {
 		APIKey:     ec.APIKey,
 		BaseURL:    ec.BaseURL,
 		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
 		SkipRetry:  !config.IsEmbeddingProviderExplicit(),
 	}
 	if (provCfg.Provider == "ollama" || provCfg.Provider == "") && provCfg.BaseURL == "" {
@@ -191,6 +274,13 @@ func registerTools(server *mcp.Server) {
 		Annotations: readOnly,
 	}, handleSearchNotesFiltered)
 
+	// list_tags
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_tags",
+		Description: "List the tags, domains, and workstreams used in the knowledge base, with note counts. Use this before search_notes_filtered to pick valid filter values instead of guessing.\n\nArgs:\n  prefix: Only return tags starting with this prefix (optional, e.g. 'team/')\n\nReturns JSON with tags, domains, and workstreams, each sorted by note count.",
+		Annotations: readOnly,
+	}, handleListTags)
+
 	// get_note
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_note",
@@ -222,23 +312,37 @@ func registerTools(server *mcp.Server) {
 	// save_note (write-side)
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "save_note",
-		Description: "Create or update a markdown note in the vault. The note is written to disk and indexed automatically.\n\nOptionally specify source files to enable provenance tracking — SAME will flag this note as stale if sources change.\n\nArgs:\n  path: Relative path within the vault (e.g. 'decisions/auth-approach.md')\n  content: Markdown content to write\n  append: If true, append to existing file instead of overwriting (default false)\n  agent: Optional writer attribution stored in frontmatter (e.g. 'codex')\n  sources: File paths that this note was derived from (optional)\n\nReturns confirmation with the saved path.",
+		Description: "Create or update a markdown note in the vault. The note is written to disk and indexed automatically.\n\nOptionally specify source files to enable provenance tracking — SAME will flag this note as stale if sources change.\n\nArgs:\n  path: Relative path within the vault (e.g. 'decisions/auth-approach.md')\n  content: Markdown content to write\n  append: If true, append to existing file instead of overwriting (default false)\n  agent: Optional writer attribution stored in frontmatter (e.g. 'codex')\n  sources: File paths that this note was derived from (optional)\n\nReturns JSON with the saved path, chunks_indexed, index_mode (semantic, keyword-only, or not-indexed), frontmatter_added (e.g. agent), and any warnings such as credentials found or nothing indexed.",
+		Annotations: writeDestructive,
+	}, withWriteHeadroom(handleSaveNote))
+
+	// update_note_frontmatter (write-side)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_note_frontmatter",
+		Description: "Update a note's YAML frontmatter without touching its body. Use this to change tags, domain, workstream, content_type, or confidence instead of rewriting the whole note with save_note. Other frontmatter keys are preserved. Calling it again with the same values is a no-op.\n\nArgs:\n  path: Relative path of the note (required)\n  fields: Object of keys to set — only tags (list or comma-separated string), domain, workstream, content_type, and confidence (0-1) are allowed. Set a key to null or an empty value to remove it.\n\nReturns the keys that changed. The note is reindexed automatically.",
+		Annotations: writeNonDestructive,
+	}, withWriteHeadroom(handleUpdateNoteFrontmatter))
+
+	// delete_note (write-side)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_note",
+		Description: "Delete a note from the vault. The file is moved to .same/trash/ (not permanently erased) and removed from the search index. Use this to clean up notes that are obsolete or were saved by mistake. Prefer mem_forget if the note should only be hidden from search.\n\nArgs:\n  path: Relative path of the note to delete (required)\n  agent: Your agent identity (optional — if set, you can only delete notes you created)\n\nReturns the trash location so the note can be recovered.",
 		Annotations: writeDestructive,
-	}, handleSaveNote)
+	}, withWriteHeadroom(handleDeleteNote))
 
 	// save_decision (write-side)
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "save_decision",
 		Description: "Log a project decision. Appends to the decision log so future sessions can find it.\n\nArgs:\n  title: Short decision title (e.g. 'Use JWT for auth')\n  body: Full decision details — what was decided, why, alternatives considered\n  status: Decision status — 'accepted', 'proposed', or 'superseded' (default 'accepted')\n  agent: Optional writer attribution stored in frontmatter (e.g. 'codex')\n\nReturns confirmation.",
 		Annotations: writeNonDestructive,
-	}, handleSaveDecision)
+	}, withWriteHeadroom(handleSaveDecision))
 
 	// create_handoff (write-side)
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "create_handoff",
 		Description: "Create a session handoff note so the next session picks up where this one left off. Write what you worked on, what's pending, and any blockers.\n\nArgs:\n  summary: What was accomplished this session\n  pending: What's left to do (optional)\n  blockers: Any blockers or open questions (optional)\n  agent: Optional writer attribution stored in frontmatter (e.g. 'codex')\n\nReturns path to the handoff note.",
 		Annotations: writeNonDestructive,
-	}, handleCreateHandoff)
+	}, withWriteHeadroom(handleCreateHandoff))
 
 	// recent_activity (read-side)
 	mcp.AddTool(server, &mcp.Tool{
@@ -247,6 +351,13 @@ func registerTools(server *mcp.Server) {
 		Annotations: readOnly,
 	}, handleRecentActivity)
 
+	// surface_context (read-side)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "surface_context",
+		Description: "Pick the vault notes most relevant to a prompt, using the same selection as SAME's automatic context surfacing in Claude Code (conversation-mode gating, ranking, pinned notes, and the token budget). Use this at the start of a task when your client does not run SAME hooks.\n\nArgs:\n  prompt: The user's prompt or task description\n\nReturns the chosen notes with paths, scores, and snippets, or the reason nothing was surfaced.",
+		Annotations: readOnly,
+	}, handleSurfaceContext)
+
 	// get_session_context (read-side)
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_session_context",
@@ -257,7 +368,7 @@ func registerTools(server *mcp.Server) {
 	// search_across_vaults (federated read-side)
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "search_across_vaults",
-		Description: "Search across multiple registered vaults at once. Use this instead of search_notes when you need context from other projects or want a cross-project view. Vaults must be registered first via the CLI (`same vault add <name> <path>`).\n\nArgs:\n  query: Natural language search query\n  top_k: Number of results (default 10, max 100)\n  vaults: Comma-separated vault aliases to search. Omit to search all registered vaults. Unknown aliases are silently skipped.\n\nReturns ranked results with titles, paths, snippets, and source vault name.",
+		Description: "Search across multiple registered vaults at once. Use this instead of search_notes when you need context from other projects or want a cross-project view. Vaults must be registered first via the CLI (`same vault add <name> <path>`).\n\nArgs:\n  query: Natural language search query\n  top_k: Number of results (default 10, max 100)\n  vaults: Comma-separated vault aliases to search. Omit to search all registered vaults. Unknown aliases are silently skipped.\n\nReturns results ranked by relevance blended with normalized_score (0-1 rank within each source vault, so vaults using different embedding models compare fairly), with titles, paths, snippets, and source vault name. The same note found in several vaults is listed once, with the other vaults in also_in.",
 		Annotations: readOnly,
 	}, handleSearchAcrossVaults)
 
@@ -266,7 +377,7 @@ func registerTools(server *mcp.Server) {
 		Name:        "mem_consolidate",
 		Description: "Consolidate related notes in the vault. Merges duplicates, resolves contradictions, extracts key facts. Creates new knowledge files without modifying originals. Use this when the vault has many similar or overlapping notes.\n\nArgs:\n  dry_run: Preview what would be consolidated without writing files (default false)\n  threshold: Similarity threshold for grouping notes, 0.0-1.0 (default 0.75)\n\nReturns consolidation summary with groups found, facts extracted, and conflicts resolved. (experimental)",
 		Annotations: writeDestructive,
-	}, handleMemConsolidate)
+	}, withWriteHeadroom(handleMemConsolidate))
 
 	// mem_brief (autonomous memory management)
 	mcp.AddTool(server, &mcp.Tool{
@@ -287,7 +398,7 @@ func registerTools(server *mcp.Server) {
 		Name:        "mem_forget",
 		Description: "Suppress a memory so it won't be surfaced in normal search. The note is not deleted -- it's marked as suppressed and can be restored with mem_restore. Use this for outdated, incorrect, or irrelevant memories.\n\nArgs:\n  path: Path of the note to suppress (required)\n  reason: Why this memory is being suppressed (optional)\n  agent: Your agent identity (optional — if set, you can only suppress notes you created)\n\nReturns confirmation of suppression. (experimental)",
 		Annotations: writeNonDestructive,
-	}, handleMemForget)
+	}, withWriteHeadroom(handleMemForget))
 
 	// mem_restore (undo mem_forget)
 	mcp.AddTool(server, &mcp.Tool{
@@ -308,7 +419,7 @@ func registerTools(server *mcp.Server) {
 		Name:        "save_kaizen",
 		Description: "Log a friction point, bug, or improvement idea discovered during work. SAME tracks provenance — if the source files change later, the item is automatically flagged as potentially addressed.\n\nArgs:\n  description: What was observed (required)\n  area: Area of the codebase (e.g. 'indexer', 'config', 'hooks') (optional)\n  agent: Who observed it (optional)\n  sources: Related file paths for provenance tracking (optional)\n\nReturns confirmation with the file path.",
 		Annotations: writeNonDestructive,
-	}, handleSaveKaizen)
+	}, withWriteHeadroom(handleSaveKaizen))
 }
 
 // Tool input types
@@ -329,6 +440,14 @@ type searchFilteredInput struct {
 	ContentType string `json:"content_type,omitempty" jsonschema:"Filter by content type (decision, handoff, note, research)"`
 }
 
+type surfaceInput struct {
+	Prompt string `json:"prompt" jsonschema:"The user's prompt or task description"`
+}
+
+type listTagsInput struct {
+	Prefix string `json:"prefix,omitempty" jsonschema:"Only return tags starting with this prefix"`
+}
+
 type getInput struct {
 	Path string `json:"path" jsonschema:"Relative path from vault root"`
 }
@@ -350,6 +469,16 @@ type saveNoteInput struct {
 	Sources []string `json:"sources,omitempty" jsonschema:"File paths that this note was derived from or references. SAME tracks these to detect when source material changes, flagging the note as potentially stale."`
 }
 
+type updateFrontmatterInput struct {
+	Path   string         `json:"path" jsonschema:"Relative path of the note to update"`
+	Fields map[string]any `json:"fields" jsonschema:"Frontmatter keys to set: tags, domain, workstream, content_type, confidence. Use null to remove a key."`
+}
+
+type deleteNoteInput struct {
+	Path  string `json:"path" jsonschema:"Relative path of the note to delete"`
+	Agent string `json:"agent,omitempty" jsonschema:"Your agent identity (optional)"`
+}
+
 type saveDecisionInput struct {
 	Title  string `json:"title" jsonschema:"Short decision title"`
 	Body   string `json:"body" jsonschema:"Full decision details"`
@@ -417,7 +546,7 @@ func handleSearchNotes(ctx context.Context, req *mcp.CallToolRequest, input sear
 	results = filterPrivatePaths(results)
 	results = sanitizeResultSnippets(results)
 	if len(results) == 0 {
-		return errorResult("No results found. The index may be empty — try running reindex() first."), nil, nil
+		return textResult("No results found. The index may be empty — try running reindex() first."), nil, nil
 	}
 
 	// Reconsolidation: increment access counts for surfaced notes (fire-and-forget).
@@ -467,7 +596,7 @@ func handleSearchNotesFiltered(ctx context.Context, req *mcp.CallToolRequest, in
 	results = filterPrivatePaths(results)
 	results = sanitizeResultSnippets(results)
 	if len(results) == 0 {
-		return errorResult("No results found matching the filters."), nil, nil
+		return textResult("No results found matching the filters."), nil, nil
 	}
 
 	// Reconsolidation: increment access counts for surfaced notes (fire-and-forget).
@@ -477,6 +606,66 @@ func handleSearchNotesFiltered(ctx context.Context, req *mcp.CallToolRequest, in
 	return textResult(string(data)), nil, nil
 }
 
+// saveNoteResult is the JSON payload returned by save_note.
+type saveNoteResult struct {
+	Path             string   `json:"path"`
+	Chunks           int      `json:"chunks_indexed"`
+	IndexMode        string   `json:"index_mode"` // semantic, keyword-only, or not-indexed
+	FrontmatterAdded []string `json:"frontmatter_added,omitempty"`
+	Contradictions   int      `json:"contradictions,omitempty"`
+	Warnings         []string `json:"warnings,omitempty"`
+}
+
+// listTagsResult is the JSON payload returned by list_tags.
+type listTagsResult struct {
+	Tags        []store.TagCount   `json:"tags"`
+	Domains     []store.ValueCount `json:"domains"`
+	Workstreams []store.ValueCount `json:"workstreams"`
+}
+
+func handleListTags(ctx context.Context, req *mcp.CallToolRequest, input listTagsInput) (*mcp.CallToolResult, any, error) {
+	if len(input.Prefix) > 200 {
+		return errorResult("Error: prefix too long (max 200 characters)."), nil, nil
+	}
+
+	tags, err := db.TagCounts(strings.TrimSpace(input.Prefix))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "same: mcp: list_tags: %v\n", err)
+		return errorResult("Error listing tags. Try running reindex() first."), nil, nil
+	}
+	domains, err := db.DomainCounts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "same: mcp: list_tags: %v\n", err)
+		return errorResult("Error listing domains. Try running reindex() first."), nil, nil
+	}
+	workstreams, err := db.WorkstreamCounts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "same: mcp: list_tags: %v\n", err)
+		return errorResult("Error listing workstreams. Try running reindex() first."), nil, nil
+	}
+
+	out := listTagsResult{
+		Tags:        make([]store.TagCount, 0, len(tags)),
+		Domains:     make([]store.ValueCount, 0, len(domains)),
+		Workstreams: make([]store.ValueCount, 0, len(workstreams)),
+	}
+	for _, t := range tags {
+		t.Tag = neutralizeTags(t.Tag)
+		out.Tags = append(out.Tags, t)
+	}
+	for _, d := range domains {
+		d.Value = neutralizeTags(d.Value)
+		out.Domains = append(out.Domains, d)
+	}
+	for _, w := range workstreams {
+		w.Value = neutralizeTags(w.Value)
+		out.Workstreams = append(out.Workstreams, w)
+	}
+
+	data, _ := json.MarshalIndent(out, "", "  ")
+	return textResult(string(data)), nil, nil
+}
+
 func handleGetNote(ctx context.Context, req *mcp.CallToolRequest, input getInput) (*mcp.CallToolResult, any, error) {
 	safePath := safeVaultPath(input.Path)
 	if safePath == "" {
@@ -540,7 +729,7 @@ func handleFindSimilar(ctx context.Context, req *mcp.CallToolRequest, input simi
 	}
 
 	if len(results) == 0 {
-		return errorResult(fmt.Sprintf("No similar notes found for: %s.", input.Path)), nil, nil
+		return textResult(fmt.Sprintf("No similar notes found for: %s.", input.Path)), nil, nil
 	}
 
 	// Reconsolidation: increment access counts for surfaced notes (fire-and-forget).
@@ -656,8 +845,11 @@ func handleSaveNote(ctx context.Context, req *mcp.CallToolRequest, input saveNot
 	} else if strings.EqualFold(relPath, "imports") {
 		return errorResult("Error: save_note cannot write to imports/. Use same import for imported content."), nil, nil
 	}
+	if denied := checkWritablePath(relPath); denied != nil {
+		return denied, nil, nil
+	}
 	if !checkWriteRateLimit() {
-		return errorResult("Error: too many write operations. Try again in a minute."), nil, nil
+		return rateLimitedResult(), nil, nil
 	}
 
 	// S11: Prepend a provenance header so readers know this was MCP-generated.
@@ -671,13 +863,15 @@ func handleSaveNote(ctx context.Context, req *mcp.CallToolRequest, input saveNot
 		return errorResult("Error: could not create destination directory. Check vault write permissions."), nil, nil
 	}
 
+	agentAdded := false
 	if input.Append {
 		_, statErr := os.Stat(safePath)
 		if os.IsNotExist(statErr) {
 			content := input.Content
 			if agent != "" {
-				content = upsertAgentFrontmatter(content, agent)
-				content = injectProvenanceHeader(content, mcpHeader)
+				withAgent := upsertAgentFrontmatter(content, agent)
+				agentAdded = withAgent != content
+				content = injectProvenanceHeader(withAgent, mcpHeader)
 			} else {
 				content = mcpHeader + "\n" + content
 			}
@@ -696,6 +890,7 @@ func handleSaveNote(ctx context.Context, req *mcp.CallToolRequest, input saveNot
 						if writeErr := os.WriteFile(safePath, []byte(updated), 0o600); writeErr != nil {
 							return errorResult("Error: could not update note metadata. The note was not modified; check file permissions."), nil, nil
 						}
+						agentAdded = true
 					}
 				}
 			}
@@ -714,8 +909,9 @@ func handleSaveNote(ctx context.Context, req *mcp.CallToolRequest, input saveNot
 	} else {
 		content := input.Content
 		if agent != "" {
-			content = upsertAgentFrontmatter(content, agent)
-			content = injectProvenanceHeader(content, mcpHeader)
+			withAgent := upsertAgentFrontmatter(content, agent)
+			agentAdded = withAgent != content
+			content = injectProvenanceHeader(withAgent, mcpHeader)
 		} else {
 			content = mcpHeader + "\n" + content
 		}
@@ -724,11 +920,25 @@ func handleSaveNote(ctx context.Context, req *mcp.CallToolRequest, input saveNot
 		}
 	}
 
+	out := saveNoteResult{Path: relPath, IndexMode: indexModeNone}
+	if agentAdded {
+		out.FrontmatterAdded = append(out.FrontmatterAdded, "agent")
+	}
+	if credWarning != "" {
+		out.Warnings = append(out.Warnings, credWarning)
+	}
+
 	// S7: Index only the saved file instead of triggering a full vault reindex.
 	// This avoids O(n) work per save_note call, preventing DoS on large vaults.
-	if err := indexer.IndexSingleFile(db, safePath, relPath, vaultRoot, embedClient); err != nil {
+	if err := indexSavedNote(safePath, relPath); err != nil {
 		// Non-fatal: the note was saved, just not indexed yet
-		return textResult(fmt.Sprintf("Saved: %s (index update failed — run reindex to fix)", input.Path)), nil, nil
+		out.Warnings = append(out.Warnings, "Index update failed — run reindex to fix.")
+		data, _ := json.MarshalIndent(out, "", "  ")
+		return textResult(string(data)), nil, nil
+	}
+	out.Chunks, out.IndexMode = indexedChunks(relPath)
+	if out.Chunks == 0 || strings.TrimSpace(indexer.ParseNote(input.Content).Body) == "" {
+		out.Warnings = append(out.Warnings, "Note has no body outside its frontmatter; nothing searchable was indexed.")
 	}
 
 	// Record provenance sources if provided by the caller.
@@ -737,11 +947,9 @@ func handleSaveNote(ctx context.Context, req *mcp.CallToolRequest, input saveNot
 
 	// Contradiction detection: find similar existing notes and check for contradictions.
 	// This is best-effort — if embedding provider is unavailable, skip silently.
-	contradictions := detectAndRecordContradictions(relPath, input.Content)
-
-	message := fmt.Sprintf("Saved: %s", input.Path)
-	if len(contradictions) > 0 {
-		message += fmt.Sprintf(" (%d contradiction(s) detected — older notes flagged)", len(contradictions))
+	if contradictions := detectAndRecordContradictions(relPath, input.Content); len(contradictions) > 0 {
+		out.Contradictions = len(contradictions)
+		out.Warnings = append(out.Warnings, fmt.Sprintf("%d contradiction(s) detected — older notes flagged.", len(contradictions)))
 	}
 	if agent != "" {
 		if readClaims, claimErr := db.GetActiveReadClaimsForPath(relPath, agent); claimErr == nil && len(readClaims) > 0 {
@@ -753,13 +961,40 @@ func handleSaveNote(ctx context.Context, req *mcp.CallToolRequest, input saveNot
 					readers = append(readers, c.Agent)
 				}
 			}
-			message += fmt.Sprintf(" (warning: read-claims by %s on %s — check for breakage)", strings.Join(readers, ", "), relPath)
+			out.Warnings = append(out.Warnings, fmt.Sprintf("read-claims by %s on %s — check for breakage.", strings.Join(readers, ", "), relPath))
 		}
 	}
-	if credWarning != "" {
-		message += "\n" + credWarning
+	data, _ := json.MarshalIndent(out, "", "  ")
+	return textResult(string(data)), nil, nil
+}
+
+// Index modes reported by save_note.
+const (
+	indexModeSemantic = "semantic"
+	indexModeKeyword  = "keyword-only"
+	indexModeNone     = "not-indexed"
+)
+
+// indexSavedNote indexes one note written by an MCP tool, without
+// embeddings when no provider is available.
+func indexSavedNote(safePath, relPath string) error {
+	if embedClient == nil {
+		return indexer.IndexSingleFileLite(db, safePath, relPath, vaultRoot)
 	}
-	return textResult(message), nil, nil
+	return indexer.IndexSingleFile(db, safePath, relPath, vaultRoot, embedClient)
+}
+
+// indexedChunks reports how many chunks of relPath are in the index and
+// whether they carry embeddings.
+func indexedChunks(relPath string) (int, string) {
+	records, err := db.GetNoteByPath(relPath)
+	if err != nil || len(records) == 0 {
+		return 0, indexModeNone
+	}
+	if vec, err := db.GetNoteEmbedding(relPath); err == nil && len(vec) > 0 {
+		return len(records), indexModeSemantic
+	}
+	return len(records), indexModeKeyword
 }
 
 // recordProvenanceSources records explicitly-provided source files and
@@ -921,6 +1156,165 @@ func detectAndRecordContradictions(notePath string, content string) []memory.Con
 	return contradictions
 }
 
+func handleUpdateNoteFrontmatter(ctx context.Context, req *mcp.CallToolRequest, input updateFrontmatterInput) (*mcp.CallToolResult, any, error) {
+	if strings.TrimSpace(input.Path) == "" {
+		return errorResult("Error: path is required."), nil, nil
+	}
+	if !strings.HasSuffix(strings.ToLower(input.Path), ".md") {
+		return errorResult("Error: only .md (markdown) files can be updated via MCP."), nil, nil
+	}
+	updates, err := normalizeFrontmatterFields(input.Fields)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Error: %v.", err)), nil, nil
+	}
+
+	safePath := safeVaultPath(input.Path)
+	if safePath == "" {
+		return errorResult("Error: path must be a relative path within the vault. Cannot write to _PRIVATE/."), nil, nil
+	}
+	relPath, relErr := store.NormalizeClaimPath(input.Path)
+	if relErr != nil {
+		return errorResult("Error: path must stay within the vault. Use a relative path like 'notes/topic.md'."), nil, nil
+	}
+	info, statErr := os.Lstat(safePath)
+	if statErr != nil || !info.Mode().IsRegular() {
+		return errorResult(fmt.Sprintf("No note found at path: %s", relPath)), nil, nil
+	}
+	if info.Size() > maxReadSize {
+		return errorResult("Error: note is too large to update via MCP."), nil, nil
+	}
+
+	existing, err := os.ReadFile(safePath)
+	if err != nil {
+		return errorResult("Error: could not read note. Check file permissions."), nil, nil
+	}
+	updated, changed, err := mergeFrontmatter(string(existing), updates)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Error: %v. Fix the frontmatter manually or rewrite the note with save_note.", err)), nil, nil
+	}
+	if !changed {
+		return textResult(fmt.Sprintf("No changes: frontmatter of %s already matches.", relPath)), nil, nil
+	}
+	if denied := checkWritablePath(relPath); denied != nil {
+		return denied, nil, nil
+	}
+
+	if !checkWriteRateLimit() {
+		return rateLimitedResult(), nil, nil
+	}
+	if err := os.WriteFile(safePath, []byte(updated), info.Mode().Perm()); err != nil {
+		return errorResult("Error: could not write note file. Check vault permissions and available disk space."), nil, nil
+	}
+
+	keys := make([]string, 0, len(updates))
+	for k := range updates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	message := fmt.Sprintf("Updated frontmatter: %s (%s)", relPath, strings.Join(keys, ", "))
+
+	if err := indexer.IndexSingleFile(db, safePath, relPath, vaultRoot, embedClient); err != nil {
+		message += " (index update failed — run reindex to fix)"
+	}
+	return textResult(message), nil, nil
+}
+
+// trashDir is where delete_note moves files, relative to the vault root.
+const trashDir = ".same/trash"
+
+func handleDeleteNote(ctx context.Context, req *mcp.CallToolRequest, input deleteNoteInput) (*mcp.CallToolResult, any, error) {
+	if strings.TrimSpace(input.Path) == "" {
+		return errorResult("Error: path is required."), nil, nil
+	}
+	if !strings.HasSuffix(strings.ToLower(input.Path), ".md") {
+		return errorResult("Error: only .md (markdown) files can be deleted via MCP."), nil, nil
+	}
+	callerAgent, err := normalizeAgent(input.Agent)
+	if err != nil {
+		return errorResult("Error: invalid agent value. Use 1-128 visible characters without newlines."), nil, nil
+	}
+
+	safePath := safeVaultPath(input.Path)
+	if safePath == "" {
+		return errorResult("Error: path must be a relative path within the vault. Cannot delete from _PRIVATE/."), nil, nil
+	}
+	relPath, relErr := store.NormalizeClaimPath(input.Path)
+	if relErr != nil {
+		return errorResult("Error: path must stay within the vault. Use a relative path like 'notes/topic.md'."), nil, nil
+	}
+	info, statErr := os.Lstat(safePath)
+	if statErr != nil || !info.Mode().IsRegular() {
+		return errorResult(fmt.Sprintf("No note found at path: %s", relPath)), nil, nil
+	}
+
+	// Agent ownership check mirrors mem_forget: agents may only delete
+	// notes they created. Vault owners (no agent param) can delete anything.
+	if callerAgent != "" {
+		if notes, err := db.GetNoteByPath(relPath); err == nil && len(notes) > 0 {
+			if noteAgent := notes[0].Agent; noteAgent != "" && noteAgent != callerAgent {
+				return errorResult(fmt.Sprintf(
+					"Error: cannot delete a note created by agent %q. "+
+						"Only the creating agent or vault owner can delete notes.", noteAgent)), nil, nil
+			}
+		}
+	}
+
+	if denied := checkWritablePath(relPath); denied != nil {
+		return denied, nil, nil
+	}
+	if !checkWriteRateLimit() {
+		return rateLimitedResult(), nil, nil
+	}
+
+	trashRel, err := moveToTrash(safePath, relPath, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "same: mcp: delete_note: %v\n", err)
+		return errorResult("Error: could not move note to trash. Check vault write permissions."), nil, nil
+	}
+
+	message := fmt.Sprintf("Deleted: %s\nMoved to: %s", relPath, trashRel)
+	// The keyword index reads the note's chunks, so clear it before they go.
+	if err := db.FTS5DeleteNote(relPath); err != nil {
+		fmt.Fprintf(os.Stderr, "same: mcp: delete_note keyword index: %v\n", err)
+		message += "\nWarning: the note could not be removed from the keyword index. Run reindex() to clean up."
+	}
+	if err := db.DeleteByPath(relPath); err != nil {
+		fmt.Fprintf(os.Stderr, "same: mcp: delete_note index: %v\n", err)
+		message += "\nWarning: the note could not be removed from the index. Run reindex() to clean up."
+	} else {
+		_ = db.DeleteFactsForPath(relPath)
+	}
+	message += fmt.Sprintf("\nTo recover, move %s back to %s and run reindex().", trashRel, relPath)
+
+	return textResult(neutralizeTags(message)), nil, nil
+}
+
+// moveToTrash moves a vault file into a timestamped folder under .same/trash/,
+// preserving its relative path. Returns the trash location relative to the
+// vault root.
+func moveToTrash(absPath, relPath string, now time.Time) (string, error) {
+	stamp := now.Format("20060102-150405")
+	base := filepath.Join(vaultRoot, filepath.FromSlash(trashDir))
+	dest := filepath.Join(base, stamp, filepath.FromSlash(relPath))
+	for i := 2; ; i++ {
+		if _, err := os.Lstat(dest); os.IsNotExist(err) {
+			break
+		}
+		dest = filepath.Join(base, fmt.Sprintf("%s-%d", stamp, i), filepath.FromSlash(relPath))
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return "", fmt.Errorf("create trash dir: %w", err)
+	}
+	if err := os.Rename(absPath, dest); err != nil {
+		return "", fmt.Errorf("move to trash: %w", err)
+	}
+	rel, err := filepath.Rel(vaultRoot, dest)
+	if err != nil {
+		return dest, nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
 func handleSaveDecision(ctx context.Context, req *mcp.CallToolRequest, input saveDecisionInput) (*mcp.CallToolResult, any, error) {
 	if strings.TrimSpace(input.Title) == "" {
 		return errorResult("Error: title is required."), nil, nil
@@ -944,6 +1338,10 @@ func handleSaveDecision(ctx context.Context, req *mcp.CallToolRequest, input sav
 		return errorResult("Error: status must be 'accepted', 'proposed', or 'superseded'."), nil, nil
 	}
 
+	if dirRel := config.DecisionDirectory(); dirRel != "" {
+		return saveDecisionNote(dirRel, input, status, agent)
+	}
+
 	// Build decision entry
 	now := time.Now().Format("2006-01-02")
 	displayStatus := strings.ToUpper(status[:1]) + status[1:]
@@ -958,19 +1356,17 @@ func handleSaveDecision(ctx context.Context, req *mcp.CallToolRequest, input sav
 			safeTitle, now, displayStatus, agent, input.Body)
 	}
 
-	// Get decision log path from config
-	cfg, _ := config.LoadConfig()
-	logName := "decisions.md"
-	if cfg != nil && cfg.Vault.DecisionLog != "" {
-		logName = cfg.Vault.DecisionLog
-	}
+	logName := config.DecisionLogPath()
 
 	safePath := safeVaultPath(logName)
 	if safePath == "" {
 		return errorResult("Error: decision log path is invalid. Set `vault.decision_log` to a relative file under the vault."), nil, nil
 	}
+	if denied := checkWritablePath(filepath.ToSlash(filepath.Clean(logName))); denied != nil {
+		return denied, nil, nil
+	}
 	if !checkWriteRateLimit() {
-		return errorResult("Error: too many write operations. Try again in a minute."), nil, nil
+		return rateLimitedResult(), nil, nil
 	}
 	// Only set file-level agent frontmatter when creating a new file.
 	// On append, each decision entry carries its own inline **Agent:** attribution,
@@ -1003,6 +1399,35 @@ func handleSaveDecision(ctx context.Context, req *mcp.CallToolRequest, input sav
 	return textResult(fmt.Sprintf("Decision logged: %s (%s)", input.Title, status)), nil, nil
 }
 
+// saveDecisionNote writes a decision as its own note under dirRel, for
+// [output.decision] style = "files".
+func saveDecisionNote(dirRel string, input saveDecisionInput, status, agent string) (*mcp.CallToolResult, any, error) {
+	dir := safeVaultPath(dirRel)
+	if dir == "" {
+		return errorResult("Error: decision directory is invalid. Set `output.decision.path` to a relative directory under the vault."), nil, nil
+	}
+	cleanDir := filepath.ToSlash(filepath.Clean(dirRel))
+	if denied := checkWritablePath(cleanDir + "/"); denied != nil {
+		return denied, nil, nil
+	}
+	if !checkWriteRateLimit() {
+		return rateLimitedResult(), nil, nil
+	}
+	name, err := memory.WriteDecisionNote(dir, memory.DecisionNote{
+		Title:  input.Title,
+		Status: status,
+		Agent:  agent,
+		Body:   input.Body,
+	})
+	if err != nil {
+		return errorResult("Error: could not write decision note. Check vault permissions and available disk space."), nil, nil
+	}
+	relPath := cleanDir + "/" + name
+	_ = indexer.IndexSingleFile(db, filepath.Join(dir, name), relPath, vaultRoot, embedClient) // best-effort indexing
+
+	return textResult(fmt.Sprintf("Decision saved: %s (%s) → %s", input.Title, status, relPath)), nil, nil
+}
+
 func handleCreateHandoff(ctx context.Context, req *mcp.CallToolRequest, input createHandoffInput) (*mcp.CallToolResult, any, error) {
 	if strings.TrimSpace(input.Summary) == "" {
 		return errorResult("Error: summary is required."), nil, nil
@@ -1016,12 +1441,7 @@ func handleCreateHandoff(ctx context.Context, req *mcp.CallToolRequest, input cr
 		return errorResult("Error: invalid agent value. Use 1-128 visible characters without newlines."), nil, nil
 	}
 
-	// Get handoff dir from config
-	cfg, _ := config.LoadConfig()
-	handoffDir := "sessions"
-	if cfg != nil && cfg.Vault.HandoffDir != "" {
-		handoffDir = cfg.Vault.HandoffDir
-	}
+	handoffDir := config.HandoffDirectory()
 
 	now := time.Now()
 	// Use time-based suffix to match auto-handoff naming convention
@@ -1032,15 +1452,49 @@ func handleCreateHandoff(ctx context.Context, req *mcp.CallToolRequest, input cr
 	if safePath == "" {
 		return errorResult("Error: handoff path is invalid. Set `vault.handoff_dir` to a relative directory under the vault."), nil, nil
 	}
+	if denied := checkWritablePath(filepath.ToSlash(filepath.Clean(relPath))); denied != nil {
+		return denied, nil, nil
+	}
 	if !checkWriteRateLimit() {
-		return errorResult("Error: too many write operations. Try again in a minute."), nil, nil
+		return rateLimitedResult(), nil, nil
 	}
 
-	// Build handoff content
+	dir := filepath.Dir(safePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errorResult("Error: could not create handoff directory. Check vault write permissions."), nil, nil
+	}
+	if err := os.WriteFile(safePath, []byte(buildHandoffContent(input, agent, now)), 0o600); err != nil {
+		return errorResult("Error: could not write handoff note. Check vault permissions and available disk space."), nil, nil
+	}
+
+	// Index only the handoff file instead of a full vault reindex.
+	_ = indexer.IndexSingleFile(db, safePath, filepath.ToSlash(relPath), vaultRoot, embedClient) // best-effort indexing
+
+	return textResult(fmt.Sprintf("Handoff saved: %s", relPath)), nil, nil
+}
+
+// buildHandoffContent renders a create_handoff note with the vault's handoff
+// template, or the built-in format when there is none. A template without
+// its own frontmatter still gets the agent attribution.
+func buildHandoffContent(input createHandoffInput, agent string, now time.Time) string {
 	var buf strings.Builder
 	if agent != "" {
 		buf.WriteString(fmt.Sprintf("---\nagent: %q\n---\n\n", agent))
 	}
+	if out, ok := memory.RenderHandoffTemplate(memory.HandoffFields{
+		Summary:  input.Summary,
+		Pending:  input.Pending,
+		Blockers: input.Blockers,
+		Date:     now.Format("2006-01-02"),
+		Agent:    agent,
+	}); ok {
+		if strings.HasPrefix(out, "---") {
+			return out
+		}
+		buf.WriteString(out)
+		return buf.String()
+	}
+
 	buf.WriteString(fmt.Sprintf("# Session Handoff — %s\n\n", now.Format("2006-01-02")))
 	buf.WriteString("## What we worked on\n")
 	buf.WriteString(input.Summary)
@@ -1055,19 +1509,7 @@ func handleCreateHandoff(ctx context.Context, req *mcp.CallToolRequest, input cr
 		buf.WriteString(input.Blockers)
 		buf.WriteString("\n")
 	}
-
-	dir := filepath.Dir(safePath)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return errorResult("Error: could not create handoff directory. Check vault write permissions."), nil, nil
-	}
-	if err := os.WriteFile(safePath, []byte(buf.String()), 0o600); err != nil {
-		return errorResult("Error: could not write handoff note. Check vault permissions and available disk space."), nil, nil
-	}
-
-	// Index only the handoff file instead of a full vault reindex.
-	_ = indexer.IndexSingleFile(db, safePath, filepath.ToSlash(relPath), vaultRoot, embedClient) // best-effort indexing
-
-	return textResult(fmt.Sprintf("Handoff saved: %s", relPath)), nil, nil
+	return buf.String()
 }
 
 func handleRecentActivity(ctx context.Context, req *mcp.CallToolRequest, input recentInput) (*mcp.CallToolResult, any, error) {
@@ -1084,7 +1526,7 @@ func handleRecentActivity(ctx context.Context, req *mcp.CallToolRequest, input r
 		return errorResult("Error fetching recent notes. Try running reindex() first."), nil, nil
 	}
 	if len(notes) == 0 {
-		return errorResult("No notes found. The index may be empty — try running reindex() first."), nil, nil
+		return textResult("No notes found. The index may be empty — try running reindex() first."), nil, nil
 	}
 
 	entries := make([]map[string]string, 0, len(notes))
@@ -1105,42 +1547,65 @@ func handleRecentActivity(ctx context.Context, req *mcp.CallToolRequest, input r
 	return textResult(string(data)), nil, nil
 }
 
-func handleGetSessionContext(ctx context.Context, req *mcp.CallToolRequest, input emptyInput) (*mcp.CallToolResult, any, error) {
-	result := map[string]any{}
-
-	// Pinned notes
-	pinned, err := db.GetPinnedNotes()
-	if err == nil && len(pinned) > 0 {
-		pinnedList := make([]map[string]string, 0, len(pinned))
-		for _, p := range pinned {
-			text := p.Text
-			if len(text) > 500 {
-				text = text[:500] + "..."
-			}
-			// SECURITY: Neutralize injection tags in pinned note text
-			pinnedList = append(pinnedList, map[string]string{
-				"path":  p.Path,
-				"title": p.Title,
-				"text":  neutralizeTags(text),
-			})
-		}
-		result["pinned_notes"] = pinnedList
+func handleSurfaceContext(ctx context.Context, req *mcp.CallToolRequest, input surfaceInput) (*mcp.CallToolResult, any, error) {
+	prompt := strings.TrimSpace(input.Prompt)
+	if prompt == "" {
+		return errorResult("prompt is required"), nil, nil
+	}
+	if len(prompt) > maxQueryLen {
+		return errorResult(fmt.Sprintf("prompt too long (max %d characters)", maxQueryLen)), nil, nil
 	}
 
-	// Latest handoff
-	handoff, err := db.GetLatestHandoff()
-	if err == nil && handoff != nil {
-		text := handoff.Text
-		if len(text) > 1000 {
-			text = text[:1000] + "..."
+	preview := hooks.PreviewContextSurfacing(db, prompt)
+
+	notes := make([]map[string]any, 0, len(preview.Notes))
+	for _, n := range preview.Notes {
+		if !n.Included {
+			continue
+		}
+		// SECURITY: Filter _PRIVATE/ paths (defense-in-depth; surfacing already skips them)
+		upper := strings.ToUpper(n.Path)
+		if strings.HasPrefix(upper, "_PRIVATE/") || strings.HasPrefix(upper, "_PRIVATE\\") {
+			continue
+		}
+		note := map[string]any{
+			"path":         n.Path,
+			"title":        n.Title,
+			"content_type": n.ContentType,
+			"score":        n.Composite,
+			"tokens":       n.Tokens,
+			"snippet":      neutralizeTags(n.Snippet),
 		}
-		// SECURITY: Neutralize injection tags in handoff text
-		result["latest_handoff"] = map[string]string{
-			"path":     handoff.Path,
-			"title":    handoff.Title,
-			"text":     neutralizeTags(text),
-			"modified": formatTimestamp(handoff.Modified),
+		if n.Pinned {
+			note["pinned"] = true
 		}
+		notes = append(notes, note)
+	}
+
+	result := map[string]any{
+		"status":       preview.Status,
+		"notes":        notes,
+		"total_tokens": preview.TotalTokens,
+		"token_budget": preview.TokenBudget,
+	}
+	if preview.Mode != "" {
+		result["mode"] = preview.Mode
+	}
+	if len(notes) == 0 && preview.Reason != "" {
+		result["reason"] = preview.Reason
+	}
+	data, _ := json.MarshalIndent(result, "", "  ")
+	return textResult(string(data)), nil, nil
+}
+
+func handleGetSessionContext(ctx context.Context, req *mcp.CallToolRequest, input emptyInput) (*mcp.CallToolResult, any, error) {
+	result := map[string]any{}
+
+	if pinned := pinnedNotesContext(); len(pinned) > 0 {
+		result["pinned_notes"] = pinned
+	}
+	if handoff := latestHandoffContext(); handoff != nil {
+		result["latest_handoff"] = handoff
 	}
 
 	// Recent notes
@@ -1200,37 +1665,14 @@ func handleSearchAcrossVaults(ctx context.Context, req *mcp.CallToolRequest, inp
 
 	topK := clampTopK(input.TopK, 10)
 
-	// Resolve vault DB paths
-	reg := config.LoadRegistry()
-	vaultDBPaths := make(map[string]string)
-
-	if input.Vaults == "" {
-		// Search all registered vaults
-		for alias, vaultPath := range reg.Vaults {
-			dbPath := filepath.Join(vaultPath, ".same", "data", "vault.db")
-			if _, err := os.Stat(dbPath); err == nil {
-				vaultDBPaths[alias] = dbPath
-			}
-		}
-	} else {
-		for _, alias := range strings.Split(input.Vaults, ",") {
-			alias = strings.TrimSpace(alias)
-			if alias == "" {
-				continue
-			}
-			// F13: Only resolve via registry map, not filesystem probing.
-			// ResolveVault falls through to os.Stat which would allow
-			// searching arbitrary paths not in the vault registry.
-			resolved, ok := reg.Vaults[alias]
-			if !ok || resolved == "" {
-				continue
-			}
-			dbPath := filepath.Join(resolved, ".same", "data", "vault.db")
-			if _, err := os.Stat(dbPath); err == nil {
-				vaultDBPaths[alias] = dbPath
-			}
-		}
+	// Resolve vault DB paths. F13: only registry entries resolve, never
+	// filesystem paths, so agents can't search arbitrary directories.
+	// Unknown or unindexed aliases are silently skipped.
+	var aliases []string
+	if input.Vaults != "" {
+		aliases = strings.Split(input.Vaults, ",")
 	}
+	vaultDBPaths, _ := config.LoadRegistry().SearchableVaults(aliases, false)
 
 	if len(vaultDBPaths) == 0 {
 		return errorResult("No searchable vaults found. Register vaults with 'same vault add <name> <path>'."), nil, nil
@@ -1261,7 +1703,7 @@ func handleSearchAcrossVaults(ctx context.Context, req *mcp.CallToolRequest, inp
 	results = sanitizeFederatedSnippets(results)
 
 	if len(results) == 0 {
-		return errorResult(fmt.Sprintf("No results found across %d vault(s).", len(vaultDBPaths))), nil, nil
+		return textResult(fmt.Sprintf("No results found across %d vault(s).", len(vaultDBPaths))), nil, nil
 	}
 
 	// Reconsolidation: increment access counts for results from the current vault (fire-and-forget).
@@ -1282,7 +1724,7 @@ func handleSearchAcrossVaults(ctx context.Context, req *mcp.CallToolRequest, inp
 
 func handleMemConsolidate(ctx context.Context, req *mcp.CallToolRequest, input memConsolidateInput) (*mcp.CallToolResult, any, error) {
 	if !checkWriteRateLimit() && !input.DryRun {
-		return errorResult("Error: too many write operations. Try again in a minute."), nil, nil
+		return rateLimitedResult(), nil, nil
 	}
 
 	noteCount, _ := db.NoteCount()
@@ -1613,7 +2055,7 @@ func handleMemForget(ctx context.Context, req *mcp.CallToolRequest, input memFor
 		return errorResult("Error: path is required."), nil, nil
 	}
 	if !checkWriteRateLimit() {
-		return errorResult("Error: too many write operations. Try again in a minute."), nil, nil
+		return rateLimitedResult(), nil, nil
 	}
 
 	// Validate the path exists in the database
@@ -1707,7 +2149,7 @@ func handleSaveKaizen(ctx context.Context, req *mcp.CallToolRequest, input saveK
 		return errorResult("Error: description exceeds 100KB limit."), nil, nil
 	}
 	if !checkWriteRateLimit() {
-		return errorResult("Error: too many write operations. Try again in a minute."), nil, nil
+		return rateLimitedResult(), nil, nil
 	}
 
 	agent, err := normalizeAgent(input.Agent)
@@ -1723,6 +2165,9 @@ func handleSaveKaizen(ctx context.Context, req *mcp.CallToolRequest, input saveK
 	}
 	relPath := fmt.Sprintf("kaizen/%s-%s.md", date, slug)
 	fullPath := filepath.Join(vaultRoot, relPath)
+	if denied := checkWritablePath(relPath); denied != nil {
+		return denied, nil, nil
+	}
 
 	// Ensure kaizen directory exists
 	kaizenDir := filepath.Join(vaultRoot, "kaizen")
@@ -1963,6 +2408,8 @@ func formatTimestamp(ts float64) string {
 	return time.Unix(int64(ts), 0).Format("2006-01-02 15:04")
 }
 
+// textResult is a successful tool result. Empty results ("No results
+// found") are successes too; the agent asked and the vault has nothing.
 func textResult(text string) *mcp.CallToolResult {
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -1971,6 +2418,9 @@ func textResult(text string) *mcp.CallToolResult {
 	}
 }
 
+// errorResult is a tool result with IsError set, for invalid input and for
+// failures the agent may want to retry or report: database errors, missing
+// notes, an embedding or LLM provider that is down, rate limits.
 func errorResult(text string) *mcp.CallToolResult {
 	return &mcp.CallToolResult{
 		IsError: true,
@@ -2039,6 +2489,7 @@ func searchWithFallback(query string, opts store.SearchOptions) ([]store.SearchR
 		results, err = db.HybridSearch(queryVec, query, opts)
 	} else if db.FTSAvailable() {
 		// Fall back to FTS5 full-text search
+		opts.Synonyms = store.ConfiguredSynonyms()
 		results, err = db.FTS5Search(query, opts)
 	} else {
 		// Final fallback: keyword search on title/text
@@ -2308,6 +2759,51 @@ func clampTopK(topK, defaultVal int) int {
 	return topK
 }
 
+// checkWritablePath enforces the [mcp] writable_paths allowlist. It returns
+// an error result naming the allowed prefixes when relPath is outside them,
+// or nil when the write may proceed. An empty allowlist allows everything;
+// a config that fails to load allows nothing, since its allowlist is unknown.
+func checkWritablePath(relPath string) *mcp.CallToolResult {
+	allowed, err := config.MCPWritablePaths()
+	if err != nil {
+		return errorResult(fmt.Sprintf(
+			"Error: MCP writes are disabled because the SAME config could not be loaded (%v). "+
+				"Ask the user to fix .same/config.toml, then try again.", err))
+	}
+	if writablePathAllowed(relPath, allowed) {
+		return nil
+	}
+	return errorResult(fmt.Sprintf(
+		"Error: %s is outside the paths MCP tools may write to (%s). "+
+			"Choose a path under one of these prefixes, or ask the user to update [mcp] writable_paths in .same/config.toml.",
+		relPath, strings.Join(allowed, ", ")))
+}
+
+// writablePathAllowed reports whether relPath falls under one of the allowed
+// prefixes. A prefix ending in "/" matches anything below that directory;
+// otherwise it matches the exact path or a directory of that name.
+// Matching is case-insensitive so case-folding filesystems can't be used to
+// sidestep the allowlist.
+func writablePathAllowed(relPath string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	rel := strings.ToLower(filepath.ToSlash(relPath))
+	for _, prefix := range allowed {
+		prefix = strings.ToLower(prefix)
+		if strings.HasSuffix(prefix, "/") {
+			if strings.HasPrefix(rel, prefix) {
+				return true
+			}
+			continue
+		}
+		if rel == prefix || strings.HasPrefix(rel, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 // safeVaultPath resolves a relative path within the vault, blocking traversal attacks,
 // access to _PRIVATE/ content, writes to dot-directories (.same/, .git/, etc.),
 // and symlink escapes from the vault boundary.
@@ -343,9 +343,12 @@ func TestHandleSaveNote_CreateNewFile(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	text := resultText(t, result)
-	if !strings.Contains(text, "Saved") {
-		t.Errorf("expected 'Saved', got %q", text)
+	out := decodeSaveNoteResult(t, result)
+	if out.Path != "notes/new-note.md" || out.Chunks != 1 || out.IndexMode != indexModeSemantic {
+		t.Errorf("unexpected save result: %+v", out)
+	}
+	if len(out.Warnings) != 0 || len(out.FrontmatterAdded) != 0 {
+		t.Errorf("expected no warnings or added frontmatter, got %+v", out)
 	}
 
 	// Verify file was written with provenance header
@@ -376,9 +379,8 @@ func TestHandleSaveNote_AppendMode(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	text := resultText(t, result)
-	if !strings.Contains(text, "Saved") {
-		t.Errorf("expected 'Saved', got %q", text)
+	if out := decodeSaveNoteResult(t, result); out.Chunks == 0 {
+		t.Errorf("expected appended note to be indexed, got %+v", out)
 	}
 
 	// Verify appended (no provenance header for append mode)
@@ -405,9 +407,8 @@ func TestHandleSaveNote_CaseSensitiveMD(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	text := resultText(t, result)
-	if !strings.Contains(text, "Saved") {
-		t.Errorf("expected .MD to be accepted, got %q", text)
+	if result.IsError {
+		t.Errorf("expected .MD to be accepted, got %q", resultText(t, result))
 	}
 }
 
@@ -438,8 +439,8 @@ func TestHandleSaveNote_WithAgentFrontmatter(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if text := resultText(t, result); !strings.Contains(text, "Saved") {
-		t.Fatalf("expected save confirmation, got %q", text)
+	if out := decodeSaveNoteResult(t, result); len(out.FrontmatterAdded) != 1 || out.FrontmatterAdded[0] != "agent" {
+		t.Fatalf("expected agent in frontmatter_added, got %+v", out)
 	}
 
 	raw, err := os.ReadFile(filepath.Join(vault, "notes", "agent-note.md"))
@@ -484,6 +485,46 @@ func TestHandleSaveNote_ReadClaimWarning(t *testing.T) {
 	}
 }
 
+func TestHandleSaveNote_ReportsKeywordOnlyAndEmptyBody(t *testing.T) {
+	setupHandlerTest(t)
+	embedClient = nil
+
+	result, _, err := handleSaveNote(context.Background(), nil, saveNoteInput{
+		Path:    "notes/meta-only.md",
+		Content: "---\ntitle: Meta Only\ntags: [draft]\n---\n",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := decodeSaveNoteResult(t, result)
+	if out.IndexMode != indexModeKeyword {
+		t.Errorf("index_mode = %q, want %q", out.IndexMode, indexModeKeyword)
+	}
+	found := false
+	for _, w := range out.Warnings {
+		if strings.Contains(w, "nothing searchable was indexed") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected empty-body warning, got %v", out.Warnings)
+	}
+}
+
+// decodeSaveNoteResult parses a successful save_note result.
+func decodeSaveNoteResult(t *testing.T, result *mcp.CallToolResult) saveNoteResult {
+	t.Helper()
+	text := resultText(t, result)
+	if result.IsError {
+		t.Fatalf("save_note failed: %s", text)
+	}
+	var out saveNoteResult
+	if err := json.Unmarshal([]byte(text), &out); err != nil {
+		t.Fatalf("unmarshal save_note result %q: %v", text, err)
+	}
+	return out
+}
+
 // --- handleSaveDecision ---
 
 func TestHandleSaveDecision_EmptyTitle(t *testing.T) {
@@ -712,6 +753,36 @@ func TestHandleCreateHandoff_Success(t *testing.T) {
 	}
 }
 
+func TestHandleCreateHandoff_UsesVaultTemplate(t *testing.T) {
+	vault := setupHandlerTest(t)
+	tmplDir := filepath.Join(vault, ".same", "templates")
+	os.MkdirAll(tmplDir, 0o755)
+	tmpl := "# Handoff {{date}} ({{agent}})\n\nSummary: {{summary}}\nNext: {{pending}}\nBlocked on: {{blockers}}\n"
+	if err := os.WriteFile(filepath.Join(tmplDir, "handoff.md"), []byte(tmpl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, _, err := handleCreateHandoff(context.Background(), nil, createHandoffInput{
+		Summary:  "Implemented feature X",
+		Pending:  "Need to add tests",
+		Blockers: "Waiting on API spec",
+		Agent:    "codex",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rel := strings.TrimPrefix(resultText(t, result), "Handoff saved: ")
+	content, err := os.ReadFile(filepath.Join(vault, rel))
+	if err != nil {
+		t.Fatalf("handoff file not created: %v", err)
+	}
+	want := "---\nagent: \"codex\"\n---\n\n# Handoff " + time.Now().Format("2006-01-02") + " (codex)\n\n" +
+		"Summary: Implemented feature X\nNext: Need to add tests\nBlocked on: Waiting on API spec\n"
+	if string(content) != want {
+		t.Errorf("handoff content =\n%s\nwant\n%s", content, want)
+	}
+}
+
 func TestHandleCreateHandoff_OptionalFields(t *testing.T) {
 	vault := setupHandlerTest(t)
 	os.MkdirAll(vault, 0o755)
@@ -792,6 +863,23 @@ func TestHandleSearchNotes_EmptyIndex(t *testing.T) {
 	if !strings.Contains(text, "No results") {
 		t.Errorf("expected 'No results' for empty index, got %q", text)
 	}
+	if result.IsError {
+		t.Error("empty results should not be flagged as a tool error")
+	}
+}
+
+func TestHandleSearchNotes_DatabaseFailureIsError(t *testing.T) {
+	setupHandlerTest(t)
+	embedClient = nil
+	db.Close()
+
+	result, _, err := handleSearchNotes(context.Background(), nil, searchInput{Query: "test query"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Errorf("expected IsError for a search that could not run, got %q", resultText(t, result))
+	}
 }
 
 func TestHandleSearchNotes_EmbeddingMismatchFallsBackToKeyword(t *testing.T) {
@@ -952,6 +1040,9 @@ func TestHandleRecentActivity_EmptyDB(t *testing.T) {
 	if !strings.Contains(text, "No notes found") {
 		t.Errorf("expected 'No notes found', got %q", text)
 	}
+	if result.IsError {
+		t.Error("empty results should not be flagged as a tool error")
+	}
 }
 
 func TestHandleRecentActivity_WithNotes(t *testing.T) {
@@ -1075,7 +1166,7 @@ func TestHandleGetSessionContext_WithPinnedNotes(t *testing.T) {
 		Text:     "This note is pinned for context",
 		Modified: float64(time.Now().Unix()),
 	}, vec)
-	db.PinNote("notes/pinned.md")
+	db.PinNote("notes/pinned.md", "")
 
 	result, _, err := handleGetSessionContext(context.Background(), nil, emptyInput{})
 	if err != nil {
@@ -1349,6 +1440,45 @@ func TestRegisterTools(t *testing.T) {
 	registerTools(server)
 }
 
+func TestNewMCPServer_ReadOnlyDropsWriteTools(t *testing.T) {
+	setupHandlerTest(t)
+
+	listTools := func() []*mcp.Tool {
+		t.Helper()
+		ctx := context.Background()
+		serverTransport, clientTransport := mcp.NewInMemoryTransports()
+		if _, err := NewMCPServer().Connect(ctx, serverTransport, nil); err != nil {
+			t.Fatalf("server connect: %v", err)
+		}
+		client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "test"}, nil)
+		session, err := client.Connect(ctx, clientTransport, nil)
+		if err != nil {
+			t.Fatalf("client connect: %v", err)
+		}
+		defer session.Close()
+		res, err := session.ListTools(ctx, nil)
+		if err != nil {
+			t.Fatalf("ListTools: %v", err)
+		}
+		return res.Tools
+	}
+
+	all := listTools()
+
+	ReadOnly = true
+	t.Cleanup(func() { ReadOnly = false })
+	readTools := listTools()
+
+	if len(readTools) != len(all)-len(writeTools) {
+		t.Fatalf("read-only tools = %d, want %d (every writeTools name must be registered)", len(readTools), len(all)-len(writeTools))
+	}
+	for _, tool := range readTools {
+		if tool.Annotations == nil || !tool.Annotations.ReadOnlyHint {
+			t.Errorf("tool %q is not annotated read-only but survived read-only mode", tool.Name)
+		}
+	}
+}
+
 // --- reindexCooldown constant ---
 
 func TestReindexCooldown(t *testing.T) {
@@ -1356,3 +1486,490 @@ func TestReindexCooldown(t *testing.T) {
 		t.Errorf("expected reindexCooldown to be 60s, got %v", reindexCooldown)
 	}
 }
+
+// ---------- list_tags ----------
+
+func TestHandleListTags_CountsAndPrivateExclusion(t *testing.T) {
+	setupHandlerTest(t)
+
+	notes := []store.NoteRecord{
+		{Path: "notes/a.md", Title: "A", Tags: `["go","api"]`, Domain: "engineering", Workstream: "auth", ChunkID: 0, ChunkHeading: "(full)", Text: "a", Modified: 1, ContentHash: "a"},
+		{Path: "notes/b.md", Title: "B", Tags: `["go"]`, Domain: "engineering", ChunkID: 0, ChunkHeading: "(full)", Text: "b", Modified: 1, ContentHash: "b"},
+		{Path: "_PRIVATE/c.md", Title: "C", Tags: `["secret"]`, Domain: "personal", Workstream: "hidden", ChunkID: 0, ChunkHeading: "(full)", Text: "c", Modified: 1, ContentHash: "c"},
+	}
+	if _, err := db.BulkInsertNotesLite(notes); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+
+	result, _, err := handleListTags(context.Background(), nil, listTagsInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", resultText(t, result))
+	}
+
+	var out listTagsResult
+	if err := json.Unmarshal([]byte(resultText(t, result)), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(out.Tags) != 2 || out.Tags[0].Tag != "go" || out.Tags[0].Count != 2 {
+		t.Errorf("tags = %+v, want go(2) and api(1)", out.Tags)
+	}
+	if len(out.Domains) != 1 || out.Domains[0].Value != "engineering" || out.Domains[0].Count != 2 {
+		t.Errorf("domains = %+v, want engineering(2)", out.Domains)
+	}
+	if len(out.Workstreams) != 1 || out.Workstreams[0].Value != "auth" {
+		t.Errorf("workstreams = %+v, want auth(1)", out.Workstreams)
+	}
+	text := resultText(t, result)
+	for _, leaked := range []string{"secret", "personal", "hidden"} {
+		if strings.Contains(text, leaked) {
+			t.Errorf("private metadata %q leaked into list_tags output", leaked)
+		}
+	}
+}
+
+func TestHandleListTags_Prefix(t *testing.T) {
+	setupHandlerTest(t)
+
+	if _, err := db.BulkInsertNotesLite([]store.NoteRecord{
+		{Path: "a.md", Title: "A", Tags: `["team/infra","team/web","misc"]`, ChunkID: 0, ChunkHeading: "(full)", Text: "a", Modified: 1, ContentHash: "a"},
+	}); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+
+	result, _, _ := handleListTags(context.Background(), nil, listTagsInput{Prefix: "team/"})
+	var out listTagsResult
+	if err := json.Unmarshal([]byte(resultText(t, result)), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(out.Tags) != 2 {
+		t.Fatalf("expected 2 team/ tags, got %+v", out.Tags)
+	}
+	if out.Domains == nil || out.Workstreams == nil {
+		t.Error("domains and workstreams should be empty arrays, not null")
+	}
+}
+
+// ---------- delete_note ----------
+
+func TestHandleDeleteNote_MovesToTrashAndUnindexes(t *testing.T) {
+	dir := setupHandlerTest(t)
+
+	notePath := filepath.Join(dir, "notes", "old.md")
+	if err := os.MkdirAll(filepath.Dir(notePath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(notePath, []byte("# Old\nobsolete content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.BulkInsertNotesLite([]store.NoteRecord{
+		{Path: "notes/old.md", Title: "Old", Tags: `[]`, ChunkID: 0, ChunkHeading: "(full)", Text: "obsolete content", Modified: 1, ContentHash: "old"},
+	}); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+	if err := db.FTS5UpsertNote("notes/old.md"); err != nil {
+		t.Fatalf("FTS5UpsertNote: %v", err)
+	}
+
+	result, _, err := handleDeleteNote(context.Background(), nil, deleteNoteInput{Path: "notes/old.md"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := resultText(t, result)
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	if !strings.Contains(text, ".same/trash/") {
+		t.Errorf("expected trash location in result, got %q", text)
+	}
+
+	if _, err := os.Stat(notePath); !os.IsNotExist(err) {
+		t.Error("note should no longer exist at its original path")
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, ".same", "trash", "*", "notes", "old.md"))
+	if len(matches) != 1 {
+		t.Errorf("expected note in trash, found %v", matches)
+	}
+	notes, err := db.GetNoteByPath("notes/old.md")
+	if err != nil {
+		t.Fatalf("GetNoteByPath: %v", err)
+	}
+	if len(notes) != 0 {
+		t.Errorf("expected index rows removed, found %d", len(notes))
+	}
+	if db.FTSAvailable() {
+		// Query the FTS table itself: searches join back to vault_notes and
+		// would hide a stale keyword entry.
+		var hits int
+		if err := db.Conn().QueryRow(`SELECT COUNT(*) FROM vault_notes_fts WHERE vault_notes_fts MATCH 'obsolete'`).Scan(&hits); err != nil {
+			t.Fatalf("count FTS rows: %v", err)
+		}
+		if hits != 0 {
+			t.Errorf("expected note removed from keyword index, found %d entries", hits)
+		}
+	}
+}
+
+func TestHandleDeleteNote_Rejected(t *testing.T) {
+	dir := setupHandlerTest(t)
+
+	privatePath := filepath.Join(dir, "_PRIVATE", "secret.md")
+	if err := os.MkdirAll(filepath.Dir(privatePath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(privatePath, []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"empty", ""},
+		{"private", "_PRIVATE/secret.md"},
+		{"private lowercase", "_private/secret.md"},
+		{"traversal", "../outside.md"},
+		{"dot dir", ".same/config.md"},
+		{"non markdown", "notes/data.json"},
+		{"missing", "notes/missing.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, _ := handleDeleteNote(context.Background(), nil, deleteNoteInput{Path: tt.path})
+			if !result.IsError {
+				t.Errorf("expected error for path %q, got %q", tt.path, resultText(t, result))
+			}
+		})
+	}
+
+	if _, err := os.Stat(privatePath); err != nil {
+		t.Errorf("private note should be untouched: %v", err)
+	}
+}
+
+func TestHandleDeleteNote_AgentOwnership(t *testing.T) {
+	dir := setupHandlerTest(t)
+
+	if err := os.WriteFile(filepath.Join(dir, "owned.md"), []byte("owned"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.BulkInsertNotesLite([]store.NoteRecord{
+		{Path: "owned.md", Title: "Owned", Tags: `[]`, Agent: "codex", ChunkID: 0, ChunkHeading: "(full)", Text: "owned", Modified: 1, ContentHash: "owned"},
+	}); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+
+	result, _, _ := handleDeleteNote(context.Background(), nil, deleteNoteInput{Path: "owned.md", Agent: "claude"})
+	if !result.IsError {
+		t.Fatalf("expected ownership error, got %q", resultText(t, result))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "owned.md")); err != nil {
+		t.Errorf("note should not be moved: %v", err)
+	}
+}
+
+// ---------- update_note_frontmatter ----------
+
+func TestHandleUpdateNoteFrontmatter_UpdatesAndReindexes(t *testing.T) {
+	dir := setupHandlerTest(t)
+
+	notePath := filepath.Join(dir, "notes", "auth.md")
+	if err := os.MkdirAll(filepath.Dir(notePath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	original := "---\ntitle: Auth\ntags: [draft]\n---\n\n# Auth\n\nUse JWT tokens.\n"
+	if err := os.WriteFile(notePath, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	input := updateFrontmatterInput{
+		Path:   "notes/auth.md",
+		Fields: map[string]any{"tags": []any{"auth", "security"}, "domain": "engineering"},
+	}
+	result, _, err := handleUpdateNoteFrontmatter(context.Background(), nil, input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := resultText(t, result)
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", text)
+	}
+	if !strings.Contains(text, "Updated frontmatter") {
+		t.Errorf("unexpected result: %q", text)
+	}
+
+	data, _ := os.ReadFile(notePath)
+	if !strings.HasSuffix(string(data), "\n# Auth\n\nUse JWT tokens.\n") {
+		t.Errorf("body should be preserved, got %q", string(data))
+	}
+
+	notes, err := db.GetNoteByPath("notes/auth.md")
+	if err != nil || len(notes) == 0 {
+		t.Fatalf("expected note to be indexed: %v", err)
+	}
+	if notes[0].Domain != "engineering" {
+		t.Errorf("indexed domain = %q, want engineering", notes[0].Domain)
+	}
+	if tags := store.ParseTags(notes[0].Tags); len(tags) != 2 {
+		t.Errorf("indexed tags = %v, want [auth security]", tags)
+	}
+
+	// Same input again is a no-op.
+	result, _, _ = handleUpdateNoteFrontmatter(context.Background(), nil, input)
+	if text := resultText(t, result); result.IsError || !strings.Contains(text, "No changes") {
+		t.Errorf("expected idempotent no-op, got %q", text)
+	}
+}
+
+func TestHandleUpdateNoteFrontmatter_Rejected(t *testing.T) {
+	dir := setupHandlerTest(t)
+
+	if err := os.WriteFile(filepath.Join(dir, "note.md"), []byte("# Note\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		input updateFrontmatterInput
+	}{
+		{"empty path", updateFrontmatterInput{Fields: map[string]any{"domain": "x"}}},
+		{"no fields", updateFrontmatterInput{Path: "note.md"}},
+		{"unknown key", updateFrontmatterInput{Path: "note.md", Fields: map[string]any{"trust_state": "validated"}}},
+		{"private", updateFrontmatterInput{Path: "_PRIVATE/note.md", Fields: map[string]any{"domain": "x"}}},
+		{"traversal", updateFrontmatterInput{Path: "../note.md", Fields: map[string]any{"domain": "x"}}},
+		{"missing", updateFrontmatterInput{Path: "missing.md", Fields: map[string]any{"domain": "x"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, _ := handleUpdateNoteFrontmatter(context.Background(), nil, tt.input)
+			if !result.IsError {
+				t.Errorf("expected error, got %q", resultText(t, result))
+			}
+		})
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, "note.md"))
+	if string(data) != "# Note\n" {
+		t.Errorf("note should be unchanged, got %q", string(data))
+	}
+}
+
+func TestWriteRateLimit_ConfigurableWithHeadroom(t *testing.T) {
+	dir := setupHandlerTest(t)
+	if err := os.MkdirAll(filepath.Join(dir, ".same"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".same", "config.toml"), []byte("[mcp]\nwrite_rate_limit = 2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if remaining, resetAt := writeRateHeadroom(); remaining != 2 || !resetAt.IsZero() {
+		t.Fatalf("fresh headroom = %d, %v; want 2 and no reset", remaining, resetAt)
+	}
+
+	save := withWriteHeadroom(handleSaveNote)
+	result, _, err := save(context.Background(), nil, saveNoteInput{Path: "notes/one.md", Content: "one"})
+	if err != nil || result.IsError {
+		t.Fatalf("first save failed: %v %v", err, result)
+	}
+	last, ok := result.Content[len(result.Content)-1].(*mcp.TextContent)
+	if !ok || !strings.Contains(last.Text, "Writes remaining: 1 of 2") {
+		t.Fatalf("expected headroom note, got %+v", result.Content)
+	}
+
+	if _, _, err := save(context.Background(), nil, saveNoteInput{Path: "notes/two.md", Content: "two"}); err != nil {
+		t.Fatal(err)
+	}
+	result, _, _ = save(context.Background(), nil, saveNoteInput{Path: "notes/three.md", Content: "three"})
+	if !result.IsError {
+		t.Fatal("third save within the window should be rate limited")
+	}
+	if text := resultText(t, result); !strings.Contains(text, "2 writes per 60s") || !strings.Contains(text, "Next write allowed in") {
+		t.Errorf("rate limit message = %q", text)
+	}
+	if len(result.Content) != 1 {
+		t.Errorf("rate-limited result should not carry a headroom note: %+v", result.Content)
+	}
+}
+
+// ---------- mcp.writable_paths ----------
+
+func writeWritablePathsConfig(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, ".same"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "[mcp]\nwritable_paths = [\"notes/\", \"sessions/\"]\n"
+	if err := os.WriteFile(filepath.Join(dir, ".same", "config.toml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandleSaveNote_WritablePathsAllowlist(t *testing.T) {
+	dir := setupHandlerTest(t)
+	writeWritablePathsConfig(t, dir)
+
+	result, _, _ := handleSaveNote(context.Background(), nil, saveNoteInput{
+		Path:    "research/outside.md",
+		Content: "# Outside",
+	})
+	text := resultText(t, result)
+	if !result.IsError {
+		t.Fatalf("expected allowlist error, got %q", text)
+	}
+	if !strings.Contains(text, "notes/, sessions/") {
+		t.Errorf("error should name the allowed prefixes, got %q", text)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "research", "outside.md")); !os.IsNotExist(err) {
+		t.Error("note outside the allowlist should not be written")
+	}
+
+	result, _, _ = handleSaveNote(context.Background(), nil, saveNoteInput{
+		Path:    "notes/inside.md",
+		Content: "# Inside",
+	})
+	if result.IsError {
+		t.Fatalf("expected save inside allowlist to succeed, got %q", resultText(t, result))
+	}
+}
+
+func TestHandleSaveNote_BrokenConfigRefusesWrites(t *testing.T) {
+	dir := setupHandlerTest(t)
+	// An allowlist followed by a syntax error: the allowlist must not be
+	// silently dropped.
+	cfg := "[mcp]\nwritable_paths = [\"notes/\"]\n[vault\n"
+	if err := os.MkdirAll(filepath.Join(dir, ".same"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".same", "config.toml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, _, _ := handleSaveNote(context.Background(), nil, saveNoteInput{
+		Path:    "research/outside.md",
+		Content: "# Outside",
+	})
+	text := resultText(t, result)
+	if !result.IsError || !strings.Contains(text, "could not be loaded") {
+		t.Fatalf("expected writes to be refused with a broken config, got %q", text)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "research", "outside.md")); !os.IsNotExist(err) {
+		t.Error("note should not be written when the config is broken")
+	}
+}
+
+func TestHandleSaveDecision_WritablePathsAllowlist(t *testing.T) {
+	dir := setupHandlerTest(t)
+	writeWritablePathsConfig(t, dir)
+
+	result, _, _ := handleSaveDecision(context.Background(), nil, saveDecisionInput{
+		Title: "Use JWT",
+		Body:  "Because it is stateless.",
+	})
+	if !result.IsError {
+		t.Fatalf("expected decisions.md to be outside the allowlist, got %q", resultText(t, result))
+	}
+
+	// Handoffs go to sessions/ by default, which is allowed.
+	result, _, _ = handleCreateHandoff(context.Background(), nil, createHandoffInput{Summary: "Did things"})
+	if result.IsError {
+		t.Fatalf("expected handoff under sessions/ to succeed, got %q", resultText(t, result))
+	}
+}
+
+func TestHandleSaveDecision_FilesStyle(t *testing.T) {
+	dir := setupHandlerTest(t)
+	t.Setenv("SAME_DECISION_LOG", "")
+	os.MkdirAll(filepath.Join(dir, ".same"), 0o755)
+	cfg := "[output.decision]\nstyle = \"files\"\npath = \"adr\"\n"
+	if err := os.WriteFile(filepath.Join(dir, ".same", "config.toml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, title := range []string{"Use JWT", "Drop sessions"} {
+		result, _, _ := handleSaveDecision(context.Background(), nil, saveDecisionInput{
+			Title: title,
+			Body:  "Because it is stateless.",
+			Agent: "codex",
+		})
+		if result.IsError {
+			t.Fatalf("save_decision: %s", resultText(t, result))
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "decisions.md")); !os.IsNotExist(err) {
+		t.Errorf("decision log written despite files style (stat err %v)", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "adr", "0002-drop-sessions.md"))
+	if err != nil {
+		t.Fatalf("second decision note: %v", err)
+	}
+	for _, want := range []string{"content_type: decision", "agent: \"codex\"", "# Drop sessions", "**Status:** Accepted", "Because it is stateless."} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("decision note missing %q:\n%s", want, data)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "adr", "0001-use-jwt.md")); err != nil {
+		t.Errorf("first decision note: %v", err)
+	}
+}
+
+// --- handleSurfaceContext ---
+
+func TestHandleSurfaceContext_EmptyPrompt(t *testing.T) {
+	setupHandlerTest(t)
+
+	result, _, err := handleSurfaceContext(context.Background(), nil, surfaceInput{Prompt: "  "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Errorf("expected error result for empty prompt, got %q", resultText(t, result))
+	}
+}
+
+func TestHandleSurfaceContext_ReturnsNotesWithoutPrivate(t *testing.T) {
+	setupHandlerTest(t)
+	t.Setenv("SAME_EMBED_PROVIDER", "none")
+
+	vec := make([]float32, 768)
+	now := float64(time.Now().Unix())
+	for _, rec := range []store.NoteRecord{
+		{Path: "notes/auth-middleware.md", Title: "Auth middleware design", Text: "The auth middleware validates JWT tokens and refreshes expired sessions.", ContentType: "note", Confidence: 0.8, Modified: now},
+		{Path: "_PRIVATE/keys.md", Title: "Private keys", Text: "secret material", ContentType: "note", Confidence: 0.8, Modified: now},
+	} {
+		rec := rec
+		if err := db.InsertNote(&rec, vec); err != nil {
+			t.Fatalf("InsertNote: %v", err)
+		}
+	}
+	if err := db.PinNote("_PRIVATE/keys.md", ""); err != nil {
+		t.Fatalf("PinNote: %v", err)
+	}
+
+	result, _, err := handleSurfaceContext(context.Background(), nil, surfaceInput{Prompt: "how does the auth middleware validate JWT tokens"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := resultText(t, result)
+	var parsed struct {
+		Status string `json:"status"`
+		Notes  []struct {
+			Path    string `json:"path"`
+			Snippet string `json:"snippet"`
+		} `json:"notes"`
+	}
+	if err := json.Unmarshal([]byte(text), &parsed); err != nil {
+		t.Fatalf("parse result: %v\n%s", err, text)
+	}
+	if parsed.Status != "injected" || len(parsed.Notes) == 0 {
+		t.Fatalf("expected surfaced notes, got %s", text)
+	}
+	if parsed.Notes[0].Path != "notes/auth-middleware.md" || !strings.Contains(parsed.Notes[0].Snippet, "JWT") {
+		t.Errorf("unexpected first note: %+v", parsed.Notes[0])
+	}
+	if strings.Contains(text, "_PRIVATE") {
+		t.Errorf("private note leaked into surface_context: %s", text)
+	}
+}
@@ -314,3 +314,30 @@ func TestSafeVaultPath_SpacesInPath(t *testing.T) {
 		t.Error("expected valid path with spaces")
 	}
 }
+
+func TestWritablePathAllowed(t *testing.T) {
+	allowed := []string{"notes/", "sessions", "decisions.md"}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"notes/topic.md", true},
+		{"Notes/Topic.md", true},
+		{"notes/deep/topic.md", true},
+		{"sessions/2026-01-01-handoff.md", true},
+		{"decisions.md", true},
+		{"notesx/topic.md", false},
+		{"sessions-old/x.md", false},
+		{"decisions.md.bak", false},
+		{"research/topic.md", false},
+		{"topic.md", false},
+	}
+	for _, tt := range tests {
+		if got := writablePathAllowed(tt.path, allowed); got != tt.want {
+			t.Errorf("writablePathAllowed(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+	if !writablePathAllowed("anything/at/all.md", nil) {
+		t.Error("empty allowlist should allow every path")
+	}
+}
@@ -1,15 +1,20 @@
 package memory
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/sgx-labs/statelessagent/internal/config"
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
@@ -111,6 +116,7 @@ type BudgetReport struct {
 	TotalTokensInjected int                  `json:"total_tokens_injected"`
 	ReferencedCount     int                  `json:"referenced_injections"`
 	UtilizationRate     float64              `json:"utilization_rate"`
+	TokenBudget         int                  `json:"token_budget"`
 	PerHook             map[string]HookStats `json:"per_hook"`
 	Suggestions         []string             `json:"suggestions"`
 }
@@ -141,7 +147,13 @@ func GetBudgetReport(db *store.DB, sessionID string, lastNSessions int) interfac
 			"hint":   "Context usage tracking starts after hooks inject context.",
 		}
 	}
+	return budgetFromRecords(records, config.SurfacingMaxTokenBudget())
+}
 
+// budgetFromRecords aggregates usage records into a BudgetReport. tokenBudget
+// is the configured context surfacing budget that suggestions are measured
+// against.
+func budgetFromRecords(records []store.UsageRecord, tokenBudget int) BudgetReport {
 	totalInjections := len(records)
 	totalTokens := 0
 	referenced := 0
@@ -196,6 +208,12 @@ func GetBudgetReport(db *store.DB, sessionID string, lastNSessions int) interfac
 			suggestions = append(suggestions, fmt.Sprintf("%s: High average tokens (%d). Consider shorter snippets.", name, hs.AvgTokensPerInject))
 		}
 	}
+	if hs, ok := perHook["context_surfacing"]; ok && hs.Injections > 3 && hs.UtilizationRate < 0.3 &&
+		hs.AvgTokensPerInject*4 >= tokenBudget*3 {
+		suggestions = append(suggestions, fmt.Sprintf(
+			"context_surfacing fills most of its %d-token budget but is rarely referenced. Consider lowering it: same config set surfacing.max_token_budget %d",
+			tokenBudget, max(config.MinSurfacingTokenBudget, tokenBudget/2)))
+	}
 
 	return BudgetReport{
 		SessionsAnalyzed:    len(sessions),
@@ -203,11 +221,92 @@ func GetBudgetReport(db *store.DB, sessionID string, lastNSessions int) interfac
 		TotalTokensInjected: totalTokens,
 		ReferencedCount:     referenced,
 		UtilizationRate:     math.Round(utilizationRate*1000) / 1000,
+		TokenBudget:         tokenBudget,
 		PerHook:             perHook,
 		Suggestions:         suggestions,
 	}
 }
 
+// budgetCSVHeader lists the columns written by WriteBudgetCSV.
+var budgetCSVHeader = []string{
+	"session_id", "started", "hook", "injections", "referenced",
+	"utilization_rate", "total_tokens", "avg_tokens_per_injection", "wasted_tokens",
+}
+
+// WriteBudgetCSV writes budget utilization as CSV for spreadsheet analysis.
+// Each session gets one row with hook "all" for its totals, followed by one
+// row per hook, so waste can be charted over time or broken down by hook.
+// Sessions are selected the same way as GetBudgetReport and written oldest
+// first.
+func WriteBudgetCSV(w io.Writer, db *store.DB, sessionID string, lastNSessions int) error {
+	var records []store.UsageRecord
+	var err error
+	if sessionID != "" {
+		records, err = db.GetUsageBySession(sessionID)
+	} else {
+		records, err = db.GetRecentUsage(lastNSessions)
+	}
+	if err != nil {
+		return fmt.Errorf("read usage: %w", err)
+	}
+
+	// Records come back in timestamp order, so first appearance is the
+	// session start.
+	var order []string
+	bySession := make(map[string][]store.UsageRecord)
+	for _, rec := range records {
+		if _, ok := bySession[rec.SessionID]; !ok {
+			order = append(order, rec.SessionID)
+		}
+		bySession[rec.SessionID] = append(bySession[rec.SessionID], rec)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(budgetCSVHeader); err != nil {
+		return err
+	}
+	for _, sid := range order {
+		recs := bySession[sid]
+		started := recs[0].Timestamp
+		r := budgetFromRecords(recs, config.SurfacingMaxTokenBudget())
+		avg := 0
+		if r.TotalInjections > 0 {
+			avg = r.TotalTokensInjected / r.TotalInjections
+		}
+		if err := cw.Write(budgetCSVRow(sid, started, "all", r.TotalInjections, r.ReferencedCount,
+			r.UtilizationRate, r.TotalTokensInjected, avg)); err != nil {
+			return err
+		}
+
+		hooks := make([]string, 0, len(r.PerHook))
+		for name := range r.PerHook {
+			hooks = append(hooks, name)
+		}
+		sort.Strings(hooks)
+		for _, name := range hooks {
+			hs := r.PerHook[name]
+			if err := cw.Write(budgetCSVRow(sid, started, name, hs.Injections, hs.Referenced,
+				hs.UtilizationRate, hs.TotalTokens, hs.AvgTokensPerInject)); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// budgetCSVRow formats one CSV row. Wasted tokens use the same estimate as
+// the human-readable report.
+func budgetCSVRow(sessionID, started, hook string, injections, referenced int, rate float64, tokens, avg int) []string {
+	wasted := tokens - int(float64(tokens)*rate)
+	return []string{
+		sessionID, started, hook,
+		strconv.Itoa(injections), strconv.Itoa(referenced),
+		strconv.FormatFloat(rate, 'f', 3, 64),
+		strconv.Itoa(tokens), strconv.Itoa(avg), strconv.Itoa(wasted),
+	}
+}
+
 // SaveBudgetReport writes the budget report to a JSON file.
 func SaveBudgetReport(report interface{}, outputPath string) error {
 	data, err := json.MarshalIndent(report, "", "  ")
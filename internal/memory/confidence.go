@@ -3,6 +3,7 @@ package memory
 
 import (
 	"math"
+	"sort"
 	"strings"
 	"time"
 )
@@ -105,6 +106,18 @@ func CompositeScore(semanticScore, modifiedEpoch, confidence float64, contentTyp
 	return round3(math.Min(1.0, math.Max(0.0, score)))
 }
 
+// KnownContentTypes returns the content_type values SAME recognizes, sorted.
+// InferContentType ignores any other frontmatter value and infers the type
+// from the path and tags instead.
+func KnownContentTypes() []string {
+	types := make([]string, 0, len(decayRates))
+	for t := range decayRates {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
 // InferContentType infers content_type from path patterns and metadata.
 func InferContentType(path string, explicitType string, tags []string) string {
 	// Explicit type wins
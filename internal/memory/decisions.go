@@ -3,6 +3,7 @@ package memory
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -219,3 +220,178 @@ func AppendToDecisionLog(decisions []Decision, logPath string, project string) i
 
 	return written
 }
+
+// LoggedDecision is one entry read back from a decision log.
+type LoggedDecision struct {
+	Title         string `json:"title"`
+	Date          string `json:"date,omitempty"`
+	Status        string `json:"status,omitempty"`
+	Agent         string `json:"agent,omitempty"`
+	Body          string `json:"body,omitempty"`
+	AutoExtracted bool   `json:"auto_extracted,omitempty"`
+}
+
+// ParseDecisionLog reads entries in the order they appear in a decision log.
+// It understands both formats written to the log: "## Decision:" blocks from
+// save_decision, and "### <date>" entries appended by the decision extractor.
+// Auto-extracted entries are reported as "proposed" since they await review.
+func ParseDecisionLog(content string) []LoggedDecision {
+	var out []LoggedDecision
+	var cur *LoggedDecision
+	var body []string
+
+	flush := func() {
+		if cur == nil {
+			return
+		}
+		cur.Body = strings.TrimSpace(strings.Join(body, "\n"))
+		if cur.Title != "" {
+			out = append(out, *cur)
+		}
+		cur, body = nil, nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "## Decision:"):
+			flush()
+			cur = &LoggedDecision{Title: strings.TrimSpace(strings.TrimPrefix(trimmed, "## Decision:"))}
+		case strings.HasPrefix(trimmed, "### "):
+			flush()
+			heading := strings.TrimSpace(strings.TrimPrefix(trimmed, "### "))
+			if i := strings.Index(heading, " "); i > 0 {
+				heading = heading[:i]
+			}
+			cur = &LoggedDecision{Date: heading, Status: "proposed", AutoExtracted: true}
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+		case cur == nil:
+		case strings.HasPrefix(trimmed, "**Date:**"):
+			cur.Date = strings.TrimSpace(strings.TrimPrefix(trimmed, "**Date:**"))
+		case strings.HasPrefix(trimmed, "**Status:**"):
+			cur.Status = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(trimmed, "**Status:**")))
+		case strings.HasPrefix(trimmed, "**Agent:**"):
+			cur.Agent = strings.TrimSpace(strings.TrimPrefix(trimmed, "**Agent:**"))
+		case cur.AutoExtracted && cur.Title == "" && strings.HasPrefix(trimmed, "- "):
+			cur.Title = strings.TrimSpace(strings.TrimPrefix(trimmed, "- "))
+		case cur.AutoExtracted && strings.Contains(trimmed, "auto-extracted*"):
+			// Confidence annotation; not part of the decision text.
+		default:
+			body = append(body, line)
+		}
+	}
+	flush()
+	return out
+}
+
+// DecisionNote is one decision written as its own note, ADR style.
+type DecisionNote struct {
+	Title         string
+	Status        string // accepted, proposed, or superseded
+	Agent         string
+	Body          string
+	AutoExtracted bool
+}
+
+// DecisionFromExtract turns an auto-extracted decision into a proposed
+// DecisionNote awaiting review.
+func DecisionFromExtract(d Decision) DecisionNote {
+	title := truncateAtWordBoundary(d.Text, 80)
+	if title == "" {
+		title = "Untitled decision"
+	}
+	return DecisionNote{
+		Title:         title,
+		Status:        "proposed",
+		Body:          fmt.Sprintf("%s\n\n*confidence: %s, auto-extracted*", d.Text, d.Confidence),
+		AutoExtracted: true,
+	}
+}
+
+// WriteDecisionNote writes d to a new file in dir, numbered after the
+// highest existing "NNNN-" prefix (0007-use-postgres.md), and returns the
+// file name. dir must already be validated to lie inside the vault.
+func WriteDecisionNote(dir string, d DecisionNote) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create decision directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("read decision directory: %w", err)
+	}
+	next := 1
+	for _, e := range entries {
+		var n int
+		if _, err := fmt.Sscanf(e.Name(), "%04d-", &n); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+
+	title := strings.Join(strings.Fields(d.Title), " ")
+	status := d.Status
+	if status == "" {
+		status = "accepted"
+	}
+	tags := "[decision]"
+	if d.AutoExtracted {
+		tags = "[decision, auto-extracted]"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "---\ntitle: %q\ncontent_type: decision\ntags: %s\n", title, tags)
+	if d.Agent != "" {
+		fmt.Fprintf(&b, "agent: %q\n", d.Agent)
+	}
+	fmt.Fprintf(&b, "---\n\n# %s\n\n**Date:** %s\n**Status:** %s\n", title,
+		time.Now().Format("2006-01-02"), strings.ToUpper(status[:1])+status[1:])
+	if d.Agent != "" {
+		fmt.Fprintf(&b, "**Agent:** %s\n", d.Agent)
+	}
+	fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(d.Body))
+
+	slug := decisionSlug(title)
+	// O_EXCL: a concurrent writer may claim the same number; take the next.
+	for attempt := 0; attempt < 100; attempt++ {
+		name := fmt.Sprintf("%04d-%s.md", next+attempt, slug)
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("create decision note: %w", err)
+		}
+		_, err = f.WriteString(b.String())
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return "", fmt.Errorf("write decision note: %w", err)
+		}
+		return name, nil
+	}
+	return "", fmt.Errorf("create decision note: no free number after %04d", next)
+}
+
+// decisionSlug converts a decision title into a filesystem-safe slug.
+func decisionSlug(s string) string {
+	var b strings.Builder
+	prevDash := true
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			prevDash = false
+		case !prevDash:
+			b.WriteByte('-')
+			prevDash = true
+		}
+	}
+	slug := strings.TrimRight(b.String(), "-")
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[:60], "-")
+	}
+	if slug == "" {
+		return "decision"
+	}
+	return slug
+}
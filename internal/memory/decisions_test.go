@@ -3,6 +3,8 @@ package memory
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -133,3 +135,67 @@ func decisionTexts(decisions []Decision) []string {
 	}
 	return texts
 }
+
+func TestParseDecisionLog(t *testing.T) {
+	log := "# Decisions & Conclusions\n\n" +
+		"*Auto-extracted decisions are tagged for human review.*\n" +
+		"\n### 2026-01-05 (project: api)\n- use SQLite for local storage\n  - *confidence: high, auto-extracted*\n" +
+		"\n## Decision: JWT for API auth\n**Date:** 2026-02-01\n**Status:** Accepted\n**Agent:** codex\n\nStateless tokens keep the gateway simple.\n" +
+		"\n## Decision: Drop GraphQL\n**Date:** 2026-03-10\n**Status:** Superseded\n\nReplaced by REST.\n"
+
+	got := ParseDecisionLog(log)
+	if len(got) != 3 {
+		t.Fatalf("got %d decisions, want 3: %+v", len(got), got)
+	}
+
+	auto := got[0]
+	if !auto.AutoExtracted || auto.Date != "2026-01-05" || auto.Status != "proposed" || auto.Title != "use SQLite for local storage" {
+		t.Errorf("auto-extracted entry = %+v", auto)
+	}
+	if auto.Body != "" {
+		t.Errorf("auto-extracted body should drop the confidence line, got %q", auto.Body)
+	}
+
+	jwt := got[1]
+	if jwt.Title != "JWT for API auth" || jwt.Date != "2026-02-01" || jwt.Status != "accepted" || jwt.Agent != "codex" {
+		t.Errorf("saved entry = %+v", jwt)
+	}
+	if jwt.Body != "Stateless tokens keep the gateway simple." {
+		t.Errorf("body = %q", jwt.Body)
+	}
+
+	if got[2].Status != "superseded" {
+		t.Errorf("status = %q, want superseded", got[2].Status)
+	}
+}
+
+func TestWriteDecisionNote_NumbersAfterExisting(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "0007-older.md"), []byte("# Older\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# ADRs\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := DecisionFromExtract(Decision{Text: "Use SQLite over Postgres because it ships in the binary", Confidence: "high"})
+	name, err := WriteDecisionNote(dir, d)
+	if err != nil {
+		t.Fatalf("WriteDecisionNote: %v", err)
+	}
+	if name != "0008-use-sqlite-over-postgres-because-it-ships-in-the-binary.md" {
+		t.Errorf("name = %q", name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"content_type: decision", "tags: [decision, auto-extracted]", "**Status:** Proposed", "*confidence: high, auto-extracted*"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("note missing %q:\n%s", want, data)
+		}
+	}
+	if got := decisionSlug("!!!"); got != "decision" {
+		t.Errorf("decisionSlug(punctuation) = %q, want decision", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -177,9 +178,75 @@ func writeHandoffFromData(data *handoffData) *HandoffResult {
 	}
 }
 
-// generateRichHandoff produces the markdown content for a rich handoff note.
+// maxHandoffTemplateSize caps how much of a handoff template is read.
+const maxHandoffTemplateSize = 64 * 1024
+
+// HandoffFields are the values a handoff template can reference. Each is
+// substituted for its {{placeholder}}; empty values become empty text.
+type HandoffFields struct {
+	Summary   string // {{summary}}
+	Pending   string // {{pending}}
+	Blockers  string // {{blockers}}
+	Decisions string // {{decisions}}
+	Files     string // {{files}}
+	Date      string // {{date}}, YYYY-MM-DD
+	SessionID string // {{session_id}}
+	Machine   string // {{machine}}
+	Agent     string // {{agent}}
+}
+
+// RenderHandoffTemplate fills the vault's handoff template
+// (.same/templates/handoff.md) with f. It returns false when there is no
+// usable template, in which case the caller writes its built-in format.
+// Unknown placeholders are left as written.
+func RenderHandoffTemplate(f HandoffFields) (string, bool) {
+	file, err := os.Open(config.HandoffTemplatePath())
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxHandoffTemplateSize))
+	if err != nil || strings.TrimSpace(string(data)) == "" {
+		return "", false
+	}
+	r := strings.NewReplacer(
+		"{{summary}}", f.Summary,
+		"{{pending}}", f.Pending,
+		"{{blockers}}", f.Blockers,
+		"{{decisions}}", f.Decisions,
+		"{{files}}", f.Files,
+		"{{date}}", f.Date,
+		"{{session_id}}", f.SessionID,
+		"{{machine}}", f.Machine,
+		"{{agent}}", f.Agent,
+	)
+	return r.Replace(string(data)), true
+}
+
+// bulletList renders items as a Markdown list, wrapping each in format.
+func bulletList(items []string, format string) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = "- " + fmt.Sprintf(format, item)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// generateRichHandoff produces the markdown content for a rich handoff note,
+// using the vault's handoff template when there is one.
 func generateRichHandoff(data *handoffData) string {
 	now := time.Now()
+	if out, ok := RenderHandoffTemplate(HandoffFields{
+		Summary:   bulletList(data.Topics, "%s"),
+		Pending:   strings.Join(data.NextSteps, "\n"),
+		Decisions: bulletList(data.Decisions, "%s"),
+		Files:     bulletList(data.FilesChanged, "`%s`"),
+		Date:      now.Format("2006-01-02"),
+		SessionID: data.SessionID,
+		Machine:   data.Machine,
+	}); ok {
+		return out
+	}
 	timestamp := now.UTC().Format(time.RFC3339)
 
 	var b strings.Builder
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestTruncateAtWordBoundary_WordBoundaryPreferred(t *testing.T) {
@@ -128,3 +129,51 @@ func TestAutoHandoffFromTranscript_AssistantMCPOnly(t *testing.T) {
 		t.Fatalf("expected meaningful changed file retained, got:\n%s", content)
 	}
 }
+
+func TestAutoHandoffFromTranscript_UsesVaultTemplate(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("VAULT_PATH", tmp)
+
+	tmplDir := filepath.Join(tmp, ".same", "templates")
+	if err := os.MkdirAll(tmplDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tmpl := "# Standup {{date}}\n\n### Done\n{{summary}}\n\n### Decided\n{{decisions}}\n\n### Unknown {{nope}}\n"
+	if err := os.WriteFile(filepath.Join(tmplDir, "handoff.md"), []byte(tmpl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	transcript := filepath.Join(tmp, "session.jsonl")
+	lines := []string{
+		`{"role":"user","content":"migrate the auth tables"}`,
+		`{"role":"assistant","content":[{"type":"tool_use","name":"mcp__same__save_decision","input":{"title":"Use PostgreSQL for metadata"}}]}`,
+		`{"role":"assistant","content":[{"type":"text","text":"Migrated."}]}`,
+		`{"role":"user","content":"thanks"}`,
+	}
+	if err := os.WriteFile(transcript, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := AutoHandoffFromTranscript(transcript, "sess-12345678")
+	if result == nil {
+		t.Fatal("expected handoff result")
+	}
+	data, err := os.ReadFile(result.Written)
+	if err != nil {
+		t.Fatalf("ReadFile handoff: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		"# Standup " + time.Now().Format("2006-01-02"),
+		"### Done\n- migrate the auth tables",
+		"### Decided\n- Use PostgreSQL for metadata",
+		"{{nope}}",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("handoff missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "## What we worked on") {
+		t.Errorf("built-in format used despite template:\n%s", content)
+	}
+}
@@ -2,6 +2,7 @@ package memory
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -105,6 +106,150 @@ func FormatStaleNotesContext(staleNotes []StaleNote) string {
 	return strings.Join(lines, "\n")
 }
 
+// AgedNote is a note not modified for at least the [surfacing]
+// stale_after_days threshold.
+type AgedNote struct {
+	Path        string `json:"path"`
+	Title       string `json:"title"`
+	DaysOld     int    `json:"days_old"`
+	ContentType string `json:"content_type"`
+}
+
+// FindAgedNotes returns notes not modified for staleAfterDays or more,
+// oldest first. Evergreen notes are never included. Returns nil when
+// staleAfterDays is 0 (off).
+func FindAgedNotes(db *store.DB, staleAfterDays, maxResults int, now time.Time) []AgedNote {
+	if staleAfterDays <= 0 {
+		return nil
+	}
+	cutoff := now.AddDate(0, 0, -staleAfterDays)
+	notes, err := db.NotesNotModifiedSince(float64(cutoff.Unix()), maxResults)
+	if err != nil {
+		return nil
+	}
+	results := make([]AgedNote, 0, len(notes))
+	for _, n := range notes {
+		results = append(results, AgedNote{
+			Path:        n.Path,
+			Title:       n.Title,
+			DaysOld:     daysSince(n.Modified, now),
+			ContentType: n.ContentType,
+		})
+	}
+	return results
+}
+
+// FormatAgedNotesContext formats aged notes for injection as context.
+func FormatAgedNotesContext(aged []AgedNote) string {
+	if len(aged) == 0 {
+		return ""
+	}
+	lines := []string{"Notes not updated in a long time (may be outdated):"}
+	for _, note := range aged {
+		lines = append(lines, fmt.Sprintf("- [%s](%s) — last modified %d days ago", note.Title, note.Path, note.DaysOld))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StaleEntry is a note flagged stale for one or more reasons.
+type StaleEntry struct {
+	Path        string   `json:"path"`
+	Title       string   `json:"title"`
+	ContentType string   `json:"content_type,omitempty"`
+	DaysOld     int      `json:"days_old"`
+	Reasons     []string `json:"reasons"`
+}
+
+// ListStaleNotes returns every note flagged stale — its source changed, it
+// is past its review-by date, its expires date has passed, or (when
+// staleAfterDays > 0) it has not been modified for that many days — oldest
+// first, at most limit entries.
+func ListStaleNotes(db *store.DB, staleAfterDays, limit int, now time.Time) ([]StaleEntry, error) {
+	byPath := make(map[string]*StaleEntry)
+	var order []*StaleEntry
+	add := func(n store.NoteRecord, reason string) {
+		e, ok := byPath[n.Path]
+		if !ok {
+			e = &StaleEntry{
+				Path:        n.Path,
+				Title:       n.Title,
+				ContentType: n.ContentType,
+				DaysOld:     daysSince(n.Modified, now),
+			}
+			byPath[n.Path] = e
+			order = append(order, e)
+		}
+		e.Reasons = append(e.Reasons, reason)
+	}
+
+	trustStale, err := db.TrustStaleNotes(limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range trustStale {
+		add(n, "source changed")
+	}
+
+	reviewed, err := db.GetStaleNotes(limit, true)
+	if err != nil {
+		return nil, err
+	}
+	today := now.Truncate(24 * time.Hour)
+	for _, n := range reviewed {
+		reviewDate, err := parseDate(strings.TrimSpace(n.ReviewBy))
+		if err != nil {
+			continue
+		}
+		if overdue := int(today.Sub(reviewDate).Hours() / 24); overdue >= 0 {
+			add(n, fmt.Sprintf("review overdue by %d days", overdue))
+		}
+	}
+
+	if staleAfterDays > 0 {
+		aged, err := db.NotesNotModifiedSince(float64(now.AddDate(0, 0, -staleAfterDays).Unix()), limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range aged {
+			add(n, fmt.Sprintf("not updated in %d+ days", staleAfterDays))
+		}
+	}
+
+	expired, err := db.ExpiredNotes(now.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range expired {
+		add(n, "expired on "+n.Expires)
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return order[i].DaysOld > order[j].DaysOld })
+	if len(order) > limit {
+		order = order[:limit]
+	}
+	entries := make([]StaleEntry, len(order))
+	for i, e := range order {
+		entries[i] = *e
+	}
+	return entries, nil
+}
+
+// ExpiryDate normalizes a frontmatter expires value to YYYY-MM-DD so the
+// index can compare it as a string. Returns "" if s is empty or unparseable.
+func ExpiryDate(s string) string {
+	t, err := parseDate(strings.TrimSpace(s))
+	if err != nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// daysSince returns the whole days between a Unix modified time and now.
+func daysSince(modified float64, now time.Time) int {
+	d := int(now.Sub(time.Unix(int64(modified), 0)).Hours() / 24)
+	return max(d, 0)
+}
+
 func parseDate(s string) (time.Time, error) {
 	// Try ISO datetime first
 	t, err := time.Parse(time.RFC3339, s)
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+func TestListStaleNotes(t *testing.T) {
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) float64 { return float64(now.AddDate(0, 0, -d).Unix()) }
+	note := func(path string, modified float64) store.NoteRecord {
+		return store.NoteRecord{Path: path, Title: path, Tags: "[]", ChunkHeading: "(full)", Text: "text", Modified: modified, ContentType: "note"}
+	}
+	old := note("old.md", daysAgo(400))
+	evergreen := note("evergreen.md", daysAgo(500))
+	evergreen.Evergreen = true
+	private := note("_PRIVATE/old.md", daysAgo(600))
+	changed := note("changed.md", daysAgo(10))
+	review := note("review.md", daysAgo(200))
+	review.ReviewBy = "2026-05-22"
+	fresh := note("fresh.md", daysAgo(1))
+	expired := note("expired.md", daysAgo(30))
+	expired.Expires = "2026-05-01"
+	upcoming := note("upcoming.md", daysAgo(30))
+	upcoming.Expires = "2026-06-01"
+	if _, err := db.BulkInsertNotesLite([]store.NoteRecord{old, evergreen, private, changed, review, fresh, expired, upcoming}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpdateTrustState([]string{"changed.md"}, "stale"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ListStaleNotes(db, 180, 20, now)
+	if err != nil {
+		t.Fatalf("ListStaleNotes: %v", err)
+	}
+	want := []StaleEntry{
+		{Path: "old.md", Title: "old.md", ContentType: "note", DaysOld: 400, Reasons: []string{"not updated in 180+ days"}},
+		{Path: "review.md", Title: "review.md", ContentType: "note", DaysOld: 200, Reasons: []string{"review overdue by 10 days", "not updated in 180+ days"}},
+		{Path: "expired.md", Title: "expired.md", ContentType: "note", DaysOld: 30, Reasons: []string{"expired on 2026-05-01"}},
+		{Path: "changed.md", Title: "changed.md", ContentType: "note", DaysOld: 10, Reasons: []string{"source changed"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListStaleNotes =\n%+v\nwant\n%+v", got, want)
+	}
+
+	// Age-based staleness off: only the explicit signals remain.
+	got, err = ListStaleNotes(db, 0, 20, now)
+	if err != nil {
+		t.Fatalf("ListStaleNotes off: %v", err)
+	}
+	if len(got) != 3 || got[0].Path != "review.md" || got[1].Path != "expired.md" || got[2].Path != "changed.md" {
+		t.Errorf("with stale_after_days off got %+v", got)
+	}
+	if aged := FindAgedNotes(db, 0, 5, now); aged != nil {
+		t.Errorf("FindAgedNotes with 0 days = %+v, want nil", aged)
+	}
+}
+
+func TestExpiryDate(t *testing.T) {
+	tests := map[string]string{
+		"2026-03-01":           "2026-03-01",
+		" 2026/03/01 ":         "2026-03-01",
+		"2026-03-01T09:30:00Z": "2026-03-01",
+		"":                     "",
+		"next sprint":          "",
+	}
+	for in, want := range tests {
+		if got := ExpiryDate(in); got != want {
+			t.Errorf("ExpiryDate(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
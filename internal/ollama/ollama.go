@@ -4,7 +4,9 @@
 package ollama
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -43,6 +45,11 @@ func NewClientWithURL(baseURL string) *Client {
 	}
 }
 
+// SetTimeout replaces the default 120s limit on non-streaming requests.
+func (c *Client) SetTimeout(d time.Duration) {
+	c.httpClient.Timeout = d
+}
+
 // Model represents an Ollama model from /api/tags.
 type Model struct {
 	Name string `json:"name"`
@@ -68,8 +75,8 @@ var embedModels = map[string]bool{
 	"bge-m3":                  true,
 }
 
-// ListChatModels returns available chat/instruct models (excludes embedding models).
-func (c *Client) ListChatModels() ([]Model, error) {
+// ListModels returns every model pulled into Ollama, embedding models included.
+func (c *Client) ListModels() ([]Model, error) {
 	resp, err := c.httpClient.Get(strings.TrimRight(c.baseURL, "/") + "/api/tags")
 	if err != nil {
 		return nil, fmt.Errorf("connect to Ollama: %w", err)
@@ -84,19 +91,36 @@ func (c *Client) ListChatModels() ([]Model, error) {
 	if err := json.NewDecoder(io.LimitReader(resp.Body, 10*1024*1024)).Decode(&tags); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
+	return tags.Models, nil
+}
+
+// ListChatModels returns available chat/instruct models (excludes embedding models).
+func (c *Client) ListChatModels() ([]Model, error) {
+	models, err := c.ListModels()
+	if err != nil {
+		return nil, err
+	}
+	return ChatModels(models), nil
+}
 
+// ChatModels filters known embedding-only models out of models.
+func ChatModels(models []Model) []Model {
 	var chat []Model
-	for _, m := range tags.Models {
-		baseName := m.Name
-		if idx := strings.Index(baseName, ":"); idx > 0 {
-			baseName = baseName[:idx]
-		}
-		if embedModels[baseName] {
+	for _, m := range models {
+		if embedModels[BaseName(m.Name)] {
 			continue
 		}
 		chat = append(chat, m)
 	}
-	return chat, nil
+	return chat
+}
+
+// BaseName strips the tag from a model name ("llama3.2:1b" -> "llama3.2").
+func BaseName(name string) string {
+	if idx := strings.Index(name, ":"); idx > 0 {
+		return name[:idx]
+	}
+	return name
 }
 
 // preferredModels lists models in preference order (smallest/fastest first).
@@ -113,8 +137,14 @@ func (c *Client) PickBestModel() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return BestModel(models), nil
+}
+
+// BestModel picks from already-listed chat models the way PickBestModel
+// does. Returns empty string if models is empty.
+func BestModel(models []Model) string {
 	if len(models) == 0 {
-		return "", nil
+		return ""
 	}
 
 	available := make(map[string]bool, len(models))
@@ -124,12 +154,12 @@ func (c *Client) PickBestModel() (string, error) {
 
 	for _, pref := range preferredModels {
 		if available[pref] {
-			return pref, nil
+			return pref
 		}
 	}
 
 	// Fall back to first available chat model
-	return models[0].Name, nil
+	return models[0].Name
 }
 
 // generateRequest is the Ollama /api/generate request body.
@@ -144,11 +174,84 @@ type generateRequest struct {
 
 type generateResponse struct {
 	Response string `json:"response"`
+	Done     bool   `json:"done"`
+	Error    string `json:"error,omitempty"`
 }
 
 // Generate sends a prompt to Ollama and returns the response.
 func (c *Client) Generate(model, prompt string) (string, error) {
-	return c.generate(model, prompt, nil)
+	return c.generate(context.Background(), model, prompt, nil)
+}
+
+// GenerateStream sends a prompt to Ollama and calls onToken with each piece
+// of the response as it arrives. Cancelling ctx aborts the HTTP request.
+// The returned text is the full response with thinking blocks removed; the
+// pieces passed to onToken are raw.
+func (c *Client) GenerateStream(ctx context.Context, model, prompt string, onToken func(string)) (string, error) {
+	body, err := json.Marshal(generateRequest{
+		Model:  model,
+		Prompt: prompt,
+		Stream: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/generate", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// A long answer can stream past the client's overall timeout; ctx
+	// bounds the request instead.
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("connect to Ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("Ollama returned %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, 10*1024*1024))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var chunk generateResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return "", fmt.Errorf("decode response: %w", err)
+		}
+		if chunk.Error != "" {
+			return "", fmt.Errorf("Ollama error: %s", chunk.Error)
+		}
+		if chunk.Response != "" {
+			full.WriteString(chunk.Response)
+			if onToken != nil {
+				onToken(chunk.Response)
+			}
+		}
+		if chunk.Done {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("read response: %w", err)
+	}
+	return llmutil.StripThinkingTokens(full.String()), nil
 }
 
 // GenerateJSON sends a prompt to Ollama and forces a JSON response.
@@ -158,13 +261,13 @@ func (c *Client) Generate(model, prompt string) (string, error) {
 // or model that doesn't support it), it falls back to format:"json".
 func (c *Client) GenerateJSON(model, prompt string) (string, error) {
 	// Try structured output with JSON schema first.
-	resp, err := c.generate(model, prompt, graphExtractionSchema)
+	resp, err := c.generate(context.Background(), model, prompt, graphExtractionSchema)
 	if err == nil {
 		return resp, nil
 	}
 
 	// Fallback: simple format:"json" string.
-	return c.generate(model, prompt, []byte(`"json"`))
+	return c.generate(context.Background(), model, prompt, []byte(`"json"`))
 }
 
 // graphExtractionSchema is a JSON schema that constrains the Ollama output
@@ -203,7 +306,7 @@ var graphExtractionSchema = func() json.RawMessage {
 	return json.RawMessage(b)
 }()
 
-func (c *Client) generate(model, prompt string, format json.RawMessage) (string, error) {
+func (c *Client) generate(ctx context.Context, model, prompt string, format json.RawMessage) (string, error) {
 	body, err := json.Marshal(generateRequest{
 		Model:  model,
 		Prompt: prompt,
@@ -214,7 +317,7 @@ func (c *Client) generate(model, prompt string, format json.RawMessage) (string,
 		return "", fmt.Errorf("marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/generate", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/generate", bytes.NewReader(body))
 	if err != nil {
 		return "", fmt.Errorf("create request: %w", err)
 	}
@@ -1,7 +1,9 @@
 package ollama
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -215,3 +217,36 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestGenerateStream_TokensAndCancel(t *testing.T) {
+	srv := newLocalHTTPServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req generateRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Stream {
+			t.Error("expected stream=true")
+		}
+		enc := json.NewEncoder(w)
+		enc.Encode(generateResponse{Response: "The answer "})
+		enc.Encode(generateResponse{Response: "is 42."})
+		enc.Encode(generateResponse{Done: true})
+	}))
+	defer srv.Close()
+
+	c := NewClientWithURL(srv.URL)
+	var tokens []string
+	answer, err := c.GenerateStream(context.Background(), "test-model", "q", func(tok string) {
+		tokens = append(tokens, tok)
+	})
+	if err != nil {
+		t.Fatalf("GenerateStream: %v", err)
+	}
+	if answer != "The answer is 42." || len(tokens) != 2 {
+		t.Errorf("answer = %q, tokens = %q", answer, tokens)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GenerateStream(ctx, "test-model", "q", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
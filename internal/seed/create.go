@@ -0,0 +1,227 @@
+package seed
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sgx-labs/statelessagent/internal/indexer"
+)
+
+// CreateOptions controls packaging a vault as a seed.
+type CreateOptions struct {
+	Name           string // seed name (lowercase alphanumeric with hyphens)
+	VaultDir       string // vault to package
+	Output         string // archive path (empty = <name>.tar.gz in the current directory)
+	Description    string
+	Version        string // seed version recorded in the manifest entry
+	MinSameVersion string // oldest SAME version the seed supports (optional)
+}
+
+// CreateResult describes a packaged seed.
+type CreateResult struct {
+	Output string
+	Seed   Seed
+	Files  []string // vault-relative paths in the archive
+}
+
+// Create bundles the vault's markdown notes into a gzip tarball laid out
+// like the seed-vaults repository: a single top-level directory holding
+// seeds.json and the notes under <name>/. _PRIVATE/, hidden directories
+// (including .same/), and .sameignore matches are left out, as are files
+// extraction would skip.
+func Create(opts CreateOptions) (*CreateResult, error) {
+	if err := validateSeedName(opts.Name); err != nil {
+		return nil, err
+	}
+	vaultDir, err := filepath.Abs(opts.VaultDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve vault: %w", err)
+	}
+
+	files, totalSize, err := seedFiles(vaultDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no markdown notes to package in %s", vaultDir)
+	}
+
+	checksum, err := filesChecksum(vaultDir, files)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := Seed{
+		Name:           opts.Name,
+		DisplayName:    displayName(opts.Name),
+		Description:    opts.Description,
+		NoteCount:      len(files),
+		SizeKB:         int((totalSize + 1023) / 1024),
+		Tags:           []string{},
+		MinSameVersion: opts.MinSameVersion,
+		Path:           opts.Name,
+		Version:        opts.Version,
+		SHA256:         checksum,
+	}
+	manifest, err := json.MarshalIndent(Manifest{SchemaVersion: 2, Seeds: []Seed{entry}}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+
+	output := opts.Output
+	if output == "" {
+		output = opts.Name + ".tar.gz"
+	}
+	if err := writeSeedArchive(output, opts.Name, vaultDir, files, append(manifest, '\n')); err != nil {
+		return nil, err
+	}
+	return &CreateResult{Output: output, Seed: entry, Files: files}, nil
+}
+
+// seedFiles returns the vault-relative notes to package, sorted, and their
+// combined size.
+func seedFiles(vaultDir string) ([]string, int64, error) {
+	var files []string
+	var total int64
+	for _, path := range indexer.WalkVaultWithIgnore(vaultDir) {
+		rel, err := filepath.Rel(vaultDir, path)
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		if strings.HasPrefix(strings.ToUpper(rel), "_PRIVATE/") {
+			continue
+		}
+		// Extraction drops hidden paths and oversized files; leave them out
+		// here so the manifest counts match what installs.
+		if _, err := validateExtractPath(rel, vaultDir); err != nil {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.Size() > MaxFileSize {
+			continue
+		}
+		files = append(files, rel)
+		total += info.Size()
+	}
+	if len(files) > MaxFileCount-1 { // seeds.json takes one slot
+		return nil, 0, fmt.Errorf("too many notes for a seed (%d, max %d)", len(files), MaxFileCount-1)
+	}
+	sort.Strings(files)
+	return files, total, nil
+}
+
+// filesChecksum returns the seed checksum of the packaged files, which
+// install verifies against the extracted archive.
+func filesChecksum(vaultDir string, files []string) (string, error) {
+	digest := make(seedDigest, len(files))
+	for _, rel := range files {
+		f, err := os.Open(filepath.Join(vaultDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return "", fmt.Errorf("checksum %s: %w", rel, err)
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", fmt.Errorf("checksum %s: %w", rel, err)
+		}
+		digest[rel] = hex.EncodeToString(h.Sum(nil))
+	}
+	return digest.sum(), nil
+}
+
+// writeSeedArchive writes the tarball to a temp file beside output and
+// renames it into place, so a failed run never leaves a partial archive.
+func writeSeedArchive(output, name, vaultDir string, files []string, manifest []byte) (err error) {
+	if dir := filepath.Dir(output); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create output directory: %w", err)
+		}
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(output), ".seed-*.tar.gz")
+	if err != nil {
+		return fmt.Errorf("create archive: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	gz := gzip.NewWriter(tmp)
+	tw := tar.NewWriter(gz)
+	root := name + "-seed/"
+
+	if err := tw.WriteHeader(&tar.Header{Name: root + "seeds.json", Mode: 0o644, Size: int64(len(manifest)), Typeflag: tar.TypeReg}); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	for _, rel := range files {
+		if err := addSeedFile(tw, root+name+"/"+rel, filepath.Join(vaultDir, filepath.FromSlash(rel))); err != nil {
+			return fmt.Errorf("add %s: %w", rel, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("finish archive: %w", err)
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		return fmt.Errorf("finish archive: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("finish archive: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), output); err != nil {
+		return fmt.Errorf("write %s: %w", output, err)
+	}
+	return nil
+}
+
+func addSeedFile(tw *tar.Writer, name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0o644,
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, io.LimitReader(f, info.Size()))
+	return err
+}
+
+// displayName turns "team-runbooks" into "Team Runbooks".
+func displayName(name string) string {
+	words := strings.Split(name, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
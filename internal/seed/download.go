@@ -2,12 +2,17 @@ package seed
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
@@ -25,13 +30,24 @@ const (
 	// MaxFileSize is the maximum size of a single extracted file.
 	MaxFileSize = 10 * 1024 * 1024 // 10 MB
 
+	// MaxExtractedSize is the maximum number of bytes read out of a
+	// decompressed tarball, which bounds what a small download can expand to.
+	MaxExtractedSize = 200 * 1024 * 1024 // 200 MB
+
 	// HTTPTimeout is the HTTP client timeout for tarball downloads.
 	HTTPTimeout = 60 * time.Second
 )
 
+// errExtractedTooLarge is returned once a decompressed tarball passes
+// MaxExtractedSize.
+var errExtractedTooLarge = fmt.Errorf("archive expands past %d MB", MaxExtractedSize/(1024*1024))
+
 // DownloadAndExtract downloads the seed-vaults tarball and extracts only the
-// files under seedPath into destDir. Returns the number of files extracted.
-func DownloadAndExtract(seedPath, destDir string) (int, error) {
+// files under seedPath into destDir. When checksum is set, the seed's files
+// are checked against it before anything is written; an empty checksum skips
+// the check, which Install only allows with InstallOptions.AllowUnverified.
+// Returns the number of files extracted.
+func DownloadAndExtract(seedPath, checksum, destDir string) (int, error) {
 	client := &http.Client{
 		Timeout: HTTPTimeout,
 		// Follow redirects (GitHub API redirects to a CDN)
@@ -54,22 +70,157 @@ func DownloadAndExtract(seedPath, destDir string) (int, error) {
 	}
 
 	// SECURITY: limit download size
-	limitedBody := io.LimitReader(resp.Body, MaxTarballSize+1)
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxTarballSize+1))
+	if err != nil {
+		return 0, fmt.Errorf("download tarball: %w", err)
+	}
+	if len(data) > MaxTarballSize {
+		return 0, fmt.Errorf("download tarball: larger than %d MB", MaxTarballSize/(1024*1024))
+	}
+
+	// SECURITY: verify before extracting so a tampered archive writes nothing
+	if checksum != "" {
+		if err := verifySeedChecksum(bytes.NewReader(data), seedPath, checksum); err != nil {
+			return 0, err
+		}
+	}
+	return extractTarGz(bytes.NewReader(data), seedPath, destDir)
+}
+
+// verifySeedChecksum compares the digest of the seed's files in a tarball
+// with the manifest checksum.
+func verifySeedChecksum(r io.Reader, seedPath, want string) error {
+	digest := make(seedDigest)
+	err := walkSeedEntries(r, seedPath, func(relPath string, header *tar.Header, body io.Reader) error {
+		if header.Typeflag != tar.TypeReg {
+			return nil
+		}
+		h := sha256.New()
+		if _, err := io.Copy(h, body); err != nil {
+			return fmt.Errorf("read %s: %w", relPath, err)
+		}
+		digest[relPath] = hex.EncodeToString(h.Sum(nil))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("verify seed: %w", err)
+	}
+	if got := digest.sum(); !strings.EqualFold(got, want) {
+		return fmt.Errorf("seed checksum mismatch: manifest has %s, archive has %s", want, got)
+	}
+	return nil
+}
+
+// seedDigest maps seed-relative file paths to the hex SHA-256 of their
+// contents.
+type seedDigest map[string]string
+
+// sum is the seed checksum: the SHA-256 of sha256sum-style lines
+// ("<hash>  <path>\n") sorted by path, so it can be reproduced inside a
+// seed directory with
+//
+//	find . -type f | sed 's|^\./||' | LC_ALL=C sort | xargs sha256sum | sha256sum
+func (d seedDigest) sum() string {
+	paths := make([]string, 0, len(d))
+	for p := range d {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	h := sha256.New()
+	for _, p := range paths {
+		fmt.Fprintf(h, "%s  %s\n", d[p], p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
 
-	return extractTarGz(limitedBody, seedPath, destDir)
+// cappedReader fails with errExtractedTooLarge after n bytes instead of
+// silently truncating like io.LimitReader.
+type cappedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *cappedReader) Read(p []byte) (int, error) {
+	if c.n <= 0 {
+		return 0, errExtractedTooLarge
+	}
+	if int64(len(p)) > c.n {
+		p = p[:c.n]
+	}
+	n, err := c.r.Read(p)
+	c.n -= int64(n)
+	return n, err
 }
 
 // extractTarGz reads a gzip-compressed tar stream and extracts files matching
 // the seedPath prefix into destDir. Internal for testing.
 func extractTarGz(r io.Reader, seedPath, destDir string) (int, error) {
+	var fileCount int
+	err := walkSeedEntries(r, seedPath, func(relPath string, header *tar.Header, body io.Reader) error {
+		// SECURITY: reject symlinks and hardlinks
+		switch header.Typeflag {
+		case tar.TypeReg:
+		// Regular file — OK
+		case tar.TypeDir:
+			// Directory — create it
+			dirPath, err := validateExtractPath(relPath, destDir)
+			if err != nil {
+				return nil // skip invalid paths
+			}
+			if err := os.MkdirAll(dirPath, 0o755); err != nil {
+				return fmt.Errorf("create directory %s: %w", relPath, err)
+			}
+			return nil
+		default:
+			// Skip symlinks, hardlinks, and anything else
+			return nil
+		}
+
+		// SECURITY: validate the extraction path
+		destPath, err := validateExtractPath(relPath, destDir)
+		if err != nil {
+			return nil // skip invalid paths
+		}
+
+		// SECURITY: enforce per-file size limit
+		if header.Size > MaxFileSize {
+			return nil
+		}
+
+		// SECURITY: enforce total file count
+		fileCount++
+		if fileCount > MaxFileCount {
+			fileCount--
+			return fmt.Errorf("too many files (max %d)", MaxFileCount)
+		}
+
+		// Ensure parent directory exists
+		if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+			return fmt.Errorf("create directory for %s: %w", relPath, err)
+		}
+
+		// Extract the file
+		if err := extractFile(body, destPath, header.Size); err != nil {
+			return fmt.Errorf("extract %s: %w", relPath, err)
+		}
+		return nil
+	})
+	return fileCount, err
+}
+
+// walkSeedEntries calls fn for each entry of a gzip-compressed tar stream
+// that lies under seedPath, passing its path relative to the seed. The first
+// path component (GitHub's {owner}-{repo}-{sha}/) is stripped before
+// matching, and reading stops with an error past MaxExtractedSize.
+func walkSeedEntries(r io.Reader, seedPath string, fn func(relPath string, header *tar.Header, body io.Reader) error) error {
 	gz, err := gzip.NewReader(r)
 	if err != nil {
-		return 0, fmt.Errorf("gzip reader: %w", err)
+		return fmt.Errorf("gzip reader: %w", err)
 	}
 	defer gz.Close()
 
-	tr := tar.NewReader(gz)
-	var fileCount int
+	// SECURITY: bound the decompressed size, not just the download
+	tr := tar.NewReader(&cappedReader{r: gz, n: MaxExtractedSize})
 
 	// Normalize seed path for matching so manifests like "./foo" remain compatible.
 	normalizedSeed := strings.ReplaceAll(seedPath, "\\", "/")
@@ -77,7 +228,7 @@ func extractTarGz(r io.Reader, seedPath, destDir string) (int, error) {
 	normalizedSeed = strings.TrimPrefix(normalizedSeed, "./")
 	if normalizedSeed == "" || normalizedSeed == "." || normalizedSeed == ".." ||
 		strings.HasPrefix(normalizedSeed, "../") || strings.HasPrefix(normalizedSeed, "/") {
-		return 0, fmt.Errorf("invalid seed path: %q", seedPath)
+		return fmt.Errorf("invalid seed path: %q", seedPath)
 	}
 	seedPrefix := strings.TrimSuffix(normalizedSeed, "/") + "/"
 
@@ -87,7 +238,10 @@ func extractTarGz(r io.Reader, seedPath, destDir string) (int, error) {
 			break
 		}
 		if err != nil {
-			return fileCount, fmt.Errorf("read tar entry: %w", err)
+			if errors.Is(err, errExtractedTooLarge) {
+				return err
+			}
+			return fmt.Errorf("read tar entry: %w", err)
 		}
 
 		// Strip first path component (GitHub adds {owner}-{repo}-{sha}/)
@@ -98,7 +252,7 @@ func extractTarGz(r io.Reader, seedPath, destDir string) (int, error) {
 		}
 		name = name[idx+1:]
 
-		// Only extract files under the seed path
+		// Only visit entries under the seed path
 		if !strings.HasPrefix(name, seedPrefix) {
 			continue
 		}
@@ -109,54 +263,11 @@ func extractTarGz(r io.Reader, seedPath, destDir string) (int, error) {
 			continue
 		}
 
-		// SECURITY: reject symlinks and hardlinks
-		switch header.Typeflag {
-		case tar.TypeReg:
-		// Regular file — OK
-		case tar.TypeDir:
-			// Directory — create it
-			dirPath, err := validateExtractPath(relPath, destDir)
-			if err != nil {
-				continue // skip invalid paths
-			}
-			if err := os.MkdirAll(dirPath, 0o755); err != nil {
-				return fileCount, fmt.Errorf("create directory %s: %w", relPath, err)
-			}
-			continue
-		default:
-			// Skip symlinks, hardlinks, and anything else
-			continue
-		}
-
-		// SECURITY: validate the extraction path
-		destPath, err := validateExtractPath(relPath, destDir)
-		if err != nil {
-			continue // skip invalid paths
-		}
-
-		// SECURITY: enforce per-file size limit
-		if header.Size > MaxFileSize {
-			continue
-		}
-
-		// SECURITY: enforce total file count
-		fileCount++
-		if fileCount > MaxFileCount {
-			return fileCount - 1, fmt.Errorf("too many files (max %d)", MaxFileCount)
-		}
-
-		// Ensure parent directory exists
-		if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
-			return fileCount, fmt.Errorf("create directory for %s: %w", relPath, err)
-		}
-
-		// Extract the file
-		if err := extractFile(tr, destPath, header.Size); err != nil {
-			return fileCount, fmt.Errorf("extract %s: %w", relPath, err)
+		if err := fn(relPath, header, tr); err != nil {
+			return err
 		}
 	}
-
-	return fileCount, nil
+	return nil
 }
 
 // extractFile writes a single tar entry to disk with size limits.
@@ -2,6 +2,7 @@ package seed
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -29,14 +30,23 @@ func DefaultSeedDir() string {
 	return filepath.Join(home, "same-seeds")
 }
 
+// ErrNoChecksum is returned by Install when the seed list has no checksum
+// for the seed and InstallOptions.AllowUnverified is not set.
+var ErrNoChecksum = errors.New("seed list has no checksum for this seed, so its files cannot be verified")
+
 // InstallOptions controls the install behavior.
 type InstallOptions struct {
 	Name    string // seed name from manifest
 	Path    string // custom install path (empty = ~/same-seeds/<name>)
 	Force   bool   // overwrite existing directory
 	NoIndex bool   // skip reindex step
+	Offline bool   // use the cached manifest instead of fetching it
 	Version string // current SAME version for compatibility check
 
+	// AllowUnverified installs a seed whose manifest entry has no checksum.
+	// Without it such seeds are refused before anything is downloaded.
+	AllowUnverified bool
+
 	// Progress callbacks (all optional)
 	OnDownloadStart func()
 	OnDownloadDone  func(sizeKB int)
@@ -49,12 +59,19 @@ type InstallResult struct {
 	DestDir   string
 	FileCount int
 	Chunks    int
+	Verified  bool // the seed's files matched the manifest checksum
 }
 
 // Install downloads and installs a seed vault.
 func Install(opts InstallOptions) (*InstallResult, error) {
 	// 1. Fetch manifest
-	manifest, err := FetchManifest(false)
+	var manifest *Manifest
+	var err error
+	if opts.Offline {
+		manifest, err = CachedManifest()
+	} else {
+		manifest, err = FetchManifest(false)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("fetch seed list: %w", err)
 	}
@@ -78,6 +95,12 @@ func Install(opts InstallOptions) (*InstallResult, error) {
 		return nil, fmt.Errorf("seed %q has no content", seed.Name)
 	}
 
+	// 3c. SECURITY: refuse seeds the manifest has no checksum for, unless
+	// the caller explicitly accepts unverified files
+	if seed.SHA256 == "" && !opts.AllowUnverified {
+		return nil, fmt.Errorf("seed %q: %w", seed.Name, ErrNoChecksum)
+	}
+
 	// 4. Resolve destination path
 	destDir := opts.Path
 	if destDir == "" {
@@ -141,7 +164,7 @@ func Install(opts InstallOptions) (*InstallResult, error) {
 		opts.OnDownloadStart()
 	}
 
-	fileCount, err := DownloadAndExtract(seed.Path, absDir)
+	fileCount, err := DownloadAndExtract(seed.Path, seed.SHA256, absDir)
 	if err != nil {
 		return nil, fmt.Errorf("download seed: %w", err)
 	}
@@ -189,13 +212,13 @@ func Install(opts InstallOptions) (*InstallResult, error) {
 
 		// Set VAULT_PATH env as belt-and-suspenders — ensures the indexer
 		// uses the seed directory even if config resolution picks up CWD.
-		origEnv := os.Getenv("VAULT_PATH")
-		os.Setenv("VAULT_PATH", absDir)
+		origEnv := os.Getenv(config.EnvVaultPath)
+		os.Setenv(config.EnvVaultPath, absDir)
 		defer func() {
 			if origEnv != "" {
-				os.Setenv("VAULT_PATH", origEnv)
+				os.Setenv(config.EnvVaultPath, origEnv)
 			} else {
-				os.Unsetenv("VAULT_PATH")
+				os.Unsetenv(config.EnvVaultPath)
 			}
 		}()
 
@@ -254,6 +277,7 @@ func Install(opts InstallOptions) (*InstallResult, error) {
 		DestDir:   absDir,
 		FileCount: fileCount,
 		Chunks:    chunks,
+		Verified:  seed.SHA256 != "",
 	}, nil
 }
 
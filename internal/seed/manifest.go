@@ -1,6 +1,7 @@
 package seed
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -44,6 +45,8 @@ type Seed struct {
 	Keywords       []string `json:"keywords,omitempty"`
 	InstallCommand string   `json:"install_command,omitempty"`
 	MinSameVersion string   `json:"min_same_version"`
+	Version        string   `json:"version,omitempty"`
+	SHA256         string   `json:"sha256,omitempty"` // digest of the seed's files, checked before install
 	Path           string   `json:"path"`
 	Status         string   `json:"status,omitempty"`
 	Featured       bool     `json:"featured"`
@@ -116,6 +119,16 @@ func FetchManifest(forceRefresh bool) (*Manifest, error) {
 	return &manifest, nil
 }
 
+// CachedManifest returns the locally cached manifest, however old, without
+// touching the network.
+func CachedManifest() (*Manifest, error) {
+	m, err := loadCachedManifest(manifestCachePath(), true)
+	if err != nil {
+		return nil, fmt.Errorf("no cached seed list: %w", err)
+	}
+	return m, nil
+}
+
 // FindSeed looks up a seed by name in the manifest.
 func FindSeed(manifest *Manifest, name string) *Seed {
 	lower := strings.ToLower(name)
@@ -194,10 +207,21 @@ func validateManifest(m *Manifest) error {
 		if err := validateSeedPath(s.Path); err != nil {
 			return fmt.Errorf("invalid seed path for %q: %w", s.Name, err)
 		}
+		if s.SHA256 != "" && !isSHA256Hex(s.SHA256) {
+			return fmt.Errorf("invalid checksum for %q: want 64 hex characters", s.Name)
+		}
 	}
 	return nil
 }
 
+func isSHA256Hex(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
 // loadCachedManifest reads and validates the cached manifest.
 // Returns nil if the cache is missing, corrupt, or (when allowStale is false) expired.
 // Set allowStale to true for network-failure fallback paths.
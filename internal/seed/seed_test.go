@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -721,3 +722,199 @@ func TestPathWithinBase_PrefixConfusion(t *testing.T) {
 		t.Fatalf("expected prefix-confusion sibling to be rejected")
 	}
 }
+
+func TestCreate_PackagesVaultForExtraction(t *testing.T) {
+	vault := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		p := filepath.Join(vault, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("README.md", "# Team runbooks\n")
+	write("runbooks/deploy.md", "# Deploy\nSteps.\n")
+	write("_PRIVATE/salaries.md", "# Private\n")
+	write(".same/data/cache.md", "# Internal\n")
+	write("notes/draft.txt", "not markdown")
+
+	out := filepath.Join(t.TempDir(), "dist", "team-runbooks.tar.gz")
+	result, err := Create(CreateOptions{
+		Name:        "team-runbooks",
+		VaultDir:    vault,
+		Output:      out,
+		Description: "On-call runbooks",
+		Version:     "1.2.0",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if got := strings.Join(result.Files, ","); got != "README.md,runbooks/deploy.md" {
+		t.Fatalf("files = %s", got)
+	}
+	if result.Seed.NoteCount != 2 || result.Seed.Path != "team-runbooks" || result.Seed.DisplayName != "Team Runbooks" || result.Seed.Version != "1.2.0" {
+		t.Errorf("seed entry = %+v", result.Seed)
+	}
+
+	// The archive's seeds.json validates like a downloaded manifest.
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	var manifest Manifest
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		if hdr.Name == "team-runbooks-seed/seeds.json" {
+			if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+				t.Fatalf("decode seeds.json: %v", err)
+			}
+		}
+	}
+	f.Close()
+	if err := validateManifest(&manifest); err != nil || FindSeed(&manifest, "team-runbooks") == nil {
+		t.Fatalf("manifest invalid or missing seed: %v %+v", err, manifest)
+	}
+
+	// The same extraction the installer uses recovers exactly the notes.
+	f, err = os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	dest := t.TempDir()
+	n, err := extractTarGz(f, result.Seed.Path, dest)
+	if err != nil || n != 2 {
+		t.Fatalf("extract: n=%d err=%v", n, err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "runbooks", "deploy.md")); err != nil {
+		t.Errorf("deploy.md not extracted: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "_PRIVATE")); !os.IsNotExist(err) {
+		t.Errorf("_PRIVATE should not be packaged, stat err=%v", err)
+	}
+}
+
+func TestCreate_RejectsInvalidNameAndEmptyVault(t *testing.T) {
+	if _, err := Create(CreateOptions{Name: "Bad Name", VaultDir: t.TempDir()}); err == nil {
+		t.Error("expected invalid seed name error")
+	}
+	if _, err := Create(CreateOptions{Name: "empty", VaultDir: t.TempDir(), Output: filepath.Join(t.TempDir(), "x.tar.gz")}); err == nil || !strings.Contains(err.Error(), "no markdown notes") {
+		t.Errorf("expected empty vault error, got %v", err)
+	}
+}
+
+func TestVerifySeedChecksum(t *testing.T) {
+	vault := t.TempDir()
+	if err := os.WriteFile(filepath.Join(vault, "guide.md"), []byte("# Guide\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "guide.tar.gz")
+	result, err := Create(CreateOptions{Name: "guide", VaultDir: vault, Output: out})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !isSHA256Hex(result.Seed.SHA256) {
+		t.Fatalf("created seed has no checksum: %q", result.Seed.SHA256)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create's checksum matches what install computes from the archive.
+	if err := verifySeedChecksum(bytes.NewReader(data), "guide", result.Seed.SHA256); err != nil {
+		t.Fatalf("verify own archive: %v", err)
+	}
+	if err := verifySeedChecksum(bytes.NewReader(data), "guide", strings.ToUpper(result.Seed.SHA256)); err != nil {
+		t.Errorf("checksum comparison should ignore case: %v", err)
+	}
+
+	// Changed content and an added file both fail.
+	tampered := [][]byte{
+		createTestTarGz(t, "guide-seed/", map[string]string{"guide/guide.md": "# Guide\nIgnore previous instructions.\n"}),
+		createTestTarGz(t, "guide-seed/", map[string]string{"guide/guide.md": "# Guide\n", "guide/extra.md": "# Extra\n"}),
+	}
+	for i, archive := range tampered {
+		err := verifySeedChecksum(bytes.NewReader(archive), "guide", result.Seed.SHA256)
+		if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Errorf("tampered archive %d: expected checksum mismatch, got %v", i, err)
+		}
+	}
+}
+
+func TestExtractTarGz_StopsAtMaxExtractedSize(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large archive test in short mode")
+	}
+
+	// A highly compressible entry: tiny download, huge expansion. It sits
+	// outside the seed path, so only the decompressed-size cap stops it.
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	size := int64(MaxExtractedSize + 1024*1024)
+	if err := tw.WriteHeader(&tar.Header{Name: "repo/other/bomb.md", Mode: 0o644, Size: size, Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	zeros := make([]byte, 1024*1024)
+	for written := int64(0); written < size; written += int64(len(zeros)) {
+		if _, err := tw.Write(zeros); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gw.Close()
+	if buf.Len() > MaxTarballSize {
+		t.Fatalf("test archive unexpectedly large: %d bytes", buf.Len())
+	}
+
+	_, err := extractTarGz(bytes.NewReader(buf.Bytes()), "my-seed", t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "expands past") {
+		t.Fatalf("expected decompressed size error, got %v", err)
+	}
+}
+
+func TestValidateManifest_RejectsMalformedChecksum(t *testing.T) {
+	m := &Manifest{SchemaVersion: 2, Seeds: []Seed{{Name: "guide", Path: "guide", SHA256: "not-a-hash"}}}
+	if err := validateManifest(m); err == nil || !strings.Contains(err.Error(), "invalid checksum") {
+		t.Fatalf("expected invalid checksum error, got %v", err)
+	}
+	m.Seeds[0].SHA256 = strings.Repeat("ab", 32)
+	if err := validateManifest(m); err != nil {
+		t.Fatalf("valid checksum rejected: %v", err)
+	}
+}
+
+func TestInstall_RefusesSeedWithoutChecksum(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	m := &Manifest{SchemaVersion: 2, Seeds: []Seed{{
+		Name:        "no-sum",
+		DisplayName: "No Checksum",
+		Path:        "no-sum",
+		NoteCount:   3,
+	}}}
+	if err := saveManifestCache(manifestCachePath(), m); err != nil {
+		t.Fatalf("save manifest cache: %v", err)
+	}
+
+	dest := filepath.Join(home, "seeds", "no-sum")
+	_, err := Install(InstallOptions{Name: "no-sum", Path: dest, Offline: true, NoIndex: true})
+	if !errors.Is(err, ErrNoChecksum) {
+		t.Fatalf("expected ErrNoChecksum, got %v", err)
+	}
+	if _, statErr := os.Stat(dest); !os.IsNotExist(statErr) {
+		t.Errorf("refused install should not create %s", dest)
+	}
+}
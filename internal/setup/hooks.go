@@ -3,6 +3,7 @@ package setup
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -50,6 +51,11 @@ type hookAction struct {
 
 // SetupHooks installs SAME hooks into .claude/settings.json.
 func SetupHooks(vaultPath string) error {
+	return SetupHooksTo(os.Stdout, vaultPath)
+}
+
+// SetupHooksTo is SetupHooks with progress written to w.
+func SetupHooksTo(w io.Writer, vaultPath string) error {
 	settingsPath := filepath.Join(vaultPath, ".claude", "settings.json")
 	// Use bare "same" from PATH for portability across machines.
 	// Only fall back to absolute path if "same" is not in PATH.
@@ -106,7 +112,7 @@ func SetupHooks(vaultPath string) error {
 		return fmt.Errorf("write settings: %w", err)
 	}
 
-	fmt.Printf("  → .claude/settings.json (%d hooks)\n", count)
+	fmt.Fprintf(w, "  → .claude/settings.json (%d hooks)\n", count)
 	return nil
 }
 
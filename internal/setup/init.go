@@ -41,6 +41,63 @@ type InitOptions struct {
 	Verbose   bool // show detailed progress (each file being processed)
 	Version   string
 	Provider  string // embedding provider override: ollama, openai, openai-compatible, none
+	Model     string // embedding model override
+	BaseURL   string // embedding endpoint override (openai, openai-compatible)
+}
+
+// validateInitEmbedding checks that the embedding flags passed to init
+// make a usable combination, so a scripted 'same init --yes' fails up front
+// instead of indexing without embeddings. ec is the effective embedding
+// config with the flags already applied.
+func validateInitEmbedding(provider, model, baseURL string, ec config.EmbeddingConfig) error {
+	switch provider {
+	case "none":
+		if model != "" || baseURL != "" {
+			return fmt.Errorf("--model and --base-url need an embedding provider; provider %q is keyword-only", provider)
+		}
+		return nil
+	case "ollama":
+		if baseURL != "" {
+			return fmt.Errorf("--base-url is for openai and openai-compatible providers; set [ollama] url or OLLAMA_URL for Ollama")
+		}
+		return nil
+	}
+	if ec.BaseURL != "" {
+		u, err := url.Parse(ec.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid base URL %q (expected http:// or https://)", ec.BaseURL)
+		}
+	}
+	switch provider {
+	case "openai":
+		if ec.APIKey == "" {
+			return fmt.Errorf("provider openai needs an API key — set OPENAI_API_KEY or SAME_EMBED_API_KEY")
+		}
+	case "openai-compatible":
+		if ec.BaseURL == "" {
+			return fmt.Errorf("provider openai-compatible needs an endpoint — pass --base-url (e.g. http://localhost:1234)")
+		}
+		if ec.Model == "" {
+			return fmt.Errorf("provider openai-compatible needs a model — pass --model")
+		}
+	}
+	return nil
+}
+
+// setenvUntilReturn sets an environment variable for the rest of init and
+// returns a func that restores the previous value.
+func setenvUntilReturn(key, value string) (func(), error) {
+	prev, had := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		return nil, fmt.Errorf("set %s: %w", key, err)
+	}
+	return func() {
+		if had {
+			_ = os.Setenv(key, prev)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	}, nil
 }
 
 // ExperienceLevel represents the user's coding experience.
@@ -292,18 +349,30 @@ func RunInit(opts InitOptions) error {
 	if err != nil {
 		return err
 	}
+	// --provider, --model and --base-url act like the SAME_EMBED_* env vars
+	// for the rest of init, so the generated config records them.
+	providerOverride := ""
 	if opts.Provider != "" {
-		prevProvider, hadProvider := os.LookupEnv("SAME_EMBED_PROVIDER")
-		if err := os.Setenv("SAME_EMBED_PROVIDER", embedProvider); err != nil {
-			return fmt.Errorf("set SAME_EMBED_PROVIDER: %w", err)
+		providerOverride = embedProvider
+	}
+	for _, o := range []struct{ key, value string }{
+		{config.EnvEmbedProvider, providerOverride},
+		{config.EnvEmbedModel, strings.TrimSpace(opts.Model)},
+		{config.EnvEmbedBaseURL, strings.TrimSpace(opts.BaseURL)},
+	} {
+		if o.value == "" {
+			continue
+		}
+		restore, err := setenvUntilReturn(o.key, o.value)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+	if opts.Provider != "" || opts.Model != "" || opts.BaseURL != "" {
+		if err := validateInitEmbedding(embedProvider, opts.Model, opts.BaseURL, config.EmbeddingProviderConfig()); err != nil {
+			return err
 		}
-		defer func() {
-			if hadProvider {
-				_ = os.Setenv("SAME_EMBED_PROVIDER", prevProvider)
-			} else {
-				_ = os.Unsetenv("SAME_EMBED_PROVIDER")
-			}
-		}()
 	}
 
 	// Check dependencies (Node, selected embedding runtime, Go version, CGO)
@@ -320,155 +389,9 @@ func RunInit(opts InitOptions) error {
 	projectCtx := scanProjectContext(cwd)
 	showProjectContext(projectCtx)
 
-	providerReady := true
-	var initDetection *ollamaDetection // set during Ollama path for smart config
-
-	switch embedProvider {
-	case "none":
-		// Explicit keyword-only mode — skip Ollama entirely
-		cli.Section("Embeddings")
-		fmt.Printf("  %s✓%s Keyword-only mode (provider=none)\n", cli.Green, cli.Reset)
-		fmt.Printf("  %s  Semantic search disabled. Switch to ollama/openai/openai-compatible later and run 'same reindex' to upgrade.%s\n", cli.Dim, cli.Reset)
-		providerReady = false
-	case "openai", "openai-compatible":
-		// User has configured an alternate provider — skip Ollama check
-		cli.Section("Embeddings")
-		fmt.Printf("  %s✓%s Using %s provider\n", cli.Green, cli.Reset, embedProvider)
-		ec := config.EmbeddingProviderConfig()
-		if ec.Model != "" {
-			fmt.Printf("  %s✓%s Model: %s\n", cli.Green, cli.Reset, ec.Model)
-		}
-		if ec.BaseURL != "" && ec.BaseURL != "https://api.openai.com" {
-			fmt.Printf("  %s✓%s Endpoint: %s\n", cli.Green, cli.Reset, ec.BaseURL)
-		}
-	default:
-		cli.Section("Embeddings")
-		if opts.Yes {
-			// Non-interactive: try Ollama with smart detection
-			det, err := checkOllamaWithDetection()
-			if err != nil {
-				providerReady = false
-				fmt.Printf("  %s⚠%s Ollama not detected. Using keyword-only mode (exact matches only).\n", cli.Yellow, cli.Reset)
-				fmt.Printf("  %s  For semantic search, install Ollama: https://ollama.com%s\n", cli.Dim, cli.Reset)
-				fmt.Printf("  %s  Then run: same reindex%s\n", cli.Dim, cli.Reset)
-			} else {
-				// Auto-configure best embedding model
-				autoConfigureEmbedding(det)
-			}
-			initDetection = det
-		} else {
-			// Interactive: probe Ollama, then let user choose provider
-			reader := bufio.NewReader(os.Stdin)
-			ollamaDetected := probeOllama()
-			chosen := offerProviderChoice(ollamaDetected)
-
-			switch chosen {
-			case "ollama":
-				det, err := checkOllamaWithDetection()
-				if err != nil {
-					providerReady = false
-				} else {
-					// Auto-configure best embedding model
-					autoConfigureEmbedding(det)
-				}
-				initDetection = det
-			case "openai":
-				embedProvider = chosen
-				_ = os.Setenv("SAME_EMBED_PROVIDER", chosen)
-				// Check for API key
-				apiKey := os.Getenv("SAME_EMBED_API_KEY")
-				if apiKey == "" {
-					apiKey = os.Getenv("OPENAI_API_KEY")
-				}
-				if apiKey == "" {
-					fmt.Printf("\n  Enter your OpenAI API key %s(or set OPENAI_API_KEY env var)%s\n", cli.Dim, cli.Reset)
-					fmt.Printf("  API key: ")
-					keyInput, _ := reader.ReadString('\n')
-					apiKey = strings.TrimSpace(keyInput)
-					if apiKey == "" {
-						return fmt.Errorf("OpenAI API key required — set OPENAI_API_KEY and run 'same init' again")
-					}
-					_ = os.Setenv("OPENAI_API_KEY", apiKey)
-				}
-				fmt.Printf("\n  %s✓%s Using OpenAI API (model: text-embedding-3-small)\n", cli.Green, cli.Reset)
-				_ = os.Setenv("SAME_EMBED_MODEL", "text-embedding-3-small")
-			case "openai-compatible":
-				embedProvider = chosen
-				_ = os.Setenv("SAME_EMBED_PROVIDER", chosen)
-				baseURL := os.Getenv("SAME_EMBED_BASE_URL")
-				ec := config.EmbeddingProviderConfig()
-				if baseURL == "" && ec.BaseURL != "" {
-					baseURL = ec.BaseURL
-				}
-				if baseURL == "" {
-					type endpoint struct {
-						url   string
-						label string
-					}
-					endpoints := []endpoint{
-						{"http://localhost:1234", "LM Studio"},
-						{"http://localhost:8080", "llama.cpp / LocalAI"},
-						{"http://localhost:11434/v1", "Ollama (OpenAI-compatible mode)"},
-						{"https://openrouter.ai/api/v1", "OpenRouter (cloud)"},
-					}
-
-					fmt.Printf("\n  %sPick your endpoint:%s\n\n", cli.Bold, cli.Reset)
-					for i, ep := range endpoints {
-						fmt.Printf("    %s%d%s) %-36s %s%s%s\n",
-							cli.Cyan, i+1, cli.Reset, ep.url, cli.Dim, ep.label, cli.Reset)
-					}
-					fmt.Printf("    %s%d%s) Custom URL\n", cli.Cyan, len(endpoints)+1, cli.Reset)
-					fmt.Printf("\n  Choice: ")
-					urlInput, _ := reader.ReadString('\n')
-					pick := strings.TrimSpace(urlInput)
-
-					var n int
-					if _, err := fmt.Sscanf(pick, "%d", &n); err == nil && n >= 1 && n <= len(endpoints) {
-						baseURL = endpoints[n-1].url
-					} else if n == len(endpoints)+1 {
-						fmt.Printf("  URL: ")
-						customInput, _ := reader.ReadString('\n')
-						baseURL = strings.TrimSpace(customInput)
-					} else if pick != "" {
-						// Treat raw input as a URL
-						baseURL = pick
-					}
-
-					if baseURL == "" {
-						return fmt.Errorf("base URL required — set SAME_EMBED_BASE_URL and run 'same init' again")
-					}
-					_ = os.Setenv("SAME_EMBED_BASE_URL", baseURL)
-				}
-				fmt.Printf("\n  %s✓%s Using OpenAI-compatible endpoint: %s\n", cli.Green, cli.Reset, baseURL)
-
-				// Prompt for API key if this looks like a remote endpoint
-				apiKey := os.Getenv("SAME_EMBED_API_KEY")
-				if apiKey == "" {
-					apiKey = os.Getenv("OPENAI_API_KEY")
-				}
-				if apiKey == "" && !strings.Contains(baseURL, "localhost") && !strings.Contains(baseURL, "127.0.0.1") {
-					fmt.Printf("\n  Enter API key for this endpoint %s(or Enter to skip if not required)%s\n", cli.Dim, cli.Reset)
-					fmt.Printf("  API key: ")
-					keyInput, _ := reader.ReadString('\n')
-					apiKey = strings.TrimSpace(keyInput)
-					if apiKey != "" {
-						_ = os.Setenv("OPENAI_API_KEY", apiKey)
-					}
-				}
-			case "none":
-				embedProvider = "none"
-				_ = os.Setenv("SAME_EMBED_PROVIDER", "none")
-				providerReady = false
-				fmt.Printf("\n  %s✓%s Keyword-only mode\n", cli.Green, cli.Reset)
-				fmt.Printf("  %s  Semantic search is disabled — recall will only match exact keywords.%s\n", cli.Dim, cli.Reset)
-				fmt.Printf("  %s  Add an embedding provider anytime and run 'same reindex' to upgrade.%s\n", cli.Dim, cli.Reset)
-			}
-		}
-	}
-
-	// Offer model selection (interactive only, skip if smart detection already picked)
-	if !opts.Yes && embedProvider != "none" && providerReady && initDetection == nil {
-		offerModelChoice(embedProvider)
+	embedProvider, providerReady, initDetection, err := chooseEmbedding(embedProvider, opts)
+	if err != nil {
+		return err
 	}
 
 	// Finding notes
@@ -654,7 +577,7 @@ func RunInit(opts InitOptions) error {
 			cli.Cyan, cli.Reset, cli.Dim, cli.Reset)
 	}
 	fmt.Println()
-	fmt.Printf("  Your AI agent has 19 MCP tools available automatically.\n")
+	fmt.Printf("  Your AI agent has 23 MCP tools available automatically.\n")
 	fmt.Printf("  Run %ssame demo%s to see everything in action.\n", cli.Cyan, cli.Reset)
 	fmt.Printf("\n  %sTip:%s Restart your editor (Claude Code, Cursor, etc.) to pick up the new MCP configuration.\n",
 		cli.Bold, cli.Reset)
@@ -671,6 +594,167 @@ func RunInit(opts InitOptions) error {
 	return nil
 }
 
+// chooseEmbedding settles the embedding provider and model for init: it
+// reports keyword-only and API providers, detects Ollama models, and runs
+// the interactive provider and model pickers unless opts.Yes is set. The
+// choice is left in the SAME_EMBED_* environment for the rest of the run.
+// It returns the provider, whether it's ready to embed, and the Ollama
+// detection result when Ollama was probed.
+func chooseEmbedding(embedProvider string, opts InitOptions) (string, bool, *ollamaDetection, error) {
+	providerReady := true
+	var initDetection *ollamaDetection // set during Ollama path for smart config
+
+	switch embedProvider {
+	case "none":
+		// Explicit keyword-only mode — skip Ollama entirely
+		cli.Section("Embeddings")
+		fmt.Printf("  %s✓%s Keyword-only mode (provider=none)\n", cli.Green, cli.Reset)
+		fmt.Printf("  %s  Semantic search disabled. Switch to ollama/openai/openai-compatible later with 'same init --reconfigure'.%s\n", cli.Dim, cli.Reset)
+		providerReady = false
+	case "openai", "openai-compatible":
+		// User has configured an alternate provider — skip Ollama check
+		cli.Section("Embeddings")
+		fmt.Printf("  %s✓%s Using %s provider\n", cli.Green, cli.Reset, embedProvider)
+		ec := config.EmbeddingProviderConfig()
+		if ec.Model != "" {
+			fmt.Printf("  %s✓%s Model: %s\n", cli.Green, cli.Reset, ec.Model)
+		}
+		if ec.BaseURL != "" && ec.BaseURL != "https://api.openai.com" {
+			fmt.Printf("  %s✓%s Endpoint: %s\n", cli.Green, cli.Reset, ec.BaseURL)
+		}
+	default:
+		cli.Section("Embeddings")
+		if opts.Yes {
+			// Non-interactive: try Ollama with smart detection
+			det, err := checkOllamaWithDetection()
+			if err != nil {
+				providerReady = false
+				fmt.Printf("  %s⚠%s Ollama not detected. Using keyword-only mode (exact matches only).\n", cli.Yellow, cli.Reset)
+				fmt.Printf("  %s  For semantic search, install Ollama: https://ollama.com%s\n", cli.Dim, cli.Reset)
+				fmt.Printf("  %s  Then run: same reindex%s\n", cli.Dim, cli.Reset)
+			} else if opts.Model == "" {
+				// Auto-configure best embedding model
+				autoConfigureEmbedding(det)
+			}
+			initDetection = det
+		} else {
+			// Interactive: probe Ollama, then let user choose provider
+			reader := bufio.NewReader(os.Stdin)
+			ollamaDetected := probeOllama()
+			chosen := offerProviderChoice(ollamaDetected)
+
+			switch chosen {
+			case "ollama":
+				det, err := checkOllamaWithDetection()
+				if err != nil {
+					providerReady = false
+				} else {
+					// Auto-configure best embedding model
+					autoConfigureEmbedding(det)
+				}
+				initDetection = det
+			case "openai":
+				embedProvider = chosen
+				_ = os.Setenv(config.EnvEmbedProvider, chosen)
+				// Check for API key
+				apiKey := os.Getenv(config.EnvEmbedAPIKey)
+				if apiKey == "" {
+					apiKey = os.Getenv(config.EnvOpenAIAPIKey)
+				}
+				if apiKey == "" {
+					fmt.Printf("\n  Enter your OpenAI API key %s(or set OPENAI_API_KEY env var)%s\n", cli.Dim, cli.Reset)
+					fmt.Printf("  API key: ")
+					keyInput, _ := reader.ReadString('\n')
+					apiKey = strings.TrimSpace(keyInput)
+					if apiKey == "" {
+						return "", false, nil, fmt.Errorf("OpenAI API key required — set OPENAI_API_KEY and run 'same init' again")
+					}
+					_ = os.Setenv(config.EnvOpenAIAPIKey, apiKey)
+				}
+				fmt.Printf("\n  %s✓%s Using OpenAI API (model: text-embedding-3-small)\n", cli.Green, cli.Reset)
+				_ = os.Setenv(config.EnvEmbedModel, "text-embedding-3-small")
+			case "openai-compatible":
+				embedProvider = chosen
+				_ = os.Setenv(config.EnvEmbedProvider, chosen)
+				baseURL := os.Getenv(config.EnvEmbedBaseURL)
+				ec := config.EmbeddingProviderConfig()
+				if baseURL == "" && ec.BaseURL != "" {
+					baseURL = ec.BaseURL
+				}
+				if baseURL == "" {
+					type endpoint struct {
+						url   string
+						label string
+					}
+					endpoints := []endpoint{
+						{"http://localhost:1234", "LM Studio"},
+						{"http://localhost:8080", "llama.cpp / LocalAI"},
+						{"http://localhost:11434/v1", "Ollama (OpenAI-compatible mode)"},
+						{"https://openrouter.ai/api/v1", "OpenRouter (cloud)"},
+					}
+
+					fmt.Printf("\n  %sPick your endpoint:%s\n\n", cli.Bold, cli.Reset)
+					for i, ep := range endpoints {
+						fmt.Printf("    %s%d%s) %-36s %s%s%s\n",
+							cli.Cyan, i+1, cli.Reset, ep.url, cli.Dim, ep.label, cli.Reset)
+					}
+					fmt.Printf("    %s%d%s) Custom URL\n", cli.Cyan, len(endpoints)+1, cli.Reset)
+					fmt.Printf("\n  Choice: ")
+					urlInput, _ := reader.ReadString('\n')
+					pick := strings.TrimSpace(urlInput)
+
+					var n int
+					if _, err := fmt.Sscanf(pick, "%d", &n); err == nil && n >= 1 && n <= len(endpoints) {
+						baseURL = endpoints[n-1].url
+					} else if n == len(endpoints)+1 {
+						fmt.Printf("  URL: ")
+						customInput, _ := reader.ReadString('\n')
+						baseURL = strings.TrimSpace(customInput)
+					} else if pick != "" {
+						// Treat raw input as a URL
+						baseURL = pick
+					}
+
+					if baseURL == "" {
+						return "", false, nil, fmt.Errorf("base URL required — set SAME_EMBED_BASE_URL and run 'same init' again")
+					}
+					_ = os.Setenv(config.EnvEmbedBaseURL, baseURL)
+				}
+				fmt.Printf("\n  %s✓%s Using OpenAI-compatible endpoint: %s\n", cli.Green, cli.Reset, baseURL)
+
+				// Prompt for API key if this looks like a remote endpoint
+				apiKey := os.Getenv(config.EnvEmbedAPIKey)
+				if apiKey == "" {
+					apiKey = os.Getenv(config.EnvOpenAIAPIKey)
+				}
+				if apiKey == "" && !strings.Contains(baseURL, "localhost") && !strings.Contains(baseURL, "127.0.0.1") {
+					fmt.Printf("\n  Enter API key for this endpoint %s(or Enter to skip if not required)%s\n", cli.Dim, cli.Reset)
+					fmt.Printf("  API key: ")
+					keyInput, _ := reader.ReadString('\n')
+					apiKey = strings.TrimSpace(keyInput)
+					if apiKey != "" {
+						_ = os.Setenv(config.EnvOpenAIAPIKey, apiKey)
+					}
+				}
+			case "none":
+				embedProvider = "none"
+				_ = os.Setenv(config.EnvEmbedProvider, "none")
+				providerReady = false
+				fmt.Printf("\n  %s✓%s Keyword-only mode\n", cli.Green, cli.Reset)
+				fmt.Printf("  %s  Semantic search is disabled — recall will only match exact keywords.%s\n", cli.Dim, cli.Reset)
+				fmt.Printf("  %s  Add an embedding provider anytime with 'same init --reconfigure'.%s\n", cli.Dim, cli.Reset)
+			}
+		}
+	}
+
+	// Offer model selection (interactive only, skip if smart detection already picked)
+	if !opts.Yes && opts.Model == "" && embedProvider != "none" && providerReady && initDetection == nil {
+		offerModelChoice(embedProvider)
+	}
+
+	return embedProvider, providerReady, initDetection, nil
+}
+
 // offerSeedInstall prompts the user to install a seed vault when the vault is empty.
 // The flow is opt-in at every step: Enter always skips.
 // Returns true if a seed was successfully installed.
@@ -1050,7 +1134,7 @@ func offerModelChoice(provider string) {
 
 	// Persist model choice in env so it's visible for the rest of init
 	// (config file write may fail if vault path isn't known yet).
-	_ = os.Setenv("SAME_EMBED_MODEL", chosen.Name)
+	_ = os.Setenv(config.EnvEmbedModel, chosen.Name)
 
 	// Also write to config file if vault is known
 	vp := config.VaultPath()
@@ -1063,7 +1147,7 @@ func offerModelChoice(provider string) {
 	// For Ollama, pull the model if not already available
 	if provider == "ollama" {
 		ollamaURL := "http://localhost:11434"
-		if v := os.Getenv("OLLAMA_URL"); v != "" {
+		if v := os.Getenv(config.EnvOllamaURL); v != "" {
 			ollamaURL = v
 		}
 		httpClient := &http.Client{Timeout: 5 * time.Second}
@@ -1102,7 +1186,7 @@ func offerModelChoice(provider string) {
 // probeOllama silently checks if Ollama is responding on localhost.
 func probeOllama() bool {
 	ollamaURL := "http://localhost:11434"
-	if v := os.Getenv("OLLAMA_URL"); v != "" {
+	if v := os.Getenv(config.EnvOllamaURL); v != "" {
 		ollamaURL = v
 	}
 	u, err := url.Parse(ollamaURL)
@@ -1342,7 +1426,7 @@ func autoConfigureEmbedding(det *ollamaDetection) {
 	if det == nil || det.BestEmbedding == "" {
 		return
 	}
-	_ = os.Setenv("SAME_EMBED_MODEL", det.BestEmbedding)
+	_ = os.Setenv(config.EnvEmbedModel, det.BestEmbedding)
 }
 
 // ollamaDetection holds the results of model detection during init.
@@ -1385,41 +1469,91 @@ var knownEmbeddingModels = map[string]bool{
 	"bge-m3":                  true,
 }
 
-// detectOllamaModels queries Ollama's /api/tags and classifies available models.
-func detectOllamaModels(ollamaURL string) *ollamaDetection {
-	det := &ollamaDetection{}
+// ollamaTag is one model listed by Ollama's /api/tags.
+type ollamaTag struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
 
+// errOllamaModelList means Ollama answered /api/tags but the model list
+// could not be read.
+var errOllamaModelList = errors.New("unreadable ollama model list")
+
+// fetchOllamaTags lists the models pulled into the Ollama server at ollamaURL.
+func fetchOllamaTags(ollamaURL string) ([]ollamaTag, error) {
 	httpClient := &http.Client{Timeout: 5 * time.Second}
 	resp, err := httpClient.Get(strings.TrimRight(ollamaURL, "/") + "/api/tags")
 	if err != nil {
-		return det
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return det
+		return nil, fmt.Errorf("ollama returned HTTP %d", resp.StatusCode)
 	}
 
-	det.Running = true
-
 	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
 	if err != nil {
-		return det
+		return nil, fmt.Errorf("%w: %v", errOllamaModelList, err)
 	}
 
 	var tagsResp struct {
-		Models []struct {
-			Name string `json:"name"`
-			Size int64  `json:"size"`
-		} `json:"models"`
+		Models []ollamaTag `json:"models"`
 	}
 	if err := json.Unmarshal(body, &tagsResp); err != nil {
+		return nil, fmt.Errorf("%w: %v", errOllamaModelList, err)
+	}
+	return tagsResp.Models, nil
+}
+
+// OllamaModels returns the names (with tags) of the models pulled into the
+// Ollama server at ollamaURL.
+func OllamaModels(ollamaURL string) ([]string, error) {
+	tags, err := fetchOllamaTags(ollamaURL)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return names, nil
+}
+
+// OllamaHasModel reports whether model is among the pulled model names. Like
+// Ollama itself, a name without a tag means the "latest" tag.
+func OllamaHasModel(pulled []string, model string) bool {
+	want := withOllamaTag(model)
+	for _, name := range pulled {
+		if withOllamaTag(name) == want {
+			return true
+		}
+	}
+	return false
+}
+
+func withOllamaTag(name string) string {
+	name = strings.TrimSpace(name)
+	if !strings.Contains(name[strings.LastIndex(name, "/")+1:], ":") {
+		name += ":latest"
+	}
+	return name
+}
+
+// detectOllamaModels queries Ollama's /api/tags and classifies available models.
+func detectOllamaModels(ollamaURL string) *ollamaDetection {
+	det := &ollamaDetection{}
+
+	tags, err := fetchOllamaTags(ollamaURL)
+	if err != nil {
+		det.Running = errors.Is(err, errOllamaModelList)
 		return det
 	}
+	det.Running = true
 
 	// Build lookup of available model base names
-	available := make(map[string]bool, len(tagsResp.Models))
-	for _, m := range tagsResp.Models {
+	available := make(map[string]bool, len(tags))
+	for _, m := range tags {
 		baseName := m.Name
 		if idx := strings.Index(baseName, ":"); idx > 0 {
 			baseName = baseName[:idx]
@@ -1468,7 +1602,7 @@ func detectOllamaModels(ollamaURL string) *ollamaDetection {
 // result for use in auto-configuration.
 func checkOllamaWithDetection() (*ollamaDetection, error) {
 	ollamaURL := "http://localhost:11434"
-	if v := os.Getenv("OLLAMA_URL"); v != "" {
+	if v := os.Getenv(config.EnvOllamaURL); v != "" {
 		ollamaURL = v
 	}
 
@@ -1940,13 +2074,13 @@ func runIndex(vaultPath string, verbose, useEmbeddings bool) (*indexer.Stats, er
 	}
 
 	// Temporarily set the vault path for the indexer
-	origVault := os.Getenv("VAULT_PATH")
-	os.Setenv("VAULT_PATH", vaultPath)
+	origVault := os.Getenv(config.EnvVaultPath)
+	os.Setenv(config.EnvVaultPath, vaultPath)
 	defer func() {
 		if origVault != "" {
-			os.Setenv("VAULT_PATH", origVault)
+			os.Setenv(config.EnvVaultPath, origVault)
 		} else {
-			os.Unsetenv("VAULT_PATH")
+			os.Unsetenv(config.EnvVaultPath)
 		}
 	}()
 
@@ -1966,47 +2100,7 @@ func runIndex(vaultPath string, verbose, useEmbeddings bool) (*indexer.Stats, er
 	}
 	defer db.Close()
 
-	barWidth := 40
-	startTime := time.Now()
-	progress := func(current, total int, path string) {
-		if total == 0 {
-			return
-		}
-		elapsed := time.Since(startTime)
-		elapsedStr := formatDuration(elapsed)
-
-		// Estimate remaining time based on progress so far
-		var remainStr string
-		if current > 0 {
-			perNote := elapsed / time.Duration(current)
-			remaining := perNote * time.Duration(total-current)
-			remainStr = formatDuration(remaining)
-		}
-
-		if verbose {
-			// Show each file being processed
-			shortPath := path
-			if len(path) > 50 {
-				shortPath = "..." + path[len(path)-47:]
-			}
-			if remainStr != "" {
-				fmt.Printf("\r  [%d/%d] %s elapsed · ~%s remaining · %s\033[K\n",
-					current, total, elapsedStr, remainStr, shortPath)
-			} else {
-				fmt.Printf("\r  [%d/%d] %s\033[K\n", current, total, shortPath)
-			}
-		} else {
-			// Show progress bar with timing
-			filled := current * barWidth / total
-			bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
-			if remainStr != "" {
-				fmt.Printf("\r  [%s] %d/%d · %s elapsed · ~%s remaining\033[K",
-					bar, current, total, elapsedStr, remainStr)
-			} else {
-				fmt.Printf("\r  [%s] %d/%d\033[K", bar, current, total)
-			}
-		}
-	}
+	progress := IndexProgress(verbose)
 
 	// Set up context with signal handling for graceful cancellation
 	ctx, cancel := context.WithCancel(context.Background())
@@ -2073,6 +2167,52 @@ func runIndex(vaultPath string, verbose, useEmbeddings bool) (*indexer.Stats, er
 	return stats, nil
 }
 
+// IndexProgress returns the indexing progress display used by init: a bar
+// with elapsed and remaining time, or one line per file when verbose.
+func IndexProgress(verbose bool) indexer.ProgressFunc {
+	const barWidth = 40
+	startTime := time.Now()
+	return func(current, total int, path string) {
+		if total == 0 {
+			return
+		}
+		elapsed := time.Since(startTime)
+		elapsedStr := formatDuration(elapsed)
+
+		// Estimate remaining time based on progress so far
+		var remainStr string
+		if current > 0 {
+			perNote := elapsed / time.Duration(current)
+			remaining := perNote * time.Duration(total-current)
+			remainStr = formatDuration(remaining)
+		}
+
+		if verbose {
+			// Show each file being processed
+			shortPath := path
+			if len(path) > 50 {
+				shortPath = "..." + path[len(path)-47:]
+			}
+			if remainStr != "" {
+				fmt.Printf("\r  [%d/%d] %s elapsed · ~%s remaining · %s\033[K\n",
+					current, total, elapsedStr, remainStr, shortPath)
+			} else {
+				fmt.Printf("\r  [%d/%d] %s\033[K\n", current, total, shortPath)
+			}
+		} else {
+			// Show progress bar with timing
+			filled := current * barWidth / total
+			bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+			if remainStr != "" {
+				fmt.Printf("\r  [%s] %d/%d · %s elapsed · ~%s remaining\033[K",
+					bar, current, total, elapsedStr, remainStr)
+			} else {
+				fmt.Printf("\r  [%s] %d/%d\033[K", bar, current, total)
+			}
+		}
+	}
+}
+
 // formatDuration returns a human-friendly duration string like "2m12s" or "45s".
 func formatDuration(d time.Duration) string {
 	d = d.Round(time.Second)
@@ -2343,6 +2483,7 @@ func runTestSearch(vaultPath string) *store.SearchResult {
 		APIKey:     ec.APIKey,
 		BaseURL:    ec.BaseURL,
 		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
 		SkipRetry:  !config.IsEmbeddingProviderExplicit(),
 	}
 	// For ollama provider, use the legacy [ollama] URL if no base_url is set
@@ -3,6 +3,7 @@ package setup
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -22,6 +23,11 @@ type mcpServer struct {
 
 // SetupMCP registers SAME as an MCP server in .mcp.json.
 func SetupMCP(vaultPath string) error {
+	return SetupMCPTo(os.Stdout, vaultPath)
+}
+
+// SetupMCPTo is SetupMCP with progress written to w.
+func SetupMCPTo(w io.Writer, vaultPath string) error {
 	mcpPath := filepath.Join(vaultPath, ".mcp.json")
 	// Use bare "same" command to rely on PATH, making the config portable
 	// across machines (codespaces, containers, different OS). Only fall back
@@ -57,18 +63,22 @@ func SetupMCP(vaultPath string) error {
 		return fmt.Errorf("write .mcp.json: %w", err)
 	}
 
-	fmt.Println("  → .mcp.json (MCP server registered with 19 tools)")
-	fmt.Println()
-	fmt.Println("  Available tools:")
+	fmt.Fprintln(w, "  → .mcp.json (MCP server registered with 23 tools)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Available tools:")
 	tools := []struct{ name, desc string }{
 		{"search_notes", "Search your knowledge base"},
 		{"search_notes_filtered", "Search with domain/tag filters"},
+		{"list_tags", "List tags, domains, and workstreams"},
 		{"search_across_vaults", "Search across all vaults"},
 		{"get_note", "Read full note content"},
 		{"find_similar_notes", "Find related notes by topic"},
 		{"get_session_context", "Get orientation for a new session"},
+		{"surface_context", "Pick relevant notes for a prompt"},
 		{"recent_activity", "See recently modified notes"},
 		{"save_note", "Create or update a note (with provenance)"},
+		{"update_note_frontmatter", "Edit note metadata in place"},
+		{"delete_note", "Move a note to trash"},
 		{"save_decision", "Log a project decision"},
 		{"create_handoff", "Write a session handoff"},
 		{"save_kaizen", "Log improvement items with provenance"},
@@ -82,7 +92,7 @@ func SetupMCP(vaultPath string) error {
 		{"mem_list_suppressed", "List suppressed notes"},
 	}
 	for _, t := range tools {
-		fmt.Printf("    %-24s %s\n", t.name, t.desc)
+		fmt.Fprintf(w, "    %-24s %s\n", t.name, t.desc)
 	}
 	return nil
 }
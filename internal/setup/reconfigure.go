@@ -0,0 +1,206 @@
+package setup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/sgx-labs/statelessagent/internal/cli"
+	"github.com/sgx-labs/statelessagent/internal/config"
+	"github.com/sgx-labs/statelessagent/internal/embedding"
+	"github.com/sgx-labs/statelessagent/internal/indexer"
+	"github.com/sgx-labs/statelessagent/internal/store"
+)
+
+// RunReconfigure changes the embedding provider and model of a vault that's
+// already set up, then reindexes. Unlike RunInit it keeps the database,
+// welcome notes, hooks and MCP config. The reindex only re-embeds every note
+// when the vault already has vectors from a different model; a keyword-only
+// vault just gets its missing embeddings filled in.
+func RunReconfigure(opts InitOptions) error {
+	unlock, err := acquireInitLock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	vaultPath := config.VaultPath()
+	if vaultPath == "" {
+		return config.ErrNoVault
+	}
+	if _, err := os.Stat(config.ConfigFilePath(vaultPath)); err != nil {
+		return fmt.Errorf("%s isn't set up yet — run 'same init' first", cli.ShortenHome(vaultPath))
+	}
+
+	before := config.EmbeddingProviderConfig()
+
+	// Without --provider, --yes keeps the current provider, except that a
+	// keyword-only vault tries Ollama (falling back to keyword-only if it's
+	// still not running). Interactive runs get the provider picker.
+	provider := opts.Provider
+	if provider == "" && opts.Yes && before.Provider != "none" {
+		provider = before.Provider
+	}
+	provider, err = normalizeEmbedProvider(provider)
+	if err != nil {
+		return err
+	}
+	for _, o := range []struct{ key, value string }{
+		{config.EnvEmbedProvider, provider},
+		{config.EnvEmbedModel, strings.TrimSpace(opts.Model)},
+		{config.EnvEmbedBaseURL, strings.TrimSpace(opts.BaseURL)},
+	} {
+		if o.value == "" {
+			continue
+		}
+		restore, err := setenvUntilReturn(o.key, o.value)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+	if opts.Provider != "" || opts.Model != "" || opts.BaseURL != "" {
+		if err := validateInitEmbedding(provider, opts.Model, opts.BaseURL, config.EmbeddingProviderConfig()); err != nil {
+			return err
+		}
+	}
+
+	// An empty provider normalizes to ollama, which is also the branch that
+	// runs the interactive picker.
+	provider, providerReady, _, err := chooseEmbedding(provider, opts)
+	if err != nil {
+		return err
+	}
+
+	after := reconfiguredEmbedding(before, config.EmbeddingProviderConfig())
+	if err := config.SetEmbeddingConfig(vaultPath, after); err != nil {
+		return fmt.Errorf("update config: %w", err)
+	}
+
+	cli.Section("Config")
+	fmt.Printf("  Embeddings: %s → %s\n", describeEmbedding(before), describeEmbedding(after))
+	fmt.Printf("  → %s\n", cli.ShortenHome(config.ConfigFilePath(vaultPath)))
+
+	cli.Section("Indexing")
+	if provider == "none" || !providerReady {
+		fmt.Printf("  %s✓%s Keyword search is unchanged; no reindex needed.\n", cli.Green, cli.Reset)
+		if provider != "none" {
+			fmt.Printf("  %s  Once %s is reachable, run 'same reindex' to add embeddings.%s\n", cli.Dim, provider, cli.Reset)
+		}
+		fmt.Println()
+		return nil
+	}
+	return reindexForEmbedding(opts.Verbose)
+}
+
+// reconfiguredEmbedding is the embedding config to save after a
+// reconfigure. A model or endpoint left over from a different provider is
+// dropped rather than carried across, unless it was given explicitly.
+func reconfiguredEmbedding(before, chosen config.EmbeddingConfig) config.EmbeddingConfig {
+	after := config.EmbeddingConfig{
+		Provider: chosen.Provider,
+		Model:    chosen.Model,
+		BaseURL:  chosen.BaseURL,
+	}
+	if after.Provider != before.Provider {
+		if os.Getenv(config.EnvEmbedModel) == "" {
+			after.Model = ""
+		}
+		if os.Getenv(config.EnvEmbedBaseURL) == "" {
+			after.BaseURL = ""
+		}
+	}
+	if after.Provider == "ollama" {
+		after.BaseURL = ""
+		if after.Model == "" {
+			after.Model = config.EmbeddingModel
+		}
+	}
+	return after
+}
+
+// describeEmbedding formats an embedding config for the before/after line.
+func describeEmbedding(ec config.EmbeddingConfig) string {
+	switch {
+	case ec.Provider == "none":
+		return "keyword-only"
+	case ec.Model == "":
+		return ec.Provider + " (default model)"
+	default:
+		return ec.Provider + "/" + ec.Model
+	}
+}
+
+// reindexForEmbedding reindexes the current vault with the configured
+// embedding provider, forcing a full re-embed only when the stored vectors
+// came from a different model.
+func reindexForEmbedding(verbose bool) error {
+	db, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	force := false
+	ec := config.EmbeddingProviderConfig()
+	provCfg := embedding.ProviderConfig{
+		Provider:   ec.Provider,
+		Model:      ec.Model,
+		APIKey:     ec.APIKey,
+		BaseURL:    ec.BaseURL,
+		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
+		SkipRetry:  true,
+	}
+	if (provCfg.Provider == "ollama" || provCfg.Provider == "") && provCfg.BaseURL == "" {
+		if ollamaURL, urlErr := config.OllamaURL(); urlErr == nil {
+			provCfg.BaseURL = ollamaURL
+		}
+	}
+	if client, embErr := embedding.NewProvider(provCfg); embErr == nil && client != nil && db.HasVectors() {
+		if mismatch := db.CheckEmbeddingMeta(client.Name(), client.Model(), client.Dimensions()); mismatch != nil {
+			force = true
+			fmt.Printf("  Existing embeddings are from another model; re-embedding every note.\n\n")
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	embedProgress := func(completed, total int) {
+		if total > 0 {
+			fmt.Fprintf(os.Stderr, "\r  Embedding: %d/%d notes (keyword search active)\033[K", completed, total)
+		}
+	}
+	stats, embResult, err := indexer.ReindexProgressive(ctx, db, force, IndexProgress(verbose), embedProgress)
+	if err != nil && !errors.Is(err, indexer.ErrCanceled) {
+		return fmt.Errorf("indexing failed: %w", err)
+	}
+	if !verbose {
+		fmt.Println()
+	}
+	if embResult != nil && embResult.Total > 0 {
+		fmt.Fprintf(os.Stderr, "\r%s\r", strings.Repeat(" ", 70))
+		if errors.Is(err, indexer.ErrCanceled) {
+			fmt.Printf("  Embedding paused: %d/%d notes done. Resume with 'same reindex'.\n",
+				embResult.Completed, embResult.Total)
+			return nil
+		}
+	}
+
+	notes := 0
+	if stats != nil {
+		notes = stats.NotesInIndex
+	}
+	if db.HasVectors() {
+		fmt.Printf("  %s✓%s %d notes indexed. Semantic search ready.\n\n", cli.Green, cli.Reset, notes)
+	} else {
+		fmt.Printf("  %s⚠%s %d notes indexed, but the embedding provider didn't respond.\n", cli.Yellow, cli.Reset, notes)
+		fmt.Printf("  %s  Keyword search works; run 'same reindex' once it's reachable.%s\n\n", cli.Dim, cli.Reset)
+	}
+	return nil
+}
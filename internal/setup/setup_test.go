@@ -1,6 +1,7 @@
 package setup
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -292,6 +293,55 @@ func TestRunInit_ProviderNonePersistsInConfig(t *testing.T) {
 	}
 }
 
+func TestValidateInitEmbedding(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		model    string
+		baseURL  string
+		ec       config.EmbeddingConfig
+		wantErr  string
+	}{
+		{name: "none", provider: "none"},
+		{name: "none with model", provider: "none", model: "x", wantErr: "keyword-only"},
+		{name: "ollama model", provider: "ollama", model: "bge-m3"},
+		{name: "ollama base url", provider: "ollama", baseURL: "http://localhost:11434", wantErr: "--base-url"},
+		{name: "openai without key", provider: "openai", wantErr: "API key"},
+		{name: "openai with key", provider: "openai", ec: config.EmbeddingConfig{APIKey: "sk-test"}},
+		{
+			name: "compatible complete", provider: "openai-compatible",
+			model: "nomic-embed-text", baseURL: "http://localhost:1234",
+			ec: config.EmbeddingConfig{Model: "nomic-embed-text", BaseURL: "http://localhost:1234"},
+		},
+		{
+			name: "compatible without url", provider: "openai-compatible", model: "m",
+			ec: config.EmbeddingConfig{Model: "m"}, wantErr: "--base-url",
+		},
+		{
+			name: "compatible without model", provider: "openai-compatible", baseURL: "http://localhost:1234",
+			ec: config.EmbeddingConfig{BaseURL: "http://localhost:1234"}, wantErr: "--model",
+		},
+		{
+			name: "bad url", provider: "openai-compatible", baseURL: "localhost:1234",
+			ec: config.EmbeddingConfig{Model: "m", BaseURL: "localhost:1234"}, wantErr: "invalid base URL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateInitEmbedding(tt.provider, tt.model, tt.baseURL, tt.ec)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestVaultHasNotes_Recursive(t *testing.T) {
 	vault := t.TempDir()
 	if vaultHasNotes(vault) {
@@ -749,6 +799,24 @@ func TestSetupMCP_CreatesNewFile(t *testing.T) {
 	}
 }
 
+func TestSetupMCPTo_WritesProgressToWriter(t *testing.T) {
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	if err := SetupMCPTo(&buf, dir); err != nil {
+		t.Fatalf("SetupMCPTo: %v", err)
+	}
+	if !strings.Contains(buf.String(), ".mcp.json") {
+		t.Errorf("expected progress in writer, got %q", buf.String())
+	}
+	buf.Reset()
+	if err := SetupHooksTo(&buf, dir); err != nil {
+		t.Fatalf("SetupHooksTo: %v", err)
+	}
+	if !strings.Contains(buf.String(), ".claude/settings.json") {
+		t.Errorf("expected progress in writer, got %q", buf.String())
+	}
+}
+
 func TestSetupMCP_PreservesExistingServers(t *testing.T) {
 	dir := t.TempDir()
 	existing := mcpConfig{
@@ -1440,3 +1508,115 @@ func TestDetectOllamaModels_NoModels(t *testing.T) {
 		t.Errorf("expected 0 chat models, got %d", len(det.ChatModels))
 	}
 }
+
+func TestOllamaModels_ListsPulledModels(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/tags", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"models":[{"name":"nomic-embed-text:latest"},{"name":"mxbai-embed-large:335m"}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	pulled, err := OllamaModels(server.URL)
+	if err != nil {
+		t.Fatalf("OllamaModels: %v", err)
+	}
+	for _, tc := range []struct {
+		model string
+		want  bool
+	}{
+		{"nomic-embed-text", true},
+		{"nomic-embed-text:latest", true},
+		{"nomic-embed-text:v1.5", false},
+		{"mxbai-embed-large:335m", true},
+		{"mxbai-embed-large", false}, // resolves to :latest, which isn't pulled
+		{"bge-m3", false},
+	} {
+		if got := OllamaHasModel(pulled, tc.model); got != tc.want {
+			t.Errorf("OllamaHasModel(%q) = %v, want %v", tc.model, got, tc.want)
+		}
+	}
+}
+
+func TestOllamaModels_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	if _, err := OllamaModels(server.URL); err == nil {
+		t.Error("expected an error for an unreachable server")
+	}
+}
+
+func TestRunReconfigure_KeepsIndexAndUpdatesConfig(t *testing.T) {
+	vault := t.TempDir()
+	if err := os.WriteFile(filepath.Join(vault, "note.md"), []byte("# Note\nhello\n"), 0o644); err != nil {
+		t.Fatalf("write note: %v", err)
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	// Nothing listens here, so Ollama is "not running" and no reindex runs.
+	t.Setenv("OLLAMA_URL", "http://127.0.0.1:1")
+
+	origVaultOverride := config.VaultOverride
+	config.VaultOverride = vault
+	t.Cleanup(func() { config.VaultOverride = origVaultOverride })
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	scratch := t.TempDir()
+	if err := os.Chdir(scratch); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+
+	if err := RunReconfigure(InitOptions{Yes: true}); err == nil {
+		t.Fatal("expected reconfigure to fail before init")
+	}
+	if err := RunInit(InitOptions{Yes: true, Provider: "none", Version: "test"}); err != nil {
+		t.Fatalf("RunInit(provider=none): %v", err)
+	}
+	dbPath := filepath.Join(vault, ".same", "data", "vault.db")
+	before, err := os.Stat(dbPath)
+	if err != nil {
+		t.Fatalf("stat db: %v", err)
+	}
+
+	if err := RunReconfigure(InitOptions{Yes: true, Provider: "ollama", Model: "all-minilm"}); err != nil {
+		t.Fatalf("RunReconfigure: %v", err)
+	}
+
+	cfg, err := config.LoadConfigFrom(config.ConfigFilePath(vault))
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.Embedding.Provider != "ollama" || cfg.Embedding.Model != "all-minilm" {
+		t.Fatalf("embedding = %s/%s, want ollama/all-minilm", cfg.Embedding.Provider, cfg.Embedding.Model)
+	}
+	after, err := os.Stat(dbPath)
+	if err != nil {
+		t.Fatalf("database removed by reconfigure: %v", err)
+	}
+	if !os.SameFile(before, after) {
+		t.Fatal("reconfigure recreated the database")
+	}
+}
+
+func TestReconfiguredEmbedding_DropsOtherProviderSettings(t *testing.T) {
+	t.Setenv("SAME_EMBED_MODEL", "")
+	t.Setenv("SAME_EMBED_BASE_URL", "")
+	before := config.EmbeddingConfig{Provider: "openai-compatible", Model: "text-embed", BaseURL: "http://localhost:1234"}
+
+	got := reconfiguredEmbedding(before, config.EmbeddingConfig{Provider: "ollama", Model: "text-embed", BaseURL: "http://localhost:1234"})
+	if got.Model != config.EmbeddingModel || got.BaseURL != "" {
+		t.Errorf("switch to ollama = %+v, want default model and no base_url", got)
+	}
+
+	same := reconfiguredEmbedding(before, config.EmbeddingConfig{Provider: "openai-compatible", Model: "other", BaseURL: "http://localhost:1234"})
+	if same.Model != "other" || same.BaseURL != "http://localhost:1234" {
+		t.Errorf("same provider = %+v, want settings kept", same)
+	}
+}
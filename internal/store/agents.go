@@ -0,0 +1,73 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AgentStats is how many notes one agent attributed itself as writing.
+// Agent is empty for notes with no agent attribution.
+type AgentStats struct {
+	Agent        string         `json:"agent"`
+	Notes        int            `json:"notes"`
+	Decisions    int            `json:"decisions"`
+	Handoffs     int            `json:"handoffs"`
+	ByType       map[string]int `json:"by_type"`
+	LastModified float64        `json:"last_modified"`
+}
+
+// AgentCounts returns note counts per agent attribution, most prolific
+// first, with unattributed notes last. Agent names are reported as written.
+// SECURITY: Excludes _PRIVATE/ content from counts.
+func (db *DB) AgentCounts() ([]AgentStats, error) {
+	rows, err := db.conn.Query(`
+		SELECT COALESCE(agent, ''), content_type, COUNT(*), MAX(modified) FROM vault_notes
+		WHERE chunk_id = 0 AND UPPER(path) NOT LIKE '_PRIVATE/%'
+		GROUP BY COALESCE(agent, ''), content_type`)
+	if err != nil {
+		return nil, fmt.Errorf("agent counts: %w", err)
+	}
+	defer rows.Close()
+
+	byAgent := make(map[string]*AgentStats)
+	for rows.Next() {
+		var agent, contentType string
+		var n int
+		var modified float64
+		if err := rows.Scan(&agent, &contentType, &n, &modified); err != nil {
+			return nil, fmt.Errorf("scan agent counts: %w", err)
+		}
+		s, ok := byAgent[agent]
+		if !ok {
+			s = &AgentStats{Agent: agent, ByType: make(map[string]int)}
+			byAgent[agent] = s
+		}
+		s.Notes += n
+		s.ByType[contentType] += n
+		switch contentType {
+		case "decision":
+			s.Decisions += n
+		case "handoff":
+			s.Handoffs += n
+		}
+		s.LastModified = max(s.LastModified, modified)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]AgentStats, 0, len(byAgent))
+	for _, s := range byAgent {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].Agent == "") != (result[j].Agent == "") {
+			return result[j].Agent == ""
+		}
+		if result[i].Notes != result[j].Notes {
+			return result[i].Notes > result[j].Notes
+		}
+		return result[i].Agent < result[j].Agent
+	})
+	return result, nil
+}
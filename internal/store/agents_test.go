@@ -0,0 +1,47 @@
+package store
+
+import "testing"
+
+func TestAgentCounts(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	note := func(path, agent, contentType string, chunk int, modified float64) NoteRecord {
+		return NoteRecord{Path: path, Title: path, Tags: "[]", Agent: agent, ChunkID: chunk, ChunkHeading: "(full)",
+			Text: path, Modified: modified, ContentHash: path, ContentType: contentType}
+	}
+	notes := []NoteRecord{
+		note("d1.md", "codex", "decision", 0, 10),
+		note("d1.md", "codex", "decision", 1, 10),
+		note("h1.md", "codex", "handoff", 0, 30),
+		note("n1.md", "claude", "note", 0, 20),
+		note("d2.md", "claude", "decision", 0, 5),
+		note("n2.md", "claude", "research", 0, 5),
+		note("plain.md", "", "note", 0, 40),
+		note("_PRIVATE/p.md", "codex", "note", 0, 50),
+	}
+	if _, err := db.BulkInsertNotesLite(notes); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+
+	got, err := db.AgentCounts()
+	if err != nil {
+		t.Fatalf("AgentCounts: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("AgentCounts = %+v, want 3 entries", got)
+	}
+	claude, codex, none := got[0], got[1], got[2]
+	if claude.Agent != "claude" || claude.Notes != 3 || claude.Decisions != 1 || claude.Handoffs != 0 || claude.ByType["research"] != 1 {
+		t.Errorf("claude = %+v", claude)
+	}
+	if codex.Agent != "codex" || codex.Notes != 2 || codex.Decisions != 1 || codex.Handoffs != 1 || codex.LastModified != 30 {
+		t.Errorf("codex = %+v (private notes must not count)", codex)
+	}
+	if none.Agent != "" || none.Notes != 1 {
+		t.Errorf("unattributed = %+v, want last with 1 note", none)
+	}
+}
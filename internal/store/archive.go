@@ -0,0 +1,76 @@
+package store
+
+import "fmt"
+
+// ArchiveNote archives an indexed note: it stays on disk and in the index
+// but is left out of context surfacing and search unless archived notes
+// are asked for. The path is remembered, so reindexing keeps it archived.
+// Returns the number of chunks flagged; 0 means no note has that path.
+func (db *DB) ArchiveNote(path string) (int64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec("UPDATE vault_notes SET archived = 1 WHERE path = ?", path)
+	if err != nil {
+		return 0, fmt.Errorf("archive note: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil || n == 0 {
+		return 0, err
+	}
+	if _, err := tx.Exec(`INSERT INTO archived_notes (path) VALUES (?) ON CONFLICT(path) DO NOTHING`, path); err != nil {
+		return 0, fmt.Errorf("archive note: %w", err)
+	}
+	return n, tx.Commit()
+}
+
+// UnarchiveNote returns an archived note to surfacing and search. Returns
+// false when the path was not archived.
+func (db *DB) UnarchiveNote(path string) (bool, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec("DELETE FROM archived_notes WHERE path = ?", path)
+	if err != nil {
+		return false, fmt.Errorf("unarchive note: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil || n == 0 {
+		return false, err
+	}
+	if _, err := tx.Exec("UPDATE vault_notes SET archived = 0 WHERE path = ?", path); err != nil {
+		return false, fmt.Errorf("unarchive note: %w", err)
+	}
+	return true, tx.Commit()
+}
+
+// ArchivedPaths returns the archived note paths, most recently archived
+// first.
+func (db *DB) ArchivedPaths() ([]string, error) {
+	rows, err := db.conn.Query(`SELECT path FROM archived_notes ORDER BY archived_at DESC, path`)
+	if err != nil {
+		return nil, fmt.Errorf("list archived: %w", err)
+	}
+	defer rows.Close()
+	var paths []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+	return paths, rows.Err()
+}
@@ -0,0 +1,136 @@
+package store
+
+import "testing"
+
+func TestArchiveNote(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	insert := func(path string) {
+		t.Helper()
+		if _, err := db.BulkInsertNotesLite([]NoteRecord{{
+			Path: path, Title: path, Tags: "[]", ChunkHeading: "(full)",
+			Text: "sprint planning notes", Modified: 1700000000, ContentType: "note",
+		}}); err != nil {
+			t.Fatalf("insert %s: %v", path, err)
+		}
+	}
+	insert("old.md")
+	insert("current.md")
+
+	if n, err := db.ArchiveNote("missing.md"); err != nil || n != 0 {
+		t.Fatalf("ArchiveNote(missing) = %d, %v; want 0, nil", n, err)
+	}
+	if n, err := db.ArchiveNote("old.md"); err != nil || n != 1 {
+		t.Fatalf("ArchiveNote = %d, %v; want 1, nil", n, err)
+	}
+
+	paths := func(opts SearchOptions) []string {
+		t.Helper()
+		results, err := db.MetadataFilterSearch(opts)
+		if err != nil {
+			t.Fatalf("MetadataFilterSearch: %v", err)
+		}
+		var out []string
+		for _, r := range results {
+			out = append(out, r.Path)
+		}
+		return out
+	}
+	if got := paths(SearchOptions{}); len(got) != 1 || got[0] != "current.md" {
+		t.Errorf("default search = %v, want only current.md", got)
+	}
+	if got := paths(SearchOptions{IncludeArchived: true}); len(got) != 2 {
+		t.Errorf("IncludeArchived search = %v, want both notes", got)
+	}
+	raw, err := db.KeywordSearch([]string{"sprint"}, 10)
+	if err != nil {
+		t.Fatalf("KeywordSearch: %v", err)
+	}
+	if len(raw) != 1 || raw[0].Path != "current.md" {
+		t.Errorf("KeywordSearch = %+v, want only current.md", raw)
+	}
+
+	// Reindexing the note keeps it archived.
+	if err := db.DeleteByPath("old.md"); err != nil {
+		t.Fatalf("DeleteByPath: %v", err)
+	}
+	insert("old.md")
+	if got := paths(SearchOptions{}); len(got) != 1 || got[0] != "current.md" {
+		t.Errorf("after reindex search = %v, want only current.md", got)
+	}
+	if archived, _ := db.ArchivedPaths(); len(archived) != 1 || archived[0] != "old.md" {
+		t.Errorf("ArchivedPaths = %v, want [old.md]", archived)
+	}
+
+	if ok, err := db.UnarchiveNote("old.md"); err != nil || !ok {
+		t.Fatalf("UnarchiveNote = %v, %v; want true, nil", ok, err)
+	}
+	if ok, _ := db.UnarchiveNote("old.md"); ok {
+		t.Error("second UnarchiveNote should report the note was not archived")
+	}
+	if got := paths(SearchOptions{}); len(got) != 2 {
+		t.Errorf("after unarchive search = %v, want both notes", got)
+	}
+}
+
+func TestArchivedAndSuppressedNotesLeaveSessionContext(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	insert := func(path, contentType string, modified float64) {
+		t.Helper()
+		if _, err := db.BulkInsertNotesLite([]NoteRecord{{
+			Path: path, Title: path, Tags: "[]", ChunkHeading: "(full)",
+			Text: "notes", Modified: modified, ContentType: contentType,
+		}}); err != nil {
+			t.Fatalf("insert %s: %v", path, err)
+		}
+	}
+	insert("decisions/kept.md", "decision", 1700000000)
+	insert("decisions/archived.md", "decision", 1700000100)
+	insert("decisions/suppressed.md", "decision", 1700000200)
+	insert("sessions/old.md", "handoff", 1700000000)
+	insert("sessions/new.md", "handoff", 1700000100)
+	for _, p := range []string{"decisions/kept.md", "decisions/archived.md", "decisions/suppressed.md"} {
+		if err := db.PinNote(p, ""); err != nil {
+			t.Fatalf("PinNote %s: %v", p, err)
+		}
+	}
+	for _, p := range []string{"decisions/archived.md", "sessions/new.md"} {
+		if _, err := db.ArchiveNote(p); err != nil {
+			t.Fatalf("ArchiveNote %s: %v", p, err)
+		}
+	}
+	if _, err := db.SuppressNote("decisions/suppressed.md"); err != nil {
+		t.Fatalf("SuppressNote: %v", err)
+	}
+
+	recent, err := db.RecentNotesByType("decision", 10)
+	if err != nil {
+		t.Fatalf("RecentNotesByType: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Path != "decisions/kept.md" {
+		t.Errorf("RecentNotesByType = %v, want only decisions/kept.md", recent)
+	}
+	pinned, err := db.GetPinnedNotes()
+	if err != nil {
+		t.Fatalf("GetPinnedNotes: %v", err)
+	}
+	if len(pinned) != 1 || pinned[0].Path != "decisions/kept.md" {
+		t.Errorf("GetPinnedNotes = %v, want only decisions/kept.md", pinned)
+	}
+	handoff, err := db.GetLatestHandoff()
+	if err != nil {
+		t.Fatalf("GetLatestHandoff: %v", err)
+	}
+	if handoff.Path != "sessions/old.md" {
+		t.Errorf("GetLatestHandoff = %s, want sessions/old.md", handoff.Path)
+	}
+}
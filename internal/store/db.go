@@ -21,7 +21,7 @@ type DB struct {
 	ftsAvailable bool       // true if FTS5 module is available
 }
 
-const maxSchemaVersion = 11
+const maxSchemaVersion = 21
 
 // Open opens or creates the database at the configured path.
 func Open() (*DB, error) {
@@ -81,6 +81,9 @@ func OpenMemory() (*DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Each connection to :memory: is a separate, empty database; keep the
+	// pool to one so concurrent callers all see the same tables.
+	conn.SetMaxOpenConns(1)
 
 	// Match on-disk performance pragmas for realistic test behavior.
 	conn.Exec("PRAGMA temp_store = MEMORY") //nolint:errcheck
@@ -282,6 +285,16 @@ func (db *DB) migrate() error {
 		{9, db.migrateV9},   // provenance tracking (note_sources + trust_state)
 		{10, db.migrateV10}, // contradiction detail tracking
 		{11, db.migrateV11}, // atomic facts table for dual-layer memory
+		{12, db.migrateV12}, // pin reasons
+		{13, db.migrateV13}, // feedback audit log
+		{14, db.migrateV14}, // confidence decay bookkeeping
+		{15, db.migrateV15}, // chunk source line ranges
+		{16, db.migrateV16}, // outgoing note links
+		{17, db.migrateV17}, // resumable reindex progress
+		{18, db.migrateV18}, // staged embedding sets
+		{19, db.migrateV19}, // evergreen notes
+		{20, db.migrateV20}, // archived notes
+		{21, db.migrateV21}, // note expiry dates
 	}
 	for _, m := range versionedMigrations {
 		if currentVersion < m.version {
@@ -470,6 +483,41 @@ func (db *DB) RebuildFTS() error {
 	return err
 }
 
+// FTS5DeleteNote removes the FTS5 entries for every chunk of path. Because
+// the FTS table uses external content, this must run while the chunks are
+// still in vault_notes, i.e. before DeleteByPath. No-op if FTS5 is unavailable.
+func (db *DB) FTS5DeleteNote(path string) error {
+	if !db.ftsAvailable {
+		return nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	_, err := db.conn.Exec(`INSERT INTO vault_notes_fts(vault_notes_fts, rowid, path, title, text)
+		SELECT 'delete', id, path, title, text FROM vault_notes WHERE path = ?`, path)
+	if err != nil {
+		return fmt.Errorf("fts delete %s: %w", path, err)
+	}
+	return nil
+}
+
+// FTS5UpsertNote indexes the chunks currently stored for path. When a note is
+// replaced, call FTS5DeleteNote before removing the old chunks and this after
+// inserting the new ones; together they keep keyword search in sync without
+// a full RebuildFTS. No-op if FTS5 is unavailable.
+func (db *DB) FTS5UpsertNote(path string) error {
+	if !db.ftsAvailable {
+		return nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	_, err := db.conn.Exec(`INSERT INTO vault_notes_fts(rowid, path, title, text)
+		SELECT id, path, title, text FROM vault_notes WHERE path = ?`, path)
+	if err != nil {
+		return fmt.Errorf("fts insert %s: %w", path, err)
+	}
+	return nil
+}
+
 // IntegrityCheck runs SQLite PRAGMA integrity_check and returns an error if corruption is detected.
 func (db *DB) IntegrityCheck() error {
 	var result string
@@ -492,20 +540,25 @@ func (db *DB) LastReindexTime() string {
 	return v
 }
 
-// CheckEmbeddingMeta compares the given embedding config against what was used
-// at last reindex. Returns an error if there's a mismatch. Returns nil if no
-// stored metadata exists (pre-migration DB or first index).
+// CheckEmbeddingMeta compares the given embedding config against the active
+// embedding model (see ActiveEmbeddingModel). Returns an error if there's a
+// mismatch; when a complete staged set exists for the configured model, the
+// error says to switch to it instead of reindexing. Returns nil if no stored
+// metadata exists (pre-migration DB or first index).
 func (db *DB) CheckEmbeddingMeta(provider, model string, dims int) error {
-	storedProvider, hasProvider := db.GetMeta("embed_provider")
-	storedModel, hasModel := db.GetMeta("embed_model")
-	storedDimsStr, hasDims := db.GetMeta("embed_dims")
+	storedProvider, storedModel, storedDims, hasModelMeta := db.ActiveEmbeddingModel()
+	_, hasDims := db.GetMeta("embed_dims")
 
 	// No stored metadata = compatible (never block on upgrade or first use)
-	if !hasProvider && !hasModel && !hasDims {
+	if !hasModelMeta && !hasDims {
 		return nil
 	}
 
-	storedDims, _ := strconv.Atoi(storedDimsStr)
+	mismatch := (hasDims && dims > 0 && storedDims > 0 && storedDims != dims) ||
+		(hasModelMeta && (storedProvider != provider || storedModel != model))
+	if mismatch && db.hasCompleteStagedSet(provider, model) {
+		return fmt.Errorf("embedding model changed (%s/%s→%s/%s). Embeddings for %s are staged; run 'same model use %s' to switch", storedProvider, storedModel, provider, model, model, model)
+	}
 
 	// Check for dimension mismatch (most critical — causes garbage results)
 	if hasDims && dims > 0 && storedDims > 0 && storedDims != dims {
@@ -513,13 +566,28 @@ func (db *DB) CheckEmbeddingMeta(provider, model string, dims int) error {
 	}
 
 	// Check for provider/model mismatch
-	if hasProvider && hasModel && (storedProvider != provider || storedModel != model) {
+	if hasModelMeta && (storedProvider != provider || storedModel != model) {
 		return fmt.Errorf("embedding model changed (%s/%s→%s/%s). Run 'same reindex --force' to rebuild", storedProvider, storedModel, provider, model)
 	}
 
 	return nil
 }
 
+// hasCompleteStagedSet reports whether a staged set for provider and model
+// covers every indexed note.
+func (db *DB) hasCompleteStagedSet(provider, model string) bool {
+	sets, err := db.EmbeddingSets()
+	if err != nil {
+		return false
+	}
+	for _, s := range sets {
+		if !s.Active && s.Provider == provider && s.Model == model {
+			return s.Vectors > 0 && s.Missing == 0
+		}
+	}
+	return false
+}
+
 // RecordRecovery logs how a session's context was recovered for reliability monitoring.
 func (db *DB) RecordRecovery(sessionID, recoveredFromSession, source string, completeness float64) error {
 	db.mu.Lock()
@@ -681,6 +749,154 @@ func (db *DB) migrateV11() error {
 	return err
 }
 
+// migrateV12 adds an optional reason to pinned notes so the pin list
+// records why each note is always injected.
+func (db *DB) migrateV12() error {
+	if !db.hasColumn("pinned_notes", "reason") {
+		if _, err := db.conn.Exec(`ALTER TABLE pinned_notes ADD COLUMN reason TEXT NOT NULL DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateV13 creates the feedback_log table that records manual confidence
+// adjustments made with 'same feedback'.
+func (db *DB) migrateV13() error {
+	if _, err := db.conn.Exec(`CREATE TABLE IF NOT EXISTS feedback_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		path TEXT NOT NULL,
+		direction TEXT NOT NULL,
+		old_confidence REAL NOT NULL,
+		new_confidence REAL NOT NULL,
+		access_boost INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL DEFAULT (unixepoch())
+	)`); err != nil {
+		return fmt.Errorf("create feedback_log table: %w", err)
+	}
+	_, err := db.conn.Exec(`CREATE INDEX IF NOT EXISTS idx_feedback_log_path ON feedback_log(path, id)`)
+	return err
+}
+
+// migrateV14 adds decayed_at to vault_notes: the Unix time confidence decay
+// was last applied to the row. Rows written by the indexer start at 0, so
+// their decay is measured from their modified time.
+func (db *DB) migrateV14() error {
+	if !db.hasColumn("vault_notes", "decayed_at") {
+		if _, err := db.conn.Exec(`ALTER TABLE vault_notes ADD COLUMN decayed_at REAL NOT NULL DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateV15 adds start_line and end_line to vault_notes: where each chunk
+// sits in its source file, for line-level citations. Existing rows keep 0
+// (unknown) until the note is reindexed.
+func (db *DB) migrateV15() error {
+	for _, col := range []string{"start_line", "end_line"} {
+		if !db.hasColumn("vault_notes", col) {
+			if _, err := db.conn.Exec(`ALTER TABLE vault_notes ADD COLUMN ` + col + ` INTEGER NOT NULL DEFAULT 0`); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// migrateV16 creates the note_links table: the [[wikilinks]] and Markdown
+// links each note makes, stored as written and resolved when read.
+func (db *DB) migrateV16() error {
+	if _, err := db.conn.Exec(`CREATE TABLE IF NOT EXISTS note_links (
+		source_path TEXT NOT NULL,
+		target TEXT NOT NULL,
+		wiki INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (source_path, target, wiki)
+	)`); err != nil {
+		return fmt.Errorf("create note_links table: %w", err)
+	}
+	return nil
+}
+
+// migrateV17 creates the reindex_progress table: the files an unfinished
+// reindex has stored, so the next run can skip them.
+func (db *DB) migrateV17() error {
+	if _, err := db.conn.Exec(`CREATE TABLE IF NOT EXISTS reindex_progress (
+		path TEXT PRIMARY KEY,
+		content_hash TEXT NOT NULL,
+		indexed_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create reindex_progress table: %w", err)
+	}
+	return nil
+}
+
+// migrateV18 creates the tables for staged embedding sets: vectors made
+// with a model other than the active one, kept until the switch.
+func (db *DB) migrateV18() error {
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS embedding_sets (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			provider TEXT NOT NULL,
+			model TEXT NOT NULL,
+			created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+			UNIQUE(provider, model)
+		)`,
+		`CREATE TABLE IF NOT EXISTS staged_embeddings (
+			set_id INTEGER NOT NULL,
+			note_id INTEGER NOT NULL,
+			embedding BLOB NOT NULL,
+			PRIMARY KEY (set_id, note_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_staged_embeddings_note ON staged_embeddings(note_id)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := db.conn.Exec(stmt); err != nil {
+			return fmt.Errorf("create staged embedding tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// migrateV19 adds evergreen to vault_notes: set from frontmatter on notes
+// that should never be flagged stale for their age.
+func (db *DB) migrateV19() error {
+	if !db.hasColumn("vault_notes", "evergreen") {
+		if _, err := db.conn.Exec(`ALTER TABLE vault_notes ADD COLUMN evergreen INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateV20 adds archived to vault_notes and the archived_notes table that
+// remembers archived paths, so a reindexed note stays archived.
+func (db *DB) migrateV20() error {
+	if !db.hasColumn("vault_notes", "archived") {
+		if _, err := db.conn.Exec(`ALTER TABLE vault_notes ADD COLUMN archived INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+	if _, err := db.conn.Exec(`CREATE TABLE IF NOT EXISTS archived_notes (
+		path TEXT PRIMARY KEY,
+		archived_at INTEGER NOT NULL DEFAULT (unixepoch())
+	)`); err != nil {
+		return fmt.Errorf("create archived_notes table: %w", err)
+	}
+	return nil
+}
+
+// migrateV21 adds expires to vault_notes: the frontmatter expires date
+// (YYYY-MM-DD) after which a note is no longer auto-surfaced.
+func (db *DB) migrateV21() error {
+	if !db.hasColumn("vault_notes", "expires") {
+		if _, err := db.conn.Exec(`ALTER TABLE vault_notes ADD COLUMN expires TEXT NOT NULL DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ListSuppressed returns paths of all suppressed (forgotten) notes.
 func (db *DB) ListSuppressed() ([]string, error) {
 	db.mu.Lock()
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	// DecayExemptAccessCount is the access_count at which a note is treated
+	// as actively used and no longer decays.
+	DecayExemptAccessCount = 10
+
+	// minDecayedConfidence matches the floor used by 'same feedback down'.
+	minDecayedConfidence = 0.05
+
+	// confidenceDecayMetaKey records when decay last ran.
+	confidenceDecayMetaKey = "confidence_decay_at"
+)
+
+// DecayConfidence lowers the stored confidence of notes that have not been
+// modified recently, halving it every halfLifeDays. Each row decays only for
+// the time since the later of its modified time and its last decay, so
+// repeated runs compound to exactly 0.5^(age/halfLife) and rows rewritten by
+// the indexer start fresh. Pinned notes, _PRIVATE/ notes, and notes with at
+// least DecayExemptAccessCount accesses are skipped.
+//
+// The run is skipped when the previous one was less than minInterval ago.
+// Returns the number of rows updated.
+func (db *DB) DecayConfidence(halfLifeDays float64, now time.Time, minInterval time.Duration) (int, error) {
+	if halfLifeDays <= 0 || math.IsNaN(halfLifeDays) || math.IsInf(halfLifeDays, 0) {
+		return 0, nil
+	}
+	if last, ok := db.GetMeta(confidenceDecayMetaKey); ok && minInterval > 0 {
+		if ts, err := strconv.ParseInt(last, 10, 64); err == nil && now.Sub(time.Unix(ts, 0)) < minInterval {
+			return 0, nil
+		}
+	}
+	nowSec := float64(now.Unix())
+
+	type row struct {
+		id         int64
+		confidence float64
+		since      float64
+	}
+	rows, err := db.conn.Query(
+		`SELECT id, confidence, MAX(modified, decayed_at) FROM vault_notes
+		 WHERE access_count < ?
+		   AND confidence > ?
+		   AND path NOT IN (SELECT path FROM pinned_notes)
+		   AND UPPER(path) NOT LIKE '_PRIVATE/%'`,
+		DecayExemptAccessCount, minDecayedConfidence,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("select notes for decay: %w", err)
+	}
+	var pending []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.id, &r.confidence, &r.since); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan decay row: %w", err)
+		}
+		if nowSec > r.since {
+			pending = append(pending, r)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("select notes for decay: %w", err)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin decay: %w", err)
+	}
+	stmt, err := tx.Prepare(`UPDATE vault_notes SET confidence = ?, decayed_at = ? WHERE id = ?`)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("prepare decay: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, r := range pending {
+		ageDays := (nowSec - r.since) / 86400.0
+		conf := math.Max(minDecayedConfidence, r.confidence*math.Pow(0.5, ageDays/halfLifeDays))
+		conf = math.Round(conf*1000) / 1000
+		if _, err := stmt.Exec(conf, nowSec, r.id); err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("decay note %d: %w", r.id, err)
+		}
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO schema_meta (key, value) VALUES (?, ?)
+		 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+		confidenceDecayMetaKey, strconv.FormatInt(now.Unix(), 10),
+	); err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("record decay time: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit decay: %w", err)
+	}
+	return len(pending), nil
+}
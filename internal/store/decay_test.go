@@ -0,0 +1,82 @@
+package store
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDecayConfidence(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 86400.0
+	old := float64(now.Unix()) - 60*day
+	notes := []NoteRecord{
+		{Path: "old.md", Modified: old},
+		{Path: "pinned.md", Modified: old},
+		{Path: "busy.md", Modified: old, AccessCount: DecayExemptAccessCount},
+		{Path: "_PRIVATE/secret.md", Modified: old},
+	}
+	for i := range notes {
+		notes[i].Title = notes[i].Path
+		notes[i].Tags = "[]"
+		notes[i].ChunkHeading = "(full)"
+		notes[i].ContentHash = notes[i].Path
+		notes[i].ContentType = "note"
+		notes[i].Confidence = 0.8
+	}
+	if _, err := db.BulkInsertNotesLite(notes); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+	if _, err := db.Conn().Exec(`UPDATE vault_notes SET access_count = ? WHERE path = 'busy.md'`, DecayExemptAccessCount); err != nil {
+		t.Fatalf("set access_count: %v", err)
+	}
+	if err := db.PinNote("pinned.md", ""); err != nil {
+		t.Fatalf("PinNote: %v", err)
+	}
+
+	conf := func(path string) float64 {
+		t.Helper()
+		var c float64
+		if err := db.Conn().QueryRow(`SELECT confidence FROM vault_notes WHERE path = ?`, path).Scan(&c); err != nil {
+			t.Fatalf("read confidence: %v", err)
+		}
+		return c
+	}
+
+	// Disabled: no-op.
+	if n, err := db.DecayConfidence(0, now, 0); err != nil || n != 0 {
+		t.Fatalf("disabled decay = %d, %v", n, err)
+	}
+
+	// 60 days old with a 30-day half-life: two halvings.
+	if _, err := db.DecayConfidence(30, now, 0); err != nil {
+		t.Fatalf("DecayConfidence: %v", err)
+	}
+	if got := conf("old.md"); math.Abs(got-0.2) > 0.001 {
+		t.Errorf("old.md confidence = %.3f, want 0.200", got)
+	}
+	for _, p := range []string{"pinned.md", "busy.md", "_PRIVATE/secret.md"} {
+		if got := conf(p); got != 0.8 {
+			t.Errorf("%s should be exempt, confidence = %.3f", p, got)
+		}
+	}
+
+	// Within minInterval: skipped.
+	if n, _ := db.DecayConfidence(30, now.Add(time.Hour), 24*time.Hour); n != 0 {
+		t.Errorf("decay within minInterval updated %d rows", n)
+	}
+
+	// A later run only applies the time since the previous one.
+	if _, err := db.DecayConfidence(30, now.Add(30*24*time.Hour), 24*time.Hour); err != nil {
+		t.Fatalf("DecayConfidence: %v", err)
+	}
+	if got := conf("old.md"); math.Abs(got-0.1) > 0.001 {
+		t.Errorf("old.md confidence after another half-life = %.3f, want 0.100", got)
+	}
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DuplicateNote is one side of a DuplicatePair.
+type DuplicateNote struct {
+	Path        string  `json:"path"`
+	Title       string  `json:"title"`
+	ContentType string  `json:"content_type"`
+	Confidence  float64 `json:"confidence"`
+	Modified    float64 `json:"modified"`
+	AccessCount int     `json:"access_count"`
+}
+
+// DuplicatePair is two notes whose embeddings are nearly identical.
+// Keep is the stronger note and Drop the weaker (see weakerNote).
+type DuplicatePair struct {
+	Keep       DuplicateNote `json:"keep"`
+	Drop       DuplicateNote `json:"drop"`
+	Similarity float64       `json:"similarity"`
+	Versioned  bool          `json:"versioned,omitempty"` // paths look like versioned copies
+}
+
+// FindDuplicates compares every note's first-chunk embedding with its
+// nearest neighbors and returns the pairs whose cosine similarity is at
+// least threshold, most similar first. neighbors bounds how many nearest
+// chunks are checked per note. Private, archived, and suppressed notes are
+// skipped, as in surfacing.
+func (db *DB) FindDuplicates(threshold float64, neighbors int) ([]DuplicatePair, error) {
+	rows, err := db.conn.Query(`
+		SELECT n.path, n.title, n.content_type, n.confidence, n.modified, n.access_count, v.embedding
+		FROM vault_notes n
+		JOIN vault_notes_vec v ON v.note_id = n.id
+		WHERE n.chunk_id = 0 AND UPPER(n.path) NOT LIKE '_PRIVATE/%'
+			AND COALESCE(n.suppressed, 0) = 0 AND n.archived = 0
+		ORDER BY n.path`)
+	if err != nil {
+		return nil, fmt.Errorf("load embeddings: %w", err)
+	}
+	type noteVec struct {
+		note DuplicateNote
+		vec  []float32
+	}
+	var notes []noteVec
+	for rows.Next() {
+		var nv noteVec
+		var data []byte
+		if err := rows.Scan(&nv.note.Path, &nv.note.Title, &nv.note.ContentType, &nv.note.Confidence,
+			&nv.note.Modified, &nv.note.AccessCount, &data); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if nv.vec, err = deserializeFloat32(data); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s: %w", nv.note.Path, err)
+		}
+		notes = append(notes, nv)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string]int, len(notes))
+	for i, nv := range notes {
+		byPath[nv.note.Path] = i
+	}
+
+	seen := make(map[[2]string]bool)
+	var pairs []DuplicatePair
+	for _, nv := range notes {
+		// The note's own chunks take some of the k slots; fetch extra.
+		raw, err := db.VectorSearchRaw(nv.vec, neighbors*2+1)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range raw {
+			j, ok := byPath[r.Path]
+			if !ok || r.Path == nv.note.Path {
+				continue
+			}
+			key := [2]string{nv.note.Path, r.Path}
+			if key[1] < key[0] {
+				key[0], key[1] = key[1], key[0]
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			sim := cosineSimilarity(nv.vec, notes[j].vec)
+			if sim < threshold {
+				continue
+			}
+			keep, drop := nv.note, notes[j].note
+			if weakerNote(keep, drop) {
+				keep, drop = drop, keep
+			}
+			pairs = append(pairs, DuplicatePair{
+				Keep:       keep,
+				Drop:       drop,
+				Similarity: math.Round(sim*1000) / 1000,
+				Versioned:  IsVersionedCopy(keep.Path, drop.Path),
+			})
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].Similarity != pairs[j].Similarity {
+			return pairs[i].Similarity > pairs[j].Similarity
+		}
+		return pairs[i].Keep.Path < pairs[j].Keep.Path
+	})
+	return pairs, nil
+}
+
+// weakerNote reports whether a is the one to drop in favor of b: lower
+// confidence first, then older, then less accessed.
+func weakerNote(a, b DuplicateNote) bool {
+	if a.Confidence != b.Confidence {
+		return a.Confidence < b.Confidence
+	}
+	if a.Modified != b.Modified {
+		return a.Modified < b.Modified
+	}
+	if a.AccessCount != b.AccessCount {
+		return a.AccessCount < b.AccessCount
+	}
+	return a.Path > b.Path
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or 0
+// when either is empty, zero, or they differ in length.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		ai, bi := float64(a[i]), float64(b[i])
+		dot += ai * bi
+		normA += ai * ai
+		normB += bi * bi
+	}
+	denom := math.Sqrt(normA) * math.Sqrt(normB)
+	if denom == 0 {
+		return 0
+	}
+	return dot / denom
+}
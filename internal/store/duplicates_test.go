@@ -0,0 +1,74 @@
+package store
+
+import "testing"
+
+func TestFindDuplicates(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	vec := func(x, y float32) []float32 {
+		v := make([]float32, 768)
+		v[0], v[1] = x, y
+		return v
+	}
+	insert := func(path string, confidence float64, v []float32) {
+		t.Helper()
+		rec := &NoteRecord{
+			Path: path, Title: path, Tags: "[]", ChunkHeading: "(full)", Text: "handoff",
+			Modified: 1700000000, ContentType: "handoff", Confidence: confidence,
+		}
+		if err := db.InsertNote(rec, v); err != nil {
+			t.Fatalf("insert %s: %v", path, err)
+		}
+	}
+	insert("handoffs/sprint.md", 0.4, vec(1, 0))
+	insert("handoffs/sprint-v2.md", 0.8, vec(0.99, 0.05))
+	insert("notes/unrelated.md", 0.5, vec(0, 1))
+	insert("_PRIVATE/sprint-copy.md", 0.9, vec(1, 0))
+
+	pairs, err := db.FindDuplicates(0.95, 5)
+	if err != nil {
+		t.Fatalf("FindDuplicates: %v", err)
+	}
+	if len(pairs) != 1 {
+		t.Fatalf("got %d pairs, want 1: %+v", len(pairs), pairs)
+	}
+	p := pairs[0]
+	if p.Keep.Path != "handoffs/sprint-v2.md" || p.Drop.Path != "handoffs/sprint.md" {
+		t.Errorf("keep/drop = %s/%s, want the higher-confidence note kept", p.Keep.Path, p.Drop.Path)
+	}
+	if !p.Versioned {
+		t.Error("expected pair to be flagged as versioned copies")
+	}
+	if p.Similarity < 0.95 || p.Similarity > 1 {
+		t.Errorf("similarity = %v, want within [0.95, 1]", p.Similarity)
+	}
+
+	if _, err := db.ArchiveNote("handoffs/sprint.md"); err != nil {
+		t.Fatalf("ArchiveNote: %v", err)
+	}
+	if pairs, err = db.FindDuplicates(0.95, 5); err != nil || len(pairs) != 0 {
+		t.Errorf("after archiving, FindDuplicates = %+v, %v; want none", pairs, err)
+	}
+}
+
+func TestIsVersionedCopy(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"handoffs/sprint.md", "handoffs/sprint-v2.md", true},
+		{"Notes/Plan.md", "Notes/plan-old.md", true},
+		{"a/plan.md", "b/plan-v2.md", false},
+		{"plan.md", "roadmap.md", false},
+		{"plan.md", "plan.md", true},
+	}
+	for _, tt := range tests {
+		if got := IsVersionedCopy(tt.a, tt.b); got != tt.want {
+			t.Errorf("IsVersionedCopy(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
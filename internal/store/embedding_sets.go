@@ -0,0 +1,276 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrNoStagedEmbeddings is returned by ActivateEmbeddingSet when nothing has
+// been staged for the requested model.
+var ErrNoStagedEmbeddings = errors.New("no staged embeddings for this model")
+
+// EmbeddingSet describes one set of note vectors. The active set lives in
+// vault_notes_vec and is what search uses; staged sets are built alongside
+// it so a model switch doesn't leave the index without vectors.
+type EmbeddingSet struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Dims     int    `json:"dims"`
+	Vectors  int    `json:"vectors"` // vectors for notes still in the index
+	Missing  int    `json:"missing"` // indexed notes without a vector
+	Active   bool   `json:"active"`
+}
+
+// ActiveEmbeddingModel returns the provider, model, and dimensions the
+// vectors in vault_notes_vec were made with. ok is false for an index that
+// predates embedding metadata or has never been embedded.
+func (db *DB) ActiveEmbeddingModel() (provider, model string, dims int, ok bool) {
+	provider, hasProvider := db.GetMeta("embed_provider")
+	model, hasModel := db.GetMeta("embed_model")
+	dimsStr, _ := db.GetMeta("embed_dims")
+	dims, _ = strconv.Atoi(dimsStr)
+	return provider, model, dims, hasProvider && hasModel
+}
+
+// StageEmbeddingSet returns the ID of the staged set for provider and model,
+// creating it if needed.
+func (db *DB) StageEmbeddingSet(provider, model string) (int64, error) {
+	if p, m, _, ok := db.ActiveEmbeddingModel(); ok && p == provider && m == model {
+		return 0, fmt.Errorf("%s/%s is already the active embedding model", provider, model)
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return stageEmbeddingSetTx(db.conn, provider, model)
+}
+
+// execQuerier is the part of *sql.DB and *sql.Tx the embedding set helpers use.
+type execQuerier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func stageEmbeddingSetTx(q execQuerier, provider, model string) (int64, error) {
+	if _, err := q.Exec(
+		`INSERT INTO embedding_sets (provider, model) VALUES (?, ?) ON CONFLICT(provider, model) DO NOTHING`,
+		provider, model,
+	); err != nil {
+		return 0, fmt.Errorf("stage embedding set: %w", err)
+	}
+	var id int64
+	if err := q.QueryRow(
+		`SELECT id FROM embedding_sets WHERE provider = ? AND model = ?`, provider, model,
+	).Scan(&id); err != nil {
+		return 0, fmt.Errorf("stage embedding set: %w", err)
+	}
+	return id, nil
+}
+
+// NoteIDsWithoutStagedVector returns the notes the staged set has no vector
+// for yet, in ID order.
+func (db *DB) NoteIDsWithoutStagedVector(setID int64) ([]int64, error) {
+	rows, err := db.conn.Query(`
+		SELECT n.id FROM vault_notes n
+		WHERE n.id NOT IN (SELECT note_id FROM staged_embeddings WHERE set_id = ?)
+		ORDER BY n.id`, setID)
+	if err != nil {
+		return nil, fmt.Errorf("unstaged note ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan unstaged note id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// InsertStagedEmbedding stores a note's vector in a staged set, replacing
+// any earlier one.
+func (db *DB) InsertStagedEmbedding(setID, noteID int64, vec []float32) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	vecData, err := serializeFloat32(vec)
+	if err != nil {
+		return fmt.Errorf("serialize embedding: %w", err)
+	}
+	if _, err := db.conn.Exec(
+		`INSERT INTO staged_embeddings (set_id, note_id, embedding) VALUES (?, ?, ?)
+		 ON CONFLICT(set_id, note_id) DO UPDATE SET embedding = excluded.embedding`,
+		setID, noteID, vecData,
+	); err != nil {
+		return fmt.Errorf("insert staged embedding for note %d: %w", noteID, err)
+	}
+	return nil
+}
+
+// EmbeddingSets lists the active set, if any, followed by the staged sets.
+func (db *DB) EmbeddingSets() ([]EmbeddingSet, error) {
+	var sets []EmbeddingSet
+	if provider, model, dims, ok := db.ActiveEmbeddingModel(); ok {
+		active := EmbeddingSet{Provider: provider, Model: model, Dims: dims, Active: true}
+		if err := db.conn.QueryRow(`SELECT COUNT(*) FROM vault_notes_vec`).Scan(&active.Vectors); err != nil {
+			return nil, fmt.Errorf("count active vectors: %w", err)
+		}
+		missing, err := db.UnembeddedNoteCount()
+		if err != nil {
+			return nil, err
+		}
+		active.Missing = missing
+		sets = append(sets, active)
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT s.provider, s.model,
+		       COALESCE(MAX(LENGTH(e.embedding)) / 4, 0),
+		       COUNT(e.note_id),
+		       (SELECT COUNT(*) FROM vault_notes) - COUNT(e.note_id)
+		FROM embedding_sets s
+		LEFT JOIN staged_embeddings e
+		       ON e.set_id = s.id AND e.note_id IN (SELECT id FROM vault_notes)
+		GROUP BY s.id
+		ORDER BY s.id`)
+	if err != nil {
+		return nil, fmt.Errorf("list embedding sets: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s EmbeddingSet
+		if err := rows.Scan(&s.Provider, &s.Model, &s.Dims, &s.Vectors, &s.Missing); err != nil {
+			return nil, fmt.Errorf("scan embedding set: %w", err)
+		}
+		sets = append(sets, s)
+	}
+	return sets, rows.Err()
+}
+
+// ActivateEmbeddingSet makes the staged set for provider and model the one
+// search uses, in a single transaction. The vectors it replaces are staged
+// under their own model so switching back is just as quick. The set must
+// cover every indexed note. Extracted facts keep their text but lose their
+// vectors, which came from the outgoing model; the next fact extraction
+// re-embeds them (see FactsWithoutEmbeddings).
+func (db *DB) ActivateEmbeddingSet(provider, model string) error {
+	// Read before the transaction: the pool has a single connection.
+	oldProvider, oldModel, _, hasActive := db.ActiveEmbeddingModel()
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("begin activate: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var setID int64
+	err = tx.QueryRow(`SELECT id FROM embedding_sets WHERE provider = ? AND model = ?`, provider, model).Scan(&setID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNoStagedEmbeddings
+	}
+	if err != nil {
+		return fmt.Errorf("find embedding set: %w", err)
+	}
+
+	var missing int
+	if err := tx.QueryRow(`
+		SELECT COUNT(*) FROM vault_notes
+		WHERE id NOT IN (SELECT note_id FROM staged_embeddings WHERE set_id = ?)`, setID,
+	).Scan(&missing); err != nil {
+		return fmt.Errorf("check staged coverage: %w", err)
+	}
+	if missing > 0 {
+		return fmt.Errorf("%d indexed notes have no %s embeddings yet", missing, model)
+	}
+	var dims, lengths int
+	if err := tx.QueryRow(`
+		SELECT COALESCE(MAX(LENGTH(embedding)) / 4, 0), COUNT(DISTINCT LENGTH(embedding))
+		FROM staged_embeddings WHERE set_id = ?`, setID,
+	).Scan(&dims, &lengths); err != nil {
+		return fmt.Errorf("check staged dimensions: %w", err)
+	}
+	if lengths > 1 {
+		return fmt.Errorf("staged %s embeddings have mixed dimensions", model)
+	}
+
+	// Keep the outgoing vectors as a staged set of their own.
+	var activeDDL string
+	if err := tx.QueryRow(`SELECT sql FROM sqlite_master WHERE name = 'vault_notes_vec'`).Scan(&activeDDL); err != nil {
+		return fmt.Errorf("read vector table schema: %w", err)
+	}
+	if hasActive {
+		oldID, err := stageEmbeddingSetTx(tx, oldProvider, oldModel)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM staged_embeddings WHERE set_id = ?`, oldID); err != nil {
+			return fmt.Errorf("clear previous staged set: %w", err)
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO staged_embeddings (set_id, note_id, embedding)
+			SELECT ?, note_id, embedding FROM vault_notes_vec`, oldID,
+		); err != nil {
+			return fmt.Errorf("stage outgoing vectors: %w", err)
+		}
+	}
+
+	tableDims := 0
+	if m := vecDimsRe.FindStringSubmatch(activeDDL); m != nil {
+		tableDims, _ = strconv.Atoi(m[1])
+	}
+	vecTables := []struct{ name, key string }{
+		{"vault_notes_vec", "note_id"},
+		{"facts_vec", "fact_id"},
+	}
+	for _, t := range vecTables {
+		if tableDims == dims {
+			if _, err := tx.Exec(`DELETE FROM ` + t.name); err != nil && !isNoSuchTableErr(err) {
+				return fmt.Errorf("clear %s: %w", t.name, err)
+			}
+			continue
+		}
+		if _, err := tx.Exec(`DROP TABLE IF EXISTS ` + t.name); err != nil {
+			return fmt.Errorf("drop %s: %w", t.name, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`CREATE VIRTUAL TABLE %s USING vec0(
+			%s INTEGER PRIMARY KEY,
+			embedding float[%d]
+		)`, t.name, t.key, dims)); err != nil {
+			return fmt.Errorf("create %s: %w", t.name, err)
+		}
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO vault_notes_vec (note_id, embedding)
+		SELECT e.note_id, e.embedding FROM staged_embeddings e
+		JOIN vault_notes n ON n.id = e.note_id
+		WHERE e.set_id = ?`, setID,
+	); err != nil {
+		return fmt.Errorf("activate staged vectors: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM staged_embeddings WHERE set_id = ?`, setID); err != nil {
+		return fmt.Errorf("clear activated set: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM embedding_sets WHERE id = ?`, setID); err != nil {
+		return fmt.Errorf("clear activated set: %w", err)
+	}
+	for key, value := range map[string]string{
+		"embed_provider": provider,
+		"embed_model":    model,
+		"embed_dims":     strconv.Itoa(dims),
+	} {
+		if _, err := tx.Exec(
+			`INSERT INTO schema_meta (key, value) VALUES (?, ?)
+			 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+			key, value,
+		); err != nil {
+			return fmt.Errorf("record active embedding model: %w", err)
+		}
+	}
+	return tx.Commit()
+}
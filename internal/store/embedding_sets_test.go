@@ -0,0 +1,151 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestActivateEmbeddingSet(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	oldDims, err := db.VectorTableDims()
+	if err != nil {
+		t.Fatalf("VectorTableDims: %v", err)
+	}
+	notes := []NoteRecord{{Path: "a.md"}, {Path: "b.md"}}
+	vecs := make([][]float32, len(notes))
+	for i := range notes {
+		notes[i].Title = notes[i].Path
+		notes[i].Tags = "[]"
+		notes[i].ChunkHeading = "(full)"
+		notes[i].ContentHash = notes[i].Path
+		notes[i].ContentType = "note"
+		vecs[i] = make([]float32, oldDims)
+		vecs[i][i] = 1
+	}
+	if _, err := db.BulkInsertNotes(notes, vecs); err != nil {
+		t.Fatalf("BulkInsertNotes: %v", err)
+	}
+	if err := db.SetEmbeddingMeta("ollama", "old-model", oldDims); err != nil {
+		t.Fatalf("SetEmbeddingMeta: %v", err)
+	}
+
+	if err := db.ActivateEmbeddingSet("ollama", "new-model"); !errors.Is(err, ErrNoStagedEmbeddings) {
+		t.Fatalf("activating an unstaged model = %v, want ErrNoStagedEmbeddings", err)
+	}
+	if _, err := db.StageEmbeddingSet("ollama", "old-model"); err == nil {
+		t.Fatal("staging the active model should fail")
+	}
+
+	setID, err := db.StageEmbeddingSet("ollama", "new-model")
+	if err != nil {
+		t.Fatalf("StageEmbeddingSet: %v", err)
+	}
+	ids, err := db.NoteIDsWithoutStagedVector(setID)
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("NoteIDsWithoutStagedVector = %v, %v; want 2 ids", ids, err)
+	}
+	if err := db.InsertStagedEmbedding(setID, ids[0], []float32{1, 0, 0, 0}); err != nil {
+		t.Fatalf("InsertStagedEmbedding: %v", err)
+	}
+
+	// A partly staged set must not replace the active vectors.
+	if err := db.ActivateEmbeddingSet("ollama", "new-model"); err == nil {
+		t.Fatal("activating an incomplete set should fail")
+	}
+	if err := db.CheckEmbeddingMeta("ollama", "new-model", 4); err == nil {
+		t.Fatal("CheckEmbeddingMeta should report the mismatch")
+	}
+
+	if err := db.InsertStagedEmbedding(setID, ids[1], []float32{0, 1, 0, 0}); err != nil {
+		t.Fatalf("InsertStagedEmbedding: %v", err)
+	}
+	sets, err := db.EmbeddingSets()
+	if err != nil || len(sets) != 2 {
+		t.Fatalf("EmbeddingSets = %+v, %v; want active and staged", sets, err)
+	}
+	if s := sets[1]; s.Active || s.Model != "new-model" || s.Dims != 4 || s.Vectors != 2 || s.Missing != 0 {
+		t.Errorf("staged set = %+v", s)
+	}
+
+	factVec := make([]float32, oldDims)
+	factVec[0] = 1
+	if err := db.InsertFact(&FactRecord{FactText: "a uses JWT", SourcePath: "a.md", Confidence: 0.8}, factVec); err != nil {
+		t.Fatalf("InsertFact: %v", err)
+	}
+
+	if err := db.ActivateEmbeddingSet("ollama", "new-model"); err != nil {
+		t.Fatalf("ActivateEmbeddingSet: %v", err)
+	}
+	if dims, _ := db.VectorTableDims(); dims != 4 {
+		t.Errorf("vector table dims = %d, want 4", dims)
+	}
+
+	// Facts survive the switch and wait for vectors from the new model.
+	if n, _ := db.FactCount(); n != 1 {
+		t.Errorf("facts after switch = %d, want 1", n)
+	}
+	stale, err := db.FactsWithoutEmbeddings()
+	if err != nil || len(stale) != 1 {
+		t.Fatalf("FactsWithoutEmbeddings = %v, %v; want the kept fact", stale, err)
+	}
+	if err := db.SetFactEmbedding(stale[0].ID, []float32{1, 0, 0, 0}); err != nil {
+		t.Fatalf("SetFactEmbedding: %v", err)
+	}
+	if stale, _ := db.FactsWithoutEmbeddings(); len(stale) != 0 {
+		t.Errorf("fact still unembedded after SetFactEmbedding: %v", stale)
+	}
+	if p, m, d, ok := db.ActiveEmbeddingModel(); !ok || p != "ollama" || m != "new-model" || d != 4 {
+		t.Errorf("ActiveEmbeddingModel = %s, %s, %d, %v", p, m, d, ok)
+	}
+	if err := db.CheckEmbeddingMeta("ollama", "new-model", 4); err != nil {
+		t.Errorf("CheckEmbeddingMeta after switch: %v", err)
+	}
+	results, err := db.VectorSearch([]float32{0, 1, 0, 0}, SearchOptions{TopK: 1})
+	if err != nil || len(results) != 1 || results[0].Path != "b.md" {
+		t.Fatalf("VectorSearch after switch = %+v, %v", results, err)
+	}
+
+	// The outgoing vectors were kept, so switching back needs no re-embedding.
+	sets, _ = db.EmbeddingSets()
+	if len(sets) != 2 || sets[1].Model != "old-model" || sets[1].Missing != 0 {
+		t.Fatalf("after switch EmbeddingSets = %+v", sets)
+	}
+	if err := db.ActivateEmbeddingSet("ollama", "old-model"); err != nil {
+		t.Fatalf("switch back: %v", err)
+	}
+	if dims, _ := db.VectorTableDims(); dims != oldDims {
+		t.Errorf("vector table dims after switching back = %d, want %d", dims, oldDims)
+	}
+}
+
+func TestDeleteByPathRemovesStagedVectors(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	ids, err := db.BulkInsertNotesLite([]NoteRecord{{Path: "a.md", Title: "a", Tags: "[]", ChunkHeading: "(full)", ContentType: "note"}})
+	if err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+	setID, err := db.StageEmbeddingSet("ollama", "new-model")
+	if err != nil {
+		t.Fatalf("StageEmbeddingSet: %v", err)
+	}
+	if err := db.InsertStagedEmbedding(setID, ids["a.md"], []float32{1, 0}); err != nil {
+		t.Fatalf("InsertStagedEmbedding: %v", err)
+	}
+	if err := db.DeleteByPath("a.md"); err != nil {
+		t.Fatalf("DeleteByPath: %v", err)
+	}
+	var n int
+	if err := db.Conn().QueryRow(`SELECT COUNT(*) FROM staged_embeddings`).Scan(&n); err != nil || n != 0 {
+		t.Errorf("staged vectors after delete = %d, %v; want 0", n, err)
+	}
+}
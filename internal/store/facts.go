@@ -98,6 +98,52 @@ func (db *DB) SearchFacts(queryVec []float32, topK int) ([]FactSearchResult, err
 	return results, rows.Err()
 }
 
+// FactsWithoutEmbeddings returns facts that have no vector, such as after
+// the active embedding model changed.
+func (db *DB) FactsWithoutEmbeddings() ([]FactRecord, error) {
+	rows, err := db.conn.Query(`
+		SELECT id, fact_text, source_path, chunk_id, confidence, created_at
+		FROM facts
+		WHERE id NOT IN (SELECT fact_id FROM facts_vec)
+		ORDER BY id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("facts without embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	var facts []FactRecord
+	for rows.Next() {
+		var f FactRecord
+		if err := rows.Scan(&f.ID, &f.FactText, &f.SourcePath, &f.ChunkID, &f.Confidence, &f.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan fact: %w", err)
+		}
+		facts = append(facts, f)
+	}
+	return facts, rows.Err()
+}
+
+// SetFactEmbedding stores the vector for an existing fact, replacing any
+// previous one.
+func (db *DB) SetFactEmbedding(id int64, embedding []float32) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	vecData, err := serializeFactVec(embedding)
+	if err != nil {
+		return fmt.Errorf("serialize fact embedding: %w", err)
+	}
+	if _, err := db.conn.Exec("DELETE FROM facts_vec WHERE fact_id = ?", id); err != nil {
+		return fmt.Errorf("clear fact vector: %w", err)
+	}
+	if _, err := db.conn.Exec(
+		"INSERT INTO facts_vec (fact_id, embedding) VALUES (?, ?)",
+		id, vecData,
+	); err != nil {
+		return fmt.Errorf("insert fact vector: %w", err)
+	}
+	return nil
+}
+
 // DeleteFactsForPath removes all facts associated with a source path.
 // Used when a note is re-indexed or deleted.
 func (db *DB) DeleteFactsForPath(sourcePath string) error {
@@ -0,0 +1,82 @@
+package store
+
+import "fmt"
+
+// Feedback directions recorded in feedback_log.
+const (
+	FeedbackUp    = "up"
+	FeedbackDown  = "down"
+	FeedbackReset = "reset"
+)
+
+// FeedbackEntry is one manual confidence adjustment.
+type FeedbackEntry struct {
+	ID            int64   `json:"id"`
+	Path          string  `json:"path"`
+	Direction     string  `json:"direction"`
+	OldConfidence float64 `json:"old_confidence"`
+	NewConfidence float64 `json:"new_confidence"`
+	AccessBoost   int     `json:"access_boost"`
+	CreatedAt     int64   `json:"created_at"`
+}
+
+// RecordFeedback appends an adjustment to the feedback log.
+func (db *DB) RecordFeedback(e FeedbackEntry) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	_, err := db.conn.Exec(
+		`INSERT INTO feedback_log (path, direction, old_confidence, new_confidence, access_boost)
+		 VALUES (?, ?, ?, ?, ?)`,
+		e.Path, e.Direction, e.OldConfidence, e.NewConfidence, e.AccessBoost,
+	)
+	if err != nil {
+		return fmt.Errorf("record feedback: %w", err)
+	}
+	return nil
+}
+
+// FeedbackHistory returns logged adjustments, newest first. An empty path
+// returns entries for all notes. limit <= 0 means no limit.
+func (db *DB) FeedbackHistory(path string, limit int) ([]FeedbackEntry, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := db.conn.Query(
+		`SELECT id, path, direction, old_confidence, new_confidence, access_boost, created_at
+		 FROM feedback_log
+		 WHERE ? = '' OR path = ?
+		 ORDER BY id DESC
+		 LIMIT ?`,
+		path, path, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("feedback history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []FeedbackEntry
+	for rows.Next() {
+		var e FeedbackEntry
+		if err := rows.Scan(&e.ID, &e.Path, &e.Direction, &e.OldConfidence, &e.NewConfidence, &e.AccessBoost, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan feedback: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// PendingFeedbackBoost returns the access_count boost that feedback has
+// added to path since its last reset, so a reset can take it back out.
+func (db *DB) PendingFeedbackBoost(path string) (int, error) {
+	var boost int
+	err := db.conn.QueryRow(
+		`SELECT COALESCE(SUM(access_boost), 0) FROM feedback_log
+		 WHERE path = ?
+		   AND id > COALESCE((SELECT MAX(id) FROM feedback_log WHERE path = ? AND direction = 'reset'), 0)`,
+		path, path,
+	).Scan(&boost)
+	if err != nil {
+		return 0, fmt.Errorf("feedback boost: %w", err)
+	}
+	return boost, nil
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -14,6 +15,7 @@ var hookLogSeq uint64
 
 // HookActivityRecord captures one hook invocation summary for user-visible logs.
 type HookActivityRecord struct {
+	RowID           int64    `json:"-"`
 	TimestampUnix   int64    `json:"timestamp_unix"`
 	HookSessionID   string   `json:"hook_session_id,omitempty"`
 	HookName        string   `json:"hook_name"`
@@ -97,7 +99,7 @@ func (db *DB) GetRecentHookActivity(limit int) ([]HookActivityRecord, error) {
 	}
 
 	rows, err := db.conn.Query(`
-		SELECT hook_timestamp, hook_session_id, hook_name, hook_status, surfaced_notes,
+		SELECT rowid, hook_timestamp, hook_session_id, hook_name, hook_status, surfaced_notes,
 		       estimated_tokens, error_message, detail, note_paths
 		FROM session_log
 		WHERE entry_kind = 'hook'
@@ -107,12 +109,32 @@ func (db *DB) GetRecentHookActivity(limit int) ([]HookActivityRecord, error) {
 		return nil, fmt.Errorf("query hook activity: %w", err)
 	}
 	defer rows.Close()
+	return scanHookActivity(rows)
+}
+
+// HookActivityAfter returns hook activity rows inserted after rowID, oldest
+// first. Pass the largest RowID seen so far to poll for new activity.
+func (db *DB) HookActivityAfter(rowID int64) ([]HookActivityRecord, error) {
+	rows, err := db.conn.Query(`
+		SELECT rowid, hook_timestamp, hook_session_id, hook_name, hook_status, surfaced_notes,
+		       estimated_tokens, error_message, detail, note_paths
+		FROM session_log
+		WHERE entry_kind = 'hook' AND rowid > ?
+		ORDER BY rowid ASC`, rowID)
+	if err != nil {
+		return nil, fmt.Errorf("query hook activity: %w", err)
+	}
+	defer rows.Close()
+	return scanHookActivity(rows)
+}
 
+func scanHookActivity(rows *sql.Rows) ([]HookActivityRecord, error) {
 	var out []HookActivityRecord
 	for rows.Next() {
 		var rec HookActivityRecord
 		var notePathsJSON string
 		if err := rows.Scan(
+			&rec.RowID,
 			&rec.TimestampUnix,
 			&rec.HookSessionID,
 			&rec.HookName,
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sgx-labs/statelessagent/internal/graph"
+)
+
+// SetNoteLinks replaces the outgoing links recorded for a note.
+func (db *DB) SetNoteLinks(sourcePath string, links []graph.NoteLink) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("begin set note links: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM note_links WHERE source_path = ?", sourcePath); err != nil {
+		return fmt.Errorf("clear note links: %w", err)
+	}
+	for _, l := range links {
+		if _, err := tx.Exec(
+			`INSERT OR IGNORE INTO note_links (source_path, target, wiki) VALUES (?, ?, ?)`,
+			sourcePath, l.Target, l.Wiki,
+		); err != nil {
+			return fmt.Errorf("record link %s: %w", l.Target, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// NoteLinksFrom returns the recorded outgoing links of each given note.
+func (db *DB) NoteLinksFrom(paths []string) (map[string][]graph.NoteLink, error) {
+	links := make(map[string][]graph.NoteLink)
+	if len(paths) == 0 {
+		return links, nil
+	}
+	args := make([]any, len(paths))
+	for i, p := range paths {
+		args[i] = p
+	}
+	rows, err := db.conn.Query(
+		`SELECT source_path, target, wiki FROM note_links
+		 WHERE source_path IN (?`+strings.Repeat(", ?", len(paths)-1)+`)
+		 ORDER BY rowid`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("note links: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var source string
+		var l graph.NoteLink
+		if err := rows.Scan(&source, &l.Target, &l.Wiki); err != nil {
+			return nil, fmt.Errorf("scan note link: %w", err)
+		}
+		links[source] = append(links[source], l)
+	}
+	return links, rows.Err()
+}
+
+// NoteLinkResolver returns a resolver over every indexed note, for mapping
+// recorded links to note paths.
+func (db *DB) NoteLinkResolver() (*graph.LinkResolver, error) {
+	rows, err := db.conn.Query(`SELECT path, title FROM vault_notes WHERE chunk_id = 0 ORDER BY path`)
+	if err != nil {
+		return nil, fmt.Errorf("list notes for links: %w", err)
+	}
+	defer rows.Close()
+	r := graph.NewLinkResolver()
+	for rows.Next() {
+		var path, title string
+		if err := rows.Scan(&path, &title); err != nil {
+			return nil, fmt.Errorf("scan note: %w", err)
+		}
+		r.Add(path, title)
+	}
+	return r, rows.Err()
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -29,6 +31,10 @@ type NoteRecord struct {
 	AccessCount         int
 	TrustState          string
 	ContradictionDetail string
+	StartLine           int    // first line of the chunk in the source file (1-based, 0 = unknown)
+	EndLine             int    // last line of the chunk in the source file
+	Evergreen           bool   // frontmatter evergreen: true; never flagged stale by age
+	Expires             string // frontmatter expires date (YYYY-MM-DD); not auto-surfaced after it
 }
 
 // InsertNote inserts a note record and its embedding vector.
@@ -38,11 +44,14 @@ func (db *DB) InsertNote(rec *NoteRecord, embedding []float32) error {
 
 	res, err := db.conn.Exec(`
 		INSERT INTO vault_notes (path, title, tags, domain, workstream, agent, chunk_id, chunk_heading,
-			text, modified, content_hash, content_type, review_by, confidence, access_count)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			text, modified, content_hash, content_type, review_by, confidence, access_count,
+			start_line, end_line, evergreen, expires, archived)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+			EXISTS (SELECT 1 FROM archived_notes WHERE path = ?))`,
 		rec.Path, rec.Title, rec.Tags, rec.Domain, rec.Workstream, rec.Agent,
 		rec.ChunkID, rec.ChunkHeading, rec.Text, rec.Modified,
 		rec.ContentHash, rec.ContentType, rec.ReviewBy, rec.Confidence, rec.AccessCount,
+		rec.StartLine, rec.EndLine, rec.Evergreen, rec.Expires, rec.Path,
 	)
 	if err != nil {
 		return fmt.Errorf("insert note: %w", err)
@@ -88,8 +97,10 @@ func (db *DB) BulkInsertNotes(records []NoteRecord, embeddings [][]float32) (map
 
 	noteStmt, err := tx.Prepare(`
 		INSERT INTO vault_notes (path, title, tags, domain, workstream, agent, chunk_id, chunk_heading,
-			text, modified, content_hash, content_type, review_by, confidence, access_count)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+			text, modified, content_hash, content_type, review_by, confidence, access_count,
+			start_line, end_line, evergreen, expires, archived)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+			EXISTS (SELECT 1 FROM archived_notes WHERE path = ?))`)
 	if err != nil {
 		return nil, fmt.Errorf("prepare note stmt: %w", err)
 	}
@@ -108,6 +119,7 @@ func (db *DB) BulkInsertNotes(records []NoteRecord, embeddings [][]float32) (map
 			rec.Path, rec.Title, rec.Tags, rec.Domain, rec.Workstream, rec.Agent,
 			rec.ChunkID, rec.ChunkHeading, rec.Text, rec.Modified,
 			rec.ContentHash, rec.ContentType, rec.ReviewBy, rec.Confidence, rec.AccessCount,
+			rec.StartLine, rec.EndLine, rec.Evergreen, rec.Expires, rec.Path,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("insert note %d: %w", i, err)
@@ -156,8 +168,10 @@ func (db *DB) BulkInsertNotesLite(records []NoteRecord) (map[string]int64, error
 
 	stmt, err := tx.Prepare(`
 		INSERT INTO vault_notes (path, title, tags, domain, workstream, agent, chunk_id, chunk_heading,
-			text, modified, content_hash, content_type, review_by, confidence, access_count)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+			text, modified, content_hash, content_type, review_by, confidence, access_count,
+			start_line, end_line, evergreen, expires, archived)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
+			EXISTS (SELECT 1 FROM archived_notes WHERE path = ?))`)
 	if err != nil {
 		return nil, fmt.Errorf("prepare stmt: %w", err)
 	}
@@ -170,6 +184,7 @@ func (db *DB) BulkInsertNotesLite(records []NoteRecord) (map[string]int64, error
 			rec.Path, rec.Title, rec.Tags, rec.Domain, rec.Workstream, rec.Agent,
 			rec.ChunkID, rec.ChunkHeading, rec.Text, rec.Modified,
 			rec.ContentHash, rec.ContentType, rec.ReviewBy, rec.Confidence, rec.AccessCount,
+			rec.StartLine, rec.EndLine, rec.Evergreen, rec.Expires, rec.Path,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("insert note %d: %w", i, err)
@@ -195,10 +210,21 @@ func (db *DB) BulkInsertNotesLite(records []NoteRecord) (map[string]int64, error
 // corresponding row in vault_notes_vec. Used by progressive indexing to
 // identify notes that still need embedding after the FTS5-only pass.
 func (db *DB) UnembeddedNoteIDs() ([]int64, error) {
+	return db.UnembeddedNoteIDsUnder("")
+}
+
+// UnembeddedNoteIDsUnder is UnembeddedNoteIDs limited to notes under the
+// vault-relative directory dir. An empty dir means the whole vault.
+func (db *DB) UnembeddedNoteIDsUnder(dir string) ([]int64, error) {
+	pattern := "%"
+	if dir != "" {
+		pattern = escapeLIKE(strings.TrimSuffix(dir, "/")) + "/%"
+	}
 	rows, err := db.conn.Query(`
 		SELECT n.id FROM vault_notes n
 		WHERE n.id NOT IN (SELECT note_id FROM vault_notes_vec)
-		ORDER BY n.id`)
+		  AND n.path LIKE ? ESCAPE '\'
+		ORDER BY n.id`, pattern)
 	if err != nil {
 		return nil, fmt.Errorf("unembedded note ids: %w", err)
 	}
@@ -225,6 +251,93 @@ func (db *DB) UnembeddedNoteCount() (int, error) {
 	return count, err
 }
 
+// PruneOrphanedVectors deletes vectors whose note row no longer exists.
+// Returns the number of vectors removed.
+func (db *DB) PruneOrphanedVectors() (int64, error) {
+	res, err := db.conn.Exec(`
+		DELETE FROM vault_notes_vec
+		WHERE note_id NOT IN (SELECT id FROM vault_notes)`)
+	if err != nil {
+		return 0, fmt.Errorf("prune orphaned vectors: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// VectorDimensionCounts returns the number of stored note vectors grouped by
+// vector length. A healthy index has a single entry.
+func (db *DB) VectorDimensionCounts() (map[int]int, error) {
+	rows, err := db.conn.Query(`
+		SELECT vec_length(embedding), COUNT(*) FROM vault_notes_vec
+		GROUP BY 1`)
+	if err != nil {
+		return nil, fmt.Errorf("vector dimension counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var dims, n int
+		if err := rows.Scan(&dims, &n); err != nil {
+			return nil, fmt.Errorf("scan vector dimension count: %w", err)
+		}
+		counts[dims] = n
+	}
+	return counts, rows.Err()
+}
+
+// NoteIDsWithVectorLengthNot returns the IDs of notes whose stored vector
+// length differs from dims.
+func (db *DB) NoteIDsWithVectorLengthNot(dims int) ([]int64, error) {
+	rows, err := db.conn.Query(`
+		SELECT note_id FROM vault_notes_vec
+		WHERE vec_length(embedding) != ?
+		ORDER BY note_id`, dims)
+	if err != nil {
+		return nil, fmt.Errorf("mismatched vector ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan mismatched vector id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// VectorTableDims returns the dimension the vault_notes_vec table was
+// created with. Every vector written to the table must have this length.
+func (db *DB) VectorTableDims() (int, error) {
+	var ddl string
+	if err := db.conn.QueryRow(
+		"SELECT sql FROM sqlite_master WHERE name = 'vault_notes_vec'",
+	).Scan(&ddl); err != nil {
+		return 0, fmt.Errorf("read vector table schema: %w", err)
+	}
+	m := vecDimsRe.FindStringSubmatch(ddl)
+	if m == nil {
+		return 0, fmt.Errorf("vector table schema has no dimension")
+	}
+	return strconv.Atoi(m[1])
+}
+
+var vecDimsRe = regexp.MustCompile(`float\[(\d+)\]`)
+
+// DeleteEmbeddingForNote removes the stored vector for a note, if any.
+func (db *DB) DeleteEmbeddingForNote(noteID int64) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, err := db.conn.Exec("DELETE FROM vault_notes_vec WHERE note_id = ?", noteID); err != nil {
+		return fmt.Errorf("delete embedding for note %d: %w", noteID, err)
+	}
+	return nil
+}
+
 // InsertEmbeddingForNote inserts a single embedding vector for an existing note.
 // Used by the background embedding backfill to add vectors one at a time.
 func (db *DB) InsertEmbeddingForNote(noteID int64, vec []float32) error {
@@ -300,6 +413,16 @@ func (db *DB) GetContentHashes() (map[string]string, error) {
 	return hashes, rows.Err()
 }
 
+// ContentHash returns the content_hash stored for path, if it is indexed.
+func (db *DB) ContentHash(path string) (string, bool) {
+	var hash string
+	err := db.conn.QueryRow("SELECT content_hash FROM vault_notes WHERE chunk_id = 0 AND path = ?", path).Scan(&hash)
+	if err != nil {
+		return "", false
+	}
+	return hash, true
+}
+
 // DeleteByPath removes all chunks for a given note path.
 // Uses a transaction to ensure vectors and notes are deleted atomically.
 func (db *DB) DeleteByPath(path string) error {
@@ -339,6 +462,12 @@ func (db *DB) DeleteByPath(path string) error {
 	); err != nil {
 		return fmt.Errorf("delete vectors: %w", err)
 	}
+	if _, err := tx.Exec(
+		"DELETE FROM staged_embeddings WHERE note_id IN (SELECT id FROM vault_notes WHERE path = ?)",
+		path,
+	); err != nil && !isNoSuchTableErr(err) {
+		return fmt.Errorf("delete staged vectors: %w", err)
+	}
 
 	if _, err := tx.Exec("DELETE FROM vault_notes WHERE path = ?", path); err != nil {
 		return fmt.Errorf("delete notes: %w", err)
@@ -352,6 +481,9 @@ func (db *DB) DeleteByPath(path string) error {
 			return fmt.Errorf("delete provenance sources: %w", err)
 		}
 	}
+	if _, err := tx.Exec("DELETE FROM note_links WHERE source_path = ?", path); err != nil && !isNoSuchTableErr(err) {
+		return fmt.Errorf("delete note links: %w", err)
+	}
 
 	return tx.Commit()
 }
@@ -378,9 +510,15 @@ func (db *DB) DeleteAllNotes() error {
 		return fmt.Errorf("delete all graph nodes: %w", err)
 	}
 
+	if _, err := tx.Exec("DELETE FROM note_links"); err != nil && !isNoSuchTableErr(err) {
+		return fmt.Errorf("delete all note links: %w", err)
+	}
 	if _, err := tx.Exec("DELETE FROM vault_notes_vec"); err != nil {
 		return fmt.Errorf("delete all vectors: %w", err)
 	}
+	if _, err := tx.Exec("DELETE FROM staged_embeddings"); err != nil && !isNoSuchTableErr(err) {
+		return fmt.Errorf("delete all staged vectors: %w", err)
+	}
 	if _, err := tx.Exec("DELETE FROM vault_notes"); err != nil {
 		return fmt.Errorf("delete all notes: %w", err)
 	}
@@ -501,13 +639,11 @@ func (db *DB) GetNoteByPath(path string) ([]NoteRecord, error) {
 // SECURITY: Excludes _PRIVATE/ content from results.
 func (db *DB) GetStaleNotes(maxResults int, overdueOnly bool) ([]NoteRecord, error) {
 	query := `
-		SELECT DISTINCT id, path, title, tags, domain, workstream, COALESCE(agent, ''), chunk_id, chunk_heading,
+		SELECT id, path, title, tags, domain, workstream, COALESCE(agent, ''), chunk_id, chunk_heading,
 			text, modified, content_hash, content_type, review_by, confidence, access_count,
 			COALESCE(trust_state, 'unknown'), COALESCE(contradiction_detail, '')
 		FROM vault_notes
-		WHERE review_by != '' AND review_by IS NOT NULL AND path NOT LIKE '_PRIVATE/%'
-		GROUP BY path
-		HAVING MIN(chunk_id)
+		WHERE chunk_id = 0 AND review_by != '' AND review_by IS NOT NULL AND path NOT LIKE '_PRIVATE/%'
 		ORDER BY review_by ASC
 		LIMIT ?`
 
@@ -601,7 +737,7 @@ func (db *DB) RecentNotes(limit int) ([]NoteRecord, error) {
 			text, modified, content_hash, content_type, review_by, confidence, access_count,
 			COALESCE(trust_state, 'unknown'), COALESCE(contradiction_detail, '')
 		FROM vault_notes
-		WHERE chunk_id = 0 AND path NOT LIKE '_PRIVATE/%'
+		WHERE chunk_id = 0 AND archived = 0 AND path NOT LIKE '_PRIVATE/%'
 		ORDER BY modified DESC
 		LIMIT ?`, limit)
 	if err != nil {
@@ -611,6 +747,137 @@ func (db *DB) RecentNotes(limit int) ([]NoteRecord, error) {
 	return scanNotes(rows)
 }
 
+// NotesNotModifiedSince returns notes (one chunk per path) last modified
+// before cutoff (Unix seconds), oldest first. Evergreen and suppressed notes
+// are skipped.
+// SECURITY: Excludes _PRIVATE/ content from results.
+func (db *DB) NotesNotModifiedSince(cutoff float64, limit int) ([]NoteRecord, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	rows, err := db.conn.Query(`
+		SELECT id, path, title, tags, domain, workstream, COALESCE(agent, ''), chunk_id, chunk_heading,
+			text, modified, content_hash, content_type, review_by, confidence, access_count,
+			COALESCE(trust_state, 'unknown'), COALESCE(contradiction_detail, '')
+		FROM vault_notes
+		WHERE chunk_id = 0 AND modified < ? AND evergreen = 0
+			AND COALESCE(suppressed, 0) = 0 AND path NOT LIKE '_PRIVATE/%'
+		ORDER BY modified ASC
+		LIMIT ?`, cutoff, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanNotes(rows)
+}
+
+// ExpiredNotes returns notes (one chunk per path) whose expires date is
+// before today (YYYY-MM-DD), soonest-expired first. Only Path, Title,
+// ContentType, Modified and Expires are set. Suppressed notes are skipped.
+// SECURITY: Excludes _PRIVATE/ content from results.
+func (db *DB) ExpiredNotes(today string) ([]NoteRecord, error) {
+	rows, err := db.conn.Query(`
+		SELECT path, title, content_type, modified, expires
+		FROM vault_notes
+		WHERE chunk_id = 0 AND expires != '' AND expires < ?
+			AND COALESCE(suppressed, 0) = 0 AND path NOT LIKE '_PRIVATE/%'
+		ORDER BY expires ASC, path ASC`, today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var notes []NoteRecord
+	for rows.Next() {
+		var n NoteRecord
+		if err := rows.Scan(&n.Path, &n.Title, &n.ContentType, &n.Modified, &n.Expires); err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
+
+// NoteState is one note's index state, as recorded by 'same diff' snapshots.
+type NoteState struct {
+	Path        string  `json:"path"`
+	ContentHash string  `json:"content_hash"`
+	Modified    float64 `json:"modified"`
+	Confidence  float64 `json:"confidence"`
+	Chunks      int     `json:"chunks"`
+	RowID       int64   `json:"row_id"` // first chunk's id; changes whenever the note is re-indexed
+}
+
+// NoteStates returns the index state of every note, ordered by path.
+// SECURITY: Excludes _PRIVATE/ content from results.
+func (db *DB) NoteStates() ([]NoteState, error) {
+	rows, err := db.conn.Query(`
+		SELECT n.path, n.content_hash, n.modified, COALESCE(n.confidence, 0), c.chunks, n.id
+		FROM vault_notes n
+		JOIN (SELECT path, COUNT(*) AS chunks FROM vault_notes GROUP BY path) c ON c.path = n.path
+		WHERE n.chunk_id = 0 AND n.path NOT LIKE '_PRIVATE/%'
+		ORDER BY n.path`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var states []NoteState
+	for rows.Next() {
+		var s NoteState
+		if err := rows.Scan(&s.Path, &s.ContentHash, &s.Modified, &s.Confidence, &s.Chunks, &s.RowID); err != nil {
+			return nil, err
+		}
+		states = append(states, s)
+	}
+	return states, rows.Err()
+}
+
+// TrustStaleNotes returns notes (one chunk per path) whose trust state is
+// stale, oldest first. Suppressed notes are skipped.
+// SECURITY: Excludes _PRIVATE/ content from results.
+func (db *DB) TrustStaleNotes(limit int) ([]NoteRecord, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	rows, err := db.conn.Query(`
+		SELECT id, path, title, tags, domain, workstream, COALESCE(agent, ''), chunk_id, chunk_heading,
+			text, modified, content_hash, content_type, review_by, confidence, access_count,
+			COALESCE(trust_state, 'unknown'), COALESCE(contradiction_detail, '')
+		FROM vault_notes
+		WHERE chunk_id = 0 AND trust_state = 'stale'
+			AND COALESCE(suppressed, 0) = 0 AND path NOT LIKE '_PRIVATE/%'
+		ORDER BY modified ASC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanNotes(rows)
+}
+
+// RecentNotesByType returns the most recently modified notes of one
+// content type (one chunk per path). Archived and suppressed notes are
+// skipped.
+// SECURITY: Excludes _PRIVATE/ content from results.
+func (db *DB) RecentNotesByType(contentType string, limit int) ([]NoteRecord, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	rows, err := db.conn.Query(`
+		SELECT id, path, title, tags, domain, workstream, COALESCE(agent, ''), chunk_id, chunk_heading,
+			text, modified, content_hash, content_type, review_by, confidence, access_count,
+			COALESCE(trust_state, 'unknown'), COALESCE(contradiction_detail, '')
+		FROM vault_notes
+		WHERE chunk_id = 0 AND content_type = ? AND path NOT LIKE '_PRIVATE/%'
+			AND archived = 0 AND COALESCE(suppressed, 0) = 0
+		ORDER BY modified DESC
+		LIMIT ?`, contentType, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanNotes(rows)
+}
+
 // AllNotes returns all notes (chunk_id=0 only, one per path).
 // SECURITY: Excludes _PRIVATE/ content from results.
 func (db *DB) AllNotes() ([]NoteRecord, error) {
@@ -636,6 +903,43 @@ func (db *DB) AdjustConfidence(path string, newConfidence float64) error {
 	return err
 }
 
+// AccessedNote is a note ranked by how often it has been accessed.
+type AccessedNote struct {
+	Path        string
+	Title       string
+	AccessCount int
+	Confidence  float64
+}
+
+// TopAccessedNotes returns the notes with the highest access_count, most
+// accessed first. Notes never accessed and _PRIVATE/ notes are omitted.
+func (db *DB) TopAccessedNotes(limit int) ([]AccessedNote, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	rows, err := db.conn.Query(`
+		SELECT path, title, access_count, confidence
+		FROM vault_notes
+		WHERE chunk_id = 0 AND access_count > 0
+		  AND UPPER(path) NOT LIKE '_PRIVATE/%'
+		ORDER BY access_count DESC, path
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("top accessed notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []AccessedNote
+	for rows.Next() {
+		var n AccessedNote
+		if err := rows.Scan(&n.Path, &n.Title, &n.AccessCount, &n.Confidence); err != nil {
+			return nil, fmt.Errorf("scan accessed note: %w", err)
+		}
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
+
 // SetAccessBoost increments the access_count by boost for all chunks at the given path.
 func (db *DB) SetAccessBoost(path string, boost int) error {
 	db.mu.Lock()
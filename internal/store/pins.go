@@ -2,13 +2,24 @@ package store
 
 import "fmt"
 
+// PinnedNote is a pinned note's chunk-0 record plus its pin metadata.
+type PinnedNote struct {
+	NoteRecord
+	Reason   string
+	PinnedAt int64 // Unix seconds
+}
+
 // PinNote pins a note path so it always appears in context surfacing.
-func (db *DB) PinNote(path string) error {
+// reason is optional. Pinning an already pinned path keeps its original
+// pin time and replaces the reason only when a new one is given.
+func (db *DB) PinNote(path, reason string) error {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 	_, err := db.conn.Exec(
-		`INSERT OR IGNORE INTO pinned_notes (path) VALUES (?)`,
-		path,
+		`INSERT INTO pinned_notes (path, reason) VALUES (?, ?)
+		 ON CONFLICT(path) DO UPDATE SET reason = excluded.reason
+		 WHERE excluded.reason != ''`,
+		path, reason,
 	)
 	if err != nil {
 		return fmt.Errorf("pin note: %w", err)
@@ -71,19 +82,22 @@ func (db *DB) IsPinned(path string) (bool, error) {
 	return count > 0, nil
 }
 
-// GetPinnedNotes returns the full NoteRecord for each pinned note.
-// Returns deduplicated records (one per path, preferring chunk 0).
-// Uses a single JOIN query instead of N+1 queries.
-func (db *DB) GetPinnedNotes() ([]NoteRecord, error) {
+// GetPinnedNotes returns the full NoteRecord and pin metadata for each
+// pinned note, oldest pin first. Returns deduplicated records (one per path,
+// preferring chunk 0). Uses a single JOIN query instead of N+1 queries.
+// Archived and suppressed notes are skipped even while pinned.
+func (db *DB) GetPinnedNotes() ([]PinnedNote, error) {
 	rows, err := db.conn.Query(
 		`SELECT n.id, n.path, n.title, n.tags, n.domain, n.workstream, COALESCE(n.agent, ''),
 		        n.chunk_id, n.chunk_heading, n.text, n.modified, n.content_hash,
-		        n.content_type, n.review_by, n.confidence, n.access_count
+		        n.content_type, n.review_by, n.confidence, n.access_count,
+		        p.reason, p.pinned_at
 		 FROM vault_notes n
 		 JOIN pinned_notes p ON p.path = n.path
 		 WHERE n.chunk_id = 0
 		   AND UPPER(n.path) NOT LIKE '_PRIVATE/%'
 		   AND UPPER(n.path) NOT LIKE '_PRIVATE\%'
+		   AND n.archived = 0 AND COALESCE(n.suppressed, 0) = 0
 		 ORDER BY p.pinned_at ASC`,
 	)
 	if err != nil {
@@ -91,13 +105,14 @@ func (db *DB) GetPinnedNotes() ([]NoteRecord, error) {
 	}
 	defer rows.Close()
 
-	var records []NoteRecord
+	var records []PinnedNote
 	for rows.Next() {
-		var rec NoteRecord
+		var rec PinnedNote
 		if err := rows.Scan(
 			&rec.ID, &rec.Path, &rec.Title, &rec.Tags, &rec.Domain, &rec.Workstream, &rec.Agent,
 			&rec.ChunkID, &rec.ChunkHeading, &rec.Text, &rec.Modified,
 			&rec.ContentHash, &rec.ContentType, &rec.ReviewBy, &rec.Confidence, &rec.AccessCount,
+			&rec.Reason, &rec.PinnedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scan pinned note: %w", err)
 		}
@@ -106,13 +121,15 @@ func (db *DB) GetPinnedNotes() ([]NoteRecord, error) {
 	return records, nil
 }
 
-// GetLatestHandoff returns the most recently modified handoff note.
+// GetLatestHandoff returns the most recently modified handoff note that
+// isn't archived or suppressed.
 func (db *DB) GetLatestHandoff() (*NoteRecord, error) {
 	row := db.conn.QueryRow(
 		`SELECT id, path, title, tags, domain, workstream, COALESCE(agent, ''), chunk_id, chunk_heading,
 		        text, modified, content_hash, content_type, review_by, confidence, access_count
 		 FROM vault_notes
 		 WHERE content_type = 'handoff'
+		   AND archived = 0 AND COALESCE(suppressed, 0) = 0
 		 ORDER BY modified DESC
 		 LIMIT 1`,
 	)
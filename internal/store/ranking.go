@@ -380,35 +380,39 @@ func RankSearchResults(results []SearchResult, queryTerms []string) []SearchResu
 	return out
 }
 
-// nearDedupRanked collapses versioned copies in the same directory.
-// When two results share a parent directory and one filename (sans .md) is a
-// prefix of the other, keeps only the better one (by title overlap then score).
-func nearDedupRanked(items []rankedResult, queryTerms []string) []rankedResult {
-	type key struct{ dir, base string }
-	pathParts := func(p string) key {
+// IsVersionedCopy reports whether two vault paths look like versioned
+// copies of one note: they share a parent directory and one filename
+// (sans .md, case-insensitive) is a prefix of the other, as with
+// "handoff.md" and "handoff-v2.md".
+func IsVersionedCopy(a, b string) bool {
+	split := func(p string) (string, string) {
 		slash := strings.LastIndex(p, "/")
 		if slash < 0 {
-			return key{"", strings.TrimSuffix(p, ".md")}
+			return "", strings.ToLower(strings.TrimSuffix(p, ".md"))
 		}
-		return key{p[:slash], strings.TrimSuffix(p[slash+1:], ".md")}
+		return p[:slash], strings.ToLower(strings.TrimSuffix(p[slash+1:], ".md"))
+	}
+	dirA, baseA := split(a)
+	dirB, baseB := split(b)
+	if dirA != dirB {
+		return false
 	}
+	return strings.HasPrefix(baseB, baseA) || strings.HasPrefix(baseA, baseB)
+}
 
+// nearDedupRanked collapses versioned copies in the same directory (see
+// IsVersionedCopy), keeping only the better one (by title overlap then score).
+func nearDedupRanked(items []rankedResult, queryTerms []string) []rankedResult {
 	remove := make(map[int]bool)
 	for i := 0; i < len(items); i++ {
 		if remove[i] {
 			continue
 		}
-		ki := pathParts(items[i].result.Path)
 		for j := i + 1; j < len(items); j++ {
 			if remove[j] {
 				continue
 			}
-			kj := pathParts(items[j].result.Path)
-			if ki.dir != kj.dir {
-				continue
-			}
-			li, lj := strings.ToLower(ki.base), strings.ToLower(kj.base)
-			if !strings.HasPrefix(lj, li) && !strings.HasPrefix(li, lj) {
+			if !IsVersionedCopy(items[i].result.Path, items[j].result.Path) {
 				continue
 			}
 			// Near-duplicate found — use full-path overlap to pick winner
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Reindex run bookkeeping kept in schema_meta while a run is unfinished.
+const (
+	reindexStartedMetaKey = "reindex_started_at" // unix seconds
+	reindexRunKeyMetaKey  = "reindex_run_key"    // settings the run indexes with
+)
+
+// BeginReindexRun marks a reindex as started and returns the files an
+// earlier, unfinished run with the same runKey already stored, mapped to the
+// content hash they were stored with. Progress from a run with a different
+// runKey (such as other chunk settings) no longer applies and is discarded.
+func (db *DB) BeginReindexRun(runKey string, now time.Time) (map[string]string, error) {
+	done := make(map[string]string)
+	if _, ok := db.GetMeta(reindexStartedMetaKey); ok {
+		if prevKey, _ := db.GetMeta(reindexRunKeyMetaKey); prevKey == runKey {
+			rows, err := db.conn.Query(`SELECT path, content_hash FROM reindex_progress`)
+			if err != nil {
+				return nil, fmt.Errorf("load reindex progress: %w", err)
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var path, hash string
+				if err := rows.Scan(&path, &hash); err != nil {
+					return nil, fmt.Errorf("scan reindex progress: %w", err)
+				}
+				done[path] = hash
+			}
+			if err := rows.Err(); err != nil {
+				return nil, fmt.Errorf("load reindex progress: %w", err)
+			}
+			return done, nil
+		}
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin reindex run: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.Exec(`DELETE FROM reindex_progress`); err != nil {
+		return nil, fmt.Errorf("clear reindex progress: %w", err)
+	}
+	for key, value := range map[string]string{
+		reindexStartedMetaKey: strconv.FormatInt(now.Unix(), 10),
+		reindexRunKeyMetaKey:  runKey,
+	} {
+		if _, err := tx.Exec(
+			`INSERT INTO schema_meta (key, value) VALUES (?, ?)
+			 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+			key, value,
+		); err != nil {
+			return nil, fmt.Errorf("record reindex run: %w", err)
+		}
+	}
+	return done, tx.Commit()
+}
+
+// MarkFileReindexed records that the current run has stored path with the
+// given content hash.
+func (db *DB) MarkFileReindexed(path, contentHash string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	_, err := db.conn.Exec(
+		`INSERT INTO reindex_progress (path, content_hash, indexed_at) VALUES (?, ?, ?)
+		 ON CONFLICT(path) DO UPDATE SET content_hash = excluded.content_hash, indexed_at = excluded.indexed_at`,
+		path, contentHash, time.Now().Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("mark %s reindexed: %w", path, err)
+	}
+	return nil
+}
+
+// FinishReindexRun clears the progress of a run that completed.
+func (db *DB) FinishReindexRun() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("finish reindex run: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.Exec(`DELETE FROM reindex_progress`); err != nil {
+		return fmt.Errorf("clear reindex progress: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM schema_meta WHERE key IN (?, ?)`, reindexStartedMetaKey, reindexRunKeyMetaKey); err != nil {
+		return fmt.Errorf("clear reindex run: %w", err)
+	}
+	return tx.Commit()
+}
+
+// UnfinishedReindex reports when a reindex that never completed started.
+func (db *DB) UnfinishedReindex() (time.Time, bool) {
+	v, ok := db.GetMeta(reindexStartedMetaKey)
+	if !ok {
+		return time.Time{}, false
+	}
+	ts, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(ts, 0), true
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReindexRunProgress(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	done, err := db.BeginReindexRun("lite:headings:0", now)
+	if err != nil || len(done) != 0 {
+		t.Fatalf("first BeginReindexRun = %v, %v; want empty", done, err)
+	}
+	if started, ok := db.UnfinishedReindex(); !ok || !started.Equal(now) {
+		t.Fatalf("UnfinishedReindex = %v, %v; want %v", started, ok, now)
+	}
+	if err := db.MarkFileReindexed("a.md", "hash-a"); err != nil {
+		t.Fatalf("MarkFileReindexed: %v", err)
+	}
+	if err := db.MarkFileReindexed("a.md", "hash-a2"); err != nil {
+		t.Fatalf("MarkFileReindexed again: %v", err)
+	}
+
+	// Same kind of run: resumes with the latest hash.
+	done, err = db.BeginReindexRun("lite:headings:0", now.Add(time.Hour))
+	if err != nil || len(done) != 1 || done["a.md"] != "hash-a2" {
+		t.Fatalf("resumed BeginReindexRun = %v, %v", done, err)
+	}
+	if started, _ := db.UnfinishedReindex(); !started.Equal(now) {
+		t.Errorf("resuming should keep the original start time, got %v", started)
+	}
+
+	// A different kind of run starts over.
+	done, err = db.BeginReindexRun("full:headings:0", now.Add(2*time.Hour))
+	if err != nil || len(done) != 0 {
+		t.Fatalf("BeginReindexRun with new key = %v, %v; want empty", done, err)
+	}
+
+	if err := db.MarkFileReindexed("b.md", "hash-b"); err != nil {
+		t.Fatalf("MarkFileReindexed: %v", err)
+	}
+	if err := db.FinishReindexRun(); err != nil {
+		t.Fatalf("FinishReindexRun: %v", err)
+	}
+	if _, ok := db.UnfinishedReindex(); ok {
+		t.Error("finished run still reported unfinished")
+	}
+	done, err = db.BeginReindexRun("full:headings:0", now.Add(3*time.Hour))
+	if err != nil || len(done) != 0 {
+		t.Errorf("BeginReindexRun after finish = %v, %v; want empty", done, err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"unicode"
 )
 
 // SearchResult represents a single search result with scoring.
@@ -24,6 +25,11 @@ type SearchResult struct {
 	ContentType  string  `json:"content_type,omitempty"`
 	Confidence   float64 `json:"confidence,omitempty"`
 	TrustState   string  `json:"trust_state,omitempty"`
+
+	// StartLine and EndLine are the chunk's line range in the source file.
+	// Set only by FillLineRanges; zero when unknown.
+	StartLine int `json:"start_line,omitempty"`
+	EndLine   int `json:"end_line,omitempty"`
 }
 
 // SearchOptions configures a vector search.
@@ -36,11 +42,73 @@ type SearchOptions struct {
 	TrustState  string // Filter by trust_state (validated, stale, contradicted, unknown)
 	ContentType string // Filter by content_type (decision, handoff, note, research, etc.)
 
+	// ModifiedAfter and ModifiedBefore bound the note's modified time
+	// (Unix seconds, matching the vault_notes.modified column). Zero means
+	// unbounded on that side. After is inclusive, Before is exclusive.
+	ModifiedAfter  float64
+	ModifiedBefore float64
+
+	// Offset skips this many ranked results before returning TopK of them.
+	// Ranking is computed over the first Offset+TopK candidates so that
+	// consecutive pages of the same query are slices of one ordering.
+	Offset int
+
+	// IncludeArchived keeps notes archived with `same archive` in the
+	// results of VectorSearch, FTS5Search, MetadataFilterSearch and the
+	// searches built on them. The raw searches used by context surfacing
+	// always leave archived notes out.
+	IncludeArchived bool
+
 	// QueryTypeBoosts maps content_type to score multiplier (e.g. {"handoff": 1.3}).
 	// Applied after composite scoring to boost results matching query intent.
 	// Use memory.InferQueryTypeBoost to compute this from the query string.
 	// The special key "_suppress_stale_penalty" suppresses stale trust penalties.
 	QueryTypeBoosts map[string]float64
+
+	// Synonyms expands FTS5Search queries: each query term also matches
+	// the words it maps to. nil disables expansion. See ConfiguredSynonyms.
+	Synonyms map[string][]string
+}
+
+// InModifiedWindow reports whether a note modified at the given Unix time
+// falls inside the ModifiedAfter/ModifiedBefore window.
+func (o SearchOptions) InModifiedWindow(modified float64) bool {
+	if o.ModifiedAfter > 0 && modified < o.ModifiedAfter {
+		return false
+	}
+	if o.ModifiedBefore > 0 && modified >= o.ModifiedBefore {
+		return false
+	}
+	return true
+}
+
+// maxSearchWindow caps Offset+TopK so deep pagination can't force an
+// unbounded KNN fetch.
+const maxSearchWindow = 500
+
+// rankWindow returns the number of ranked candidates needed to serve a page
+// (Offset+TopK), clamping a negative offset to zero.
+func (o *SearchOptions) rankWindow() int {
+	if o.Offset < 0 {
+		o.Offset = 0
+	}
+	window := o.TopK + o.Offset
+	if window > maxSearchWindow {
+		window = maxSearchWindow
+	}
+	return window
+}
+
+// PageResults returns results[offset:offset+limit], clamped to bounds.
+func PageResults[T any](results []T, offset, limit int) []T {
+	if offset >= len(results) {
+		return nil
+	}
+	results = results[offset:]
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
 }
 
 // VectorSearch performs a KNN vector search with optional metadata filtering
@@ -52,6 +120,7 @@ func (db *DB) VectorSearch(queryVec []float32, opts SearchOptions) ([]SearchResu
 	if opts.TopK > 100 {
 		opts.TopK = 100
 	}
+	window := opts.rankWindow()
 
 	vecData, err := serializeFloat32(queryVec)
 	if err != nil {
@@ -59,7 +128,7 @@ func (db *DB) VectorSearch(queryVec []float32, opts SearchOptions) ([]SearchResu
 	}
 
 	// Fetch extra results for deduplication and filtering
-	fetchK := opts.TopK * 5
+	fetchK := window * 5
 
 	rows, err := db.conn.Query(`
 		SELECT v.distance, n.id, n.path, n.title, n.chunk_heading, n.text,
@@ -69,9 +138,9 @@ func (db *DB) VectorSearch(queryVec []float32, opts SearchOptions) ([]SearchResu
 		JOIN vault_notes n ON n.id = v.note_id
 		WHERE v.embedding MATCH ? AND k = ?
 			AND UPPER(n.path) NOT LIKE '_PRIVATE/%%'
-			AND COALESCE(n.suppressed, 0) = 0
+			AND COALESCE(n.suppressed, 0) = 0 AND (? OR n.archived = 0)
 		ORDER BY v.distance`,
-		vecData, fetchK,
+		vecData, fetchK, opts.IncludeArchived,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("vector search: %w", err)
@@ -133,6 +202,9 @@ func (db *DB) VectorSearch(queryVec []float32, opts SearchOptions) ([]SearchResu
 		if opts.ContentType != "" && !strings.EqualFold(r.contentType, opts.ContentType) {
 			continue
 		}
+		if !opts.InModifiedWindow(r.modified) {
+			continue
+		}
 		filtered = append(filtered, r)
 	}
 
@@ -145,7 +217,7 @@ func (db *DB) VectorSearch(queryVec []float32, opts SearchOptions) ([]SearchResu
 		}
 		seen[r.path] = true
 		deduped = append(deduped, r)
-		if len(deduped) >= opts.TopK {
+		if len(deduped) >= window {
 			break
 		}
 	}
@@ -197,10 +269,7 @@ func (db *DB) VectorSearch(queryVec []float32, opts SearchOptions) ([]SearchResu
 			}
 		}
 
-		snippet := r.text
-		if len(snippet) > 500 {
-			snippet = snippet[:500]
-		}
+		snippet := Snippet(r.text, ResultSnippetChars)
 
 		results = append(results, SearchResult{
 			Path:         r.path,
@@ -219,7 +288,7 @@ func (db *DB) VectorSearch(queryVec []float32, opts SearchOptions) ([]SearchResu
 		})
 	}
 
-	return results, nil
+	return PageResults(results, opts.Offset, opts.TopK), nil
 }
 
 // VectorSearchRaw returns raw results with full metadata for composite scoring.
@@ -257,7 +326,7 @@ func (db *DB) VectorSearchRaw(queryVec []float32, fetchK int) ([]RawSearchResult
 		JOIN vault_notes n ON n.id = v.note_id
 		WHERE v.embedding MATCH ? AND k = ?
 			AND UPPER(n.path) NOT LIKE '_PRIVATE/%%'
-			AND COALESCE(n.suppressed, 0) = 0
+			AND COALESCE(n.suppressed, 0) = 0 AND n.archived = 0
 		ORDER BY v.distance`,
 		vecData, fetchK,
 	)
@@ -334,7 +403,7 @@ func (db *DB) KeywordSearch(terms []string, limit int) ([]RawSearchResult, error
 			n.access_count, COALESCE(n.trust_state, 'unknown')
 		FROM vault_notes n
 		WHERE n.chunk_id = 0 AND UPPER(n.path) NOT LIKE '_PRIVATE/%%'
-			AND COALESCE(n.suppressed, 0) = 0 AND EXISTS (
+			AND COALESCE(n.suppressed, 0) = 0 AND n.archived = 0 AND EXISTS (
 			SELECT 1 FROM vault_notes n2
 			WHERE n2.path = n.path AND (%s)
 		)
@@ -419,7 +488,7 @@ func (db *DB) ContentTermSearch(terms []string, minTerms int, limit int) ([]RawS
 		FROM vault_notes n
 		JOIN note_coverage nc ON n.path = nc.path
 		WHERE n.chunk_id = 0 AND UPPER(n.path) NOT LIKE '_PRIVATE/%%'
-			AND COALESCE(n.suppressed, 0) = 0 AND nc.cov >= ?
+			AND COALESCE(n.suppressed, 0) = 0 AND n.archived = 0 AND nc.cov >= ?
 		ORDER BY nc.cov DESC,
 			CAST(nc.chunk_freq * nc.chunk_freq AS REAL) / nc.chunk_count DESC,
 			n.modified DESC
@@ -492,7 +561,7 @@ func (db *DB) KeywordSearchTitleMatch(terms []string, minMatches int, limit int,
 			n.access_count, COALESCE(n.trust_state, 'unknown')
 		FROM vault_notes n
 		WHERE n.chunk_id = 0 AND UPPER(n.path) NOT LIKE '_PRIVATE/%%'
-			AND COALESCE(n.suppressed, 0) = 0 AND (%s) >= ?
+			AND COALESCE(n.suppressed, 0) = 0 AND n.archived = 0 AND (%s) >= ?
 		ORDER BY (%s) DESC, n.modified DESC
 		LIMIT ?`,
 		scoreExpr, scoreExpr)
@@ -522,6 +591,20 @@ func (db *DB) KeywordSearchTitleMatch(terms []string, minMatches int, limit int,
 // Vector results fill most of TopK; keyword-only results are scored by
 // term coverage and interleaved by score so strong title matches rank high.
 func (db *DB) HybridSearch(queryVec []float32, queryText string, opts SearchOptions) ([]SearchResult, error) {
+	// Paginated requests rank the full Offset+TopK window, then slice, so
+	// the keyword merge and re-sorting below see the same candidates as
+	// an unpaginated search for that many results.
+	if opts.Offset > 0 {
+		offset, pageSize := opts.Offset, opts.TopK
+		opts.TopK = opts.rankWindow()
+		opts.Offset = 0
+		results, err := db.HybridSearch(queryVec, queryText, opts)
+		if err != nil {
+			return nil, err
+		}
+		return PageResults(results, offset, pageSize), nil
+	}
+
 	// 1. Vector search (primary)
 	vectorResults, err := db.VectorSearch(queryVec, opts)
 	if err != nil {
@@ -627,6 +710,9 @@ func (db *DB) HybridSearch(queryVec []float32, queryText string, opts SearchOpti
 			if len(opts.Tags) > 0 && !hasTags(r.Tags, opts.Tags) {
 				continue
 			}
+			if !opts.InModifiedWindow(r.Modified) {
+				continue
+			}
 
 			seen[r.Path] = true
 
@@ -679,7 +765,7 @@ func (db *DB) HybridSearch(queryVec []float32, queryText string, opts SearchOpti
 				if filled >= opts.TopK {
 					break
 				}
-				if seen[r.Path] {
+				if seen[r.Path] || !opts.InModifiedWindow(r.Modified) {
 					continue
 				}
 				seen[r.Path] = true
@@ -712,7 +798,7 @@ func (db *DB) HybridSearch(queryVec []float32, queryText string, opts SearchOpti
 	// results whose source notes have matching facts. Facts provide precision
 	// (atomic knowledge) while notes provide recall (full context).
 	if db.HasFacts() && queryVec != nil {
-		merged = db.boostFromFacts(queryVec, merged, opts.TopK)
+		merged = db.boostFromFacts(queryVec, merged, opts)
 	}
 
 	return merged, nil
@@ -722,7 +808,8 @@ func (db *DB) HybridSearch(queryVec []float32, queryText string, opts SearchOpti
 // whose source notes have matching facts. If a fact's source note is already
 // in results, its score gets a small boost. If not, the note is added with
 // a fact-derived score. The fact text is included in the snippet for context.
-func (db *DB) boostFromFacts(queryVec []float32, results []SearchResult, topK int) []SearchResult {
+func (db *DB) boostFromFacts(queryVec []float32, results []SearchResult, opts SearchOptions) []SearchResult {
+	topK := opts.TopK
 	factResults, err := db.SearchFacts(queryVec, 20)
 	if err != nil || len(factResults) == 0 {
 		return results
@@ -769,7 +856,7 @@ func (db *DB) boostFromFacts(queryVec []float32, results []SearchResult, topK in
 
 			// Look up the note's root chunk for display
 			note, err := db.getNoteRootByPath(path)
-			if err != nil || note == nil {
+			if err != nil || note == nil || !opts.InModifiedWindow(note.Modified) {
 				continue
 			}
 
@@ -918,7 +1005,7 @@ func (db *DB) FuzzyTitleSearch(terms []string, limit int) ([]RawSearchResult, er
 			n.domain, n.workstream, COALESCE(n.agent, ''), n.tags, n.content_type, n.confidence, n.modified,
 			n.access_count, COALESCE(n.trust_state, 'unknown')
 		FROM vault_notes n WHERE n.chunk_id = 0 AND UPPER(n.path) NOT LIKE '_PRIVATE/%'
-			AND COALESCE(n.suppressed, 0) = 0
+			AND COALESCE(n.suppressed, 0) = 0 AND n.archived = 0
 		ORDER BY n.modified DESC
 		LIMIT ?`, scanLimit)
 	if err != nil {
@@ -959,6 +1046,98 @@ func (db *DB) FuzzyTitleSearch(terms []string, limit int) ([]RawSearchResult, er
 	return results, rows.Err()
 }
 
+// spellingScanLimit caps the chunks SpellingSuggestions reads words from,
+// most recently modified first.
+const spellingScanLimit = 2000
+
+// SpellingSuggestions returns up to limit rewrites of query with likely
+// typos fixed, for when a search finds nothing. Each search term of at
+// least four letters that appears in no note title or heading is replaced
+// by a title or heading word at edit distance 1, preferring words used by
+// more notes. Returns nil when no term needs or has a correction.
+func (db *DB) SpellingSuggestions(query string, limit int) ([]string, error) {
+	terms := ExtractSearchTerms(query)
+	if len(terms) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT path, title, chunk_heading FROM vault_notes
+		WHERE UPPER(path) NOT LIKE '_PRIVATE/%' AND COALESCE(suppressed, 0) = 0 AND archived = 0
+		ORDER BY modified DESC
+		LIMIT ?`, spellingScanLimit)
+	if err != nil {
+		return nil, fmt.Errorf("spelling vocabulary: %w", err)
+	}
+	defer rows.Close()
+
+	// word -> number of notes using it
+	vocab := make(map[string]int)
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var path, title, heading string
+		if err := rows.Scan(&path, &title, &heading); err != nil {
+			return nil, fmt.Errorf("scan spelling vocabulary: %w", err)
+		}
+		for _, w := range splitTitleWords(strings.ToLower(title + " " + heading)) {
+			w = strings.Trim(w, ".,;:!?\"'()[]{}#*`")
+			if len(w) < 3 || seen[path+"\x00"+w] {
+				continue
+			}
+			seen[path+"\x00"+w] = true
+			vocab[w]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("spelling vocabulary: %w", err)
+	}
+
+	// Candidate corrections per misspelled term, most used first.
+	corrections := make(map[string][]string)
+	for _, term := range terms {
+		if len(term) < 4 || vocab[term] > 0 {
+			continue
+		}
+		var candidates []string
+		for w := range vocab {
+			if editDistance1(term, w) {
+				candidates = append(candidates, w)
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if vocab[candidates[i]] != vocab[candidates[j]] {
+				return vocab[candidates[i]] > vocab[candidates[j]]
+			}
+			return candidates[i] < candidates[j]
+		})
+		if len(candidates) > 0 {
+			corrections[term] = candidates
+		}
+	}
+	if len(corrections) == 0 {
+		return nil, nil
+	}
+
+	// The i-th suggestion uses each term's i-th candidate (or its last).
+	words := strings.Fields(query)
+	var suggestions []string
+	for i := 0; i < limit; i++ {
+		fixed := make([]string, len(words))
+		for k, w := range words {
+			fixed[k] = w
+			if c, ok := corrections[strings.Trim(strings.ToLower(w), ".,;:!?\"'()[]{}")]; ok {
+				fixed[k] = c[min(i, len(c)-1)]
+			}
+		}
+		suggestion := strings.Join(fixed, " ")
+		if containsString(suggestions, suggestion) {
+			break
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
+
 // splitTitleWords splits a title into lowercase words, treating common
 // punctuation as separators.
 func splitTitleWords(title string) []string {
@@ -1094,6 +1273,7 @@ func (db *DB) FTS5Search(query string, opts SearchOptions) ([]SearchResult, erro
 	if opts.TopK <= 0 {
 		opts.TopK = 10
 	}
+	window := opts.rankWindow()
 
 	// FTS5 query: use OR between terms so partial matches are included.
 	// BM25 ranking naturally scores documents with more matching terms higher,
@@ -1103,13 +1283,7 @@ func (db *DB) FTS5Search(query string, opts SearchOptions) ([]SearchResult, erro
 		return nil, nil
 	}
 	// Sanitize terms to prevent FTS5 operator injection
-	sanitizedTerms := make([]string, 0, len(terms))
-	for _, term := range terms {
-		sanitized := sanitizeFTS5Term(term)
-		if sanitized != "" {
-			sanitizedTerms = append(sanitizedTerms, sanitized)
-		}
-	}
+	sanitizedTerms := expandFTS5Terms(terms, opts.Synonyms)
 	if len(sanitizedTerms) == 0 {
 		return nil, nil
 	}
@@ -1122,10 +1296,10 @@ func (db *DB) FTS5Search(query string, opts SearchOptions) ([]SearchResult, erro
 		FROM vault_notes_fts f
 		JOIN vault_notes n ON n.id = f.rowid
 		WHERE vault_notes_fts MATCH ? AND UPPER(n.path) NOT LIKE '_PRIVATE/%%'
-			AND COALESCE(n.suppressed, 0) = 0
+			AND COALESCE(n.suppressed, 0) = 0 AND (? OR n.archived = 0)
 		ORDER BY bm25(vault_notes_fts) ASC
 		LIMIT ?`,
-		ftsQuery, opts.TopK*3,
+		ftsQuery, opts.IncludeArchived, window*3,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("FTS5 search: %w", err)
@@ -1151,9 +1325,7 @@ func (db *DB) FTS5Search(query string, opts SearchOptions) ([]SearchResult, erro
 		}
 		seen[r.Path] = true
 
-		if len(r.Snippet) > 500 {
-			r.Snippet = r.Snippet[:500]
-		}
+		r.Snippet = Snippet(r.Snippet, ResultSnippetChars)
 		r.Score = 0.5 // FTS results get a baseline score
 		// Reconsolidation boost for frequently-accessed notes
 		if accessCount > 0 {
@@ -1184,22 +1356,106 @@ func (db *DB) FTS5Search(query string, opts SearchOptions) ([]SearchResult, erro
 		if opts.ContentType != "" && !strings.EqualFold(r.ContentType, opts.ContentType) {
 			continue
 		}
+		if !opts.InModifiedWindow(modified) {
+			continue
+		}
 
 		results = append(results, r)
-		if len(results) >= opts.TopK {
+		if len(results) >= window {
 			break
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return results, rows.Err()
+	return PageResults(results, opts.Offset, opts.TopK), nil
+}
+
+// rrfK is the reciprocal rank fusion constant. 60 is the value from the
+// original RRF paper; it damps the difference between the top few ranks so
+// one list can't dominate on a single strong hit.
+const rrfK = 60
+
+// minFusionCandidates is how many results each ranking contributes at least,
+// so a note ranked just outside TopK by both can still fuse into it.
+const minFusionCandidates = 20
+
+// FusedSearch runs VectorSearch and FTS5Search for the same query and merges
+// them with reciprocal rank fusion, so exact keyword hits the embedding
+// misses and paraphrases the keywords miss both surface. Unlike
+// HybridSearch, which supplements vector results with title matches, both
+// rankings count equally. Scores are the fused score scaled to 0–1, where 1
+// means ranked first by both. Requires FTS5 and stored vectors.
+func (db *DB) FusedSearch(queryVec []float32, queryText string, opts SearchOptions) ([]SearchResult, error) {
+	if !db.ftsAvailable {
+		return nil, fmt.Errorf("FTS5 not available")
+	}
+	if opts.TopK <= 0 {
+		opts.TopK = 10
+	}
+	offset, pageSize := opts.Offset, opts.TopK
+	candidates := opts
+	candidates.TopK = min(max(opts.rankWindow()*2, minFusionCandidates), maxSearchWindow)
+	candidates.Offset = 0
+
+	vectorResults, err := db.VectorSearch(queryVec, candidates)
+	if err != nil {
+		return nil, err
+	}
+	ftsResults, err := db.FTS5Search(queryText, candidates)
+	if err != nil {
+		return nil, err
+	}
+	return PageResults(fuseRankings(vectorResults, ftsResults), offset, pageSize), nil
+}
+
+// fuseRankings merges ranked lists by reciprocal rank fusion: each note
+// scores the sum of 1/(rrfK+rank) over the lists it appears in. A note's
+// fields come from the first list that has it.
+func fuseRankings(lists ...[]SearchResult) []SearchResult {
+	var fused []SearchResult
+	index := make(map[string]int)
+	scores := make(map[string]float64)
+	for _, list := range lists {
+		for rank, r := range list {
+			if _, ok := index[r.Path]; !ok {
+				index[r.Path] = len(fused)
+				fused = append(fused, r)
+			}
+			scores[r.Path] += 1 / float64(rrfK+rank+1)
+		}
+	}
+	best := float64(len(lists)) / float64(rrfK+1)
+	for i := range fused {
+		fused[i].Score = round3(scores[fused[i].Path] / best)
+	}
+	sort.SliceStable(fused, func(i, j int) bool {
+		return scores[fused[i].Path] > scores[fused[j].Path]
+	})
+	return fused
 }
 
 // FederatedResult extends SearchResult with the source vault name.
+// NormalizedScore is the result's position within its own vault's top
+// federatedScoreWindow candidates, scaled to 0–1: raw distances are not
+// comparable across vaults embedded with different models. Merged results
+// are ordered by the mean of NormalizedScore and the raw Score, so a vault's
+// best hit doesn't outrank strong hits elsewhere just for being first.
+// AlsoIn lists other vaults whose near-identical note was folded into this
+// one.
 type FederatedResult struct {
 	SearchResult
-	Vault string `json:"vault"`
+	Vault           string   `json:"vault"`
+	NormalizedScore float64  `json:"normalized_score"`
+	AlsoIn          []string `json:"also_in,omitempty"`
 }
 
+// federatedScoreWindow is how many of each vault's top candidates
+// NormalizedScore is computed over. It doesn't depend on the page, so a
+// result keeps the same normalized score at any offset.
+const federatedScoreWindow = 50
+
 // MaxFederatedVaults is the maximum number of vaults that can be searched in
 // a single federated search call. Prevents resource exhaustion.
 const MaxFederatedVaults = 50
@@ -1224,10 +1480,14 @@ func FederatedSearch(vaultDBPaths map[string]string, queryVec []float32, queryTe
 		return nil, nil
 	}
 
-	perVaultK := opts.TopK
-	if perVaultK <= 0 {
-		perVaultK = 10
+	pageSize := opts.TopK
+	if opts.TopK <= 0 {
+		opts.TopK = 10
 	}
+	// Each vault contributes a full Offset+TopK window (and at least the
+	// normalization window) so the merged ranking is stable across pages;
+	// the page is sliced after merging.
+	perVaultK := max(opts.rankWindow(), federatedScoreWindow)
 
 	var allResults []FederatedResult
 	var searchErrors []string
@@ -1247,6 +1507,9 @@ func FederatedSearch(vaultDBPaths map[string]string, queryVec []float32, queryTe
 			Workstream:      opts.Workstream,
 			Agent:           opts.Agent,
 			Tags:            opts.Tags,
+			ModifiedAfter:   opts.ModifiedAfter,
+			ModifiedBefore:  opts.ModifiedBefore,
+			IncludeArchived: opts.IncludeArchived,
 			QueryTypeBoosts: opts.QueryTypeBoosts,
 		}
 
@@ -1261,6 +1524,9 @@ func FederatedSearch(vaultDBPaths map[string]string, queryVec []float32, queryTe
 				raw, kwErr := vaultDB.KeywordSearch(terms, vaultOpts.TopK)
 				if kwErr == nil {
 					for _, r := range raw {
+						if !vaultOpts.InModifiedWindow(r.Modified) {
+							continue
+						}
 						results = append(results, RawToSearchResult(r, 0.5))
 					}
 				}
@@ -1282,43 +1548,173 @@ func FederatedSearch(vaultDBPaths map[string]string, queryVec []float32, queryTe
 			continue
 		}
 
-		for _, r := range results {
+		normalized := normalizeVaultScores(results)
+		for i, r := range results {
 			allResults = append(allResults, FederatedResult{
-				SearchResult: r,
-				Vault:        alias,
+				SearchResult:    r,
+				Vault:           alias,
+				NormalizedScore: normalized[i],
 			})
 		}
 	}
 
-	// Sort by score descending, then deduplicate by path+vault
-	sort.Slice(allResults, func(i, j int) bool {
-		return allResults[i].Score > allResults[j].Score
+	sortFederated(allResults)
+	deduped := dedupFederated(allResults)
+
+	// Slice out the requested page
+	deduped = PageResults(deduped, opts.Offset, pageSize)
+
+	// Log any vault-level errors so users can diagnose issues.
+	if len(searchErrors) > 0 {
+		fmt.Fprintf(os.Stderr, "same: federated search: %d vault(s) skipped: %s\n",
+			len(searchErrors), strings.Join(searchErrors, "; "))
+	}
+
+	return deduped, nil
+}
+
+// sortFederated orders merged results by federatedRank, falling back to a
+// stable vault/path order so map iteration doesn't reorder ties.
+func sortFederated(results []FederatedResult) {
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if ra, rb := federatedRank(a), federatedRank(b); ra != rb {
+			return ra > rb
+		}
+		if a.Vault != b.Vault {
+			return a.Vault < b.Vault
+		}
+		return a.Path < b.Path
 	})
+}
+
+// federatedRank blends a result's position within its vault with its raw
+// relevance.
+func federatedRank(r FederatedResult) float64 {
+	return (r.NormalizedScore + r.Score) / 2
+}
+
+// normalizeVaultScores min-max scales one vault's results to 0–1, best = 1,
+// using only the first federatedScoreWindow results for the range; anything
+// ranked below that gets 0. Distance is used when the vault returned vector
+// distances; keyword-only results carry no distance, so their score is used
+// instead. A vault whose results all tie (including a single result) gets 1
+// for each. results must be in the vault's rank order.
+func normalizeVaultScores(results []SearchResult) []float64 {
+	out := make([]float64, len(results))
+	if len(results) == 0 {
+		return out
+	}
+
+	window := results[:min(len(results), federatedScoreWindow)]
+	useDistance := false
+	for _, r := range window {
+		if r.Distance > 0 {
+			useDistance = true
+			break
+		}
+	}
+	value := func(r SearchResult) float64 {
+		if useDistance {
+			return -r.Distance // lower distance is better
+		}
+		return r.Score
+	}
+
+	lo, hi := value(window[0]), value(window[0])
+	for _, r := range window[1:] {
+		v := value(r)
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	for i, r := range results {
+		switch {
+		case i >= len(window):
+			out[i] = 0
+		case hi == lo:
+			out[i] = 1
+		default:
+			out[i] = (value(r) - lo) / (hi - lo)
+		}
+	}
+	return out
+}
+
+// federatedTitleSimilarity is the title token overlap (Jaccard) at which
+// results from different vaults are treated as the same document.
+const federatedTitleSimilarity = 0.8
 
-	// Deduplicate: same path in same vault (shouldn't happen but defensive)
+// dedupFederated drops repeated vault+path entries and folds notes from
+// different vaults whose titles are near-identical into the best-ranked
+// copy, recording the other vaults in AlsoIn. results must already be sorted.
+func dedupFederated(results []FederatedResult) []FederatedResult {
 	seen := make(map[string]bool)
-	var deduped []FederatedResult
-	for _, r := range allResults {
+	var out []FederatedResult
+	var titles [][]string
+	for _, r := range results {
 		key := r.Vault + ":" + r.Path
 		if seen[key] {
 			continue
 		}
 		seen[key] = true
-		deduped = append(deduped, r)
-	}
 
-	// Trim to requested TopK
-	if opts.TopK > 0 && len(deduped) > opts.TopK {
-		deduped = deduped[:opts.TopK]
+		tokens := titleTokens(r.Title)
+		dup := -1
+		for i, kept := range out {
+			if kept.Vault != r.Vault && tokenJaccard(tokens, titles[i]) >= federatedTitleSimilarity {
+				dup = i
+				break
+			}
+		}
+		if dup >= 0 {
+			if !containsString(out[dup].AlsoIn, r.Vault) {
+				out[dup].AlsoIn = append(out[dup].AlsoIn, r.Vault)
+			}
+			continue
+		}
+		out = append(out, r)
+		titles = append(titles, tokens)
 	}
+	return out
+}
 
-	// Log any vault-level errors so users can diagnose issues.
-	if len(searchErrors) > 0 {
-		fmt.Fprintf(os.Stderr, "same: federated search: %d vault(s) skipped: %s\n",
-			len(searchErrors), strings.Join(searchErrors, "; "))
+// titleTokens lowercases a title and splits it into alphanumeric words.
+func titleTokens(title string) []string {
+	return strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func tokenJaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	setA := make(map[string]bool, len(a))
+	for _, t := range a {
+		setA[t] = true
+	}
+	setB := make(map[string]bool, len(b))
+	inter := 0
+	for _, t := range b {
+		if setB[t] {
+			continue
+		}
+		setB[t] = true
+		if setA[t] {
+			inter++
+		}
 	}
+	union := len(setA) + len(setB) - inter
+	return float64(inter) / float64(union)
+}
 
-	return deduped, nil
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
 
 // MetadataFilterSearch finds notes matching metadata filters without requiring
@@ -1338,6 +1734,9 @@ func (db *DB) MetadataFilterSearch(opts SearchOptions) ([]SearchResult, error) {
 	conditions = append(conditions, "n.chunk_id = 0")
 	conditions = append(conditions, "UPPER(n.path) NOT LIKE '_PRIVATE/%%'")
 	conditions = append(conditions, "COALESCE(n.suppressed, 0) = 0")
+	if !opts.IncludeArchived {
+		conditions = append(conditions, "n.archived = 0")
+	}
 
 	if opts.TrustState != "" {
 		conditions = append(conditions, "LOWER(COALESCE(n.trust_state, 'unknown')) = LOWER(?)")
@@ -1359,6 +1758,14 @@ func (db *DB) MetadataFilterSearch(opts SearchOptions) ([]SearchResult, error) {
 		conditions = append(conditions, "LOWER(COALESCE(n.agent, '')) = LOWER(?)")
 		args = append(args, opts.Agent)
 	}
+	if opts.ModifiedAfter > 0 {
+		conditions = append(conditions, "n.modified >= ?")
+		args = append(args, opts.ModifiedAfter)
+	}
+	if opts.ModifiedBefore > 0 {
+		conditions = append(conditions, "n.modified < ?")
+		args = append(args, opts.ModifiedBefore)
+	}
 
 	args = append(args, opts.TopK)
 
@@ -1394,9 +1801,7 @@ func (db *DB) MetadataFilterSearch(opts SearchOptions) ([]SearchResult, error) {
 			continue
 		}
 
-		if len(r.Snippet) > 500 {
-			r.Snippet = r.Snippet[:500]
-		}
+		r.Snippet = Snippet(r.Snippet, ResultSnippetChars)
 		r.Score = 0
 		r.Distance = 0
 
@@ -1410,14 +1815,11 @@ func (db *DB) MetadataFilterSearch(opts SearchOptions) ([]SearchResult, error) {
 }
 
 // RawToSearchResult converts a RawSearchResult into a SearchResult with the
-// given score. Snippets are truncated to 500 characters. Confidence is rounded.
+// given score. Snippets are cut to ResultSnippetChars by Snippet. Confidence is rounded.
 // Use this instead of manually constructing SearchResult from raw results to
 // avoid missing fields (e.g. TrustState, Agent, Workstream).
 func RawToSearchResult(r RawSearchResult, score float64) SearchResult {
-	snippet := r.Text
-	if len(snippet) > 500 {
-		snippet = snippet[:500]
-	}
+	snippet := Snippet(r.Text, ResultSnippetChars)
 	return SearchResult{
 		Path:         r.Path,
 		Title:        r.Title,
@@ -1435,6 +1837,52 @@ func RawToSearchResult(r RawSearchResult, score float64) SearchResult {
 	}
 }
 
+// FillLineRanges sets StartLine/EndLine on each result from the chunk it
+// came from. Results carry only path and heading, so the chunk is matched by
+// those and the start of its snippet; if no chunk's text matches, the first
+// chunk under the heading is used. Chunks indexed before line ranges were
+// recorded leave the result at zero.
+func (db *DB) FillLineRanges(results []SearchResult) error {
+	for i := range results {
+		r := &results[i]
+		rows, err := db.conn.Query(
+			`SELECT text, start_line, end_line FROM vault_notes
+			 WHERE path = ? AND chunk_heading = ?
+			 ORDER BY chunk_id`,
+			r.Path, r.ChunkHeading,
+		)
+		if err != nil {
+			return fmt.Errorf("line ranges for %s: %w", r.Path, err)
+		}
+		prefix := r.Snippet
+		if len(prefix) > 200 {
+			prefix = prefix[:200]
+		}
+		first := true
+		for rows.Next() {
+			var text string
+			var start, end int
+			if err := rows.Scan(&text, &start, &end); err != nil {
+				rows.Close()
+				return fmt.Errorf("scan line range: %w", err)
+			}
+			match := strings.HasPrefix(text, prefix)
+			if first || match {
+				r.StartLine, r.EndLine = start, end
+			}
+			if match {
+				break
+			}
+			first = false
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("line ranges for %s: %w", r.Path, err)
+		}
+	}
+	return nil
+}
+
 func round3(f float64) float64 {
 	return float64(int(f*1000+0.5)) / 1000
 }
@@ -175,7 +175,7 @@ func TestSearchAndNoteReadsHandleNullAgent(t *testing.T) {
 		t.Fatalf("expected empty note agent for NULL value, got %q", notes[0].Agent)
 	}
 
-	if err := db.PinNote(note.Path); err != nil {
+	if err := db.PinNote(note.Path, ""); err != nil {
 		t.Fatalf("PinNote: %v", err)
 	}
 	pinned, err := db.GetPinnedNotes()
@@ -1472,3 +1472,500 @@ func TestHybridSearch_FusedScoresCappedForBodyExactMatch(t *testing.T) {
 		t.Fatalf("expected the stronger semantic result to stay first, got %s", results[0].Path)
 	}
 }
+
+func TestSearchOptions_ModifiedWindow(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	vec := make([]float32, 768)
+	notes := []NoteRecord{
+		{
+			Path: "notes/old.md", Title: "Old", Tags: `[]`,
+			ChunkID: 0, ChunkHeading: "(full)",
+			Text:     "window-term old content",
+			Modified: 1700000000, ContentHash: "ho", ContentType: "note", Confidence: 0.5,
+		},
+		{
+			Path: "notes/mid.md", Title: "Mid", Tags: `[]`,
+			ChunkID: 0, ChunkHeading: "(full)",
+			Text:     "window-term mid content",
+			Modified: 1700100000, ContentHash: "hm", ContentType: "note", Confidence: 0.5,
+		},
+		{
+			Path: "notes/new.md", Title: "New", Tags: `[]`,
+			ChunkID: 0, ChunkHeading: "(full)",
+			Text:     "window-term new content",
+			Modified: 1700200000, ContentHash: "hn", ContentType: "note", Confidence: 0.5,
+		},
+	}
+	for i := range notes {
+		if err := db.InsertNote(&notes[i], vec); err != nil {
+			t.Fatalf("InsertNote %d: %v", i, err)
+		}
+	}
+
+	opts := SearchOptions{TopK: 10, ModifiedAfter: 1700050000, ModifiedBefore: 1700200000}
+
+	vecResults, err := db.VectorSearch(vec, opts)
+	if err != nil {
+		t.Fatalf("VectorSearch: %v", err)
+	}
+	if len(vecResults) != 1 || vecResults[0].Path != "notes/mid.md" {
+		t.Errorf("VectorSearch window: expected only mid.md, got %+v", vecResults)
+	}
+
+	if db.FTSAvailable() {
+		ftsResults, err := db.FTS5Search("window-term", opts)
+		if err != nil {
+			t.Fatalf("FTS5Search: %v", err)
+		}
+		if len(ftsResults) != 1 || ftsResults[0].Path != "notes/mid.md" {
+			t.Errorf("FTS5Search window: expected only mid.md, got %+v", ftsResults)
+		}
+	}
+
+	metaResults, err := db.MetadataFilterSearch(SearchOptions{ModifiedAfter: 1700050000})
+	if err != nil {
+		t.Fatalf("MetadataFilterSearch: %v", err)
+	}
+	if len(metaResults) != 2 {
+		t.Errorf("MetadataFilterSearch after: expected 2 results, got %d", len(metaResults))
+	}
+}
+
+func TestSearchOptions_OffsetPaging(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	for i := 0; i < 8; i++ {
+		vec := make([]float32, 768)
+		vec[0] = float32(i) // increasing distance from the zero query vector
+		rec := NoteRecord{
+			Path: fmt.Sprintf("notes/page-%d.md", i), Title: fmt.Sprintf("Page %d", i), Tags: `[]`,
+			ChunkID: 0, ChunkHeading: "(full)",
+			Text:     "paging-term content",
+			Modified: float64(1700000000 + i), ContentHash: fmt.Sprintf("h%d", i), ContentType: "note", Confidence: 0.5,
+		}
+		if err := db.InsertNote(&rec, vec); err != nil {
+			t.Fatalf("InsertNote %d: %v", i, err)
+		}
+	}
+
+	query := make([]float32, 768)
+	all, err := db.VectorSearch(query, SearchOptions{TopK: 6})
+	if err != nil {
+		t.Fatalf("VectorSearch: %v", err)
+	}
+	page2, err := db.VectorSearch(query, SearchOptions{TopK: 3, Offset: 3})
+	if err != nil {
+		t.Fatalf("VectorSearch offset: %v", err)
+	}
+	if len(page2) != 3 {
+		t.Fatalf("expected 3 results on page 2, got %d", len(page2))
+	}
+	for i, r := range page2 {
+		if r.Path != all[3+i].Path {
+			t.Errorf("page 2 result %d = %s, want %s", i, r.Path, all[3+i].Path)
+		}
+	}
+
+	past, err := db.VectorSearch(query, SearchOptions{TopK: 3, Offset: 50})
+	if err != nil {
+		t.Fatalf("VectorSearch past end: %v", err)
+	}
+	if len(past) != 0 {
+		t.Errorf("expected no results past the end, got %d", len(past))
+	}
+
+	if db.FTSAvailable() {
+		ftsAll, err := db.FTS5Search("paging-term", SearchOptions{TopK: 8})
+		if err != nil {
+			t.Fatalf("FTS5Search: %v", err)
+		}
+		ftsPage, err := db.FTS5Search("paging-term", SearchOptions{TopK: 2, Offset: 4})
+		if err != nil {
+			t.Fatalf("FTS5Search offset: %v", err)
+		}
+		if len(ftsPage) != 2 || ftsPage[0].Path != ftsAll[4].Path || ftsPage[1].Path != ftsAll[5].Path {
+			t.Errorf("FTS5 page mismatch: got %+v", ftsPage)
+		}
+	}
+}
+
+func TestPageResults(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5}
+	if got := PageResults(in, 1, 2); len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Errorf("PageResults(1,2) = %v", got)
+	}
+	if got := PageResults(in, 4, 10); len(got) != 1 || got[0] != 5 {
+		t.Errorf("PageResults(4,10) = %v", got)
+	}
+	if got := PageResults(in, 5, 2); got != nil {
+		t.Errorf("PageResults past end = %v, want nil", got)
+	}
+	if got := PageResults(in, 0, 0); len(got) != 5 {
+		t.Errorf("PageResults with no limit = %v", got)
+	}
+}
+
+func TestVectorSearch_OverlappingChunksCollapse(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	// Two overlapping chunks of the same note both match the query; the
+	// shared passage must only surface once.
+	shared := "the retry budget is three attempts"
+	for i, text := range []string{"intro text. " + shared, shared + ". more detail"} {
+		vec := make([]float32, 768)
+		vec[0] = float32(i) * 0.1
+		rec := NoteRecord{
+			Path: "notes/long.md", Title: "Long", Tags: `[]`,
+			ChunkID: i, ChunkHeading: fmt.Sprintf("(part %d)", i+1),
+			Text: text, Modified: 1700000000, ContentHash: "long", ContentType: "note", Confidence: 0.5,
+		}
+		if err := db.InsertNote(&rec, vec); err != nil {
+			t.Fatalf("InsertNote %d: %v", i, err)
+		}
+	}
+
+	results, err := db.VectorSearch(make([]float32, 768), SearchOptions{TopK: 5})
+	if err != nil {
+		t.Fatalf("VectorSearch: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected overlapping chunks to collapse to 1 result, got %d", len(results))
+	}
+	if results[0].ChunkHeading != "(part 1)" {
+		t.Errorf("expected the closest chunk to win, got %q", results[0].ChunkHeading)
+	}
+}
+
+func TestNormalizeVaultScores(t *testing.T) {
+	byDistance := normalizeVaultScores([]SearchResult{
+		{Distance: 10, Score: 0.9},
+		{Distance: 20, Score: 0.8},
+		{Distance: 30, Score: 0.1},
+	})
+	want := []float64{1, 0.5, 0}
+	for i := range want {
+		if d := byDistance[i] - want[i]; d > 1e-9 || d < -1e-9 {
+			t.Errorf("distance-normalized[%d] = %v, want %v", i, byDistance[i], want[i])
+		}
+	}
+
+	// Keyword results have no distance; fall back to score.
+	byScore := normalizeVaultScores([]SearchResult{{Score: 0.2}, {Score: 0.6}})
+	if byScore[0] != 0 || byScore[1] != 1 {
+		t.Errorf("score-normalized = %v, want [0 1]", byScore)
+	}
+
+	single := normalizeVaultScores([]SearchResult{{Distance: 42}})
+	if single[0] != 1 {
+		t.Errorf("single result normalized = %v, want 1", single[0])
+	}
+}
+
+func TestDedupFederated_FoldsMatchingTitlesAcrossVaults(t *testing.T) {
+	in := []FederatedResult{
+		{SearchResult: SearchResult{Path: "a.md", Title: "Authentication Design"}, Vault: "dev", NormalizedScore: 1},
+		{SearchResult: SearchResult{Path: "b.md", Title: "authentication design"}, Vault: "mirror", NormalizedScore: 0.9},
+		{SearchResult: SearchResult{Path: "c.md", Title: "Authentication Design"}, Vault: "dev", NormalizedScore: 0.8},
+		{SearchResult: SearchResult{Path: "d.md", Title: "Authentication Messaging"}, Vault: "marketing", NormalizedScore: 0.7},
+		{SearchResult: SearchResult{Path: "a.md", Title: "Authentication Design"}, Vault: "dev", NormalizedScore: 0.6},
+	}
+	out := dedupFederated(in)
+
+	if len(out) != 3 {
+		t.Fatalf("expected 3 results, got %d: %+v", len(out), out)
+	}
+	if out[0].Vault != "dev" || out[0].Path != "a.md" {
+		t.Errorf("expected best copy kept first, got %s:%s", out[0].Vault, out[0].Path)
+	}
+	if len(out[0].AlsoIn) != 1 || out[0].AlsoIn[0] != "mirror" {
+		t.Errorf("AlsoIn = %v, want [mirror]", out[0].AlsoIn)
+	}
+	// Same title within one vault is a different note, not a duplicate.
+	if out[1].Path != "c.md" {
+		t.Errorf("expected same-vault note with same title to be kept, got %s", out[1].Path)
+	}
+	if out[2].Vault != "marketing" {
+		t.Errorf("expected dissimilar title to be kept, got %s", out[2].Vault)
+	}
+}
+
+func TestFederatedSearch_NormalizesAndDedups(t *testing.T) {
+	note := func(path, title, text string) NoteRecord {
+		return NoteRecord{
+			Path: path, Title: title, Tags: "[]", ChunkID: 0, ChunkHeading: "(full)",
+			Text: text, Modified: 1700000000, ContentHash: path, ContentType: "note", Confidence: 0.5,
+		}
+	}
+	devDB := createTestVaultDB(t, "dev", []NoteRecord{
+		note("notes/auth.md", "Authentication Design", "JWT-based authentication with refresh tokens."),
+	})
+	mirrorDB := createTestVaultDB(t, "mirror", []NoteRecord{
+		note("copied/auth.md", "Authentication Design", "JWT-based authentication with refresh tokens."),
+		note("copied/ops.md", "Ops Runbook", "Rotate authentication keys quarterly."),
+	})
+
+	results, err := FederatedSearch(map[string]string{"dev": devDB, "mirror": mirrorDB}, nil, "authentication", SearchOptions{TopK: 10})
+	if err != nil {
+		t.Fatalf("FederatedSearch: %v", err)
+	}
+
+	designs := 0
+	for _, r := range results {
+		if r.NormalizedScore < 0 || r.NormalizedScore > 1 {
+			t.Errorf("normalized score out of range for %s:%s: %v", r.Vault, r.Path, r.NormalizedScore)
+		}
+		if r.Title == "Authentication Design" {
+			designs++
+			if len(r.AlsoIn) != 1 {
+				t.Errorf("expected duplicate vault recorded in AlsoIn, got %v", r.AlsoIn)
+			}
+		}
+	}
+	if designs != 1 {
+		t.Errorf("expected duplicate note listed once, got %d copies", designs)
+	}
+}
+
+func TestFuseRankings(t *testing.T) {
+	vector := []SearchResult{{Path: "a.md", Distance: 1}, {Path: "b.md"}, {Path: "c.md"}}
+	fts := []SearchResult{{Path: "c.md"}, {Path: "d.md"}, {Path: "a.md", Distance: 9}}
+
+	fused := fuseRankings(vector, fts)
+	var order []string
+	for _, r := range fused {
+		order = append(order, r.Path)
+	}
+	// a and c are in both lists and beat the single-list hits; a ranks 1st
+	// and 3rd, c 3rd and 1st, so they tie and keep first-seen order.
+	if got := strings.Join(order, ","); got != "a.md,c.md,b.md,d.md" {
+		t.Fatalf("fused order = %s", got)
+	}
+	if fused[0].Distance != 1 {
+		t.Errorf("fields should come from the first list, got distance %v", fused[0].Distance)
+	}
+	if fused[0].Score <= fused[2].Score || fused[0].Score > 1 {
+		t.Errorf("scores = %v, %v", fused[0].Score, fused[2].Score)
+	}
+	if top := fuseRankings([]SearchResult{{Path: "x.md"}}, []SearchResult{{Path: "x.md"}}); top[0].Score != 1 {
+		t.Errorf("first in every list should score 1, got %v", top[0].Score)
+	}
+}
+
+func TestFusedSearch(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+	if !db.FTSAvailable() {
+		if _, err := db.FusedSearch(nil, "query", SearchOptions{TopK: 5}); err == nil {
+			t.Error("expected error when FTS5 not available")
+		}
+		return
+	}
+
+	dims, err := db.VectorTableDims()
+	if err != nil {
+		t.Fatalf("VectorTableDims: %v", err)
+	}
+	vecAt := func(i int) []float32 {
+		v := make([]float32, dims)
+		v[i] = 1
+		return v
+	}
+	query := vecAt(0)
+	notes := []struct {
+		path, text string
+		vec        []float32
+	}{
+		{"semantic.md", "A note about login flows.", vecAt(0)},
+		{"keyword.md", "The zephyrquux rollout checklist.", vecAt(5)},
+		{"unrelated.md", "Gardening tips.", vecAt(9)},
+	}
+	for _, n := range notes {
+		rec := &NoteRecord{Path: n.path, Title: n.path, Tags: "[]", ChunkHeading: "(full)", Text: n.text, ContentType: "note", Confidence: 0.5}
+		if err := db.InsertNote(rec, n.vec); err != nil {
+			t.Fatalf("InsertNote: %v", err)
+		}
+	}
+	if err := db.RebuildFTS(); err != nil {
+		t.Fatalf("RebuildFTS: %v", err)
+	}
+
+	results, err := db.FusedSearch(query, "zephyrquux", SearchOptions{TopK: 2})
+	if err != nil {
+		t.Fatalf("FusedSearch: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, r := range results {
+		got[r.Path] = true
+	}
+	if len(results) != 2 || !got["semantic.md"] || !got["keyword.md"] {
+		t.Fatalf("FusedSearch = %+v; want the vector hit and the keyword hit", results)
+	}
+}
+
+func TestExpandFTS5Terms(t *testing.T) {
+	table := SynonymTable([][]string{{"k8s", "cluster"}})
+	got := expandFTS5Terms([]string{"login", "k8s"}, table)
+	want := []string{"login", "k8s", "signin", `"sign in"`, "authentication", "auth", "kubernetes", "cluster"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("expandFTS5Terms = %q, want %q", got, want)
+	}
+	if got := expandFTS5Terms([]string{"login"}, nil); len(got) != 1 {
+		t.Errorf("without synonyms = %q, want just the term", got)
+	}
+}
+
+func TestFTS5Search_Synonyms(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+	if !db.FTSAvailable() {
+		t.Skip("FTS5 not available")
+	}
+	if _, err := db.BulkInsertNotesLite([]NoteRecord{{
+		Path: "auth.md", Title: "Auth", Tags: "[]", ChunkHeading: "(full)", ContentType: "note",
+		Text: "We settled on OAuth for authentication.",
+	}}); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+	if err := db.RebuildFTS(); err != nil {
+		t.Fatalf("RebuildFTS: %v", err)
+	}
+
+	if results, _ := db.FTS5Search("login", SearchOptions{TopK: 5}); len(results) != 0 {
+		t.Fatalf("without synonyms, login matched %+v", results)
+	}
+	results, err := db.FTS5Search("login", SearchOptions{TopK: 5, Synonyms: SynonymTable(nil)})
+	if err != nil {
+		t.Fatalf("FTS5Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Path != "auth.md" {
+		t.Errorf("with synonyms = %+v, want auth.md", results)
+	}
+}
+
+func TestSpellingSuggestions(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+	var notes []NoteRecord
+	for _, n := range []struct{ path, title string }{
+		{"a.md", "Authentication design"},
+		{"b.md", "Authentication rollout"},
+		{"c.md", "Authenticator app notes"},
+		{"_PRIVATE/x.md", "Deployment secrets"},
+	} {
+		notes = append(notes, NoteRecord{Path: n.path, Title: n.title, Tags: "[]", ChunkHeading: "(full)", ContentType: "note"})
+	}
+	if _, err := db.BulkInsertNotesLite(notes); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+
+	got, err := db.SpellingSuggestions("autentication design", 3)
+	if err != nil {
+		t.Fatalf("SpellingSuggestions: %v", err)
+	}
+	if len(got) != 1 || got[0] != "authentication design" {
+		t.Errorf("suggestions = %q, want [authentication design]", got)
+	}
+
+	if got, _ := db.SpellingSuggestions("authentication design", 3); got != nil {
+		t.Errorf("correctly spelled query got suggestions %q", got)
+	}
+	if got, _ := db.SpellingSuggestions("deploymen", 3); got != nil {
+		t.Errorf("private titles leaked into suggestions: %q", got)
+	}
+}
+
+func TestNormalizeVaultScores_FixedWindow(t *testing.T) {
+	results := make([]SearchResult, federatedScoreWindow+5)
+	for i := range results {
+		results[i] = SearchResult{Distance: float64(i + 1)}
+	}
+	norm := normalizeVaultScores(results)
+	if norm[0] != 1 || norm[federatedScoreWindow-1] != 0 {
+		t.Errorf("window endpoints = %v, %v; want 1, 0", norm[0], norm[federatedScoreWindow-1])
+	}
+	for i := federatedScoreWindow; i < len(norm); i++ {
+		if norm[i] != 0 {
+			t.Errorf("result %d below the window normalized to %v, want 0", i, norm[i])
+		}
+	}
+	// Fetching more candidates must not change scores inside the window.
+	shorter := normalizeVaultScores(results[:federatedScoreWindow])
+	for i := range shorter {
+		if shorter[i] != norm[i] {
+			t.Fatalf("normalized[%d] = %v with a longer fetch, %v without", i, norm[i], shorter[i])
+		}
+	}
+}
+
+func TestSortFederated_KeepsRawRelevance(t *testing.T) {
+	results := []FederatedResult{
+		// Best hit of a vault with only weak matches.
+		{SearchResult: SearchResult{Path: "weak.md", Score: 0.2}, Vault: "a", NormalizedScore: 1},
+		// Second hit of a vault with strong matches.
+		{SearchResult: SearchResult{Path: "strong.md", Score: 0.9}, Vault: "b", NormalizedScore: 0.8},
+	}
+	sortFederated(results)
+	if results[0].Path != "strong.md" {
+		t.Errorf("expected the strong hit first, got %s", results[0].Path)
+	}
+}
+
+func TestFederatedSearch_NormalizedScoreStableAcrossPages(t *testing.T) {
+	var notes []NoteRecord
+	for i, text := range []string{
+		"authentication authentication authentication tokens",
+		"authentication tokens and sessions",
+		"sessions mention authentication once",
+		"authentication",
+	} {
+		path := fmt.Sprintf("notes/n%d.md", i)
+		notes = append(notes, NoteRecord{
+			Path: path, Title: fmt.Sprintf("Note %d", i), Tags: "[]", ChunkID: 0, ChunkHeading: "(full)",
+			Text: text, Modified: 1700000000, ContentHash: path, ContentType: "note", Confidence: 0.5,
+		})
+	}
+	dbPath := createTestVaultDB(t, "dev", notes)
+	vaults := map[string]string{"dev": dbPath}
+
+	full, err := FederatedSearch(vaults, nil, "authentication", SearchOptions{TopK: 10})
+	if err != nil {
+		t.Fatalf("FederatedSearch: %v", err)
+	}
+	if len(full) < 2 {
+		t.Fatalf("expected several results, got %d", len(full))
+	}
+	for i, want := range full {
+		page, err := FederatedSearch(vaults, nil, "authentication", SearchOptions{TopK: 1, Offset: i})
+		if err != nil {
+			t.Fatalf("FederatedSearch offset %d: %v", i, err)
+		}
+		if len(page) != 1 || page[0].Path != want.Path {
+			t.Fatalf("offset %d = %+v, want %s", i, page, want.Path)
+		}
+		if page[0].NormalizedScore != want.NormalizedScore {
+			t.Errorf("%s normalized to %v on page %d, %v on the full list", want.Path, page[0].NormalizedScore, i, want.NormalizedScore)
+		}
+	}
+}
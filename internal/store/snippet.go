@@ -0,0 +1,149 @@
+package store
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ResultSnippetChars is the length search results cut their snippets to.
+const ResultSnippetChars = 500
+
+// Snippet prepares note text for display: SnippetSource, then cut to at
+// most maxLen bytes without ending inside a fenced code block.
+func Snippet(text string, maxLen int) string {
+	text = SnippetSource(text)
+	if len(text) <= maxLen {
+		return text
+	}
+	return FenceSafePrefix(text, maxLen)
+}
+
+// SnippetSource drops what shouldn't open a preview: a leading YAML
+// frontmatter block (left in a note's text when the indexer couldn't parse
+// it) and, when a chunk was split inside a code block, the code before the
+// stray closing fence.
+func SnippetSource(text string) string {
+	text = stripFrontmatter(text)
+	if end, ok := strayClosingFence(text); ok {
+		text = strings.TrimLeft(text[end:], "\n")
+	}
+	return text
+}
+
+// FenceSafePrefix returns text cut to at most n bytes. A cut that would
+// land inside a fenced code block moves back to before the block when
+// enough prose precedes it; otherwise the block is cut and closed so the
+// snippet stays valid Markdown. Never splits a UTF-8 sequence.
+func FenceSafePrefix(text string, n int) string {
+	if n >= len(text) {
+		return text
+	}
+	if n < 0 {
+		n = 0
+	}
+	for _, f := range fencedRanges(text) {
+		if n <= f.start || n >= f.end {
+			continue
+		}
+		if f.start >= n/3 {
+			return strings.TrimRight(text[:f.start], " \n")
+		}
+		const closing = "\n" + "```"
+		if cut := n - len(closing); cut > f.start {
+			return strings.TrimRight(runeSafePrefix(text, cut), " \n") + closing
+		}
+		break
+	}
+	return runeSafePrefix(text, n)
+}
+
+// FenceStart returns the start of the fenced code block containing byte
+// offset i, or i itself when it is outside any block.
+func FenceStart(text string, i int) int {
+	for _, f := range fencedRanges(text) {
+		if i > f.start && i < f.end {
+			return f.start
+		}
+	}
+	return i
+}
+
+func runeSafePrefix(text string, n int) string {
+	for n > 0 && n < len(text) && !utf8.RuneStart(text[n]) {
+		n--
+	}
+	return text[:n]
+}
+
+// stripFrontmatter removes a leading "---" delimited block.
+func stripFrontmatter(text string) string {
+	first, rest, ok := strings.Cut(strings.TrimPrefix(text, "\uFEFF"), "\n")
+	if !ok || strings.TrimSpace(first) != "---" {
+		return text
+	}
+	for {
+		line, after, found := strings.Cut(rest, "\n")
+		if t := strings.TrimSpace(line); t == "---" || t == "..." {
+			return strings.TrimLeft(after, "\r\n")
+		}
+		if !found {
+			return text
+		}
+		rest = after
+	}
+}
+
+// fence is a fenced code block's byte range, from the start of the opening
+// fence line to the end of the closing one. An unclosed block runs to the
+// end of the text.
+type fence struct{ start, end int }
+
+func fencedRanges(text string) []fence {
+	var fences []fence
+	open := -1
+	var marker string
+	for lineStart := 0; lineStart < len(text); {
+		lineEnd := len(text)
+		if nl := strings.IndexByte(text[lineStart:], '\n'); nl >= 0 {
+			lineEnd = lineStart + nl
+		}
+		line := strings.TrimSpace(text[lineStart:lineEnd])
+		switch {
+		case open < 0 && (strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~")):
+			open, marker = lineStart, line[:3]
+		case open >= 0 && strings.HasPrefix(line, marker) && strings.Trim(line, marker[:1]) == "":
+			fences = append(fences, fence{open, lineEnd})
+			open = -1
+		}
+		lineStart = lineEnd + 1
+	}
+	if open >= 0 {
+		fences = append(fences, fence{open, len(text)})
+	}
+	return fences
+}
+
+// strayClosingFence reports whether text opens inside a code block: it has
+// an odd number of fence lines and the first one is bare (no language tag),
+// which reads as a closing fence. Returns the offset just past that line.
+func strayClosingFence(text string) (int, bool) {
+	count, first, firstEnd := 0, "", 0
+	for lineStart := 0; lineStart < len(text); {
+		lineEnd := len(text)
+		if nl := strings.IndexByte(text[lineStart:], '\n'); nl >= 0 {
+			lineEnd = lineStart + nl
+		}
+		line := strings.TrimSpace(text[lineStart:lineEnd])
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			if count == 0 {
+				first, firstEnd = line, lineEnd
+			}
+			count++
+		}
+		lineStart = lineEnd + 1
+	}
+	if count%2 == 0 || strings.Trim(first, first[:1]) != "" {
+		return 0, false
+	}
+	return firstEnd, true
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnippetSource(t *testing.T) {
+	cases := []struct{ name, in, want string }{
+		{"frontmatter", "---\ntitle: x\n---\nBody.", "Body."},
+		{"unclosed frontmatter kept", "---\ntitle: x\nBody.", "---\ntitle: x\nBody."},
+		{"plain", "Body.", "Body."},
+		{"starts inside code", "\tx := 1\n}\n```\n\nProse after.", "Prose after."},
+		{"balanced code kept", "```sh\nls\n```\nProse.", "```sh\nls\n```\nProse."},
+		{"opening fence at end kept", "Prose.\n```go\nfunc", "Prose.\n```go\nfunc"},
+	}
+	for _, tc := range cases {
+		if got := SnippetSource(tc.in); got != tc.want {
+			t.Errorf("%s: SnippetSource = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFenceSafePrefix(t *testing.T) {
+	prose := strings.Repeat("Prose sentence. ", 5)
+	text := prose + "\n```go\nfunc main() {\n\tprintln(\"hi\")\n}\n```\nMore."
+
+	// Enough prose before the block: cut before it.
+	if got := FenceSafePrefix(text, len(prose)+15); got != strings.TrimSpace(prose) {
+		t.Errorf("cut inside block = %q, want the prose only", got)
+	}
+
+	// Block near the start: keep part of it and close the fence.
+	short := "Intro.\n```go\nfunc main() {\n\tprintln(\"hi\")\n}\n```\n"
+	got := FenceSafePrefix(short, 30)
+	if !strings.HasSuffix(got, "\n```") || len(got) > 30 {
+		t.Errorf("cut inside early block = %q, want a closed fence within 30 bytes", got)
+	}
+
+	// Never splits a multi-byte rune.
+	if got := FenceSafePrefix("héllo", 2); got != "h" {
+		t.Errorf("rune-safe cut = %q, want %q", got, "h")
+	}
+}
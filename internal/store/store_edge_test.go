@@ -1061,7 +1061,7 @@ func TestGetStaleNotes_TrimsToMaxResults(t *testing.T) {
 	if err != nil {
 		t.Fatalf("GetStaleNotes: %v", err)
 	}
-	if len(results) > 3 {
-		t.Errorf("expected at most 3 results, got %d", len(results))
+	if len(results) != 3 {
+		t.Errorf("expected 3 results, got %d", len(results))
 	}
 }
@@ -343,7 +343,7 @@ func TestPinsCRUD(t *testing.T) {
 	defer db.Close()
 
 	// Pin a note
-	if err := db.PinNote("notes/important.md"); err != nil {
+	if err := db.PinNote("notes/important.md", ""); err != nil {
 		t.Fatalf("PinNote: %v", err)
 	}
 
@@ -445,8 +445,8 @@ func TestSchemaVersion(t *testing.T) {
 
 	// After migrate(), version should be 11 (through facts table migration).
 	v := db.SchemaVersion()
-	if v != 11 {
-		t.Errorf("expected schema version 11, got %d", v)
+	if v != 21 {
+		t.Errorf("expected schema version 21, got %d", v)
 	}
 }
 
@@ -900,7 +900,7 @@ func TestGetPinnedNotes(t *testing.T) {
 	}
 
 	// Pin the note
-	if err := db.PinNote("notes/pinned.md"); err != nil {
+	if err := db.PinNote("notes/pinned.md", ""); err != nil {
 		t.Fatalf("PinNote: %v", err)
 	}
 
@@ -920,6 +920,54 @@ func TestGetPinnedNotes(t *testing.T) {
 	}
 }
 
+func TestPinNote_Reason(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	rec := &NoteRecord{
+		Path: "notes/api.md", Title: "API Rules", Tags: "[]", ChunkID: 0,
+		ChunkHeading: "(full)", Text: "rules", Modified: 1700000000,
+		ContentHash: "hash1", ContentType: "note", Confidence: 0.5,
+	}
+	if err := db.InsertNote(rec, make([]float32, 768)); err != nil {
+		t.Fatalf("InsertNote: %v", err)
+	}
+
+	if err := db.PinNote("notes/api.md", "every agent needs these"); err != nil {
+		t.Fatalf("PinNote: %v", err)
+	}
+	pinned, err := db.GetPinnedNotes()
+	if err != nil || len(pinned) != 1 {
+		t.Fatalf("GetPinnedNotes: %v (%d)", err, len(pinned))
+	}
+	if pinned[0].Reason != "every agent needs these" {
+		t.Errorf("reason = %q", pinned[0].Reason)
+	}
+	if pinned[0].PinnedAt == 0 {
+		t.Error("expected pinned_at to be set")
+	}
+
+	// Re-pinning without a reason keeps the existing one.
+	if err := db.PinNote("notes/api.md", ""); err != nil {
+		t.Fatalf("PinNote: %v", err)
+	}
+	pinned, _ = db.GetPinnedNotes()
+	if pinned[0].Reason != "every agent needs these" {
+		t.Errorf("reason after re-pin = %q, want it kept", pinned[0].Reason)
+	}
+
+	if err := db.PinNote("notes/api.md", "superseded by style guide"); err != nil {
+		t.Fatalf("PinNote: %v", err)
+	}
+	pinned, _ = db.GetPinnedNotes()
+	if pinned[0].Reason != "superseded by style guide" {
+		t.Errorf("reason after update = %q", pinned[0].Reason)
+	}
+}
+
 func TestGetLatestHandoff(t *testing.T) {
 	db, err := OpenMemory()
 	if err != nil {
@@ -1707,3 +1755,206 @@ func TestUnsuppressNote_NonExistent(t *testing.T) {
 
 // Suppress unused import warnings
 var _ = math.Pi
+
+func TestPruneOrphanedVectors(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	vec := make([]float32, 768)
+	vec[0] = 1.0
+	for _, path := range []string{"notes/keep.md", "notes/orphan.md"} {
+		rec := &NoteRecord{
+			Path: path, Title: path, Tags: "[]", ChunkID: 0,
+			ChunkHeading: "(full)", Text: "content", Modified: 1700000000,
+			ContentHash: path, ContentType: "note", Confidence: 0.5,
+		}
+		if err := db.InsertNote(rec, vec); err != nil {
+			t.Fatalf("InsertNote: %v", err)
+		}
+	}
+
+	// Remove the note row directly, leaving its vector behind.
+	if _, err := db.Conn().Exec("DELETE FROM vault_notes WHERE path = ?", "notes/orphan.md"); err != nil {
+		t.Fatalf("delete note row: %v", err)
+	}
+
+	n, err := db.PruneOrphanedVectors()
+	if err != nil {
+		t.Fatalf("PruneOrphanedVectors: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("pruned %d vectors, want 1", n)
+	}
+
+	var remaining int
+	if err := db.Conn().QueryRow("SELECT COUNT(*) FROM vault_notes_vec").Scan(&remaining); err != nil {
+		t.Fatalf("count vectors: %v", err)
+	}
+	if remaining != 1 {
+		t.Errorf("expected 1 vector left, got %d", remaining)
+	}
+}
+
+func TestVectorDimensionHelpers(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	dims, err := db.VectorTableDims()
+	if err != nil {
+		t.Fatalf("VectorTableDims: %v", err)
+	}
+	if dims != 768 {
+		t.Fatalf("VectorTableDims = %d, want 768", dims)
+	}
+
+	vec := make([]float32, dims)
+	vec[0] = 1.0
+	rec := &NoteRecord{
+		Path: "notes/a.md", Title: "A", Tags: "[]", ChunkID: 0,
+		ChunkHeading: "(full)", Text: "content", Modified: 1700000000,
+		ContentHash: "a", ContentType: "note", Confidence: 0.5,
+	}
+	if err := db.InsertNote(rec, vec); err != nil {
+		t.Fatalf("InsertNote: %v", err)
+	}
+
+	counts, err := db.VectorDimensionCounts()
+	if err != nil {
+		t.Fatalf("VectorDimensionCounts: %v", err)
+	}
+	if len(counts) != 1 || counts[768] != 1 {
+		t.Errorf("VectorDimensionCounts = %v, want map[768:1]", counts)
+	}
+
+	ids, err := db.NoteIDsWithVectorLengthNot(768)
+	if err != nil {
+		t.Fatalf("NoteIDsWithVectorLengthNot: %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("expected no mismatched ids, got %v", ids)
+	}
+	ids, err = db.NoteIDsWithVectorLengthNot(1024)
+	if err != nil {
+		t.Fatalf("NoteIDsWithVectorLengthNot: %v", err)
+	}
+	if len(ids) != 1 {
+		t.Fatalf("expected 1 mismatched id against 1024 dims, got %v", ids)
+	}
+
+	if err := db.DeleteEmbeddingForNote(ids[0]); err != nil {
+		t.Fatalf("DeleteEmbeddingForNote: %v", err)
+	}
+	if db.HasVectors() {
+		t.Error("expected vector to be deleted")
+	}
+}
+
+func TestFTS5DeleteAndUpsertNote(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+	if !db.FTSAvailable() {
+		t.Skip("FTS5 not available")
+	}
+
+	insert := func(path, text string) {
+		t.Helper()
+		rec := NoteRecord{
+			Path: path, Title: path, Tags: "[]", ChunkID: 0,
+			ChunkHeading: "(full)", Text: text, Modified: 1700000000,
+			ContentHash: text, ContentType: "note", Confidence: 0.5,
+		}
+		if _, err := db.BulkInsertNotesLite([]NoteRecord{rec}); err != nil {
+			t.Fatalf("insert %s: %v", path, err)
+		}
+		if err := db.FTS5UpsertNote(path); err != nil {
+			t.Fatalf("FTS5UpsertNote: %v", err)
+		}
+	}
+	hits := func(term string) int {
+		t.Helper()
+		res, err := db.FTS5Search(term, SearchOptions{TopK: 10})
+		if err != nil {
+			t.Fatalf("FTS5Search: %v", err)
+		}
+		return len(res)
+	}
+
+	insert("notes/gone.md", "zebracorn migration plan")
+	if hits("zebracorn") != 1 {
+		t.Fatalf("expected inserted note to be keyword-searchable")
+	}
+
+	if err := db.FTS5DeleteNote("notes/gone.md"); err != nil {
+		t.Fatalf("FTS5DeleteNote: %v", err)
+	}
+	if err := db.DeleteByPath("notes/gone.md"); err != nil {
+		t.Fatalf("DeleteByPath: %v", err)
+	}
+	// A new note may reuse the deleted rowid; stale tokens must not match it.
+	insert("notes/new.md", "unrelated content")
+
+	if n := hits("zebracorn"); n != 0 {
+		t.Errorf("expected deleted note to vanish from FTS5Search, got %d hits", n)
+	}
+	if n := hits("unrelated"); n != 1 {
+		t.Errorf("expected new note to be searchable, got %d hits", n)
+	}
+}
+
+func TestFeedbackLog_HistoryAndPendingBoost(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	entries := []FeedbackEntry{
+		{Path: "a.md", Direction: FeedbackUp, OldConfidence: 0.5, NewConfidence: 0.7, AccessBoost: 5},
+		{Path: "b.md", Direction: FeedbackDown, OldConfidence: 0.5, NewConfidence: 0.2},
+		{Path: "a.md", Direction: FeedbackReset, OldConfidence: 0.7, NewConfidence: 0.5, AccessBoost: -5},
+		{Path: "a.md", Direction: FeedbackUp, OldConfidence: 0.5, NewConfidence: 0.7, AccessBoost: 5},
+	}
+	for _, e := range entries {
+		if err := db.RecordFeedback(e); err != nil {
+			t.Fatalf("RecordFeedback: %v", err)
+		}
+	}
+
+	all, err := db.FeedbackHistory("", 0)
+	if err != nil {
+		t.Fatalf("FeedbackHistory: %v", err)
+	}
+	if len(all) != 4 || all[0].Direction != FeedbackUp || all[0].Path != "a.md" || all[3].Direction != FeedbackUp {
+		t.Fatalf("history should be newest first, got %+v", all)
+	}
+	if all[0].CreatedAt == 0 {
+		t.Error("expected created_at to be set")
+	}
+
+	onlyB, _ := db.FeedbackHistory("b.md", 0)
+	if len(onlyB) != 1 || onlyB[0].Direction != FeedbackDown {
+		t.Errorf("path filter = %+v", onlyB)
+	}
+	limited, _ := db.FeedbackHistory("", 2)
+	if len(limited) != 2 {
+		t.Errorf("limit 2 returned %d entries", len(limited))
+	}
+
+	// Only the boost after the reset is still applied.
+	boost, err := db.PendingFeedbackBoost("a.md")
+	if err != nil {
+		t.Fatalf("PendingFeedbackBoost: %v", err)
+	}
+	if boost != 5 {
+		t.Errorf("pending boost = %d, want 5", boost)
+	}
+}
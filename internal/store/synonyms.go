@@ -0,0 +1,88 @@
+package store
+
+import (
+	"strings"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
+)
+
+// builtinSynonymGroups are words that name the same thing often enough in
+// engineering notes that a keyword search for one should find the others.
+// Kept short on purpose: every entry widens every matching query.
+var builtinSynonymGroups = [][]string{
+	{"login", "signin", "sign in", "authentication", "auth"},
+	{"logout", "signout", "sign out"},
+	{"authorization", "permissions", "access control"},
+	{"password", "passphrase", "credentials"},
+	{"bug", "defect"},
+	{"error", "failure", "exception"},
+	{"deploy", "deployment", "release", "rollout"},
+	{"database", "db"},
+	{"config", "configuration", "settings"},
+	{"docs", "documentation"},
+	{"repo", "repository"},
+	{"kubernetes", "k8s"},
+	{"javascript", "js"},
+	{"typescript", "ts"},
+	{"performance", "perf", "latency"},
+	{"meeting", "standup", "sync"},
+	{"decision", "adr"},
+	{"todo", "task"},
+	{"api", "endpoint"},
+	{"env", "environment"},
+	{"fix", "patch", "hotfix"},
+	{"delete", "remove"},
+}
+
+// SynonymTable maps each word in the built-in groups and in extra to the
+// other words in its groups. A word in several groups gets all of them.
+func SynonymTable(extra [][]string) map[string][]string {
+	table := make(map[string][]string)
+	groups := append(append([][]string{}, builtinSynonymGroups...), extra...)
+	for _, g := range groups {
+		for _, w := range g {
+			for _, other := range g {
+				if other != w && !containsString(table[w], other) {
+					table[w] = append(table[w], other)
+				}
+			}
+		}
+	}
+	return table
+}
+
+// ConfiguredSynonyms returns the synonym table to put in
+// SearchOptions.Synonyms, or nil when [search] synonyms is off.
+func ConfiguredSynonyms() map[string][]string {
+	if !config.SearchSynonymsEnabled() {
+		return nil
+	}
+	return SynonymTable(config.SearchSynonymGroups())
+}
+
+// expandFTS5Terms returns the sanitized FTS5 terms for a query's terms plus
+// their synonyms, without duplicates. Multi-word synonyms become phrases.
+func expandFTS5Terms(terms []string, synonyms map[string][]string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	add := func(term string) {
+		sanitized := strings.Join(strings.Fields(sanitizeFTS5Term(term)), " ")
+		if sanitized == "" || seen[sanitized] {
+			return
+		}
+		seen[sanitized] = true
+		if strings.Contains(sanitized, " ") {
+			sanitized = `"` + sanitized + `"`
+		}
+		out = append(out, sanitized)
+	}
+	for _, t := range terms {
+		add(t)
+	}
+	for _, t := range terms {
+		for _, syn := range synonyms[t] {
+			add(syn)
+		}
+	}
+	return out
+}
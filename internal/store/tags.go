@@ -0,0 +1,109 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TagCount is a tag and the number of notes carrying it.
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// TagCounts returns every tag in the index with the number of notes that
+// carry it, sorted by count (descending) then tag name. Tags are compared
+// case-insensitively, matching the tag filter in search, and reported in
+// lowercase. If prefix is non-empty only tags starting with it are returned.
+// SECURITY: Excludes _PRIVATE/ content from counts.
+func (db *DB) TagCounts(prefix string) ([]TagCount, error) {
+	rows, err := db.conn.Query(`
+		SELECT tags FROM vault_notes
+		WHERE chunk_id = 0 AND UPPER(path) NOT LIKE '_PRIVATE/%'
+			AND tags != '' AND tags != '[]'`)
+	if err != nil {
+		return nil, fmt.Errorf("tag counts: %w", err)
+	}
+	defer rows.Close()
+
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	counts := make(map[string]int)
+	for rows.Next() {
+		var tagsJSON string
+		if err := rows.Scan(&tagsJSON); err != nil {
+			return nil, fmt.Errorf("scan tags: %w", err)
+		}
+		// Count each tag once per note even if the frontmatter repeats it.
+		noteTags := make(map[string]bool)
+		for _, t := range ParseTags(tagsJSON) {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t == "" || noteTags[t] {
+				continue
+			}
+			if prefix != "" && !strings.HasPrefix(t, prefix) {
+				continue
+			}
+			noteTags[t] = true
+			counts[t]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for tag, n := range counts {
+		result = append(result, TagCount{Tag: tag, Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Tag < result[j].Tag
+	})
+	return result, nil
+}
+
+// ValueCount is a metadata value and the number of notes carrying it.
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// DomainCounts returns the distinct non-empty domain values with note counts.
+// SECURITY: Excludes _PRIVATE/ content from counts.
+func (db *DB) DomainCounts() ([]ValueCount, error) {
+	return db.metadataValueCounts("domain")
+}
+
+// WorkstreamCounts returns the distinct non-empty workstream values with note
+// counts. SECURITY: Excludes _PRIVATE/ content from counts.
+func (db *DB) WorkstreamCounts() ([]ValueCount, error) {
+	return db.metadataValueCounts("workstream")
+}
+
+// metadataValueCounts groups root chunks by a metadata column. column must be
+// a trusted identifier (never user input).
+func (db *DB) metadataValueCounts(column string) ([]ValueCount, error) {
+	rows, err := db.conn.Query(fmt.Sprintf(`
+		SELECT %[1]s, COUNT(*) FROM vault_notes
+		WHERE chunk_id = 0 AND UPPER(path) NOT LIKE '_PRIVATE/%%'
+			AND COALESCE(%[1]s, '') != ''
+		GROUP BY %[1]s
+		ORDER BY COUNT(*) DESC, %[1]s ASC`, column))
+	if err != nil {
+		return nil, fmt.Errorf("%s counts: %w", column, err)
+	}
+	defer rows.Close()
+
+	var result []ValueCount
+	for rows.Next() {
+		var vc ValueCount
+		if err := rows.Scan(&vc.Value, &vc.Count); err != nil {
+			return nil, fmt.Errorf("scan %s counts: %w", column, err)
+		}
+		result = append(result, vc)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,78 @@
+package store
+
+import "testing"
+
+func TestTagCounts(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	notes := []NoteRecord{
+		{Path: "a.md", Title: "A", Tags: `["auth", "team/backend"]`, ChunkID: 0, ChunkHeading: "(full)", Text: "a", Modified: 1, ContentHash: "a"},
+		{Path: "a.md", Title: "A", Tags: `["auth", "team/backend"]`, ChunkID: 1, ChunkHeading: "More", Text: "a2", Modified: 1, ContentHash: "a"},
+		{Path: "b.md", Title: "B", Tags: `["Auth", "auth", "team/frontend"]`, ChunkID: 0, ChunkHeading: "(full)", Text: "b", Modified: 1, ContentHash: "b"},
+		{Path: "c.md", Title: "C", Tags: `[]`, ChunkID: 0, ChunkHeading: "(full)", Text: "c", Modified: 1, ContentHash: "c"},
+		{Path: "_PRIVATE/secret.md", Title: "S", Tags: `["secret", "auth"]`, ChunkID: 0, ChunkHeading: "(full)", Text: "s", Modified: 1, ContentHash: "s"},
+	}
+	if _, err := db.BulkInsertNotesLite(notes); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+
+	counts, err := db.TagCounts("")
+	if err != nil {
+		t.Fatalf("TagCounts: %v", err)
+	}
+	want := []TagCount{{"auth", 2}, {"team/backend", 1}, {"team/frontend", 1}}
+	if len(counts) != len(want) {
+		t.Fatalf("TagCounts = %+v, want %+v", counts, want)
+	}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("TagCounts[%d] = %+v, want %+v", i, counts[i], want[i])
+		}
+	}
+
+	prefixed, err := db.TagCounts("Team/")
+	if err != nil {
+		t.Fatalf("TagCounts prefix: %v", err)
+	}
+	if len(prefixed) != 2 {
+		t.Fatalf("expected 2 team/ tags, got %+v", prefixed)
+	}
+}
+
+func TestDomainAndWorkstreamCounts(t *testing.T) {
+	db, err := OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	defer db.Close()
+
+	notes := []NoteRecord{
+		{Path: "a.md", Title: "A", Tags: `[]`, Domain: "engineering", Workstream: "api", ChunkID: 0, ChunkHeading: "(full)", Text: "a", Modified: 1, ContentHash: "a"},
+		{Path: "b.md", Title: "B", Tags: `[]`, Domain: "engineering", ChunkID: 0, ChunkHeading: "(full)", Text: "b", Modified: 1, ContentHash: "b"},
+		{Path: "c.md", Title: "C", Tags: `[]`, Domain: "product", Workstream: "api", ChunkID: 0, ChunkHeading: "(full)", Text: "c", Modified: 1, ContentHash: "c"},
+		{Path: "_PRIVATE/d.md", Title: "D", Tags: `[]`, Domain: "secret", Workstream: "hidden", ChunkID: 0, ChunkHeading: "(full)", Text: "d", Modified: 1, ContentHash: "d"},
+	}
+	if _, err := db.BulkInsertNotesLite(notes); err != nil {
+		t.Fatalf("BulkInsertNotesLite: %v", err)
+	}
+
+	domains, err := db.DomainCounts()
+	if err != nil {
+		t.Fatalf("DomainCounts: %v", err)
+	}
+	if len(domains) != 2 || domains[0] != (ValueCount{"engineering", 2}) || domains[1] != (ValueCount{"product", 1}) {
+		t.Errorf("DomainCounts = %+v", domains)
+	}
+
+	workstreams, err := db.WorkstreamCounts()
+	if err != nil {
+		t.Fatalf("WorkstreamCounts: %v", err)
+	}
+	if len(workstreams) != 1 || workstreams[0] != (ValueCount{"api", 2}) {
+		t.Errorf("WorkstreamCounts = %+v", workstreams)
+	}
+}
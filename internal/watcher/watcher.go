@@ -19,13 +19,22 @@ import (
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
+// Options controls optional watcher behavior.
+type Options struct {
+	// ReloadConfig also watches .same/config.toml and reloads settings
+	// (skip dirs, noise paths, profile and display mode) when it changes.
+	ReloadConfig bool
+}
+
 // Watch starts watching the vault for changes and reindexes modified files.
 // It blocks until the context is done or an unrecoverable error occurs.
-func Watch(ctx context.Context, db *store.DB) error {
+func Watch(ctx context.Context, db *store.DB, opts Options) error {
 	vaultPath := config.VaultPath()
+	configPath := config.ConfigFilePath(vaultPath)
 
-	// Load .sameignore patterns once at watcher startup
-	ignorePatterns := indexer.LoadSameignore(vaultPath)
+	// .sameignore rules, including nested files. Cached entries are
+	// invalidated when a .sameignore changes.
+	ignorePatterns := indexer.LoadVaultIgnore(vaultPath)
 
 	w, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -41,7 +50,18 @@ func Watch(ctx context.Context, db *store.DB) error {
 		}
 	}
 
+	// The .same directory is skipped by the walk above. Watch the directory
+	// rather than the file so editors that save by rename are still seen.
+	if opts.ReloadConfig {
+		if err := w.Add(filepath.Dir(configPath)); err != nil {
+			fmt.Fprintf(os.Stderr, "  [WARN] Could not watch config %s: %v\n", configPath, err)
+		}
+	}
+
 	fmt.Fprintf(os.Stderr, "Watching %d directories in %s\n", len(dirs), vaultPath)
+	if opts.ReloadConfig {
+		fmt.Fprintf(os.Stderr, "Reloading settings when %s changes\n", configPath)
+	}
 	fmt.Fprintf(os.Stderr, "Press Ctrl+C to stop.\n\n")
 
 	// Debounce: collect changed files over a window before reindexing
@@ -49,6 +69,8 @@ func Watch(ctx context.Context, db *store.DB) error {
 		mu      sync.Mutex
 		pending = make(map[string]bool)
 		timer   *time.Timer
+
+		configTimer *time.Timer
 	)
 
 	const debounceDelay = 2 * time.Second
@@ -70,6 +92,21 @@ func Watch(ctx context.Context, db *store.DB) error {
 		reindexFiles(db, paths, vaultPath)
 	}
 
+	reload := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		summary, err := reloadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  [WARN] Config reload failed, keeping previous settings: %v\n", err)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "  Config reloaded (%s)\n", summary)
+		// skip_dirs may have shrunk; pick up any directories now in scope.
+		for _, d := range walkDirsWithIgnore(vaultPath, ignorePatterns) {
+			_ = w.Add(d)
+		}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -77,6 +114,9 @@ func Watch(ctx context.Context, db *store.DB) error {
 			if timer != nil {
 				timer.Stop()
 			}
+			if configTimer != nil {
+				configTimer.Stop()
+			}
 			mu.Unlock()
 			flush()
 			return nil
@@ -85,6 +125,26 @@ func Watch(ctx context.Context, db *store.DB) error {
 				return nil
 			}
 
+			if opts.ReloadConfig && isConfigEvent(event, configPath) {
+				mu.Lock()
+				if configTimer != nil {
+					configTimer.Stop()
+				}
+				configTimer = time.AfterFunc(debounceDelay, reload)
+				mu.Unlock()
+				continue
+			}
+
+			if filepath.Base(event.Name) == ".sameignore" {
+				ignorePatterns.Invalidate(relativePath(filepath.Dir(event.Name), vaultPath))
+				// Directories may have been un-ignored; Add is a no-op for
+				// directories already watched.
+				for _, d := range walkDirsWithIgnore(vaultPath, ignorePatterns) {
+					_ = w.Add(d)
+				}
+				continue
+			}
+
 			// Only care about markdown files (skip meta-docs)
 			if !strings.HasSuffix(event.Name, ".md") || config.SkipFiles[filepath.Base(event.Name)] {
 				// But watch new directories
@@ -97,11 +157,8 @@ func Watch(ctx context.Context, db *store.DB) error {
 			}
 
 			// Check .sameignore patterns
-			if ignorePatterns != nil {
-				relPath := relativePath(event.Name, vaultPath)
-				if ignorePatterns.ShouldIgnore(relPath, false) {
-					continue
-				}
+			if ignorePatterns.ShouldIgnore(relativePath(event.Name, vaultPath), false) {
+				continue
 			}
 
 			if event.Has(fsnotify.Rename) {
@@ -133,6 +190,29 @@ func Watch(ctx context.Context, db *store.DB) error {
 	}
 }
 
+// isConfigEvent reports whether event changed the vault config file.
+func isConfigEvent(event fsnotify.Event, configPath string) bool {
+	if filepath.Clean(event.Name) != filepath.Clean(configPath) {
+		return false
+	}
+	return event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename)
+}
+
+// reloadConfig re-reads the config file and reapplies the settings that are
+// cached in process-wide state. Everything else (thresholds, profile, display
+// mode, embedding provider) is read from config on each use, so a successful
+// parse is enough for it to take effect. Returns a short summary for the log.
+func reloadConfig() (string, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	// Rebuild unconditionally so removed skip_dirs entries stop applying.
+	config.RebuildSkipDirs(cfg.Vault.SkipDirs)
+	store.NoisePaths = config.NoisePaths()
+	return fmt.Sprintf("profile: %s, display: %s", config.CurrentProfile(), config.DisplayMode()), nil
+}
+
 func shouldWatchDir(path string) bool {
 	info, err := os.Lstat(path)
 	if err != nil {
@@ -155,6 +235,7 @@ func reindexFiles(db *store.DB, paths []string, vaultPath string) {
 		APIKey:     ec.APIKey,
 		BaseURL:    ec.BaseURL,
 		Dimensions: ec.Dimensions,
+		Timeout:    ec.RequestTimeout(),
 		SkipRetry:  !config.IsEmbeddingProviderExplicit(),
 	}
 	// For ollama provider, use the legacy [ollama] URL if no base_url is set.
@@ -194,6 +275,9 @@ func reindexFiles(db *store.DB, paths []string, vaultPath string) {
 		if info.IsDir() {
 			continue
 		}
+		if indexer.FileUnchanged(db, fp, relPath) {
+			continue
+		}
 
 		var err error
 		if liteMode {
@@ -216,6 +300,9 @@ func reindexFiles(db *store.DB, paths []string, vaultPath string) {
 
 func removeFromIndex(db *store.DB, absPath, vaultPath string) {
 	relPath := relativePath(absPath, vaultPath)
+	if err := db.FTS5DeleteNote(relPath); err != nil {
+		fmt.Fprintf(os.Stderr, "  [WARN] keyword index %s: %v\n", relPath, err)
+	}
 	if err := db.DeleteByPath(relPath); err != nil {
 		fmt.Fprintf(os.Stderr, "  [ERROR] remove %s: %v\n", relPath, err)
 		return
@@ -227,7 +314,7 @@ func walkDirs(root string) []string {
 	return walkDirsWithIgnore(root, nil)
 }
 
-func walkDirsWithIgnore(root string, ip *indexer.IgnorePatterns) []string {
+func walkDirsWithIgnore(root string, ip *indexer.VaultIgnore) []string {
 	var dirs []string
 	if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
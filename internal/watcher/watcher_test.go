@@ -5,7 +5,11 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/sgx-labs/statelessagent/internal/config"
 	"github.com/sgx-labs/statelessagent/internal/store"
 )
 
@@ -109,6 +113,99 @@ func TestReindexFiles_MissingPathDeletesIndexedEntry(t *testing.T) {
 	}
 }
 
+func TestReindexFiles_DeletedFileLeavesKeywordIndex(t *testing.T) {
+	t.Setenv("SAME_EMBED_PROVIDER", "none")
+
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatalf("open memory db: %v", err)
+	}
+	defer db.Close()
+	if !db.FTSAvailable() {
+		t.Skip("FTS5 not available")
+	}
+
+	vault := t.TempDir()
+	mkdirAll(t, filepath.Join(vault, "notes"))
+	abs := filepath.Join(vault, "notes", "doomed.md")
+	if err := os.WriteFile(abs, []byte("# Doomed\n\nquokkafish retrospective notes\n"), 0o644); err != nil {
+		t.Fatalf("write note: %v", err)
+	}
+
+	reindexFiles(db, []string{abs}, vault)
+	res, err := db.FTS5Search("quokkafish", store.SearchOptions{TopK: 5})
+	if err != nil {
+		t.Fatalf("FTS5Search: %v", err)
+	}
+	if len(res) != 1 {
+		t.Fatalf("expected indexed note in keyword search, got %d results", len(res))
+	}
+
+	if err := os.Remove(abs); err != nil {
+		t.Fatalf("remove note: %v", err)
+	}
+	reindexFiles(db, []string{abs}, vault)
+
+	res, err = db.FTS5Search("quokkafish", store.SearchOptions{TopK: 5})
+	if err != nil {
+		t.Fatalf("FTS5Search: %v", err)
+	}
+	if len(res) != 0 {
+		t.Fatalf("expected deleted note to vanish from keyword search, got %d results", len(res))
+	}
+	var ftsRows int
+	if err := db.Conn().QueryRow("SELECT COUNT(*) FROM vault_notes_fts WHERE vault_notes_fts MATCH 'quokkafish'").Scan(&ftsRows); err != nil {
+		t.Fatalf("count fts rows: %v", err)
+	}
+	if ftsRows != 0 {
+		t.Fatalf("expected no FTS rows for deleted note, got %d", ftsRows)
+	}
+}
+
+func TestReindexFiles_SkipsUnchangedContent(t *testing.T) {
+	t.Setenv("SAME_EMBED_PROVIDER", "none")
+
+	db, err := store.OpenMemory()
+	if err != nil {
+		t.Fatalf("open memory db: %v", err)
+	}
+	defer db.Close()
+
+	vault := t.TempDir()
+	abs := filepath.Join(vault, "synced.md")
+	if err := os.WriteFile(abs, []byte("---\ntags: [sync]\n---\n# Synced\n\nbody\n"), 0o644); err != nil {
+		t.Fatalf("write note: %v", err)
+	}
+	rootID := func() int64 {
+		t.Helper()
+		var id int64
+		if err := db.Conn().QueryRow("SELECT id FROM vault_notes WHERE path = 'synced.md' AND chunk_id = 0").Scan(&id); err != nil {
+			t.Fatalf("root id: %v", err)
+		}
+		return id
+	}
+
+	reindexFiles(db, []string{abs}, vault)
+	first := rootID()
+
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(abs, later, later); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	reindexFiles(db, []string{abs}, vault)
+	if got := rootID(); got != first {
+		t.Errorf("touched file was re-indexed (row %d -> %d)", first, got)
+	}
+
+	if err := os.WriteFile(abs, []byte("---\ntags: [sync]\n---\n# Synced\n\nedited body\n"), 0o644); err != nil {
+		t.Fatalf("rewrite note: %v", err)
+	}
+	reindexFiles(db, []string{abs}, vault)
+	if got := rootID(); got == first {
+		t.Error("edited file was not re-indexed")
+	}
+}
+
 func TestShouldWatchDir_SkipsSymlinkDirectories(t *testing.T) {
 	root := t.TempDir()
 	realDir := filepath.Join(root, "notes")
@@ -132,6 +229,69 @@ func TestShouldWatchDir_SkipsSymlinkDirectories(t *testing.T) {
 	}
 }
 
+func TestIsConfigEvent_MatchesOnlyConfigFile(t *testing.T) {
+	vault := t.TempDir()
+	cfgPath := filepath.Join(vault, ".same", "config.toml")
+
+	if !isConfigEvent(fsnotify.Event{Name: cfgPath, Op: fsnotify.Write}, cfgPath) {
+		t.Fatalf("expected write to config.toml to match")
+	}
+	if !isConfigEvent(fsnotify.Event{Name: cfgPath, Op: fsnotify.Create}, cfgPath) {
+		t.Fatalf("expected create of config.toml (editor rename-save) to match")
+	}
+	if isConfigEvent(fsnotify.Event{Name: cfgPath, Op: fsnotify.Chmod}, cfgPath) {
+		t.Fatalf("expected chmod to be ignored")
+	}
+	other := filepath.Join(vault, ".same", "data", "vault.db")
+	if isConfigEvent(fsnotify.Event{Name: other, Op: fsnotify.Write}, cfgPath) {
+		t.Fatalf("expected database writes to be ignored")
+	}
+}
+
+func TestReloadConfig_ReappliesSkipDirs(t *testing.T) {
+	vault := t.TempDir()
+	mkdirAll(t, filepath.Join(vault, ".same"))
+	mkdirAll(t, filepath.Join(vault, "drafts"))
+	config.VaultOverride = vault
+	t.Cleanup(func() {
+		config.VaultOverride = ""
+		config.RebuildSkipDirs(nil)
+	})
+
+	cfgPath := config.ConfigFilePath(vault)
+	writeConfig := func(body string) {
+		t.Helper()
+		if err := os.WriteFile(cfgPath, []byte(body), 0o600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+
+	writeConfig("[vault]\nskip_dirs = [\"drafts\"]\n")
+	if _, err := reloadConfig(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if !config.SkipDirs["drafts"] {
+		t.Fatalf("expected drafts to be skipped after reload")
+	}
+
+	writeConfig("[display]\nmode = \"compact\"\n")
+	summary, err := reloadConfig()
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if config.SkipDirs["drafts"] {
+		t.Fatalf("expected drafts to be watched again once removed from skip_dirs")
+	}
+	if !strings.Contains(summary, "display: compact") {
+		t.Fatalf("summary = %q, want display mode", summary)
+	}
+
+	writeConfig("[vault\n")
+	if _, err := reloadConfig(); err == nil {
+		t.Fatalf("expected parse error for invalid TOML")
+	}
+}
+
 func insertLiteNote(t *testing.T, db *store.DB, relPath string) {
 	t.Helper()
 
@@ -1,8 +1,12 @@
 package web
 
 import (
+	"context"
 	"crypto/subtle"
+	"fmt"
+	"net"
 	"net/http"
+	"os"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -30,6 +34,43 @@ func newMCPHandler(opts MCPOptions) http.Handler {
 	return bearerAuth(opts.Token, handler)
 }
 
+// ServeMCP serves only the MCP endpoints on addr until ctx is cancelled:
+// Streamable HTTP on /mcp and the older HTTP+SSE transport on /sse. Both
+// require the Bearer token. Unless allowRemote is set, requests must also
+// name a loopback host, which blocks DNS rebinding from a browser.
+func ServeMCP(ctx context.Context, addr string, opts MCPOptions, allowRemote bool) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen %s: %w", addr, err)
+	}
+	fmt.Fprintf(os.Stderr, "SAME MCP server: http://%s/mcp (SSE: /sse)\n", listener.Addr())
+
+	if err := serveUntilDone(ctx, listener, newMCPMux(opts, allowRemote)); err != nil {
+		return fmt.Errorf("serve MCP: %w", err)
+	}
+	return nil
+}
+
+// newMCPMux routes /mcp and /sse for ServeMCP.
+func newMCPMux(opts MCPOptions, allowRemote bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", newMCPHandler(opts))
+	mux.Handle("/sse", newSSEHandler(opts))
+	if allowRemote {
+		return mux
+	}
+	return localhostOnly(mux)
+}
+
+// newSSEHandler serves the HTTP+SSE MCP transport for clients that predate
+// Streamable HTTP, behind the same Bearer token check.
+func newSSEHandler(opts MCPOptions) http.Handler {
+	handler := mcp.NewSSEHandler(func(r *http.Request) *mcp.Server {
+		return opts.Server
+	}, nil)
+	return bearerAuth(opts.Token, handler)
+}
+
 // bearerAuth wraps an http.Handler with Bearer token authentication.
 // Returns 401 Unauthorized if the token is missing or incorrect.
 func bearerAuth(token string, next http.Handler) http.Handler {
@@ -213,3 +213,32 @@ func TestBearerAuth_ConstantTimeComparison(t *testing.T) {
 		})
 	}
 }
+
+func TestMCPMux_RoutesAndHostCheck(t *testing.T) {
+	opts := MCPOptions{Server: newTestMCPServer(), Token: "test-token-123"}
+
+	tests := []struct {
+		name        string
+		allowRemote bool
+		path        string
+		host        string
+		expect      int
+	}{
+		{"sse requires token", false, "/sse", "127.0.0.1:4079", http.StatusUnauthorized},
+		{"mcp requires token", false, "/mcp", "localhost:4079", http.StatusUnauthorized},
+		{"remote host rejected", false, "/mcp", "evil.example.com", http.StatusForbidden},
+		{"remote host allowed", true, "/mcp", "evil.example.com", http.StatusUnauthorized},
+		{"unknown path", false, "/api/notes", "127.0.0.1:4079", http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			req.Host = tc.host
+			rr := httptest.NewRecorder()
+			newMCPMux(opts, tc.allowRemote).ServeHTTP(rr, req)
+			if rr.Code != tc.expect {
+				t.Errorf("expected %d, got %d: %s", tc.expect, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
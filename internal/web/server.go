@@ -83,6 +83,15 @@ func Serve(ctx context.Context, addr string, embedClient embedding.Provider, ver
 	}
 	fmt.Fprintf(os.Stderr, "SAME web dashboard: http://%s\n", listener.Addr())
 
+	if err := serveUntilDone(ctx, listener, handler); err != nil {
+		return fmt.Errorf("serve dashboard: %w", err)
+	}
+	return nil
+}
+
+// serveUntilDone serves handler on listener until ctx is cancelled, then
+// shuts down gracefully.
+func serveUntilDone(ctx context.Context, listener net.Listener, handler http.Handler) error {
 	srv := &http.Server{
 		Handler:           handler,
 		ReadHeaderTimeout: 10 * time.Second,
@@ -106,20 +115,17 @@ func Serve(ctx context.Context, addr string, embedClient embedding.Provider, ver
 		}
 	}()
 
-	err = srv.Serve(listener)
+	err := srv.Serve(listener)
 	close(serveDone)
 	wg.Wait()
 
 	if shutdownErr != nil {
-		return fmt.Errorf("shutdown web server: %w", shutdownErr)
+		return fmt.Errorf("shutdown: %w", shutdownErr)
 	}
 	if errors.Is(err, http.ErrServerClosed) {
 		return nil
 	}
-	if err != nil {
-		return fmt.Errorf("serve dashboard: %w", err)
-	}
-	return nil
+	return err
 }
 
 type server struct {
@@ -401,11 +407,15 @@ func (s *server) handleSearch(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *server) handlePinned(w http.ResponseWriter, r *http.Request) {
-	notes, err := s.db.GetPinnedNotes()
+	pinned, err := s.db.GetPinnedNotes()
 	if err != nil {
 		writeJSON(w, []any{})
 		return
 	}
+	notes := make([]store.NoteRecord, len(pinned))
+	for i, p := range pinned {
+		notes[i] = p.NoteRecord
+	}
 	writeJSON(w, filterPrivateNotes(notes))
 }
 